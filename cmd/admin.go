@@ -66,10 +66,13 @@ func subcmdAuth() *cli.Command {
 			microcmdAuthUpdateLdapSimpleAuth(),
 			microcmdAuthAddPAM(),
 			microcmdAuthUpdatePAM(),
+			microcmdAuthAddSAML(),
+			microcmdAuthUpdateSAML(),
 			microcmdAuthAddSMTP(),
 			microcmdAuthUpdateSMTP(),
 			microcmdAuthList(),
 			microcmdAuthDelete(),
+			microcmdAuthGenerateSCIMToken(),
 		},
 	}
 }
@@ -36,6 +36,16 @@ func microcmdAuthDelete() *cli.Command {
 	}
 }
 
+func microcmdAuthGenerateSCIMToken() *cli.Command {
+	return &cli.Command{
+		Name:   "generate-scim-token",
+		Usage:  "Generate the SCIM provisioning token of an auth source, replacing the previous one",
+		Flags:  []cli.Flag{idFlag()},
+		Before: noDanglingArgs,
+		Action: runGenerateSCIMToken,
+	}
+}
+
 func microcmdAuthList() *cli.Command {
 	return &cli.Command{
 		Name:   "list",
@@ -134,3 +144,29 @@ func runDeleteAuth(ctx context.Context, c *cli.Command) error {
 
 	return auth_service.DeleteSource(ctx, source)
 }
+
+func runGenerateSCIMToken(ctx context.Context, c *cli.Command) error {
+	if !c.IsSet("id") {
+		return errors.New("--id flag is missing")
+	}
+
+	ctx, cancel := installSignals(ctx)
+	defer cancel()
+
+	if err := initDB(ctx); err != nil {
+		return err
+	}
+
+	source, err := auth_model.GetSourceByID(ctx, c.Int64("id"))
+	if err != nil {
+		return err
+	}
+
+	token, err := auth_model.GenerateSCIMToken(ctx, source.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(token)
+	return nil
+}
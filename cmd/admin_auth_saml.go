@@ -0,0 +1,322 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/services/auth/source/saml"
+
+	"github.com/urfave/cli/v3"
+)
+
+func samlCLIFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "name",
+			Value: "",
+			Usage: "Application Name",
+		},
+		&cli.StringFlag{
+			Name:  "idp-metadata-url",
+			Value: "",
+			Usage: "URL of the identity provider metadata",
+		},
+		&cli.StringFlag{
+			Name:  "idp-metadata-file",
+			Value: "",
+			Usage: "File containing the identity provider metadata XML, used instead of the metadata URL",
+		},
+		&cli.StringFlag{
+			Name:  "sp-certificate-file",
+			Value: "",
+			Usage: "File containing the PEM encoded service provider certificate (generated if not set)",
+		},
+		&cli.StringFlag{
+			Name:  "sp-private-key-file",
+			Value: "",
+			Usage: "File containing the PEM encoded service provider private key (generated if not set)",
+		},
+		&cli.BoolFlag{
+			Name:  "sign-requests",
+			Usage: "Sign the authentication requests sent to the identity provider",
+		},
+		&cli.BoolFlag{
+			Name:  "require-encrypted-assertions",
+			Usage: "Reject responses of the identity provider containing unencrypted assertions",
+		},
+		&cli.StringFlag{
+			Name:  "name-id-format",
+			Value: "",
+			Usage: "NameID format requested from the identity provider",
+		},
+		&cli.StringFlag{
+			Name:  "icon-url",
+			Value: "",
+			Usage: "Custom icon URL for SAML login source",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-username",
+			Value: "",
+			Usage: "Attribute providing the username",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-email",
+			Value: "",
+			Usage: "Attribute providing the email address",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-full-name",
+			Value: "",
+			Usage: "Attribute providing the full name",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-groups",
+			Value: "",
+			Usage: "Attribute providing group names for this source",
+		},
+		&cli.StringFlag{
+			Name:  "admin-group",
+			Value: "",
+			Usage: "Group attribute value for administrator users",
+		},
+		&cli.StringFlag{
+			Name:  "restricted-group",
+			Value: "",
+			Usage: "Group attribute value for restricted users",
+		},
+		&cli.StringFlag{
+			Name:  "group-team-map",
+			Value: "",
+			Usage: "JSON mapping between groups and org teams",
+		},
+		&cli.BoolFlag{
+			Name:  "group-team-map-removal",
+			Usage: "Activate automatic team membership removal depending on groups",
+		},
+		&cli.StringFlag{
+			Name:  "attribute-quota-groups",
+			Value: "",
+			Usage: "Attribute providing quota group names for this source",
+		},
+		&cli.StringFlag{
+			Name:  "quota-group-map",
+			Value: "",
+			Usage: "JSON mapping between groups and quota groups",
+		},
+		&cli.BoolFlag{
+			Name:  "quota-group-map-removal",
+			Usage: "Activate automatic quota group removal depending on groups",
+		},
+		&cli.BoolFlag{
+			Name:  "active",
+			Usage: "This Authentication Source is Activated.",
+			Value: true,
+		},
+	}
+}
+
+func microcmdAuthAddSAML() *cli.Command {
+	return &cli.Command{
+		Name:   "add-saml",
+		Usage:  "Add new SAML authentication source",
+		Before: noDanglingArgs,
+		Action: newAuthService().addSAML,
+		Flags:  samlCLIFlags(),
+	}
+}
+
+func microcmdAuthUpdateSAML() *cli.Command {
+	return &cli.Command{
+		Name:   "update-saml",
+		Usage:  "Update existing SAML authentication source",
+		Before: noDanglingArgs,
+		Action: newAuthService().updateSAML,
+		Flags:  append(samlCLIFlags()[:1], append([]cli.Flag{idFlag()}, samlCLIFlags()[1:]...)...),
+	}
+}
+
+func readSAMLFileFlag(c *cli.Command, name string) (string, error) {
+	if c.String(name) == "" {
+		return "", nil
+	}
+	content, err := os.ReadFile(c.String(name))
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+func parseSAMLConfig(_ context.Context, c *cli.Command) (*saml.Source, error) {
+	config := &saml.Source{
+		IdentityProviderMetadataURL: c.String("idp-metadata-url"),
+		SignRequests:                c.Bool("sign-requests"),
+		RequireEncryptedAssertions:  c.Bool("require-encrypted-assertions"),
+		NameIDFormat:                c.String("name-id-format"),
+		IconURL:                     c.String("icon-url"),
+		AttributeUsername:           c.String("attribute-username"),
+		AttributeEmail:              c.String("attribute-email"),
+		AttributeFullName:           c.String("attribute-full-name"),
+		AttributeGroups:             c.String("attribute-groups"),
+		AdminGroup:                  c.String("admin-group"),
+		RestrictedGroup:             c.String("restricted-group"),
+		GroupTeamMap:                c.String("group-team-map"),
+		GroupTeamMapRemoval:         c.Bool("group-team-map-removal"),
+		AttributeQuotaGroups:        c.String("attribute-quota-groups"),
+		QuotaGroupMap:               c.String("quota-group-map"),
+		QuotaGroupMapRemoval:        c.Bool("quota-group-map-removal"),
+	}
+
+	var err error
+	if config.IdentityProviderMetadata, err = readSAMLFileFlag(c, "idp-metadata-file"); err != nil {
+		return nil, err
+	}
+	if config.ServiceProviderCertificate, err = readSAMLFileFlag(c, "sp-certificate-file"); err != nil {
+		return nil, err
+	}
+	if config.ServiceProviderPrivateKey, err = readSAMLFileFlag(c, "sp-private-key-file"); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (a *authService) addSAML(ctx context.Context, c *cli.Command) error {
+	ctx, cancel := installSignals(ctx)
+	defer cancel()
+
+	if err := a.initDB(ctx); err != nil {
+		return err
+	}
+
+	if !c.IsSet("name") || len(c.String("name")) == 0 {
+		return errors.New("name must be set")
+	}
+	if c.String("idp-metadata-url") == "" && c.String("idp-metadata-file") == "" {
+		return errors.New("idp-metadata-url or idp-metadata-file must be set")
+	}
+	if (c.String("sp-certificate-file") == "") != (c.String("sp-private-key-file") == "") {
+		return errors.New("sp-certificate-file and sp-private-key-file must be set together")
+	}
+	active := true
+	if c.IsSet("active") {
+		active = c.Bool("active")
+	}
+
+	config, err := parseSAMLConfig(ctx, c)
+	if err != nil {
+		return err
+	}
+	if err := config.EnsureServiceProviderKeyPair(c.String("name")); err != nil {
+		return err
+	}
+
+	return a.createAuthSource(ctx, &auth_model.Source{
+		Type:     auth_model.SAML,
+		Name:     c.String("name"),
+		IsActive: active,
+		Cfg:      config,
+	})
+}
+
+func (a *authService) updateSAML(ctx context.Context, c *cli.Command) error {
+	if !c.IsSet("id") {
+		return errors.New("--id flag is missing")
+	}
+
+	ctx, cancel := installSignals(ctx)
+	defer cancel()
+
+	if err := a.initDB(ctx); err != nil {
+		return err
+	}
+
+	source, err := a.getAuthSource(ctx, c, auth_model.SAML)
+	if err != nil {
+		return err
+	}
+
+	samlConfig := source.Cfg.(*saml.Source)
+
+	if c.IsSet("name") {
+		source.Name = c.String("name")
+	}
+
+	if c.IsSet("idp-metadata-url") {
+		samlConfig.IdentityProviderMetadataURL = c.String("idp-metadata-url")
+	}
+	if c.IsSet("idp-metadata-file") {
+		if samlConfig.IdentityProviderMetadata, err = readSAMLFileFlag(c, "idp-metadata-file"); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("sp-certificate-file") {
+		if samlConfig.ServiceProviderCertificate, err = readSAMLFileFlag(c, "sp-certificate-file"); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("sp-private-key-file") {
+		if samlConfig.ServiceProviderPrivateKey, err = readSAMLFileFlag(c, "sp-private-key-file"); err != nil {
+			return err
+		}
+	}
+
+	if c.IsSet("sign-requests") {
+		samlConfig.SignRequests = c.Bool("sign-requests")
+	}
+	if c.IsSet("require-encrypted-assertions") {
+		samlConfig.RequireEncryptedAssertions = c.Bool("require-encrypted-assertions")
+	}
+	if c.IsSet("name-id-format") {
+		samlConfig.NameIDFormat = c.String("name-id-format")
+	}
+	if c.IsSet("icon-url") {
+		samlConfig.IconURL = c.String("icon-url")
+	}
+
+	if c.IsSet("attribute-username") {
+		samlConfig.AttributeUsername = c.String("attribute-username")
+	}
+	if c.IsSet("attribute-email") {
+		samlConfig.AttributeEmail = c.String("attribute-email")
+	}
+	if c.IsSet("attribute-full-name") {
+		samlConfig.AttributeFullName = c.String("attribute-full-name")
+	}
+
+	if c.IsSet("attribute-groups") {
+		samlConfig.AttributeGroups = c.String("attribute-groups")
+	}
+	if c.IsSet("admin-group") {
+		samlConfig.AdminGroup = c.String("admin-group")
+	}
+	if c.IsSet("restricted-group") {
+		samlConfig.RestrictedGroup = c.String("restricted-group")
+	}
+	if c.IsSet("group-team-map") {
+		samlConfig.GroupTeamMap = c.String("group-team-map")
+	}
+	if c.IsSet("group-team-map-removal") {
+		samlConfig.GroupTeamMapRemoval = c.Bool("group-team-map-removal")
+	}
+	if c.IsSet("attribute-quota-groups") {
+		samlConfig.AttributeQuotaGroups = c.String("attribute-quota-groups")
+	}
+	if c.IsSet("quota-group-map") {
+		samlConfig.QuotaGroupMap = c.String("quota-group-map")
+	}
+	if c.IsSet("quota-group-map-removal") {
+		samlConfig.QuotaGroupMapRemoval = c.Bool("quota-group-map-removal")
+	}
+
+	if c.IsSet("active") {
+		source.IsActive = c.Bool("active")
+	}
+
+	source.Cfg = samlConfig
+
+	return a.updateAuthSource(ctx, source)
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/modules/test"
+	"forgejo.org/services/auth/source/saml"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestAddSAML(t *testing.T) {
+	// Mock cli functions to do not exit on error
+	defer test.MockVariableValue(&cli.OsExiter, func(code int) {})()
+
+	metadataFile := filepath.Join(t.TempDir(), "metadata.xml")
+	require.NoError(t, os.WriteFile(metadataFile, []byte("<EntityDescriptor/>"), 0o600))
+
+	cases := []struct {
+		args   []string
+		source *auth.Source
+		errMsg string
+	}{
+		// case 0
+		{
+			args: []string{
+				"saml-test",
+				"--name", "Company IdP",
+				"--idp-metadata-file", metadataFile,
+				"--attribute-username", "uid",
+				"--attribute-email", "mail",
+				"--attribute-groups", "memberOf",
+				"--group-team-map", `{"developers": {"org1": ["team1"]}}`,
+				"--sign-requests",
+				"--require-encrypted-assertions",
+			},
+			source: &auth.Source{
+				Type:     auth.SAML,
+				Name:     "Company IdP",
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderMetadata:   "<EntityDescriptor/>",
+					SignRequests:               true,
+					RequireEncryptedAssertions: true,
+					AttributeUsername:          "uid",
+					AttributeEmail:             "mail",
+					AttributeGroups:            "memberOf",
+					GroupTeamMap:               `{"developers": {"org1": ["team1"]}}`,
+				},
+			},
+		},
+		// case 1
+		{
+			args: []string{
+				"saml-test",
+				"--idp-metadata-url", "https://idp.example.com/metadata",
+			},
+			errMsg: "name must be set",
+		},
+		// case 2
+		{
+			args: []string{
+				"saml-test",
+				"--name", "Company IdP",
+			},
+			errMsg: "idp-metadata-url or idp-metadata-file must be set",
+		},
+		// case 3
+		{
+			args: []string{
+				"saml-test",
+				"--name", "Company IdP",
+				"--idp-metadata-url", "https://idp.example.com/metadata",
+				"--sp-certificate-file", metadataFile,
+			},
+			errMsg: "sp-certificate-file and sp-private-key-file must be set together",
+		},
+	}
+
+	for n, c := range cases {
+		// Mock functions.
+		var createdAuthSource *auth.Source
+		service := &authService{
+			initDB: func(context.Context) error {
+				return nil
+			},
+			createAuthSource: func(ctx context.Context, authSource *auth.Source) error {
+				createdAuthSource = authSource
+				return nil
+			},
+			updateAuthSource: func(ctx context.Context, authSource *auth.Source) error {
+				assert.FailNow(t, "should not call updateAuthSource", "case: %d", n)
+				return nil
+			},
+			getAuthSourceByID: func(ctx context.Context, id int64) (*auth.Source, error) {
+				assert.FailNow(t, "should not call getAuthSourceByID", "case: %d", n)
+				return nil, nil
+			},
+		}
+
+		// Create a copy of command to test
+		app := cli.Command{}
+		app.Flags = microcmdAuthAddSAML().Flags
+		app.Action = service.addSAML
+
+		// Run it
+		err := app.Run(t.Context(), c.args)
+		if c.errMsg != "" {
+			assert.EqualError(t, err, c.errMsg, "case %d: error should match", n)
+			continue
+		}
+		require.NoError(t, err, "case %d: should have no errors", n)
+
+		// the service provider key pair is generated
+		cfg := createdAuthSource.Cfg.(*saml.Source)
+		assert.Contains(t, cfg.ServiceProviderCertificate, "BEGIN CERTIFICATE", "case %d", n)
+		assert.Contains(t, cfg.ServiceProviderPrivateKey, "PRIVATE KEY", "case %d", n)
+		cfg.ServiceProviderCertificate, cfg.ServiceProviderPrivateKey = "", ""
+		assert.Equal(t, c.source, createdAuthSource, "case %d: wrong authSource", n)
+	}
+}
+
+func TestUpdateSAML(t *testing.T) {
+	// Mock cli functions to do not exit on error
+	defer test.MockVariableValue(&cli.OsExiter, func(code int) {})()
+
+	var updatedAuthSource *auth.Source
+	service := &authService{
+		initDB: func(context.Context) error {
+			return nil
+		},
+		createAuthSource: func(ctx context.Context, authSource *auth.Source) error {
+			assert.FailNow(t, "should not call createAuthSource")
+			return nil
+		},
+		updateAuthSource: func(ctx context.Context, authSource *auth.Source) error {
+			updatedAuthSource = authSource
+			return nil
+		},
+		getAuthSourceByID: func(ctx context.Context, id int64) (*auth.Source, error) {
+			assert.EqualValues(t, 7, id)
+			return &auth.Source{
+				Type:     auth.SAML,
+				Name:     "Company IdP",
+				IsActive: true,
+				Cfg: &saml.Source{
+					IdentityProviderMetadataURL: "https://idp.example.com/metadata",
+					AttributeUsername:           "uid",
+				},
+			}, nil
+		},
+	}
+
+	app := cli.Command{}
+	app.Flags = microcmdAuthUpdateSAML().Flags
+	app.Action = service.updateSAML
+
+	require.NoError(t, app.Run(t.Context(), []string{
+		"saml-test",
+		"--id", "7",
+		"--attribute-email", "mail",
+		"--quota-group-map-removal",
+		"--active=false",
+	}))
+	assert.Equal(t, &auth.Source{
+		Type:     auth.SAML,
+		Name:     "Company IdP",
+		IsActive: false,
+		Cfg: &saml.Source{
+			IdentityProviderMetadataURL: "https://idp.example.com/metadata",
+			AttributeUsername:           "uid",
+			AttributeEmail:              "mail",
+			QuotaGroupMapRemoval:        true,
+		},
+	}, updatedAuthSource)
+
+	err := app.Run(t.Context(), []string{"saml-test", "--name", "Other"})
+	require.EqualError(t, err, "--id flag is missing")
+}
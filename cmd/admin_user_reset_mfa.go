@@ -47,7 +47,7 @@ func runResetMFA(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
-	webAuthnList, err := auth_model.GetWebAuthnCredentialsByUID(ctx, user.ID)
+	webAuthnList, err := auth_model.GetSecurityKeysByUID(ctx, user.ID)
 	if err != nil {
 		return err
 	}
@@ -388,6 +388,18 @@ func runDump(stdCtx context.Context, ctx *cli.Command) error {
 		}()
 	}
 
+	if setting.Blobs.Enabled {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := storage.Blobs.IterateObjects("", func(objPath string, object storage.Object) error {
+				return addObject(archiveJobs, object, path.Join("data", "blobs", objPath), verbose)
+			}); err != nil {
+				fatal("Failed to dump deduplicated content: %v", err)
+			}
+		}()
+	}
+
 	// Doesn't check if LogRootPath exists before processing --skip-log intentionally,
 	// ensuring that it's clear the dump is skipped whether the directory's initialized
 	// yet or not.
@@ -462,6 +474,7 @@ func dumpData(ctx *cli.Command, archiveJobs chan archives.ArchiveAsyncJob, wg *s
 	excludes = append(excludes, setting.LFS.Storage.Path)
 	excludes = append(excludes, setting.Attachment.Storage.Path)
 	excludes = append(excludes, setting.Packages.Storage.Path)
+	excludes = append(excludes, setting.Blobs.Storage.Path)
 	excludes = append(excludes, setting.Log.RootPath)
 	excludes = append(excludes, absFileName)
 	if err := addRecursiveExclude(archiveJobs, "data", setting.AppDataPath, excludes, verbose); err != nil {
@@ -179,6 +179,7 @@ func innerNewMainApp(version, versionExtra string, subCmdsStandaloneArgs, subCmd
 		cmdManager(),
 		cmdEmbedded(),
 		cmdMigrateStorage(),
+		cmdRotateStorageKey(),
 		cmdDumpRepository(),
 		cmdRestoreRepository(),
 	}
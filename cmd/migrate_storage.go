@@ -45,7 +45,7 @@ func cmdMigrateStorage() *cli.Command {
 				Name:    "storage",
 				Aliases: []string{"s"},
 				Value:   "",
-				Usage:   "New storage type: local (default) or minio",
+				Usage:   "New storage type: local (default), minio or webdav",
 			},
 			&cli.StringFlag{
 				Name:    "path",
@@ -96,6 +96,30 @@ func cmdMigrateStorage() *cli.Command {
 				Value: "",
 				Usage: "Minio checksum algorithm (default/md5)",
 			},
+			&cli.StringFlag{
+				Name:  "webdav-endpoint",
+				Value: "",
+				Usage: "WebDAV storage endpoint",
+			},
+			&cli.StringFlag{
+				Name:  "webdav-username",
+				Value: "",
+				Usage: "WebDAV storage username",
+			},
+			&cli.StringFlag{
+				Name:  "webdav-password",
+				Value: "",
+				Usage: "WebDAV storage password",
+			},
+			&cli.StringFlag{
+				Name:  "webdav-base-path",
+				Value: "",
+				Usage: "WebDAV storage base path on the server",
+			},
+			&cli.BoolFlag{
+				Name:  "webdav-insecure-skip-verify",
+				Usage: "Skip SSL verification",
+			},
 		},
 	}
 }
@@ -242,6 +266,18 @@ func runMigrateStorage(stdCtx context.Context, ctx *cli.Command) error {
 					ChecksumAlgorithm:  ctx.String("minio-checksum-algorithm"),
 				},
 			})
+	case string(setting.WebDAVStorageType):
+		dstStorage, err = storage.NewWebDAVStorage(
+			stdCtx,
+			&setting.Storage{
+				WebDAVConfig: setting.WebDAVStorageConfig{
+					Endpoint:           ctx.String("webdav-endpoint"),
+					Username:           ctx.String("webdav-username"),
+					Password:           ctx.String("webdav-password"),
+					BasePath:           ctx.String("webdav-base-path"),
+					InsecureSkipVerify: ctx.Bool("webdav-insecure-skip-verify"),
+				},
+			})
 	default:
 		return fmt.Errorf("unsupported storage type: %s", ctx.String("storage"))
 	}
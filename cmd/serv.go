@@ -271,7 +271,9 @@ func runServ(ctx context.Context, c *cli.Command) error {
 		}
 	}
 
-	results, extra := private.ServCommand(ctx, keyID, username, reponame, requestedMode, verb, lfsVerb)
+	// SSH_CONNECTION is "client-ip client-port server-ip server-port", the address is needed for the IP allowlists of organizations
+	remoteAddr, _, _ := strings.Cut(os.Getenv("SSH_CONNECTION"), " ")
+	results, extra := private.ServCommand(ctx, keyID, username, reponame, requestedMode, remoteAddr, verb, lfsVerb)
 	if extra.HasError() {
 		return fail(ctx, extra.UserMsg, "ServCommand failed: %s", extra.Error)
 	}
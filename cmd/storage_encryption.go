@@ -0,0 +1,98 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/storage"
+
+	"github.com/urfave/cli/v3"
+)
+
+func cmdRotateStorageKey() *cli.Command {
+	return &cli.Command{
+		Name:  "rotate-storage-key",
+		Usage: "Re-encrypt stored files with the current storage encryption master key",
+		Description: `Encrypts all stored files which are unencrypted or still encrypted with one of the
+[storage_encryption].PREVIOUS_MASTER_KEYS using the current [storage_encryption].MASTER_KEY.
+
+To rotate the master key, move the current MASTER_KEY to PREVIOUS_MASTER_KEYS, set a new
+MASTER_KEY and restart Forgejo. Then run this command while Forgejo keeps serving the files,
+and remove the old key from PREVIOUS_MASTER_KEYS once it has completed.`,
+		Before: noDanglingArgs,
+		Action: runRotateStorageKey,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "type",
+				Aliases: []string{"t"},
+				Value:   "",
+				Usage:   "Comma separated types of stored files to re-encrypt (leave blank for all). Allowed types: 'attachments', 'lfs', 'packages', 'actions-artifacts', 'blobs'",
+			},
+		},
+	}
+}
+
+func runRotateStorageKey(ctx context.Context, c *cli.Command) error {
+	ctx, cancel := installSignals(ctx)
+	defer cancel()
+
+	setting.MustInstalled()
+
+	if !setting.StorageEncryption.Enabled {
+		return errors.New("storage encryption is not enabled, set [storage_encryption].ENABLED = true")
+	}
+
+	encryptedStorages := []struct {
+		Type    string
+		Enabled bool
+		Storage *setting.Storage
+	}{
+		{"attachments", setting.Attachment.Enabled, setting.Attachment.Storage},
+		{"lfs", setting.LFS.StartServer, setting.LFS.Storage},
+		{"packages", setting.Packages.Enabled, setting.Packages.Storage},
+		{"actions-artifacts", setting.Actions.Enabled, setting.Actions.ArtifactStorage},
+		{"blobs", setting.Blobs.Enabled, setting.Blobs.Storage},
+	}
+
+	types := make(map[string]bool)
+	for _, tp := range strings.Split(c.String("type"), ",") {
+		if tp = strings.ToLower(strings.TrimSpace(tp)); tp != "" {
+			types[tp] = true
+		}
+	}
+	for tp := range types {
+		known := false
+		for _, s := range encryptedStorages {
+			known = known || s.Type == tp
+		}
+		if !known {
+			return fmt.Errorf("unsupported storage: %s", tp)
+		}
+	}
+
+	for _, s := range encryptedStorages {
+		if len(types) > 0 && !types[s.Type] || len(types) == 0 && !s.Enabled {
+			continue
+		}
+
+		original, err := storage.NewStorage(s.Storage.Type, s.Storage)
+		if err != nil {
+			return err
+		}
+
+		log.Info("Re-encrypting %s files", s.Type)
+		count, err := storage.NewEncryptedStorage(original, setting.StorageEncryption.MasterKey, setting.StorageEncryption.PreviousMasterKeys).ReencryptObjects(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Type, err)
+		}
+		fmt.Printf("%s: %d files have been re-encrypted\n", s.Type, count)
+	}
+	return nil
+}
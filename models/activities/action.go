@@ -514,7 +514,13 @@ func GetFeeds(ctx context.Context, opts GetFeedsOptions) (ActionList, int64, err
 		return nil, 0, fmt.Errorf("LoadAttributes: %w", err)
 	}
 
-	return actions, count, nil
+	// the issue of an action is only known from its content, so confidential issues can't be filtered by the query
+	visible, err := ActionList(actions).withoutHiddenConfidentialIssues(ctx, opts.Actor)
+	if err != nil {
+		return nil, 0, fmt.Errorf("withoutHiddenConfidentialIssues: %w", err)
+	}
+
+	return visible, count, nil
 }
 
 // ActivityReadable return whether doer can read activities of user
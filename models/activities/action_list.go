@@ -10,6 +10,7 @@ import (
 
 	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/container"
@@ -201,3 +202,32 @@ func (actions ActionList) LoadIssues(ctx context.Context) error {
 	}
 	return nil
 }
+
+// withoutHiddenConfidentialIssues returns the actions without the ones on confidential issues that the doer can't see,
+// the issues of the actions must be loaded
+func (actions ActionList) withoutHiddenConfidentialIssues(ctx context.Context, doer *user_model.User) (ActionList, error) {
+	visible := make(ActionList, 0, len(actions))
+	perms := make(map[int64]access_model.Permission)
+	for _, action := range actions {
+		if action.Issue == nil || !action.Issue.IsConfidential {
+			visible = append(visible, action)
+			continue
+		}
+		perm, has := perms[action.RepoID]
+		if !has {
+			var err error
+			if perm, err = access_model.GetUserRepoPermission(ctx, action.Repo, doer); err != nil {
+				return nil, err
+			}
+			perms[action.RepoID] = perm
+		}
+		ok, err := action.Issue.IsVisibleTo(ctx, doer, perm)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			visible = append(visible, action)
+		}
+	}
+	return visible, nil
+}
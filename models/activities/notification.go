@@ -293,6 +293,33 @@ func SetIssueReadBy(ctx context.Context, issueID, userID int64) error {
 	return setIssueNotificationStatusReadIfUnread(ctx, userID, issueID)
 }
 
+// DeleteHiddenIssueNotifications deletes the notifications of an issue of the users who can't see it
+func DeleteHiddenIssueNotifications(ctx context.Context, issue *issues_model.Issue) error {
+	notifications := make([]*Notification, 0, 10)
+	if err := db.GetEngine(ctx).Where("issue_id = ?", issue.ID).Find(&notifications); err != nil {
+		return err
+	}
+	for _, notification := range notifications {
+		user, err := user_model.GetUserByID(ctx, notification.UserID)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return err
+		}
+		visible, err := issues_model.IsIssueVisibleToUser(ctx, issue, user)
+		if err != nil {
+			return err
+		}
+		if !visible {
+			if _, err := db.DeleteByID[Notification](ctx, notification.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func setIssueNotificationStatusReadIfUnread(ctx context.Context, userID, issueID int64) error {
 	notification, err := GetIssueNotification(ctx, userID, issueID)
 	// ignore if not exists
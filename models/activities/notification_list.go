@@ -162,6 +162,15 @@ func createOrUpdateIssueNotifications(ctx context.Context, issueID, commentID, n
 		if !issue.IsPull && !access_model.CheckRepoUnitUser(ctx, issue.Repo, user, unit.TypeIssues) {
 			continue
 		}
+		if issue.IsConfidential {
+			visible, err := issues_model.IsIssueVisibleToUser(ctx, issue, user)
+			if err != nil {
+				return err
+			}
+			if !visible {
+				continue
+			}
+		}
 
 		if notificationExists(notifications, issue.ID, userID) {
 			if err = updateIssueNotification(ctx, userID, issue.ID, commentID); err != nil {
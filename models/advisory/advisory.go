@@ -0,0 +1,304 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package advisory
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/cvss"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// State is the state of a security advisory
+type State int
+
+const (
+	// StateDraft advisories are only visible to the administrators of the repository
+	StateDraft State = iota
+	// StatePublished advisories are visible to everyone who can read the repository
+	StatePublished
+)
+
+// Severities are the qualitative severity ratings an advisory can have
+var Severities = []cvss.Severity{cvss.SeverityLow, cvss.SeverityMedium, cvss.SeverityHigh, cvss.SeverityCritical}
+
+// CreditTypes are the types of the credits of an advisory, as defined by the OSV format
+var CreditTypes = []string{
+	"FINDER", "REPORTER", "ANALYST", "COORDINATOR", "REMEDIATION_DEVELOPER",
+	"REMEDIATION_REVIEWER", "REMEDIATION_VERIFIER", "TOOL", "SPONSOR", "OTHER",
+}
+
+// Affected is a package affected by an advisory
+type Affected struct {
+	// Ecosystem is the OSV ecosystem of the package, such as npm or Go
+	Ecosystem string `json:",omitempty"`
+	Package   string
+	// Versions is the range of the vulnerable versions, such as "< 1.2.3"
+	Versions string
+	// Patched is the first version which is not vulnerable
+	Patched string `json:",omitempty"`
+}
+
+// Credit is a person or an organization credited for an advisory
+type Credit struct {
+	Name string
+	Type string
+}
+
+// Advisory is a security advisory of a repository. Drafts may have a temporary
+// private fork of the repository where the fix is developed.
+type Advisory struct {
+	ID          int64                  `xorm:"pk autoincr"`
+	RepoID      int64                  `xorm:"INDEX NOT NULL"`
+	Repo        *repo_model.Repository `xorm:"-"`
+	Identifier  string                 `xorm:"VARCHAR(50) UNIQUE NOT NULL"`
+	AuthorID    int64                  `xorm:"NOT NULL DEFAULT 0"`
+	Author      *user_model.User       `xorm:"-"`
+	Title       string                 `xorm:"NOT NULL"`
+	Description string                 `xorm:"TEXT"`
+
+	Severity   cvss.Severity `xorm:"VARCHAR(20) NOT NULL"`
+	CVSSVector string        `xorm:"'cvss_vector' VARCHAR(255)"`
+	CVEID      string        `xorm:"'cve_id' VARCHAR(50) INDEX"`
+	Affected   []Affected    `xorm:"JSON TEXT"`
+	Credits    []Credit      `xorm:"JSON TEXT"`
+
+	State       State  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ForkRepoID  int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	FixCommitID string `xorm:"VARCHAR(64)"`
+
+	PublishedUnix timeutil.TimeStamp `xorm:"INDEX"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+func init() {
+	db.RegisterModel(new(Advisory))
+}
+
+// TableName sets the table name of the security advisories
+func (Advisory) TableName() string {
+	return "security_advisory"
+}
+
+// ErrAdvisoryNotExist represents a "AdvisoryNotExist" kind of error.
+type ErrAdvisoryNotExist struct {
+	Identifier string
+}
+
+// IsErrAdvisoryNotExist checks if an error is a ErrAdvisoryNotExist.
+func IsErrAdvisoryNotExist(err error) bool {
+	_, ok := err.(ErrAdvisoryNotExist)
+	return ok
+}
+
+func (err ErrAdvisoryNotExist) Error() string {
+	return fmt.Sprintf("security advisory does not exist [identifier: %s]", err.Identifier)
+}
+
+func (err ErrAdvisoryNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// LoadRepo loads the repository of the advisory
+func (a *Advisory) LoadRepo(ctx context.Context) (err error) {
+	if a.Repo == nil {
+		a.Repo, err = repo_model.GetRepositoryByID(ctx, a.RepoID)
+	}
+	return err
+}
+
+// LoadAuthor loads the author of the advisory, the ghost user if it was deleted
+func (a *Advisory) LoadAuthor(ctx context.Context) (err error) {
+	if a.Author == nil {
+		a.Author, err = user_model.GetPossibleUserByID(ctx, a.AuthorID)
+		if user_model.IsErrUserNotExist(err) {
+			a.Author, err = user_model.NewGhostUser(), nil
+		}
+	}
+	return err
+}
+
+// Link returns the relative URL of the advisory, the repository must be loaded
+func (a *Advisory) Link() string {
+	return a.Repo.Link() + "/security/advisories/" + url.PathEscape(a.Identifier)
+}
+
+// HTMLURL returns the absolute URL of the advisory, the repository must be loaded
+func (a *Advisory) HTMLURL() string {
+	return a.Repo.HTMLURL() + "/security/advisories/" + url.PathEscape(a.Identifier)
+}
+
+// IsDraft returns true if the advisory is not published yet
+func (a *Advisory) IsDraft() bool {
+	return a.State == StateDraft
+}
+
+// CVSS returns the parsed CVSS vector of the advisory, nil if it has none
+func (a *Advisory) CVSS() *cvss.Vector {
+	if a.CVSSVector == "" {
+		return nil
+	}
+	v, err := cvss.ParseV3(a.CVSSVector)
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+func (a *Advisory) validate() error {
+	if a.Title == "" || len(a.Title) > 255 {
+		return util.NewInvalidArgumentErrorf("the title of an advisory must have between 1 and 255 characters")
+	}
+	if a.CVSSVector != "" {
+		v, err := cvss.ParseV3(a.CVSSVector)
+		if err != nil {
+			return util.NewInvalidArgumentErrorf("invalid CVSS vector: %v", err)
+		}
+		// the severity of a scored advisory is the one of its score
+		a.Severity = v.Severity()
+	}
+	if !slices.Contains(Severities, a.Severity) {
+		return util.NewInvalidArgumentErrorf("unknown severity %q", a.Severity)
+	}
+	if a.CVEID != "" && !IsValidCVEID(a.CVEID) {
+		return util.NewInvalidArgumentErrorf("%q is not a CVE identifier", a.CVEID)
+	}
+	for _, affected := range a.Affected {
+		if affected.Package == "" || affected.Versions == "" {
+			return util.NewInvalidArgumentErrorf("the affected packages need a name and a range of versions")
+		}
+	}
+	for _, credit := range a.Credits {
+		if credit.Name == "" || !slices.Contains(CreditTypes, credit.Type) {
+			return util.NewInvalidArgumentErrorf("the credits need a name and a known type")
+		}
+	}
+	return nil
+}
+
+// IsValidCVEID returns true if the string is a CVE identifier such as CVE-2024-12345
+func IsValidCVEID(id string) bool {
+	year, number, ok := strings.Cut(strings.TrimPrefix(id, "CVE-"), "-")
+	if !ok || !strings.HasPrefix(id, "CVE-") || len(year) != 4 || len(number) < 4 {
+		return false
+	}
+	for _, c := range year + number {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// the alphabet of the identifiers avoids the vowels and the look-alike characters
+const identifierAlphabet = "23456789cfghjmpqrvwx"
+
+// newIdentifier returns a random identifier such as FJSA-4c8x-9wq2-hv3m
+func newIdentifier() string {
+	buf := util.CryptoRandomBytes(12)
+	var sb strings.Builder
+	sb.WriteString("FJSA")
+	for i, b := range buf {
+		if i%4 == 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteByte(identifierAlphabet[int(b)%len(identifierAlphabet)])
+	}
+	return sb.String()
+}
+
+// CreateAdvisory creates a draft security advisory with a new identifier
+func CreateAdvisory(ctx context.Context, a *Advisory) error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+	a.Identifier = newIdentifier()
+	a.State = StateDraft
+	return db.Insert(ctx, a)
+}
+
+// UpdateAdvisory updates the description of a security advisory
+func UpdateAdvisory(ctx context.Context, a *Advisory) error {
+	if err := a.validate(); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols("title", "description", "severity", "cvss_vector", "cve_id", "affected", "credits").Update(a)
+	return err
+}
+
+// UpdateAdvisoryCols updates some columns of a security advisory
+func UpdateAdvisoryCols(ctx context.Context, a *Advisory, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(a.ID).Cols(cols...).Update(a)
+	return err
+}
+
+// GetAdvisoryByIdentifier returns a security advisory of a repository
+func GetAdvisoryByIdentifier(ctx context.Context, repoID int64, identifier string) (*Advisory, error) {
+	a := new(Advisory)
+	has, err := db.GetEngine(ctx).Where("repo_id = ? AND identifier = ?", repoID, identifier).Get(a)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrAdvisoryNotExist{Identifier: identifier}
+	}
+	return a, nil
+}
+
+// GetAdvisoryByForkRepoID returns the draft security advisory whose temporary fork is a repository, nil if there is none
+func GetAdvisoryByForkRepoID(ctx context.Context, forkRepoID int64) (*Advisory, error) {
+	a := new(Advisory)
+	has, err := db.GetEngine(ctx).Where("fork_repo_id = ?", forkRepoID).Get(a)
+	if err != nil || !has {
+		return nil, err
+	}
+	return a, nil
+}
+
+// FindAdvisoriesOptions are the options to find the security advisories of a repository
+type FindAdvisoriesOptions struct {
+	db.ListOptions
+	RepoID int64
+	State  optional.Option[State]
+}
+
+// ToConds implements db.FindOptions
+func (opts FindAdvisoriesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.State.Has() {
+		cond = cond.And(builder.Eq{"state": opts.State.Value()})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts FindAdvisoriesOptions) ToOrders() string {
+	return "published_unix DESC, id DESC"
+}
+
+// DeleteAdvisory deletes a security advisory
+func DeleteAdvisory(ctx context.Context, a *Advisory) error {
+	_, err := db.DeleteByID[Advisory](ctx, a.ID)
+	return err
+}
+
+// UnlinkForkRepo forgets the repository as the temporary fork of an advisory, when it is deleted
+func UnlinkForkRepo(ctx context.Context, forkRepoID int64) error {
+	_, err := db.GetEngine(ctx).Where("fork_repo_id = ?", forkRepoID).Cols("fork_repo_id").Update(&Advisory{})
+	return err
+}
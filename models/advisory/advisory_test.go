@@ -0,0 +1,98 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package advisory_test
+
+import (
+	"testing"
+
+	advisory_model "forgejo.org/models/advisory"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/cvss"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvisory(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	a := &advisory_model.Advisory{
+		RepoID:     1,
+		AuthorID:   2,
+		Title:      "remote code execution",
+		Severity:   cvss.SeverityLow,
+		CVSSVector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		Affected:   []advisory_model.Affected{{Ecosystem: "Go", Package: "example.com/lib", Versions: "< 1.2.3", Patched: "1.2.3"}},
+		Credits:    []advisory_model.Credit{{Name: "user5", Type: "FINDER"}},
+	}
+	require.NoError(t, advisory_model.CreateAdvisory(db.DefaultContext, a))
+	assert.Regexp(t, `^FJSA(-[2-9cfghjmpqrvwx]{4}){3}$`, a.Identifier)
+	assert.True(t, a.IsDraft())
+
+	got, err := advisory_model.GetAdvisoryByIdentifier(db.DefaultContext, 1, a.Identifier)
+	require.NoError(t, err)
+	// the severity of a scored advisory is the one of its score
+	assert.Equal(t, cvss.SeverityCritical, got.Severity)
+	assert.InDelta(t, 9.8, got.CVSS().BaseScore(), 0.0001)
+	assert.Equal(t, a.Affected, got.Affected)
+	assert.Equal(t, a.Credits, got.Credits)
+
+	_, err = advisory_model.GetAdvisoryByIdentifier(db.DefaultContext, 2, a.Identifier)
+	assert.True(t, advisory_model.IsErrAdvisoryNotExist(err))
+
+	t.Run("Update", func(t *testing.T) {
+		a.CVSSVector = ""
+		a.Severity = cvss.SeverityMedium
+		a.CVEID = "CVE-2026-12345"
+		require.NoError(t, advisory_model.UpdateAdvisory(db.DefaultContext, a))
+		unittest.AssertExistsAndLoadBean(t, &advisory_model.Advisory{ID: a.ID, Severity: cvss.SeverityMedium, CVEID: "CVE-2026-12345"})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, invalid := range []*advisory_model.Advisory{
+			{RepoID: 1, Severity: cvss.SeverityLow},
+			{RepoID: 1, Title: "t", Severity: "unknown"},
+			{RepoID: 1, Title: "t", CVSSVector: "CVSS:3.1/AV:N"},
+			{RepoID: 1, Title: "t", Severity: cvss.SeverityLow, CVEID: "CVE-26-1"},
+			{RepoID: 1, Title: "t", Severity: cvss.SeverityLow, Affected: []advisory_model.Affected{{Package: "lib"}}},
+			{RepoID: 1, Title: "t", Severity: cvss.SeverityLow, Credits: []advisory_model.Credit{{Name: "user5", Type: "FAN"}}},
+		} {
+			assert.ErrorIs(t, advisory_model.CreateAdvisory(db.DefaultContext, invalid), util.ErrInvalidArgument)
+		}
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		advisories, err := db.Find[advisory_model.Advisory](db.DefaultContext, advisory_model.FindAdvisoriesOptions{RepoID: 1})
+		require.NoError(t, err)
+		assert.Len(t, advisories, 1)
+
+		advisories, err = db.Find[advisory_model.Advisory](db.DefaultContext, advisory_model.FindAdvisoriesOptions{RepoID: 1, State: optional.Some(advisory_model.StatePublished)})
+		require.NoError(t, err)
+		assert.Empty(t, advisories)
+	})
+
+	t.Run("Fork", func(t *testing.T) {
+		a.ForkRepoID = 10
+		require.NoError(t, advisory_model.UpdateAdvisoryCols(db.DefaultContext, a, "fork_repo_id"))
+		got, err := advisory_model.GetAdvisoryByForkRepoID(db.DefaultContext, 10)
+		require.NoError(t, err)
+		assert.Equal(t, a.ID, got.ID)
+
+		require.NoError(t, advisory_model.UnlinkForkRepo(db.DefaultContext, 10))
+		got, err = advisory_model.GetAdvisoryByForkRepoID(db.DefaultContext, 10)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestIsValidCVEID(t *testing.T) {
+	assert.True(t, advisory_model.IsValidCVEID("CVE-2024-1234"))
+	assert.True(t, advisory_model.IsValidCVEID("CVE-2024-1234567"))
+	assert.False(t, advisory_model.IsValidCVEID("CVE-2024-123"))
+	assert.False(t, advisory_model.IsValidCVEID("GHSA-2024-1234"))
+	assert.False(t, advisory_model.IsValidCVEID("CVE-20x4-1234"))
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -200,6 +201,22 @@ func CheckPublicKeyString(content string) (_ string, err error) {
 	return "", fmt.Errorf("key type is not allowed: %s", keyType)
 }
 
+// CheckPublicKeyPolicy checks that the public key is of one of the allowed types, any type being
+// allowed when there are none, and that RSA keys are at least minRSASize bits long.
+func CheckPublicKeyPolicy(content string, allowedTypes []string, minRSASize int) error {
+	keyType, length, err := SSHNativeParsePublicKey(content)
+	if err != nil {
+		return fmt.Errorf("SSHNativeParsePublicKey: %w", err)
+	}
+	if len(allowedTypes) > 0 && !slices.Contains(allowedTypes, keyType) {
+		return fmt.Errorf("key type is not allowed: %s", keyType)
+	}
+	if keyType == "rsa" && length < minRSASize {
+		return fmt.Errorf("key length is not enough: got %d, needs %d", length, minRSASize)
+	}
+	return nil
+}
+
 // SSHNativeParsePublicKey extracts the key type and length using the golang SSH library.
 func SSHNativeParsePublicKey(keyLine string) (string, int, error) {
 	fields := strings.Fields(keyLine)
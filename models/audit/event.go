@@ -0,0 +1,194 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// Action is the kind of security relevant event recorded in the audit log
+type Action string
+
+const (
+	ActionUserLogin       Action = "user.login"
+	ActionUserLoginFailed Action = "user.login_failed"
+
+	ActionAccessTokenCreate Action = "access_token.create"
+	ActionAccessTokenDelete Action = "access_token.delete"
+
+	ActionTwoFactorEnable      Action = "two_factor.enable"
+	ActionTwoFactorDisable     Action = "two_factor.disable"
+	ActionSecurityKeyAdd       Action = "security_key.add"
+	ActionSecurityKeyRemove    Action = "security_key.remove"
+	ActionSSHCertificateIssue  Action = "ssh_certificate.issue"
+	ActionCollaboratorAdd      Action = "collaborator.add"
+	ActionCollaboratorRemove   Action = "collaborator.remove"
+	ActionCollaboratorAccess   Action = "collaborator.access"
+	ActionOrgSecurityPolicy    Action = "organization.security_policy"
+	ActionTeamCreate           Action = "team.create"
+	ActionTeamUpdate           Action = "team.update"
+	ActionTeamDelete           Action = "team.delete"
+	ActionTeamMemberAdd        Action = "team.member_add"
+	ActionTeamMemberRemove     Action = "team.member_remove"
+	ActionTeamRepoAdd          Action = "team.repo_add"
+	ActionTeamRepoRemove       Action = "team.repo_remove"
+	ActionBranchProtectionEdit Action = "branch_protection.edit"
+	ActionBranchProtectionDrop Action = "branch_protection.delete"
+	ActionSecretUpdate         Action = "secret.update"
+	ActionSecretDelete         Action = "secret.delete"
+	ActionRepoVisibility       Action = "repository.visibility"
+	ActionRepoTransfer         Action = "repository.transfer"
+	ActionRepoDelete           Action = "repository.delete"
+
+	ActionAdminUserCreate       Action = "admin.user_create"
+	ActionAdminUserUpdate       Action = "admin.user_update"
+	ActionAdminUserDelete       Action = "admin.user_delete"
+	ActionAdminUserSignOut      Action = "admin.user_sign_out"
+	ActionAdminAuthSourceCreate Action = "admin.auth_source_create"
+	ActionAdminAuthSourceUpdate Action = "admin.auth_source_update"
+	ActionAdminAuthSourceDelete Action = "admin.auth_source_delete"
+	ActionAdminCronRun          Action = "admin.cron_run"
+)
+
+// Actions lists every recorded action, in the order they are offered as
+// filters in the audit log views
+var Actions = []Action{
+	ActionUserLogin, ActionUserLoginFailed,
+	ActionAccessTokenCreate, ActionAccessTokenDelete,
+	ActionTwoFactorEnable, ActionTwoFactorDisable,
+	ActionSecurityKeyAdd, ActionSecurityKeyRemove,
+	ActionSSHCertificateIssue,
+	ActionCollaboratorAdd, ActionCollaboratorRemove, ActionCollaboratorAccess,
+	ActionOrgSecurityPolicy,
+	ActionTeamCreate, ActionTeamUpdate, ActionTeamDelete,
+	ActionTeamMemberAdd, ActionTeamMemberRemove,
+	ActionTeamRepoAdd, ActionTeamRepoRemove,
+	ActionBranchProtectionEdit, ActionBranchProtectionDrop,
+	ActionSecretUpdate, ActionSecretDelete,
+	ActionRepoVisibility, ActionRepoTransfer, ActionRepoDelete,
+	ActionAdminUserCreate, ActionAdminUserUpdate, ActionAdminUserDelete, ActionAdminUserSignOut,
+	ActionAdminAuthSourceCreate, ActionAdminAuthSourceUpdate, ActionAdminAuthSourceDelete,
+	ActionAdminCronRun,
+}
+
+// TrKey returns the translation key of the action
+func (a Action) TrKey() string {
+	return "audit.action." + string(a)
+}
+
+// TargetType is the kind of object an audit event acted on
+type TargetType string
+
+const (
+	TargetUser             TargetType = "user"
+	TargetOrganization     TargetType = "organization"
+	TargetRepository       TargetType = "repository"
+	TargetTeam             TargetType = "team"
+	TargetAccessToken      TargetType = "access_token"
+	TargetSecurityKey      TargetType = "security_key"
+	TargetBranchProtection TargetType = "branch_protection"
+	TargetSecret           TargetType = "secret"
+	TargetAuthSource       TargetType = "auth_source"
+	TargetSystem           TargetType = "system"
+)
+
+// Event is an append-only record of a security relevant event.
+// OwnerID and RepoID are the scope of the event, they are used for the
+// organization and repository views of the audit log.
+type Event struct {
+	ID          int64              `xorm:"pk autoincr"`
+	Action      Action             `xorm:"VARCHAR(64) INDEX NOT NULL"`
+	ActorID     int64              `xorm:"INDEX"`
+	ActorName   string             `xorm:"VARCHAR(255)"`
+	IPAddress   string             `xorm:"VARCHAR(64)"`
+	OwnerID     int64              `xorm:"INDEX"`
+	RepoID      int64              `xorm:"INDEX"`
+	TargetType  TargetType         `xorm:"VARCHAR(32)"`
+	TargetID    int64              `xorm:"INDEX"`
+	TargetName  string             `xorm:"VARCHAR(255)"`
+	Before      string             `xorm:"TEXT"`
+	After       string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func init() {
+	db.RegisterModel(new(Event))
+}
+
+// TableName represents the real table name of Event
+func (Event) TableName() string {
+	return "audit_event"
+}
+
+// TrKey returns the translation key of the action
+func (e *Event) TrKey() string {
+	return e.Action.TrKey()
+}
+
+// InsertEvent appends an event to the audit log, events are never updated
+func InsertEvent(ctx context.Context, e *Event) error {
+	return db.Insert(ctx, e)
+}
+
+// FindEventsOptions represents the filters of the audit log views
+type FindEventsOptions struct {
+	db.ListOptions
+	ActorID    int64
+	OwnerID    int64
+	RepoID     int64
+	Action     Action
+	TargetType TargetType
+	TargetID   int64
+	Since      timeutil.TimeStamp
+	Before     timeutil.TimeStamp
+}
+
+func (opts FindEventsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.ActorID > 0 {
+		cond = cond.And(builder.Eq{"actor_id": opts.ActorID})
+	}
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Action != "" {
+		cond = cond.And(builder.Eq{"action": opts.Action})
+	}
+	if opts.TargetType != "" {
+		cond = cond.And(builder.Eq{"target_type": opts.TargetType})
+		if opts.TargetID > 0 {
+			cond = cond.And(builder.Eq{"target_id": opts.TargetID})
+		}
+	}
+	if opts.Since > 0 {
+		cond = cond.And(builder.Gte{"created_unix": opts.Since})
+	}
+	if opts.Before > 0 {
+		cond = cond.And(builder.Lt{"created_unix": opts.Before})
+	}
+	return cond
+}
+
+func (opts FindEventsOptions) ToOrders() string {
+	return "created_unix DESC, id DESC"
+}
+
+// DeleteOldEvents deletes the events older than the retention period
+func DeleteOldEvents(ctx context.Context, olderThan time.Duration) error {
+	if olderThan <= 0 {
+		return nil
+	}
+
+	_, err := db.GetEngine(ctx).Where("created_unix < ?", time.Now().Add(-olderThan).Unix()).Delete(&Event{})
+	return err
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit_test
+
+import (
+	"testing"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+
+	// the audit log has no fixtures, scope the queries to the events of this test
+	const actorID = 1001
+	events := []*audit_model.Event{
+		{Action: audit_model.ActionUserLoginFailed, ActorID: actorID, ActorName: "user2", TargetType: audit_model.TargetUser, TargetID: 2},
+		{Action: audit_model.ActionCollaboratorAdd, ActorID: actorID, OwnerID: 2, RepoID: 1, TargetType: audit_model.TargetUser, TargetID: 4},
+		{Action: audit_model.ActionTeamCreate, ActorID: actorID, OwnerID: 3, TargetType: audit_model.TargetTeam, TargetID: 1},
+	}
+	for _, e := range events {
+		require.NoError(t, audit_model.InsertEvent(ctx, e))
+	}
+
+	found, err := db.Find[audit_model.Event](ctx, audit_model.FindEventsOptions{ActorID: actorID})
+	require.NoError(t, err)
+	require.Len(t, found, 3)
+	// newest events come first
+	assert.Equal(t, events[2].ID, found[0].ID)
+	assert.Equal(t, "audit.action.team.create", found[0].TrKey())
+
+	found, err = db.Find[audit_model.Event](ctx, audit_model.FindEventsOptions{ActorID: actorID, RepoID: 1})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, audit_model.ActionCollaboratorAdd, found[0].Action)
+
+	found, err = db.Find[audit_model.Event](ctx, audit_model.FindEventsOptions{ActorID: actorID, OwnerID: 3})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, audit_model.ActionTeamCreate, found[0].Action)
+
+	found, err = db.Find[audit_model.Event](ctx, audit_model.FindEventsOptions{ActorID: actorID, Action: audit_model.ActionUserLoginFailed})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "user2", found[0].ActorName)
+
+	found, err = db.Find[audit_model.Event](ctx, audit_model.FindEventsOptions{ActorID: actorID, Since: timeutil.TimeStampNow() + 60})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestDeleteOldEvents(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	ctx := db.DefaultContext
+
+	recent := &audit_model.Event{Action: audit_model.ActionUserLogin, ActorID: 2}
+	require.NoError(t, audit_model.InsertEvent(ctx, recent))
+	old := &audit_model.Event{Action: audit_model.ActionUserLogin, ActorID: 3}
+	require.NoError(t, audit_model.InsertEvent(ctx, old))
+	_, err := db.GetEngine(ctx).ID(old.ID).Cols("created_unix").NoAutoTime().
+		Update(&audit_model.Event{CreatedUnix: timeutil.TimeStamp(time.Now().Add(-48 * time.Hour).Unix())})
+	require.NoError(t, err)
+
+	require.NoError(t, audit_model.DeleteOldEvents(ctx, 24*time.Hour))
+	unittest.AssertExistsAndLoadBean(t, &audit_model.Event{ID: recent.ID})
+	unittest.AssertNotExistsBean(t, &audit_model.Event{ID: old.ID})
+}
@@ -9,6 +9,7 @@ import (
 	"crypto/subtle"
 	"encoding/hex"
 	"fmt"
+	"slices"
 	"time"
 
 	"forgejo.org/models/db"
@@ -56,6 +57,34 @@ func (err ErrAccessTokenEmpty) Unwrap() error {
 	return util.ErrInvalidArgument
 }
 
+// ErrAccessTokenExpired represents a "AccessTokenExpired" kind of error.
+type ErrAccessTokenExpired struct {
+	ID int64
+}
+
+// IsErrAccessTokenExpired checks if an error is a ErrAccessTokenExpired.
+func IsErrAccessTokenExpired(err error) bool {
+	_, ok := err.(ErrAccessTokenExpired)
+	return ok
+}
+
+func (err ErrAccessTokenExpired) Error() string {
+	return fmt.Sprintf("access token has expired [id: %d]", err.ID)
+}
+
+func (err ErrAccessTokenExpired) Unwrap() error {
+	return util.ErrPermissionDenied
+}
+
+var (
+	// ErrAccessTokenExpiryRequired is returned if a token restricted to repositories or an organization has no expiry date
+	ErrAccessTokenExpiryRequired = util.NewInvalidArgumentErrorf("access tokens restricted to repositories or an organization must have an expiry date")
+	// ErrAccessTokenExpiryInPast is returned if the expiry date of a new token is not in the future
+	ErrAccessTokenExpiryInPast = util.NewInvalidArgumentErrorf("the expiry date of the access token must be in the future")
+	// ErrAccessTokenLifetimeExceeded is returned if the expiry date of a new token exceeds the maximum lifetime
+	ErrAccessTokenLifetimeExceeded = util.NewInvalidArgumentErrorf("the expiry date of the access token exceeds the maximum lifetime")
+)
+
 var successfulAccessTokenCache *lru.Cache[string, any]
 
 // AccessToken represents a personal access token.
@@ -69,6 +98,14 @@ type AccessToken struct {
 	TokenLastEight string `xorm:"INDEX token_last_eight"`
 	Scope          AccessTokenScope
 
+	// OrgID and RepoIDs restrict the token to the repositories of an organization
+	// and to single repositories. The token isn't restricted if both are empty.
+	OrgID   int64   `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoIDs []int64 `xorm:"JSON TEXT"`
+
+	ExpiresUnix    timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"` // zero means the token never expires
+	ExpiryNotified bool               `xorm:"NOT NULL DEFAULT false"`
+
 	CreatedUnix       timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix       timeutil.TimeStamp `xorm:"INDEX updated"`
 	HasRecentActivity bool               `xorm:"-"`
@@ -98,6 +135,9 @@ func init() {
 
 // NewAccessToken creates new access token.
 func NewAccessToken(ctx context.Context, t *AccessToken) error {
+	if err := t.prepareExpiry(); err != nil {
+		return err
+	}
 	generateAccessToken(t)
 	_, err := db.GetEngine(ctx).Insert(t)
 	return err
@@ -111,6 +151,53 @@ func generateAccessToken(t *AccessToken) {
 	t.TokenLastEight = t.Token[len(t.Token)-8:]
 }
 
+// prepareExpiry validates the expiry date of a new token against the maximum lifetime
+// and defaults to the maximum lifetime if the token has no expiry date.
+func (t *AccessToken) prepareExpiry() error {
+	now := timeutil.TimeStampNow()
+	if t.ExpiresUnix == 0 {
+		if t.IsRestricted() {
+			return ErrAccessTokenExpiryRequired
+		}
+		if setting.AccessTokenMaxLifetime > 0 {
+			t.ExpiresUnix = now.AddDuration(setting.AccessTokenMaxLifetime)
+		}
+		return nil
+	}
+	if t.ExpiresUnix <= now {
+		return ErrAccessTokenExpiryInPast
+	}
+	if setting.AccessTokenMaxLifetime > 0 && t.ExpiresUnix > now.AddDuration(setting.AccessTokenMaxLifetime) {
+		return ErrAccessTokenLifetimeExceeded
+	}
+	return nil
+}
+
+// IsExpired returns whether the expiry date of the token has passed.
+func (t *AccessToken) IsExpired() bool {
+	return t.ExpiresUnix > 0 && t.ExpiresUnix <= timeutil.TimeStampNow()
+}
+
+// IsRestricted returns whether the token is restricted to an organization or to single repositories.
+func (t *AccessToken) IsRestricted() bool {
+	return t.OrgID > 0 || len(t.RepoIDs) > 0
+}
+
+// CanAccessRepo returns whether the token may be used for the repository owned by ownerID.
+func (t *AccessToken) CanAccessRepo(repoID, ownerID int64) bool {
+	if !t.IsRestricted() {
+		return true
+	}
+	return (t.OrgID > 0 && t.OrgID == ownerID) || slices.Contains(t.RepoIDs, repoID)
+}
+
+// CanAccessOwner returns whether the token may be used for the resources of the
+// owner that don't belong to a repository, like packages. Tokens restricted to
+// single repositories can't be used for them.
+func (t *AccessToken) CanAccessOwner(ownerID int64) bool {
+	return !t.IsRestricted() || (t.OrgID > 0 && t.OrgID == ownerID)
+}
+
 // DisplayPublicOnly whether to display this as a public-only token.
 func (t *AccessToken) DisplayPublicOnly() bool {
 	publicOnly, err := t.Scope.PublicOnly()
@@ -169,6 +256,9 @@ func GetAccessTokenBySHA(ctx context.Context, token string) (*AccessToken, error
 			return nil, err
 		}
 		if has {
+			if accessToken.IsExpired() {
+				return nil, ErrAccessTokenExpired{accessToken.ID}
+			}
 			return accessToken, nil
 		}
 		successfulAccessTokenCache.Remove(token)
@@ -185,6 +275,9 @@ func GetAccessTokenBySHA(ctx context.Context, token string) (*AccessToken, error
 	for _, t := range tokens {
 		tempHash := HashToken(token, t.TokenSalt)
 		if subtle.ConstantTimeCompare([]byte(t.TokenHash), []byte(tempHash)) == 1 {
+			if t.IsExpired() {
+				return nil, ErrAccessTokenExpired{t.ID}
+			}
 			if successfulAccessTokenCache != nil {
 				successfulAccessTokenCache.Add(token, t.ID)
 			}
@@ -199,6 +292,24 @@ func AccessTokenByNameExists(ctx context.Context, token *AccessToken) (bool, err
 	return db.GetEngine(ctx).Table("access_token").Where("name = ?", token.Name).And("uid = ?", token.UID).Exist()
 }
 
+// FindExpiringAccessTokens returns the tokens expiring before the deadline whose owners haven't been notified yet.
+func FindExpiringAccessTokens(ctx context.Context, deadline timeutil.TimeStamp) ([]*AccessToken, error) {
+	tokens := make([]*AccessToken, 0, 10)
+	return tokens, db.GetEngine(ctx).
+		Where("expires_unix > ? AND expires_unix <= ? AND expiry_notified = ?", timeutil.TimeStampNow(), deadline, false).
+		OrderBy("uid, expires_unix").
+		Find(&tokens)
+}
+
+// SetAccessTokensExpiryNotified marks the owners of the tokens as notified about the upcoming expiry.
+func SetAccessTokensExpiryNotified(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).In("id", ids).Cols("expiry_notified").NoAutoTime().Update(&AccessToken{ExpiryNotified: true})
+	return err
+}
+
 // ListAccessTokensOptions contain filter options
 type ListAccessTokensOptions struct {
 	db.ListOptions
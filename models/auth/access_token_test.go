@@ -10,6 +10,8 @@ import (
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
 	"forgejo.org/modules/timeutil"
 
 	"github.com/stretchr/testify/assert"
@@ -161,3 +163,85 @@ func TestRegenerateAccessTokenByID(t *testing.T) {
 	assert.Equal(t, token.Name, newToken.Name)
 	assert.Equal(t, token.Scope, newToken.Scope)
 }
+
+func TestNewAccessTokenExpiry(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+
+	restricted := &auth_model.AccessToken{UID: 3, Name: "restricted", RepoIDs: []int64{3}}
+	require.ErrorIs(t, auth_model.NewAccessToken(db.DefaultContext, restricted), auth_model.ErrAccessTokenExpiryRequired)
+
+	restricted.ExpiresUnix = now.Add(-60)
+	require.ErrorIs(t, auth_model.NewAccessToken(db.DefaultContext, restricted), auth_model.ErrAccessTokenExpiryInPast)
+
+	restricted.ExpiresUnix = now.Add(3600)
+	require.NoError(t, auth_model.NewAccessToken(db.DefaultContext, restricted))
+
+	t.Run("MaxLifetime", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.AccessTokenMaxLifetime, 24*time.Hour)()
+
+		token := &auth_model.AccessToken{UID: 3, Name: "classic"}
+		require.NoError(t, auth_model.NewAccessToken(db.DefaultContext, token))
+		assert.InDelta(t, int64(now.AddDuration(24*time.Hour)), int64(token.ExpiresUnix), 5)
+
+		token = &auth_model.AccessToken{UID: 3, Name: "too long", ExpiresUnix: now.Add(48 * 3600)}
+		require.ErrorIs(t, auth_model.NewAccessToken(db.DefaultContext, token), auth_model.ErrAccessTokenLifetimeExceeded)
+	})
+}
+
+func TestGetAccessTokenBySHAExpired(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	_, err := db.GetEngine(db.DefaultContext).ID(1).Cols("expires_unix").Update(&auth_model.AccessToken{ExpiresUnix: timeutil.TimeStampNow().Add(-60)})
+	require.NoError(t, err)
+
+	_, err = auth_model.GetAccessTokenBySHA(db.DefaultContext, "d2c6c1ba3890b309189a8e618c72a162e4efbf36")
+	require.Error(t, err)
+	assert.True(t, auth_model.IsErrAccessTokenExpired(err))
+}
+
+func TestAccessTokenCanAccessRepo(t *testing.T) {
+	token := &auth_model.AccessToken{}
+	assert.False(t, token.IsRestricted())
+	assert.True(t, token.CanAccessRepo(1, 2))
+
+	token = &auth_model.AccessToken{OrgID: 3, RepoIDs: []int64{1}}
+	assert.True(t, token.IsRestricted())
+	assert.True(t, token.CanAccessRepo(1, 2))
+	assert.True(t, token.CanAccessRepo(5, 3))
+	assert.False(t, token.CanAccessRepo(4, 5))
+}
+
+func TestAccessTokenCanAccessOwner(t *testing.T) {
+	token := &auth_model.AccessToken{}
+	assert.True(t, token.CanAccessOwner(2))
+
+	token = &auth_model.AccessToken{OrgID: 3}
+	assert.True(t, token.CanAccessOwner(3))
+	assert.False(t, token.CanAccessOwner(2))
+
+	token = &auth_model.AccessToken{RepoIDs: []int64{1}}
+	assert.False(t, token.CanAccessOwner(2))
+}
+
+func TestFindExpiringAccessTokens(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	for id, expires := range map[int64]timeutil.TimeStamp{1: now.Add(3600), 2: now.Add(30 * 24 * 3600), 3: now.Add(-60)} {
+		_, err := db.GetEngine(db.DefaultContext).ID(id).Cols("expires_unix").Update(&auth_model.AccessToken{ExpiresUnix: expires})
+		require.NoError(t, err)
+	}
+
+	tokens, err := auth_model.FindExpiringAccessTokens(db.DefaultContext, now.Add(7*24*3600))
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.EqualValues(t, 1, tokens[0].ID)
+
+	require.NoError(t, auth_model.SetAccessTokensExpiryNotified(db.DefaultContext, []int64{tokens[0].ID}))
+
+	tokens, err = auth_model.FindExpiringAccessTokens(db.DefaultContext, now.Add(7*24*3600))
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
@@ -13,6 +13,8 @@ import (
 	"forgejo.org/models/db"
 	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
 )
 
 type AuthorizationPurpose string
@@ -111,3 +113,20 @@ func HashValidator(validator []byte) string {
 	h.Write(validator)
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// DeleteLongTermAuthTokens deletes the "remember me" tokens of the user,
+// except the tokens with the given lookup keys.
+func DeleteLongTermAuthTokens(ctx context.Context, userID int64, keepLookupKeys ...string) error {
+	var cond builder.Cond = builder.Eq{"uid": userID, "purpose": LongTermAuthorization}
+	if len(keepLookupKeys) > 0 {
+		cond = cond.And(builder.NotIn("lookup_key", keepLookupKeys))
+	}
+	_, err := db.GetEngine(ctx).Where(cond).Delete(&AuthorizationToken{})
+	return err
+}
+
+// DeleteLongTermAuthTokenByLookupKey deletes the "remember me" token of the user with the given lookup key
+func DeleteLongTermAuthTokenByLookupKey(ctx context.Context, userID int64, lookupKey string) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"uid": userID, "purpose": LongTermAuthorization, "lookup_key": lookupKey}).Delete(&AuthorizationToken{})
+	return err
+}
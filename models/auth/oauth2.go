@@ -347,6 +347,10 @@ func deleteOAuth2Application(ctx context.Context, id, userid int64) error {
 		return err
 	}
 
+	if _, err := sess.Where("application_id = ?", id).Delete(new(OAuth2DeviceAuthorization)); err != nil {
+		return err
+	}
+
 	if _, err := sess.Where("application_id = ?", id).Delete(new(OAuth2Grant)); err != nil {
 		return err
 	}
@@ -633,6 +637,11 @@ func DeleteOAuth2RelictsByUserID(ctx context.Context, userID int64) error {
 		return err
 	}
 
+	if _, err := db.GetEngine(ctx).In("grant_id", deleteCond).
+		Delete(&OAuth2DeviceAuthorization{}); err != nil {
+		return err
+	}
+
 	if err := db.DeleteBeans(ctx,
 		&OAuth2Application{UID: userID},
 		&OAuth2Grant{UserID: userID},
@@ -0,0 +1,186 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// userCodeAlphabet are the characters of user codes, consonants only to avoid
+// forming words and confusing similar characters, see RFC 8628 section 6.1
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+// OAuth2DeviceAuthorization is a pending authorization of a device that can't
+// open a browser, see RFC 8628. The device polls the token endpoint with the
+// device code while the user enters the user code in a browser.
+type OAuth2DeviceAuthorization struct {
+	ID             int64  `xorm:"pk autoincr"`
+	ApplicationID  int64  `xorm:"INDEX"`
+	DeviceCodeHash string `xorm:"VARCHAR(64) UNIQUE NOT NULL"`
+	UserCode       string `xorm:"VARCHAR(9) UNIQUE NOT NULL"`
+	Scope          string `xorm:"TEXT"`
+	// GrantID is set once the user approved the authorization
+	GrantID int64
+	Denied  bool `xorm:"NOT NULL DEFAULT false"`
+	// Interval is the minimal number of seconds between two polls of the device
+	Interval       int64
+	LastPolledUnix timeutil.TimeStamp
+	ExpiresUnix    timeutil.TimeStamp `xorm:"INDEX"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(OAuth2DeviceAuthorization))
+}
+
+// TableName sets the table name to `oauth2_device_authorization`
+func (device *OAuth2DeviceAuthorization) TableName() string {
+	return "oauth2_device_authorization"
+}
+
+// IsExpired returns whether the device code and user code can't be used anymore
+func (device *OAuth2DeviceAuthorization) IsExpired() bool {
+	return device.ExpiresUnix <= timeutil.TimeStampNow()
+}
+
+// IsApproved returns whether the user approved the authorization
+func (device *OAuth2DeviceAuthorization) IsApproved() bool {
+	return device.GrantID != 0
+}
+
+// Approve marks the authorization as approved by the user with the grant
+func (device *OAuth2DeviceAuthorization) Approve(ctx context.Context, grantID int64) error {
+	device.GrantID = grantID
+	_, err := db.GetEngine(ctx).ID(device.ID).Cols("grant_id").Update(device)
+	return err
+}
+
+// Deny marks the authorization as denied by the user
+func (device *OAuth2DeviceAuthorization) Deny(ctx context.Context) error {
+	device.Denied = true
+	_, err := db.GetEngine(ctx).ID(device.ID).Cols("denied").Update(device)
+	return err
+}
+
+// UpdatePoll records a poll of the device and, when it polls too fast, the new interval
+func (device *OAuth2DeviceAuthorization) UpdatePoll(ctx context.Context) error {
+	device.LastPolledUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(device.ID).Cols("last_polled_unix", "interval").Update(device)
+	return err
+}
+
+// Invalidate deletes the authorization so its device code can't be used again,
+// it returns false when it was already deleted by a concurrent poll.
+func (device *OAuth2DeviceAuthorization) Invalidate(ctx context.Context) (bool, error) {
+	n, err := db.GetEngine(ctx).ID(device.ID).NoAutoCondition().Delete(device)
+	return n == 1, err
+}
+
+func hashDeviceCode(deviceCode string) string {
+	h := sha256.Sum256([]byte(deviceCode))
+	return hex.EncodeToString(h[:])
+}
+
+func generateUserCode() (string, error) {
+	buf := make([]byte, 8)
+	alphabetLen := big.NewInt(int64(len(userCodeAlphabet)))
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			return "", err
+		}
+		buf[i] = userCodeAlphabet[n.Int64()]
+	}
+	return string(buf[:4]) + "-" + string(buf[4:]), nil
+}
+
+// NormalizeUserCode converts a user code as typed by a user to the stored form,
+// ignoring the case and any separator.
+func NormalizeUserCode(userCode string) string {
+	code := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		if !strings.ContainsRune(userCodeAlphabet, r) {
+			return -1
+		}
+		return r
+	}, userCode)
+	if len(code) != 8 {
+		return ""
+	}
+	return code[:4] + "-" + code[4:]
+}
+
+// CreateOAuth2DeviceAuthorization starts the authorization of a device for the
+// application, it returns the device code which is only stored hashed.
+func CreateOAuth2DeviceAuthorization(ctx context.Context, app *OAuth2Application, scope string, expiresIn, interval int64) (*OAuth2DeviceAuthorization, string, error) {
+	// expired authorizations are of no use, remove them while we are at it
+	if _, err := db.GetEngine(ctx).Where(builder.Lte{"expires_unix": timeutil.TimeStampNow()}).Delete(&OAuth2DeviceAuthorization{}); err != nil {
+		return nil, "", err
+	}
+
+	// Add a prefix to the base32, this is in order to make it easier
+	// for code scanners to grab sensitive tokens.
+	deviceCode := "gtd_" + base32Lower.EncodeToString(util.CryptoRandomBytes(32))
+	device := &OAuth2DeviceAuthorization{
+		ApplicationID:  app.ID,
+		DeviceCodeHash: hashDeviceCode(deviceCode),
+		Scope:          scope,
+		Interval:       interval,
+		ExpiresUnix:    timeutil.TimeStampNow().Add(expiresIn),
+	}
+	// user codes are short, retry on the rare collision with a pending one
+	var err error
+	for range 3 {
+		if device.UserCode, err = generateUserCode(); err != nil {
+			return nil, "", err
+		}
+		if err = db.Insert(ctx, device); err == nil {
+			return device, deviceCode, nil
+		}
+		if has, _ := db.GetEngine(ctx).Exist(&OAuth2DeviceAuthorization{UserCode: device.UserCode}); !has {
+			break
+		}
+	}
+	return nil, "", err
+}
+
+// GetOAuth2DeviceAuthorizationByDeviceCode returns the authorization of a device code, nil if there is none
+func GetOAuth2DeviceAuthorizationByDeviceCode(ctx context.Context, deviceCode string) (*OAuth2DeviceAuthorization, error) {
+	device := new(OAuth2DeviceAuthorization)
+	if has, err := db.GetEngine(ctx).Where("device_code_hash = ?", hashDeviceCode(deviceCode)).Get(device); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return device, nil
+}
+
+// GetOAuth2DeviceAuthorizationByUserCode returns the pending authorization of a user code, nil if there is none
+func GetOAuth2DeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*OAuth2DeviceAuthorization, error) {
+	userCode = NormalizeUserCode(userCode)
+	if userCode == "" {
+		return nil, nil
+	}
+	device := new(OAuth2DeviceAuthorization)
+	if has, err := db.GetEngine(ctx).Where(builder.Eq{"user_code": userCode, "grant_id": 0, "denied": false}).
+		And(builder.Gt{"expires_unix": timeutil.TimeStampNow()}).Get(device); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, nil
+	}
+	return device, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth_test
+
+import (
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeUserCode(t *testing.T) {
+	assert.Equal(t, "BCDF-GHJK", auth_model.NormalizeUserCode("BCDF-GHJK"))
+	assert.Equal(t, "BCDF-GHJK", auth_model.NormalizeUserCode("bcdf ghjk"))
+	assert.Equal(t, "BCDF-GHJK", auth_model.NormalizeUserCode("bcdfghjk"))
+	assert.Empty(t, auth_model.NormalizeUserCode("BCDF-GHJ"))
+	assert.Empty(t, auth_model.NormalizeUserCode("ABCD-EFGH"))
+}
+
+func TestOAuth2DeviceAuthorization(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	app := unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Application{ID: 1})
+
+	device, deviceCode, err := auth_model.CreateOAuth2DeviceAuthorization(db.DefaultContext, app, "read:user", 60, 5)
+	require.NoError(t, err)
+	assert.Regexp(t, `^[B-Z]{4}-[B-Z]{4}$`, device.UserCode)
+	assert.NotContains(t, device.DeviceCodeHash, deviceCode)
+	assert.False(t, device.IsExpired())
+
+	loaded, err := auth_model.GetOAuth2DeviceAuthorizationByDeviceCode(db.DefaultContext, deviceCode)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, device.ID, loaded.ID)
+
+	loaded, err = auth_model.GetOAuth2DeviceAuthorizationByUserCode(db.DefaultContext, device.UserCode)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, device.ID, loaded.ID)
+
+	// Approved authorizations can't be looked up by their user code anymore.
+	require.NoError(t, loaded.Approve(db.DefaultContext, 1))
+	loaded, err = auth_model.GetOAuth2DeviceAuthorizationByUserCode(db.DefaultContext, device.UserCode)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	loaded, err = auth_model.GetOAuth2DeviceAuthorizationByDeviceCode(db.DefaultContext, deviceCode)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.True(t, loaded.IsApproved())
+
+	// Only the first of concurrent polls invalidates the device code.
+	invalidated, err := loaded.Invalidate(db.DefaultContext)
+	require.NoError(t, err)
+	assert.True(t, invalidated)
+	invalidated, err = loaded.Invalidate(db.DefaultContext)
+	require.NoError(t, err)
+	assert.False(t, invalidated)
+	loaded, err = auth_model.GetOAuth2DeviceAuthorizationByDeviceCode(db.DefaultContext, deviceCode)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	// Expired authorizations are removed when new ones are created.
+	expired, _, err := auth_model.CreateOAuth2DeviceAuthorization(db.DefaultContext, app, "", 0, 5)
+	require.NoError(t, err)
+	assert.True(t, expired.IsExpired())
+	_, _, err = auth_model.CreateOAuth2DeviceAuthorization(db.DefaultContext, app, "", 60, 5)
+	require.NoError(t, err)
+	unittest.AssertNotExistsBean(t, &auth_model.OAuth2DeviceAuthorization{ID: expired.ID})
+}
@@ -0,0 +1,248 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// SCIMToken is the bearer token an identity provider uses to provision
+// the users and groups of an authentication source with SCIM.
+// Every authentication source has at most one token.
+type SCIMToken struct {
+	ID             int64 `xorm:"pk autoincr"`
+	SourceID       int64 `xorm:"UNIQUE NOT NULL"`
+	TokenHash      string
+	TokenSalt      string
+	TokenLastEight string             `xorm:"INDEX token_last_eight"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+// SCIMGroup is a group provisioned with SCIM, its members are synchronized
+// to organization teams with the group team map of the authentication source
+type SCIMGroup struct {
+	ID          int64              `xorm:"pk autoincr"`
+	SourceID    int64              `xorm:"INDEX NOT NULL"`
+	ExternalID  string             `xorm:"INDEX"`
+	DisplayName string             `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// SCIMGroupMember is a user belonging to a SCIM group
+type SCIMGroupMember struct {
+	ID      int64 `xorm:"pk autoincr"`
+	GroupID int64 `xorm:"UNIQUE(s) NOT NULL"`
+	UserID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(SCIMToken))
+	db.RegisterModel(new(SCIMGroup))
+	db.RegisterModel(new(SCIMGroupMember))
+}
+
+// ErrSCIMTokenNotExist represents a "SCIMTokenNotExist" kind of error.
+type ErrSCIMTokenNotExist struct{}
+
+// IsErrSCIMTokenNotExist checks if an error is a ErrSCIMTokenNotExist.
+func IsErrSCIMTokenNotExist(err error) bool {
+	_, ok := err.(ErrSCIMTokenNotExist)
+	return ok
+}
+
+func (err ErrSCIMTokenNotExist) Error() string {
+	return "SCIM token does not exist"
+}
+
+func (err ErrSCIMTokenNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// ErrSCIMGroupNotExist represents a "SCIMGroupNotExist" kind of error.
+type ErrSCIMGroupNotExist struct {
+	ID int64
+}
+
+// IsErrSCIMGroupNotExist checks if an error is a ErrSCIMGroupNotExist.
+func IsErrSCIMGroupNotExist(err error) bool {
+	_, ok := err.(ErrSCIMGroupNotExist)
+	return ok
+}
+
+func (err ErrSCIMGroupNotExist) Error() string {
+	return fmt.Sprintf("SCIM group does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSCIMGroupNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// GenerateSCIMToken creates a new SCIM token for the authentication source,
+// replacing the existing one, and returns the token in plain text
+func GenerateSCIMToken(ctx context.Context, sourceID int64) (string, error) {
+	token := hex.EncodeToString(util.CryptoRandomBytes(20))
+	salt := util.CryptoRandomString(util.RandomStringMedium)
+	t := &SCIMToken{
+		SourceID:       sourceID,
+		TokenHash:      HashToken(token, salt),
+		TokenSalt:      salt,
+		TokenLastEight: token[len(token)-8:],
+	}
+
+	return token, db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&SCIMToken{SourceID: sourceID}); err != nil {
+			return err
+		}
+		return db.Insert(ctx, t)
+	})
+}
+
+// HasSCIMToken returns whether a SCIM token has been generated for the authentication source
+func HasSCIMToken(ctx context.Context, sourceID int64) (bool, error) {
+	return db.GetEngine(ctx).Exist(&SCIMToken{SourceID: sourceID})
+}
+
+// DeleteSCIMToken revokes the SCIM token of the authentication source
+func DeleteSCIMToken(ctx context.Context, sourceID int64) error {
+	_, err := db.GetEngine(ctx).Delete(&SCIMToken{SourceID: sourceID})
+	return err
+}
+
+// GetSCIMTokenByToken returns the SCIM token matching the plain text token
+func GetSCIMTokenByToken(ctx context.Context, token string) (*SCIMToken, error) {
+	if len(token) != 40 {
+		return nil, ErrSCIMTokenNotExist{}
+	}
+
+	var tokens []*SCIMToken
+	if err := db.GetEngine(ctx).Where("token_last_eight = ?", token[len(token)-8:]).Find(&tokens); err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t.TokenHash), []byte(HashToken(token, t.TokenSalt))) == 1 {
+			return t, nil
+		}
+	}
+	return nil, ErrSCIMTokenNotExist{}
+}
+
+// FindSCIMGroupsOptions represents the options to search the SCIM groups of an authentication source
+type FindSCIMGroupsOptions struct {
+	db.ListOptions
+	SourceID    int64
+	DisplayName string
+	ExternalID  string
+}
+
+func (opts FindSCIMGroupsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"source_id": opts.SourceID})
+	if opts.DisplayName != "" {
+		cond = cond.And(builder.Eq{"display_name": opts.DisplayName})
+	}
+	if opts.ExternalID != "" {
+		cond = cond.And(builder.Eq{"external_id": opts.ExternalID})
+	}
+	return cond
+}
+
+func (opts FindSCIMGroupsOptions) ToOrders() string {
+	return "id ASC"
+}
+
+// GetSCIMGroupByID returns the SCIM group of the authentication source
+func GetSCIMGroupByID(ctx context.Context, sourceID, id int64) (*SCIMGroup, error) {
+	group := &SCIMGroup{}
+	has, err := db.GetEngine(ctx).Where("id = ? AND source_id = ?", id, sourceID).Get(group)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSCIMGroupNotExist{ID: id}
+	}
+	return group, nil
+}
+
+// GetSCIMGroupMemberIDs returns the IDs of the users belonging to the group
+func GetSCIMGroupMemberIDs(ctx context.Context, groupID int64) ([]int64, error) {
+	userIDs := make([]int64, 0, 10)
+	return userIDs, db.GetEngine(ctx).Table("scim_group_member").
+		Where("group_id = ?", groupID).
+		OrderBy("user_id").
+		Cols("user_id").
+		Find(&userIDs)
+}
+
+// GetSCIMGroupNamesByUserID returns the display names of the groups of the authentication source the user belongs to
+func GetSCIMGroupNamesByUserID(ctx context.Context, sourceID, userID int64) ([]string, error) {
+	names := make([]string, 0, 5)
+	return names, db.GetEngine(ctx).Table("scim_group").
+		Join("INNER", "scim_group_member", "scim_group_member.group_id = scim_group.id").
+		Where("scim_group.source_id = ? AND scim_group_member.user_id = ?", sourceID, userID).
+		Cols("scim_group.display_name").
+		Find(&names)
+}
+
+// AddSCIMGroupMembers adds the users to the group, users already belonging to it are skipped
+func AddSCIMGroupMembers(ctx context.Context, groupID int64, userIDs ...int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		for _, userID := range userIDs {
+			has, err := db.GetEngine(ctx).Exist(&SCIMGroupMember{GroupID: groupID, UserID: userID})
+			if err != nil {
+				return err
+			}
+			if has {
+				continue
+			}
+			if err := db.Insert(ctx, &SCIMGroupMember{GroupID: groupID, UserID: userID}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveSCIMGroupMembers removes the users from the group
+func RemoveSCIMGroupMembers(ctx context.Context, groupID int64, userIDs ...int64) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := db.GetEngine(ctx).Where("group_id = ?", groupID).In("user_id", userIDs).Delete(new(SCIMGroupMember))
+	return err
+}
+
+// DeleteSCIMGroup deletes the group and its memberships
+func DeleteSCIMGroup(ctx context.Context, groupID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Delete(&SCIMGroupMember{GroupID: groupID}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).ID(groupID).Delete(new(SCIMGroup))
+		return err
+	})
+}
+
+// DeleteSCIMDataBySourceID deletes the SCIM token and groups of the authentication source
+func DeleteSCIMDataBySourceID(ctx context.Context, sourceID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).
+			Where(builder.In("group_id", builder.Select("id").From("scim_group").Where(builder.Eq{"source_id": sourceID}))).
+			Delete(new(SCIMGroupMember)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Delete(&SCIMGroup{SourceID: sourceID}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Delete(&SCIMToken{SourceID: sourceID})
+		return err
+	})
+}
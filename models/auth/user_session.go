@@ -0,0 +1,140 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// The ways a user can sign in, recorded for every session in the registry.
+const (
+	LoginMethodPassword     = "password"
+	LoginMethodTwoFactor    = "two_factor"
+	LoginMethodSecurityKey  = "security_key"
+	LoginMethodPasskey      = "passkey"
+	LoginMethodOpenID       = "openid"
+	LoginMethodOAuth2       = "oauth2"
+	LoginMethodSAML         = "saml"
+	LoginMethodRememberMe   = "remember_me"
+	LoginMethodReverseProxy = "reverse_proxy"
+	LoginMethodSignUp       = "sign_up"
+	LoginMethodUnknown      = "unknown"
+)
+
+// UserSession is the registry entry of a signed-in web session, it lets
+// users see where they are signed in and revoke sessions.
+type UserSession struct {
+	ID  int64 `xorm:"pk autoincr"`
+	UID int64 `xorm:"INDEX NOT NULL"`
+	// KeyHash is the SHA-256 of the session ID, the ID itself is a credential
+	KeyHash string `xorm:"VARCHAR(64) UNIQUE NOT NULL"`
+	// AuthTokenKey is the lookup key of the "remember me" token of the session
+	AuthTokenKey string             `xorm:"VARCHAR(64) INDEX"`
+	LoginMethod  string             `xorm:"VARCHAR(32)"`
+	UserAgent    string             `xorm:"TEXT"`
+	IPAddress    string             `xorm:"VARCHAR(64)"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	LastSeenUnix timeutil.TimeStamp `xorm:"INDEX"`
+	// RevokedUnix is set when the session is signed out, the entry is kept
+	// until the session expires so that it can't register itself again
+	RevokedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+func init() {
+	db.RegisterModel(new(UserSession))
+}
+
+// HashSessionKey returns the hash the session with the given ID is registered with
+func HashSessionKey(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+// IsRevoked reports whether the session has been signed out
+func (s *UserSession) IsRevoked() bool {
+	return s.RevokedUnix != 0
+}
+
+// LoginMethodTrKey returns the translation key of the login method
+func (s *UserSession) LoginMethodTrKey() string {
+	method := s.LoginMethod
+	if method == "" {
+		method = LoginMethodUnknown
+	}
+	return "settings.sessions.method." + method
+}
+
+// InsertUserSession registers a session
+func InsertUserSession(ctx context.Context, s *UserSession) error {
+	return db.Insert(ctx, s)
+}
+
+// GetUserSessionByKeyHash returns the registered session with the given key hash, it may be revoked
+func GetUserSessionByKeyHash(ctx context.Context, keyHash string) (*UserSession, error) {
+	s, exist, err := db.Get[UserSession](ctx, builder.Eq{"key_hash": keyHash})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, fmt.Errorf("user session: %w", util.ErrNotExist)
+	}
+	return s, nil
+}
+
+// GetUserSessionByID returns the signed-in session of the user with the given ID
+func GetUserSessionByID(ctx context.Context, uid, id int64) (*UserSession, error) {
+	s, exist, err := db.Get[UserSession](ctx, builder.Eq{"id": id, "uid": uid, "revoked_unix": 0})
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, fmt.Errorf("user session %d: %w", id, util.ErrNotExist)
+	}
+	return s, nil
+}
+
+// FindUserSessions returns the signed-in sessions of the user seen since the given time, most recently seen first
+func FindUserSessions(ctx context.Context, uid int64, seenSince timeutil.TimeStamp) ([]*UserSession, error) {
+	sessions := make([]*UserSession, 0, 5)
+	return sessions, db.GetEngine(ctx).
+		Where(builder.Eq{"uid": uid, "revoked_unix": 0}.And(builder.Gte{"last_seen_unix": seenSince})).
+		OrderBy("last_seen_unix DESC, id DESC").
+		Find(&sessions)
+}
+
+// UpdateUserSessionActivity stores the last activity of a session
+func UpdateUserSessionActivity(ctx context.Context, s *UserSession) error {
+	_, err := db.GetEngine(ctx).ID(s.ID).Cols("auth_token_key", "user_agent", "ip_address", "last_seen_unix").Update(s)
+	return err
+}
+
+// RevokeUserSessions marks the signed-in sessions of the user as revoked, all
+// of them or the ones with the given IDs. The sessions are signed out on their
+// next request.
+func RevokeUserSessions(ctx context.Context, uid int64, ids ...int64) error {
+	cond := builder.Eq{"uid": uid, "revoked_unix": 0}
+	if len(ids) > 0 {
+		cond["id"] = ids
+	}
+	// the revocation counts as the last activity, so the entry is kept as long
+	// as the session it revokes may live
+	now := timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).Where(cond).Cols("revoked_unix", "last_seen_unix").
+		Update(&UserSession{RevokedUnix: now, LastSeenUnix: now})
+	return err
+}
+
+// DeleteInactiveUserSessions removes the sessions of a user that have not
+// been seen since the given time, their underlying session expired
+func DeleteInactiveUserSessions(ctx context.Context, uid int64, seenBefore timeutil.TimeStamp) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"uid": uid}.And(builder.Lt{"last_seen_unix": seenBefore})).Delete(&UserSession{})
+	return err
+}
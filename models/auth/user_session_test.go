@@ -0,0 +1,121 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserSession(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// The table has no fixtures, scope everything to a user that owns nothing else.
+	const uid = 1001
+
+	old := &auth.UserSession{UID: uid, KeyHash: auth.HashSessionKey("old"), LoginMethod: auth.LoginMethodPassword, LastSeenUnix: 100}
+	recent := &auth.UserSession{UID: uid, KeyHash: auth.HashSessionKey("recent"), LoginMethod: auth.LoginMethodPasskey, LastSeenUnix: 300}
+	other := &auth.UserSession{UID: uid + 1, KeyHash: auth.HashSessionKey("other"), LastSeenUnix: 300}
+	for _, s := range []*auth.UserSession{old, recent, other} {
+		require.NoError(t, auth.InsertUserSession(db.DefaultContext, s))
+	}
+
+	t.Run("HashSessionKey", func(t *testing.T) {
+		assert.Len(t, old.KeyHash, 64)
+		assert.NotEqual(t, "old", old.KeyHash)
+		assert.Equal(t, old.KeyHash, auth.HashSessionKey("old"))
+	})
+
+	t.Run("LoginMethodTrKey", func(t *testing.T) {
+		assert.Equal(t, "settings.sessions.method.passkey", recent.LoginMethodTrKey())
+		assert.Equal(t, "settings.sessions.method.unknown", other.LoginMethodTrKey())
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		s, err := auth.GetUserSessionByKeyHash(db.DefaultContext, recent.KeyHash)
+		require.NoError(t, err)
+		assert.Equal(t, recent.ID, s.ID)
+
+		_, err = auth.GetUserSessionByKeyHash(db.DefaultContext, auth.HashSessionKey("missing"))
+		require.ErrorIs(t, err, util.ErrNotExist)
+
+		s, err = auth.GetUserSessionByID(db.DefaultContext, uid, old.ID)
+		require.NoError(t, err)
+		assert.Equal(t, old.KeyHash, s.KeyHash)
+
+		// Sessions of other users are not returned.
+		_, err = auth.GetUserSessionByID(db.DefaultContext, uid, other.ID)
+		require.ErrorIs(t, err, util.ErrNotExist)
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		sessions, err := auth.FindUserSessions(db.DefaultContext, uid, 0)
+		require.NoError(t, err)
+		if assert.Len(t, sessions, 2) {
+			assert.Equal(t, recent.ID, sessions[0].ID)
+			assert.Equal(t, old.ID, sessions[1].ID)
+		}
+
+		sessions, err = auth.FindUserSessions(db.DefaultContext, uid, 200)
+		require.NoError(t, err)
+		assert.Len(t, sessions, 1)
+	})
+
+	t.Run("UpdateActivity", func(t *testing.T) {
+		old.LastSeenUnix = 400
+		old.IPAddress = "192.0.2.1"
+		require.NoError(t, auth.UpdateUserSessionActivity(db.DefaultContext, old))
+
+		s, err := auth.GetUserSessionByID(db.DefaultContext, uid, old.ID)
+		require.NoError(t, err)
+		assert.Equal(t, timeutil.TimeStamp(400), s.LastSeenUnix)
+		assert.Equal(t, "192.0.2.1", s.IPAddress)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		require.NoError(t, auth.RevokeUserSessions(db.DefaultContext, uid, old.ID))
+		sessions, err := auth.FindUserSessions(db.DefaultContext, uid, 0)
+		require.NoError(t, err)
+		if assert.Len(t, sessions, 1) {
+			assert.Equal(t, recent.ID, sessions[0].ID)
+		}
+		_, err = auth.GetUserSessionByID(db.DefaultContext, uid, old.ID)
+		require.ErrorIs(t, err, util.ErrNotExist)
+
+		// The revoked session stays registered so that it can't register itself again.
+		s, err := auth.GetUserSessionByKeyHash(db.DefaultContext, old.KeyHash)
+		require.NoError(t, err)
+		assert.True(t, s.IsRevoked())
+		assert.Greater(t, s.LastSeenUnix, timeutil.TimeStamp(400))
+
+		require.NoError(t, auth.RevokeUserSessions(db.DefaultContext, uid))
+		sessions, err = auth.FindUserSessions(db.DefaultContext, uid, 0)
+		require.NoError(t, err)
+		assert.Empty(t, sessions)
+
+		// The session of the other user is untouched.
+		s, err = auth.GetUserSessionByKeyHash(db.DefaultContext, other.KeyHash)
+		require.NoError(t, err)
+		assert.False(t, s.IsRevoked())
+	})
+
+	t.Run("DeleteInactive", func(t *testing.T) {
+		require.NoError(t, auth.DeleteInactiveUserSessions(db.DefaultContext, uid, timeutil.TimeStampNow().Add(60)))
+		_, err := auth.GetUserSessionByKeyHash(db.DefaultContext, old.KeyHash)
+		require.ErrorIs(t, err, util.ErrNotExist)
+		_, err = auth.GetUserSessionByKeyHash(db.DefaultContext, recent.KeyHash)
+		require.ErrorIs(t, err, util.ErrNotExist)
+
+		_, err = auth.GetUserSessionByKeyHash(db.DefaultContext, other.KeyHash)
+		require.NoError(t, err)
+	})
+}
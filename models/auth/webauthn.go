@@ -56,6 +56,7 @@ type WebAuthnCredential struct {
 	BackupState     bool `xorm:"NOT NULL DEFAULT false"`
 	// If legacy is set to true, backup_eligible and backup_state isn't set.
 	Legacy      bool               `xorm:"NOT NULL DEFAULT true"`
+	Passkey     bool               `xorm:"NOT NULL DEFAULT false"` // Passkeys are used to sign in without a password, not as a second factor.
 	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
 	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
 }
@@ -155,8 +156,21 @@ func GetWebAuthnCredentialByID(ctx context.Context, id int64) (*WebAuthnCredenti
 }
 
 // HasWebAuthnRegistrationsByUID returns whether a given user has WebAuthn registrations
+// used as a second factor, passkeys are not taken into account
 func HasWebAuthnRegistrationsByUID(ctx context.Context, uid int64) (bool, error) {
-	return db.GetEngine(ctx).Where("user_id = ?", uid).Exist(&WebAuthnCredential{})
+	return db.GetEngine(ctx).Where("user_id = ? AND passkey = ?", uid, false).Exist(&WebAuthnCredential{})
+}
+
+// GetPasskeysByUID returns the passkeys of the given user
+func GetPasskeysByUID(ctx context.Context, uid int64) (WebAuthnCredentialList, error) {
+	creds := make(WebAuthnCredentialList, 0)
+	return creds, db.GetEngine(ctx).Where("user_id = ? AND passkey = ?", uid, true).Find(&creds)
+}
+
+// GetSecurityKeysByUID returns the WebAuthn credentials of the given user that are not passkeys
+func GetSecurityKeysByUID(ctx context.Context, uid int64) (WebAuthnCredentialList, error) {
+	creds := make(WebAuthnCredentialList, 0)
+	return creds, db.GetEngine(ctx).Where("user_id = ? AND passkey = ?", uid, false).Find(&creds)
 }
 
 // GetWebAuthnCredentialByCredID returns WebAuthn credential by credential ID
@@ -172,6 +186,15 @@ func GetWebAuthnCredentialByCredID(ctx context.Context, userID int64, credID []b
 
 // CreateCredential will create a new WebAuthnCredential from the given Credential
 func CreateCredential(ctx context.Context, userID int64, name string, cred *webauthn.Credential) (*WebAuthnCredential, error) {
+	return createCredential(ctx, userID, name, cred, false)
+}
+
+// CreatePasskey will create a new passkey from the given Credential
+func CreatePasskey(ctx context.Context, userID int64, name string, cred *webauthn.Credential) (*WebAuthnCredential, error) {
+	return createCredential(ctx, userID, name, cred, true)
+}
+
+func createCredential(ctx context.Context, userID int64, name string, cred *webauthn.Credential, passkey bool) (*WebAuthnCredential, error) {
 	c := &WebAuthnCredential{
 		UserID:          userID,
 		Name:            name,
@@ -184,6 +207,7 @@ func CreateCredential(ctx context.Context, userID int64, name string, cred *weba
 		BackupEligible:  cred.Flags.BackupEligible,
 		BackupState:     cred.Flags.BackupState,
 		Legacy:          false,
+		Passkey:         passkey,
 	}
 
 	if err := db.Insert(ctx, c); err != nil {
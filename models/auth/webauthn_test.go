@@ -76,3 +76,37 @@ func TestCreateCredential(t *testing.T) {
 
 	unittest.AssertExistsIf(t, true, &auth_model.WebAuthnCredential{Name: "WebAuthn Created Credential", UserID: 1, BackupEligible: true, BackupState: true}, "legacy = false")
 }
+
+func TestCreatePasskey(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	res, err := auth_model.CreatePasskey(db.DefaultContext, 1, "Passkey", &webauthn.Credential{ID: []byte("Passkey")})
+	require.NoError(t, err)
+	assert.True(t, res.Passkey)
+
+	// passkeys are not a second factor
+	has, err := auth_model.HasWebAuthnRegistrationsByUID(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	passkeys, err := auth_model.GetPasskeysByUID(db.DefaultContext, 1)
+	require.NoError(t, err)
+	require.Len(t, passkeys, 1)
+	assert.Equal(t, []byte("Passkey"), passkeys[0].CredentialID)
+
+	keys, err := auth_model.GetSecurityKeysByUID(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = auth_model.CreateCredential(db.DefaultContext, 1, "Security key", &webauthn.Credential{ID: []byte("Security key")})
+	require.NoError(t, err)
+
+	has, err = auth_model.HasWebAuthnRegistrationsByUID(db.DefaultContext, 1)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	keys, err = auth_model.GetSecurityKeysByUID(db.DefaultContext, 1)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "Security key", keys[0].Name)
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package automation
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// RuleLogKeep is the number of executions kept in the log of a rule
+const RuleLogKeep = 100
+
+// RuleLog is an execution of an automation rule
+type RuleLog struct {
+	ID      int64 `xorm:"pk autoincr"`
+	RuleID  int64 `xorm:"INDEX NOT NULL"`
+	RepoID  int64 `xorm:"NOT NULL"`
+	IssueID int64 `xorm:"NOT NULL"`
+	Event   Event `xorm:"VARCHAR(50) NOT NULL"`
+	// IsSuccess is false if one of the actions failed, Message holds the error then
+	IsSuccess bool   `xorm:"NOT NULL DEFAULT false"`
+	Message   string `xorm:"TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+func init() {
+	db.RegisterModel(new(RuleLog))
+}
+
+// TableName sets the table name of the execution logs of the automation rules
+func (RuleLog) TableName() string {
+	return "automation_rule_log"
+}
+
+// InsertRuleLog inserts an execution of a rule and removes the oldest executions
+// beyond RuleLogKeep
+func InsertRuleLog(ctx context.Context, l *RuleLog) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := db.Insert(ctx, l); err != nil {
+			return err
+		}
+		var oldest []int64
+		if err := db.GetEngine(ctx).Table("automation_rule_log").Cols("id").
+			Where("rule_id = ?", l.RuleID).OrderBy("id DESC").Limit(1, RuleLogKeep).Find(&oldest); err != nil {
+			return err
+		}
+		if len(oldest) == 0 {
+			return nil
+		}
+		_, err := db.GetEngine(ctx).Where("rule_id = ?", l.RuleID).And(builder.Lte{"id": oldest[0]}).Delete(new(RuleLog))
+		return err
+	})
+}
+
+// GetRuleLogs returns the executions of a rule, the most recent first
+func GetRuleLogs(ctx context.Context, ruleID int64, opts db.ListOptions) ([]*RuleLog, int64, error) {
+	sess := db.GetEngine(ctx).Where("rule_id = ?", ruleID).OrderBy("id DESC")
+	if opts.PageSize > 0 {
+		sess = db.SetSessionPagination(sess, &opts)
+	}
+	logs := make([]*RuleLog, 0, opts.PageSize)
+	count, err := sess.FindAndCount(&logs)
+	return logs, count, err
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"forgejo.org/models/db"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// Event is the event which triggers an automation rule
+type Event string
+
+const (
+	EventIssueOpened       Event = "issue_opened"
+	EventIssueClosed       Event = "issue_closed"
+	EventIssueReopened     Event = "issue_reopened"
+	EventIssueLabeled      Event = "issue_labeled"
+	EventIssueComment      Event = "issue_comment"
+	EventPullRequestOpened Event = "pull_request_opened"
+	EventPullRequestPushed Event = "pull_request_pushed"
+	EventPullRequestMerged Event = "pull_request_merged"
+	// EventIssueStale is triggered by a cron task for the open issues and
+	// pull requests without activity since Conditions.InactiveDays
+	EventIssueStale Event = "issue_stale"
+)
+
+// Events are all the events which can trigger an automation rule
+var Events = []Event{
+	EventIssueOpened,
+	EventIssueClosed,
+	EventIssueReopened,
+	EventIssueLabeled,
+	EventIssueComment,
+	EventPullRequestOpened,
+	EventPullRequestPushed,
+	EventPullRequestMerged,
+	EventIssueStale,
+}
+
+// IsValid returns true if the event is known
+func (e Event) IsValid() bool {
+	return slices.Contains(Events, e)
+}
+
+// Conditions are the conditions an issue or a pull request must match to run the actions of a rule
+type Conditions struct {
+	// IsPull restricts the rule to the issues (false) or to the pull requests (true)
+	IsPull *bool `json:",omitempty"`
+	// Labels are the names of the labels the issue must all have
+	Labels []string `json:",omitempty"`
+	// ExcludedLabels are the names of the labels the issue must not have
+	ExcludedLabels []string `json:",omitempty"`
+	// Authors are the names of the users one of which must be the author of the issue
+	Authors []string `json:",omitempty"`
+	// Paths are glob patterns one of which must match a file changed by the pull request
+	Paths []string `json:",omitempty"`
+	// InactiveDays is the number of days without activity after which an issue is stale
+	InactiveDays int `json:",omitempty"`
+}
+
+// IssueType returns "issues" or "pulls" if the rule is restricted to the issues or to the pull requests
+func (c *Conditions) IssueType() string {
+	if c.IsPull == nil {
+		return ""
+	} else if *c.IsPull {
+		return "pulls"
+	}
+	return "issues"
+}
+
+// Actions are the actions a rule runs on the matching issues and pull requests
+type Actions struct {
+	AddLabels    []string `json:",omitempty"`
+	RemoveLabels []string `json:",omitempty"`
+	Assignees    []string `json:",omitempty"`
+	Reviewers    []string `json:",omitempty"`
+	Comment      string   `json:",omitempty"`
+	Close        bool     `json:",omitempty"`
+	// ProjectColumnID is the column of a project the issue is moved to
+	ProjectColumnID int64 `json:",omitempty"`
+	// OnLinkedIssues runs the actions on the issues a pull request closes
+	// instead of the pull request itself
+	OnLinkedIssues bool `json:",omitempty"`
+}
+
+// IsEmpty returns true if there is no action to run
+func (a *Actions) IsEmpty() bool {
+	return len(a.AddLabels) == 0 && len(a.RemoveLabels) == 0 && len(a.Assignees) == 0 &&
+		len(a.Reviewers) == 0 && a.Comment == "" && !a.Close && a.ProjectColumnID == 0
+}
+
+// Rule is an automation rule of a repository, or of all the repositories of an owner
+type Rule struct {
+	ID         int64      `xorm:"pk autoincr"`
+	OwnerID    int64      `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID     int64      `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name       string     `xorm:"NOT NULL"`
+	Event      Event      `xorm:"VARCHAR(50) INDEX NOT NULL"`
+	IsActive   bool       `xorm:"INDEX NOT NULL DEFAULT true"`
+	Conditions Conditions `xorm:"JSON TEXT"`
+	Actions    Actions    `xorm:"JSON TEXT"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+func init() {
+	db.RegisterModel(new(Rule))
+}
+
+// TableName sets the table name of the automation rules
+func (Rule) TableName() string {
+	return "automation_rule"
+}
+
+// ErrRuleNotExist represents a "RuleNotExist" kind of error.
+type ErrRuleNotExist struct {
+	ID int64
+}
+
+// IsErrRuleNotExist checks if an error is a ErrRuleNotExist.
+func IsErrRuleNotExist(err error) bool {
+	_, ok := err.(ErrRuleNotExist)
+	return ok
+}
+
+func (err ErrRuleNotExist) Error() string {
+	return fmt.Sprintf("automation rule does not exist [id: %d]", err.ID)
+}
+
+func (err ErrRuleNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+func (r *Rule) validate(ctx context.Context) error {
+	if r.Name == "" || len(r.Name) > 100 {
+		return util.NewInvalidArgumentErrorf("the name of an automation rule must have between 1 and 100 characters")
+	}
+	if !r.Event.IsValid() {
+		return util.NewInvalidArgumentErrorf("unknown event %q", r.Event)
+	}
+	if r.Event == EventIssueStale && r.Conditions.InactiveDays <= 0 {
+		return util.NewInvalidArgumentErrorf("the rules of stale issues need a number of inactive days")
+	}
+	if r.Actions.IsEmpty() {
+		return util.NewInvalidArgumentErrorf("an automation rule needs at least one action")
+	}
+	if (r.OwnerID == 0) == (r.RepoID == 0) {
+		return util.NewInvalidArgumentErrorf("an automation rule belongs to either an owner or a repository")
+	}
+	if r.Actions.ProjectColumnID > 0 {
+		return r.validateProjectColumn(ctx)
+	}
+	return nil
+}
+
+// validateProjectColumn checks that the issues of the rule can be added to the
+// project of the column it moves them to
+func (r *Rule) validateProjectColumn(ctx context.Context) error {
+	column, err := project_model.GetColumn(ctx, r.Actions.ProjectColumnID)
+	if project_model.IsErrProjectColumnNotExist(err) {
+		return util.NewInvalidArgumentErrorf("the project column does not exist")
+	} else if err != nil {
+		return err
+	}
+	project, err := project_model.GetProjectByID(ctx, column.ProjectID)
+	if project_model.IsErrProjectNotExist(err) {
+		return util.NewInvalidArgumentErrorf("the project column does not exist")
+	} else if err != nil {
+		return err
+	}
+
+	ownerID := r.OwnerID
+	var repo *repo_model.Repository
+	if r.RepoID > 0 {
+		if repo, err = repo_model.GetRepositoryByID(ctx, r.RepoID); err != nil {
+			return err
+		}
+		ownerID = repo.OwnerID
+	}
+	if !project.CanBeAccessedByOwnerRepo(ownerID, repo) {
+		return util.NewInvalidArgumentErrorf("the project column belongs to a project of another owner or repository")
+	}
+	return nil
+}
+
+// CreateRule creates an automation rule
+func CreateRule(ctx context.Context, r *Rule) error {
+	if err := r.validate(ctx); err != nil {
+		return err
+	}
+	return db.Insert(ctx, r)
+}
+
+// UpdateRule updates an automation rule
+func UpdateRule(ctx context.Context, r *Rule) error {
+	if err := r.validate(ctx); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(r.ID).Cols("name", "event", "is_active", "conditions", "actions").Update(r)
+	return err
+}
+
+// GetRuleByID returns an automation rule of an owner or of a repository
+func GetRuleByID(ctx context.Context, ownerID, repoID, id int64) (*Rule, error) {
+	r := new(Rule)
+	has, err := db.GetEngine(ctx).Where("id = ? AND owner_id = ? AND repo_id = ?", id, ownerID, repoID).Get(r)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrRuleNotExist{ID: id}
+	}
+	return r, nil
+}
+
+// GetRules returns the automation rules of an owner or of a repository
+func GetRules(ctx context.Context, ownerID, repoID int64) ([]*Rule, error) {
+	rules := make([]*Rule, 0, 5)
+	return rules, db.GetEngine(ctx).Where("owner_id = ? AND repo_id = ?", ownerID, repoID).OrderBy("name").Find(&rules)
+}
+
+// GetActiveRulesForRepo returns the active automation rules of an event which apply to a repository,
+// the rules of the repository and the rules of its owner
+func GetActiveRulesForRepo(ctx context.Context, ownerID, repoID int64, event Event) ([]*Rule, error) {
+	rules := make([]*Rule, 0, 5)
+	return rules, db.GetEngine(ctx).
+		Where(builder.Or(builder.Eq{"repo_id": repoID}, builder.Eq{"owner_id": ownerID, "repo_id": 0})).
+		And("event = ? AND is_active = ?", event, true).
+		OrderBy("id").
+		Find(&rules)
+}
+
+// GetActiveRulesByEvent returns the active automation rules of an event of all the owners and repositories
+func GetActiveRulesByEvent(ctx context.Context, event Event) ([]*Rule, error) {
+	rules := make([]*Rule, 0, 5)
+	return rules, db.GetEngine(ctx).Where("event = ? AND is_active = ?", event, true).OrderBy("id").Find(&rules)
+}
+
+// DeleteRule deletes an automation rule and its execution log
+func DeleteRule(ctx context.Context, r *Rule) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.DeleteByID[Rule](ctx, r.ID); err != nil {
+			return err
+		}
+		_, err := db.DeleteByBean(ctx, &RuleLog{RuleID: r.ID})
+		return err
+	})
+}
+
+// DeleteRulesByRepoID deletes the automation rules of a repository and their execution logs
+func DeleteRulesByRepoID(ctx context.Context, repoID int64) error {
+	if _, err := db.GetEngine(ctx).Where(builder.In("rule_id", builder.Select("id").From("automation_rule").Where(builder.Eq{"repo_id": repoID}))).Delete(new(RuleLog)); err != nil {
+		return err
+	}
+	_, err := db.DeleteByBean(ctx, &Rule{RepoID: repoID})
+	return err
+}
+
+// DeleteRulesByOwnerID deletes the automation rules of an owner and their execution logs
+func DeleteRulesByOwnerID(ctx context.Context, ownerID int64) error {
+	if _, err := db.GetEngine(ctx).Where(builder.In("rule_id", builder.Select("id").From("automation_rule").Where(builder.Eq{"owner_id": ownerID}))).Delete(new(RuleLog)); err != nil {
+		return err
+	}
+	_, err := db.DeleteByBean(ctx, &Rule{OwnerID: ownerID})
+	return err
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package automation_test
+
+import (
+	"testing"
+
+	automation_model "forgejo.org/models/automation"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repoRule := &automation_model.Rule{
+		RepoID:   1,
+		Name:     "triage",
+		Event:    automation_model.EventIssueOpened,
+		IsActive: true,
+		Actions:  automation_model.Actions{AddLabels: []string{"needs-triage"}},
+	}
+	require.NoError(t, automation_model.CreateRule(db.DefaultContext, repoRule))
+	ownerRule := &automation_model.Rule{
+		OwnerID:    2,
+		Name:       "stale",
+		Event:      automation_model.EventIssueStale,
+		IsActive:   true,
+		Conditions: automation_model.Conditions{InactiveDays: 60},
+		Actions:    automation_model.Actions{Comment: "Closed for inactivity", Close: true},
+	}
+	require.NoError(t, automation_model.CreateRule(db.DefaultContext, ownerRule))
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, r := range []*automation_model.Rule{
+			{RepoID: 1, Name: "", Event: automation_model.EventIssueOpened, Actions: automation_model.Actions{Close: true}},
+			{RepoID: 1, Name: "unknown", Event: "unknown", Actions: automation_model.Actions{Close: true}},
+			{RepoID: 1, Name: "no action", Event: automation_model.EventIssueOpened},
+			{RepoID: 1, Name: "no days", Event: automation_model.EventIssueStale, Actions: automation_model.Actions{Close: true}},
+			{Name: "no scope", Event: automation_model.EventIssueOpened, Actions: automation_model.Actions{Close: true}},
+			{RepoID: 1, Name: "unknown column", Event: automation_model.EventIssueOpened, Actions: automation_model.Actions{ProjectColumnID: 1000}},
+			{RepoID: 1, Name: "column of another repository", Event: automation_model.EventIssueOpened, Actions: automation_model.Actions{ProjectColumnID: 5}},
+			{OwnerID: 2, Name: "column of a repository", Event: automation_model.EventIssueOpened, Actions: automation_model.Actions{ProjectColumnID: 1}},
+		} {
+			require.ErrorIs(t, automation_model.CreateRule(db.DefaultContext, r), util.ErrInvalidArgument, r.Name)
+		}
+	})
+
+	t.Run("ProjectColumn", func(t *testing.T) {
+		r := &automation_model.Rule{RepoID: 1, Name: "to do", Event: automation_model.EventIssueOpened, Actions: automation_model.Actions{ProjectColumnID: 1}}
+		require.NoError(t, automation_model.CreateRule(db.DefaultContext, r))
+		_, err := db.DeleteByID[automation_model.Rule](db.DefaultContext, r.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		r, err := automation_model.GetRuleByID(db.DefaultContext, 0, 1, repoRule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"needs-triage"}, r.Actions.AddLabels)
+
+		_, err = automation_model.GetRuleByID(db.DefaultContext, 0, 2, repoRule.ID)
+		assert.True(t, automation_model.IsErrRuleNotExist(err))
+
+		rules, err := automation_model.GetActiveRulesForRepo(db.DefaultContext, 2, 1, automation_model.EventIssueOpened)
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, repoRule.ID, rules[0].ID)
+
+		rules, err = automation_model.GetActiveRulesForRepo(db.DefaultContext, 2, 1, automation_model.EventIssueStale)
+		require.NoError(t, err)
+		require.Len(t, rules, 1)
+		assert.Equal(t, 60, rules[0].Conditions.InactiveDays)
+
+		rules, err = automation_model.GetActiveRulesForRepo(db.DefaultContext, 3, 3, automation_model.EventIssueStale)
+		require.NoError(t, err)
+		assert.Empty(t, rules)
+
+		repoRule.IsActive = false
+		require.NoError(t, automation_model.UpdateRule(db.DefaultContext, repoRule))
+		rules, err = automation_model.GetActiveRulesForRepo(db.DefaultContext, 2, 1, automation_model.EventIssueOpened)
+		require.NoError(t, err)
+		assert.Empty(t, rules)
+	})
+
+	t.Run("Log", func(t *testing.T) {
+		for i := 0; i < automation_model.RuleLogKeep+2; i++ {
+			require.NoError(t, automation_model.InsertRuleLog(db.DefaultContext, &automation_model.RuleLog{
+				RuleID:    repoRule.ID,
+				RepoID:    1,
+				IssueID:   int64(i + 1),
+				Event:     automation_model.EventIssueOpened,
+				IsSuccess: true,
+			}))
+		}
+		logs, count, err := automation_model.GetRuleLogs(db.DefaultContext, repoRule.ID, db.ListOptions{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.EqualValues(t, automation_model.RuleLogKeep, count)
+		require.Len(t, logs, 10)
+		assert.EqualValues(t, automation_model.RuleLogKeep+2, logs[0].IssueID)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, automation_model.DeleteRule(db.DefaultContext, repoRule))
+		unittest.AssertNotExistsBean(t, &automation_model.Rule{ID: repoRule.ID})
+		unittest.AssertNotExistsBean(t, &automation_model.RuleLog{RuleID: repoRule.ID})
+
+		require.NoError(t, automation_model.DeleteRulesByOwnerID(db.DefaultContext, 2))
+		unittest.AssertNotExistsBean(t, &automation_model.Rule{ID: ownerRule.ID})
+	})
+}
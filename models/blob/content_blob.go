@@ -0,0 +1,142 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package blob
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrContentBlobNotExist indicates a content blob not exist error
+var ErrContentBlobNotExist = util.NewNotExistErrorf("content blob does not exist")
+
+// ContentBlob represents a content-addressed blob which is shared by LFS objects, package blobs and attachments
+type ContentBlob struct {
+	ID          int64              `xorm:"pk autoincr"`
+	HashSHA256  string             `xorm:"hash_sha256 UNIQUE NOT NULL"`
+	Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+	RefCount    int64              `xorm:"NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated INDEX NOT NULL"`
+}
+
+func init() {
+	db.RegisterModel(new(ContentBlob))
+}
+
+// references lists the columns of the tables which reference content blobs by their sha256 hash
+var references = []struct {
+	Table  string
+	Column string
+}{
+	{"lfs_meta_object", "oid"},
+	{"package_blob", "hash_sha256"},
+	{"attachment", "hash_sha256"},
+}
+
+// GetBlobByHash gets a blob by its sha256 hash
+func GetBlobByHash(ctx context.Context, hash string) (*ContentBlob, error) {
+	cb := &ContentBlob{}
+	has, err := db.GetEngine(ctx).Where("hash_sha256 = ?", hash).Get(cb)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrContentBlobNotExist
+	}
+	return cb, nil
+}
+
+// AddReference increments the reference counter of the blob and creates it if it does not exist
+func AddReference(ctx context.Context, hash string, size int64) error {
+	if !setting.Blobs.Enabled || hash == "" {
+		return nil
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		e := db.GetEngine(ctx)
+
+		n, err := e.Where("hash_sha256 = ?", hash).Incr("ref_count").Update(new(ContentBlob))
+		if err != nil || n > 0 {
+			return err
+		}
+
+		_, err = e.Insert(&ContentBlob{
+			HashSHA256: hash,
+			Size:       size,
+			RefCount:   1,
+		})
+		return err
+	})
+}
+
+// EnsureBlob makes sure a stored blob has a row, blobs stored without a reference being
+// added have none and would never be removed by the cleanup of unreferenced blobs
+func EnsureBlob(ctx context.Context, hash string, size int64) error {
+	if !setting.Blobs.Enabled || hash == "" {
+		return nil
+	}
+
+	e := db.GetEngine(ctx)
+	has, err := e.Where("hash_sha256 = ?", hash).Exist(new(ContentBlob))
+	if err != nil || has {
+		return err
+	}
+	if _, err := e.Insert(&ContentBlob{HashSHA256: hash, Size: size}); err != nil {
+		// the row may have been inserted by a concurrent reference
+		if has, _ := e.Where("hash_sha256 = ?", hash).Exist(new(ContentBlob)); has {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveReference decrements the reference counter of the blob.
+// Unreferenced blobs are removed later by the cleanup task.
+func RemoveReference(ctx context.Context, hash string) error {
+	if !setting.Blobs.Enabled || hash == "" {
+		return nil
+	}
+
+	_, err := db.GetEngine(ctx).Where("hash_sha256 = ? AND ref_count > 0", hash).Decr("ref_count").Update(new(ContentBlob))
+	return err
+}
+
+// CountReferences counts the LFS objects, package blobs and attachments which reference the content
+func CountReferences(ctx context.Context, hash string) (int64, error) {
+	var count int64
+	for _, ref := range references {
+		n, err := db.GetEngine(ctx).Table(ref.Table).Where(builder.Eq{ref.Column: hash}).Count()
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+	return count, nil
+}
+
+// SetReferenceCount overwrites the reference counter of the blob
+func SetReferenceCount(ctx context.Context, id, count int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Cols("ref_count").Update(&ContentBlob{RefCount: count})
+	return err
+}
+
+// FindUnreferencedBlobs gets all blobs without references which were last updated before the given time
+func FindUnreferencedBlobs(ctx context.Context, olderThan timeutil.TimeStamp) ([]*ContentBlob, error) {
+	cbs := make([]*ContentBlob, 0, 10)
+	return cbs, db.GetEngine(ctx).
+		Where("ref_count <= 0 AND updated_unix < ?", olderThan).
+		Find(&cbs)
+}
+
+// DeleteBlobByID deletes a blob by id
+func DeleteBlobByID(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(&ContentBlob{})
+	return err
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package blob_test
+
+import (
+	"testing"
+
+	blob_model "forgejo.org/models/blob"
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentBlobReferences(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	hash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	t.Run("Disabled", func(t *testing.T) {
+		require.NoError(t, blob_model.AddReference(db.DefaultContext, hash, 0))
+		_, err := blob_model.GetBlobByHash(db.DefaultContext, hash)
+		require.ErrorIs(t, err, blob_model.ErrContentBlobNotExist)
+	})
+
+	defer test.MockVariableValue(&setting.Blobs.Enabled, true)()
+
+	t.Run("Counter", func(t *testing.T) {
+		require.NoError(t, blob_model.AddReference(db.DefaultContext, hash, 0))
+		require.NoError(t, blob_model.AddReference(db.DefaultContext, hash, 0))
+
+		cb, err := blob_model.GetBlobByHash(db.DefaultContext, hash)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, cb.RefCount)
+
+		for range 3 {
+			require.NoError(t, blob_model.RemoveReference(db.DefaultContext, hash))
+		}
+
+		cb, err = blob_model.GetBlobByHash(db.DefaultContext, hash)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, cb.RefCount)
+
+		cbs, err := blob_model.FindUnreferencedBlobs(db.DefaultContext, timeutil.TimeStampNow()+1)
+		require.NoError(t, err)
+		require.Len(t, cbs, 1)
+		assert.Equal(t, cb.ID, cbs[0].ID)
+
+		cbs, err = blob_model.FindUnreferencedBlobs(db.DefaultContext, cb.UpdatedUnix)
+		require.NoError(t, err)
+		assert.Empty(t, cbs)
+	})
+
+	t.Run("CountReferences", func(t *testing.T) {
+		count, err := blob_model.CountReferences(db.DefaultContext, hash)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, count)
+
+		require.NoError(t, db.Insert(db.DefaultContext, &repo_model.Attachment{UUID: "dedup-1", RepoID: 1, HashSHA256: hash}))
+		require.NoError(t, db.Insert(db.DefaultContext, &repo_model.Attachment{UUID: "dedup-2", RepoID: 1, HashSHA256: hash}))
+
+		count, err = blob_model.CountReferences(db.DefaultContext, hash)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, count)
+
+		cb, err := blob_model.GetBlobByHash(db.DefaultContext, hash)
+		require.NoError(t, err)
+		require.NoError(t, blob_model.SetReferenceCount(db.DefaultContext, cb.ID, count))
+
+		cb, err = blob_model.GetBlobByHash(db.DefaultContext, hash)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, cb.RefCount)
+	})
+
+	t.Run("EnsureBlob", func(t *testing.T) {
+		// an existing blob keeps its counter
+		require.NoError(t, blob_model.EnsureBlob(db.DefaultContext, hash, 0))
+		cb, err := blob_model.GetBlobByHash(db.DefaultContext, hash)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, cb.RefCount)
+
+		// a blob stored without a reference becomes a candidate of the cleanup
+		orphan := "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"
+		require.NoError(t, blob_model.EnsureBlob(db.DefaultContext, orphan, 7))
+		cb, err = blob_model.GetBlobByHash(db.DefaultContext, orphan)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, cb.RefCount)
+		assert.EqualValues(t, 7, cb.Size)
+	})
+}
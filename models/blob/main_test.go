@@ -0,0 +1,17 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package blob_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/unittest"
+
+	_ "forgejo.org/models/git"
+	_ "forgejo.org/models/packages"
+)
+
+func TestMain(m *testing.M) {
+	unittest.MainTest(m)
+}
@@ -0,0 +1,28 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add repository restrictions and expiry to access_token",
+		Upgrade:     addAccessTokenRestrictions,
+	})
+}
+
+func addAccessTokenRestrictions(x *xorm.Engine) error {
+	type AccessToken struct {
+		OrgID          int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		RepoIDs        []int64            `xorm:"JSON TEXT"`
+		ExpiresUnix    timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		ExpiryNotified bool               `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync(new(AccessToken))
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add audit_event table",
+		Upgrade:     addAuditEvent,
+	})
+}
+
+func addAuditEvent(x *xorm.Engine) error {
+	type AuditEvent struct {
+		ID          int64              `xorm:"pk autoincr"`
+		Action      string             `xorm:"VARCHAR(64) INDEX NOT NULL"`
+		ActorID     int64              `xorm:"INDEX"`
+		ActorName   string             `xorm:"VARCHAR(255)"`
+		IPAddress   string             `xorm:"VARCHAR(64)"`
+		OwnerID     int64              `xorm:"INDEX"`
+		RepoID      int64              `xorm:"INDEX"`
+		TargetType  string             `xorm:"VARCHAR(32)"`
+		TargetID    int64              `xorm:"INDEX"`
+		TargetName  string             `xorm:"VARCHAR(255)"`
+		Before      string             `xorm:"TEXT"`
+		After       string             `xorm:"TEXT"`
+		CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	}
+
+	return x.Sync(new(AuditEvent))
+}
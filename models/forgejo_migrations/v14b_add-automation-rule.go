@@ -0,0 +1,55 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add automation_rule and automation_rule_log tables",
+		Upgrade:     addAutomationRule,
+	})
+}
+
+type v14bAutomationRule struct {
+	ID          int64              `xorm:"pk autoincr"`
+	OwnerID     int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name        string             `xorm:"NOT NULL"`
+	Event       string             `xorm:"VARCHAR(50) INDEX NOT NULL"`
+	IsActive    bool               `xorm:"INDEX NOT NULL DEFAULT true"`
+	Conditions  string             `xorm:"TEXT"`
+	Actions     string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// TableName sets the name of this table
+func (v14bAutomationRule) TableName() string {
+	return "automation_rule"
+}
+
+type v14bAutomationRuleLog struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RuleID      int64              `xorm:"INDEX NOT NULL"`
+	RepoID      int64              `xorm:"NOT NULL"`
+	IssueID     int64              `xorm:"NOT NULL"`
+	Event       string             `xorm:"VARCHAR(50) NOT NULL"`
+	IsSuccess   bool               `xorm:"NOT NULL DEFAULT false"`
+	Message     string             `xorm:"TEXT"`
+	CreatedUnix timeutil.TimeStamp `xorm:"INDEX created"`
+}
+
+// TableName sets the name of this table
+func (v14bAutomationRuleLog) TableName() string {
+	return "automation_rule_log"
+}
+
+func addAutomationRule(x *xorm.Engine) error {
+	return x.Sync(new(v14bAutomationRule), new(v14bAutomationRuleLog))
+}
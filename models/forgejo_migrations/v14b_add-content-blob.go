@@ -0,0 +1,33 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add content_blob table and hash_sha256 column to attachment",
+		Upgrade:     addContentBlob,
+	})
+}
+
+func addContentBlob(x *xorm.Engine) error {
+	type ContentBlob struct {
+		ID          int64              `xorm:"pk autoincr"`
+		HashSHA256  string             `xorm:"hash_sha256 UNIQUE NOT NULL"`
+		Size        int64              `xorm:"NOT NULL DEFAULT 0"`
+		RefCount    int64              `xorm:"NOT NULL DEFAULT 0"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated INDEX NOT NULL"`
+	}
+
+	type Attachment struct {
+		HashSHA256 string `xorm:"hash_sha256 INDEX NOT NULL DEFAULT ''"`
+	}
+
+	return x.Sync(new(ContentBlob), new(Attachment))
+}
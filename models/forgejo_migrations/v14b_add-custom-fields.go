@@ -0,0 +1,42 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add custom_field and custom_field_value tables",
+		Upgrade:     addCustomFields,
+	})
+}
+
+func addCustomFields(x *xorm.Engine) error {
+	type CustomField struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RepoID      int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		ProjectID   int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+		Name        string             `xorm:"NOT NULL"`
+		Type        string             `xorm:"VARCHAR(16) NOT NULL"`
+		Options     []string           `xorm:"TEXT JSON"`
+		Sorting     int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type CustomFieldValue struct {
+		ID          int64              `xorm:"pk autoincr"`
+		FieldID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		IssueID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Value       string             `xorm:"VARCHAR(255) INDEX NOT NULL"`
+		SortValue   float64            `xorm:"NOT NULL DEFAULT 0"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(CustomField), new(CustomFieldValue))
+}
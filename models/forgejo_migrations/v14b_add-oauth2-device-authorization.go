@@ -0,0 +1,40 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add oauth2_device_authorization table",
+		Upgrade:     addOAuth2DeviceAuthorization,
+	})
+}
+
+type v14bOAuth2DeviceAuthorization struct {
+	ID             int64  `xorm:"pk autoincr"`
+	ApplicationID  int64  `xorm:"INDEX"`
+	DeviceCodeHash string `xorm:"VARCHAR(64) UNIQUE NOT NULL"`
+	UserCode       string `xorm:"VARCHAR(9) UNIQUE NOT NULL"`
+	Scope          string `xorm:"TEXT"`
+	GrantID        int64
+	Denied         bool `xorm:"NOT NULL DEFAULT false"`
+	Interval       int64
+	LastPolledUnix timeutil.TimeStamp
+	ExpiresUnix    timeutil.TimeStamp `xorm:"INDEX"`
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+// TableName sets the name of this table
+func (*v14bOAuth2DeviceAuthorization) TableName() string {
+	return "oauth2_device_authorization"
+}
+
+func addOAuth2DeviceAuthorization(x *xorm.Engine) error {
+	return x.Sync(new(v14bOAuth2DeviceAuthorization))
+}
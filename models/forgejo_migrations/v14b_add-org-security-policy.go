@@ -0,0 +1,31 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add org_security_policy table",
+		Upgrade:     addOrgSecurityPolicy,
+	})
+}
+
+func addOrgSecurityPolicy(x *xorm.Engine) error {
+	type OrgSecurityPolicy struct {
+		ID               int64              `xorm:"pk autoincr"`
+		OrgID            int64              `xorm:"UNIQUE NOT NULL"`
+		RequireTwoFactor bool               `xorm:"NOT NULL DEFAULT false"`
+		SSHKeyTypes      []string           `xorm:"TEXT JSON"`
+		MinRSAKeySize    int                `xorm:"NOT NULL DEFAULT 0"`
+		IPAllowlist      []string           `xorm:"TEXT JSON"`
+		UpdatedUnix      timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(OrgSecurityPolicy))
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add package_sbom and package_component tables",
+		Upgrade:     addPackageSBOM,
+	})
+}
+
+func addPackageSBOM(x *xorm.Engine) error {
+	type PackageSBOM struct {
+		ID          int64              `xorm:"pk autoincr"`
+		VersionID   int64              `xorm:"UNIQUE NOT NULL"`
+		BlobID      int64              `xorm:"INDEX NOT NULL"`
+		Format      string             `xorm:"NOT NULL"`
+		SpecVersion string             `xorm:"NOT NULL DEFAULT ''"`
+		CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+	}
+
+	type PackageComponent struct {
+		ID              int64    `xorm:"pk autoincr"`
+		VersionID       int64    `xorm:"INDEX NOT NULL"`
+		Name            string   `xorm:"NOT NULL"`
+		LowerName       string   `xorm:"INDEX NOT NULL"`
+		Version         string   `xorm:"NOT NULL DEFAULT ''"`
+		LowerVersion    string   `xorm:"INDEX NOT NULL DEFAULT ''"`
+		PURL            string   `xorm:"purl INDEX NOT NULL DEFAULT ''"`
+		Type            string   `xorm:"NOT NULL DEFAULT ''"`
+		Licenses        []string `xorm:"JSON TEXT"`
+		Vulnerabilities []string `xorm:"JSON TEXT"`
+	}
+
+	return x.Sync(new(PackageSBOM), new(PackageComponent))
+}
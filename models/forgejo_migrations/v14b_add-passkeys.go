@@ -0,0 +1,26 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add passkey to webauthn_credential and require_phishing_resistant_auth to user",
+		Upgrade:     addPasskeys,
+	})
+}
+
+func addPasskeys(x *xorm.Engine) error {
+	type WebauthnCredential struct {
+		Passkey bool `xorm:"NOT NULL DEFAULT false"`
+	}
+	type User struct {
+		RequirePhishingResistantAuth bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync(new(WebauthnCredential), new(User))
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add project_view table",
+		Upgrade:     addProjectView,
+	})
+}
+
+func addProjectView(x *xorm.Engine) error {
+	type ProjectView struct {
+		ID          int64              `xorm:"pk autoincr"`
+		ProjectID   int64              `xorm:"INDEX NOT NULL"`
+		Name        string             `xorm:"NOT NULL"`
+		Type        string             `xorm:"VARCHAR(16) NOT NULL"`
+		Fields      []string           `xorm:"TEXT JSON"`
+		GroupBy     string             `xorm:"VARCHAR(50)"`
+		SortBy      string             `xorm:"VARCHAR(50)"`
+		SortDesc    bool               `xorm:"NOT NULL DEFAULT false"`
+		DateField   string             `xorm:"VARCHAR(50)"`
+		CreatorID   int64              `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	return x.Sync(new(ProjectView))
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add saved_filter table",
+		Upgrade:     addSavedFilter,
+	})
+}
+
+func addSavedFilter(x *xorm.Engine) error {
+	type SavedFilter struct {
+		ID             int64  `xorm:"pk autoincr"`
+		UserID         int64  `xorm:"INDEX NOT NULL"`
+		Name           string `xorm:"NOT NULL"`
+		Query          string `xorm:"TEXT NOT NULL"`
+		IsPull         bool   `xorm:"NOT NULL DEFAULT false"`
+		ViewType       string `xorm:"VARCHAR(20)"`
+		LastViewedUnix timeutil.TimeStamp
+		CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(SavedFilter))
+}
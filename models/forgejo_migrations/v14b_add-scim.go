@@ -0,0 +1,45 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add scim_token, scim_group and scim_group_member tables",
+		Upgrade:     addSCIM,
+	})
+}
+
+func addSCIM(x *xorm.Engine) error {
+	type SCIMToken struct {
+		ID             int64 `xorm:"pk autoincr"`
+		SourceID       int64 `xorm:"UNIQUE NOT NULL"`
+		TokenHash      string
+		TokenSalt      string
+		TokenLastEight string             `xorm:"INDEX token_last_eight"`
+		CreatedUnix    timeutil.TimeStamp `xorm:"INDEX created"`
+	}
+
+	type SCIMGroup struct {
+		ID          int64              `xorm:"pk autoincr"`
+		SourceID    int64              `xorm:"INDEX NOT NULL"`
+		ExternalID  string             `xorm:"INDEX"`
+		DisplayName string             `xorm:"NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type SCIMGroupMember struct {
+		ID      int64 `xorm:"pk autoincr"`
+		GroupID int64 `xorm:"UNIQUE(s) NOT NULL"`
+		UserID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	}
+
+	return x.Sync(new(SCIMToken), new(SCIMGroup), new(SCIMGroupMember))
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add security_advisory table",
+		Upgrade:     addSecurityAdvisory,
+	})
+}
+
+type v14bSecurityAdvisoryAffected struct {
+	Ecosystem string `json:",omitempty"`
+	Package   string
+	Versions  string
+	Patched   string `json:",omitempty"`
+}
+
+type v14bSecurityAdvisoryCredit struct {
+	Name string
+	Type string
+}
+
+type v14bSecurityAdvisory struct {
+	ID          int64  `xorm:"pk autoincr"`
+	RepoID      int64  `xorm:"INDEX NOT NULL"`
+	Identifier  string `xorm:"VARCHAR(50) UNIQUE NOT NULL"`
+	AuthorID    int64  `xorm:"NOT NULL DEFAULT 0"`
+	Title       string `xorm:"NOT NULL"`
+	Description string `xorm:"TEXT"`
+
+	Severity   string                         `xorm:"VARCHAR(20) NOT NULL"`
+	CVSSVector string                         `xorm:"'cvss_vector' VARCHAR(255)"`
+	CVEID      string                         `xorm:"'cve_id' VARCHAR(50) INDEX"`
+	Affected   []v14bSecurityAdvisoryAffected `xorm:"JSON TEXT"`
+	Credits    []v14bSecurityAdvisoryCredit   `xorm:"JSON TEXT"`
+
+	State       int    `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ForkRepoID  int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	FixCommitID string `xorm:"VARCHAR(64)"`
+
+	PublishedUnix timeutil.TimeStamp `xorm:"INDEX"`
+	CreatedUnix   timeutil.TimeStamp `xorm:"INDEX created"`
+	UpdatedUnix   timeutil.TimeStamp `xorm:"INDEX updated"`
+}
+
+// TableName sets the name of this table
+func (v14bSecurityAdvisory) TableName() string {
+	return "security_advisory"
+}
+
+func addSecurityAdvisory(x *xorm.Engine) error {
+	return x.Sync(new(v14bSecurityAdvisory))
+}
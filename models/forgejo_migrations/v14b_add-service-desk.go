@@ -0,0 +1,34 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add service_desk_contact table and is_confidential to issue",
+		Upgrade:     addServiceDesk,
+	})
+}
+
+func addServiceDesk(x *xorm.Engine) error {
+	type Issue struct {
+		IsConfidential bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	type ServiceDeskContact struct {
+		ID          int64              `xorm:"pk autoincr"`
+		IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+		Email       string             `xorm:"NOT NULL"`
+		Name        string             `xorm:"NOT NULL DEFAULT ''"`
+		Key         string             `xorm:"UNIQUE NOT NULL"`
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(Issue), new(ServiceDeskContact))
+}
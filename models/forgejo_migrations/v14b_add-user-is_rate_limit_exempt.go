@@ -0,0 +1,23 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add is_rate_limit_exempt to user",
+		Upgrade:     addUserIsRateLimitExempt,
+	})
+}
+
+func addUserIsRateLimitExempt(x *xorm.Engine) error {
+	type User struct {
+		IsRateLimitExempt bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync(new(User))
+}
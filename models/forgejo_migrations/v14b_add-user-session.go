@@ -0,0 +1,34 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package forgejo_migrations
+
+import (
+	"forgejo.org/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func init() {
+	registerMigration(&Migration{
+		Description: "add user_session table",
+		Upgrade:     addUserSession,
+	})
+}
+
+func addUserSession(x *xorm.Engine) error {
+	type UserSession struct {
+		ID           int64              `xorm:"pk autoincr"`
+		UID          int64              `xorm:"INDEX NOT NULL"`
+		KeyHash      string             `xorm:"VARCHAR(64) UNIQUE NOT NULL"`
+		AuthTokenKey string             `xorm:"VARCHAR(64) INDEX"`
+		LoginMethod  string             `xorm:"VARCHAR(32)"`
+		UserAgent    string             `xorm:"TEXT"`
+		IPAddress    string             `xorm:"VARCHAR(64)"`
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+		LastSeenUnix timeutil.TimeStamp `xorm:"INDEX"`
+		RevokedUnix  timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(UserSession))
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	blob_model "forgejo.org/models/blob"
 	"forgejo.org/models/db"
 	"forgejo.org/models/perm"
 	repo_model "forgejo.org/models/repo"
@@ -154,6 +155,9 @@ func NewLFSMetaObject(ctx context.Context, repoID int64, p lfs.Pointer) (*LFSMet
 	if err = db.Insert(ctx, m); err != nil {
 		return nil, err
 	}
+	if err = blob_model.AddReference(ctx, p.Oid, p.Size); err != nil {
+		return nil, err
+	}
 
 	return m, committer.Commit()
 }
@@ -196,8 +200,12 @@ func RemoveLFSMetaObjectByOidFn(ctx context.Context, repoID int64, oid string, f
 	defer committer.Close()
 
 	m := &LFSMetaObject{Pointer: lfs.Pointer{Oid: oid}, RepositoryID: repoID}
-	if _, err := db.DeleteByBean(ctx, m); err != nil {
+	if deleted, err := db.DeleteByBean(ctx, m); err != nil {
 		return -1, err
+	} else if deleted > 0 {
+		if err := blob_model.RemoveReference(ctx, oid); err != nil {
+			return -1, err
+		}
 	}
 
 	count, err := db.CountByBean(ctx, &LFSMetaObject{Pointer: lfs.Pointer{Oid: oid}})
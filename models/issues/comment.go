@@ -826,6 +826,7 @@ func CreateComment(ctx context.Context, opts *CreateCommentOptions) (_ *Comment,
 		Type:             opts.Type,
 		PosterID:         opts.Doer.ID,
 		Poster:           opts.Doer,
+		OriginalAuthor:   opts.OriginalAuthor,
 		IssueID:          opts.Issue.ID,
 		LabelID:          LabelID,
 		OldMilestoneID:   opts.OldMilestoneID,
@@ -1015,6 +1016,8 @@ type CreateCommentOptions struct {
 	Issue *Issue
 	Label *Label
 
+	OriginalAuthor string // name of the author when the doer is not a user of the instance
+
 	DependentIssueID int64
 	OldMilestoneID   int64
 	MilestoneID      int64
@@ -1060,17 +1063,18 @@ func GetCommentByID(ctx context.Context, id int64) (*Comment, error) {
 // FindCommentsOptions describes the conditions to Find comments
 type FindCommentsOptions struct {
 	db.ListOptions
-	RepoID      int64
-	IssueID     int64
-	ReviewID    int64
-	Since       int64
-	Before      int64
-	Line        int64
-	TreePath    string
-	Type        CommentType
-	IssueIDs    []int64
-	Invalidated optional.Option[bool]
-	IsPull      optional.Option[bool]
+	RepoID       int64
+	IssueID      int64
+	ReviewID     int64
+	Since        int64
+	Before       int64
+	Line         int64
+	TreePath     string
+	Type         CommentType
+	IssueIDs     []int64
+	Invalidated  optional.Option[bool]
+	IsPull       optional.Option[bool]
+	Confidential *ConfidentialFilter // only supported with RepoID, nil does not restrict the comments of confidential issues
 }
 
 // ToConds implements FindOptions interface
@@ -1108,6 +1112,9 @@ func (opts FindCommentsOptions) ToConds() builder.Cond {
 	if opts.IsPull.Has() {
 		cond = cond.And(builder.Eq{"issue.is_pull": opts.IsPull.Value()})
 	}
+	if opts.Confidential != nil {
+		cond = cond.And(confidentialCond(opts.Confidential))
+	}
 	return cond
 }
 
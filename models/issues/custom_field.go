@@ -0,0 +1,484 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// CustomFieldType is the type of the values of a custom field
+type CustomFieldType string
+
+const (
+	CustomFieldTypeText   CustomFieldType = "text"
+	CustomFieldTypeNumber CustomFieldType = "number"
+	CustomFieldTypeDate   CustomFieldType = "date"
+	CustomFieldTypeSelect CustomFieldType = "select"
+	CustomFieldTypeUser   CustomFieldType = "user"
+)
+
+// CustomFieldTypes are all the types of custom fields
+var CustomFieldTypes = []CustomFieldType{CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeDate, CustomFieldTypeSelect, CustomFieldTypeUser}
+
+// IsValid returns whether the type is known
+func (t CustomFieldType) IsValid() bool {
+	return slices.Contains(CustomFieldTypes, t)
+}
+
+// ErrCustomFieldNotExist represents a "CustomFieldNotExist" kind of error.
+type ErrCustomFieldNotExist struct {
+	ID int64
+}
+
+// IsErrCustomFieldNotExist checks if an error is a ErrCustomFieldNotExist.
+func IsErrCustomFieldNotExist(err error) bool {
+	_, ok := err.(ErrCustomFieldNotExist)
+	return ok
+}
+
+func (err ErrCustomFieldNotExist) Error() string {
+	return fmt.Sprintf("custom field does not exist [id: %d]", err.ID)
+}
+
+func (err ErrCustomFieldNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// CustomField is a typed field of issues, defined either for the issues of a
+// repository or for the issues of an organization project.
+type CustomField struct {
+	ID        int64           `xorm:"pk autoincr"`
+	RepoID    int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	ProjectID int64           `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Name      string          `xorm:"NOT NULL"`
+	Type      CustomFieldType `xorm:"VARCHAR(16) NOT NULL"`
+	// Options are the values of a single-select field
+	Options     []string           `xorm:"TEXT JSON"`
+	Sorting     int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// CustomFieldValue is the value of a custom field for an issue. Value is the
+// normalized value, SortValue orders numbers and dates.
+type CustomFieldValue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	FieldID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	IssueID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Value       string             `xorm:"VARCHAR(255) INDEX NOT NULL"`
+	SortValue   float64            `xorm:"NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+
+	Field *CustomField     `xorm:"-"`
+	User  *user_model.User `xorm:"-"`
+}
+
+func init() {
+	db.RegisterModel(new(CustomField))
+	db.RegisterModel(new(CustomFieldValue))
+}
+
+// customFieldDateLayout is the layout of the values of date fields
+const customFieldDateLayout = time.DateOnly
+
+func (field *CustomField) validate() error {
+	field.Name = strings.TrimSpace(field.Name)
+	if field.Name == "" || len(field.Name) > 50 {
+		return util.NewInvalidArgumentErrorf("the name of a custom field must have between 1 and 50 characters")
+	}
+	if !field.Type.IsValid() {
+		return util.NewInvalidArgumentErrorf("unknown custom field type: %s", field.Type)
+	}
+	if (field.RepoID == 0) == (field.ProjectID == 0) {
+		return util.NewInvalidArgumentErrorf("a custom field belongs to either a repository or a project")
+	}
+	if field.Type != CustomFieldTypeSelect {
+		field.Options = nil
+		return nil
+	}
+
+	options := make([]string, 0, len(field.Options))
+	for _, option := range field.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || slices.Contains(options, option) {
+			continue
+		}
+		if len(option) > 255 {
+			return util.NewInvalidArgumentErrorf("the options of a custom field must have at most 255 characters")
+		}
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		return util.NewInvalidArgumentErrorf("a single-select field needs options")
+	}
+	field.Options = options
+	return nil
+}
+
+// NormalizeValue checks a value for the field and returns its stored form and sort value.
+// The value of a user field is the name of the user and stored as the ID of the user.
+func (field *CustomField) NormalizeValue(ctx context.Context, value string) (string, float64, error) {
+	value = strings.TrimSpace(value)
+	switch field.Type {
+	case CustomFieldTypeText:
+		if len(value) > 255 {
+			return "", 0, util.NewInvalidArgumentErrorf("the value of %s must have at most 255 characters", field.Name)
+		}
+		return value, 0, nil
+	case CustomFieldTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", 0, util.NewInvalidArgumentErrorf("the value of %s must be a number", field.Name)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), number, nil
+	case CustomFieldTypeDate:
+		date, err := time.Parse(customFieldDateLayout, value)
+		if err != nil {
+			return "", 0, util.NewInvalidArgumentErrorf("the value of %s must be a date formatted as YYYY-MM-DD", field.Name)
+		}
+		return date.Format(customFieldDateLayout), float64(date.Unix()), nil
+	case CustomFieldTypeSelect:
+		if !slices.Contains(field.Options, value) {
+			return "", 0, util.NewInvalidArgumentErrorf("%q is not an option of %s", value, field.Name)
+		}
+		return value, float64(slices.Index(field.Options, value)), nil
+	case CustomFieldTypeUser:
+		u, err := user_model.GetUserByName(ctx, value)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				return "", 0, util.NewInvalidArgumentErrorf("the user %s of %s does not exist", value, field.Name)
+			}
+			return "", 0, err
+		}
+		return strconv.FormatInt(u.ID, 10), float64(u.ID), nil
+	}
+	return "", 0, util.NewInvalidArgumentErrorf("unknown custom field type: %s", field.Type)
+}
+
+// DisplayValue returns the value as shown to and entered by users
+func (v *CustomFieldValue) DisplayValue() string {
+	if v.Field != nil && v.Field.Type == CustomFieldTypeUser {
+		if v.User != nil {
+			return v.User.Name
+		}
+		return user_model.GhostUserName
+	}
+	return v.Value
+}
+
+// NewCustomField creates a custom field
+func NewCustomField(ctx context.Context, field *CustomField) error {
+	if err := field.validate(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, field)
+}
+
+// UpdateCustomField updates the name, the options and the sorting of a custom field, its type can't be changed
+func UpdateCustomField(ctx context.Context, field *CustomField) error {
+	if err := field.validate(); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(field.ID).Cols("name", "options", "sorting").Update(field); err != nil {
+			return err
+		}
+		if field.Type != CustomFieldTypeSelect {
+			return nil
+		}
+		// values of removed options are removed, the others are sorted as the options
+		values := make([]*CustomFieldValue, 0, 10)
+		if err := db.GetEngine(ctx).Where("field_id = ?", field.ID).Find(&values); err != nil {
+			return err
+		}
+		for _, v := range values {
+			idx := slices.Index(field.Options, v.Value)
+			if idx < 0 {
+				if _, err := db.DeleteByID[CustomFieldValue](ctx, v.ID); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := db.GetEngine(ctx).ID(v.ID).Cols("sort_value").Update(&CustomFieldValue{SortValue: float64(idx)}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteCustomField deletes a custom field and its values
+func DeleteCustomField(ctx context.Context, field *CustomField) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where("field_id = ?", field.ID).Delete(&CustomFieldValue{}); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[CustomField](ctx, field.ID)
+		return err
+	})
+}
+
+// GetCustomFieldByID returns a custom field by its ID
+func GetCustomFieldByID(ctx context.Context, id int64) (*CustomField, error) {
+	field, exist, err := db.GetByID[CustomField](ctx, id)
+	if err != nil {
+		return nil, err
+	} else if !exist {
+		return nil, ErrCustomFieldNotExist{id}
+	}
+	return field, nil
+}
+
+// GetCustomFieldInRepoByID returns a custom field of a repository by its ID
+func GetCustomFieldInRepoByID(ctx context.Context, repoID, id int64) (*CustomField, error) {
+	field, err := GetCustomFieldByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if field.RepoID != repoID {
+		return nil, ErrCustomFieldNotExist{id}
+	}
+	return field, nil
+}
+
+// GetCustomFieldInProjectByID returns a custom field of a project by its ID
+func GetCustomFieldInProjectByID(ctx context.Context, projectID, id int64) (*CustomField, error) {
+	field, err := GetCustomFieldByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if field.ProjectID != projectID {
+		return nil, ErrCustomFieldNotExist{id}
+	}
+	return field, nil
+}
+
+// FindCustomFieldOptions are the options to list custom fields
+type FindCustomFieldOptions struct {
+	db.ListOptions
+	RepoID     int64
+	ProjectIDs []int64
+}
+
+func (opts FindCustomFieldOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.Or(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if len(opts.ProjectIDs) > 0 {
+		cond = cond.Or(builder.In("project_id", opts.ProjectIDs))
+	}
+	if opts.RepoID == 0 && len(opts.ProjectIDs) == 0 {
+		return builder.Expr("1 = 0")
+	}
+	return cond
+}
+
+func (opts FindCustomFieldOptions) ToOrders() string {
+	return "project_id ASC, sorting ASC, id ASC"
+}
+
+// CustomFieldList is a list of custom fields
+type CustomFieldList []*CustomField
+
+// GetByID returns the field of the list with the ID
+func (fields CustomFieldList) GetByID(id int64) *CustomField {
+	for _, field := range fields {
+		if field.ID == id {
+			return field
+		}
+	}
+	return nil
+}
+
+// GetIssueCustomFields returns the custom fields of the issue: the fields of its
+// repository and the fields of the project it belongs to
+func GetIssueCustomFields(ctx context.Context, issue *Issue) (CustomFieldList, error) {
+	opts := FindCustomFieldOptions{RepoID: issue.RepoID}
+	if projectID := issue.projectID(ctx); projectID > 0 {
+		opts.ProjectIDs = []int64{projectID}
+	}
+	return db.Find[CustomField](ctx, opts)
+}
+
+// GetIssueCustomFieldValues returns the values of the custom fields of the issues, by issue ID
+func GetIssueCustomFieldValues(ctx context.Context, issueIDs []int64) (map[int64][]*CustomFieldValue, error) {
+	result := make(map[int64][]*CustomFieldValue, len(issueIDs))
+	if len(issueIDs) == 0 {
+		return result, nil
+	}
+
+	values := make([]*CustomFieldValue, 0, len(issueIDs))
+	if err := db.GetEngine(ctx).In("issue_id", issueIDs).Find(&values); err != nil {
+		return nil, err
+	}
+
+	fieldIDs := make([]int64, 0, len(values))
+	userIDs := make([]int64, 0, len(values))
+	for _, v := range values {
+		fieldIDs = append(fieldIDs, v.FieldID)
+	}
+	fields := make(map[int64]*CustomField, len(fieldIDs))
+	if err := db.GetEngine(ctx).In("id", fieldIDs).Find(&fields); err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		v.Field = fields[v.FieldID]
+		if v.Field != nil && v.Field.Type == CustomFieldTypeUser {
+			if id, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+	userList, err := user_model.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[int64]*user_model.User, len(userList))
+	for _, u := range userList {
+		users[u.ID] = u
+	}
+
+	for _, v := range values {
+		if v.Field == nil {
+			continue
+		}
+		if v.Field.Type == CustomFieldTypeUser {
+			id, _ := strconv.ParseInt(v.Value, 10, 64)
+			v.User = users[id]
+		}
+		result[v.IssueID] = append(result[v.IssueID], v)
+	}
+	for _, issueValues := range result {
+		slices.SortFunc(issueValues, func(a, b *CustomFieldValue) int {
+			return cmp.Or(
+				cmp.Compare(a.Field.ProjectID, b.Field.ProjectID),
+				cmp.Compare(a.Field.Sorting, b.Field.Sorting),
+				cmp.Compare(a.Field.ID, b.Field.ID),
+			)
+		})
+	}
+	return result, nil
+}
+
+// SetIssueCustomFieldValue sets the value of the custom field for the issue, an empty value removes it
+func SetIssueCustomFieldValue(ctx context.Context, issue *Issue, field *CustomField, value string) error {
+	if strings.TrimSpace(value) == "" {
+		_, err := db.GetEngine(ctx).Where("field_id = ? AND issue_id = ?", field.ID, issue.ID).Delete(&CustomFieldValue{})
+		return err
+	}
+
+	normalized, sortValue, err := field.NormalizeValue(ctx, value)
+	if err != nil {
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing := new(CustomFieldValue)
+		has, err := db.GetEngine(ctx).Where("field_id = ? AND issue_id = ?", field.ID, issue.ID).Get(existing)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return db.Insert(ctx, &CustomFieldValue{FieldID: field.ID, IssueID: issue.ID, Value: normalized, SortValue: sortValue})
+		}
+		existing.Value = normalized
+		existing.SortValue = sortValue
+		_, err = db.GetEngine(ctx).ID(existing.ID).Cols("value", "sort_value").Update(existing)
+		return err
+	})
+}
+
+// CustomFieldFilter restricts a search to the issues with a value of a custom field,
+// an empty value matching the issues without value
+type CustomFieldFilter struct {
+	FieldID int64
+	Value   string
+}
+
+// ParseCustomFieldFilter parses a filter formatted as "<field id>:<value>"
+func ParseCustomFieldFilter(s string) (CustomFieldFilter, bool) {
+	id, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return CustomFieldFilter{}, false
+	}
+	fieldID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || fieldID <= 0 {
+		return CustomFieldFilter{}, false
+	}
+	return CustomFieldFilter{FieldID: fieldID, Value: value}, true
+}
+
+// NormalizeCustomFieldFilters converts the values of the filters to their stored form,
+// a user field can be filtered by user name. Filters of unknown fields are kept as they are.
+func NormalizeCustomFieldFilters(ctx context.Context, filters []CustomFieldFilter) ([]CustomFieldFilter, error) {
+	normalized := make([]CustomFieldFilter, 0, len(filters))
+	for _, filter := range filters {
+		field, err := GetCustomFieldByID(ctx, filter.FieldID)
+		if err != nil && !IsErrCustomFieldNotExist(err) {
+			return nil, err
+		}
+		if field != nil && filter.Value != "" {
+			if filter.Value, _, err = field.NormalizeValue(ctx, filter.Value); err != nil {
+				// no issue has an invalid value
+				filter.Value = "\x00"
+			}
+		}
+		normalized = append(normalized, filter)
+	}
+	return normalized, nil
+}
+
+// CustomFieldSortType returns the sort type of IssuesOptions ordering issues by the value of a custom field
+func CustomFieldSortType(fieldID int64, desc bool) string {
+	if desc {
+		return fmt.Sprintf("customfield-%d-desc", fieldID)
+	}
+	return fmt.Sprintf("customfield-%d", fieldID)
+}
+
+// ParseCustomFieldSortType returns the field and the order of a custom field sort type
+func ParseCustomFieldSortType(sortType string) (fieldID int64, desc, ok bool) {
+	s, found := strings.CutPrefix(sortType, "customfield-")
+	if !found {
+		return 0, false, false
+	}
+	s, desc = strings.CutSuffix(s, "-desc")
+	fieldID, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || fieldID <= 0 {
+		return 0, false, false
+	}
+	return fieldID, desc, true
+}
+
+// DeleteProjectCustomFields deletes the custom fields of a project and their values
+func DeleteProjectCustomFields(ctx context.Context, projectID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		fieldIDs := make([]int64, 0, 10)
+		if err := db.GetEngine(ctx).Table("custom_field").Where("project_id = ?", projectID).Cols("id").Find(&fieldIDs); err != nil {
+			return err
+		}
+		if len(fieldIDs) == 0 {
+			return nil
+		}
+		if _, err := db.GetEngine(ctx).In("field_id", fieldIDs).Delete(&CustomFieldValue{}); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).In("id", fieldIDs).Delete(&CustomField{})
+		return err
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCustomFieldFilter(t *testing.T) {
+	filter, ok := issues_model.ParseCustomFieldFilter("3:high:er")
+	assert.True(t, ok)
+	assert.Equal(t, issues_model.CustomFieldFilter{FieldID: 3, Value: "high:er"}, filter)
+
+	filter, ok = issues_model.ParseCustomFieldFilter("3:")
+	assert.True(t, ok)
+	assert.Empty(t, filter.Value)
+
+	for _, s := range []string{"", "3", "a:b", "0:b", "-1:b"} {
+		_, ok = issues_model.ParseCustomFieldFilter(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestParseCustomFieldSortType(t *testing.T) {
+	fieldID, desc, ok := issues_model.ParseCustomFieldSortType(issues_model.CustomFieldSortType(7, true))
+	assert.True(t, ok)
+	assert.EqualValues(t, 7, fieldID)
+	assert.True(t, desc)
+
+	fieldID, desc, ok = issues_model.ParseCustomFieldSortType("customfield-7")
+	assert.True(t, ok)
+	assert.EqualValues(t, 7, fieldID)
+	assert.False(t, desc)
+
+	for _, s := range []string{"latest", "customfield-", "customfield-x", "customfield-0-desc"} {
+		_, _, ok = issues_model.ParseCustomFieldSortType(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestCustomFieldNormalizeValue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	test := func(field *issues_model.CustomField, value, expected string, expectedSort float64) {
+		t.Helper()
+		normalized, sortValue, err := field.NormalizeValue(db.DefaultContext, value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, normalized)
+		assert.InDelta(t, expectedSort, sortValue, 0)
+	}
+	testInvalid := func(field *issues_model.CustomField, value string) {
+		t.Helper()
+		_, _, err := field.NormalizeValue(db.DefaultContext, value)
+		require.Error(t, err)
+	}
+
+	number := &issues_model.CustomField{Name: "Estimate", Type: issues_model.CustomFieldTypeNumber}
+	test(number, " 2.50 ", "2.5", 2.5)
+	testInvalid(number, "two")
+
+	date := &issues_model.CustomField{Name: "Due", Type: issues_model.CustomFieldTypeDate}
+	test(date, "2026-01-02", "2026-01-02", 1767312000)
+	testInvalid(date, "02/01/2026")
+
+	sel := &issues_model.CustomField{Name: "Priority", Type: issues_model.CustomFieldTypeSelect, Options: []string{"high", "low"}}
+	test(sel, "low", "low", 1)
+	testInvalid(sel, "medium")
+
+	user := &issues_model.CustomField{Name: "Customer", Type: issues_model.CustomFieldTypeUser}
+	test(user, "user2", "2", 2)
+	testInvalid(user, "user-does-not-exist")
+}
+
+func TestNewCustomField(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	field := &issues_model.CustomField{RepoID: 1, Name: " Priority ", Type: issues_model.CustomFieldTypeSelect, Options: []string{"high", " ", "low", "high"}}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, field))
+	field = unittest.AssertExistsAndLoadBean(t, &issues_model.CustomField{ID: field.ID})
+	assert.Equal(t, "Priority", field.Name)
+	assert.Equal(t, []string{"high", "low"}, field.Options)
+
+	require.Error(t, issues_model.NewCustomField(db.DefaultContext, &issues_model.CustomField{RepoID: 1, Name: "Options", Type: issues_model.CustomFieldTypeSelect}))
+	require.Error(t, issues_model.NewCustomField(db.DefaultContext, &issues_model.CustomField{RepoID: 1, Name: "Unknown", Type: "color"}))
+	require.Error(t, issues_model.NewCustomField(db.DefaultContext, &issues_model.CustomField{RepoID: 1, ProjectID: 1, Name: "Both", Type: issues_model.CustomFieldTypeText}))
+	require.Error(t, issues_model.NewCustomField(db.DefaultContext, &issues_model.CustomField{RepoID: 1, Type: issues_model.CustomFieldTypeText}))
+}
+
+func TestIssueCustomFieldValues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	priority := &issues_model.CustomField{RepoID: 1, Name: "Priority", Type: issues_model.CustomFieldTypeSelect, Options: []string{"high", "low"}}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, priority))
+	estimate := &issues_model.CustomField{ProjectID: 1, Name: "Estimate", Type: issues_model.CustomFieldTypeNumber}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, estimate))
+	other := &issues_model.CustomField{RepoID: 2, Name: "Other", Type: issues_model.CustomFieldTypeText}
+	require.NoError(t, issues_model.NewCustomField(db.DefaultContext, other))
+
+	issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue5 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+
+	t.Run("Fields", func(t *testing.T) {
+		// issue 1 is in project 1, issue 5 isn't in a project
+		fields, err := issues_model.GetIssueCustomFields(db.DefaultContext, issue1)
+		require.NoError(t, err)
+		require.Len(t, fields, 2)
+		assert.Equal(t, priority.ID, fields[0].ID)
+		assert.Equal(t, estimate.ID, fields[1].ID)
+
+		fields, err = issues_model.GetIssueCustomFields(db.DefaultContext, issue5)
+		require.NoError(t, err)
+		require.Len(t, fields, 1)
+		assert.Equal(t, priority.ID, fields[0].ID)
+	})
+
+	require.NoError(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, issue1, priority, "low"))
+	require.NoError(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, issue1, estimate, "3"))
+	require.NoError(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, issue5, priority, "high"))
+	require.Error(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, issue5, priority, "medium"))
+
+	t.Run("Values", func(t *testing.T) {
+		values, err := issues_model.GetIssueCustomFieldValues(db.DefaultContext, []int64{1, 5})
+		require.NoError(t, err)
+		require.Len(t, values[1], 2)
+		assert.Equal(t, "low", values[1][0].DisplayValue())
+		assert.Equal(t, "3", values[1][1].DisplayValue())
+		require.Len(t, values[5], 1)
+		assert.Equal(t, "high", values[5][0].DisplayValue())
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		issues, err := issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:      []int64{1},
+			CustomFields: []issues_model.CustomFieldFilter{{FieldID: priority.ID, Value: "high"}},
+		})
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.EqualValues(t, 5, issues[0].ID)
+
+		// issues without a value
+		issues, err = issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:      []int64{1},
+			CustomFields: []issues_model.CustomFieldFilter{{FieldID: priority.ID}},
+		})
+		require.NoError(t, err)
+		for _, issue := range issues {
+			assert.NotContains(t, []int64{1, 5}, issue.ID)
+		}
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		issues, err := issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:  []int64{1},
+			SortType: issues_model.CustomFieldSortType(priority.ID, false),
+		})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(issues), 2)
+		// "high" is the first option, the issues without value come last
+		assert.EqualValues(t, 5, issues[0].ID)
+		assert.EqualValues(t, 1, issues[1].ID)
+
+		issues, err = issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:  []int64{1},
+			SortType: issues_model.CustomFieldSortType(priority.ID, true),
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, issues[0].ID)
+		assert.EqualValues(t, 5, issues[1].ID)
+	})
+
+	t.Run("UpdateOptions", func(t *testing.T) {
+		priority.Options = []string{"low", "medium"}
+		require.NoError(t, issues_model.UpdateCustomField(db.DefaultContext, priority))
+		unittest.AssertNotExistsBean(t, &issues_model.CustomFieldValue{FieldID: priority.ID, IssueID: 5})
+		value := unittest.AssertExistsAndLoadBean(t, &issues_model.CustomFieldValue{FieldID: priority.ID, IssueID: 1})
+		assert.InDelta(t, 0, value.SortValue, 0)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, issues_model.SetIssueCustomFieldValue(db.DefaultContext, issue1, estimate, ""))
+		unittest.AssertNotExistsBean(t, &issues_model.CustomFieldValue{FieldID: estimate.ID})
+
+		require.NoError(t, issues_model.DeleteCustomField(db.DefaultContext, priority))
+		unittest.AssertNotExistsBean(t, &issues_model.CustomField{ID: priority.ID})
+		unittest.AssertNotExistsBean(t, &issues_model.CustomFieldValue{FieldID: priority.ID})
+
+		require.NoError(t, issues_model.DeleteProjectCustomFields(db.DefaultContext, 1))
+		unittest.AssertNotExistsBean(t, &issues_model.CustomField{ID: estimate.ID})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.CustomField{ID: other.ID})
+	})
+}
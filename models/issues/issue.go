@@ -134,6 +134,9 @@ type Issue struct {
 	// with write access
 	IsLocked bool `xorm:"NOT NULL DEFAULT false"`
 
+	// IsConfidential restricts the visibility of an issue to users with write access
+	IsConfidential bool `xorm:"NOT NULL DEFAULT false"`
+
 	// For view issue page.
 	ShowRole RoleDescriptor `xorm:"-"`
 }
@@ -0,0 +1,124 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	access_model "forgejo.org/models/perm/access"
+	user_model "forgejo.org/models/user"
+
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// ConfidentialFilter restricts the confidential issues found by a search to the ones a user can see
+type ConfidentialFilter struct {
+	UserID  int64   // the confidential issues posted by or assigned to this user are found
+	RepoIDs []int64 // all the confidential issues of these repositories are found
+}
+
+// IsVisibleTo returns whether a user with the given permission on the repository of the issue can see it.
+// A confidential issue is only visible to its poster, its assignees and the users with write access.
+func (issue *Issue) IsVisibleTo(ctx context.Context, user *user_model.User, perm access_model.Permission) (bool, error) {
+	if !perm.CanReadIssuesOrPulls(issue.IsPull) {
+		return false, nil
+	}
+	if !issue.IsConfidential || perm.CanWriteIssuesOrPulls(issue.IsPull) {
+		return true, nil
+	}
+	if user == nil || user.ID <= 0 {
+		return false, nil
+	}
+	if user.IsAdmin || issue.PosterID == user.ID {
+		return true, nil
+	}
+	return IsUserAssignedToIssue(ctx, issue, user)
+}
+
+// IsIssueVisibleToUser loads the permission of a user on the repository of the issue and returns whether the user can see it
+func IsIssueVisibleToUser(ctx context.Context, issue *Issue, user *user_model.User) (bool, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return false, err
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, user)
+	if err != nil {
+		return false, err
+	}
+	return issue.IsVisibleTo(ctx, user, perm)
+}
+
+// FilterVisibleTo returns the issues of the list without the confidential issues that a user can't see
+func (issues IssueList) FilterVisibleTo(ctx context.Context, user *user_model.User) (IssueList, error) {
+	visible := make(IssueList, 0, len(issues))
+	perms := make(map[int64]access_model.Permission)
+	for _, issue := range issues {
+		if !issue.IsConfidential {
+			visible = append(visible, issue)
+			continue
+		}
+		perm, has := perms[issue.RepoID]
+		if !has {
+			if err := issue.LoadRepo(ctx); err != nil {
+				return nil, err
+			}
+			var err error
+			if perm, err = access_model.GetUserRepoPermission(ctx, issue.Repo, user); err != nil {
+				return nil, err
+			}
+			perms[issue.RepoID] = perm
+		}
+		ok, err := issue.IsVisibleTo(ctx, user, perm)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			visible = append(visible, issue)
+		}
+	}
+	return visible, nil
+}
+
+// ConfidentialIssueUserIDs returns the IDs of the users who can see a confidential issue without write access
+func ConfidentialIssueUserIDs(ctx context.Context, issue *Issue) ([]int64, error) {
+	if !issue.IsConfidential {
+		return nil, nil
+	}
+	ids := make([]int64, 0, 2)
+	if err := db.GetEngine(ctx).Table("issue_assignees").Where("issue_id = ?", issue.ID).Cols("assignee_id").Find(&ids); err != nil {
+		return nil, err
+	}
+	if issue.PosterID > 0 {
+		ids = append(ids, issue.PosterID)
+	}
+	return ids, nil
+}
+
+// UpdateIssueConfidential changes whether an issue is confidential
+func UpdateIssueConfidential(ctx context.Context, issue *Issue, confidential bool) error {
+	issue.IsConfidential = confidential
+	_, err := db.GetEngine(ctx).ID(issue.ID).Cols("is_confidential").NoAutoTime().Update(issue)
+	return err
+}
+
+func confidentialCond(filter *ConfidentialFilter) builder.Cond {
+	cond := builder.NewCond().Or(builder.Eq{"issue.is_confidential": false})
+	if filter.UserID > 0 {
+		cond = cond.Or(
+			builder.Eq{"issue.poster_id": filter.UserID},
+			builder.In("issue.id", builder.Select("issue_id").From("issue_assignees").Where(builder.Eq{"assignee_id": filter.UserID})),
+		)
+	}
+	if len(filter.RepoIDs) > 0 {
+		cond = cond.Or(builder.In("issue.repo_id", filter.RepoIDs))
+	}
+	return cond
+}
+
+func applyConfidentialCondition(sess *xorm.Session, opts *IssuesOptions) {
+	if opts.Confidential != nil {
+		sess.And(confidentialCond(opts.Confidential))
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfidentialIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// issue 1 of the public user2/repo1 is posted by and assigned to user1
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	reader := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 5})
+
+	isVisible := func(t *testing.T, user *user_model.User) bool {
+		t.Helper()
+		visible, err := issues_model.IsIssueVisibleToUser(db.DefaultContext, issue, user)
+		require.NoError(t, err)
+		return visible
+	}
+	searchIDs := func(t *testing.T, filter *issues_model.ConfidentialFilter) []int64 {
+		t.Helper()
+		ids, _, err := issues_model.IssueIDs(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:      []int64{1},
+			Confidential: filter,
+		})
+		require.NoError(t, err)
+		return ids
+	}
+
+	assert.True(t, isVisible(t, reader))
+	assert.True(t, isVisible(t, nil))
+	assert.Contains(t, searchIDs(t, &issues_model.ConfidentialFilter{UserID: reader.ID}), issue.ID)
+
+	require.NoError(t, issues_model.UpdateIssueConfidential(db.DefaultContext, issue, true))
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID, IsConfidential: true})
+
+	t.Run("Visibility", func(t *testing.T) {
+		assert.True(t, isVisible(t, owner))
+		assert.False(t, isVisible(t, reader))
+		assert.False(t, isVisible(t, nil))
+
+		visible, err := issues_model.IssueList{issue}.FilterVisibleTo(db.DefaultContext, reader)
+		require.NoError(t, err)
+		assert.Empty(t, visible)
+
+		ids, err := issues_model.ConfidentialIssueUserIDs(db.DefaultContext, issue)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []int64{1, 1}, ids)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		assert.Contains(t, searchIDs(t, nil), issue.ID)
+		assert.NotContains(t, searchIDs(t, &issues_model.ConfidentialFilter{}), issue.ID)
+		assert.NotContains(t, searchIDs(t, &issues_model.ConfidentialFilter{UserID: reader.ID}), issue.ID)
+		// the poster and the repositories with write access
+		assert.Contains(t, searchIDs(t, &issues_model.ConfidentialFilter{UserID: 1}), issue.ID)
+		assert.Contains(t, searchIDs(t, &issues_model.ConfidentialFilter{UserID: reader.ID, RepoIDs: []int64{1}}), issue.ID)
+	})
+
+	t.Run("Assignee", func(t *testing.T) {
+		require.NoError(t, db.Insert(db.DefaultContext, &issues_model.IssueAssignees{IssueID: issue.ID, AssigneeID: reader.ID}))
+
+		assert.True(t, isVisible(t, reader))
+		assert.Contains(t, searchIDs(t, &issues_model.ConfidentialFilter{UserID: reader.ID}), issue.ID)
+	})
+}
@@ -78,6 +78,10 @@ func LoadIssuesFromColumn(ctx context.Context, b *project_model.Column, doer *us
 		issueList = append(issueList, issues...)
 	}
 
+	if issueList, err = issueList.FilterVisibleTo(ctx, doer); err != nil {
+		return nil, err
+	}
+
 	if err := issueList.LoadComments(ctx); err != nil {
 		return nil, err
 	}
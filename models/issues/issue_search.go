@@ -39,9 +39,14 @@ type IssuesOptions struct { //nolint
 	IsClosed           optional.Option[bool]
 	IsPull             optional.Option[bool]
 	LabelIDs           []int64
+	LabelIDGroups      [][]int64 // groups of labels, the issues have at least one label of every group
 	IncludedLabelNames []string
 	ExcludedLabelNames []string
 	IncludeMilestones  []string
+	CustomFields       []CustomFieldFilter
+	ParentID           int64 // the issues must be sub-issues of this issue
+	HasSubIssues       optional.Option[bool]
+	Confidential       *ConfidentialFilter // nil does not restrict the confidential issues found
 	SortType           string
 	IssueIDs           []int64
 	UpdatedAfterUnix   int64
@@ -108,6 +113,16 @@ func applySorts(sess *xorm.Session, sortType string, priorityRepoID, priorityIss
 	case "project-column-sorting":
 		sess.Asc("project_issue.sorting").Desc("issue.created_unix").Desc("issue.id")
 	default:
+		if fieldID, desc, ok := ParseCustomFieldSortType(sortType); ok {
+			// issues without value come last
+			sess.Join("LEFT", "custom_field_value", "custom_field_value.issue_id = issue.id AND custom_field_value.field_id = ?", fieldID).
+				OrderBy("CASE WHEN custom_field_value.id IS NULL THEN 1 ELSE 0 END ASC")
+			if desc {
+				sess.Desc("custom_field_value.sort_value").Desc("custom_field_value.value")
+			} else {
+				sess.Asc("custom_field_value.sort_value").Asc("custom_field_value.value")
+			}
+		}
 		sess.Desc("issue.created_unix").Desc("issue.id")
 	}
 }
@@ -156,6 +171,10 @@ func applyLabelsCondition(sess *xorm.Session, opts *IssuesOptions) {
 		}
 	}
 
+	for _, group := range opts.LabelIDGroups {
+		sess.In("issue.id", builder.Select("issue_id").From("issue_label").Where(builder.In("label_id", group)))
+	}
+
 	if len(opts.IncludedLabelNames) > 0 {
 		sess.In("issue.id", BuildLabelNamesIssueIDsCondition(opts.IncludedLabelNames))
 	}
@@ -165,6 +184,29 @@ func applyLabelsCondition(sess *xorm.Session, opts *IssuesOptions) {
 	}
 }
 
+func applyCustomFieldsCondition(sess *xorm.Session, opts *IssuesOptions) {
+	for _, filter := range opts.CustomFields {
+		if filter.Value == "" {
+			sess.And(builder.NotIn("issue.id", builder.Select("issue_id").From("custom_field_value").Where(builder.Eq{"field_id": filter.FieldID})))
+		} else {
+			sess.In("issue.id", builder.Select("issue_id").From("custom_field_value").Where(builder.Eq{"field_id": filter.FieldID, "value": filter.Value}))
+		}
+	}
+}
+
+func applySubIssuesCondition(sess *xorm.Session, opts *IssuesOptions) {
+	if opts.ParentID > 0 {
+		sess.In("issue.id", builder.Select("issue_id").From("sub_issue").Where(builder.Eq{"parent_id": opts.ParentID}))
+	}
+	if opts.HasSubIssues.Has() {
+		if opts.HasSubIssues.Value() {
+			sess.In("issue.id", builder.Select("parent_id").From("sub_issue"))
+		} else {
+			sess.And(builder.NotIn("issue.id", builder.Select("parent_id").From("sub_issue")))
+		}
+	}
+}
+
 func applyMilestoneCondition(sess *xorm.Session, opts *IssuesOptions) {
 	if len(opts.MilestoneIDs) == 1 && opts.MilestoneIDs[0] == db.NoConditionID {
 		sess.And("issue.milestone_id = 0")
@@ -279,6 +321,12 @@ func applyConditions(sess *xorm.Session, opts *IssuesOptions) {
 
 	applyLabelsCondition(sess, opts)
 
+	applyCustomFieldsCondition(sess, opts)
+
+	applySubIssuesCondition(sess, opts)
+
+	applyConfidentialCondition(sess, opts)
+
 	if opts.User != nil {
 		cond := issuePullAccessibleRepoCond("issue.repo_id", opts.User.ID, opts.Org, opts.Team, opts.IsPull.Value())
 		// If AllPublic was set, then also consider all issues in public
@@ -709,6 +709,17 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&SubIssue{})
+		if err != nil {
+			return nil, err
+		}
+
+		// Delete sub-issues in other repositories
+		_, err = sess.In("parent_id", issueIDs).Delete(&SubIssue{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&IssueUser{})
 		if err != nil {
 			return nil, err
@@ -724,6 +735,11 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&ServiceDeskContact{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("issue_id", issueIDs).Delete(&Stopwatch{})
 		if err != nil {
 			return nil, err
@@ -739,6 +755,11 @@ func DeleteIssuesByRepoID(ctx context.Context, repoID int64) (attachmentPaths []
 			return nil, err
 		}
 
+		_, err = sess.In("issue_id", issueIDs).Delete(&CustomFieldValue{})
+		if err != nil {
+			return nil, err
+		}
+
 		_, err = sess.In("dependent_issue_id", issueIDs).Delete(&Comment{})
 		if err != nil {
 			return nil, err
@@ -280,6 +280,21 @@ func (c *Comment) LoadRefIssue(ctx context.Context) (err error) {
 	return err
 }
 
+// IsRefIssueVisibleTo returns whether a user can see the confidential issue where this reference was created,
+// it is always true if the referring issue isn't confidential
+func (c *Comment) IsRefIssueVisibleTo(ctx context.Context, user *user_model.User) (bool, error) {
+	if !CommentTypeIsRef(c.Type) {
+		return true, nil
+	}
+	if err := c.LoadRefIssue(ctx); err != nil {
+		return false, err
+	}
+	if !c.RefIssue.IsConfidential {
+		return true, nil
+	}
+	return IsIssueVisibleToUser(ctx, c.RefIssue, user)
+}
+
 // CommentTypeIsRef returns true if CommentType is a reference from another issue
 func CommentTypeIsRef(t CommentType) bool {
 	return t == CommentTypeCommentRef || t == CommentTypePullRef || t == CommentTypeIssueRef
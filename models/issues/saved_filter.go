@@ -0,0 +1,116 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ErrSavedFilterNotExist represents a "SavedFilterNotExist" kind of error.
+type ErrSavedFilterNotExist struct {
+	ID int64
+}
+
+// IsErrSavedFilterNotExist checks if an error is a ErrSavedFilterNotExist.
+func IsErrSavedFilterNotExist(err error) bool {
+	_, ok := err.(ErrSavedFilterNotExist)
+	return ok
+}
+
+func (err ErrSavedFilterNotExist) Error() string {
+	return fmt.Sprintf("saved filter does not exist [id: %d]", err.ID)
+}
+
+func (err ErrSavedFilterNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// SavedFilter is a search of the issues or pull requests of the dashboard saved by a user
+type SavedFilter struct {
+	ID     int64  `xorm:"pk autoincr"`
+	UserID int64  `xorm:"INDEX NOT NULL"`
+	Name   string `xorm:"NOT NULL"`
+	// Query is the keyword of the search, in the issue search query language
+	Query  string `xorm:"TEXT NOT NULL"`
+	IsPull bool   `xorm:"NOT NULL DEFAULT false"`
+	// ViewType is the filter of the dashboard: created_by, assigned, your_repositories...
+	ViewType string `xorm:"VARCHAR(20)"`
+
+	// LastViewedUnix is the last time the user viewed the search, the issues
+	// updated since then are counted as unread
+	LastViewedUnix timeutil.TimeStamp
+	CreatedUnix    timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SavedFilter))
+}
+
+// Link returns the link of the search on the dashboard
+func (f *SavedFilter) Link() string {
+	page := "/issues"
+	if f.IsPull {
+		page = "/pulls"
+	}
+	query := url.Values{
+		"type":         {f.ViewType},
+		"q":            {f.Query},
+		"saved_filter": {strconv.FormatInt(f.ID, 10)},
+	}
+	return setting.AppSubURL + page + "?" + query.Encode()
+}
+
+// CreateSavedFilter creates a saved filter
+func CreateSavedFilter(ctx context.Context, f *SavedFilter) error {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" || len(f.Name) > 50 {
+		return util.NewInvalidArgumentErrorf("the name of a saved filter must have between 1 and 50 characters")
+	}
+	f.Query = strings.TrimSpace(f.Query)
+	if f.Query == "" {
+		return util.NewInvalidArgumentErrorf("the query of a saved filter cannot be empty")
+	}
+	f.LastViewedUnix = timeutil.TimeStampNow()
+	return db.Insert(ctx, f)
+}
+
+// GetSavedFilterByUserIDAndID returns a saved filter of a user
+func GetSavedFilterByUserIDAndID(ctx context.Context, userID, id int64) (*SavedFilter, error) {
+	f := new(SavedFilter)
+	has, err := db.GetEngine(ctx).Where("id = ? AND user_id = ?", id, userID).Get(f)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrSavedFilterNotExist{ID: id}
+	}
+	return f, nil
+}
+
+// GetSavedFilters returns the saved filters of the issues or pull requests of a user
+func GetSavedFilters(ctx context.Context, userID int64, isPull bool) ([]*SavedFilter, error) {
+	filters := make([]*SavedFilter, 0, 5)
+	return filters, db.GetEngine(ctx).Where("user_id = ? AND is_pull = ?", userID, isPull).OrderBy("name").Find(&filters)
+}
+
+// MarkSavedFilterViewed marks the issues of a saved filter as read
+func MarkSavedFilterViewed(ctx context.Context, f *SavedFilter) error {
+	f.LastViewedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(f.ID).Cols("last_viewed_unix").Update(f)
+	return err
+}
+
+// DeleteSavedFilter deletes a saved filter
+func DeleteSavedFilter(ctx context.Context, f *SavedFilter) error {
+	_, err := db.DeleteByID[SavedFilter](ctx, f.ID)
+	return err
+}
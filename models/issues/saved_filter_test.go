@@ -0,0 +1,51 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"fmt"
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedFilters(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	f := &issues_model.SavedFilter{UserID: 2, Name: " bugs ", Query: "label:bug", ViewType: "assigned"}
+	require.NoError(t, issues_model.CreateSavedFilter(db.DefaultContext, f))
+	assert.Equal(t, "bugs", f.Name)
+	assert.NotZero(t, f.LastViewedUnix)
+	assert.Equal(t, fmt.Sprintf("/issues?q=label%%3Abug&saved_filter=%d&type=assigned", f.ID), f.Link())
+	require.NoError(t, issues_model.CreateSavedFilter(db.DefaultContext, &issues_model.SavedFilter{UserID: 2, Name: "mine", Query: "author:@me", IsPull: true}))
+
+	err := issues_model.CreateSavedFilter(db.DefaultContext, &issues_model.SavedFilter{UserID: 2, Name: " ", Query: "label:bug"})
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+	err = issues_model.CreateSavedFilter(db.DefaultContext, &issues_model.SavedFilter{UserID: 2, Name: "empty", Query: ""})
+	require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	filters, err := issues_model.GetSavedFilters(db.DefaultContext, 2, false)
+	require.NoError(t, err)
+	require.Len(t, filters, 1)
+	assert.Equal(t, f.ID, filters[0].ID)
+
+	_, err = issues_model.GetSavedFilterByUserIDAndID(db.DefaultContext, 3, f.ID)
+	assert.True(t, issues_model.IsErrSavedFilterNotExist(err))
+
+	f.LastViewedUnix = 0
+	require.NoError(t, issues_model.MarkSavedFilterViewed(db.DefaultContext, f))
+	got, err := issues_model.GetSavedFilterByUserIDAndID(db.DefaultContext, 2, f.ID)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, got.LastViewedUnix, timeutil.TimeStampNow()-1)
+
+	require.NoError(t, issues_model.DeleteSavedFilter(db.DefaultContext, got))
+	unittest.AssertNotExistsBean(t, &issues_model.SavedFilter{ID: f.ID})
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ServiceDeskContact is the external sender of an issue created from an email
+// sent to the service desk address of a repository. The contact keeps
+// replying to the issue by email through an address containing its key.
+type ServiceDeskContact struct {
+	ID          int64              `xorm:"pk autoincr"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	Email       string             `xorm:"NOT NULL"`
+	Name        string             `xorm:"NOT NULL DEFAULT ''"`
+	Key         string             `xorm:"UNIQUE NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ServiceDeskContact))
+}
+
+// DisplayName returns the name of the contact followed by its email address
+func (c *ServiceDeskContact) DisplayName() string {
+	if c.Name == "" {
+		return c.Email
+	}
+	return fmt.Sprintf("%s <%s>", c.Name, c.Email)
+}
+
+// NewServiceDeskContact creates the contact of an issue with a new random key
+func NewServiceDeskContact(ctx context.Context, c *ServiceDeskContact) error {
+	// the key is part of an email address, whose case may not be preserved
+	c.Key = hex.EncodeToString(util.CryptoRandomBytes(16))
+	return db.Insert(ctx, c)
+}
+
+// GetServiceDeskContact returns the contact of an issue created by the service desk, if any
+func GetServiceDeskContact(ctx context.Context, issueID int64) (*ServiceDeskContact, bool, error) {
+	c := new(ServiceDeskContact)
+	has, err := db.GetEngine(ctx).Where("issue_id = ?", issueID).Get(c)
+	if err != nil || !has {
+		return nil, false, err
+	}
+	return c, true, nil
+}
+
+// GetServiceDeskContactByKey returns the contact having the given key
+func GetServiceDeskContactByKey(ctx context.Context, key string) (*ServiceDeskContact, error) {
+	c := new(ServiceDeskContact)
+	has, err := db.GetEngine(ctx).Where("`key` = ?", strings.ToLower(key)).Get(c)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, util.NewNotExistErrorf("service desk contact does not exist")
+	}
+	return c, nil
+}
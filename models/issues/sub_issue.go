@@ -0,0 +1,214 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues
+
+import (
+	"context"
+	"fmt"
+
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ErrSubIssueHasParent represents a "SubIssueHasParent" kind of error.
+type ErrSubIssueHasParent struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueHasParent checks if an error is a ErrSubIssueHasParent.
+func IsErrSubIssueHasParent(err error) bool {
+	_, ok := err.(ErrSubIssueHasParent)
+	return ok
+}
+
+func (err ErrSubIssueHasParent) Error() string {
+	return fmt.Sprintf("issue already has a parent [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueHasParent) Unwrap() error {
+	return util.ErrAlreadyExist
+}
+
+// ErrCircularSubIssue represents a "CircularSubIssue" kind of error.
+type ErrCircularSubIssue struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrCircularSubIssue checks if an error is a ErrCircularSubIssue.
+func IsErrCircularSubIssue(err error) bool {
+	_, ok := err.(ErrCircularSubIssue)
+	return ok
+}
+
+func (err ErrCircularSubIssue) Error() string {
+	return fmt.Sprintf("sub-issue would be an ancestor of its parent [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrCircularSubIssue) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ErrSubIssueNotExist represents a "SubIssueNotExist" kind of error.
+type ErrSubIssueNotExist struct {
+	IssueID  int64
+	ParentID int64
+}
+
+// IsErrSubIssueNotExist checks if an error is a ErrSubIssueNotExist.
+func IsErrSubIssueNotExist(err error) bool {
+	_, ok := err.(ErrSubIssueNotExist)
+	return ok
+}
+
+func (err ErrSubIssueNotExist) Error() string {
+	return fmt.Sprintf("issue is not a sub-issue of the parent [issue id: %d, parent id: %d]", err.IssueID, err.ParentID)
+}
+
+func (err ErrSubIssueNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// SubIssue represents an issue which is a child of another issue. An issue has
+// at most one parent, which is an issue of a repository of the same owner.
+type SubIssue struct {
+	ID          int64              `xorm:"pk autoincr"`
+	ParentID    int64              `xorm:"INDEX NOT NULL"`
+	IssueID     int64              `xorm:"UNIQUE NOT NULL"`
+	UserID      int64              `xorm:"NOT NULL"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(SubIssue))
+}
+
+// SubIssueProgress is the progress of the sub-issues of an issue
+type SubIssueProgress struct {
+	Total          int
+	Closed         int
+	TrackedSeconds int64
+}
+
+// Percent returns the percentage of the closed sub-issues
+func (p *SubIssueProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Closed * 100 / p.Total
+}
+
+// GetParentIssueID returns the ID of the parent of an issue, 0 if it has none
+func GetParentIssueID(ctx context.Context, issueID int64) (int64, error) {
+	var parentID int64
+	_, err := db.GetEngine(ctx).Table("sub_issue").Where("issue_id = ?", issueID).Cols("parent_id").Get(&parentID)
+	return parentID, err
+}
+
+// AddSubIssue makes an issue a sub-issue of the parent
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, issue *Issue) error {
+	if parent.ID == issue.ID {
+		return util.NewInvalidArgumentErrorf("an issue cannot be its own sub-issue")
+	}
+	if parent.IsPull || issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests cannot have or be sub-issues")
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if parent.Repo.OwnerID != issue.Repo.OwnerID {
+		return util.NewInvalidArgumentErrorf("a sub-issue must belong to a repository of the owner of its parent")
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		parentID, err := GetParentIssueID(ctx, issue.ID)
+		if err != nil {
+			return err
+		}
+		if parentID != 0 {
+			return ErrSubIssueHasParent{IssueID: issue.ID, ParentID: parentID}
+		}
+
+		// the issue must not be an ancestor of its new parent
+		for ancestorID := parent.ID; ancestorID != 0; {
+			if ancestorID == issue.ID {
+				return ErrCircularSubIssue{IssueID: issue.ID, ParentID: parent.ID}
+			}
+			if ancestorID, err = GetParentIssueID(ctx, ancestorID); err != nil {
+				return err
+			}
+		}
+
+		return db.Insert(ctx, &SubIssue{
+			ParentID: parent.ID,
+			IssueID:  issue.ID,
+			UserID:   doer.ID,
+		})
+	})
+}
+
+// RemoveSubIssue removes an issue from the sub-issues of the parent
+func RemoveSubIssue(ctx context.Context, parent, issue *Issue) error {
+	affected, err := db.GetEngine(ctx).Where("parent_id = ? AND issue_id = ?", parent.ID, issue.ID).Delete(&SubIssue{})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrSubIssueNotExist{IssueID: issue.ID, ParentID: parent.ID}
+	}
+	return nil
+}
+
+// GetParentIssue returns the parent of an issue, nil if it has none
+func GetParentIssue(ctx context.Context, issue *Issue) (*Issue, error) {
+	parentID, err := GetParentIssueID(ctx, issue.ID)
+	if err != nil || parentID == 0 {
+		return nil, err
+	}
+	return GetIssueByID(ctx, parentID)
+}
+
+// GetSubIssues returns the sub-issues of an issue with their repositories
+func GetSubIssues(ctx context.Context, parentID int64) (IssueList, error) {
+	issues := make(IssueList, 0, 10)
+	if err := db.GetEngine(ctx).
+		Join("INNER", "sub_issue", "sub_issue.issue_id = issue.id").
+		Where("sub_issue.parent_id = ?", parentID).
+		OrderBy("sub_issue.id").
+		Find(&issues); err != nil {
+		return nil, err
+	}
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// HasSubIssues returns whether an issue has sub-issues
+func HasSubIssues(ctx context.Context, issueID int64) (bool, error) {
+	return db.GetEngine(ctx).Where("parent_id = ?", issueID).Exist(&SubIssue{})
+}
+
+// GetSubIssueProgress returns the progress of sub-issues: how many of them are closed
+// and the time tracked on them
+func GetSubIssueProgress(ctx context.Context, issues IssueList) (*SubIssueProgress, error) {
+	progress := &SubIssueProgress{Total: len(issues)}
+	if len(issues) == 0 {
+		return progress, nil
+	}
+	for _, issue := range issues {
+		if issue.IsClosed {
+			progress.Closed++
+		}
+	}
+	var err error
+	progress.TrackedSeconds, err = GetTrackedSeconds(ctx, FindTrackedTimesOptions{IssueIDs: issues.getIssueIDs()})
+	return progress, err
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issues_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	// issues 1 and 5 belong to user2/repo1, issues 4 and 7 to user2/repo2
+	parent := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue4 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 4})
+	issue5 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	issue7 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7})
+
+	t.Run("Add", func(t *testing.T) {
+		require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, parent, issue5))
+		require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, parent, issue4))
+		require.NoError(t, issues_model.AddSubIssue(db.DefaultContext, doer, issue4, issue7))
+
+		subIssues, err := issues_model.GetSubIssues(db.DefaultContext, parent.ID)
+		require.NoError(t, err)
+		require.Len(t, subIssues, 2)
+		assert.EqualValues(t, 5, subIssues[0].ID)
+		assert.EqualValues(t, 4, subIssues[1].ID)
+		assert.NotNil(t, subIssues[1].Repo)
+
+		got, err := issues_model.GetParentIssue(db.DefaultContext, issue7)
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, got.ID)
+
+		got, err = issues_model.GetParentIssue(db.DefaultContext, parent)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+
+		has, err := issues_model.HasSubIssues(db.DefaultContext, issue4.ID)
+		require.NoError(t, err)
+		assert.True(t, has)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		err := issues_model.AddSubIssue(db.DefaultContext, doer, parent, parent)
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+		err = issues_model.AddSubIssue(db.DefaultContext, doer, issue7, parent)
+		assert.True(t, issues_model.IsErrCircularSubIssue(err))
+
+		err = issues_model.AddSubIssue(db.DefaultContext, doer, issue7, issue5)
+		assert.True(t, issues_model.IsErrSubIssueHasParent(err))
+
+		// a pull request
+		pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+		err = issues_model.AddSubIssue(db.DefaultContext, doer, parent, pull)
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+
+		// an issue of user3/repo3
+		other := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 6})
+		err = issues_model.AddSubIssue(db.DefaultContext, doer, parent, other)
+		require.ErrorIs(t, err, util.ErrInvalidArgument)
+	})
+
+	t.Run("Progress", func(t *testing.T) {
+		subIssues, err := issues_model.GetSubIssues(db.DefaultContext, parent.ID)
+		require.NoError(t, err)
+		progress, err := issues_model.GetSubIssueProgress(db.DefaultContext, subIssues)
+		require.NoError(t, err)
+		assert.Equal(t, 2, progress.Total)
+		assert.Equal(t, 2, progress.Closed)
+		assert.EqualValues(t, 1+1+3+71, progress.TrackedSeconds)
+		assert.Equal(t, 100, progress.Percent())
+
+		progress, err = issues_model.GetSubIssueProgress(db.DefaultContext, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, progress.Percent())
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		issues, err := issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:  []int64{1, 2},
+			ParentID: parent.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, issues, 2)
+		assert.ElementsMatch(t, []int64{4, 5}, []int64{issues[0].ID, issues[1].ID})
+
+		issues, err = issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:      []int64{1, 2},
+			HasSubIssues: optional.Some(true),
+		})
+		require.NoError(t, err)
+		require.Len(t, issues, 2)
+		assert.ElementsMatch(t, []int64{1, 4}, []int64{issues[0].ID, issues[1].ID})
+
+		issues, err = issues_model.Issues(db.DefaultContext, &issues_model.IssuesOptions{
+			RepoIDs:      []int64{1, 2},
+			HasSubIssues: optional.Some(false),
+		})
+		require.NoError(t, err)
+		for _, issue := range issues {
+			assert.NotContains(t, []int64{1, 4}, issue.ID)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		require.NoError(t, issues_model.RemoveSubIssue(db.DefaultContext, parent, issue5))
+		unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: issue5.ID})
+
+		err := issues_model.RemoveSubIssue(db.DefaultContext, parent, issue5)
+		assert.True(t, issues_model.IsErrSubIssueNotExist(err))
+	})
+}
@@ -87,6 +87,7 @@ func (tl TrackedTimeList) LoadAttributes(ctx context.Context) error {
 type FindTrackedTimesOptions struct {
 	db.ListOptions
 	IssueID           int64
+	IssueIDs          []int64
 	UserID            int64
 	RepositoryID      int64
 	MilestoneID       int64
@@ -100,6 +101,9 @@ func (opts *FindTrackedTimesOptions) ToConds() builder.Cond {
 	if opts.IssueID != 0 {
 		cond = cond.And(builder.Eq{"issue_id": opts.IssueID})
 	}
+	if len(opts.IssueIDs) > 0 {
+		cond = cond.And(builder.In("issue_id", opts.IssueIDs))
+	}
 	if opts.UserID != 0 {
 		cond = cond.And(builder.Eq{"user_id": opts.UserID})
 	}
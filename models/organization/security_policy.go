@@ -0,0 +1,203 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package organization
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// SSHKeyTypes are the SSH key types an organization can restrict the keys of its members to,
+// as named by asymkey.SSHNativeParsePublicKey
+var SSHKeyTypes = []string{"ed25519", "ed25519-sk", "ecdsa", "ecdsa-sk", "rsa", "dsa"}
+
+// OrgSecurityPolicy is the security policy an organization enforces for its members
+type OrgSecurityPolicy struct {
+	ID               int64 `xorm:"pk autoincr"`
+	OrgID            int64 `xorm:"UNIQUE NOT NULL"`
+	RequireTwoFactor bool  `xorm:"NOT NULL DEFAULT false"`
+	// SSHKeyTypes are the allowed types of SSH keys, any type is allowed when empty
+	SSHKeyTypes   []string `xorm:"TEXT JSON"`
+	MinRSAKeySize int      `xorm:"NOT NULL DEFAULT 0"`
+	// IPAllowlist are the IP addresses and CIDR ranges members can access the organization from,
+	// any address is allowed when empty
+	IPAllowlist []string           `xorm:"TEXT JSON"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(OrgSecurityPolicy))
+}
+
+// IsEnforced returns whether the policy restricts anything
+func (policy *OrgSecurityPolicy) IsEnforced() bool {
+	return policy.RequireTwoFactor || policy.RestrictsSSHKeys() || len(policy.IPAllowlist) > 0
+}
+
+// RestrictsSSHKeys returns whether the policy restricts the SSH keys of the members
+func (policy *OrgSecurityPolicy) RestrictsSSHKeys() bool {
+	return len(policy.SSHKeyTypes) > 0 || policy.MinRSAKeySize > 0
+}
+
+// IsIPAllowed returns whether the remote address, with or without port, is in the IP allowlist
+func (policy *OrgSecurityPolicy) IsIPAllowed(remoteAddr string) bool {
+	if len(policy.IPAllowlist) == 0 {
+		return true
+	}
+	ip := net.ParseIP(remoteHost(remoteAddr))
+	if ip == nil {
+		return false
+	}
+	for _, entry := range policy.IPAllowlist {
+		if _, ipNet, err := net.ParseCIDR(entry); err == nil {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		} else if allowed := net.ParseIP(entry); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteHost strips the port of a remote address
+func remoteHost(remoteAddr string) string {
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		return host
+	}
+	return remoteAddr
+}
+
+// IsSSHKeyAllowed returns whether the type and length of the public key satisfy the policy
+func (policy *OrgSecurityPolicy) IsSSHKeyAllowed(key *asymkey_model.PublicKey) bool {
+	if !policy.RestrictsSSHKeys() {
+		return true
+	}
+	return asymkey_model.CheckPublicKeyPolicy(key.Content, policy.SSHKeyTypes, policy.MinRSAKeySize) == nil
+}
+
+// ParseIPAllowlist parses one IP address or CIDR range per line, ignoring empty lines
+func ParseIPAllowlist(s string) ([]string, error) {
+	var allowlist []string
+	for _, line := range strings.Split(s, "\n") {
+		entry := strings.TrimSpace(line)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			_, ipNet, err := net.ParseCIDR(entry)
+			if err != nil {
+				return nil, util.NewInvalidArgumentErrorf("invalid CIDR range: %s", entry)
+			}
+			entry = ipNet.String()
+		} else if ip := net.ParseIP(entry); ip == nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid IP address: %s", entry)
+		} else {
+			entry = ip.String()
+		}
+		if !slices.Contains(allowlist, entry) {
+			allowlist = append(allowlist, entry)
+		}
+	}
+	return allowlist, nil
+}
+
+// GetOrgSecurityPolicy returns the security policy of the organization, an empty one if it has none
+func GetOrgSecurityPolicy(ctx context.Context, orgID int64) (*OrgSecurityPolicy, error) {
+	policy := &OrgSecurityPolicy{OrgID: orgID}
+	if _, err := db.GetEngine(ctx).Where("org_id = ?", orgID).Get(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// UpdateOrgSecurityPolicy creates or updates the security policy of an organization
+func UpdateOrgSecurityPolicy(ctx context.Context, policy *OrgSecurityPolicy) error {
+	for _, keyType := range policy.SSHKeyTypes {
+		if !slices.Contains(SSHKeyTypes, keyType) {
+			return util.NewInvalidArgumentErrorf("unknown SSH key type: %s", keyType)
+		}
+	}
+	if policy.MinRSAKeySize < 0 {
+		return util.NewInvalidArgumentErrorf("invalid minimum RSA key size: %d", policy.MinRSAKeySize)
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		existing := new(OrgSecurityPolicy)
+		has, err := db.GetEngine(ctx).Where("org_id = ?", policy.OrgID).Get(existing)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return db.Insert(ctx, policy)
+		}
+		policy.ID = existing.ID
+		_, err = db.GetEngine(ctx).ID(policy.ID).AllCols().Update(policy)
+		return err
+	})
+}
+
+// SecurityPolicyViolations are what a member has to fix to comply with the security policy of an organization
+type SecurityPolicyViolations struct {
+	TwoFactor bool
+	// SSHKeys are the SSH keys of the member which are not allowed
+	SSHKeys []*asymkey_model.PublicKey
+	// RemoteAddr is set when the member accesses the organization from an address which is not allowed,
+	// it is "unknown" when the address of the member is not known
+	RemoteAddr string
+}
+
+const unknownRemoteAddr = "unknown"
+
+// IsEmpty returns whether the member complies with the policy
+func (violations *SecurityPolicyViolations) IsEmpty() bool {
+	return !violations.TwoFactor && len(violations.SSHKeys) == 0 && violations.RemoteAddr == ""
+}
+
+// CheckMember returns what the user has to fix to comply with the policy when accessing from the remote address
+func (policy *OrgSecurityPolicy) CheckMember(ctx context.Context, u *user_model.User, remoteAddr string) (*SecurityPolicyViolations, error) {
+	violations := &SecurityPolicyViolations{}
+	if policy.RequireTwoFactor {
+		hasTwoFactor, err := auth_model.HasTwoFactorByUID(ctx, u.ID)
+		if err != nil {
+			return nil, err
+		}
+		violations.TwoFactor = !hasTwoFactor
+	}
+
+	if policy.RestrictsSSHKeys() {
+		keys, err := db.Find[asymkey_model.PublicKey](ctx, asymkey_model.FindPublicKeyOptions{
+			OwnerID:  u.ID,
+			KeyTypes: []asymkey_model.KeyType{asymkey_model.KeyTypeUser},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if !policy.IsSSHKeyAllowed(key) {
+				violations.SSHKeys = append(violations.SSHKeys, key)
+			}
+		}
+	}
+
+	if !policy.IsIPAllowed(remoteAddr) {
+		log.Debug("Member %s of organization %d accesses it from %s which is not allowed", u.Name, policy.OrgID, remoteAddr)
+		violations.RemoteAddr = remoteHost(remoteAddr)
+		if violations.RemoteAddr == "" {
+			// an unknown address must still be denied
+			violations.RemoteAddr = unknownRemoteAddr
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package organization_test
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIPAllowlist(t *testing.T) {
+	allowlist, err := organization.ParseIPAllowlist("192.0.2.1\n\n 198.51.100.7/24 \n2001:db8::1\n192.0.2.1\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.1", "198.51.100.0/24", "2001:db8::1"}, allowlist)
+
+	_, err = organization.ParseIPAllowlist("192.0.2.300")
+	require.Error(t, err)
+	_, err = organization.ParseIPAllowlist("192.0.2.0/33")
+	require.Error(t, err)
+}
+
+func TestOrgSecurityPolicyIsIPAllowed(t *testing.T) {
+	policy := &organization.OrgSecurityPolicy{}
+	assert.True(t, policy.IsIPAllowed("203.0.113.5"))
+
+	policy.IPAllowlist = []string{"192.0.2.1", "198.51.100.0/24", "2001:db8::/32"}
+	assert.True(t, policy.IsIPAllowed("192.0.2.1"))
+	assert.True(t, policy.IsIPAllowed("192.0.2.1:2222"))
+	assert.True(t, policy.IsIPAllowed("198.51.100.42"))
+	assert.True(t, policy.IsIPAllowed("[2001:db8::5]:22"))
+	assert.False(t, policy.IsIPAllowed("192.0.2.2"))
+	assert.False(t, policy.IsIPAllowed("203.0.113.5:443"))
+	assert.False(t, policy.IsIPAllowed(""))
+}
+
+func TestOrgSecurityPolicy(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	policy, err := organization.GetOrgSecurityPolicy(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.False(t, policy.IsEnforced())
+
+	policy.RequireTwoFactor = true
+	policy.SSHKeyTypes = []string{"ed25519", "rsa"}
+	policy.MinRSAKeySize = 4096
+	require.NoError(t, organization.UpdateOrgSecurityPolicy(db.DefaultContext, policy))
+
+	policy, err = organization.GetOrgSecurityPolicy(db.DefaultContext, 3)
+	require.NoError(t, err)
+	assert.True(t, policy.IsEnforced())
+	assert.Equal(t, []string{"ed25519", "rsa"}, policy.SSHKeyTypes)
+
+	t.Run("CheckMember", func(t *testing.T) {
+		// the 3072 bits RSA key of user2 is too short and user2 has no two-factor authentication
+		user2 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+		violations, err := policy.CheckMember(db.DefaultContext, user2, "192.0.2.1")
+		require.NoError(t, err)
+		assert.True(t, violations.TwoFactor)
+		require.Len(t, violations.SSHKeys, 1)
+		assert.EqualValues(t, 1, violations.SSHKeys[0].ID)
+		assert.Empty(t, violations.RemoteAddr)
+
+		user24 := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 24})
+		violations, err = policy.CheckMember(db.DefaultContext, user24, "192.0.2.1")
+		require.NoError(t, err)
+		assert.True(t, violations.IsEmpty())
+
+		policy.IPAllowlist = []string{"198.51.100.0/24"}
+		violations, err = policy.CheckMember(db.DefaultContext, user24, "192.0.2.1:2222")
+		require.NoError(t, err)
+		assert.Equal(t, "192.0.2.1", violations.RemoteAddr)
+
+		// an unknown address is not allowed either
+		for _, remoteAddr := range []string{"", "not-an-address"} {
+			violations, err = policy.CheckMember(db.DefaultContext, user24, remoteAddr)
+			require.NoError(t, err)
+			assert.False(t, violations.IsEmpty())
+			assert.NotEmpty(t, violations.RemoteAddr)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		policy := &organization.OrgSecurityPolicy{OrgID: 3, MinRSAKeySize: 2048}
+		require.NoError(t, organization.UpdateOrgSecurityPolicy(db.DefaultContext, policy))
+		unittest.AssertCount(t, &organization.OrgSecurityPolicy{OrgID: 3}, 1)
+
+		policy, err := organization.GetOrgSecurityPolicy(db.DefaultContext, 3)
+		require.NoError(t, err)
+		assert.False(t, policy.RequireTwoFactor)
+		assert.Empty(t, policy.SSHKeyTypes)
+		assert.Equal(t, 2048, policy.MinRSAKeySize)
+
+		policy.SSHKeyTypes = []string{"ssh-rsa"}
+		require.Error(t, organization.UpdateOrgSecurityPolicy(db.DefaultContext, policy))
+	})
+}
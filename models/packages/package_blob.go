@@ -8,10 +8,12 @@ import (
 	"strconv"
 	"time"
 
+	blob_model "forgejo.org/models/blob"
 	"forgejo.org/models/db"
 	"forgejo.org/models/perm"
 	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
 	"forgejo.org/modules/structs"
 	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/util"
@@ -66,6 +68,9 @@ func GetOrInsertBlob(ctx context.Context, pb *PackageBlob) (*PackageBlob, bool,
 	if _, err = e.Insert(pb); err != nil {
 		return nil, false, err
 	}
+	if err = blob_model.AddReference(ctx, pb.HashSHA256, pb.Size); err != nil {
+		return nil, false, err
+	}
 	return pb, false, nil
 }
 
@@ -90,20 +95,34 @@ func ExistPackageBlobWithSHA(ctx context.Context, blobSha256 string) (bool, erro
 	})
 }
 
-// FindExpiredUnreferencedBlobs gets all blobs without associated files older than the specific duration
+// FindExpiredUnreferencedBlobs gets all blobs without associated files or SBOMs older than the specific duration
 func FindExpiredUnreferencedBlobs(ctx context.Context, olderThan time.Duration) ([]*PackageBlob, error) {
 	pbs := make([]*PackageBlob, 0, 10)
 	return pbs, db.GetEngine(ctx).
 		Table("package_blob").
 		Join("LEFT", "package_file", "package_file.blob_id = package_blob.id").
-		Where("package_file.id IS NULL AND package_blob.created_unix < ?", time.Now().Add(-olderThan).Unix()).
+		Join("LEFT", "package_sbom", "package_sbom.blob_id = package_blob.id").
+		Where("package_file.id IS NULL AND package_sbom.id IS NULL AND package_blob.created_unix < ?", time.Now().Add(-olderThan).Unix()).
 		Find(&pbs)
 }
 
 // DeleteBlobByID deletes a blob by id
 func DeleteBlobByID(ctx context.Context, blobID int64) error {
-	_, err := db.GetEngine(ctx).ID(blobID).Delete(&PackageBlob{})
-	return err
+	if !setting.Blobs.Enabled {
+		_, err := db.GetEngine(ctx).ID(blobID).Delete(&PackageBlob{})
+		return err
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		pb, err := GetBlobByID(ctx, blobID)
+		if err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).ID(blobID).Delete(&PackageBlob{}); err != nil {
+			return err
+		}
+		return blob_model.RemoveReference(ctx, pb.HashSHA256)
+	})
 }
 
 // GetTotalBlobSize returns the total blobs size in bytes
@@ -117,7 +136,8 @@ func GetTotalUnreferencedBlobSize(ctx context.Context) (int64, error) {
 	return db.GetEngine(ctx).
 		Table("package_blob").
 		Join("LEFT", "package_file", "package_file.blob_id = package_blob.id").
-		Where("package_file.id IS NULL").
+		Join("LEFT", "package_sbom", "package_sbom.blob_id = package_blob.id").
+		Where("package_file.id IS NULL AND package_sbom.id IS NULL").
 		SumInt(&PackageBlob{}, "size")
 }
 
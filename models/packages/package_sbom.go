@@ -0,0 +1,119 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"context"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrPackageSBOMNotExist indicates a package SBOM not exist error
+var ErrPackageSBOMNotExist = util.NewNotExistErrorf("package SBOM does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageSBOM))
+	db.RegisterModel(new(PackageComponent))
+}
+
+// PackageSBOM represents a software bill of materials attached to a package version
+type PackageSBOM struct {
+	ID          int64              `xorm:"pk autoincr"`
+	VersionID   int64              `xorm:"UNIQUE NOT NULL"`
+	BlobID      int64              `xorm:"INDEX NOT NULL"`
+	Format      string             `xorm:"NOT NULL"`
+	SpecVersion string             `xorm:"NOT NULL DEFAULT ''"`
+	CreatorID   int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// PackageComponent represents a dependency listed in the SBOM of a package version
+type PackageComponent struct {
+	ID              int64    `xorm:"pk autoincr"`
+	VersionID       int64    `xorm:"INDEX NOT NULL"`
+	Name            string   `xorm:"NOT NULL"`
+	LowerName       string   `xorm:"INDEX NOT NULL"`
+	Version         string   `xorm:"NOT NULL DEFAULT ''"`
+	LowerVersion    string   `xorm:"INDEX NOT NULL DEFAULT ''"`
+	PURL            string   `xorm:"purl INDEX NOT NULL DEFAULT ''"`
+	Type            string   `xorm:"NOT NULL DEFAULT ''"`
+	Licenses        []string `xorm:"JSON TEXT"`
+	Vulnerabilities []string `xorm:"JSON TEXT"`
+}
+
+// GetSBOMByVersionID gets the SBOM of a package version
+func GetSBOMByVersionID(ctx context.Context, versionID int64) (*PackageSBOM, error) {
+	ps := &PackageSBOM{}
+
+	has, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Get(ps)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageSBOMNotExist
+	}
+	return ps, nil
+}
+
+// InsertSBOM inserts the SBOM and its components
+func InsertSBOM(ctx context.Context, ps *PackageSBOM, pcs []*PackageComponent) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := db.Insert(ctx, ps); err != nil {
+			return err
+		}
+		for _, pc := range pcs {
+			pc.VersionID = ps.VersionID
+			pc.LowerName = strings.ToLower(pc.Name)
+			pc.LowerVersion = strings.ToLower(pc.Version)
+		}
+		// insert in batches to stay below the parameter limit of the databases
+		for len(pcs) > 0 {
+			n := min(len(pcs), 100)
+			if err := db.Insert(ctx, pcs[:n]); err != nil {
+				return err
+			}
+			pcs = pcs[n:]
+		}
+		return nil
+	})
+}
+
+// DeleteSBOMByVersionID deletes the SBOM and the components of a package version
+func DeleteSBOMByVersionID(ctx context.Context, versionID int64) error {
+	if _, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageComponent{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where("version_id = ?", versionID).Delete(&PackageSBOM{})
+	return err
+}
+
+// GetComponentsByVersionID gets all components of a package version
+func GetComponentsByVersionID(ctx context.Context, versionID int64) ([]*PackageComponent, error) {
+	pcs := make([]*PackageComponent, 0, 10)
+	return pcs, db.GetEngine(ctx).Where("version_id = ?", versionID).Asc("lower_name", "lower_version").Find(&pcs)
+}
+
+// ComponentSearchValue describes a component a package version must include
+type ComponentSearchValue struct {
+	Name    string // matches the component name or the package URL if it starts with "pkg:"
+	Version string
+}
+
+func (c ComponentSearchValue) toCond() builder.Cond {
+	cond := builder.Expr("package_component.version_id = package_version.id")
+	if strings.HasPrefix(c.Name, "pkg:") {
+		cond = cond.And(builder.Eq{"package_component.purl": c.Name})
+	} else {
+		cond = cond.And(builder.Eq{"package_component.lower_name": strings.ToLower(c.Name)})
+	}
+	if c.Version != "" {
+		cond = cond.And(builder.Eq{"package_component.lower_version": strings.ToLower(c.Version)})
+	}
+	return builder.Exists(builder.Select("package_component.id").From("package_component").Where(cond))
+}
@@ -186,6 +186,7 @@ type PackageSearchOptions struct {
 	IsInternal      optional.Option[bool]
 	HasFileWithName string                // only results are found which are associated with a file with the specific name
 	HasFiles        optional.Option[bool] // only results are found which have associated files
+	HasComponent    ComponentSearchValue  // only results are found which include the component in their SBOM
 	Sort            VersionSort
 	db.Paginator
 }
@@ -264,6 +265,10 @@ func (opts *PackageSearchOptions) ToConds() builder.Cond {
 		cond = cond.And(filesCond)
 	}
 
+	if opts.HasComponent.Name != "" {
+		cond = cond.And(opts.HasComponent.toCond())
+	}
+
 	return cond
 }
 
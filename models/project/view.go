@@ -0,0 +1,219 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models/db"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+// ViewType is the layout of a project view
+type ViewType string
+
+const (
+	// ViewTypeTable shows the issues of the project as the rows of a table
+	ViewTypeTable ViewType = "table"
+	// ViewTypeRoadmap shows the issues of the project on a timeline
+	ViewTypeRoadmap ViewType = "roadmap"
+)
+
+// Fields of the issues shown, grouped and sorted by a view. A custom field is
+// given as "customfield-<id>".
+const (
+	ViewFieldTitle     = "title"
+	ViewFieldState     = "state"
+	ViewFieldColumn    = "column"
+	ViewFieldAssignees = "assignees"
+	ViewFieldMilestone = "milestone"
+	ViewFieldLabels    = "labels"
+	ViewFieldDeadline  = "deadline"
+	ViewFieldCreated   = "created"
+	ViewFieldUpdated   = "updated"
+
+	viewCustomFieldPrefix = "customfield-"
+)
+
+var (
+	// ViewFields are the built-in fields that can be shown in a table view
+	ViewFields = []string{ViewFieldState, ViewFieldColumn, ViewFieldAssignees, ViewFieldMilestone, ViewFieldLabels, ViewFieldDeadline, ViewFieldCreated, ViewFieldUpdated}
+	// ViewGroupByFields are the built-in fields a view can group the issues by
+	ViewGroupByFields = []string{ViewFieldState, ViewFieldColumn, ViewFieldAssignees, ViewFieldMilestone}
+	// ViewSortByFields are the built-in fields a view can sort the issues by
+	ViewSortByFields = []string{ViewFieldTitle, ViewFieldState, ViewFieldColumn, ViewFieldMilestone, ViewFieldDeadline, ViewFieldCreated, ViewFieldUpdated}
+	// ViewDateFields are the built-in fields placing the issues on a roadmap
+	ViewDateFields = []string{ViewFieldMilestone, ViewFieldDeadline}
+)
+
+// ViewCustomField returns the view field of a custom field
+func ViewCustomField(fieldID int64) string {
+	return fmt.Sprintf("%s%d", viewCustomFieldPrefix, fieldID)
+}
+
+// ParseViewCustomField returns the ID of the custom field of a view field
+func ParseViewCustomField(field string) (int64, bool) {
+	s, ok := strings.CutPrefix(field, viewCustomFieldPrefix)
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+func isValidViewField(field string, builtin []string) bool {
+	if _, ok := ParseViewCustomField(field); ok {
+		return true
+	}
+	return slices.Contains(builtin, field)
+}
+
+// ErrViewNotExist represents a "ViewNotExist" kind of error.
+type ErrViewNotExist struct {
+	ID int64
+}
+
+// IsErrViewNotExist checks if an error is a ErrViewNotExist
+func IsErrViewNotExist(err error) bool {
+	_, ok := err.(ErrViewNotExist)
+	return ok
+}
+
+func (err ErrViewNotExist) Error() string {
+	return fmt.Sprintf("project view does not exist [id: %d]", err.ID)
+}
+
+func (err ErrViewNotExist) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// View is a saved table or roadmap view of a project, shared by all the users
+// who can see the project
+type View struct {
+	ID        int64    `xorm:"pk autoincr"`
+	ProjectID int64    `xorm:"INDEX NOT NULL"`
+	Name      string   `xorm:"NOT NULL"`
+	Type      ViewType `xorm:"VARCHAR(16) NOT NULL"`
+	// Fields are the fields shown in the columns of a table view
+	Fields    []string `xorm:"TEXT JSON"`
+	GroupBy   string   `xorm:"VARCHAR(50)"`
+	SortBy    string   `xorm:"VARCHAR(50)"`
+	SortDesc  bool     `xorm:"NOT NULL DEFAULT false"`
+	DateField string   `xorm:"VARCHAR(50)"`
+	CreatorID int64    `xorm:"NOT NULL"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// TableName return the real table name
+func (View) TableName() string {
+	return "project_view"
+}
+
+func init() {
+	db.RegisterModel(new(View))
+}
+
+// IsTable returns whether the view is a table
+func (v *View) IsTable() bool {
+	return v.Type == ViewTypeTable
+}
+
+// IsRoadmap returns whether the view is a roadmap
+func (v *View) IsRoadmap() bool {
+	return v.Type == ViewTypeRoadmap
+}
+
+func (v *View) validate() error {
+	v.Name = strings.TrimSpace(v.Name)
+	if v.Name == "" || len(v.Name) > 50 {
+		return util.NewInvalidArgumentErrorf("the name of a view must have between 1 and 50 characters")
+	}
+
+	switch v.Type {
+	case ViewTypeTable:
+		v.DateField = ""
+	case ViewTypeRoadmap:
+		if !isValidViewField(v.DateField, ViewDateFields) {
+			return util.NewInvalidArgumentErrorf("invalid date field: %s", v.DateField)
+		}
+	default:
+		return util.NewInvalidArgumentErrorf("invalid view type: %s", v.Type)
+	}
+
+	fields := make([]string, 0, len(v.Fields))
+	for _, field := range v.Fields {
+		if field == "" || slices.Contains(fields, field) {
+			continue
+		}
+		if !isValidViewField(field, ViewFields) {
+			return util.NewInvalidArgumentErrorf("invalid field: %s", field)
+		}
+		fields = append(fields, field)
+	}
+	v.Fields = fields
+
+	if v.GroupBy != "" && !isValidViewField(v.GroupBy, ViewGroupByFields) {
+		return util.NewInvalidArgumentErrorf("invalid group by field: %s", v.GroupBy)
+	}
+	if v.SortBy != "" && !isValidViewField(v.SortBy, ViewSortByFields) {
+		return util.NewInvalidArgumentErrorf("invalid sort by field: %s", v.SortBy)
+	}
+	return nil
+}
+
+// NewView creates a view of a project
+func NewView(ctx context.Context, v *View) error {
+	if err := v.validate(); err != nil {
+		return err
+	}
+	return db.Insert(ctx, v)
+}
+
+// UpdateView updates a view of a project
+func UpdateView(ctx context.Context, v *View) error {
+	if err := v.validate(); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(v.ID).Cols("name", "type", "fields", "group_by", "sort_by", "sort_desc", "date_field").Update(v)
+	return err
+}
+
+// DeleteView deletes a view of a project
+func DeleteView(ctx context.Context, v *View) error {
+	_, err := db.DeleteByID[View](ctx, v.ID)
+	return err
+}
+
+// GetViewByProjectIDAndID returns a view of a project
+func GetViewByProjectIDAndID(ctx context.Context, projectID, id int64) (*View, error) {
+	v := new(View)
+	has, err := db.GetEngine(ctx).Where("id = ? AND project_id = ?", id, projectID).Get(v)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrViewNotExist{ID: id}
+	}
+	return v, nil
+}
+
+// GetProjectViews returns the views of a project
+func GetProjectViews(ctx context.Context, projectID int64) ([]*View, error) {
+	views := make([]*View, 0, 5)
+	return views, db.GetEngine(ctx).Where("project_id = ?", projectID).OrderBy("id").Find(&views)
+}
+
+func deleteViewsByProjectID(ctx context.Context, projectID int64) error {
+	_, err := db.GetEngine(ctx).Where("project_id = ?", projectID).Delete(&View{})
+	return err
+}
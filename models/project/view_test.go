@@ -0,0 +1,78 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseViewCustomField(t *testing.T) {
+	id, ok := ParseViewCustomField(ViewCustomField(4))
+	assert.True(t, ok)
+	assert.EqualValues(t, 4, id)
+
+	for _, s := range []string{"", "state", "customfield-", "customfield-x", "customfield-0"} {
+		_, ok = ParseViewCustomField(s)
+		assert.False(t, ok, s)
+	}
+}
+
+func TestViewValidate(t *testing.T) {
+	view := &View{Name: " Sprint ", Type: ViewTypeTable, Fields: []string{"state", "", "customfield-2", "state"}, DateField: "deadline"}
+	require.NoError(t, view.validate())
+	assert.Equal(t, "Sprint", view.Name)
+	assert.Equal(t, []string{"state", "customfield-2"}, view.Fields)
+	assert.Empty(t, view.DateField)
+
+	for _, view := range []*View{
+		{Name: " ", Type: ViewTypeTable},
+		{Name: "Kanban", Type: "kanban"},
+		{Name: "Roadmap", Type: ViewTypeRoadmap, DateField: "created"},
+		{Name: "Fields", Type: ViewTypeTable, Fields: []string{"color"}},
+		{Name: "Group", Type: ViewTypeTable, GroupBy: "labels"},
+		{Name: "Sort", Type: ViewTypeTable, SortBy: "labels"},
+	} {
+		require.Error(t, view.validate(), view.Name)
+	}
+}
+
+func TestProjectViews(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	table := &View{ProjectID: 1, Name: "Table", Type: ViewTypeTable, Fields: []string{ViewFieldState, ViewFieldAssignees}, GroupBy: ViewFieldColumn, CreatorID: 2}
+	require.NoError(t, NewView(db.DefaultContext, table))
+	roadmap := &View{ProjectID: 1, Name: "Roadmap", Type: ViewTypeRoadmap, DateField: ViewFieldMilestone, CreatorID: 2}
+	require.NoError(t, NewView(db.DefaultContext, roadmap))
+	require.Error(t, NewView(db.DefaultContext, &View{ProjectID: 1, Name: "Roadmap", Type: ViewTypeRoadmap, CreatorID: 2}))
+
+	views, err := GetProjectViews(db.DefaultContext, 1)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	assert.Equal(t, table.ID, views[0].ID)
+	assert.Equal(t, []string{ViewFieldState, ViewFieldAssignees}, views[0].Fields)
+	assert.Equal(t, roadmap.ID, views[1].ID)
+
+	table.SortBy = ViewFieldUpdated
+	table.SortDesc = true
+	require.NoError(t, UpdateView(db.DefaultContext, table))
+	view, err := GetViewByProjectIDAndID(db.DefaultContext, 1, table.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ViewFieldUpdated, view.SortBy)
+	assert.True(t, view.SortDesc)
+
+	_, err = GetViewByProjectIDAndID(db.DefaultContext, 2, table.ID)
+	assert.True(t, IsErrViewNotExist(err))
+
+	require.NoError(t, DeleteView(db.DefaultContext, table))
+	unittest.AssertNotExistsBean(t, &View{ID: table.ID})
+
+	require.NoError(t, DeleteProjectByID(db.DefaultContext, 1))
+	unittest.AssertNotExistsBean(t, &View{ID: roadmap.ID})
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"path"
 
+	blob_model "forgejo.org/models/blob"
 	"forgejo.org/models/db"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/storage"
@@ -34,6 +35,7 @@ type Attachment struct {
 	CreatedUnix       timeutil.TimeStamp `xorm:"created"`
 	CustomDownloadURL string             `xorm:"-"`
 	ExternalURL       string
+	HashSHA256        string `xorm:"hash_sha256 INDEX NOT NULL DEFAULT ''"` // only set if the content is stored in the shared blob storage
 }
 
 func init() {
@@ -57,6 +59,9 @@ func AttachmentRelativePath(uuid string) string {
 
 // RelativePath returns the relative path of the attachment
 func (a *Attachment) RelativePath() string {
+	if a.HashSHA256 != "" {
+		return storage.BlobRelativePath(a.HashSHA256)
+	}
 	return AttachmentRelativePath(a.UUID)
 }
 
@@ -210,6 +215,12 @@ func DeleteAttachments(ctx context.Context, attachments []*Attachment, remove bo
 		return 0, err
 	}
 
+	for _, a := range attachments {
+		if err := blob_model.RemoveReference(ctx, a.HashSHA256); err != nil {
+			return 0, err
+		}
+	}
+
 	if remove {
 		for i, a := range attachments {
 			if err := storage.Attachments.Delete(a.RelativePath()); err != nil {
@@ -48,6 +48,27 @@ func (repo *Repository) AllowOnlyContributorsToTrackTime(ctx context.Context) bo
 	return u.IssuesConfig().AllowOnlyContributorsToTrackTime
 }
 
+// CloseParentIssues returns whether the issues are closed when all their sub-issues are closed
+func (repo *Repository) CloseParentIssues(ctx context.Context) bool {
+	u, err := repo.GetUnit(ctx, unit.TypeIssues)
+	if err != nil {
+		return false
+	}
+	return u.IssuesConfig().CloseParentIssues
+}
+
+// IsServiceDeskEnabled returns whether issues are created from emails sent to the service desk address
+func (repo *Repository) IsServiceDeskEnabled(ctx context.Context) bool {
+	if !setting.IncomingEmail.Enabled {
+		return false
+	}
+	u, err := repo.GetUnit(ctx, unit.TypeIssues)
+	if err != nil {
+		return false
+	}
+	return u.IssuesConfig().EnableServiceDesk
+}
+
 // IsDependenciesEnabled returns if dependencies are enabled and returns the default setting if not set.
 func (repo *Repository) IsDependenciesEnabled(ctx context.Context) bool {
 	var u *RepoUnit
@@ -46,6 +46,12 @@ func RepositoryListOfMap(repoMap map[int64]*Repository) RepositoryList {
 	return RepositoryList(ValuesRepository(repoMap))
 }
 
+// GetRepositoriesByOwnerID returns all the repositories of an owner
+func GetRepositoriesByOwnerID(ctx context.Context, ownerID int64) (RepositoryList, error) {
+	repos := make(RepositoryList, 0, 10)
+	return repos, db.GetEngine(ctx).Where("owner_id = ?", ownerID).Find(&repos)
+}
+
 func (repos RepositoryList) LoadUnits(ctx context.Context) error {
 	if len(repos) == 0 {
 		return nil
@@ -137,6 +137,8 @@ type IssuesConfig struct {
 	EnableTimetracker                bool
 	AllowOnlyContributorsToTrackTime bool
 	EnableDependencies               bool
+	CloseParentIssues                bool // close an issue when all its sub-issues are closed
+	EnableServiceDesk                bool // create issues from emails sent to the service desk address
 }
 
 // FromDB fills up a IssuesConfig from serialized format.
@@ -4,6 +4,7 @@
 package webauthn
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/gob"
 
@@ -75,3 +76,12 @@ func (u *User) WebAuthnCredentials() []webauthn.Credential {
 
 	return dbCreds.ToCredentials()
 }
+
+// GetUserByWebAuthnID returns the user of the user handle of a discoverable credential
+func GetUserByWebAuthnID(ctx context.Context, id []byte) (*user_model.User, error) {
+	uid, n := binary.Varint(id)
+	if n <= 0 || uid <= 0 {
+		return nil, user_model.ErrUserNotExist{}
+	}
+	return user_model.GetUserByID(ctx, uid)
+}
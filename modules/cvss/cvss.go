@@ -0,0 +1,143 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package cvss parses CVSS v3 vectors and computes their base score
+// as specified in https://www.first.org/cvss/v3.1/specification-document
+package cvss
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Severity is the qualitative severity rating of a score
+type Severity string
+
+const (
+	SeverityNone     Severity = "none"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// SeverityOf returns the qualitative severity rating of a score
+func SeverityOf(score float64) Severity {
+	switch {
+	case score == 0:
+		return SeverityNone
+	case score < 4:
+		return SeverityLow
+	case score < 7:
+		return SeverityMedium
+	case score < 9:
+		return SeverityHigh
+	default:
+		return SeverityCritical
+	}
+}
+
+// the weights of the values of the base metrics, the privileges required
+// are weighted differently when the scope is changed
+var weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"S":  {"U": 0, "C": 0},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+var changedScopePrivilegesWeights = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}
+
+// Vector is a parsed CVSS v3 vector with all the base metrics
+type Vector struct {
+	raw     string
+	metrics map[string]string
+}
+
+// ParseV3 parses a CVSS v3.0 or v3.1 vector such as CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H,
+// only the base metrics are supported
+func ParseV3(vector string) (*Vector, error) {
+	parts := strings.Split(vector, "/")
+	if parts[0] != "CVSS:3.0" && parts[0] != "CVSS:3.1" {
+		return nil, fmt.Errorf("%q is not a CVSS v3 vector", vector)
+	}
+	v := &Vector{raw: vector, metrics: make(map[string]string, len(weights))}
+	for _, part := range parts[1:] {
+		metric, value, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid metric %q", part)
+		}
+		values, known := weights[metric]
+		if !known {
+			return nil, fmt.Errorf("unsupported metric %q", metric)
+		}
+		if _, known := values[value]; !known {
+			return nil, fmt.Errorf("invalid value %q of the metric %q", value, metric)
+		}
+		if _, dup := v.metrics[metric]; dup {
+			return nil, fmt.Errorf("duplicate metric %q", metric)
+		}
+		v.metrics[metric] = value
+	}
+	for metric := range weights {
+		if _, has := v.metrics[metric]; !has {
+			return nil, fmt.Errorf("missing metric %q", metric)
+		}
+	}
+	return v, nil
+}
+
+// String returns the vector as it was parsed
+func (v *Vector) String() string {
+	return v.raw
+}
+
+// BaseScore returns the base score of the vector, between 0 and 10
+func (v *Vector) BaseScore() float64 {
+	weight := func(metric string) float64 {
+		return weights[metric][v.metrics[metric]]
+	}
+	changed := v.metrics["S"] == "C"
+
+	iss := 1 - (1-weight("C"))*(1-weight("I"))*(1-weight("A"))
+	var impact float64
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	} else {
+		impact = 6.42 * iss
+	}
+	if impact <= 0 {
+		return 0
+	}
+
+	privileges := weight("PR")
+	if changed {
+		privileges = changedScopePrivilegesWeights[v.metrics["PR"]]
+	}
+	exploitability := 8.22 * weight("AV") * weight("AC") * privileges * weight("UI")
+
+	if changed {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10))
+	}
+	return roundUp(math.Min(impact+exploitability, 10))
+}
+
+// Severity returns the qualitative severity rating of the base score
+func (v *Vector) Severity() Severity {
+	return SeverityOf(v.BaseScore())
+}
+
+// roundUp returns the smallest number with one decimal which is equal to or higher than
+// its input, avoiding the floating point errors as CVSS v3.1 requires
+func roundUp(input float64) float64 {
+	i := int64(math.Round(input * 100000))
+	if i%10000 == 0 {
+		return float64(i) / 100000
+	}
+	return float64(i/10000+1) / 10
+}
@@ -24,7 +24,7 @@ import (
 const (
 	issueIndexerAnalyzer      = "issueIndexer"
 	issueIndexerDocType       = "issueIndexerDocType"
-	issueIndexerLatestVersion = 5
+	issueIndexerLatestVersion = 8
 )
 
 const unicodeNormalizeName = "unicodeNormalize"
@@ -68,6 +68,10 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	numberFieldMapping.Store = false
 	numberFieldMapping.IncludeInAll = false
 
+	keywordFieldMapping := bleve.NewKeywordFieldMapping()
+	keywordFieldMapping.Store = false
+	keywordFieldMapping.IncludeInAll = false
+
 	docMapping.AddFieldMappingsAt("is_public", boolFieldMapping)
 
 	docMapping.AddFieldMappingsAt("index", numberFieldMapping)
@@ -88,6 +92,12 @@ func generateIssueIndexMapping() (mapping.IndexMapping, error) {
 	docMapping.AddFieldMappingsAt("reviewed_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("review_requested_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("subscriber_ids", numberFieldMapping)
+	docMapping.AddFieldMappingsAt("custom_field_ids", numberFieldMapping)
+	docMapping.AddFieldMappingsAt("custom_field_values", keywordFieldMapping)
+	docMapping.AddFieldMappingsAt("parent_id", numberFieldMapping)
+	docMapping.AddFieldMappingsAt("has_sub_issues", boolFieldMapping)
+	docMapping.AddFieldMappingsAt("is_confidential", boolFieldMapping)
+	docMapping.AddFieldMappingsAt("confidential_ids", numberFieldMapping)
 	docMapping.AddFieldMappingsAt("updated_unix", numberFieldMapping)
 
 	docMapping.AddFieldMappingsAt("created_unix", numberFieldMapping)
@@ -227,6 +237,13 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 			}
 			filters = append(filters, bleve.NewDisjunctionQuery(includeQueries...))
 		}
+		for _, group := range options.LabelIDGroups {
+			var groupQueries []query.Query
+			for _, labelID := range group {
+				groupQueries = append(groupQueries, inner_bleve.NumericEqualityQuery(labelID, "label_ids"))
+			}
+			filters = append(filters, bleve.NewDisjunctionQuery(groupQueries...))
+		}
 		if len(options.ExcludedLabelIDs) > 0 {
 			for _, labelID := range options.ExcludedLabelIDs {
 				q.AddMustNot(inner_bleve.NumericEqualityQuery(labelID, "label_ids"))
@@ -251,12 +268,36 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		"reviewed_ids":         options.ReviewedID,
 		"review_requested_ids": options.ReviewRequestedID,
 		"subscriber_ids":       options.SubscriberID,
+		"parent_id":            options.ParentID,
 	} {
 		if val.Has() {
 			filters = append(filters, inner_bleve.NumericEqualityQuery(val.Value(), key))
 		}
 	}
 
+	if options.HasSubIssues.Has() {
+		filters = append(filters, inner_bleve.BoolFieldQuery(options.HasSubIssues.Value(), "has_sub_issues"))
+	}
+
+	if options.Confidential != nil {
+		confidentialQueries := []query.Query{inner_bleve.BoolFieldQuery(false, "is_confidential")}
+		if options.Confidential.UserID > 0 {
+			confidentialQueries = append(confidentialQueries, inner_bleve.NumericEqualityQuery(options.Confidential.UserID, "confidential_ids"))
+		}
+		for _, repoID := range options.Confidential.RepoIDs {
+			confidentialQueries = append(confidentialQueries, inner_bleve.NumericEqualityQuery(repoID, "repo_id"))
+		}
+		filters = append(filters, bleve.NewDisjunctionQuery(confidentialQueries...))
+	}
+
+	for _, filter := range options.CustomFields {
+		if filter.Value == "" {
+			q.AddMustNot(inner_bleve.NumericEqualityQuery(filter.FieldID, "custom_field_ids"))
+		} else {
+			filters = append(filters, inner_bleve.TermQuery(internal.CustomFieldValueToken(filter.FieldID, filter.Value), "custom_field_values"))
+		}
+	}
+
 	if options.UpdatedAfterUnix.Has() || options.UpdatedBeforeUnix.Has() {
 		filters = append(filters, inner_bleve.NumericRangeInclusiveQuery(
 			options.UpdatedAfterUnix,
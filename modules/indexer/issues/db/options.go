@@ -34,7 +34,11 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issues_
 	case internal.SortByDeadlineAsc:
 		sortType = "nearduedate"
 	default:
-		sortType = "newest"
+		if options.SortBy.IsCustomField() {
+			sortType = options.SortBy.ToIssueSort()
+		} else {
+			sortType = "newest"
+		}
 	}
 
 	// See the comment of issues_model.SearchOptions for the reason why we need to convert
@@ -67,8 +71,10 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issues_
 		IncludedLabelNames: nil,
 		ExcludedLabelNames: nil,
 		IncludeMilestones:  nil,
+		ParentID:           options.ParentID.Value(),
+		HasSubIssues:       options.HasSubIssues,
 		SortType:           sortType,
-		IssueIDs:           nil,
+		IssueIDs:           options.IssueIDs,
 		UpdatedAfterUnix:   options.UpdatedAfterUnix.Value(),
 		UpdatedBeforeUnix:  options.UpdatedBeforeUnix.Value(),
 		PriorityRepoID:     0,
@@ -78,6 +84,10 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issues_
 		User:               nil,
 	}
 
+	if options.Confidential != nil {
+		opts.Confidential = &issues_model.ConfidentialFilter{UserID: options.Confidential.UserID, RepoIDs: options.Confidential.RepoIDs}
+	}
+
 	if options.PriorityRepoID.Has() {
 		opts.SortType = "priorityrepo"
 		opts.PriorityRepoID = options.PriorityRepoID.Value()
@@ -97,6 +107,7 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issues_
 		for _, id := range options.ExcludedLabelIDs {
 			opts.LabelIDs = append(opts.LabelIDs, -id)
 		}
+		opts.LabelIDGroups = options.LabelIDGroups
 
 		if len(options.IncludedLabelIDs) == 0 && len(options.IncludedAnyLabelIDs) > 0 {
 			labels, err := issues_model.GetLabelsByIDs(ctx, options.IncludedAnyLabelIDs, "name")
@@ -113,5 +124,9 @@ func ToDBOptions(ctx context.Context, options *internal.SearchOptions) (*issues_
 		}
 	}
 
+	for _, filter := range options.CustomFields {
+		opts.CustomFields = append(opts.CustomFields, issues_model.CustomFieldFilter{FieldID: filter.FieldID, Value: filter.Value})
+	}
+
 	return opts, nil
 }
@@ -8,10 +8,14 @@ import (
 
 	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/indexer/issues/internal"
 	"forgejo.org/modules/optional"
 )
 
-func ToSearchOptions(ctx context.Context, keyword string, opts *issues_model.IssuesOptions) *SearchOptions {
+// ToSearchOptions converts the options of a database query to the options of a search
+// for the keyword by the doer, which can be nil
+func ToSearchOptions(ctx context.Context, doer *user_model.User, keyword string, opts *issues_model.IssuesOptions) *SearchOptions {
 	searchOpt := &SearchOptions{
 		RepoIDs:   opts.RepoIDs,
 		AllPublic: opts.AllPublic,
@@ -69,6 +73,19 @@ func ToSearchOptions(ctx context.Context, keyword string, opts *issues_model.Iss
 	searchOpt.ReviewRequestedID = convertID(opts.ReviewRequestedID)
 	searchOpt.SubscriberID = convertID(opts.SubscriberID)
 
+	if opts.ParentID > 0 {
+		searchOpt.ParentID = optional.Some(opts.ParentID)
+	}
+	searchOpt.HasSubIssues = opts.HasSubIssues
+
+	if opts.Confidential != nil {
+		searchOpt.Confidential = &ConfidentialFilter{UserID: opts.Confidential.UserID, RepoIDs: opts.Confidential.RepoIDs}
+	}
+
+	for _, filter := range opts.CustomFields {
+		searchOpt.CustomFields = append(searchOpt.CustomFields, CustomFieldFilter{FieldID: filter.FieldID, Value: filter.Value})
+	}
+
 	if opts.UpdatedAfterUnix > 0 {
 		searchOpt.UpdatedAfterUnix = optional.Some(opts.UpdatedAfterUnix)
 	}
@@ -99,11 +116,18 @@ func ToSearchOptions(ctx context.Context, keyword string, opts *issues_model.Iss
 		searchOpt.SortBy = SortByDeadlineDesc
 	case "priority", "priorityrepo", "project-column-sorting":
 		// Unsupported sort type for search
-		fallthrough
-	default:
 		searchOpt.SortBy = SortByUpdatedDesc
+	default:
+		if _, _, ok := issues_model.ParseCustomFieldSortType(opts.SortType); ok {
+			searchOpt.SortBy = internal.SortBy(opts.SortType)
+		} else {
+			searchOpt.SortBy = SortByUpdatedDesc
+		}
 	}
 
+	if doer != nil {
+		searchOpt.DoerID = doer.ID
+	}
 	_ = searchOpt.WithKeyword(ctx, keyword)
 
 	return searchOpt
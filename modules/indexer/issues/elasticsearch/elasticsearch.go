@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 2
+	issueIndexerLatestVersion = 5
 	// multi-match-types, currently only 2 types are used
 	// Reference: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/query-dsl-multi-match-query.html#multi-match-types
 	esMultiMatchTypeBestFields   = "best_fields"
@@ -74,6 +74,12 @@ const (
 			"reviewed_ids": { "type": "long", "index": true },
 			"review_requested_ids": { "type": "long", "index": true },
 			"subscriber_ids": { "type": "long", "index": true },
+			"custom_field_ids": { "type": "long", "index": true },
+			"custom_field_values": { "type": "keyword", "index": true },
+			"parent_id": { "type": "long", "index": true },
+			"has_sub_issues": { "type": "boolean", "index": true },
+			"is_confidential": { "type": "boolean", "index": true },
+			"confidential_ids": { "type": "long", "index": true },
 			"updated_unix": { "type": "long", "index": true },
 
 			"created_unix": { "type": "long", "index": true },
@@ -208,6 +214,9 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		} else if len(options.IncludedAnyLabelIDs) > 0 {
 			query.Must(elastic.NewTermsQuery("label_ids", toAnySlice(options.IncludedAnyLabelIDs)...))
 		}
+		for _, group := range options.LabelIDGroups {
+			query.Must(elastic.NewTermsQuery("label_ids", toAnySlice(group)...))
+		}
 		if len(options.ExcludedLabelIDs) > 0 {
 			q := elastic.NewBoolQuery()
 			for _, labelID := range options.ExcludedLabelIDs {
@@ -251,6 +260,33 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.Must(elastic.NewTermQuery("subscriber_ids", options.SubscriberID.Value()))
 	}
 
+	if options.ParentID.Has() {
+		query.Must(elastic.NewTermQuery("parent_id", options.ParentID.Value()))
+	}
+	if options.HasSubIssues.Has() {
+		query.Must(elastic.NewTermQuery("has_sub_issues", options.HasSubIssues.Value()))
+	}
+
+	if options.Confidential != nil {
+		q := elastic.NewBoolQuery()
+		q.Should(elastic.NewTermQuery("is_confidential", false))
+		if options.Confidential.UserID > 0 {
+			q.Should(elastic.NewTermQuery("confidential_ids", options.Confidential.UserID))
+		}
+		if len(options.Confidential.RepoIDs) > 0 {
+			q.Should(elastic.NewTermsQuery("repo_id", toAnySlice(options.Confidential.RepoIDs)...))
+		}
+		query.Must(q)
+	}
+
+	for _, filter := range options.CustomFields {
+		if filter.Value == "" {
+			query.MustNot(elastic.NewTermQuery("custom_field_ids", filter.FieldID))
+		} else {
+			query.Must(elastic.NewTermQuery("custom_field_values", internal.CustomFieldValueToken(filter.FieldID, filter.Value)))
+		}
+	}
+
 	if options.UpdatedAfterUnix.Has() || options.UpdatedBeforeUnix.Has() {
 		q := elastic.NewRangeQuery("updated_unix")
 		if options.UpdatedAfterUnix.Has() {
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/modules/graceful"
 	"forgejo.org/modules/indexer/issues/bleve"
@@ -270,6 +271,12 @@ func IsAvailable(ctx context.Context) bool {
 // SearchOptions indicates the options for searching issues
 type SearchOptions = internal.SearchOptions
 
+// CustomFieldFilter filters issues by the value of a custom field
+type CustomFieldFilter = internal.CustomFieldFilter
+
+// ConfidentialFilter restricts the confidential issues found to the ones a user can see
+type ConfidentialFilter = internal.ConfidentialFilter
+
 const (
 	SortByScore        = internal.SortByScore
 	SortByCreatedDesc  = internal.SortByCreatedDesc
@@ -305,6 +312,9 @@ func ParseSortBy(sortBy string, defaultSortBy internal.SortBy) internal.SortBy {
 	case "farduedate":
 		return SortByDeadlineDesc
 	default:
+		if _, _, ok := issues_model.ParseCustomFieldSortType(sortBy); ok {
+			return internal.SortBy(sortBy)
+		}
 		return defaultSortBy
 	}
 }
@@ -321,6 +331,28 @@ func SearchIssues(ctx context.Context, opts *SearchOptions) ([]int64, int64, err
 		// Even worse, the external indexer like elastic search may not be available for a while,
 		// and the user may not be able to list issues completely until it is available again.
 		indexer = db_index.NewIndexer()
+	} else if opts.SortBy.IsCustomField() {
+		// Only the database can sort issues by the value of a custom field,
+		// so the issues matching the keyword are searched first then sorted by the database.
+		result, err := indexer.Search(ctx, opts.Copy(func(options *SearchOptions) {
+			options.Paginator = &db.ListOptionsAll
+			options.SortBy = SortByScore
+		}))
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(result.Hits) == 0 {
+			return []int64{}, 0, nil
+		}
+		issueIDs := make([]int64, 0, len(result.Hits))
+		for _, hit := range result.Hits {
+			issueIDs = append(issueIDs, hit.ID)
+		}
+		opts = opts.Copy(func(options *SearchOptions) {
+			options.Tokens = nil
+			options.IssueIDs = issueIDs
+		})
+		indexer = db_index.NewIndexer()
 	}
 
 	result, err := indexer.Search(ctx, opts)
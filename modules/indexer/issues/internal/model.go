@@ -4,6 +4,9 @@
 package internal
 
 import (
+	"strconv"
+	"strings"
+
 	"forgejo.org/models/db"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/timeutil"
@@ -35,6 +38,12 @@ type IndexerData struct {
 	ReviewedIDs        []int64            `json:"reviewed_ids"`
 	ReviewRequestedIDs []int64            `json:"review_requested_ids"`
 	SubscriberIDs      []int64            `json:"subscriber_ids"`
+	CustomFieldIDs     []int64            `json:"custom_field_ids"`    // the custom fields the issue has a value of
+	CustomFieldValues  []string           `json:"custom_field_values"` // the values of the custom fields, see CustomFieldValueToken
+	ParentID           int64              `json:"parent_id"`           // the parent of a sub-issue
+	HasSubIssues       bool               `json:"has_sub_issues"`
+	IsConfidential     bool               `json:"is_confidential"`
+	ConfidentialIDs    []int64            `json:"confidential_ids"` // the users who can see a confidential issue without write access
 	UpdatedUnix        timeutil.TimeStamp `json:"updated_unix"`
 
 	// Fields used for sorting
@@ -75,6 +84,8 @@ type SearchResult struct {
 type SearchOptions struct {
 	Tokens []Token
 
+	DoerID int64 // the searching user, who is "@me" in the keyword
+
 	RepoIDs        []int64                // repository IDs which the issues belong to
 	AllPublic      bool                   // if include all public repositories
 	PriorityRepoID optional.Option[int64] // issues from this repository will be prioritized when SortByScore
@@ -82,10 +93,11 @@ type SearchOptions struct {
 	IsPull   optional.Option[bool] // if the issues is a pull request
 	IsClosed optional.Option[bool] // if the issues is closed
 
-	IncludedLabelIDs    []int64 // labels the issues have
-	ExcludedLabelIDs    []int64 // labels the issues don't have
-	IncludedAnyLabelIDs []int64 // labels the issues have at least one. It will be ignored if IncludedLabelIDs is not empty. It's an uncommon filter, but it has been supported accidentally by issues.IssuesOptions.IncludedLabelNames.
-	NoLabelOnly         bool    // if the issues have no label, if true, IncludedLabelIDs and ExcludedLabelIDs, IncludedAnyLabelIDs will be ignored
+	IncludedLabelIDs    []int64   // labels the issues have
+	ExcludedLabelIDs    []int64   // labels the issues don't have
+	IncludedAnyLabelIDs []int64   // labels the issues have at least one. It will be ignored if IncludedLabelIDs is not empty. It's an uncommon filter, but it has been supported accidentally by issues.IssuesOptions.IncludedLabelNames.
+	NoLabelOnly         bool      // if the issues have no label, if true, IncludedLabelIDs and ExcludedLabelIDs, IncludedAnyLabelIDs will be ignored
+	LabelIDGroups       [][]int64 // groups of labels, the issues have at least one label of every group. It's used for labels given by name, which can be the names of labels of several repositories.
 
 	MilestoneIDs []int64 // milestones the issues have
 
@@ -103,6 +115,15 @@ type SearchOptions struct {
 
 	SubscriberID optional.Option[int64] // subscriber of the issues
 
+	CustomFields []CustomFieldFilter // values of custom fields the issues have
+
+	ParentID     optional.Option[int64] // parent of the issues
+	HasSubIssues optional.Option[bool]  // whether the issues have sub-issues
+
+	Confidential *ConfidentialFilter // confidential issues the searching user can see, nil does not restrict them
+
+	IssueIDs []int64 // issues to search in, only supported by the database indexer
+
 	UpdatedAfterUnix  optional.Option[int64]
 	UpdatedBeforeUnix optional.Option[int64]
 
@@ -111,6 +132,12 @@ type SearchOptions struct {
 	SortBy SortBy // sort by field
 }
 
+// ConfidentialFilter restricts the confidential issues found to the ones a user can see
+type ConfidentialFilter struct {
+	UserID  int64   // the confidential issues posted by or assigned to this user are found
+	RepoIDs []int64 // all the confidential issues of these repositories are found
+}
+
 // Copy returns a copy of the options.
 // Be careful, it's not a deep copy, so `SearchOptions.RepoIDs = {...}` is OK while `SearchOptions.RepoIDs[0] = ...` is not.
 func (o *SearchOptions) Copy(edit ...func(options *SearchOptions)) *SearchOptions {
@@ -124,6 +151,17 @@ func (o *SearchOptions) Copy(edit ...func(options *SearchOptions)) *SearchOption
 	return &v
 }
 
+// CustomFieldFilter filters issues by the value of a custom field, an empty value matches the issues without value
+type CustomFieldFilter struct {
+	FieldID int64
+	Value   string
+}
+
+// CustomFieldValueToken returns the token of the value of a custom field in IndexerData.CustomFieldValues
+func CustomFieldValueToken(fieldID int64, value string) string {
+	return strconv.FormatInt(fieldID, 10) + ":" + value
+}
+
 type SortBy string
 
 const (
@@ -144,13 +182,26 @@ const (
 	//                    If we do something like that query the issues in the specified repository first then append other issues,
 	//                    it will break the pagination.
 	//
+	// - "customfield-<field id>[-desc]":
+	//                    The values of custom fields are not stored in a sortable way in the indexer,
+	//                    the issues matching the keyword are sorted by the database, see IsCustomField.
+	//
 	// - "project-column-sorting":
 	//                    Although it's possible to support it by adding project.ProjectIssue.Sorting to the indexer,
 	//                    but what if the issue belongs to multiple projects?
 	//                    Since it's unsupported to search issues with keyword in project page, we don't need to support it.
 )
 
+// IsCustomField returns whether the issues are sorted by the value of a custom field,
+// the sort is then the sort type of issues.IssuesOptions
+func (s SortBy) IsCustomField() bool {
+	return strings.HasPrefix(string(s), "customfield-")
+}
+
 func (s SortBy) ToIssueSort() string {
+	if s.IsCustomField() {
+		return string(s)
+	}
 	switch s {
 	case SortByScore:
 		return "relevance"
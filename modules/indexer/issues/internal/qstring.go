@@ -6,10 +6,13 @@ package internal
 import (
 	"context"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/user"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/optional"
@@ -132,9 +135,13 @@ func (o *SearchOptions) WithKeyword(ctx context.Context, keyword string) (err er
 	it := Tokenizer{in: in}
 
 	var (
-		tokens     []Token
-		userNames  []string
-		userFilter []userFilter
+		tokens             []Token
+		userNames          []string
+		userFilter         []userFilter
+		parentRef          string
+		repoRefs           []string
+		includedLabelNames []string
+		excludedLabelNames []string
 	)
 
 	for token, err := it.next(); err == nil; token, err = it.next() {
@@ -159,6 +166,18 @@ func (o *SearchOptions) WithKeyword(ctx context.Context, keyword string) (err er
 		case token.Term == "is:closed":
 			o.IsClosed = optional.Some(token.Kind != BoolOptNot)
 
+		// has-children => with sub-issues & -has-children => without sub-issues
+		case token.Term == "has-children":
+			o.HasSubIssues = optional.Some(token.Kind != BoolOptNot)
+
+		// label:<name> => with the label & -label:<name> => without the label
+		case token.IsOf("label:"):
+			if token.Kind == BoolOptNot {
+				excludedLabelNames = append(excludedLabelNames, token.Term[6:])
+			} else {
+				includedLabelNames = append(includedLabelNames, token.Term[6:])
+			}
+
 		// The rest of the presets MUST NOT be a negation.
 		case token.Kind == BoolOptNot:
 			tokens = append(tokens, token)
@@ -171,20 +190,29 @@ func (o *SearchOptions) WithKeyword(ctx context.Context, keyword string) (err er
 		case token.IsOf("sort:"):
 			o.SortBy = parseSortBy(token.Term[5:])
 
-		// modified:[ < | > ]<date>.
+		// modified:[ < | > ]<date> or updated:[ < | > ]<date>.
 		// for example, modified:>2025-08-29
-		case token.IsOf("modified:"):
-			switch token.Term[9] {
+		case token.IsOf("modified:"), token.IsOf("updated:"):
+			value := token.Term[strings.IndexByte(token.Term, ':')+1:]
+			switch value[0] {
 			case '>':
-				o.UpdatedAfterUnix = toUnix(token.Term[10:])
+				o.UpdatedAfterUnix = toUnix(value[1:])
 			case '<':
-				o.UpdatedBeforeUnix = toUnix(token.Term[10:])
+				o.UpdatedBeforeUnix = toUnix(value[1:])
 			default:
-				t := toUnix(token.Term[9:])
+				t := toUnix(value)
 				o.UpdatedAfterUnix = t
 				o.UpdatedBeforeUnix = t
 			}
 
+		// repo:<owner>/<repo> or repo:<owner>/*
+		case token.IsOf("repo:"):
+			repoRefs = append(repoRefs, token.Term[5:])
+
+		// parent:#<index> or parent:<owner>/<repo>#<index>
+		case token.IsOf("parent:"):
+			parentRef = token.Term[7:]
+
 		// for user filter's
 		// append the names and roles
 		case token.IsOf("author:"):
@@ -210,12 +238,54 @@ func (o *SearchOptions) WithKeyword(ctx context.Context, keyword string) (err er
 
 	o.Tokens = tokens
 
-	ids, err := user.GetUserIDsByNames(ctx, userNames, true)
-	if err != nil {
-		return err
+	if parentRef != "" {
+		// Skip an invalid reference, like an invalid user name.
+		parentID, err := o.resolveIssueReference(ctx, parentRef)
+		if err != nil {
+			return err
+		}
+		if parentID > 0 {
+			o.ParentID = optional.Some(parentID)
+		}
+	}
+
+	if len(repoRefs) > 0 {
+		if err := o.restrictRepositories(ctx, repoRefs); err != nil {
+			return err
+		}
 	}
 
-	for i, id := range ids {
+	// A label name can be the name of labels of several repositories.
+	// Skip an invalid name, like an invalid user name.
+	for _, name := range includedLabelNames {
+		ids, err := issues_model.GetLabelIDsByNames(ctx, []string{name})
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			o.LabelIDGroups = append(o.LabelIDGroups, ids)
+		}
+	}
+	if len(excludedLabelNames) > 0 {
+		ids, err := issues_model.GetLabelIDsByNames(ctx, excludedLabelNames)
+		if err != nil {
+			return err
+		}
+		o.ExcludedLabelIDs = append(o.ExcludedLabelIDs, ids...)
+	}
+
+	for i, name := range userNames {
+		// @me is the searching user
+		id := o.DoerID
+		if name != "@me" {
+			u, err := user.GetUserByName(ctx, name)
+			if err != nil && !user.IsErrUserNotExist(err) {
+				return err
+			}
+			if u != nil {
+				id = u.ID
+			}
+		}
 		// Skip all invalid IDs.
 		// Hopefully this won't be too astonishing for the user.
 		if id <= 0 {
@@ -237,6 +307,96 @@ func (o *SearchOptions) WithKeyword(ctx context.Context, keyword string) (err er
 	return nil
 }
 
+// resolveIssueReference returns the ID of the issue referenced by "#<index>" in the
+// searched repository or by "<owner>/<repo>#<index>", zero if there is no such issue.
+func (o *SearchOptions) resolveIssueReference(ctx context.Context, ref string) (int64, error) {
+	repoRef, indexStr, ok := strings.Cut(ref, "#")
+	if !ok {
+		return 0, nil
+	}
+	index, err := strconv.ParseInt(indexStr, 10, 64)
+	if err != nil {
+		return 0, nil
+	}
+
+	var repoID int64
+	if repoRef == "" {
+		if len(o.RepoIDs) != 1 {
+			return 0, nil
+		}
+		repoID = o.RepoIDs[0]
+	} else {
+		ownerName, repoName, ok := strings.Cut(repoRef, "/")
+		if !ok {
+			return 0, nil
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if repo_model.IsErrRepoNotExist(err) {
+			return 0, nil
+		} else if err != nil {
+			return 0, err
+		}
+		repoID = repo.ID
+	}
+
+	issue, err := issues_model.GetIssueByIndex(ctx, repoID, index)
+	if issues_model.IsErrIssueNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return issue.ID, nil
+}
+
+// restrictRepositories restricts the search to the repositories given by "<owner>/<repo>"
+// or "<owner>/*" among the searched repositories.
+func (o *SearchOptions) restrictRepositories(ctx context.Context, refs []string) error {
+	repos := make(repo_model.RepositoryList, 0, len(refs))
+	for _, ref := range refs {
+		ownerName, repoName, ok := strings.Cut(ref, "/")
+		if !ok {
+			continue
+		}
+		if repoName != "*" {
+			repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+			if repo_model.IsErrRepoNotExist(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			repos = append(repos, repo)
+			continue
+		}
+		owner, err := user.GetUserByName(ctx, ownerName)
+		if user.IsErrUserNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		ownerRepos, err := repo_model.GetRepositoriesByOwnerID(ctx, owner.ID)
+		if err != nil {
+			return err
+		}
+		repos = append(repos, ownerRepos...)
+	}
+
+	// Without repositories, all the repositories are searched.
+	searchAll := len(o.RepoIDs) == 0 && !o.AllPublic
+	repoIDs := make([]int64, 0, len(repos))
+	for _, repo := range repos {
+		if searchAll || slices.Contains(o.RepoIDs, repo.ID) || (o.AllPublic && !repo.IsPrivate) {
+			repoIDs = append(repoIDs, repo.ID)
+		}
+	}
+	if len(repoIDs) == 0 {
+		// no repository matches, don't let the indexer search all the repositories
+		repoIDs = []int64{0}
+	}
+	o.RepoIDs = repoIDs
+	o.AllPublic = false
+	return nil
+}
+
 func toUnix(value string) optional.Option[int64] {
 	time, err := time.Parse(time.DateOnly, value)
 	if err != nil {
@@ -248,7 +408,8 @@ func toUnix(value string) optional.Option[int64] {
 }
 
 func parseSortBy(sortBy string) SortBy {
-	switch sortBy {
+	// sort:updated-desc is the same as sort:updated:desc
+	switch strings.Replace(sortBy, "-", ":", 1) {
 	case "created:asc":
 		return SortByCreatedAsc
 	case "created:desc":
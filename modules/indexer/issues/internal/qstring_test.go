@@ -7,6 +7,9 @@ import (
 	"context"
 	"testing"
 
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
 	"forgejo.org/models/user"
 	"forgejo.org/modules/optional"
@@ -348,12 +351,36 @@ func TestIssueQueryStringWithFilters(t *testing.T) {
 				PosterID: optional.Some(int64(2)),
 			},
 		},
+		{
+			Keyword: "has-children",
+			Opts: &SearchOptions{
+				HasSubIssues: optional.Some(true),
+			},
+		},
+		{
+			Keyword: "-has-children",
+			Opts: &SearchOptions{
+				HasSubIssues: optional.Some(false),
+			},
+		},
 		{
 			Keyword: "sort:updated:asc",
 			Opts: &SearchOptions{
 				SortBy: SortByUpdatedAsc,
 			},
 		},
+		{
+			Keyword: "sort:updated-desc",
+			Opts: &SearchOptions{
+				SortBy: SortByUpdatedDesc,
+			},
+		},
+		{
+			Keyword: "updated:>2025-08-28",
+			Opts: &SearchOptions{
+				UpdatedAfterUnix: optional.Some(int64(1756339200)),
+			},
+		},
 		{
 			Keyword: "sort:test",
 			Opts: &SearchOptions{
@@ -411,6 +438,22 @@ func TestIssueQueryStringWithFilters(t *testing.T) {
 				},
 			},
 		},
+		{
+			Keyword: "assignee:@me",
+			Opts:    &SearchOptions{},
+		},
+		{
+			Keyword: "label:unknown",
+			Opts:    &SearchOptions{},
+		},
+		{
+			Keyword: "parent:#1",
+			Opts:    &SearchOptions{},
+		},
+		{
+			Keyword: "parent:test/repo#1",
+			Opts:    &SearchOptions{},
+		},
 		{
 			Keyword: "modified:",
 			Opts: &SearchOptions{
@@ -432,6 +475,60 @@ func TestIssueQueryStringWithFilters(t *testing.T) {
 	}
 }
 
+func TestIssueQueryStringWithNames(t *testing.T) {
+	ctx := t.Context()
+	require.NoError(t, user.CreateUser(ctx, &user.User{
+		ID:        3,
+		Name:      "org",
+		LowerName: "org",
+		Email:     "org@localhost",
+	}))
+	require.NoError(t, db.Insert(ctx,
+		&repo_model.Repository{ID: 1, OwnerID: 3, OwnerName: "org", Name: "public", LowerName: "public"},
+		&repo_model.Repository{ID: 2, OwnerID: 3, OwnerName: "org", Name: "private", LowerName: "private", IsPrivate: true},
+		&issues_model.Label{ID: 10, RepoID: 1, Name: "bug"},
+		&issues_model.Label{ID: 11, RepoID: 2, Name: "bug"},
+		&issues_model.Label{ID: 12, RepoID: 1, Name: "wontfix"},
+	))
+
+	t.Run("Me", func(t *testing.T) {
+		opts := &SearchOptions{DoerID: 3}
+		require.NoError(t, opts.WithKeyword(ctx, "assignee:@me author:org"))
+		assert.Equal(t, optional.Some[int64](3), opts.AssigneeID)
+		assert.Equal(t, optional.Some[int64](3), opts.PosterID)
+	})
+
+	t.Run("Labels", func(t *testing.T) {
+		opts := &SearchOptions{}
+		require.NoError(t, opts.WithKeyword(ctx, "label:bug label:wontfix -label:wontfix"))
+		require.Len(t, opts.LabelIDGroups, 2)
+		assert.ElementsMatch(t, []int64{10, 11}, opts.LabelIDGroups[0])
+		assert.Equal(t, []int64{12}, opts.LabelIDGroups[1])
+		assert.Equal(t, []int64{12}, opts.ExcludedLabelIDs)
+	})
+
+	t.Run("Repos", func(t *testing.T) {
+		for _, c := range []struct {
+			Keyword   string
+			RepoIDs   []int64
+			AllPublic bool
+			Expected  []int64
+		}{
+			{Keyword: "repo:org/*", Expected: []int64{1, 2}},
+			{Keyword: "repo:org/*", RepoIDs: []int64{2}, Expected: []int64{2}},
+			{Keyword: "repo:org/*", AllPublic: true, Expected: []int64{1}},
+			{Keyword: "repo:org/private", AllPublic: true, Expected: []int64{0}},
+			{Keyword: "repo:org/public repo:org/private", RepoIDs: []int64{1, 2}, Expected: []int64{1, 2}},
+			{Keyword: "repo:unknown/*", RepoIDs: []int64{1, 2}, Expected: []int64{0}},
+		} {
+			opts := &SearchOptions{RepoIDs: c.RepoIDs, AllPublic: c.AllPublic}
+			require.NoError(t, opts.WithKeyword(ctx, c.Keyword))
+			assert.ElementsMatch(t, c.Expected, opts.RepoIDs, c.Keyword)
+			assert.False(t, opts.AllPublic, c.Keyword)
+		}
+	})
+}
+
 func TestToken_ParseIssueReference(t *testing.T) {
 	var tk Token
 	{
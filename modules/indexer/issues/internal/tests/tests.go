@@ -324,6 +324,23 @@ var cases = []*testIndexerCase{
 		ExpectedIDs:   []int64{1003, 1001, 1000},
 		ExpectedTotal: 3,
 	},
+	{
+		Name: "label groups",
+		ExtraData: []*internal.IndexerData{
+			{ID: 1000, Title: "hello a", LabelIDs: []int64{2000, 2002}},
+			{ID: 1001, Title: "hello b", LabelIDs: []int64{2001, 2003}},
+			{ID: 1002, Title: "hello c", LabelIDs: []int64{2000, 2001}},
+			{ID: 1003, Title: "hello d", LabelIDs: []int64{2003}},
+			{ID: 1004, Title: "hello e", LabelIDs: []int64{}},
+		},
+		Keyword: "hello",
+
+		SearchOptions: &internal.SearchOptions{
+			LabelIDGroups: [][]int64{{2000, 2001}, {2002, 2003}},
+		},
+		ExpectedIDs:   []int64{1001, 1000},
+		ExpectedTotal: 2,
+	},
 	{
 		Name: "MilestoneIDs",
 		SearchOptions: &internal.SearchOptions{
@@ -558,6 +575,97 @@ var cases = []*testIndexerCase{
 			}), result.Total)
 		},
 	},
+	{
+		Name: "CustomField",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			CustomFields: []internal.CustomFieldFilter{{FieldID: 1, Value: "value 1"}},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Contains(t, data[v.ID].CustomFieldValues, "1:value 1")
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return slices.Contains(v.CustomFieldValues, "1:value 1")
+			}), result.Total)
+		},
+	},
+	{
+		Name: "NoCustomField",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			CustomFields: []internal.CustomFieldFilter{{FieldID: 1}},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Empty(t, data[v.ID].CustomFieldIDs)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return len(v.CustomFieldIDs) == 0
+			}), result.Total)
+		},
+	},
+	{
+		Name: "ParentID",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			ParentID: optional.Some[int64](3),
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.Equal(t, int64(3), data[v.ID].ParentID)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return v.ParentID == 3
+			}), result.Total)
+		},
+	},
+	{
+		Name: "HasSubIssues",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			HasSubIssues: optional.Some(true),
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			for _, v := range result.Hits {
+				assert.True(t, data[v.ID].HasSubIssues)
+			}
+			assert.Equal(t, countIndexerData(data, func(v *internal.IndexerData) bool {
+				return v.HasSubIssues
+			}), result.Total)
+		},
+	},
+	{
+		Name: "Confidential",
+		SearchOptions: &internal.SearchOptions{
+			Paginator: &db.ListOptions{
+				PageSize: 5,
+			},
+			Confidential: &internal.ConfidentialFilter{UserID: 1, RepoIDs: []int64{1}},
+		},
+		Expected: func(t *testing.T, data map[int64]*internal.IndexerData, result *internal.SearchResult) {
+			assert.Len(t, result.Hits, 5)
+			visible := func(v *internal.IndexerData) bool {
+				return !v.IsConfidential || v.RepoID == 1 || slices.Contains(v.ConfidentialIDs, 1)
+			}
+			for _, v := range result.Hits {
+				assert.True(t, visible(data[v.ID]))
+			}
+			assert.Equal(t, countIndexerData(data, visible), result.Total)
+		},
+	},
 	{
 		Name: "updated",
 		SearchOptions: &internal.SearchOptions{
@@ -840,6 +948,19 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				subscriberIDs[i] = int64(i) + 1 // SubscriberID should not be 0
 			}
 
+			var customFieldIDs []int64
+			var customFieldValues []string
+			if id%4 != 0 {
+				customFieldIDs = []int64{1}
+				customFieldValues = []string{internal.CustomFieldValueToken(1, fmt.Sprintf("value %d", id%3))}
+			}
+
+			posterID := id%10 + 1 // PosterID should not be 0
+			var confidentialIDs []int64
+			if id%5 == 0 {
+				confidentialIDs = []int64{posterID}
+			}
+
 			data = append(data, &internal.IndexerData{
 				ID:                 id,
 				Index:              issueIndex,
@@ -855,12 +976,18 @@ func generateDefaultIndexerData() []*internal.IndexerData {
 				MilestoneID:        issueIndex % 4,
 				ProjectID:          issueIndex % 5,
 				ProjectColumnID:    issueIndex % 6,
-				PosterID:           id%10 + 1, // PosterID should not be 0
+				PosterID:           posterID,
 				AssigneeID:         issueIndex % 10,
 				MentionIDs:         mentionIDs,
 				ReviewedIDs:        reviewedIDs,
 				ReviewRequestedIDs: reviewRequestedIDs,
 				SubscriberIDs:      subscriberIDs,
+				CustomFieldIDs:     customFieldIDs,
+				CustomFieldValues:  customFieldValues,
+				ParentID:           id % 7,
+				HasSubIssues:       issueIndex%4 == 1,
+				IsConfidential:     id%5 == 0,
+				ConfidentialIDs:    confidentialIDs,
 				UpdatedUnix:        timeutil.TimeStamp(id + issueIndex),
 				CreatedUnix:        timeutil.TimeStamp(id),
 				DeadlineUnix:       timeutil.TimeStamp(id + issueIndex + repoID),
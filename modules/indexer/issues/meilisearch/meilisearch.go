@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	issueIndexerLatestVersion = 3
+	issueIndexerLatestVersion = 6
 
 	// TODO: make this configurable if necessary
 	maxTotalHits = 10000
@@ -72,6 +72,12 @@ func NewIndexer(url, apiKey, indexerName string) *Indexer {
 			"reviewed_ids",
 			"review_requested_ids",
 			"subscriber_ids",
+			"custom_field_ids",
+			"custom_field_values",
+			"parent_id",
+			"has_sub_issues",
+			"is_confidential",
+			"confidential_ids",
 			"updated_unix",
 		},
 		SortableAttributes: []string{
@@ -158,6 +164,9 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		} else if len(options.IncludedAnyLabelIDs) > 0 {
 			query.And(inner_meilisearch.NewFilterIn("label_ids", options.IncludedAnyLabelIDs...))
 		}
+		for _, group := range options.LabelIDGroups {
+			query.And(inner_meilisearch.NewFilterIn("label_ids", group...))
+		}
 		if len(options.ExcludedLabelIDs) > 0 {
 			q := &inner_meilisearch.FilterAnd{}
 			for _, labelID := range options.ExcludedLabelIDs {
@@ -201,6 +210,33 @@ func (b *Indexer) Search(ctx context.Context, options *internal.SearchOptions) (
 		query.And(inner_meilisearch.NewFilterEq("subscriber_ids", options.SubscriberID.Value()))
 	}
 
+	if options.ParentID.Has() {
+		query.And(inner_meilisearch.NewFilterEq("parent_id", options.ParentID.Value()))
+	}
+	if options.HasSubIssues.Has() {
+		query.And(inner_meilisearch.NewFilterEq("has_sub_issues", options.HasSubIssues.Value()))
+	}
+
+	if options.Confidential != nil {
+		q := &inner_meilisearch.FilterOr{}
+		q.Or(inner_meilisearch.NewFilterEq("is_confidential", false))
+		if options.Confidential.UserID > 0 {
+			q.Or(inner_meilisearch.NewFilterEq("confidential_ids", options.Confidential.UserID))
+		}
+		if len(options.Confidential.RepoIDs) > 0 {
+			q.Or(inner_meilisearch.NewFilterIn("repo_id", options.Confidential.RepoIDs...))
+		}
+		query.And(q)
+	}
+
+	for _, filter := range options.CustomFields {
+		if filter.Value == "" {
+			query.And(inner_meilisearch.NewFilterNot(inner_meilisearch.NewFilterEq("custom_field_ids", filter.FieldID)))
+		} else {
+			query.And(inner_meilisearch.NewFilterEqString("custom_field_values", internal.CustomFieldValueToken(filter.FieldID, filter.Value)))
+		}
+	}
+
 	if options.UpdatedAfterUnix.Has() {
 		query.And(inner_meilisearch.NewFilterGte("updated_unix", options.UpdatedAfterUnix.Value()))
 	}
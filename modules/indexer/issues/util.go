@@ -87,6 +87,31 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		return nil, false, err
 	}
 
+	customFieldValues, err := issues_model.GetIssueCustomFieldValues(ctx, []int64{issue.ID})
+	if err != nil {
+		return nil, false, err
+	}
+	customFieldIDs := make([]int64, 0, len(customFieldValues[issue.ID]))
+	customFieldTokens := make([]string, 0, len(customFieldValues[issue.ID]))
+	for _, v := range customFieldValues[issue.ID] {
+		customFieldIDs = append(customFieldIDs, v.FieldID)
+		customFieldTokens = append(customFieldTokens, internal.CustomFieldValueToken(v.FieldID, v.Value))
+	}
+
+	parentID, err := issues_model.GetParentIssueID(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	hasSubIssues, err := issues_model.HasSubIssues(ctx, issue.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	confidentialIDs, err := issues_model.ConfidentialIssueUserIDs(ctx, issue)
+	if err != nil {
+		return nil, false, err
+	}
+
 	var projectID int64
 	if issue.Project != nil {
 		projectID = issue.Project.ID
@@ -113,6 +138,12 @@ func getIssueIndexerData(ctx context.Context, issueID int64) (*internal.IndexerD
 		ReviewedIDs:        reviewedIDs,
 		ReviewRequestedIDs: reviewRequestedIDs,
 		SubscriberIDs:      subscriberIDs,
+		CustomFieldIDs:     customFieldIDs,
+		CustomFieldValues:  customFieldTokens,
+		ParentID:           parentID,
+		HasSubIssues:       hasSubIssues,
+		IsConfidential:     issue.IsConfidential,
+		ConfidentialIDs:    confidentialIDs,
 		UpdatedUnix:        issue.UpdatedUnix,
 		CreatedUnix:        issue.CreatedUnix,
 		DeadlineUnix:       issue.DeadlineUnix,
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -52,6 +53,9 @@ func validateYaml(template *api.IssueTemplate) error {
 		if err := validateLabel(field, idx); err != nil {
 			return err
 		}
+		if err := validateShowIf(field, idx, ids); err != nil {
+			return err
+		}
 
 		position := newErrorPosition(idx, field.Type)
 		switch field.Type {
@@ -158,6 +162,17 @@ func validateID(field *api.IssueFormField, idx int, ids container.Set[string]) e
 	return nil
 }
 
+func validateShowIf(field *api.IssueFormField, idx int, ids container.Set[string]) error {
+	if field.ShowIf == nil {
+		return nil
+	}
+	// the ID of the field itself is already in ids, a field can't depend on itself or on a later field
+	if field.ShowIf.ID == field.ID || !ids.Contains(field.ShowIf.ID) {
+		return newErrorPosition(idx, field.Type).Errorf("'show_if' should reference the 'id' of an earlier field")
+	}
+	return nil
+}
+
 func validateOptions(field *api.IssueFormField, idx int) error {
 	if field.Type != api.IssueFormFieldTypeDropdown && field.Type != api.IssueFormFieldTypeCheckboxes {
 		return nil
@@ -292,11 +307,28 @@ func newErrorPosition(fieldIdx int, fieldType api.IssueFormFieldType, optionInde
 	return errorPosition(ret)
 }
 
+// ShownFields returns the fields of a template which are shown with the submitted values. A field is hidden
+// when the condition of its 'show_if' is not met, or when the field it depends on is hidden.
+func ShownFields(template *api.IssueTemplate, values url.Values) []*api.IssueFormField {
+	shown := make(map[string]*valuedField, len(template.Fields))
+	fields := make([]*api.IssueFormField, 0, len(template.Fields))
+	for _, field := range template.Fields {
+		if condition := field.ShowIf; condition != nil {
+			if dependency, ok := shown[condition.ID]; !ok || !dependency.Satisfies(condition) {
+				continue
+			}
+		}
+		shown[field.ID] = &valuedField{IssueFormField: field, Values: values}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
 // RenderToMarkdown renders template to markdown with specified values
 func RenderToMarkdown(template *api.IssueTemplate, values url.Values) string {
 	builder := &strings.Builder{}
 
-	for _, field := range template.Fields {
+	for _, field := range ShownFields(template, values) {
 		f := &valuedField{
 			IssueFormField: field,
 			Values:         values,
@@ -405,6 +437,39 @@ func (f *valuedField) Value() string {
 	return strings.TrimSpace(f.Get("form-field-" + f.ID))
 }
 
+// Answers returns the value of an input or a textarea, or the labels of the selected options of a dropdown or checkboxes
+func (f *valuedField) Answers() []string {
+	switch f.Type {
+	case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+		if value := f.Value(); value != "" {
+			return []string{value}
+		}
+	case api.IssueFormFieldTypeDropdown, api.IssueFormFieldTypeCheckboxes:
+		var labels []string
+		for _, option := range f.Options() {
+			if option.IsChecked() {
+				labels = append(labels, option.Label())
+			}
+		}
+		return labels
+	}
+	return nil
+}
+
+// Satisfies returns whether the answers to the field meet a condition of a later field
+func (f *valuedField) Satisfies(condition *api.IssueFormFieldCondition) bool {
+	answers := f.Answers()
+	if len(condition.Values) == 0 {
+		return len(answers) > 0
+	}
+	for _, answer := range answers {
+		if slices.Contains(condition.Values, answer) {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *valuedField) Options() []*valuedOption {
 	if options, ok := f.Attributes["options"].([]any); ok {
 		ret := make([]*valuedOption, 0, len(options))
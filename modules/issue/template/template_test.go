@@ -746,6 +746,63 @@ Content
 			},
 			wantErr: "",
 		},
+		{
+			name: "show_if unknown id",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: input
+    id: id1
+    attributes:
+      label: a
+    show_if:
+      id: id2
+  - type: input
+    id: id2
+    attributes:
+      label: b
+`,
+			wantErr: "body[0](input): 'show_if' should reference the 'id' of an earlier field",
+		},
+		{
+			name: "show_if itself",
+			content: `
+name: "test"
+about: "this is about"
+body:
+  - type: input
+    id: id1
+    attributes:
+      label: a
+    show_if:
+      id: id1
+`,
+			wantErr: "body[0](input): 'show_if' should reference the 'id' of an earlier field",
+		},
+		{
+			name:     "assignees, milestone and project in markdown",
+			filename: "test.md",
+			content: `---
+name: Name
+about: About
+assignees: user1, user2
+milestone: v1.0
+project: Triage
+---
+Content
+`,
+			want: &api.IssueTemplate{
+				Name:      "Name",
+				About:     "About",
+				Assignees: []string{"user1", "user2"},
+				Milestone: "v1.0",
+				Project:   "Triage",
+				Content:   "Content\n",
+				FileName:  "test.md",
+			},
+			wantErr: "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -908,6 +965,62 @@ Option 1 of dropdown, Option 2 of dropdown
 	}
 }
 
+func TestShownFields(t *testing.T) {
+	template, err := Unmarshal("test.yaml", []byte(`
+name: Name
+about: About
+body:
+  - type: dropdown
+    id: kind
+    attributes:
+      label: Kind
+      options:
+        - Bug
+        - Crash
+  - type: textarea
+    id: trace
+    attributes:
+      label: Stack trace
+    validations:
+      required: true
+    show_if:
+      id: kind
+      values: [Crash]
+  - type: input
+    id: core
+    attributes:
+      label: Core dump
+    show_if:
+      id: trace
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: Agreed
+`))
+	require.NoError(t, err)
+
+	shownIDs := func(values url.Values) []string {
+		var ids []string
+		for _, field := range ShownFields(template, values) {
+			ids = append(ids, field.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"kind", "terms"}, shownIDs(url.Values{"form-field-kind": {"0"}}))
+	assert.Equal(t, []string{"kind", "trace", "terms"}, shownIDs(url.Values{"form-field-kind": {"1"}}))
+	assert.Equal(t, []string{"kind", "trace", "core", "terms"}, shownIDs(url.Values{"form-field-kind": {"1"}, "form-field-trace": {"panic"}}))
+	// a field depending on a hidden field is hidden
+	assert.Equal(t, []string{"kind", "terms"}, shownIDs(url.Values{"form-field-kind": {"0"}, "form-field-trace": {"panic"}}))
+
+	assert.Equal(t, "### Kind\n\nCrash\n\n### Stack trace\n\npanic\n\n### Core dump\n\n_No response_\n\n### Terms\n\n- [ ] Agreed\n\n",
+		RenderToMarkdown(template, url.Values{"form-field-kind": {"1"}, "form-field-trace": {"panic"}}))
+	assert.Equal(t, "### Kind\n\nBug\n\n### Terms\n\n- [ ] Agreed\n\n",
+		RenderToMarkdown(template, url.Values{"form-field-kind": {"0"}, "form-field-trace": {"panic"}}))
+}
+
 func Test_minQuotes(t *testing.T) {
 	type args struct {
 		value string
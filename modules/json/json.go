@@ -32,6 +32,9 @@ type Interface interface {
 	Indent(dst *bytes.Buffer, src []byte, prefix, indent string) error
 }
 
+// RawMessage is a raw encoded JSON value, see encoding/json.RawMessage
+type RawMessage = json.RawMessage
+
 var (
 	// DefaultJSONHandler default json handler
 	DefaultJSONHandler Interface = JSONiter{jsoniter.ConfigCompatibleWithStandardLibrary}
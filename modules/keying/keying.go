@@ -82,6 +82,18 @@ func deriveKey(context string) Context {
 	})}
 }
 
+// DeriveKeyFromIKM derives the key for a given context from the specified
+// input keying material instead of the main IKM of this module. This allows
+// keys of a subsystem to be rotated independently of the main IKM.
+func DeriveKeyFromIKM(ikm []byte, context string) Context {
+	buf, err := hkdf.Extract(hash, ikm, nil)
+	if err != nil {
+		panic(err)
+	}
+	e := expandPRK(buf, context)
+	return Context{func() cipher.AEAD { return e }}
+}
+
 func expandPRK(prk []byte, context string) cipher.AEAD {
 	if len(prk) != sha256.Size {
 		panic("keying: not initialized")
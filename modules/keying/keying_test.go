@@ -105,6 +105,24 @@ func TestKeying(t *testing.T) {
 	})
 }
 
+func TestDeriveKeyFromIKM(t *testing.T) {
+	key1 := DeriveKeyFromIKM([]byte("first master key"), "TESTING")
+	key2 := DeriveKeyFromIKM([]byte("second master key"), "TESTING")
+
+	ciphertext := key1.Encrypt([]byte("independent of the main IKM"), nil)
+
+	plaintext, err := key2.Decrypt(ciphertext, nil)
+	require.Error(t, err)
+	assert.Empty(t, plaintext)
+
+	plaintext, err = DeriveKeyFromIKM([]byte("first master key"), "TESTING").Decrypt(ciphertext, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, "independent of the main IKM", plaintext)
+
+	_, err = DeriveKeyFromIKM([]byte("first master key"), "TESTING2").Decrypt(ciphertext, nil)
+	require.Error(t, err)
+}
+
 func TestKeyingColumnAndID(t *testing.T) {
 	assert.Equal(t, []byte{0x74, 0x61, 0x62, 0x6c, 0x65, 0x3a, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, ColumnAndID("table", math.MinInt64))
 	assert.Equal(t, []byte{0x74, 0x61, 0x62, 0x6c, 0x65, 0x3a, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, ColumnAndID("table", -1))
@@ -0,0 +1,266 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sbom
+
+import (
+	"io"
+	"strings"
+
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/util"
+)
+
+// Format is the format of a SBOM document
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+)
+
+const (
+	MediaTypeCycloneDX = "application/vnd.cyclonedx+json"
+	MediaTypeSPDX      = "application/spdx+json"
+
+	// MaxDocumentSize is the maximum size of a SBOM document which gets parsed
+	MaxDocumentSize = 32 * 1024 * 1024
+)
+
+var (
+	ErrInvalidDocument   = util.NewInvalidArgumentErrorf("SBOM document is invalid")
+	ErrUnsupportedFormat = util.NewInvalidArgumentErrorf("SBOM format is not supported, only CycloneDX JSON and SPDX JSON are")
+	ErrDocumentTooLarge  = util.NewInvalidArgumentErrorf("SBOM document is too large")
+	ErrNoComponentsInBOM = util.NewInvalidArgumentErrorf("SBOM document does not contain any components")
+)
+
+// spdxUnknownLicenses are license values which do not name a license
+var spdxUnknownLicenses = container.SetOf("", "NOASSERTION", "NONE")
+
+// Document represents a parsed SBOM document
+type Document struct {
+	Format      Format
+	SpecVersion string
+	Components  []*Component
+
+	seen container.Set[string]
+}
+
+// Component is a dependency listed in a SBOM document
+type Component struct {
+	Name            string
+	Version         string
+	PURL            string
+	Type            string
+	Licenses        []string
+	Vulnerabilities []string
+}
+
+// IsSupportedMediaType checks if the media type belongs to a supported SBOM format
+func IsSupportedMediaType(mediaType string) bool {
+	return strings.EqualFold(mediaType, MediaTypeCycloneDX) || strings.EqualFold(mediaType, MediaTypeSPDX)
+}
+
+type probe struct {
+	BOMFormat   string `json:"bomFormat"`
+	SPDXVersion string `json:"spdxVersion"`
+}
+
+// ParseDocument parses a CycloneDX or SPDX document in JSON format
+func ParseDocument(r io.Reader) (*Document, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	var p probe
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, ErrInvalidDocument
+	}
+
+	var doc *Document
+	switch {
+	case p.BOMFormat == "CycloneDX":
+		doc, err = parseCycloneDX(data)
+	case strings.HasPrefix(p.SPDXVersion, "SPDX-"):
+		doc, err = parseSPDX(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc.Components) == 0 {
+		return nil, ErrNoComponentsInBOM
+	}
+	return doc, nil
+}
+
+type cycloneDXDocument struct {
+	SpecVersion     string                    `json:"specVersion"`
+	Components      []*cycloneDXComponent     `json:"components"`
+	Vulnerabilities []*cycloneDXVulnerability `json:"vulnerabilities"`
+}
+
+type cycloneDXComponent struct {
+	BOMRef     string                `json:"bom-ref"`
+	Type       string                `json:"type"`
+	Group      string                `json:"group"`
+	Name       string                `json:"name"`
+	Version    string                `json:"version"`
+	PURL       string                `json:"purl"`
+	Licenses   []*cycloneDXLicense   `json:"licenses"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXLicense struct {
+	License *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+	Expression string `json:"expression"`
+}
+
+type cycloneDXVulnerability struct {
+	ID      string `json:"id"`
+	Affects []struct {
+		Ref string `json:"ref"`
+	} `json:"affects"`
+}
+
+func parseCycloneDX(data []byte) (*Document, error) {
+	var cdx cycloneDXDocument
+	if err := json.Unmarshal(data, &cdx); err != nil {
+		return nil, ErrInvalidDocument
+	}
+
+	vulnerabilities := make(map[string][]string)
+	for _, v := range cdx.Vulnerabilities {
+		if v.ID == "" {
+			continue
+		}
+		for _, a := range v.Affects {
+			vulnerabilities[a.Ref] = append(vulnerabilities[a.Ref], v.ID)
+		}
+	}
+
+	doc := &Document{
+		Format:      FormatCycloneDX,
+		SpecVersion: cdx.SpecVersion,
+	}
+
+	var walk func([]*cycloneDXComponent)
+	walk = func(components []*cycloneDXComponent) {
+		for _, c := range components {
+			if c.Name != "" {
+				name := c.Name
+				if c.Group != "" {
+					name = c.Group + "/" + c.Name
+				}
+
+				licenses := make([]string, 0, len(c.Licenses))
+				for _, l := range c.Licenses {
+					switch {
+					case l.Expression != "":
+						licenses = append(licenses, l.Expression)
+					case l.License != nil && l.License.ID != "":
+						licenses = append(licenses, l.License.ID)
+					case l.License != nil && l.License.Name != "":
+						licenses = append(licenses, l.License.Name)
+					}
+				}
+
+				var vulns []string
+				if c.BOMRef != "" {
+					vulns = vulnerabilities[c.BOMRef]
+				}
+
+				doc.addComponent(&Component{
+					Name:            name,
+					Version:         c.Version,
+					PURL:            c.PURL,
+					Type:            c.Type,
+					Licenses:        licenses,
+					Vulnerabilities: vulns,
+				})
+			}
+			walk(c.Components)
+		}
+	}
+	walk(cdx.Components)
+
+	return doc, nil
+}
+
+type spdxDocument struct {
+	SPDXVersion string         `json:"spdxVersion"`
+	Packages    []*spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	Name                  string `json:"name"`
+	VersionInfo           string `json:"versionInfo"`
+	LicenseConcluded      string `json:"licenseConcluded"`
+	LicenseDeclared       string `json:"licenseDeclared"`
+	PrimaryPackagePurpose string `json:"primaryPackagePurpose"`
+	ExternalRefs          []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+func parseSPDX(data []byte) (*Document, error) {
+	var spdx spdxDocument
+	if err := json.Unmarshal(data, &spdx); err != nil {
+		return nil, ErrInvalidDocument
+	}
+
+	doc := &Document{
+		Format:      FormatSPDX,
+		SpecVersion: strings.TrimPrefix(spdx.SPDXVersion, "SPDX-"),
+	}
+
+	for _, p := range spdx.Packages {
+		if p.Name == "" {
+			continue
+		}
+
+		c := &Component{
+			Name:    p.Name,
+			Version: p.VersionInfo,
+			Type:    strings.ToLower(p.PrimaryPackagePurpose),
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				c.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		// The concluded license has precedence because it is the result of an analysis
+		for _, license := range []string{p.LicenseConcluded, p.LicenseDeclared} {
+			if !spdxUnknownLicenses.Contains(license) {
+				c.Licenses = []string{license}
+				break
+			}
+		}
+
+		doc.addComponent(c)
+	}
+
+	return doc, nil
+}
+
+// addComponent adds the component if it is not already listed
+func (doc *Document) addComponent(c *Component) {
+	if doc.seen == nil {
+		doc.seen = make(container.Set[string])
+	}
+	if doc.seen.Add(c.Name + "\x00" + c.Version + "\x00" + c.PURL) {
+		doc.Components = append(doc.Components, c)
+	}
+}
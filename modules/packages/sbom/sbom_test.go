@@ -0,0 +1,115 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package sbom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cycloneDXContent = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {
+      "bom-ref": "pkg:golang/golang.org/x/net@v0.17.0",
+      "type": "library",
+      "group": "golang.org/x",
+      "name": "net",
+      "version": "v0.17.0",
+      "purl": "pkg:golang/golang.org/x/net@v0.17.0",
+      "licenses": [{"license": {"id": "BSD-3-Clause"}}],
+      "components": [
+        {
+          "type": "library",
+          "name": "nested",
+          "version": "1.0.0",
+          "licenses": [{"expression": "MIT OR Apache-2.0"}]
+        }
+      ]
+    },
+    {
+      "type": "library",
+      "group": "golang.org/x",
+      "name": "net",
+      "version": "v0.17.0",
+      "purl": "pkg:golang/golang.org/x/net@v0.17.0"
+    }
+  ],
+  "vulnerabilities": [
+    {
+      "id": "CVE-2023-44487",
+      "affects": [{"ref": "pkg:golang/golang.org/x/net@v0.17.0"}]
+    }
+  ]
+}`
+
+const spdxContent = `{
+  "spdxVersion": "SPDX-2.3",
+  "packages": [
+    {
+      "name": "lodash",
+      "versionInfo": "4.17.21",
+      "licenseConcluded": "NOASSERTION",
+      "licenseDeclared": "MIT",
+      "primaryPackagePurpose": "LIBRARY",
+      "externalRefs": [
+        {"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:lodash:lodash:4.17.21"},
+        {"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/lodash@4.17.21"}
+      ]
+    }
+  ]
+}`
+
+func TestParseDocument(t *testing.T) {
+	t.Run("CycloneDX", func(t *testing.T) {
+		doc, err := ParseDocument(strings.NewReader(cycloneDXContent))
+		require.NoError(t, err)
+		assert.Equal(t, FormatCycloneDX, doc.Format)
+		assert.Equal(t, "1.5", doc.SpecVersion)
+		require.Len(t, doc.Components, 2)
+
+		c := doc.Components[0]
+		assert.Equal(t, "golang.org/x/net", c.Name)
+		assert.Equal(t, "v0.17.0", c.Version)
+		assert.Equal(t, "pkg:golang/golang.org/x/net@v0.17.0", c.PURL)
+		assert.Equal(t, "library", c.Type)
+		assert.Equal(t, []string{"BSD-3-Clause"}, c.Licenses)
+		assert.Equal(t, []string{"CVE-2023-44487"}, c.Vulnerabilities)
+
+		c = doc.Components[1]
+		assert.Equal(t, "nested", c.Name)
+		assert.Equal(t, []string{"MIT OR Apache-2.0"}, c.Licenses)
+		assert.Empty(t, c.Vulnerabilities)
+	})
+
+	t.Run("SPDX", func(t *testing.T) {
+		doc, err := ParseDocument(strings.NewReader(spdxContent))
+		require.NoError(t, err)
+		assert.Equal(t, FormatSPDX, doc.Format)
+		assert.Equal(t, "2.3", doc.SpecVersion)
+		require.Len(t, doc.Components, 1)
+
+		c := doc.Components[0]
+		assert.Equal(t, "lodash", c.Name)
+		assert.Equal(t, "4.17.21", c.Version)
+		assert.Equal(t, "pkg:npm/lodash@4.17.21", c.PURL)
+		assert.Equal(t, "library", c.Type)
+		assert.Equal(t, []string{"MIT"}, c.Licenses)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseDocument(strings.NewReader("dummy"))
+		require.ErrorIs(t, err, ErrInvalidDocument)
+
+		_, err = ParseDocument(strings.NewReader(`{"name":"test"}`))
+		require.ErrorIs(t, err, ErrUnsupportedFormat)
+
+		_, err = ParseDocument(strings.NewReader(`{"bomFormat":"CycloneDX","specVersion":"1.5","components":[]}`))
+		require.ErrorIs(t, err, ErrNoComponentsInBOM)
+	})
+}
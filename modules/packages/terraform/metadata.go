@@ -0,0 +1,247 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/util"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/go-version"
+)
+
+const (
+	PropertyOS           = "terraform.os"
+	PropertyArch         = "terraform.arch"
+	PropertyFileType     = "terraform.file_type"
+	PropertyProtocols    = "terraform.protocols"
+	PropertySigningKeyID = "terraform.signing_key_id"
+
+	KindModule   = "module"
+	KindProvider = "provider"
+
+	// DefaultProtocolVersion is announced for providers which were uploaded without a manifest
+	DefaultProtocolVersion = "5.0"
+
+	maxReadmeSize = 1 << 20
+)
+
+// FileType is the role of a file in a provider release
+type FileType string
+
+const (
+	FileTypeArchive   FileType = "archive"
+	FileTypeSHA256Sum FileType = "shasums"
+	FileTypeSignature FileType = "signature"
+	FileTypeManifest  FileType = "manifest"
+)
+
+var (
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("package version is invalid")
+	ErrInvalidFilename      = util.NewInvalidArgumentErrorf("provider filename is invalid")
+	ErrInvalidManifest      = util.NewInvalidArgumentErrorf("provider manifest is invalid")
+	ErrInvalidSHA256Sums    = util.NewInvalidArgumentErrorf("SHA256SUMS file is invalid")
+	ErrInvalidModuleArchive = util.NewInvalidArgumentErrorf("module archive is invalid")
+	ErrSignatureNotVerified = util.NewInvalidArgumentErrorf("signature could not be verified with any of the known keys")
+)
+
+var (
+	namePattern             = regexp.MustCompile(`\A[a-z0-9](?:[a-z0-9_-]{0,62}[a-z0-9])?\z`)
+	providerFilenamePattern = regexp.MustCompile(`\Aterraform-provider-([a-z0-9_-]+)_([^_]+)_(.+)\z`)
+	platformPattern         = regexp.MustCompile(`\A([a-z0-9]+)_([a-z0-9]+)\.zip\z`)
+	sha256SumsLinePattern   = regexp.MustCompile(`\A([0-9a-f]{64})\s+\*?(\S+)\z`)
+)
+
+// Metadata represents the metadata of a Terraform module or provider
+type Metadata struct {
+	Kind   string `json:"kind"`
+	Readme string `json:"readme,omitempty"`
+}
+
+// ProviderFile describes a file of a provider release
+type ProviderFile struct {
+	Type     string
+	Version  string
+	FileType FileType
+	OS       string
+	Arch     string
+}
+
+// IsValidName checks if the name is a valid module name, module system or provider type
+func IsValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// IsValidVersion checks if the version is a valid semantic version
+func IsValidVersion(v string) bool {
+	_, err := version.NewSemver(v)
+	return err == nil
+}
+
+// ModulePackageName gets the package name of a module
+func ModulePackageName(name, system string) string {
+	return name + "/" + system
+}
+
+// ProviderPackageName gets the package name of a provider
+func ProviderPackageName(providerType string) string {
+	return "terraform-provider-" + providerType
+}
+
+// ParseProviderFilename parses a filename of a provider release as produced by goreleaser
+func ParseProviderFilename(filename string) (*ProviderFile, error) {
+	m := providerFilenamePattern.FindStringSubmatch(filename)
+	if m == nil {
+		return nil, ErrInvalidFilename
+	}
+
+	pf := &ProviderFile{
+		Type:    m[1],
+		Version: m[2],
+	}
+	if !IsValidName(pf.Type) {
+		return nil, ErrInvalidName
+	}
+	if !IsValidVersion(pf.Version) {
+		return nil, ErrInvalidVersion
+	}
+
+	switch m[3] {
+	case "SHA256SUMS":
+		pf.FileType = FileTypeSHA256Sum
+	case "SHA256SUMS.sig":
+		pf.FileType = FileTypeSignature
+	case "manifest.json":
+		pf.FileType = FileTypeManifest
+	default:
+		pm := platformPattern.FindStringSubmatch(m[3])
+		if pm == nil {
+			return nil, ErrInvalidFilename
+		}
+		pf.FileType = FileTypeArchive
+		pf.OS = pm[1]
+		pf.Arch = pm[2]
+	}
+
+	return pf, nil
+}
+
+// SHA256SumsFilename gets the name of the checksum file of a provider release
+func SHA256SumsFilename(providerType, providerVersion string) string {
+	return ProviderPackageName(providerType) + "_" + providerVersion + "_SHA256SUMS"
+}
+
+// SignatureFilename gets the name of the checksum signature file of a provider release
+func SignatureFilename(providerType, providerVersion string) string {
+	return SHA256SumsFilename(providerType, providerVersion) + ".sig"
+}
+
+// ParseModuleArchive parses the module archive (.tar.gz) and extracts the README
+func ParseModuleArchive(r io.Reader) (*Metadata, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrInvalidModuleArchive
+	}
+	defer gzr.Close()
+
+	m := &Metadata{
+		Kind: KindModule,
+	}
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, ErrInvalidModuleArchive
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if strings.EqualFold(path.Clean(hd.Name), "README.md") {
+			readme, err := io.ReadAll(io.LimitReader(tr, maxReadmeSize))
+			if err != nil {
+				return nil, err
+			}
+			m.Readme = string(readme)
+		}
+	}
+
+	return m, nil
+}
+
+type manifest struct {
+	Version  int `json:"version"`
+	Metadata struct {
+		ProtocolVersions []string `json:"protocol_versions"`
+	} `json:"metadata"`
+}
+
+// ParseManifest parses a provider manifest and returns the supported protocol versions
+func ParseManifest(r io.Reader) ([]string, error) {
+	var m manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, ErrInvalidManifest
+	}
+	if m.Version != 1 || len(m.Metadata.ProtocolVersions) == 0 {
+		return nil, ErrInvalidManifest
+	}
+	return m.Metadata.ProtocolVersions, nil
+}
+
+// ParseSHA256Sums parses a SHA256SUMS file and returns the checksums by filename
+func ParseSHA256Sums(r io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		m := sha256SumsLinePattern.FindStringSubmatch(line)
+		if m == nil {
+			return nil, ErrInvalidSHA256Sums
+		}
+		sums[m[2]] = m[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(sums) == 0 {
+		return nil, ErrInvalidSHA256Sums
+	}
+
+	return sums, nil
+}
+
+// VerifySignature checks the detached signature of a SHA256SUMS file and returns the entity which made it.
+// Binary and ASCII armored signatures are supported.
+func VerifySignature(sums io.Reader, signature []byte, keyring openpgp.KeyRing) (*openpgp.Entity, error) {
+	check := openpgp.CheckDetachedSignature
+	if bytes.HasPrefix(bytes.TrimSpace(signature), []byte("-----BEGIN")) {
+		check = openpgp.CheckArmoredDetachedSignature
+	}
+
+	signer, err := check(keyring, sums, bytes.NewReader(signature), nil)
+	if err != nil {
+		return nil, ErrSignatureNotVerified
+	}
+	return signer, nil
+}
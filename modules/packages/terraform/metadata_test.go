@@ -0,0 +1,142 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProviderFilename(t *testing.T) {
+	t.Run("Archive", func(t *testing.T) {
+		pf, err := ParseProviderFilename("terraform-provider-example_1.2.3_linux_amd64.zip")
+		require.NoError(t, err)
+		assert.Equal(t, "example", pf.Type)
+		assert.Equal(t, "1.2.3", pf.Version)
+		assert.Equal(t, FileTypeArchive, pf.FileType)
+		assert.Equal(t, "linux", pf.OS)
+		assert.Equal(t, "amd64", pf.Arch)
+	})
+
+	t.Run("Checksums", func(t *testing.T) {
+		for filename, fileType := range map[string]FileType{
+			"terraform-provider-example_1.2.3_SHA256SUMS":     FileTypeSHA256Sum,
+			"terraform-provider-example_1.2.3_SHA256SUMS.sig": FileTypeSignature,
+			"terraform-provider-example_1.2.3_manifest.json":  FileTypeManifest,
+		} {
+			pf, err := ParseProviderFilename(filename)
+			require.NoError(t, err)
+			assert.Equal(t, fileType, pf.FileType)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, filename := range []string{
+			"example_1.2.3_linux_amd64.zip",
+			"terraform-provider-example_1.2.3_linux.zip",
+			"terraform-provider-example_1.2.3_linux_amd64.tar.gz",
+			"terraform-provider-Example_1.2.3_linux_amd64.zip",
+		} {
+			_, err := ParseProviderFilename(filename)
+			require.ErrorIs(t, err, ErrInvalidFilename, filename)
+		}
+
+		_, err := ParseProviderFilename("terraform-provider-example_latest_linux_amd64.zip")
+		require.ErrorIs(t, err, ErrInvalidVersion)
+	})
+}
+
+func TestParseModuleArchive(t *testing.T) {
+	createArchive := func(files map[string]string) io.Reader {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for filename, content := range files {
+			hdr := &tar.Header{
+				Name: filename,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			}
+			tw.WriteHeader(hdr)
+			tw.Write([]byte(content))
+		}
+		tw.Close()
+		zw.Close()
+		return &buf
+	}
+
+	t.Run("InvalidArchive", func(t *testing.T) {
+		_, err := ParseModuleArchive(strings.NewReader("dummy"))
+		require.ErrorIs(t, err, ErrInvalidModuleArchive)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		m, err := ParseModuleArchive(createArchive(map[string]string{
+			"main.tf":          `resource "null_resource" "test" {}`,
+			"./README.md":      "# Module",
+			"docs/README.md":   "ignored",
+			"variables.tf":     "",
+			"modules/a/new.tf": "",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, KindModule, m.Kind)
+		assert.Equal(t, "# Module", m.Readme)
+	})
+}
+
+func TestParseManifest(t *testing.T) {
+	protocols, err := ParseManifest(strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["5.0","6.0"]}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5.0", "6.0"}, protocols)
+
+	_, err = ParseManifest(strings.NewReader(`{"version":1,"metadata":{}}`))
+	require.ErrorIs(t, err, ErrInvalidManifest)
+}
+
+func TestParseSHA256Sums(t *testing.T) {
+	sums, err := ParseSHA256Sums(strings.NewReader(`
+e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  terraform-provider-example_1.2.3_linux_amd64.zip
+2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae  terraform-provider-example_1.2.3_darwin_arm64.zip
+`))
+	require.NoError(t, err)
+	assert.Len(t, sums, 2)
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", sums["terraform-provider-example_1.2.3_linux_amd64.zip"])
+
+	_, err = ParseSHA256Sums(strings.NewReader("invalid"))
+	require.ErrorIs(t, err, ErrInvalidSHA256Sums)
+}
+
+func TestVerifySignature(t *testing.T) {
+	signer, err := openpgp.NewEntity("Forgejo", "", "forgejo@example.com", nil)
+	require.NoError(t, err)
+	other, err := openpgp.NewEntity("Other", "", "other@example.com", nil)
+	require.NoError(t, err)
+
+	content := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855  terraform-provider-example_1.2.3_linux_amd64.zip\n"
+
+	var binarySignature bytes.Buffer
+	require.NoError(t, openpgp.DetachSign(&binarySignature, signer, strings.NewReader(content), nil))
+	var armoredSignature bytes.Buffer
+	require.NoError(t, openpgp.ArmoredDetachSign(&armoredSignature, signer, strings.NewReader(content), nil))
+
+	for _, signature := range [][]byte{binarySignature.Bytes(), armoredSignature.Bytes()} {
+		e, err := VerifySignature(strings.NewReader(content), signature, openpgp.EntityList{other, signer})
+		require.NoError(t, err)
+		assert.Equal(t, signer.PrimaryKey.KeyId, e.PrimaryKey.KeyId)
+
+		_, err = VerifySignature(strings.NewReader(content), signature, openpgp.EntityList{other})
+		require.ErrorIs(t, err, ErrSignatureNotVerified)
+
+		_, err = VerifySignature(strings.NewReader(content+"tampered"), signature, openpgp.EntityList{signer})
+		require.ErrorIs(t, err, ErrSignatureNotVerified)
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Audit settings
+var Audit = struct {
+	Enabled       bool   `ini:"ENABLED"`
+	Sink          string `ini:"SINK"`
+	SinkFile      string `ini:"SINK_FILE"`
+	SyslogNetwork string `ini:"SYSLOG_NETWORK"`
+	SyslogAddress string `ini:"SYSLOG_ADDRESS"`
+	SyslogTag     string `ini:"SYSLOG_TAG"`
+}{
+	Enabled:   true,
+	SyslogTag: "forgejo-audit",
+}
+
+func loadAuditFrom(rootCfg ConfigProvider) error {
+	sec := rootCfg.Section("audit")
+	if err := sec.MapTo(&Audit); err != nil {
+		return fmt.Errorf("failed to map Audit settings: %v", err)
+	}
+
+	switch Audit.Sink {
+	case "":
+	case "file":
+		if Audit.SinkFile == "" {
+			Audit.SinkFile = "audit.log"
+		}
+		if !filepath.IsAbs(Audit.SinkFile) {
+			Audit.SinkFile = filepath.Join(Log.RootPath, Audit.SinkFile)
+		}
+	case "syslog":
+	default:
+		return fmt.Errorf("invalid [audit] SINK %q, expected file or syslog", Audit.Sink)
+	}
+	return nil
+}
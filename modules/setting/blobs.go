@@ -0,0 +1,26 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+// Blobs represents the configuration of the content-addressed storage
+// which is shared by LFS objects, package blobs and attachments
+var Blobs = struct {
+	Enabled bool
+	Storage *Storage
+}{
+	Storage: &Storage{},
+}
+
+func loadBlobsFrom(rootCfg ConfigProvider) (err error) {
+	sec, _ := rootCfg.GetSection("blobs")
+	if sec == nil {
+		Blobs.Storage, err = getStorage(rootCfg, "blobs", "", nil)
+		return err
+	}
+
+	Blobs.Enabled = sec.Key("ENABLED").MustBool(false)
+
+	Blobs.Storage, err = getStorage(rootCfg, "blobs", "", sec)
+	return err
+}
@@ -95,6 +95,8 @@ var OAuth2 = struct {
 	Enabled                     bool
 	AccessTokenExpirationTime   int64
 	RefreshTokenExpirationTime  int64
+	DeviceCodeExpirationTime    int64
+	DeviceCodePollingInterval   int64
 	InvalidateRefreshTokens     bool
 	JWTSigningAlgorithm         string `ini:"JWT_SIGNING_ALGORITHM"`
 	JWTSigningPrivateKeyFile    string `ini:"JWT_SIGNING_PRIVATE_KEY_FILE"`
@@ -105,6 +107,8 @@ var OAuth2 = struct {
 	Enabled:                     true,
 	AccessTokenExpirationTime:   3600,
 	RefreshTokenExpirationTime:  730,
+	DeviceCodeExpirationTime:    900,
+	DeviceCodePollingInterval:   5,
 	InvalidateRefreshTokens:     true,
 	JWTSigningAlgorithm:         "RS256",
 	JWTSigningPrivateKeyFile:    "jwt/private.pem",
@@ -42,6 +42,7 @@ var (
 		LimitSizeAlt          int64
 		LimitSizeRubyGems     int64
 		LimitSizeSwift        int64
+		LimitSizeTerraform    int64
 		LimitSizeVagrant      int64
 		DefaultRPMSignEnabled bool
 	}{
@@ -102,6 +103,7 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeRpm = mustBytes(sec, "LIMIT_SIZE_RPM")
 	Packages.LimitSizeRubyGems = mustBytes(sec, "LIMIT_SIZE_RUBYGEMS")
 	Packages.LimitSizeSwift = mustBytes(sec, "LIMIT_SIZE_SWIFT")
+	Packages.LimitSizeTerraform = mustBytes(sec, "LIMIT_SIZE_TERRAFORM")
 	Packages.LimitSizeVagrant = mustBytes(sec, "LIMIT_SIZE_VAGRANT")
 	Packages.DefaultRPMSignEnabled = sec.Key("DEFAULT_RPM_SIGN_ENABLED").MustBool(false)
 	Packages.LimitSizeAlt = mustBytes(sec, "LIMIT_SIZE_ALT")
@@ -0,0 +1,74 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimitBucket configures the token buckets of a class of routes
+type RateLimitBucket struct {
+	Enabled bool `ini:"ENABLED"`
+	// Limit is the number of requests a user or token may make per Period
+	Limit int `ini:"LIMIT"`
+	// Burst is the size of the bucket, requests above Limit are allowed until it is empty
+	Burst int `ini:"BURST"`
+	// AnonymousLimit and AnonymousBurst apply to requests without credentials, per IP address
+	AnonymousLimit int           `ini:"ANONYMOUS_LIMIT"`
+	AnonymousBurst int           `ini:"ANONYMOUS_BURST"`
+	Period         time.Duration `ini:"PERIOD"`
+}
+
+// Rate limit settings
+var RateLimit = struct {
+	Enabled  bool
+	API      RateLimitBucket
+	Git      RateLimitBucket
+	Packages RateLimitBucket
+}{
+	API: RateLimitBucket{
+		Enabled:        true,
+		Limit:          5000,
+		AnonymousLimit: 60,
+		Period:         time.Hour,
+	},
+	Git: RateLimitBucket{
+		Enabled:        true,
+		Limit:          1000,
+		AnonymousLimit: 100,
+		Period:         time.Hour,
+	},
+	Packages: RateLimitBucket{
+		Enabled:        true,
+		Limit:          5000,
+		AnonymousLimit: 500,
+		Period:         time.Hour,
+	},
+}
+
+func loadRateLimitFrom(rootCfg ConfigProvider) error {
+	RateLimit.Enabled = rootCfg.Section("ratelimit").Key("ENABLED").MustBool(false)
+
+	for name, bucket := range map[string]*RateLimitBucket{
+		"api":      &RateLimit.API,
+		"git":      &RateLimit.Git,
+		"packages": &RateLimit.Packages,
+	} {
+		sec := rootCfg.Section("ratelimit." + name)
+		if err := sec.MapTo(bucket); err != nil {
+			return fmt.Errorf("failed to map [ratelimit.%s] settings: %v", name, err)
+		}
+		if bucket.Limit <= 0 || bucket.AnonymousLimit <= 0 || bucket.Period <= 0 {
+			return fmt.Errorf("[ratelimit.%s] LIMIT, ANONYMOUS_LIMIT and PERIOD must be positive", name)
+		}
+		if bucket.Burst < bucket.Limit {
+			bucket.Burst = bucket.Limit
+		}
+		if bucket.AnonymousBurst < bucket.AnonymousLimit {
+			bucket.AnonymousBurst = bucket.AnonymousLimit
+		}
+	}
+	return nil
+}
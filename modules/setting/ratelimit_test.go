@@ -0,0 +1,68 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"testing"
+	"time"
+
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRateLimit(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		defer test.MockProtect(&RateLimit)()
+
+		cfg, err := NewConfigProviderFromData(``)
+		require.NoError(t, err)
+		require.NoError(t, loadRateLimitFrom(cfg))
+
+		assert.False(t, RateLimit.Enabled)
+		assert.True(t, RateLimit.API.Enabled)
+		assert.Equal(t, 5000, RateLimit.API.Limit)
+		assert.Equal(t, 5000, RateLimit.API.Burst)
+		assert.Equal(t, 60, RateLimit.API.AnonymousBurst)
+		assert.Equal(t, time.Hour, RateLimit.Git.Period)
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		defer test.MockProtect(&RateLimit)()
+
+		cfg, err := NewConfigProviderFromData(`
+[ratelimit]
+ENABLED = true
+[ratelimit.api]
+LIMIT = 100
+BURST = 150
+ANONYMOUS_LIMIT = 10
+PERIOD = 1m
+[ratelimit.packages]
+ENABLED = false
+`)
+		require.NoError(t, err)
+		require.NoError(t, loadRateLimitFrom(cfg))
+
+		assert.True(t, RateLimit.Enabled)
+		assert.Equal(t, 100, RateLimit.API.Limit)
+		assert.Equal(t, 150, RateLimit.API.Burst)
+		assert.Equal(t, 10, RateLimit.API.AnonymousBurst)
+		assert.Equal(t, time.Minute, RateLimit.API.Period)
+		assert.False(t, RateLimit.Packages.Enabled)
+		assert.True(t, RateLimit.Git.Enabled)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		defer test.MockProtect(&RateLimit)()
+
+		cfg, err := NewConfigProviderFromData(`
+[ratelimit.git]
+LIMIT = 0
+`)
+		require.NoError(t, err)
+		require.ErrorContains(t, loadRateLimitFrom(cfg), "[ratelimit.git]")
+	})
+}
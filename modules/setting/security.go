@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"forgejo.org/modules/auth/password/hash"
 	"forgejo.org/modules/generate"
@@ -37,6 +38,8 @@ var (
 	PasswordCheckPwn                   bool
 	SuccessfulTokensCacheSize          int
 	DisableQueryAuthToken              bool
+	AccessTokenMaxLifetime             time.Duration
+	AccessTokenExpiryNotice            time.Duration
 )
 
 // loadSecret load the secret from ini by uriKey or verbatimKey, only one of them could be set
@@ -141,6 +144,8 @@ func loadSecurityFrom(rootCfg ConfigProvider) {
 
 	PasswordCheckPwn = sec.Key("PASSWORD_CHECK_PWN").MustBool(false)
 	SuccessfulTokensCacheSize = sec.Key("SUCCESSFUL_TOKENS_CACHE_SIZE").MustInt(20)
+	AccessTokenMaxLifetime = sec.Key("ACCESS_TOKEN_MAX_LIFETIME").MustDuration(0)
+	AccessTokenExpiryNotice = sec.Key("ACCESS_TOKEN_EXPIRY_NOTICE").MustDuration(7 * 24 * time.Hour)
 
 	InternalToken = loadSecret(sec, "INTERNAL_TOKEN_URI", "INTERNAL_TOKEN")
 	if InstallLock && InternalToken == "" {
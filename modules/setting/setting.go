@@ -137,12 +137,24 @@ func loadCommonSettingsFrom(cfg ConfigProvider) error {
 	if err := loadPackagesFrom(cfg); err != nil {
 		return err
 	}
+	if err := loadBlobsFrom(cfg); err != nil {
+		return err
+	}
+	if err := loadStorageEncryptionFrom(cfg); err != nil {
+		return err
+	}
 	if err := loadActionsFrom(cfg); err != nil {
 		return err
 	}
 	if err := loadModerationFrom(cfg); err != nil {
 		return err
 	}
+	if err := loadAuditFrom(cfg); err != nil {
+		return err
+	}
+	if err := loadRateLimitFrom(cfg); err != nil {
+		return err
+	}
 
 	loadUIFrom(cfg)
 	loadAdminFrom(cfg)
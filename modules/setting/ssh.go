@@ -51,6 +51,10 @@ var SSH = struct {
 	TrustedUserCAKeysParsed               []gossh.PublicKey  `ini:"-"`
 	PerWriteTimeout                       time.Duration      `ini:"SSH_PER_WRITE_TIMEOUT"`
 	PerWritePerKbTimeout                  time.Duration      `ini:"SSH_PER_WRITE_PER_KB_TIMEOUT"`
+	CAEnabled                             bool               `ini:"SSH_CA_ENABLED"`
+	CAKeyPath                             string             `ini:"SSH_CA_KEY"`
+	CACertificateValidity                 time.Duration      `ini:"SSH_CA_CERTIFICATE_VALIDITY"`
+	CAMaxCertificateValidity              time.Duration      `ini:"SSH_CA_MAX_CERTIFICATE_VALIDITY"`
 }{
 	Disabled:                      false,
 	StartBuiltinServer:            false,
@@ -66,6 +70,9 @@ var SSH = struct {
 	AuthorizedKeysCommandTemplate: "{{.AppPath}} --config={{.CustomConf}} serv key-{{.Key.ID}}",
 	PerWriteTimeout:               PerWriteTimeout,
 	PerWritePerKbTimeout:          PerWritePerKbTimeout,
+	CAKeyPath:                     "ssh/forgejo-ca",
+	CACertificateValidity:         8 * time.Hour,
+	CAMaxCertificateValidity:      24 * time.Hour,
 }
 
 func parseAuthorizedPrincipalsAllow(values []string) ([]string, bool) {
@@ -134,6 +141,12 @@ func loadSSHFrom(rootCfg ConfigProvider) {
 			SSH.ServerHostKeys[i] = filepath.Join(AppDataPath, key)
 		}
 	}
+	if !filepath.IsAbs(SSH.CAKeyPath) {
+		SSH.CAKeyPath = filepath.Join(AppDataPath, SSH.CAKeyPath)
+	}
+	if SSH.CAMaxCertificateValidity < SSH.CACertificateValidity {
+		SSH.CAMaxCertificateValidity = SSH.CACertificateValidity
+	}
 
 	SSH.KeygenPath = sec.Key("SSH_KEYGEN_PATH").String()
 	SSH.Port = sec.Key("SSH_PORT").MustInt(22)
@@ -143,6 +156,7 @@ func loadSSHFrom(rootCfg ConfigProvider) {
 	// When disable SSH, start builtin server value is ignored.
 	if SSH.Disabled {
 		SSH.StartBuiltinServer = false
+		SSH.CAEnabled = false
 	}
 
 	SSH.TrustedUserCAKeysFile = sec.Key("SSH_TRUSTED_USER_CA_KEYS_FILENAME").MustString(filepath.Join(SSH.RootPath, "gitea-trusted-user-ca-keys.pem"))
@@ -155,7 +169,7 @@ func loadSSHFrom(rootCfg ConfigProvider) {
 
 		SSH.TrustedUserCAKeysParsed = append(SSH.TrustedUserCAKeysParsed, pubKey)
 	}
-	if len(SSH.TrustedUserCAKeys) > 0 {
+	if len(SSH.TrustedUserCAKeys) > 0 || SSH.CAEnabled {
 		// Set the default as email,username otherwise we can leave it empty
 		sec.Key("SSH_AUTHORIZED_PRINCIPALS_ALLOW").MustString("username,email")
 	} else {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // StorageType is a type of Storage
@@ -18,11 +19,14 @@ const (
 	LocalStorageType StorageType = "local"
 	// MinioStorageType is the type descriptor for minio storage
 	MinioStorageType StorageType = "minio"
+	// WebDAVStorageType is the type descriptor for webdav storage
+	WebDAVStorageType StorageType = "webdav"
 )
 
 var storageTypes = []StorageType{
 	LocalStorageType,
 	MinioStorageType,
+	WebDAVStorageType,
 }
 
 // IsValidStorageType returns true if the given storage type is valid
@@ -50,12 +54,30 @@ type MinioStorageConfig struct {
 	ServeDirect        bool   `ini:"SERVE_DIRECT"`
 }
 
+// WebDAVStorageConfig represents the configuration for a webdav storage
+type WebDAVStorageConfig struct {
+	Endpoint           string `ini:"WEBDAV_ENDPOINT" json:",omitempty"`
+	Username           string `ini:"WEBDAV_USERNAME" json:",omitempty"`
+	Password           string `ini:"WEBDAV_PASSWORD" json:",omitempty"`
+	BasePath           string `ini:"WEBDAV_BASE_PATH" json:",omitempty"`
+	InsecureSkipVerify bool   `ini:"WEBDAV_INSECURE_SKIP_VERIFY"`
+}
+
+// StorageTieringConfig represents the configuration for keeping recent objects on local disk
+// before they are moved to the configured storage
+type StorageTieringConfig struct {
+	Path      string        `json:",omitempty"` // local directory for recent objects, tiering is disabled if empty
+	MoveAfter time.Duration // age of objects which are moved to the configured storage
+}
+
 // Storage represents configuration of storages
 type Storage struct {
-	Type          StorageType        // local or minio
-	Path          string             `json:",omitempty"` // for local type
-	TemporaryPath string             `json:",omitempty"`
-	MinioConfig   MinioStorageConfig // for minio type
+	Type          StorageType         // local, minio or webdav
+	Path          string              `json:",omitempty"` // for local type
+	TemporaryPath string              `json:",omitempty"`
+	MinioConfig   MinioStorageConfig  // for minio type
+	WebDAVConfig  WebDAVStorageConfig // for webdav type
+	Tiering       StorageTieringConfig
 }
 
 func (storage *Storage) ToShadowCopy() Storage {
@@ -66,6 +88,9 @@ func (storage *Storage) ToShadowCopy() Storage {
 	if shadowStorage.MinioConfig.SecretAccessKey != "" {
 		shadowStorage.MinioConfig.SecretAccessKey = "******"
 	}
+	if shadowStorage.WebDAVConfig.Password != "" {
+		shadowStorage.WebDAVConfig.Password = "******"
+	}
 	return shadowStorage
 }
 
@@ -102,14 +127,23 @@ func getStorage(rootCfg ConfigProvider, name, typ string, sec ConfigSection) (*S
 	overrideSec := getStorageOverrideSection(rootCfg, sec, tp, name)
 
 	targetType := targetSec.Key("STORAGE_TYPE").String()
+	var storage *Storage
 	switch targetType {
 	case string(LocalStorageType):
 		return getStorageForLocal(targetSec, overrideSec, tp, name)
 	case string(MinioStorageType):
-		return getStorageForMinio(targetSec, overrideSec, tp, name)
+		storage, err = getStorageForMinio(targetSec, overrideSec, tp, name)
+	case string(WebDAVStorageType):
+		storage, err = getStorageForWebDAV(targetSec, overrideSec, tp, name)
 	default:
 		return nil, fmt.Errorf("unsupported storage type %q", targetType)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	storage.Tiering = getStorageTiering(targetSec, overrideSec, tp, name)
+	return storage, nil
 }
 
 type targetSecType int
@@ -273,3 +307,56 @@ func getStorageForMinio(targetSec, overrideSec ConfigSection, tp targetSecType,
 	}
 	return &storage, nil
 }
+
+func getStorageForWebDAV(targetSec, overrideSec ConfigSection, tp targetSecType, name string) (*Storage, error) {
+	var storage Storage
+	storage.Type = StorageType(targetSec.Key("STORAGE_TYPE").String())
+	if err := targetSec.MapTo(&storage.WebDAVConfig); err != nil {
+		return nil, fmt.Errorf("map webdav config failed: %v", err)
+	}
+	if storage.WebDAVConfig.Endpoint == "" {
+		return nil, errors.New("WEBDAV_ENDPOINT is required for webdav storage")
+	}
+
+	var defaultPath string
+	if storage.WebDAVConfig.BasePath != "" {
+		if tp == targetSecIsStorage || tp == targetSecIsDefault {
+			defaultPath = strings.TrimSuffix(storage.WebDAVConfig.BasePath, "/") + "/" + name + "/"
+		} else {
+			defaultPath = storage.WebDAVConfig.BasePath
+		}
+	}
+	if defaultPath == "" {
+		defaultPath = name + "/"
+	}
+
+	if overrideSec != nil {
+		storage.WebDAVConfig.BasePath = ConfigSectionKeyString(overrideSec, "WEBDAV_BASE_PATH", defaultPath)
+	} else {
+		storage.WebDAVConfig.BasePath = defaultPath
+	}
+	return &storage, nil
+}
+
+// getStorageTiering reads the tiering configuration of a remote storage.
+// A tiering path shared by several storages gets a sub directory per storage.
+func getStorageTiering(targetSec, overrideSec ConfigSection, tp targetSecType, name string) StorageTieringConfig {
+	tiering := StorageTieringConfig{
+		Path:      ConfigSectionKeyString(targetSec, "TIERING_PATH", ""),
+		MoveAfter: 30 * 24 * time.Hour,
+	}
+	if tiering.Path != "" && tp != targetSecIsStorageWithName && tp != targetSecIsSec {
+		tiering.Path = filepath.Join(tiering.Path, name)
+	}
+	tiering.Path = ConfigSectionKeyString(overrideSec, "TIERING_PATH", tiering.Path)
+	if tiering.Path != "" && !filepath.IsAbs(tiering.Path) {
+		tiering.Path = filepath.Join(AppDataPath, tiering.Path)
+	}
+
+	for _, sec := range []ConfigSection{targetSec, overrideSec} {
+		if k := ConfigSectionKey(sec, "TIERING_MOVE_AFTER_DAYS"); k != nil {
+			tiering.MoveAfter = time.Duration(k.MustInt(30)) * 24 * time.Hour
+		}
+	}
+	return tiering
+}
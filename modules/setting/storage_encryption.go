@@ -0,0 +1,29 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import "errors"
+
+// StorageEncryption represents the configuration of the encryption at rest
+// of attachments, LFS objects, packages and action artifacts
+var StorageEncryption = struct {
+	Enabled            bool
+	MasterKey          string
+	PreviousMasterKeys []string
+}{}
+
+func loadStorageEncryptionFrom(rootCfg ConfigProvider) error {
+	sec := rootCfg.Section("storage_encryption")
+	StorageEncryption.Enabled = sec.Key("ENABLED").MustBool(false)
+	if !StorageEncryption.Enabled {
+		return nil
+	}
+
+	StorageEncryption.MasterKey = loadSecret(sec, "MASTER_KEY_URI", "MASTER_KEY")
+	if StorageEncryption.MasterKey == "" {
+		return errors.New("[storage_encryption].MASTER_KEY or MASTER_KEY_URI must be set if the storage encryption is enabled")
+	}
+	StorageEncryption.PreviousMasterKeys = sec.Key("PREVIOUS_MASTER_KEYS").Strings(",")
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"testing"
+
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loadStorageEncryptionFrom(t *testing.T) {
+	defer test.MockProtect(&StorageEncryption)()
+
+	cfg, err := NewConfigProviderFromData(`
+[storage_encryption]
+ENABLED = true
+MASTER_KEY = new-key
+PREVIOUS_MASTER_KEYS = old-key, older-key
+`)
+	require.NoError(t, err)
+	require.NoError(t, loadStorageEncryptionFrom(cfg))
+
+	assert.True(t, StorageEncryption.Enabled)
+	assert.Equal(t, "new-key", StorageEncryption.MasterKey)
+	assert.Equal(t, []string{"old-key", "older-key"}, StorageEncryption.PreviousMasterKeys)
+
+	cfg, err = NewConfigProviderFromData(`
+[storage_encryption]
+ENABLED = true
+`)
+	require.NoError(t, err)
+	require.Error(t, loadStorageEncryptionFrom(cfg))
+}
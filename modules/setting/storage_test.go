@@ -6,6 +6,7 @@ package setting
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -466,3 +467,40 @@ MINIO_BASE_PATH = /lfs
 	assert.True(t, LFS.Storage.MinioConfig.UseSSL)
 	assert.Equal(t, "/lfs", LFS.Storage.MinioConfig.BasePath)
 }
+
+func Test_getStorageConfigurationWebDAV(t *testing.T) {
+	cfg, err := NewConfigProviderFromData(`
+[storage]
+STORAGE_TYPE = webdav
+WEBDAV_ENDPOINT = https://dav.example.com/files/
+WEBDAV_USERNAME = forgejo
+WEBDAV_PASSWORD = secret
+TIERING_PATH = /data/tier
+
+[storage.lfs]
+WEBDAV_BASE_PATH = large-files/
+TIERING_MOVE_AFTER_DAYS = 7
+`)
+	require.NoError(t, err)
+	require.NoError(t, loadLFSFrom(cfg))
+	assert.EqualValues(t, "webdav", LFS.Storage.Type)
+	assert.Equal(t, "https://dav.example.com/files/", LFS.Storage.WebDAVConfig.Endpoint)
+	assert.Equal(t, "forgejo", LFS.Storage.WebDAVConfig.Username)
+	assert.Equal(t, "secret", LFS.Storage.WebDAVConfig.Password)
+	assert.Equal(t, "large-files/", LFS.Storage.WebDAVConfig.BasePath)
+	assert.Equal(t, filepath.Join("/data/tier", "lfs"), LFS.Storage.Tiering.Path)
+	assert.Equal(t, 7*24*time.Hour, LFS.Storage.Tiering.MoveAfter)
+	assert.Equal(t, "******", LFS.Storage.ToShadowCopy().WebDAVConfig.Password)
+
+	require.NoError(t, loadRepoArchiveFrom(cfg))
+	assert.Equal(t, "repo-archive/", RepoArchive.Storage.WebDAVConfig.BasePath)
+	assert.Equal(t, filepath.Join("/data/tier", "repo-archive"), RepoArchive.Storage.Tiering.Path)
+	assert.Equal(t, 30*24*time.Hour, RepoArchive.Storage.Tiering.MoveAfter)
+
+	cfg, err = NewConfigProviderFromData(`
+[repo-archive]
+STORAGE_TYPE = webdav
+`)
+	require.NoError(t, err)
+	require.Error(t, loadRepoArchiveFrom(cfg))
+}
@@ -0,0 +1,122 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// ErrCADisabled is returned when certificates are requested but Forgejo is not an SSH certificate authority
+var ErrCADisabled = errors.New("the SSH certificate authority is disabled")
+
+var caSigner gossh.Signer
+
+// InitCA loads the key of the certificate authority, generating it on first
+// use, and trusts it for user certificates.
+func InitCA() error {
+	exist, err := util.IsExist(setting.SSH.CAKeyPath)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		if err := os.MkdirAll(filepath.Dir(setting.SSH.CAKeyPath), 0o700); err != nil {
+			return err
+		}
+		if err := genCAKey(setting.SSH.CAKeyPath); err != nil {
+			return fmt.Errorf("failed to generate SSH CA key: %w", err)
+		}
+		logger.Info("New SSH certificate authority key is generated: %s", setting.SSH.CAKeyPath)
+	}
+
+	privateKey, err := os.ReadFile(setting.SSH.CAKeyPath)
+	if err != nil {
+		return err
+	}
+	signer, err := gossh.ParsePrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse SSH CA key %s: %w", setting.SSH.CAKeyPath, err)
+	}
+	caSigner = signer
+
+	authorizedKey := strings.TrimSpace(string(gossh.MarshalAuthorizedKey(signer.PublicKey())))
+	if !slices.Contains(setting.SSH.TrustedUserCAKeys, authorizedKey) {
+		setting.SSH.TrustedUserCAKeys = append(setting.SSH.TrustedUserCAKeys, authorizedKey)
+		setting.SSH.TrustedUserCAKeysParsed = append(setting.SSH.TrustedUserCAKeysParsed, signer.PublicKey())
+	}
+	return nil
+}
+
+func genCAKey(keyPath string) error {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	block, err := gossh.MarshalPrivateKey(privateKey, "Forgejo SSH certificate authority")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(block), 0o600); err != nil {
+		return err
+	}
+
+	pub, err := gossh.NewPublicKey(privateKey.Public())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(keyPath+".pub", gossh.MarshalAuthorizedKey(pub), 0o600)
+}
+
+// CAPublicKey returns the public key of the certificate authority, nil if it is disabled
+func CAPublicKey() gossh.PublicKey {
+	if !setting.SSH.CAEnabled || caSigner == nil {
+		return nil
+	}
+	return caSigner.PublicKey()
+}
+
+// SignUserCertificate issues a user certificate for the public key, valid for
+// the principals from now until validBefore.
+func SignUserCertificate(pub gossh.PublicKey, keyID string, principals []string, validBefore time.Time) (*gossh.Certificate, error) {
+	if !setting.SSH.CAEnabled || caSigner == nil {
+		return nil, ErrCADisabled
+	}
+
+	var serial [8]byte
+	if _, err := rand.Read(serial[:]); err != nil {
+		return nil, err
+	}
+
+	cert := &gossh.Certificate{
+		Key:             pub,
+		Serial:          binary.BigEndian.Uint64(serial[:]),
+		CertType:        gossh.UserCert,
+		KeyId:           keyID,
+		ValidPrincipals: principals,
+		// allow for clocks of clients and servers being slightly off
+		ValidAfter:  uint64(time.Now().Add(-5 * time.Minute).Unix()),
+		ValidBefore: uint64(validBefore.Unix()),
+		Permissions: gossh.Permissions{
+			Extensions: map[string]string{"permit-pty": ""},
+		},
+	}
+	if err := cert.SignCert(rand.Reader, caSigner); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
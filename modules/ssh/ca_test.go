@@ -0,0 +1,65 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestCertificateAuthority(t *testing.T) {
+	defer test.MockVariableValue(&setting.SSH.CAKeyPath, filepath.Join(t.TempDir(), "ssh", "ca"))()
+	defer test.MockVariableValue(&setting.SSH.TrustedUserCAKeys, nil)()
+	defer test.MockVariableValue(&setting.SSH.TrustedUserCAKeysParsed, nil)()
+	defer test.MockVariableValue(&caSigner, nil)()
+
+	t.Run("Disabled", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.SSH.CAEnabled, false)()
+
+		assert.Nil(t, CAPublicKey())
+	})
+
+	defer test.MockVariableValue(&setting.SSH.CAEnabled, true)()
+
+	require.NoError(t, InitCA())
+	caKey := CAPublicKey()
+	require.NotNil(t, caKey)
+	assert.FileExists(t, setting.SSH.CAKeyPath+".pub")
+	assert.Len(t, setting.SSH.TrustedUserCAKeysParsed, 1)
+
+	// The generated key is reused and trusted only once.
+	require.NoError(t, InitCA())
+	assert.Equal(t, caKey.Marshal(), CAPublicKey().Marshal())
+	assert.Len(t, setting.SSH.TrustedUserCAKeys, 1)
+
+	userPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pub, err := gossh.NewPublicKey(userPub)
+	require.NoError(t, err)
+
+	validBefore := time.Now().Add(time.Hour)
+	cert, err := SignUserCertificate(pub, "forgejo-user2", []string{"user2"}, validBefore)
+	require.NoError(t, err)
+	assert.Equal(t, "forgejo-user2", cert.KeyId)
+	assert.Equal(t, []string{"user2"}, cert.ValidPrincipals)
+	assert.EqualValues(t, validBefore.Unix(), cert.ValidBefore)
+
+	checker := gossh.CertChecker{
+		IsUserAuthority: func(auth gossh.PublicKey) bool {
+			return string(auth.Marshal()) == string(caKey.Marshal())
+		},
+	}
+	require.NoError(t, checker.CheckCert("user2", cert))
+	require.Error(t, checker.CheckCert("user1", cert))
+}
@@ -25,6 +25,12 @@ func Init(ctx context.Context) error {
 		return nil
 	}
 
+	if setting.SSH.CAEnabled {
+		if err := InitCA(); err != nil {
+			return fmt.Errorf("failed to initialize the SSH certificate authority: %w", err)
+		}
+	}
+
 	if setting.SSH.StartBuiltinServer {
 		Listen(setting.SSH.ListenHost, setting.SSH.ListenPort, setting.SSH.ServerCiphers, setting.SSH.ServerKeyExchanges, setting.SSH.ServerMACs)
 		log.Info("SSH server started on %s. Cipher list (%v), key exchange algorithms (%v), MACs (%v)",
@@ -56,6 +56,19 @@ func getExitStatusFromError(err error) int {
 	return waitStatus.ExitStatus()
 }
 
+// sshConnection formats the addresses of the session like the SSH_CONNECTION variable set by OpenSSH
+func sshConnection(session ssh.Session) string {
+	host, port, err := net.SplitHostPort(session.RemoteAddr().String())
+	if err != nil {
+		return ""
+	}
+	localHost, localPort, err := net.SplitHostPort(session.LocalAddr().String())
+	if err != nil {
+		return ""
+	}
+	return strings.Join([]string{host, port, localHost, localPort}, " ")
+}
+
 func sessionHandler(session ssh.Session) {
 	keyID := session.ConnPermissions().Extensions["forgejo-key-id"]
 
@@ -83,6 +96,7 @@ func sessionHandler(session ssh.Session) {
 		"SSH_ORIGINAL_COMMAND="+command,
 		"SKIP_MINWINSVC=1",
 		"GIT_PROTOCOL="+gitProtocol,
+		"SSH_CONNECTION="+sshConnection(session),
 	)
 
 	stdout, err := cmd.StdoutPipe()
@@ -0,0 +1,171 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
+)
+
+var _ ObjectStorage = &DeduplicatedStorage{}
+
+// DeduplicatedStorage stores objects with a content-addressed path (aa/bb/aabb0000... or aa/bb/0000...)
+// only once in the shared blob storage. All other objects and objects which were stored
+// before the deduplication got enabled are handled by the original storage.
+// Blobs are never deleted by this storage because other storages may still reference them,
+// deleting an object releases the blob which is removed by a cron task once it is unreferenced.
+type DeduplicatedStorage struct {
+	blobs    ObjectStorage
+	original ObjectStorage
+}
+
+// NewDeduplicatedStorage wraps the original storage to store content-addressed objects in the blob storage
+func NewDeduplicatedStorage(blobs, original ObjectStorage) *DeduplicatedStorage {
+	return &DeduplicatedStorage{
+		blobs:    blobs,
+		original: original,
+	}
+}
+
+// ErrBlobHashMismatch is returned when the content saved at a content-addressed path doesn't match its hash
+var ErrBlobHashMismatch = util.NewInvalidArgumentErrorf("content does not match its sha256 hash")
+
+// ReleaseBlob is called when an object with a content-addressed path is deleted. It is set by the
+// service managing the blob references, which removes the blob later if nothing references it anymore.
+var ReleaseBlob = func(hash string, size int64) error { return nil }
+
+// BlobRelativePath converts the sha256 hash aabb0000... to the blob path aa/bb/aabb0000...
+func BlobRelativePath(hash string) string {
+	return path.Join(hash[0:2], hash[2:4], hash)
+}
+
+// blobHashFromPath extracts the sha256 hash from a content-addressed path
+func blobHashFromPath(p string) (string, bool) {
+	parts := strings.Split(util.PathJoinRelX(p), "/")
+	if len(parts) != 3 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		return "", false
+	}
+
+	hash := parts[2]
+	if len(hash) == 60 { // LFS paths don't repeat the prefix
+		hash = parts[0] + parts[1] + hash
+	}
+	if len(hash) != 64 || !strings.HasPrefix(hash, parts[0]+parts[1]) {
+		return "", false
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return "", false
+		}
+	}
+	return hash, true
+}
+
+// Open opens the blob or falls back to the original storage
+func (d *DeduplicatedStorage) Open(p string) (Object, error) {
+	if hash, ok := blobHashFromPath(p); ok {
+		obj, err := d.blobs.Open(BlobRelativePath(hash))
+		if !errors.Is(err, os.ErrNotExist) {
+			return obj, err
+		}
+	}
+	return d.original.Open(p)
+}
+
+// Save stores the content in the blob storage if it is not present yet.
+// The content is written to a temporary path first and only becomes the blob
+// once its hash is verified, so a blob can't be poisoned by a mismatching upload.
+// The reader is consumed in any case to let callers verify the content.
+func (d *DeduplicatedStorage) Save(p string, r io.Reader, size int64) (int64, error) {
+	hash, ok := blobHashFromPath(p)
+	if !ok {
+		return d.original.Save(p, r, size)
+	}
+
+	blobPath := BlobRelativePath(hash)
+	if _, err := d.blobs.Stat(blobPath); err == nil {
+		return io.Copy(io.Discard, r)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+
+	tmpPath := path.Join("tmp", hex.EncodeToString(util.CryptoRandomBytes(16)))
+	defer func() {
+		if err := d.blobs.Delete(tmpPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Error("Unable to delete the temporary blob %s: %v", tmpPath, err)
+		}
+	}()
+
+	h := sha256.New()
+	written, err := d.blobs.Save(tmpPath, io.TeeReader(r, h), size)
+	if err != nil {
+		return written, err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != hash {
+		return written, ErrBlobHashMismatch
+	}
+
+	tmp, err := d.blobs.Open(tmpPath)
+	if err != nil {
+		return written, err
+	}
+	defer tmp.Close()
+	if _, err := d.blobs.Save(blobPath, tmp, written); err != nil {
+		return written, fmt.Errorf("promote blob %s: %w", hash, err)
+	}
+	return written, nil
+}
+
+// Stat returns the info of the blob or falls back to the original storage
+func (d *DeduplicatedStorage) Stat(p string) (os.FileInfo, error) {
+	if hash, ok := blobHashFromPath(p); ok {
+		fi, err := d.blobs.Stat(BlobRelativePath(hash))
+		if !errors.Is(err, os.ErrNotExist) {
+			return fi, err
+		}
+	}
+	return d.original.Stat(p)
+}
+
+// Delete removes the object from the original storage and releases the blob
+func (d *DeduplicatedStorage) Delete(p string) error {
+	if hash, ok := blobHashFromPath(p); ok {
+		fi, err := d.blobs.Stat(BlobRelativePath(hash))
+		if err == nil {
+			if err := ReleaseBlob(hash, fi.Size()); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return d.original.Delete(p)
+}
+
+// URL gets the redirect URL of the blob or falls back to the original storage
+func (d *DeduplicatedStorage) URL(p, name string, reqParams url.Values) (*url.URL, error) {
+	if hash, ok := blobHashFromPath(p); ok {
+		blobPath := BlobRelativePath(hash)
+		if _, err := d.blobs.Stat(blobPath); err == nil {
+			return d.blobs.URL(blobPath, name, reqParams)
+		}
+	}
+	return d.original.URL(p, name, reqParams)
+}
+
+// IterateObjects iterates across the objects of the original storage.
+// The deduplicated content can only be iterated using the blob storage.
+func (d *DeduplicatedStorage) IterateObjects(dirName string, fn func(path string, obj Object) error) error {
+	return d.original.IterateObjects(dirName, fn)
+}
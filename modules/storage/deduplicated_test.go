@@ -0,0 +1,134 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobHashFromPath(t *testing.T) {
+	hash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	cases := []struct {
+		Path     string
+		Expected string
+	}{
+		{"e3/b0/" + hash, hash},                          // packages
+		{"e3/b0/" + hash[4:], hash},                      // lfs
+		{"/e3/b0/" + hash, hash},                         // leading slash
+		{"e3/b1/" + hash, ""},                            // wrong prefix
+		{"e/3/" + hash, ""},                              // wrong segments
+		{"e3/b0/" + strings.ToUpper(hash), ""},           // not lower case hex
+		{"a/b/ab3c9be0-28c5-4c7d-bd37-2d8a6a2fc7f1", ""}, // attachment uuid
+		{"file.txt", ""},
+	}
+	for _, c := range cases {
+		h, ok := blobHashFromPath(c.Path)
+		assert.Equal(t, c.Expected, h, c.Path)
+		assert.Equal(t, c.Expected != "", ok, c.Path)
+	}
+}
+
+func TestDeduplicatedStorage(t *testing.T) {
+	blobs, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	lfs, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+	packages, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+
+	hash := "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73" // sha256 of "content"
+	lfsPath := "ed/70/" + hash[4:]
+	packagePath := "ed/70/" + hash
+
+	// stored before the deduplication was enabled
+	_, err = lfs.Save("aa/bb/legacy", strings.NewReader("legacy"), -1)
+	require.NoError(t, err)
+
+	dedupLFS := NewDeduplicatedStorage(blobs, lfs)
+	dedupPackages := NewDeduplicatedStorage(blobs, packages)
+
+	_, err = dedupLFS.Save(lfsPath, strings.NewReader("content"), -1)
+	require.NoError(t, err)
+
+	r := &spyCloser{Reader: strings.NewReader("content")}
+	n, err := dedupPackages.Save(packagePath, r, -1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, n)
+	remaining, _ := io.ReadAll(r)
+	assert.Empty(t, remaining, "reader must be consumed")
+
+	_, err = lfs.Stat(lfsPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = packages.Stat(packagePath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	count := 0
+	require.NoError(t, blobs.IterateObjects("", func(path string, obj Object) error {
+		assert.Equal(t, BlobRelativePath(hash), path)
+		count++
+		return nil
+	}))
+	assert.Equal(t, 1, count)
+
+	for p, s := range map[string]ObjectStorage{lfsPath: dedupLFS, packagePath: dedupPackages} {
+		obj, err := s.Open(p)
+		require.NoError(t, err)
+		data, err := io.ReadAll(obj)
+		require.NoError(t, err)
+		assert.Equal(t, "content", string(data))
+		require.NoError(t, obj.Close())
+	}
+
+	obj, err := dedupLFS.Open("aa/bb/legacy")
+	require.NoError(t, err)
+	data, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", string(data))
+	require.NoError(t, obj.Close())
+
+	// deleting must keep the shared content and release the blob
+	var released []string
+	defer test.MockVariableValue(&ReleaseBlob, func(hash string, size int64) error {
+		released = append(released, hash)
+		assert.EqualValues(t, 7, size)
+		return nil
+	})()
+	require.NoError(t, dedupLFS.Delete(lfsPath))
+	_, err = dedupPackages.Stat(packagePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{hash}, released)
+
+	// content not matching its hash never becomes a blob
+	poisoned := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" // sha256 of "hello"
+	_, err = dedupPackages.Save("2c/f2/"+poisoned, strings.NewReader("evil"), -1)
+	require.ErrorIs(t, err, ErrBlobHashMismatch)
+	_, err = blobs.Stat(BlobRelativePath(poisoned))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = dedupPackages.Save("2c/f2/"+poisoned, strings.NewReader("hello"), -1)
+	require.NoError(t, err)
+	obj, err = dedupPackages.Open("2c/f2/" + poisoned)
+	require.NoError(t, err)
+	data, err = io.ReadAll(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	require.NoError(t, obj.Close())
+
+	// no temporary content is left
+	count = 0
+	require.NoError(t, blobs.IterateObjects("tmp", func(path string, obj Object) error {
+		count++
+		return nil
+	}))
+	assert.Zero(t, count)
+}
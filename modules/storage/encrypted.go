@@ -0,0 +1,488 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+
+	"forgejo.org/modules/keying"
+	"forgejo.org/modules/util/filebuffer"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var _ ObjectStorage = &EncryptedStorage{}
+
+// Encrypted objects start with a fixed size header:
+//
+//	magic (8 bytes) | key id (8 bytes) | chunk size (4 bytes) | wrapped data encryption key
+//
+// followed by the content which is split into chunks which are encrypted
+// with the data encryption key. The nonce of a chunk is derived from its index
+// and a flag for the last chunk, which protects against reordering and truncation.
+const (
+	encryptionMagic        = "FJENC\x00\x00\x01"
+	encryptionKeyIDSize    = 8
+	encryptionChunkSize    = 64 * 1024
+	encryptionMaxChunkSize = 16 * 1024 * 1024
+	encryptionPrefixSize   = 8 + encryptionKeyIDSize + 4 // magic, key id and chunk size
+	encryptionWrappedSize  = chacha20poly1305.NonceSizeX + chacha20poly1305.KeySize + chacha20poly1305.Overhead
+	encryptionHeaderSize   = encryptionPrefixSize + encryptionWrappedSize
+)
+
+// ErrUnknownEncryptionKey is returned if an object was encrypted with a master key which is not configured
+var ErrUnknownEncryptionKey = errors.New("object was encrypted with an unknown master key")
+
+type encryptionKeyID [encryptionKeyIDSize]byte
+
+type encryptionKey struct {
+	id  encryptionKeyID
+	kek keying.Context
+}
+
+func newEncryptionKey(masterKey string) *encryptionKey {
+	k := &encryptionKey{
+		kek: keying.DeriveKeyFromIKM([]byte(masterKey), "storage_encryption"),
+	}
+	sum := sha256.Sum256([]byte("forgejo storage encryption key id:" + masterKey))
+	copy(k.id[:], sum[:])
+	return k
+}
+
+// EncryptedStorage encrypts all objects before storing them in the original storage
+// using envelope encryption: every object is encrypted with its own random data key
+// which is wrapped with a key derived from the master key.
+// Objects which were stored before the encryption got enabled are read as is.
+type EncryptedStorage struct {
+	original ObjectStorage
+	current  *encryptionKey
+	keys     map[encryptionKeyID]*encryptionKey
+}
+
+// NewEncryptedStorage wraps the original storage to encrypt objects with the master key.
+// Objects encrypted with one of the previous master keys can still be read.
+func NewEncryptedStorage(original ObjectStorage, masterKey string, previousMasterKeys []string) *EncryptedStorage {
+	e := &EncryptedStorage{
+		original: original,
+		current:  newEncryptionKey(masterKey),
+		keys:     make(map[encryptionKeyID]*encryptionKey, len(previousMasterKeys)+1),
+	}
+	e.keys[e.current.id] = e.current
+	for _, previous := range previousMasterKeys {
+		k := newEncryptionKey(previous)
+		e.keys[k.id] = k
+	}
+	return e
+}
+
+// encryptedSize returns the size of the stored object for the given content size
+func encryptedSize(size int64) int64 {
+	chunks := max(1, (size+encryptionChunkSize-1)/encryptionChunkSize)
+	return int64(encryptionHeaderSize) + size + chunks*chacha20poly1305.Overhead
+}
+
+func chunkNonce(nonce []byte, index uint64, last bool) []byte {
+	clear(nonce)
+	if last {
+		nonce[0] = 1
+	}
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], index)
+	return nonce
+}
+
+// Save encrypts the content and stores it in the original storage.
+// The returned size is the size of the unencrypted content.
+func (e *EncryptedStorage) Save(path string, r io.Reader, size int64) (int64, error) {
+	dek := make([]byte, chacha20poly1305.KeySize)
+	if _, err := rand.Read(dek); err != nil {
+		return 0, err
+	}
+	aead, err := chacha20poly1305.NewX(dek)
+	if err != nil {
+		return 0, err
+	}
+
+	header := make([]byte, encryptionPrefixSize, encryptionHeaderSize)
+	copy(header, encryptionMagic)
+	copy(header[len(encryptionMagic):], e.current.id[:])
+	binary.BigEndian.PutUint32(header[len(encryptionMagic)+encryptionKeyIDSize:], encryptionChunkSize)
+	header = append(header, e.current.kek.Encrypt(dek, header)...)
+
+	er := &encryptingReader{
+		r:       r,
+		aead:    aead,
+		out:     header,
+		pending: make([]byte, 0, encryptionChunkSize),
+		next:    make([]byte, encryptionChunkSize),
+		nonce:   make([]byte, aead.NonceSize()),
+	}
+
+	encSize := int64(-1)
+	if size >= 0 {
+		encSize = encryptedSize(size)
+	}
+	if _, err := e.original.Save(path, er, encSize); err != nil {
+		return 0, err
+	}
+	return er.n, nil
+}
+
+// Open opens the object and decrypts it while reading
+func (e *EncryptedStorage) Open(path string) (Object, error) {
+	obj, err := e.original.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := e.decryptObject(obj)
+	if err != nil {
+		_ = obj.Close()
+		return nil, err
+	}
+	return decrypted, nil
+}
+
+// Stat returns the info of the object with the size of the decrypted content
+func (e *EncryptedStorage) Stat(path string) (os.FileInfo, error) {
+	obj, err := e.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+	return obj.Stat()
+}
+
+// Delete deletes the object from the original storage
+func (e *EncryptedStorage) Delete(path string) error {
+	return e.original.Delete(path)
+}
+
+// URL is not supported for encrypted objects because they must be decrypted by Forgejo
+func (e *EncryptedStorage) URL(path, name string, reqParams url.Values) (*url.URL, error) {
+	obj, err := e.original.Open(path)
+	if err == nil {
+		header, err := readEncryptionHeader(obj)
+		_ = obj.Close()
+		if err != nil {
+			return nil, err
+		}
+		if header != nil {
+			return nil, ErrURLNotSupported
+		}
+	}
+	return e.original.URL(path, name, reqParams)
+}
+
+// IterateObjects iterates across the decrypted objects of the original storage
+func (e *EncryptedStorage) IterateObjects(dirName string, fn func(path string, obj Object) error) error {
+	return e.original.IterateObjects(dirName, func(path string, obj Object) error {
+		decrypted, err := e.decryptObject(obj)
+		if err != nil {
+			return fmt.Errorf("unable to decrypt %s: %w", path, err)
+		}
+		return fn(path, decrypted)
+	})
+}
+
+// Reencrypt encrypts the object with the current master key if it is unencrypted
+// or encrypted with a previous master key. It reports whether the object was rewritten.
+func (e *EncryptedStorage) Reencrypt(path string) (bool, error) {
+	obj, err := e.original.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer obj.Close()
+
+	header, err := readEncryptionHeader(obj)
+	if err != nil {
+		return false, err
+	}
+	if header != nil && bytes.Equal(header[len(encryptionMagic):len(encryptionMagic)+encryptionKeyIDSize], e.current.id[:]) {
+		return false, nil
+	}
+
+	decrypted, err := e.decryptObject(obj)
+	if err != nil {
+		return false, err
+	}
+
+	// The content is buffered because some storages can't overwrite an object while it is read
+	buf, err := filebuffer.CreateFromReader(decrypted, 32*1024*1024)
+	if err != nil {
+		return false, err
+	}
+	defer buf.Close()
+
+	if _, err := e.Save(path, buf, buf.Size()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ReencryptObjects encrypts all objects of the storage which are not encrypted with the current master key
+func (e *EncryptedStorage) ReencryptObjects(ctx context.Context) (int, error) {
+	var paths []string
+	if err := e.original.IterateObjects("", func(path string, obj Object) error {
+		paths = append(paths, path)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, path := range paths {
+		select {
+		case <-ctx.Done():
+			return count, ctx.Err()
+		default:
+		}
+
+		rewritten, err := e.Reencrypt(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) { // deleted in the meantime
+				continue
+			}
+			return count, fmt.Errorf("unable to reencrypt %s: %w", path, err)
+		}
+		if rewritten {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// readEncryptionHeader reads the header of the object and returns nil if the object is not encrypted.
+// The object is positioned at the start of the content afterwards.
+func readEncryptionHeader(obj Object) ([]byte, error) {
+	if _, err := obj.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	header := make([]byte, encryptionHeaderSize)
+	n, err := io.ReadFull(obj, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	if n == encryptionHeaderSize && string(header[:len(encryptionMagic)]) == encryptionMagic {
+		return header, nil
+	}
+	if _, err := obj.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// decryptObject wraps the object to decrypt it while reading. Unencrypted objects are returned as is.
+func (e *EncryptedStorage) decryptObject(obj Object) (Object, error) {
+	header, err := readEncryptionHeader(obj)
+	if err != nil || header == nil {
+		return obj, err
+	}
+
+	var id encryptionKeyID
+	copy(id[:], header[len(encryptionMagic):])
+	key, ok := e.keys[id]
+	if !ok {
+		return nil, ErrUnknownEncryptionKey
+	}
+
+	chunkSize := int64(binary.BigEndian.Uint32(header[len(encryptionMagic)+encryptionKeyIDSize:]))
+	if chunkSize == 0 || chunkSize > encryptionMaxChunkSize {
+		return nil, fmt.Errorf("invalid chunk size %d of encrypted object", chunkSize)
+	}
+
+	dek, err := key.kek.Decrypt(header[encryptionPrefixSize:], header[:encryptionPrefixSize])
+	if err != nil {
+		return nil, fmt.Errorf("unable to unwrap the data encryption key: %w", err)
+	}
+	aead, err := chacha20poly1305.NewX(dek)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := obj.Stat()
+	if err != nil {
+		return nil, err
+	}
+	payload := fi.Size() - encryptionHeaderSize
+	encChunkSize := chunkSize + chacha20poly1305.Overhead
+	chunks := (payload + encChunkSize - 1) / encChunkSize
+	if chunks == 0 || payload-chunks*chacha20poly1305.Overhead < 0 {
+		return nil, errors.New("encrypted object is truncated")
+	}
+
+	return &encryptedObject{
+		obj:        obj,
+		info:       fi,
+		aead:       aead,
+		nonce:      make([]byte, aead.NonceSize()),
+		chunkSize:  chunkSize,
+		chunks:     chunks,
+		size:       payload - chunks*chacha20poly1305.Overhead,
+		objOffset:  encryptionHeaderSize,
+		chunkIndex: -1,
+	}, nil
+}
+
+// encryptingReader encrypts the content of the underlying reader chunk by chunk.
+// It always reads one chunk ahead to know which chunk is the last one.
+type encryptingReader struct {
+	r       io.Reader
+	aead    cipher.AEAD
+	out     []byte // encrypted data which has not been read yet
+	buf     []byte
+	pending []byte // content of the chunk which gets encrypted next
+	next    []byte
+	nonce   []byte
+	index   uint64
+	started bool
+	done    bool
+	n       int64 // size of the content read from r
+}
+
+func (er *encryptingReader) readChunk(buf []byte) ([]byte, error) {
+	n, err := io.ReadFull(er.r, buf[:cap(buf)])
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		err = nil
+	}
+	er.n += int64(n)
+	return buf[:n], err
+}
+
+func (er *encryptingReader) Read(p []byte) (int, error) {
+	for len(er.out) == 0 {
+		if er.done {
+			return 0, io.EOF
+		}
+		if err := er.encryptChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, er.out)
+	er.out = er.out[n:]
+	return n, nil
+}
+
+func (er *encryptingReader) encryptChunk() error {
+	var err error
+	if !er.started {
+		if er.pending, err = er.readChunk(er.pending); err != nil {
+			return err
+		}
+		er.started = true
+	}
+	next := er.next[:0]
+	if len(er.pending) == encryptionChunkSize {
+		if next, err = er.readChunk(next); err != nil {
+			return err
+		}
+	}
+	last := len(next) == 0
+
+	er.buf = er.aead.Seal(er.buf[:0], chunkNonce(er.nonce, er.index, last), er.pending, nil)
+	er.out = er.buf
+	er.index++
+	er.pending, er.next = next, er.pending
+	er.done = last
+	return nil
+}
+
+type encryptedFileInfo struct {
+	os.FileInfo
+	size int64
+}
+
+func (fi encryptedFileInfo) Size() int64 {
+	return fi.size
+}
+
+// encryptedObject decrypts the chunks of the object on demand which allows seeking and range reads
+type encryptedObject struct {
+	obj        Object
+	info       os.FileInfo
+	aead       cipher.AEAD
+	nonce      []byte
+	chunkSize  int64
+	chunks     int64
+	size       int64 // size of the decrypted content
+	offset     int64 // position in the decrypted content
+	objOffset  int64 // position in the underlying object
+	chunk      []byte
+	chunkIndex int64
+}
+
+func (o *encryptedObject) loadChunk(index int64) error {
+	start := int64(encryptionHeaderSize) + index*(o.chunkSize+chacha20poly1305.Overhead)
+	if o.objOffset != start {
+		if _, err := o.obj.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+		o.objOffset = start
+	}
+
+	length := min(o.chunkSize, o.size-index*o.chunkSize) + chacha20poly1305.Overhead
+	buf := make([]byte, length)
+	if _, err := io.ReadFull(o.obj, buf); err != nil {
+		o.objOffset = -1
+		return err
+	}
+	o.objOffset += length
+
+	chunk, err := o.aead.Open(buf[:0], chunkNonce(o.nonce, uint64(index), index == o.chunks-1), buf, nil)
+	if err != nil {
+		o.chunkIndex = -1
+		return fmt.Errorf("unable to decrypt chunk %d: %w", index, err)
+	}
+	o.chunk = chunk
+	o.chunkIndex = index
+	return nil
+}
+
+func (o *encryptedObject) Read(p []byte) (int, error) {
+	if o.offset >= o.size {
+		return 0, io.EOF
+	}
+	index := o.offset / o.chunkSize
+	if index != o.chunkIndex {
+		if err := o.loadChunk(index); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, o.chunk[o.offset-index*o.chunkSize:])
+	o.offset += int64(n)
+	return n, nil
+}
+
+func (o *encryptedObject) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += o.offset
+	case io.SeekEnd:
+		offset += o.size
+	default:
+		return 0, errors.New("Seek: invalid whence")
+	}
+	if offset < 0 {
+		return 0, errors.New("Seek: invalid offset")
+	}
+	o.offset = offset
+	return offset, nil
+}
+
+func (o *encryptedObject) Stat() (os.FileInfo, error) {
+	return encryptedFileInfo{FileInfo: o.info, size: o.size}, nil
+}
+
+func (o *encryptedObject) Close() error {
+	return o.obj.Close()
+}
@@ -0,0 +1,176 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"forgejo.org/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readObject(t *testing.T, s ObjectStorage, path string) []byte {
+	t.Helper()
+	obj, err := s.Open(path)
+	require.NoError(t, err)
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	return data
+}
+
+func TestEncryptedStorage(t *testing.T) {
+	dir := t.TempDir()
+	original, err := NewLocalStorage(t.Context(), &setting.Storage{Path: dir})
+	require.NoError(t, err)
+
+	s := NewEncryptedStorage(original, "master-key", nil)
+
+	for _, size := range []int{0, 1, encryptionChunkSize - 1, encryptionChunkSize, encryptionChunkSize + 1, 3*encryptionChunkSize + 5} {
+		content := make([]byte, size)
+		_, _ = rand.Read(content)
+
+		for _, knownSize := range []bool{true, false} {
+			saveSize := int64(-1)
+			if knownSize {
+				saveSize = int64(size)
+			}
+			n, err := s.Save("file", bytes.NewReader(content), saveSize)
+			require.NoError(t, err)
+			assert.EqualValues(t, size, n)
+
+			fi, err := original.Stat("file")
+			require.NoError(t, err)
+			assert.Equal(t, encryptedSize(int64(size)), fi.Size())
+
+			fi, err = s.Stat("file")
+			require.NoError(t, err)
+			assert.EqualValues(t, size, fi.Size())
+
+			assert.Equal(t, content, readObject(t, s, "file"))
+			if size > 0 {
+				raw := readObject(t, original, "file")
+				assert.False(t, bytes.Contains(raw, content), "content must not be stored in plaintext")
+			}
+		}
+	}
+
+	t.Run("Seek", func(t *testing.T) {
+		content := make([]byte, 2*encryptionChunkSize+100)
+		_, _ = rand.Read(content)
+		_, err := s.Save("seek", bytes.NewReader(content), int64(len(content)))
+		require.NoError(t, err)
+
+		obj, err := s.Open("seek")
+		require.NoError(t, err)
+		defer obj.Close()
+
+		for _, offset := range []int64{encryptionChunkSize - 3, 5, 2 * encryptionChunkSize} {
+			pos, err := obj.Seek(offset, io.SeekStart)
+			require.NoError(t, err)
+			assert.Equal(t, offset, pos)
+
+			buf := make([]byte, 10)
+			_, err = io.ReadFull(obj, buf)
+			require.NoError(t, err)
+			assert.Equal(t, content[offset:offset+10], buf)
+		}
+
+		pos, err := obj.Seek(-4, io.SeekEnd)
+		require.NoError(t, err)
+		assert.EqualValues(t, len(content)-4, pos)
+		rest, err := io.ReadAll(obj)
+		require.NoError(t, err)
+		assert.Equal(t, content[len(content)-4:], rest)
+	})
+
+	t.Run("Legacy", func(t *testing.T) {
+		_, err := original.Save("legacy", strings.NewReader("plain"), -1)
+		require.NoError(t, err)
+
+		assert.Equal(t, "plain", string(readObject(t, s, "legacy")))
+		fi, err := s.Stat("legacy")
+		require.NoError(t, err)
+		assert.EqualValues(t, 5, fi.Size())
+	})
+
+	t.Run("URL", func(t *testing.T) {
+		_, err := s.URL("file", "file", nil)
+		require.ErrorIs(t, err, ErrURLNotSupported)
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		other := NewEncryptedStorage(original, "other-key", nil)
+		_, err := other.Open("file")
+		require.ErrorIs(t, err, ErrUnknownEncryptionKey)
+	})
+
+	t.Run("Tampered", func(t *testing.T) {
+		_, err := s.Save("tampered", strings.NewReader("content"), -1)
+		require.NoError(t, err)
+
+		raw := readObject(t, original, "tampered")
+		raw[len(raw)-1] ^= 1
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "tampered"), raw, 0o644))
+
+		obj, err := s.Open("tampered")
+		require.NoError(t, err)
+		defer obj.Close()
+		_, err = io.ReadAll(obj)
+		require.Error(t, err)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		content := make([]byte, 2*encryptionChunkSize)
+		_, err := s.Save("truncated", bytes.NewReader(content), -1)
+		require.NoError(t, err)
+
+		raw := readObject(t, original, "truncated")
+		raw = raw[:encryptionHeaderSize+encryptionChunkSize+16]
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "truncated"), raw, 0o644))
+
+		obj, err := s.Open("truncated")
+		require.NoError(t, err)
+		defer obj.Close()
+		_, err = io.ReadAll(obj)
+		require.Error(t, err)
+	})
+}
+
+func TestEncryptedStorageReencrypt(t *testing.T) {
+	original, err := NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+
+	_, err = original.Save("plain", strings.NewReader("plain"), -1)
+	require.NoError(t, err)
+	_, err = NewEncryptedStorage(original, "old-key", nil).Save("old", strings.NewReader("old"), -1)
+	require.NoError(t, err)
+
+	s := NewEncryptedStorage(original, "new-key", []string{"old-key"})
+	_, err = s.Save("new", strings.NewReader("new"), -1)
+	require.NoError(t, err)
+
+	assert.Equal(t, "old", string(readObject(t, s, "old")))
+
+	count, err := s.ReencryptObjects(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = s.ReencryptObjects(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	rotated := NewEncryptedStorage(original, "new-key", nil)
+	for _, name := range []string{"plain", "old", "new"} {
+		assert.Equal(t, name, string(readObject(t, rotated, name)))
+	}
+}
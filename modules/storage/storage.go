@@ -110,11 +110,15 @@ var (
 	Actions ObjectStorage = UninitializedStorage
 	// Actions Artifacts represents actions artifacts storage
 	ActionsArtifacts ObjectStorage = UninitializedStorage
+
+	// Blobs represents the content-addressed storage shared by lfs, packages and attachments
+	Blobs ObjectStorage = UninitializedStorage
 )
 
 // Init init the storage
 func Init() error {
 	for _, f := range []func() error{
+		initBlobs,
 		initAttachments,
 		initAvatars,
 		initRepoAvatars,
@@ -140,7 +144,38 @@ func NewStorage(typStr Type, cfg *setting.Storage) (ObjectStorage, error) {
 		return nil, fmt.Errorf("Unsupported storage type: %s", typStr)
 	}
 
-	return fn(context.Background(), cfg)
+	s, err := fn(context.Background(), cfg)
+	if err != nil || typStr == setting.LocalStorageType || cfg.Tiering.Path == "" {
+		return s, err
+	}
+	return NewTieredStorage(context.Background(), s, cfg)
+}
+
+func initBlobs() (err error) {
+	if !setting.Blobs.Enabled {
+		Blobs = DiscardStorage("Content deduplication isn't enabled")
+		return nil
+	}
+	log.Info("Initialising Blobs storage with type: %s", setting.Blobs.Storage.Type)
+	Blobs, err = NewStorage(setting.Blobs.Storage.Type, setting.Blobs.Storage)
+	Blobs = encrypted(Blobs)
+	return err
+}
+
+// deduplicated stores the content-addressed objects of the storage in the shared blob storage if enabled
+func deduplicated(s ObjectStorage) ObjectStorage {
+	if !setting.Blobs.Enabled || s == nil {
+		return s
+	}
+	return NewDeduplicatedStorage(Blobs, s)
+}
+
+// encrypted encrypts the objects of the storage at rest if enabled
+func encrypted(s ObjectStorage) ObjectStorage {
+	if !setting.StorageEncryption.Enabled || s == nil {
+		return s
+	}
+	return NewEncryptedStorage(s, setting.StorageEncryption.MasterKey, setting.StorageEncryption.PreviousMasterKeys)
 }
 
 func initAvatars() (err error) {
@@ -156,6 +191,7 @@ func initAttachments() (err error) {
 	}
 	log.Info("Initialising Attachment storage with type: %s", setting.Attachment.Storage.Type)
 	Attachments, err = NewStorage(setting.Attachment.Storage.Type, setting.Attachment.Storage)
+	Attachments = deduplicated(encrypted(Attachments))
 	return err
 }
 
@@ -166,6 +202,7 @@ func initLFS() (err error) {
 	}
 	log.Info("Initialising LFS storage with type: %s", setting.LFS.Storage.Type)
 	LFS, err = NewStorage(setting.LFS.Storage.Type, setting.LFS.Storage)
+	LFS = deduplicated(encrypted(LFS))
 	return err
 }
 
@@ -188,6 +225,7 @@ func initPackages() (err error) {
 	}
 	log.Info("Initialising Packages storage with type: %s", setting.Packages.Storage.Type)
 	Packages, err = NewStorage(setting.Packages.Storage.Type, setting.Packages.Storage)
+	Packages = deduplicated(encrypted(Packages))
 	return err
 }
 
@@ -203,5 +241,6 @@ func initActions() (err error) {
 	}
 	log.Info("Initialising ActionsArtifacts storage with type: %s", setting.Actions.ArtifactStorage.Type)
 	ActionsArtifacts, err = NewStorage(setting.Actions.ArtifactStorage.Type, setting.Actions.ArtifactStorage)
+	ActionsArtifacts = encrypted(ActionsArtifacts)
 	return err
 }
@@ -0,0 +1,175 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+)
+
+var _ ObjectStorage = &TieredStorage{}
+
+var (
+	tieredStoragesMutex sync.Mutex
+	tieredStorages      = map[string]*TieredStorage{}
+)
+
+// TieredStorage keeps new objects on the local disk and moves them
+// to the underlying (usually remote) storage once they got old enough
+type TieredStorage struct {
+	hot       ObjectStorage
+	cold      ObjectStorage
+	moveAfter time.Duration
+}
+
+// NewTieredStorage wraps the cold storage with a local hot tier configured by cfg.Tiering
+func NewTieredStorage(ctx context.Context, cold ObjectStorage, cfg *setting.Storage) (*TieredStorage, error) {
+	if !filepath.IsAbs(cfg.Tiering.Path) {
+		return nil, errors.New("StorageTieringConfig.Path should have been prepared by setting/storage.go and should be an absolute path")
+	}
+
+	log.Info("Creating new Tiered Storage at %s", cfg.Tiering.Path)
+	hot, err := NewLocalStorage(ctx, &setting.Storage{
+		Path:          filepath.Join(cfg.Tiering.Path, "objects"),
+		TemporaryPath: filepath.Join(cfg.Tiering.Path, "tmp"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t := &TieredStorage{
+		hot:       hot,
+		cold:      cold,
+		moveAfter: cfg.Tiering.MoveAfter,
+	}
+
+	tieredStoragesMutex.Lock()
+	tieredStorages[cfg.Tiering.Path] = t
+	tieredStoragesMutex.Unlock()
+
+	return t, nil
+}
+
+// Open opens the object from the hot tier if present, otherwise from the cold tier
+func (t *TieredStorage) Open(path string) (Object, error) {
+	obj, err := t.hot.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t.cold.Open(path)
+	}
+	return obj, err
+}
+
+// Save stores the object in the hot tier
+func (t *TieredStorage) Save(path string, r io.Reader, size int64) (int64, error) {
+	return t.hot.Save(path, r, size)
+}
+
+// Stat returns the info of the object from the hot tier if present, otherwise from the cold tier
+func (t *TieredStorage) Stat(path string) (os.FileInfo, error) {
+	fi, err := t.hot.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t.cold.Stat(path)
+	}
+	return fi, err
+}
+
+// Delete removes the object from both tiers
+func (t *TieredStorage) Delete(path string) error {
+	if err := t.hot.Delete(path); err != nil {
+		return err
+	}
+	return t.cold.Delete(path)
+}
+
+// URL returns the direct url of the object if it has already been moved to the cold tier
+func (t *TieredStorage) URL(path, name string, reqParams url.Values) (*url.URL, error) {
+	if _, err := t.hot.Stat(path); err == nil {
+		return nil, ErrURLNotSupported
+	}
+	return t.cold.URL(path, name, reqParams)
+}
+
+// IterateObjects iterates across the objects of both tiers
+func (t *TieredStorage) IterateObjects(dirName string, fn func(path string, obj Object) error) error {
+	seen := make(container.Set[string])
+	if err := t.hot.IterateObjects(dirName, func(path string, obj Object) error {
+		path = filepath.ToSlash(path)
+		seen.Add(path)
+		return fn(path, obj)
+	}); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return t.cold.IterateObjects(dirName, func(path string, obj Object) error {
+		if seen.Contains(path) {
+			return nil
+		}
+		return fn(path, obj)
+	})
+}
+
+// MoveExpiredObjects moves all objects of the hot tier which are older than the configured age to the cold tier
+func (t *TieredStorage) MoveExpiredObjects(ctx context.Context) (int, error) {
+	threshold := time.Now().Add(-t.moveAfter)
+
+	var expired []string
+	if err := t.hot.IterateObjects("", func(path string, obj Object) error {
+		fi, err := obj.Stat()
+		if err != nil {
+			return err
+		}
+		if fi.ModTime().Before(threshold) {
+			expired = append(expired, filepath.ToSlash(path))
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, path := range expired {
+		select {
+		case <-ctx.Done():
+			return moved, ctx.Err()
+		default:
+		}
+
+		if _, err := Copy(t.cold, path, t.hot, path); err != nil {
+			return moved, err
+		}
+		if err := t.hot.Delete(path); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+// MoveTieredObjects moves the expired objects of all tiered storages to their cold tier
+func MoveTieredObjects(ctx context.Context) error {
+	tieredStoragesMutex.Lock()
+	storages := make(map[string]*TieredStorage, len(tieredStorages))
+	for path, t := range tieredStorages {
+		storages[path] = t
+	}
+	tieredStoragesMutex.Unlock()
+
+	for path, t := range storages {
+		moved, err := t.MoveExpiredObjects(ctx)
+		if err != nil {
+			return err
+		}
+		log.Debug("Moved %d objects from %s to the cold storage", moved, path)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"forgejo.org/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTieredStorageIterator(t *testing.T) {
+	server := newTestWebDAVServer(t)
+	testStorageIterator(t, setting.WebDAVStorageType, &setting.Storage{
+		WebDAVConfig: setting.WebDAVStorageConfig{
+			Endpoint: server.URL + "/dav/",
+			Username: "forgejo",
+			Password: "secret",
+		},
+		Tiering: setting.StorageTieringConfig{
+			Path:      t.TempDir(),
+			MoveAfter: time.Hour,
+		},
+	})
+}
+
+func TestTieredStorage(t *testing.T) {
+	server := newTestWebDAVServer(t)
+	tieringPath := t.TempDir()
+
+	s, err := NewStorage(setting.WebDAVStorageType, &setting.Storage{
+		WebDAVConfig: setting.WebDAVStorageConfig{
+			Endpoint: server.URL + "/dav/",
+			Username: "forgejo",
+			Password: "secret",
+		},
+		Tiering: setting.StorageTieringConfig{
+			Path:      tieringPath,
+			MoveAfter: time.Hour,
+		},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &TieredStorage{}, s)
+	ts := s.(*TieredStorage)
+
+	_, err = s.Save("old/file", strings.NewReader("old"), -1)
+	require.NoError(t, err)
+	_, err = s.Save("new/file", strings.NewReader("new"), -1)
+	require.NoError(t, err)
+
+	oldPath := filepath.Join(tieringPath, "objects", "old", "file")
+	past := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, os.Chtimes(oldPath, past, past))
+
+	moved, err := ts.MoveExpiredObjects(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, moved)
+
+	assert.NoFileExists(t, oldPath)
+	assert.FileExists(t, filepath.Join(tieringPath, "objects", "new", "file"))
+
+	_, err = ts.cold.Stat("old/file")
+	require.NoError(t, err)
+	_, err = ts.cold.Stat("new/file")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	for path, content := range map[string]string{"old/file": "old", "new/file": "new"} {
+		obj, err := s.Open(path)
+		require.NoError(t, err)
+		buf, err := io.ReadAll(obj)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(buf))
+		require.NoError(t, obj.Close())
+	}
+
+	require.NoError(t, s.Delete("old/file"))
+	_, err = s.Stat("old/file")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
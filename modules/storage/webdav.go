@@ -0,0 +1,489 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+)
+
+var _ ObjectStorage = &WebDAVStorage{}
+
+// WebDAVStorage represents a storage on a WebDAV server
+type WebDAVStorage struct {
+	ctx      context.Context
+	client   *http.Client
+	endpoint *url.URL
+	username string
+	password string
+	basePath string
+
+	collections sync.Map // collections which are known to exist
+}
+
+// NewWebDAVStorage returns a webdav storage
+func NewWebDAVStorage(ctx context.Context, cfg *setting.Storage) (ObjectStorage, error) {
+	config := cfg.WebDAVConfig
+
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webdav endpoint %q: %w", config.Endpoint, err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("invalid webdav endpoint %q: scheme must be http or https", config.Endpoint)
+	}
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/")
+
+	log.Info("Creating WebDAV storage at %s with base path %s", endpoint.Redacted(), config.BasePath)
+
+	s := &WebDAVStorage{
+		ctx: ctx,
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify},
+			},
+		},
+		endpoint: endpoint,
+		username: config.Username,
+		password: config.Password,
+		basePath: strings.Trim(config.BasePath, "/"),
+	}
+
+	// Check the connection and the credentials before the storage is used
+	if err := s.makeCollections(s.basePath); err != nil {
+		return nil, fmt.Errorf("webdav storage at %s is not usable: %w", endpoint.Redacted(), err)
+	}
+
+	return s, nil
+}
+
+func (w *WebDAVStorage) buildWebDAVPath(p string) string {
+	p = util.PathJoinRelX(w.basePath, p)
+	if p == "." {
+		p = ""
+	}
+	return p
+}
+
+func (w *WebDAVStorage) buildURL(p string) string {
+	u := *w.endpoint
+	u.Path = w.endpoint.Path + "/" + p
+	u.RawPath = ""
+	return u.String()
+}
+
+func (w *WebDAVStorage) do(method, p string, body io.Reader, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(w.ctx, method, w.buildURL(p), body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if w.username != "" || w.password != "" {
+		req.SetBasicAuth(w.username, w.password)
+	}
+	return w.client.Do(req)
+}
+
+func convertWebDAVStatus(method, p string, resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return os.ErrNotExist
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return os.ErrPermission
+	}
+	return fmt.Errorf("webdav %s %q failed: %s", method, p, resp.Status)
+}
+
+// makeCollections creates all collections of the path which do not exist yet
+func (w *WebDAVStorage) makeCollections(p string) error {
+	if p == "" {
+		return nil
+	}
+
+	current := ""
+	for _, part := range strings.Split(p, "/") {
+		current = path.Join(current, part)
+		if _, ok := w.collections.Load(current); ok {
+			continue
+		}
+
+		resp, err := w.do("MKCOL", current+"/", nil, nil)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusCreated, http.StatusOK:
+		// 405 is returned if the collection exists already
+		case http.StatusMethodNotAllowed:
+		default:
+			return convertWebDAVStatus("MKCOL", current, resp)
+		}
+		w.collections.Store(current, struct{}{})
+	}
+	return nil
+}
+
+// Open opens a file
+func (w *WebDAVStorage) Open(p string) (Object, error) {
+	fi, err := w.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if fi.IsDir() {
+		return nil, os.ErrNotExist
+	}
+	return &webdavObject{
+		storage: w,
+		path:    w.buildWebDAVPath(p),
+		info:    fi,
+	}, nil
+}
+
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// Save saves a file to the webdav server
+func (w *WebDAVStorage) Save(p string, r io.Reader, size int64) (int64, error) {
+	p = w.buildWebDAVPath(p)
+	if dir := path.Dir(p); dir != "." {
+		if err := w.makeCollections(dir); err != nil {
+			return 0, err
+		}
+	}
+
+	// countingReader hides the Close method, so the reader of the caller is not closed by the request
+	cr := &countingReader{Reader: r}
+	req, err := http.NewRequestWithContext(w.ctx, http.MethodPut, w.buildURL(p), cr)
+	if err != nil {
+		return 0, err
+	}
+	if size >= 0 {
+		req.ContentLength = size
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if w.username != "" || w.password != "" {
+		req.SetBasicAuth(w.username, w.password)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return cr.n, nil
+	default:
+		return 0, convertWebDAVStatus(http.MethodPut, p, resp)
+	}
+}
+
+type webdavFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	isDir   bool
+}
+
+func (fi *webdavFileInfo) Name() string {
+	return fi.name
+}
+
+func (fi *webdavFileInfo) Size() int64 {
+	return fi.size
+}
+
+func (fi *webdavFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+
+func (fi *webdavFileInfo) IsDir() bool {
+	return fi.isDir
+}
+
+func (fi *webdavFileInfo) Mode() os.FileMode {
+	if fi.isDir {
+		return os.ModeDir | os.ModePerm
+	}
+	return os.ModePerm
+}
+
+func (fi *webdavFileInfo) Sys() any {
+	return nil
+}
+
+type webdavMultiStatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		PropStat []struct {
+			Prop struct {
+				ResourceType struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+				ContentLength string `xml:"getcontentlength"`
+				LastModified  string `xml:"getlastmodified"`
+			} `xml:"prop"`
+			Status string `xml:"status"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+const webdavPropFindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:"><d:prop><d:resourcetype/><d:getcontentlength/><d:getlastmodified/></d:prop></d:propfind>`
+
+type webdavEntry struct {
+	path string // path relative to the endpoint
+	info *webdavFileInfo
+}
+
+// propFind lists the resource and, with depth 1, its direct members
+func (w *WebDAVStorage) propFind(p string, depth int) ([]*webdavEntry, error) {
+	resp, err := w.do("PROPFIND", p, strings.NewReader(webdavPropFindBody), http.Header{
+		"Depth":        []string{strconv.Itoa(depth)},
+		"Content-Type": []string{"application/xml; charset=utf-8"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, convertWebDAVStatus("PROPFIND", p, resp)
+	}
+
+	var ms webdavMultiStatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*webdavEntry, 0, len(ms.Responses))
+	for _, r := range ms.Responses {
+		href, err := url.Parse(r.Href)
+		if err != nil {
+			return nil, err
+		}
+		rel, ok := strings.CutPrefix(href.Path, w.endpoint.Path+"/")
+		if !ok {
+			log.Warn("WebDAV server returned the unexpected resource %q", r.Href)
+			continue
+		}
+		rel = strings.TrimSuffix(rel, "/")
+
+		fi := &webdavFileInfo{name: path.Base(rel)}
+		for _, ps := range r.PropStat {
+			if !strings.Contains(ps.Status, " 200 ") {
+				continue
+			}
+			fi.isDir = ps.Prop.ResourceType.Collection != nil
+			fi.size, _ = strconv.ParseInt(ps.Prop.ContentLength, 10, 64)
+			fi.modTime, _ = http.ParseTime(ps.Prop.LastModified)
+		}
+		entries = append(entries, &webdavEntry{path: rel, info: fi})
+	}
+	return entries, nil
+}
+
+// Stat returns the stat information of the object
+func (w *WebDAVStorage) Stat(p string) (os.FileInfo, error) {
+	p = w.buildWebDAVPath(p)
+	entries, err := w.propFind(p, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, os.ErrNotExist
+	}
+	return entries[0].info, nil
+}
+
+// Delete deletes a file
+func (w *WebDAVStorage) Delete(p string) error {
+	p = w.buildWebDAVPath(p)
+	resp, err := w.do(http.MethodDelete, p, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusAccepted, http.StatusNotFound:
+		return nil
+	default:
+		return convertWebDAVStatus(http.MethodDelete, p, resp)
+	}
+}
+
+// URL gets the redirect URL to a file
+func (w *WebDAVStorage) URL(path, name string, reqParams url.Values) (*url.URL, error) {
+	return nil, ErrURLNotSupported
+}
+
+// IterateObjects iterates across the objects in the webdav storage
+func (w *WebDAVStorage) IterateObjects(dirName string, fn func(path string, obj Object) error) error {
+	dir := w.buildWebDAVPath(dirName)
+
+	pending := []string{dir}
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+
+		select {
+		case <-w.ctx.Done():
+			return w.ctx.Err()
+		default:
+		}
+
+		collection := current
+		if collection != "" {
+			collection += "/"
+		}
+		entries, err := w.propFind(collection, 1)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+
+		for _, entry := range entries {
+			if entry.path == current {
+				continue
+			}
+			if entry.info.isDir {
+				pending = append(pending, entry.path)
+				continue
+			}
+
+			relPath := entry.path
+			if w.basePath != "" {
+				relPath = strings.TrimPrefix(relPath, w.basePath+"/")
+			}
+			if err := func() error {
+				obj := &webdavObject{storage: w, path: entry.path, info: entry.info}
+				defer obj.Close()
+				return fn(relPath, obj)
+			}(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// webdavObject reads an object with (ranged) GET requests on demand
+type webdavObject struct {
+	storage *WebDAVStorage
+	path    string
+	info    os.FileInfo
+	offset  int64
+	body    io.ReadCloser
+}
+
+func (o *webdavObject) Read(p []byte) (int, error) {
+	if o.offset >= o.info.Size() {
+		return 0, io.EOF
+	}
+
+	if o.body == nil {
+		header := http.Header{}
+		if o.offset > 0 {
+			header.Set("Range", fmt.Sprintf("bytes=%d-", o.offset))
+		}
+		resp, err := o.storage.do(http.MethodGet, o.path, nil, header)
+		if err != nil {
+			return 0, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusPartialContent:
+		case http.StatusOK:
+			// The server does not support ranges, so the skipped part must be discarded
+			if o.offset > 0 {
+				if _, err := io.CopyN(io.Discard, resp.Body, o.offset); err != nil {
+					resp.Body.Close()
+					return 0, err
+				}
+			}
+		default:
+			resp.Body.Close()
+			return 0, convertWebDAVStatus(http.MethodGet, o.path, resp)
+		}
+		o.body = resp.Body
+	}
+
+	n, err := o.body.Read(p)
+	o.offset += int64(n)
+	return n, err
+}
+
+func (o *webdavObject) Seek(offset int64, whence int) (int64, error) {
+	var abs int64
+	switch whence {
+	case io.SeekStart:
+		abs = offset
+	case io.SeekCurrent:
+		abs = o.offset + offset
+	case io.SeekEnd:
+		abs = o.info.Size() + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+	if abs < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	if abs != o.offset && o.body != nil {
+		o.body.Close()
+		o.body = nil
+	}
+	o.offset = abs
+	return abs, nil
+}
+
+func (o *webdavObject) Close() error {
+	if o.body == nil {
+		return nil
+	}
+	err := o.body.Close()
+	o.body = nil
+	return err
+}
+
+func (o *webdavObject) Stat() (os.FileInfo, error) {
+	return o.info, nil
+}
+
+func init() {
+	RegisterStorageType(setting.WebDAVStorageType, NewWebDAVStorage)
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package storage
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"forgejo.org/modules/setting"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/webdav"
+)
+
+func newTestWebDAVServer(t *testing.T) *httptest.Server {
+	handler := &webdav.Handler{
+		Prefix:     "/dav",
+		FileSystem: webdav.Dir(t.TempDir()),
+		LockSystem: webdav.NewMemLS(),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "forgejo" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWebDAVStorageIterator(t *testing.T) {
+	server := newTestWebDAVServer(t)
+	testStorageIterator(t, setting.WebDAVStorageType, &setting.Storage{
+		WebDAVConfig: setting.WebDAVStorageConfig{
+			Endpoint: server.URL + "/dav/",
+			Username: "forgejo",
+			Password: "secret",
+			BasePath: "attachments/",
+		},
+	})
+}
+
+func TestWebDAVStorage(t *testing.T) {
+	server := newTestWebDAVServer(t)
+
+	_, err := NewWebDAVStorage(t.Context(), &setting.Storage{
+		WebDAVConfig: setting.WebDAVStorageConfig{
+			Endpoint: server.URL + "/dav/",
+			Username: "forgejo",
+			Password: "wrong",
+			BasePath: "lfs/",
+		},
+	})
+	require.ErrorIs(t, err, os.ErrPermission)
+
+	s, err := NewWebDAVStorage(t.Context(), &setting.Storage{
+		WebDAVConfig: setting.WebDAVStorageConfig{
+			Endpoint: server.URL + "/dav/",
+			Username: "forgejo",
+			Password: "secret",
+			BasePath: "lfs/",
+		},
+	})
+	require.NoError(t, err)
+
+	content := "0123456789"
+	n, err := s.Save("ab/cd/file", strings.NewReader(content), -1)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(content), n)
+
+	fi, err := s.Stat("ab/cd/file")
+	require.NoError(t, err)
+	assert.EqualValues(t, len(content), fi.Size())
+	assert.Equal(t, "file", fi.Name())
+
+	obj, err := s.Open("ab/cd/file")
+	require.NoError(t, err)
+	_, err = obj.Seek(4, io.SeekStart)
+	require.NoError(t, err)
+	buf, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	assert.Equal(t, "456789", string(buf))
+	require.NoError(t, obj.Close())
+
+	_, err = s.URL("ab/cd/file", "file", nil)
+	require.ErrorIs(t, err, ErrURLNotSupported)
+
+	require.NoError(t, s.Delete("ab/cd/file"))
+	require.NoError(t, s.Delete("ab/cd/file"))
+
+	_, err = s.Stat("ab/cd/file")
+	require.ErrorIs(t, err, os.ErrNotExist)
+	_, err = s.Open("ab/cd/file")
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = s.Save("empty", bytes.NewReader(nil), 0)
+	require.NoError(t, err)
+	fi, err = s.Stat("empty")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, fi.Size())
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import "time"
+
+// AuditEvent represents an entry of the audit log
+type AuditEvent struct {
+	ID int64 `json:"id"`
+	// the kind of event, e.g. user.login or repository.delete
+	Action    string `json:"action"`
+	ActorID   int64  `json:"actor_id"`
+	ActorName string `json:"actor_name"`
+	IPAddress string `json:"ip_address"`
+	// the user or organization owning the target
+	OwnerID int64 `json:"owner_id"`
+	RepoID  int64 `json:"repo_id"`
+	// enum: ["user", "organization", "repository", "team", "access_token", "security_key", "branch_protection", "secret", "auth_source", "system"]
+	TargetType string         `json:"target_type"`
+	TargetID   int64          `json:"target_id"`
+	TargetName string         `json:"target_name"`
+	Before     map[string]any `json:"before,omitempty"`
+	After      map[string]any `json:"after,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+}
@@ -80,6 +80,9 @@ type Issue struct {
 	Repo        *RepositoryMeta  `json:"repository"`
 
 	PinOrder int `json:"pin_order"`
+
+	// Whether the issue is only visible to its poster, its assignees and the users with write access
+	IsConfidential bool `json:"is_confidential"`
 }
 
 // CreateIssueOption options to create one issue
@@ -98,6 +101,14 @@ type CreateIssueOption struct {
 	// list of label ids
 	Labels []int64 `json:"labels"`
 	Closed bool    `json:"closed"`
+	// whether the issue is only visible to its poster, its assignees and the users with write access
+	Confidential bool `json:"confidential"`
+	// path of an issue template of the default branch, its labels, milestone, project and assignees
+	// are used when none are given, and the body of an issue form is rendered from the template fields
+	Template string `json:"template"`
+	// answers to the fields of an issue form by field id, dropdowns and checkboxes take the
+	// comma-separated indexes of their selected options
+	TemplateFields map[string]string `json:"template_fields"`
 }
 
 // EditIssueOption options for editing an issue
@@ -115,6 +126,8 @@ type EditIssueOption struct {
 	RemoveDeadline *bool      `json:"unset_due_date"`
 	// swagger:strfmt date-time
 	Updated *time.Time `json:"updated_at"`
+	// whether the issue is only visible to its poster, its assignees and the users with write access
+	Confidential *bool `json:"confidential"`
 }
 
 // EditDeadlineOption options for creating a deadline
@@ -145,11 +158,12 @@ const (
 // IssueFormField represents a form field
 // swagger:model
 type IssueFormField struct {
-	Type        IssueFormFieldType      `json:"type" yaml:"type"`
-	ID          string                  `json:"id" yaml:"id"`
-	Attributes  map[string]any          `json:"attributes" yaml:"attributes"`
-	Validations map[string]any          `json:"validations" yaml:"validations"`
-	Visible     []IssueFormFieldVisible `json:"visible,omitempty"`
+	Type        IssueFormFieldType       `json:"type" yaml:"type"`
+	ID          string                   `json:"id" yaml:"id"`
+	Attributes  map[string]any           `json:"attributes" yaml:"attributes"`
+	Validations map[string]any           `json:"validations" yaml:"validations"`
+	Visible     []IssueFormFieldVisible  `json:"visible,omitempty"`
+	ShowIf      *IssueFormFieldCondition `json:"show_if,omitempty" yaml:"show_if"`
 }
 
 func (iff IssueFormField) VisibleOnForm() bool {
@@ -167,6 +181,14 @@ func (iff IssueFormField) VisibleInContent() bool {
 	return slices.Contains(iff.Visible, IssueFormFieldVisibleContent)
 }
 
+// IssueFormFieldCondition shows a form field only when an earlier field is answered with one of the values,
+// or with any value if there are none. The answers to dropdowns and checkboxes are the labels of their options.
+// swagger:model
+type IssueFormFieldCondition struct {
+	ID     string   `json:"id" yaml:"id"`
+	Values []string `json:"values,omitempty" yaml:"values"`
+}
+
 // IssueFormFieldVisible defines issue form field visible
 // swagger:model
 type IssueFormFieldVisible string
@@ -179,14 +201,20 @@ const (
 // IssueTemplate represents an issue template for a repository
 // swagger:model
 type IssueTemplate struct {
-	Name     string              `json:"name" yaml:"name"`
-	Title    string              `json:"title" yaml:"title"`
-	About    string              `json:"about" yaml:"about"` // Using "description" in a template file is compatible
-	Labels   IssueTemplateLabels `json:"labels" yaml:"labels"`
-	Ref      string              `json:"ref" yaml:"ref"`
-	Content  string              `json:"content" yaml:"-"`
-	Fields   []*IssueFormField   `json:"body" yaml:"body"`
-	FileName string              `json:"file_name" yaml:"-"`
+	Name   string              `json:"name" yaml:"name"`
+	Title  string              `json:"title" yaml:"title"`
+	About  string              `json:"about" yaml:"about"` // Using "description" in a template file is compatible
+	Labels IssueTemplateLabels `json:"labels" yaml:"labels"`
+	// usernames of the users assigned to the new issues
+	Assignees IssueTemplateLabels `json:"assignees" yaml:"assignees"`
+	// name of the milestone of the new issues
+	Milestone string `json:"milestone" yaml:"milestone"`
+	// title of the project of the repository or of its owner the new issues are added to
+	Project  string            `json:"project" yaml:"project"`
+	Ref      string            `json:"ref" yaml:"ref"`
+	Content  string            `json:"content" yaml:"-"`
+	Fields   []*IssueFormField `json:"body" yaml:"body"`
+	FileName string            `json:"file_name" yaml:"-"`
 }
 
 type IssueTemplateLabels []string
@@ -0,0 +1,53 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+// CustomField a typed field of the issues of a repository or of a project
+// swagger:model
+type CustomField struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// enum: text,number,date,select,user
+	Type string `json:"type"`
+	// the options of a single-select field
+	Options []string `json:"options"`
+	Sorting int64    `json:"sorting"`
+	// the project of the field, zero for a field of the repository
+	ProjectID int64 `json:"project_id"`
+}
+
+// CreateCustomFieldOption options for creating a custom field
+type CreateCustomFieldOption struct {
+	// required:true
+	Name string `json:"name" binding:"Required;MaxSize(50)"`
+	// required:true
+	// enum: text,number,date,select,user
+	Type string `json:"type" binding:"Required;In(text,number,date,select,user)"`
+	// the options of a single-select field
+	Options []string `json:"options"`
+	Sorting int64    `json:"sorting"`
+}
+
+// EditCustomFieldOption options for editing a custom field, its type can't be changed
+type EditCustomFieldOption struct {
+	Name    *string  `json:"name" binding:"MaxSize(50)"`
+	Options []string `json:"options"`
+	Sorting *int64   `json:"sorting"`
+}
+
+// IssueCustomFieldValue the value of a custom field of an issue
+// swagger:model
+type IssueCustomFieldValue struct {
+	FieldID int64  `json:"field_id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	// the value, a date is formatted as YYYY-MM-DD and a user is given by name
+	Value string `json:"value"`
+}
+
+// SetIssueCustomFieldValueOption options for setting the value of a custom field of an issue
+type SetIssueCustomFieldValueOption struct {
+	// the value, an empty value removes it
+	Value string `json:"value"`
+}
@@ -0,0 +1,14 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+// SubIssueProgress represents the progress of the sub-issues of an issue
+type SubIssueProgress struct {
+	// number of sub-issues
+	Total int `json:"total"`
+	// number of closed sub-issues
+	Closed int `json:"closed"`
+	// time tracked on the sub-issues in seconds
+	TotalTrackedTime int64 `json:"total_tracked_time"`
+}
@@ -31,3 +31,25 @@ type PackageFile struct {
 	HashSHA256 string `json:"sha256"`
 	HashSHA512 string `json:"sha512"`
 }
+
+// PackageSBOM represents the software bill of materials attached to a package version
+type PackageSBOM struct {
+	// format of the document
+	// enum: ["cyclonedx", "spdx"]
+	Format      string `json:"format"`
+	SpecVersion string `json:"spec_version"`
+	Size        int64  `json:"size"`
+	HashSHA256  string `json:"sha256"`
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PackageComponent represents a dependency listed in the SBOM of a package version
+type PackageComponent struct {
+	Name            string   `json:"name"`
+	Version         string   `json:"version"`
+	PURL            string   `json:"purl"`
+	Type            string   `json:"type"`
+	Licenses        []string `json:"licenses"`
+	Vulnerabilities []string `json:"vulnerabilities"`
+}
@@ -24,6 +24,10 @@ type InternalTracker struct {
 	AllowOnlyContributorsToTrackTime bool `json:"allow_only_contributors_to_track_time"`
 	// Enable dependencies for issues and pull requests (Built-in issue tracker)
 	EnableIssueDependencies bool `json:"enable_issue_dependencies"`
+	// Close issues when all their sub-issues are closed (Built-in issue tracker)
+	CloseParentIssues bool `json:"close_parent_issues"`
+	// Create issues from emails sent to the service desk address (Built-in issue tracker)
+	EnableServiceDesk bool `json:"enable_service_desk"`
 }
 
 // ExternalTracker represents settings for external tracker
@@ -0,0 +1,113 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import (
+	"time"
+)
+
+// SecurityAdvisory represents a security advisory of a repository
+type SecurityAdvisory struct {
+	Identifier  string `json:"identifier"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// enum: low,medium,high,critical
+	Severity   string  `json:"severity"`
+	CVSSVector string  `json:"cvss_vector"`
+	CVSSScore  float64 `json:"cvss_score"`
+	CVEID      string  `json:"cve_id"`
+	// enum: draft,published
+	State       string                      `json:"state"`
+	Affected    []*SecurityAdvisoryAffected `json:"affected"`
+	Credits     []*SecurityAdvisoryCredit   `json:"credits"`
+	FixCommitID string                      `json:"fix_commit_id"`
+	HTMLURL     string                      `json:"html_url"`
+	Author      *User                       `json:"author"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+	// swagger:strfmt date-time
+	Published *time.Time `json:"published_at"`
+}
+
+// SecurityAdvisoryAffected represents a package affected by a security advisory
+type SecurityAdvisoryAffected struct {
+	Ecosystem string `json:"ecosystem"`
+	Package   string `json:"package"`
+	// the range of the vulnerable versions, such as ">= 1.0.0, < 1.2.3"
+	Versions string `json:"vulnerable_versions"`
+	Patched  string `json:"patched_version"`
+}
+
+// SecurityAdvisoryCredit represents a person or an organization credited for a security advisory
+type SecurityAdvisoryCredit struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// OSVVulnerability is a published security advisory in the Open Source Vulnerability format,
+// see https://ossf.github.io/osv-schema/
+type OSVVulnerability struct {
+	SchemaVersion string `json:"schema_version"`
+	ID            string `json:"id"`
+	// swagger:strfmt date-time
+	Modified time.Time `json:"modified"`
+	// swagger:strfmt date-time
+	Published        time.Time         `json:"published"`
+	Aliases          []string          `json:"aliases,omitempty"`
+	Summary          string            `json:"summary"`
+	Details          string            `json:"details,omitempty"`
+	Severity         []*OSVSeverity    `json:"severity,omitempty"`
+	Affected         []*OSVAffected    `json:"affected,omitempty"`
+	References       []*OSVReference   `json:"references,omitempty"`
+	Credits          []*OSVCredit      `json:"credits,omitempty"`
+	DatabaseSpecific map[string]string `json:"database_specific,omitempty"`
+}
+
+// OSVSeverity is the severity of an OSV vulnerability
+type OSVSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// OSVAffected is a package or a repository affected by an OSV vulnerability
+type OSVAffected struct {
+	Package          *OSVPackage       `json:"package,omitempty"`
+	Ranges           []*OSVRange       `json:"ranges,omitempty"`
+	Versions         []string          `json:"versions,omitempty"`
+	DatabaseSpecific map[string]string `json:"database_specific,omitempty"`
+}
+
+// OSVPackage is a package affected by an OSV vulnerability
+type OSVPackage struct {
+	Ecosystem string `json:"ecosystem,omitempty"`
+	Name      string `json:"name"`
+}
+
+// OSVRange is a range of versions or commits affected by an OSV vulnerability
+type OSVRange struct {
+	Type   string      `json:"type"`
+	Repo   string      `json:"repo,omitempty"`
+	Events []*OSVEvent `json:"events"`
+}
+
+// OSVEvent is a version or a commit where an OSV vulnerability was introduced or fixed
+type OSVEvent struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// OSVReference is a link to more information about an OSV vulnerability
+type OSVReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// OSVCredit is a person or an organization credited for an OSV vulnerability
+type OSVCredit struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
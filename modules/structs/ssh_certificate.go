@@ -0,0 +1,40 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package structs
+
+import "time"
+
+// CreateSSHCertificateOption options when requesting an SSH certificate
+type CreateSSHCertificateOption struct {
+	// An SSH public key to certify
+	//
+	// required: true
+	Key string `json:"key" binding:"Required"`
+	// How long the certificate is valid, the configured default when omitted
+	ValiditySeconds int64 `json:"validity_seconds"`
+}
+
+// SSHCertificate a short-lived SSH user certificate
+type SSHCertificate struct {
+	// The certificate in the OpenSSH authorized keys format
+	Certificate string   `json:"certificate"`
+	KeyID       string   `json:"key_id"`
+	Serial      string   `json:"serial"`
+	Principals  []string `json:"principals"`
+	// swagger:strfmt date-time
+	ValidAfter time.Time `json:"valid_after"`
+	// swagger:strfmt date-time
+	ValidBefore time.Time `json:"valid_before"`
+}
+
+// SSHCertificateAuthority the SSH certificate authority of the instance
+type SSHCertificateAuthority struct {
+	// The public key of the authority in the OpenSSH authorized keys format
+	PublicKey   string `json:"public_key"`
+	Fingerprint string `json:"fingerprint"`
+	// The validity of certificates in seconds when none is requested
+	DefaultValiditySeconds int64 `json:"default_validity_seconds"`
+	// The longest validity of certificates in seconds
+	MaxValiditySeconds int64 `json:"max_validity_seconds"`
+}
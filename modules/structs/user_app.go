@@ -16,6 +16,12 @@ type AccessToken struct {
 	Token          string   `json:"sha1"`
 	TokenLastEight string   `json:"token_last_eight"`
 	Scopes         []string `json:"scopes"`
+	// full names of the repositories the token is restricted to
+	Repositories []string `json:"repositories,omitempty"`
+	// name of the organization the token is restricted to
+	Organization string `json:"organization,omitempty"`
+	// swagger:strfmt date-time
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 // AccessTokenList represents a list of API access token.
@@ -29,6 +35,14 @@ type CreateAccessTokenOption struct {
 	Name string `json:"name" binding:"Required"`
 	// example: ["all", "read:activitypub","read:issue", "write:misc", "read:notification", "read:organization", "read:package", "read:repository", "read:user"]
 	Scopes []string `json:"scopes"`
+	// restrict the token to these repositories, given by their full names
+	// example: ["owner/repo"]
+	Repositories []string `json:"repositories"`
+	// restrict the token to the repositories of this organization
+	Organization string `json:"organization"`
+	// expiry date of the token, required if the token is restricted to repositories or an organization
+	// swagger:strfmt date-time
+	ExpiresAt *time.Time `json:"expires_at"`
 }
 
 // CreateOAuth2ApplicationOptions holds options to create an oauth2 application
@@ -4,6 +4,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 )
@@ -12,3 +13,15 @@ import (
 func IsAPIPath(req *http.Request) bool {
 	return strings.HasPrefix(req.URL.Path, "/api/")
 }
+
+type remoteAddrContextKeyType struct{}
+
+// RemoteAddrContextKey is the key of the remote address of the client in the context of a request
+var RemoteAddrContextKey = remoteAddrContextKeyType{}
+
+// GetRemoteAddr returns the client machine ip address of the request a context
+// was derived from, or an empty string outside of a request
+func GetRemoteAddr(ctx context.Context) string {
+	addr, _ := ctx.Value(RemoteAddrContextKey).(string)
+	return addr
+}
@@ -36,9 +36,11 @@ import (
 	"forgejo.org/routers/api/packages/rpm"
 	"forgejo.org/routers/api/packages/rubygems"
 	"forgejo.org/routers/api/packages/swift"
+	"forgejo.org/routers/api/packages/terraform"
 	"forgejo.org/routers/api/packages/vagrant"
 	"forgejo.org/services/auth"
 	"forgejo.org/services/context"
+	"forgejo.org/services/ratelimit"
 )
 
 func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.Context) {
@@ -84,6 +86,11 @@ func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.Context) {
 			}
 		}
 
+		if !context.AccessTokenAllowsOwner(ctx.Data, ctx.Package.Owner) {
+			ctx.Error(http.StatusForbidden, "reqPackageAccess", "token is restricted to other owners")
+			return
+		}
+
 		if ctx.Package.AccessMode < accessMode && !ctx.IsUserSiteAdmin() {
 			ctx.Resp.Header().Set("WWW-Authenticate", `Basic realm="Gitea Package API"`)
 			ctx.Error(http.StatusUnauthorized, "reqPackageAccess", "user should have specific permission or be a site admin")
@@ -139,6 +146,19 @@ func CommonRoutes() *web.Route {
 		&conan.Auth{},
 		&chef.Auth{},
 	})
+	r.Use(ratelimit.Middleware(ratelimit.ClassPackages))
+
+	// Terraform resolves the registry through service discovery on the instance, the owner is the namespace
+	r.Group("/terraform", func() {
+		r.Group("/modules/v1/{username}/{name}/{system}", func() {
+			r.Get("/versions", terraform.EnumerateModuleVersions)
+			r.Get("/{version}/download", terraform.DownloadModule)
+		})
+		r.Group("/providers/v1/{username}/{provider}", func() {
+			r.Get("/versions", terraform.EnumerateProviderVersions)
+			r.Get("/{version}/download/{os}/{arch}", terraform.DownloadProvider)
+		})
+	}, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
 
 	r.Group("/{username}", func() {
 		r.Group("/alpine", func() {
@@ -747,6 +767,20 @@ func CommonRoutes() *web.Route {
 				r.Get("/identifiers", swift.CheckAcceptMediaType(swift.AcceptJSON), swift.LookupPackageIdentifiers)
 			}, reqPackageAccess(perm.AccessModeRead))
 		})
+		r.Group("/terraform", func() {
+			r.Group("/modules/{name}/{system}/{version}", func() {
+				r.Get("", terraform.DownloadModuleArchive)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), enforcePackagesQuota(), terraform.UploadModule)
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), terraform.DeleteModule)
+			})
+			r.Group("/providers/{provider}/{version}", func() {
+				r.Delete("", reqPackageAccess(perm.AccessModeWrite), terraform.DeleteProvider)
+				r.Group("/{filename}", func() {
+					r.Get("", terraform.DownloadProviderFile)
+					r.Put("", reqPackageAccess(perm.AccessModeWrite), enforcePackagesQuota(), terraform.UploadProviderFile)
+				})
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/vagrant", func() {
 			r.Group("/authenticate", func() {
 				r.Get("", vagrant.CheckAuthenticate)
@@ -777,6 +811,7 @@ func ContainerRoutes() *web.Route {
 		&auth.Basic{},
 		&container.Auth{},
 	})
+	r.Use(ratelimit.Middleware(ratelimit.ClassPackages))
 
 	r.Get("", container.ReqContainerAccess, container.DetermineSupport)
 	r.Group("/token", func() {
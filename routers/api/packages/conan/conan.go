@@ -120,8 +120,9 @@ func Authenticate(ctx *context.Context) {
 
 	// If there's an API scope, ensure it propagates.
 	scope, _ := ctx.Data.GetData()["ApiTokenScope"].(auth_model.AccessTokenScope)
+	accessToken, _ := ctx.Data.GetData()["ApiAccessToken"].(*auth_model.AccessToken)
 
-	token, err := packages_service.CreateAuthorizationToken(ctx.Doer, scope)
+	token, err := packages_service.CreateAuthorizationToken(ctx.Doer, scope, accessToken)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -23,7 +23,7 @@ func (a *Auth) Name() string {
 // Verify extracts the user from the Bearer token
 // If it's an anonymous session a ghost user is returned
 func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataStore, sess auth.SessionStore) (*user_model.User, error) {
-	uid, scope, err := packages.ParseAuthorizationToken(req)
+	uid, scope, restriction, err := packages.ParseAuthorizationToken(req)
 	if err != nil {
 		log.Trace("ParseAuthorizationToken: %v", err)
 		return nil, err
@@ -38,6 +38,9 @@ func (a *Auth) Verify(req *http.Request, w http.ResponseWriter, store auth.DataS
 		store.GetData()["IsApiToken"] = true
 		store.GetData()["ApiTokenScope"] = scope
 	}
+	if restriction != nil {
+		store.GetData()["ApiAccessToken"] = restriction
+	}
 
 	u, err := user_model.GetPossibleUserByID(req.Context(), uid)
 	if err != nil {
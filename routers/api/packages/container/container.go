@@ -167,8 +167,9 @@ func Authenticate(ctx *context.Context) {
 
 	// If there's an API scope, ensure it propagates.
 	scope, _ := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope)
+	accessToken, _ := ctx.Data["ApiAccessToken"].(*auth_model.AccessToken)
 
-	token, err := packages_service.CreateAuthorizationToken(u, scope)
+	token, err := packages_service.CreateAuthorizationToken(u, scope, accessToken)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
@@ -19,6 +19,7 @@ import (
 	"forgejo.org/modules/log"
 	packages_module "forgejo.org/modules/packages"
 	container_module "forgejo.org/modules/packages/container"
+	sbom_module "forgejo.org/modules/packages/sbom"
 	"forgejo.org/modules/util"
 	notify_service "forgejo.org/services/notify"
 	packages_service "forgejo.org/services/packages"
@@ -161,6 +162,12 @@ func processImageManifest(ctx context.Context, mci *manifestCreationInfo, buf *p
 			}
 		}
 
+		if manifest.Subject != nil {
+			if err := attachReferrerSBOM(ctx, mci, &manifest, blobReferences[1:]); err != nil {
+				return err
+			}
+		}
+
 		pb, created, digest, err := createManifestBlob(ctx, mci, pv, buf)
 		removeBlob := false
 		defer func() {
@@ -196,6 +203,59 @@ func processImageManifest(ctx context.Context, mci *manifestCreationInfo, buf *p
 	return manifestDigest, nil
 }
 
+// attachReferrerSBOM attaches the SBOM carried by a referrer manifest to all versions of its subject manifest.
+// Referrers pushed before their subject are stored as usual but do not get attached.
+func attachReferrerSBOM(ctx context.Context, mci *manifestCreationInfo, manifest *oci.Manifest, layers []*blobReference) error {
+	artifactType := manifest.ArtifactType
+	if artifactType == "" {
+		artifactType = manifest.Config.MediaType
+	}
+
+	var layer *blobReference
+	for _, ref := range layers {
+		if sbom_module.IsSupportedMediaType(ref.MediaType) || (len(layers) == 1 && sbom_module.IsSupportedMediaType(artifactType)) {
+			layer = ref
+			break
+		}
+	}
+	if layer == nil {
+		return nil
+	}
+
+	pvs, err := container_model.GetManifestVersions(ctx, &container_model.BlobSearchOptions{
+		OwnerID:    mci.Owner.ID,
+		Image:      mci.Image,
+		Digest:     string(manifest.Subject.Digest),
+		IsManifest: true,
+	})
+	if err != nil {
+		return err
+	}
+	if len(pvs) == 0 {
+		return nil
+	}
+
+	r, err := packages_module.NewContentStore().Get(packages_module.BlobHash256Key(layer.File.Blob.HashSHA256))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	doc, err := sbom_module.ParseDocument(r)
+	if err != nil {
+		// The artifact itself is valid, so an unusable SBOM must not reject the push
+		log.Warn("Ignoring SBOM of referrer %s/%s for %s: %v", mci.Owner.Name, mci.Image, manifest.Subject.Digest, err)
+		return nil
+	}
+
+	for _, pv := range pvs {
+		if _, err := packages_service.AttachSBOM(ctx, mci.Creator, pv, layer.File.Blob, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func processImageManifestIndex(ctx context.Context, mci *manifestCreationInfo, buf *packages_module.HashedBuffer) (string, error) {
 	manifestDigest := ""
 
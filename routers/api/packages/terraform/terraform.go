@@ -0,0 +1,695 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package terraform
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	"forgejo.org/models/db"
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/modules/log"
+	packages_module "forgejo.org/modules/packages"
+	terraform_module "forgejo.org/modules/packages/terraform"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/routers/api/packages/helper"
+	"forgejo.org/services/context"
+	packages_service "forgejo.org/services/packages"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+const maxSignatureSize = 64 * 1024
+
+func apiError(ctx *context.Context, status int, obj any) {
+	helper.LogAndProcessError(ctx, status, obj, func(message string) {
+		ctx.JSON(status, struct {
+			Errors []string `json:"errors"`
+		}{
+			Errors: []string{
+				message,
+			},
+		})
+	})
+}
+
+func registryURL(ctx *context.Context) string {
+	return fmt.Sprintf("%sapi/packages/%s/terraform", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name))
+}
+
+type moduleVersion struct {
+	Version string `json:"version"`
+}
+
+type moduleVersions struct {
+	Modules []*moduleVersionList `json:"modules"`
+}
+
+type moduleVersionList struct {
+	Versions []*moduleVersion `json:"versions"`
+}
+
+// EnumerateModuleVersions lists all versions of a module
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#list-available-versions-for-a-specific-module
+func EnumerateModuleVersions(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ModulePackageName(ctx.Params("name"), ctx.Params("system")))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	versions := make([]*moduleVersion, 0, len(pvs))
+	for _, pv := range pvs {
+		versions = append(versions, &moduleVersion{Version: pv.Version})
+	}
+
+	ctx.JSON(http.StatusOK, &moduleVersions{
+		Modules: []*moduleVersionList{
+			{Versions: versions},
+		},
+	})
+}
+
+// DownloadModule points the client to the archive of a module version
+// https://developer.hashicorp.com/terraform/internals/module-registry-protocol#download-source-code-for-a-specific-module-version
+func DownloadModule(ctx *context.Context) {
+	name := ctx.Params("name")
+	system := ctx.Params("system")
+	version := ctx.Params("version")
+
+	if _, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ModulePackageName(name, system), version); err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The archive parameter tells the client how to unpack the downloaded file
+	ctx.Resp.Header().Set("X-Terraform-Get", fmt.Sprintf("%s/modules/%s/%s/%s?archive=tar.gz", registryURL(ctx), url.PathEscape(name), url.PathEscape(system), url.PathEscape(version)))
+	ctx.Status(http.StatusNoContent)
+}
+
+func moduleArchiveFilename(name, system, version string) string {
+	return fmt.Sprintf("%s-%s-%s.tar.gz", name, system, version)
+}
+
+// DownloadModuleArchive serves the archive of a module version
+func DownloadModuleArchive(ctx *context.Context) {
+	name := ctx.Params("name")
+	system := ctx.Params("system")
+	version := ctx.Params("version")
+
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        terraform_module.ModulePackageName(name, system),
+			Version:     version,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: moduleArchiveFilename(name, system, version),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadModule creates a new module version from a .tar.gz archive
+func UploadModule(ctx *context.Context) {
+	name := ctx.Params("name")
+	system := ctx.Params("system")
+	version := ctx.Params("version")
+
+	if !terraform_module.IsValidName(name) || !terraform_module.IsValidName(system) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidName)
+		return
+	}
+	if !terraform_module.IsValidVersion(version) {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidVersion)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	metadata, err := terraform_module.ParseModuleArchive(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        terraform_module.ModulePackageName(name, system),
+				Version:     version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: moduleArchiveFilename(name, system, version),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// DeleteModule deletes a module version
+func DeleteModule(ctx *context.Context) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        terraform_module.ModulePackageName(ctx.Params("name"), ctx.Params("system")),
+			Version:     ctx.Params("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+type providerPlatform struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+}
+
+type providerVersion struct {
+	Version   string              `json:"version"`
+	Protocols []string            `json:"protocols"`
+	Platforms []*providerPlatform `json:"platforms"`
+}
+
+type providerVersions struct {
+	Versions []*providerVersion `json:"versions"`
+}
+
+type gpgPublicKey struct {
+	KeyID      string `json:"key_id"`
+	ASCIIArmor string `json:"ascii_armor"`
+}
+
+type signingKeys struct {
+	GPGPublicKeys []*gpgPublicKey `json:"gpg_public_keys"`
+}
+
+type providerPackage struct {
+	Protocols           []string     `json:"protocols"`
+	OS                  string       `json:"os"`
+	Arch                string       `json:"arch"`
+	Filename            string       `json:"filename"`
+	DownloadURL         string       `json:"download_url"`
+	SHASumsURL          string       `json:"shasums_url"`
+	SHASumsSignatureURL string       `json:"shasums_signature_url"`
+	SHASum              string       `json:"shasum"`
+	SigningKeys         *signingKeys `json:"signing_keys"`
+}
+
+func providerProtocols(pd *packages_model.PackageDescriptor) []string {
+	for _, pf := range pd.Files {
+		if protocols := pf.Properties.GetByName(terraform_module.PropertyProtocols); protocols != "" {
+			return strings.Split(protocols, ",")
+		}
+	}
+	return []string{terraform_module.DefaultProtocolVersion}
+}
+
+// EnumerateProviderVersions lists all versions of a provider and their platforms
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#list-available-versions
+func EnumerateProviderVersions(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ProviderPackageName(ctx.Params("provider")))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+
+	versions := make([]*providerVersion, 0, len(pds))
+	for _, pd := range pds {
+		platforms := make([]*providerPlatform, 0, len(pd.Files))
+		for _, pf := range pd.Files {
+			if pf.Properties.GetByName(terraform_module.PropertyFileType) != string(terraform_module.FileTypeArchive) {
+				continue
+			}
+			platforms = append(platforms, &providerPlatform{
+				OS:   pf.Properties.GetByName(terraform_module.PropertyOS),
+				Arch: pf.Properties.GetByName(terraform_module.PropertyArch),
+			})
+		}
+
+		versions = append(versions, &providerVersion{
+			Version:   pd.Version.Version,
+			Protocols: providerProtocols(pd),
+			Platforms: platforms,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, &providerVersions{
+		Versions: versions,
+	})
+}
+
+// DownloadProvider describes the release archive of a provider for a specific platform
+// https://developer.hashicorp.com/terraform/internals/provider-registry-protocol#find-a-provider-package
+func DownloadProvider(ctx *context.Context) {
+	providerType := ctx.Params("provider")
+	providerVersion := ctx.Params("version")
+	osName := ctx.Params("os")
+	arch := ctx.Params("arch")
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ProviderPackageName(providerType), providerVersion)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	var archive, signature *packages_model.PackageFileDescriptor
+	hasSHASums := false
+	for _, pf := range pd.Files {
+		switch terraform_module.FileType(pf.Properties.GetByName(terraform_module.PropertyFileType)) {
+		case terraform_module.FileTypeArchive:
+			if pf.Properties.GetByName(terraform_module.PropertyOS) == osName && pf.Properties.GetByName(terraform_module.PropertyArch) == arch {
+				archive = pf
+			}
+		case terraform_module.FileTypeSHA256Sum:
+			hasSHASums = true
+		case terraform_module.FileTypeSignature:
+			signature = pf
+		}
+	}
+	if archive == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+		return
+	}
+	if !hasSHASums || signature == nil {
+		apiError(ctx, http.StatusNotFound, errors.New("provider release is not signed"))
+		return
+	}
+
+	keyID := signature.Properties.GetByName(terraform_module.PropertySigningKeyID)
+	key, err := asymkey_model.GetGPGImportByKeyID(ctx, keyID)
+	if err != nil {
+		if asymkey_model.IsErrGPGKeyImportNotExist(err) {
+			apiError(ctx, http.StatusNotFound, fmt.Errorf("signing key %s does not exist anymore", keyID))
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	versionURL := fmt.Sprintf("%s/providers/%s/%s", registryURL(ctx), url.PathEscape(providerType), url.PathEscape(pd.Version.Version))
+
+	ctx.JSON(http.StatusOK, &providerPackage{
+		Protocols:           providerProtocols(pd),
+		OS:                  osName,
+		Arch:                arch,
+		Filename:            archive.File.Name,
+		DownloadURL:         versionURL + "/" + url.PathEscape(archive.File.Name),
+		SHASumsURL:          versionURL + "/" + url.PathEscape(terraform_module.SHA256SumsFilename(providerType, pd.Version.Version)),
+		SHASumsSignatureURL: versionURL + "/" + url.PathEscape(terraform_module.SignatureFilename(providerType, pd.Version.Version)),
+		SHASum:              archive.Blob.HashSHA256,
+		SigningKeys: &signingKeys{
+			GPGPublicKeys: []*gpgPublicKey{
+				{
+					KeyID:      key.KeyID,
+					ASCIIArmor: key.Content,
+				},
+			},
+		},
+	})
+}
+
+// DownloadProviderFile serves a file of a provider release
+func DownloadProviderFile(ctx *context.Context) {
+	s, u, pf, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        terraform_module.ProviderPackageName(ctx.Params("provider")),
+			Version:     ctx.Params("version"),
+		},
+		&packages_service.PackageFileInfo{
+			Filename: ctx.Params("filename"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadProviderFile adds a file to a provider release.
+// The SHA256SUMS file must be uploaded before its signature.
+func UploadProviderFile(ctx *context.Context) {
+	providerType := ctx.Params("provider")
+	providerVersion := ctx.Params("version")
+	filename := ctx.Params("filename")
+
+	pfi, err := terraform_module.ParseProviderFilename(filename)
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if pfi.Type != providerType || pfi.Version != providerVersion {
+		apiError(ctx, http.StatusBadRequest, terraform_module.ErrInvalidFilename)
+		return
+	}
+
+	upload, needsClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if needsClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	properties := map[string]string{
+		terraform_module.PropertyFileType: string(pfi.FileType),
+	}
+
+	var checkErr error
+	switch pfi.FileType {
+	case terraform_module.FileTypeArchive:
+		properties[terraform_module.PropertyOS] = pfi.OS
+		properties[terraform_module.PropertyArch] = pfi.Arch
+
+		_, _, hashSHA256, _, _ := buf.Sums()
+		checkErr = checkProviderSHA256Sums(ctx, providerType, providerVersion, nil, map[string]string{filename: hex.EncodeToString(hashSHA256)})
+	case terraform_module.FileTypeManifest:
+		protocols, err := terraform_module.ParseManifest(buf)
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		properties[terraform_module.PropertyProtocols] = strings.Join(protocols, ",")
+	case terraform_module.FileTypeSHA256Sum:
+		sums, err := terraform_module.ParseSHA256Sums(buf)
+		if err != nil {
+			apiError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		checkErr = checkProviderSHA256Sums(ctx, providerType, providerVersion, sums, map[string]string{})
+	case terraform_module.FileTypeSignature:
+		keyID, err := verifyProviderSignature(ctx, providerType, providerVersion, buf)
+		if err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				apiError(ctx, http.StatusBadRequest, err)
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
+			return
+		}
+		properties[terraform_module.PropertySigningKeyID] = keyID
+	}
+	if checkErr != nil {
+		if errors.Is(checkErr, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, checkErr)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, checkErr)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, _, err = packages_service.CreatePackageOrAddFileToExisting(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeTerraform,
+				Name:        terraform_module.ProviderPackageName(providerType),
+				Version:     providerVersion,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata: &terraform_module.Metadata{
+				Kind: terraform_module.KindProvider,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: filename,
+			},
+			Creator:    ctx.Doer,
+			Data:       buf,
+			IsLead:     pfi.FileType == terraform_module.FileTypeArchive,
+			Properties: properties,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageFile:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// checkProviderSHA256Sums checks the SHA-256 of the archives of a provider release against its SHA256SUMS file,
+// whichever of them is uploaded second. sums are the checksums of an uploaded SHA256SUMS file, nil when an archive
+// is uploaded. archives maps the filenames of the archives to their SHA-256, the ones already uploaded are added to it.
+func checkProviderSHA256Sums(ctx *context.Context, providerType, providerVersion string, sums, archives map[string]string) error {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeTerraform, terraform_module.ProviderPackageName(providerType), providerVersion)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			return nil
+		}
+		return err
+	}
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		return err
+	}
+
+	for _, pf := range pd.Files {
+		switch terraform_module.FileType(pf.Properties.GetByName(terraform_module.PropertyFileType)) {
+		case terraform_module.FileTypeArchive:
+			if sums != nil {
+				archives[pf.File.Name] = pf.Blob.HashSHA256
+			}
+		case terraform_module.FileTypeSHA256Sum:
+			if sums != nil {
+				continue
+			}
+			s, err := packages_module.NewContentStore().Get(packages_module.BlobHash256Key(pf.Blob.HashSHA256))
+			if err != nil {
+				return err
+			}
+			sums, err = terraform_module.ParseSHA256Sums(s)
+			s.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if sums == nil {
+		return nil
+	}
+
+	for filename, hash := range archives {
+		if sums[filename] != hash {
+			return util.NewInvalidArgumentErrorf("the SHA-256 of %s does not match the SHA256SUMS file", filename)
+		}
+	}
+	return nil
+}
+
+// verifyProviderSignature checks the uploaded signature of the SHA256SUMS file against the GPG keys of the uploader
+// and returns the id of the key which made the signature.
+func verifyProviderSignature(ctx *context.Context, providerType, providerVersion string, signature io.Reader) (string, error) {
+	sig, err := io.ReadAll(io.LimitReader(signature, maxSignatureSize))
+	if err != nil {
+		return "", err
+	}
+
+	s, _, _, err := packages_service.GetFileStreamByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        terraform_module.ProviderPackageName(providerType),
+			Version:     providerVersion,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: terraform_module.SHA256SumsFilename(providerType, providerVersion),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			return "", util.NewInvalidArgumentErrorf("the SHA256SUMS file must be uploaded before its signature")
+		}
+		return "", err
+	}
+	defer s.Close()
+
+	keys, err := db.Find[asymkey_model.GPGKey](ctx, asymkey_model.FindGPGKeyOptions{
+		OwnerID: ctx.Doer.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	keyring := make(openpgp.EntityList, 0, len(keys))
+	for _, key := range keys {
+		entity, err := asymkey_model.GPGKeyToEntity(ctx, key)
+		if err != nil {
+			log.Warn("Unable to load GPG key %s: %v", key.KeyID, err)
+			continue
+		}
+		keyring = append(keyring, entity)
+	}
+
+	signer, err := terraform_module.VerifySignature(s, sig, keyring)
+	if err != nil {
+		return "", err
+	}
+	return signer.PrimaryKey.KeyIdString(), nil
+}
+
+// DeleteProvider deletes a provider release with all its files
+func DeleteProvider(ctx *context.Context) {
+	err := packages_service.RemovePackageVersionByNameAndVersion(
+		ctx,
+		ctx.Doer,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeTerraform,
+			Name:        terraform_module.ProviderPackageName(ctx.Params("provider")),
+			Version:     ctx.Params("version"),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
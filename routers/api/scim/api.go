@@ -0,0 +1,201 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package scim provides the SCIM 2.0 endpoints identity providers use to provision
+// the users and groups of an authentication source, they are mounted on `/api/scim/v2`.
+// Every authentication source has its own bearer token, which limits the requests to the
+// users and groups of that source.
+package scim
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	scim_service "forgejo.org/services/scim"
+)
+
+const sourceKey = "SCIMSource"
+
+// Routes returns the SCIM 2.0 routes
+func Routes() *web.Route {
+	m := web.NewRoute()
+
+	m.Use(context.APIContexter())
+	m.Use(verifyToken)
+
+	m.Get("/ServiceProviderConfig", ServiceProviderConfig)
+	m.Get("/ResourceTypes", ResourceTypes)
+
+	m.Group("/Users", func() {
+		m.Get("", ListUsers)
+		m.Post("", CreateUser)
+		m.Group("/{id}", func() {
+			m.Get("", GetUser)
+			m.Put("", ReplaceUser)
+			m.Patch("", PatchUser)
+			m.Delete("", DeleteUser)
+		})
+	})
+
+	m.Group("/Groups", func() {
+		m.Get("", ListGroups)
+		m.Post("", CreateGroup)
+		m.Group("/{id}", func() {
+			m.Get("", GetGroup)
+			m.Put("", ReplaceGroup)
+			m.Patch("", PatchGroup)
+			m.Delete("", DeleteGroup)
+		})
+	})
+
+	return m
+}
+
+// verifyToken authenticates the identity provider by the bearer token of its authentication source
+func verifyToken(ctx *context.APIContext) {
+	token, ok := strings.CutPrefix(ctx.Req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		ctx.Resp.Header().Set("WWW-Authenticate", `Bearer realm="SCIM"`)
+		writeError(ctx, scim_service.NewError(http.StatusUnauthorized, "", "a bearer token is required"))
+		return
+	}
+
+	scimToken, err := auth_model.GetSCIMTokenByToken(ctx, strings.TrimSpace(token))
+	if err != nil {
+		if !auth_model.IsErrSCIMTokenNotExist(err) {
+			log.Error("GetSCIMTokenByToken: %v", err)
+		}
+		ctx.Resp.Header().Set("WWW-Authenticate", `Bearer realm="SCIM", error="invalid_token"`)
+		writeError(ctx, scim_service.NewError(http.StatusUnauthorized, "", "invalid token"))
+		return
+	}
+
+	source, err := auth_model.GetSourceByID(ctx, scimToken.SourceID)
+	if err != nil {
+		writeServerError(ctx, "GetSourceByID", err)
+		return
+	}
+	if !source.IsActive {
+		writeError(ctx, scim_service.NewError(http.StatusForbidden, "", "the authentication source is not active"))
+		return
+	}
+	ctx.Data[sourceKey] = source
+}
+
+func getSource(ctx *context.APIContext) *auth_model.Source {
+	return ctx.Data[sourceKey].(*auth_model.Source)
+}
+
+func writeJSON(ctx *context.APIContext, status int, obj any) {
+	ctx.Resp.Header().Set("Content-Type", scim_service.ContentType)
+	ctx.Resp.WriteHeader(status)
+	if err := json.NewEncoder(ctx.Resp).Encode(obj); err != nil {
+		log.Error("Failed to encode SCIM response: %v", err)
+	}
+}
+
+func writeError(ctx *context.APIContext, err *scim_service.Error) {
+	writeJSON(ctx, err.StatusCode(), err)
+}
+
+func writeServerError(ctx *context.APIContext, title string, err error) {
+	log.Error("SCIM %s: %v", title, err)
+	writeError(ctx, scim_service.NewError(http.StatusInternalServerError, "", "internal server error"))
+}
+
+// handleError renders the errors of the SCIM service
+func handleError(ctx *context.APIContext, title string, err error) {
+	var scimErr *scim_service.Error
+	switch {
+	case errors.As(err, &scimErr):
+		writeError(ctx, scimErr)
+	case errors.Is(err, util.ErrNotExist):
+		writeError(ctx, scim_service.NewError(http.StatusNotFound, "", "%v", err))
+	case errors.Is(err, util.ErrAlreadyExist):
+		writeError(ctx, scim_service.NewError(http.StatusConflict, "uniqueness", "%v", err))
+	case errors.Is(err, util.ErrInvalidArgument):
+		writeError(ctx, scim_service.NewError(http.StatusBadRequest, "invalidValue", "%v", err))
+	default:
+		writeServerError(ctx, title, err)
+	}
+}
+
+// decodeBody decodes the JSON body of the request, it returns false if the error response has been written
+func decodeBody(ctx *context.APIContext, obj any) bool {
+	body, err := io.ReadAll(io.LimitReader(ctx.Req.Body, 1<<20))
+	if err != nil {
+		writeError(ctx, scim_service.NewError(http.StatusBadRequest, "invalidSyntax", "%v", err))
+		return false
+	}
+	if err := json.Unmarshal(body, obj); err != nil {
+		writeError(ctx, scim_service.NewError(http.StatusBadRequest, "invalidSyntax", "%v", err))
+		return false
+	}
+	return true
+}
+
+// pagination returns the 1-based startIndex and the count of a list request
+func pagination(ctx *context.APIContext) (startIndex, count int) {
+	startIndex, count = 1, scim_service.MaxResults
+	if v, err := strconv.Atoi(ctx.FormString("startIndex")); err == nil && v > 1 {
+		startIndex = v
+	}
+	if v, err := strconv.Atoi(ctx.FormString("count")); err == nil && v >= 0 && v < count {
+		count = v
+	}
+	return startIndex, count
+}
+
+// ServiceProviderConfig returns the SCIM features supported by Forgejo
+func ServiceProviderConfig(ctx *context.APIContext) {
+	supported := func(b bool) map[string]bool {
+		return map[string]bool{"supported": b}
+	}
+	writeJSON(ctx, http.StatusOK, map[string]any{
+		"schemas":        []string{scim_service.SchemaServiceProviderConfig},
+		"patch":          supported(true),
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": scim_service.MaxResults},
+		"changePassword": supported(false),
+		"sort":           supported(false),
+		"etag":           supported(false),
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer Token",
+			"description": "Authentication with the SCIM token of the authentication source",
+			"primary":     true,
+		}},
+	})
+}
+
+// ResourceTypes returns the resource types supported by Forgejo
+func ResourceTypes(ctx *context.APIContext) {
+	resourceType := func(name, endpoint, schema string) map[string]any {
+		return map[string]any{
+			"schemas":  []string{scim_service.SchemaResourceType},
+			"id":       name,
+			"name":     name,
+			"endpoint": endpoint,
+			"schema":   schema,
+		}
+	}
+	writeJSON(ctx, http.StatusOK, &scim_service.ListResponse{
+		Schemas:      []string{scim_service.SchemaListResponse},
+		TotalResults: 2,
+		StartIndex:   1,
+		ItemsPerPage: 2,
+		Resources: []any{
+			resourceType("User", "/Users", scim_service.SchemaUser),
+			resourceType("Group", "/Groups", scim_service.SchemaGroup),
+		},
+	})
+}
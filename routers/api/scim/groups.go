@@ -0,0 +1,142 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"net/http"
+	"strings"
+
+	"forgejo.org/services/context"
+	scim_service "forgejo.org/services/scim"
+)
+
+// withMembers returns false if the identity provider asked to exclude the members,
+// listing the members of large groups is expensive
+func withMembers(ctx *context.APIContext) bool {
+	for _, attr := range strings.Split(ctx.FormString("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return false
+		}
+	}
+	return true
+}
+
+// ListGroups lists the groups of the authentication source
+func ListGroups(ctx *context.APIContext) {
+	filter, err := scim_service.ParseFilter(ctx.FormString("filter"), "displayName", "externalId")
+	if err != nil {
+		handleError(ctx, "ParseFilter", err)
+		return
+	}
+	startIndex, count := pagination(ctx)
+
+	resp, err := scim_service.ListGroups(ctx, getSource(ctx), filter, startIndex, count, withMembers(ctx))
+	if err != nil {
+		handleError(ctx, "ListGroups", err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, resp)
+}
+
+// GetGroup returns a group of the authentication source
+func GetGroup(ctx *context.APIContext) {
+	group, err := scim_service.GetGroup(ctx, getSource(ctx), ctx.Params("id"))
+	if err != nil {
+		handleError(ctx, "GetGroup", err)
+		return
+	}
+	g, err := scim_service.ToGroup(ctx, group, withMembers(ctx))
+	if err != nil {
+		handleError(ctx, "ToGroup", err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, g)
+}
+
+// CreateGroup creates a group of the authentication source
+func CreateGroup(ctx *context.APIContext) {
+	in := &scim_service.Group{}
+	if !decodeBody(ctx, in) {
+		return
+	}
+
+	group, err := scim_service.CreateGroup(ctx, getSource(ctx), in)
+	if err != nil {
+		handleError(ctx, "CreateGroup", err)
+		return
+	}
+	g, err := scim_service.ToGroup(ctx, group, true)
+	if err != nil {
+		handleError(ctx, "ToGroup", err)
+		return
+	}
+	ctx.Resp.Header().Set("Location", g.Meta.Location)
+	writeJSON(ctx, http.StatusCreated, g)
+}
+
+// ReplaceGroup replaces the display name and the members of a group of the authentication source
+func ReplaceGroup(ctx *context.APIContext) {
+	source := getSource(ctx)
+	group, err := scim_service.GetGroup(ctx, source, ctx.Params("id"))
+	if err != nil {
+		handleError(ctx, "GetGroup", err)
+		return
+	}
+	in := &scim_service.Group{}
+	if !decodeBody(ctx, in) {
+		return
+	}
+
+	if err := scim_service.ReplaceGroup(ctx, source, group, in); err != nil {
+		handleError(ctx, "ReplaceGroup", err)
+		return
+	}
+	g, err := scim_service.ToGroup(ctx, group, true)
+	if err != nil {
+		handleError(ctx, "ToGroup", err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, g)
+}
+
+// PatchGroup modifies a group of the authentication source, e.g. to add or remove members
+func PatchGroup(ctx *context.APIContext) {
+	source := getSource(ctx)
+	group, err := scim_service.GetGroup(ctx, source, ctx.Params("id"))
+	if err != nil {
+		handleError(ctx, "GetGroup", err)
+		return
+	}
+	patch := &scim_service.PatchOp{}
+	if !decodeBody(ctx, patch) {
+		return
+	}
+
+	if err := scim_service.PatchGroup(ctx, source, group, patch); err != nil {
+		handleError(ctx, "PatchGroup", err)
+		return
+	}
+	g, err := scim_service.ToGroup(ctx, group, true)
+	if err != nil {
+		handleError(ctx, "ToGroup", err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, g)
+}
+
+// DeleteGroup deletes a group of the authentication source
+func DeleteGroup(ctx *context.APIContext) {
+	source := getSource(ctx)
+	group, err := scim_service.GetGroup(ctx, source, ctx.Params("id"))
+	if err != nil {
+		handleError(ctx, "GetGroup", err)
+		return
+	}
+
+	if err := scim_service.DeleteGroup(ctx, source, group); err != nil {
+		handleError(ctx, "DeleteGroup", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"net/http"
+
+	"forgejo.org/services/context"
+	scim_service "forgejo.org/services/scim"
+)
+
+// ListUsers lists the users of the authentication source
+func ListUsers(ctx *context.APIContext) {
+	filter, err := scim_service.ParseFilter(ctx.FormString("filter"), "userName", "emails.value", "emails")
+	if err != nil {
+		handleError(ctx, "ParseFilter", err)
+		return
+	}
+	startIndex, count := pagination(ctx)
+
+	resp, err := scim_service.ListUsers(ctx, getSource(ctx), filter, startIndex, count)
+	if err != nil {
+		handleError(ctx, "ListUsers", err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, resp)
+}
+
+// GetUser returns a user of the authentication source
+func GetUser(ctx *context.APIContext) {
+	source := getSource(ctx)
+	u, err := scim_service.GetUser(ctx, source, ctx.Params("id"))
+	if err != nil {
+		handleError(ctx, "GetUser", err)
+		return
+	}
+	user, err := scim_service.ToUser(ctx, source, u)
+	if err != nil {
+		handleError(ctx, "ToUser", err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, user)
+}
+
+// CreateUser creates a user of the authentication source
+func CreateUser(ctx *context.APIContext) {
+	in := &scim_service.User{}
+	if !decodeBody(ctx, in) {
+		return
+	}
+
+	source := getSource(ctx)
+	u, err := scim_service.CreateUser(ctx, source, in)
+	if err != nil {
+		handleError(ctx, "CreateUser", err)
+		return
+	}
+	user, err := scim_service.ToUser(ctx, source, u)
+	if err != nil {
+		handleError(ctx, "ToUser", err)
+		return
+	}
+	ctx.Resp.Header().Set("Location", user.Meta.Location)
+	writeJSON(ctx, http.StatusCreated, user)
+}
+
+// ReplaceUser replaces the attributes of a user of the authentication source
+func ReplaceUser(ctx *context.APIContext) {
+	source := getSource(ctx)
+	u, err := scim_service.GetUser(ctx, source, ctx.Params("id"))
+	if err != nil {
+		handleError(ctx, "GetUser", err)
+		return
+	}
+	in := &scim_service.User{}
+	if !decodeBody(ctx, in) {
+		return
+	}
+
+	if err := scim_service.ReplaceUser(ctx, source, u, in); err != nil {
+		handleError(ctx, "ReplaceUser", err)
+		return
+	}
+	user, err := scim_service.ToUser(ctx, source, u)
+	if err != nil {
+		handleError(ctx, "ToUser", err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, user)
+}
+
+// PatchUser modifies a user of the authentication source, e.g. to deactivate it
+func PatchUser(ctx *context.APIContext) {
+	source := getSource(ctx)
+	u, err := scim_service.GetUser(ctx, source, ctx.Params("id"))
+	if err != nil {
+		handleError(ctx, "GetUser", err)
+		return
+	}
+	patch := &scim_service.PatchOp{}
+	if !decodeBody(ctx, patch) {
+		return
+	}
+
+	if err := scim_service.PatchUser(ctx, source, u, patch); err != nil {
+		handleError(ctx, "PatchUser", err)
+		return
+	}
+	user, err := scim_service.ToUser(ctx, source, u)
+	if err != nil {
+		handleError(ctx, "ToUser", err)
+		return
+	}
+	writeJSON(ctx, http.StatusOK, user)
+}
+
+// DeleteUser deletes a user of the authentication source
+func DeleteUser(ctx *context.APIContext) {
+	source := getSource(ctx)
+	u, err := scim_service.GetUser(ctx, source, ctx.Params("id"))
+	if err != nil {
+		handleError(ctx, "GetUser", err)
+		return
+	}
+
+	if err := scim_service.DeleteUser(ctx, source, u); err != nil {
+		handleError(ctx, "DeleteUser", err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
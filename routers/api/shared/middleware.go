@@ -13,6 +13,7 @@ import (
 	"forgejo.org/routers/common"
 	"forgejo.org/services/auth"
 	"forgejo.org/services/context"
+	"forgejo.org/services/ratelimit"
 
 	"github.com/go-chi/cors"
 )
@@ -38,6 +39,7 @@ func Middlewares() (stack []any) {
 		verifyAuthWithOptions(&common.VerifyOptions{
 			SignInRequired: setting.Service.RequireSignInView,
 		}),
+		ratelimit.APIMiddleware(),
 	)
 }
 
@@ -6,10 +6,12 @@ package admin
 import (
 	"net/http"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/structs"
 	"forgejo.org/modules/util"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/cron"
 )
@@ -79,6 +81,7 @@ func PostCronTask(ctx *context.APIContext) {
 		ctx.NotFound()
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminCronRun, audit_service.SystemTarget(task.Name), nil, nil)
 	task.Run()
 	log.Trace("Cron Task %s started by admin(%s)", task.Name, ctx.Doer.Name)
 
@@ -171,6 +171,10 @@ func repoAssignment() func(ctx *context.APIContext) {
 		ctx.Repo.Owner = owner
 		ctx.ContextUser = owner
 
+		if owner.IsOrganization() && !checkOrgSecurityPolicy(ctx, organization.OrgFromUser(owner)) {
+			return
+		}
+
 		// Get repository.
 		repo, err := repo_model.GetRepositoryByName(ctx, owner.ID, repoName)
 		if err != nil {
@@ -231,7 +235,37 @@ func repoAssignment() func(ctx *context.APIContext) {
 			ctx.NotFound()
 			return
 		}
+
+		if !context.AccessTokenAllowsRepo(ctx.Data, repo) {
+			ctx.Error(http.StatusForbidden, "reqToken", "token is restricted to other repositories")
+			return
+		}
+	}
+}
+
+// checkOrgSecurityPolicy rejects the requests of members who don't comply with the security policy
+// of the organization, telling them what to fix. It returns false if the response has been written.
+func checkOrgSecurityPolicy(ctx *context.APIContext, org *organization.Organization) bool {
+	violations, err := context.CheckOrgSecurityPolicy(ctx, org, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "CheckOrgSecurityPolicy", err)
+		return false
+	}
+	if violations != nil {
+		ctx.Error(http.StatusForbidden, "OrgSecurityPolicy", context.OrgSecurityPolicyMessage(org, violations))
+		return false
+	}
+	return true
+}
+
+// restrictedAccessToken returns the personal access token used for the request
+// if it is restricted to single repositories or an organization
+func restrictedAccessToken(ctx *context.APIContext) *auth_model.AccessToken {
+	token, ok := ctx.Data["ApiAccessToken"].(*auth_model.AccessToken)
+	if !ok || !token.IsRestricted() {
+		return nil
 	}
+	return token
 }
 
 // must be used within a group with a call to repoAssignment() to set ctx.Repo
@@ -260,6 +294,13 @@ func commentAssignment(idParam string) func(ctx *context.APIContext) {
 			ctx.NotFound()
 			return
 		}
+		if visible, err := comment.Issue.IsVisibleTo(ctx, ctx.Doer, ctx.Repo.Permission); err != nil {
+			ctx.InternalServerError(err)
+			return
+		} else if !visible {
+			ctx.NotFound()
+			return
+		}
 
 		comment.Issue.Repo = ctx.Repo.Repository
 
@@ -269,6 +310,10 @@ func commentAssignment(idParam string) func(ctx *context.APIContext) {
 
 func reqPackageAccess(accessMode perm.AccessMode) func(ctx *context.APIContext) {
 	return func(ctx *context.APIContext) {
+		if !context.AccessTokenAllowsOwner(ctx.Data, ctx.Package.Owner) {
+			ctx.Error(http.StatusForbidden, "reqPackageAccess", "token is restricted to other owners")
+			return
+		}
 		if ctx.Package.AccessMode < accessMode && !ctx.IsUserSiteAdmin() {
 			ctx.Error(http.StatusForbidden, "reqPackageAccess", "user should have specific permission or be a site admin")
 			return
@@ -368,6 +413,26 @@ func tokenRequiresScopes(requiredScopeCategories ...auth_model.AccessTokenScopeC
 
 		ctx.Data["requiredScopeCategories"] = requiredScopeCategories
 
+		// restricted tokens can only be used for the routes of their repositories or organization,
+		// the user and admin routes would give access to everything else
+		if restrictedAccessToken(ctx) != nil {
+			bound := ctx.Params(":reponame") != "" || ctx.Params(":org") != "" || ctx.Params(":teamid") != ""
+			for _, category := range requiredScopeCategories {
+				switch category {
+				case auth_model.AccessTokenScopeCategoryPackage:
+					// reqPackageAccess checks the owner of the package
+					continue
+				case auth_model.AccessTokenScopeCategoryUser, auth_model.AccessTokenScopeCategoryAdmin:
+				default:
+					if bound {
+						continue
+					}
+				}
+				ctx.Error(http.StatusForbidden, "tokenRequiresScope", "token is restricted to specific repositories or an organization")
+				return
+			}
+		}
+
 		// check if scope only applies to public resources
 		publicOnly, err := scope.PublicOnly()
 		if err != nil {
@@ -667,6 +732,15 @@ func orgAssignment(args ...bool) func(ctx *context.APIContext) {
 				return
 			}
 			ctx.ContextUser = ctx.Org.Organization.AsUser()
+
+			if token := restrictedAccessToken(ctx); token != nil && token.OrgID != ctx.Org.Organization.ID {
+				ctx.Error(http.StatusForbidden, "reqToken", "token is restricted to other organizations")
+				return
+			}
+
+			if !checkOrgSecurityPolicy(ctx, ctx.Org.Organization) {
+				return
+			}
 		}
 
 		if assignTeam {
@@ -679,6 +753,22 @@ func orgAssignment(args ...bool) func(ctx *context.APIContext) {
 				}
 				return
 			}
+
+			if token := restrictedAccessToken(ctx); token != nil && token.OrgID != ctx.Org.Team.OrgID {
+				ctx.Error(http.StatusForbidden, "reqToken", "token is restricted to other organizations")
+				return
+			}
+
+			if !assignOrg {
+				org, err := organization.GetOrgByID(ctx, ctx.Org.Team.OrgID)
+				if err != nil {
+					ctx.Error(http.StatusInternalServerError, "GetOrgByID", err)
+					return
+				}
+				if !checkOrgSecurityPolicy(ctx, org) {
+					return
+				}
+			}
 		}
 	}
 }
@@ -755,6 +845,27 @@ func mustEnableIssuesOrPulls(ctx *context.APIContext) {
 	}
 }
 
+// mustSeeConfidentialIssue hides a confidential issue from the users who can't see it
+func mustSeeConfidentialIssue(ctx *context.APIContext) {
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+	if !issue.IsConfidential {
+		return
+	}
+	if visible, err := issue.IsVisibleTo(ctx, ctx.Doer, ctx.Repo.Permission); err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsVisibleTo", err)
+	} else if !visible {
+		ctx.NotFound()
+	}
+}
+
 func mustEnableLocalIssuesIfIsIssue(ctx *context.APIContext) {
 	if ctx.Repo.Repository.UnitEnabled(ctx, unit.TypeIssues) {
 		return
@@ -921,6 +1032,7 @@ func Routes() *web.Route {
 				m.Get("/api", settings.GetGeneralAPISettings)
 				m.Get("/attachment", settings.GetGeneralAttachmentSettings)
 				m.Get("/repository", settings.GetGeneralRepoSettings)
+				m.Get("/ssh_ca", settings.GetSSHCertificateAuthority)
 			})
 		})
 
@@ -1038,6 +1150,7 @@ func Routes() *web.Route {
 				m.Combo("/{id}").Get(user.GetPublicKey).
 					Delete(user.DeletePublicKey)
 			})
+			m.Post("/ssh_certificates", bind(api.CreateSSHCertificateOption{}), user.CreateSSHCertificate)
 
 			// (admin:application scope)
 			m.Group("/applications", func() {
@@ -1156,6 +1269,7 @@ func Routes() *web.Route {
 						m.Post("/tests", context.ReferencesGitRepo(), context.RepoRefForAPI, repo.TestHook)
 					})
 				}, reqToken(), reqAdmin(), reqWebhooksEnabled())
+				m.Get("/audit", reqToken(), reqAdmin(), repo.ListAuditEvents)
 				m.Group("/collaborators", func() {
 					m.Get("", reqAnyRepoReader(), repo.ListCollaborators)
 					m.Group("/{collaborator}", func() {
@@ -1289,6 +1403,11 @@ func Routes() *web.Route {
 							Delete(reqToken(), reqRepoWriter(unit.TypeReleases), repo.DeleteReleaseByTag)
 					})
 				}, reqRepoReader(unit.TypeReleases))
+				m.Group("/security-advisories", func() {
+					m.Get("", repo.ListSecurityAdvisories)
+					m.Get("/{identifier}", repo.GetSecurityAdvisory)
+					m.Get("/{identifier}/osv", repo.GetSecurityAdvisoryOSV)
+				}, reqRepoReader(unit.TypeCode))
 				m.Post("/mirror-sync", reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, context.EnforceQuotaAPI(quota_model.LimitSubjectSizeGitAll, context.QuotaTargetRepo), repo.MirrorSync)
 				m.Post("/push_mirrors-sync", reqAdmin(), reqToken(), mustNotBeArchived, repo.PushMirrorSync)
 				m.Group("/push_mirrors", func() {
@@ -1472,6 +1591,10 @@ func Routes() *web.Route {
 								Delete(reqToken(), bind(api.DeleteLabelsOption{}), repo.ClearIssueLabels)
 							m.Delete("/{identifier}", reqToken(), bind(api.DeleteLabelsOption{}), repo.DeleteIssueLabel)
 						})
+						m.Group("/custom_fields", func() {
+							m.Get("", repo.ListIssueCustomFieldValues)
+							m.Put("/{id}", reqToken(), mustNotBeArchived, bind(api.SetIssueCustomFieldValueOption{}), repo.SetIssueCustomFieldValue)
+						})
 						m.Group("/times", func() {
 							m.Combo("").
 								Get(repo.ListTrackedTimes).
@@ -1508,6 +1631,14 @@ func Routes() *web.Route {
 							Get(repo.GetIssueDependencies).
 							Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.CreateIssueDependency).
 							Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveIssueDependency)
+						m.Group("/sub_issues", func() {
+							m.Combo("").
+								Get(repo.ListSubIssues).
+								Post(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.AddSubIssue).
+								Delete(reqToken(), mustNotBeArchived, bind(api.IssueMeta{}), repo.RemoveSubIssue)
+							m.Get("/progress", repo.GetSubIssueProgress)
+						})
+						m.Get("/parent", repo.GetIssueParent)
 						m.Combo("/blocks").
 							Get(repo.GetIssueBlocks).
 							Post(reqToken(), bind(api.IssueMeta{}), repo.CreateIssueBlocking).
@@ -1518,7 +1649,7 @@ func Routes() *web.Route {
 								Delete(reqToken(), reqAdmin(), repo.UnpinIssue)
 							m.Patch("/{position}", reqToken(), reqAdmin(), repo.MoveIssuePin)
 						})
-					}, mustEnableLocalIssuesIfIsIssue)
+					}, mustEnableLocalIssuesIfIsIssue, mustSeeConfidentialIssue)
 				}, mustEnableIssuesOrPulls)
 				m.Group("/labels", func() {
 					m.Combo("").Get(repo.ListLabels).
@@ -1527,6 +1658,13 @@ func Routes() *web.Route {
 						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditLabelOption{}), repo.EditLabel).
 						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteLabel)
 				})
+				m.Group("/custom_fields", func() {
+					m.Combo("").Get(repo.ListCustomFields).
+						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateCustomFieldOption{}), repo.CreateCustomField)
+					m.Combo("/{id}").Get(repo.GetCustomField).
+						Patch(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.EditCustomFieldOption{}), repo.EditCustomField).
+						Delete(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), repo.DeleteCustomField)
+				})
 				m.Group("/milestones", func() {
 					m.Combo("").Get(repo.ListMilestones).
 						Post(reqToken(), reqRepoWriter(unit.TypeIssues, unit.TypePullRequests), bind(api.CreateMilestoneOption{}), repo.CreateMilestone)
@@ -1544,6 +1682,12 @@ func Routes() *web.Route {
 					m.Get("", packages.GetPackage)
 					m.Delete("", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
 					m.Get("/files", packages.ListPackageFiles)
+					m.Get("/components", packages.ListPackageComponents)
+					m.Group("/sbom", func() {
+						m.Get("", packages.GetPackageSBOM)
+						m.Put("", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.UploadPackageSBOM)
+						m.Delete("", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.DeletePackageSBOM)
+					})
 				})
 
 				m.Post("/-/link/{repo_name}", reqToken(), reqPackageAccess(perm.AccessModeWrite), packages.LinkPackage)
@@ -1608,6 +1752,7 @@ func Routes() *web.Route {
 				m.Delete("", org.DeleteAvatar)
 			}, reqToken(), reqOrgOwnership())
 			m.Get("/activities/feeds", org.ListOrgActivityFeeds)
+			m.Get("/audit", reqToken(), reqOrgOwnership(), org.ListAuditEvents)
 
 			if setting.Quota.Enabled {
 				m.Group("/quota", func() {
@@ -1649,6 +1794,7 @@ func Routes() *web.Route {
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryOrganization), orgAssignment(false, true), reqToken(), reqTeamMembership(), checkTokenPublicOnly())
 
 		m.Group("/admin", func() {
+			m.Get("/audit", admin.ListAuditEvents)
 			m.Group("/cron", func() {
 				m.Get("", admin.ListCronTasks)
 				m.Post("/{task}", admin.PostCronTask)
@@ -1668,6 +1814,7 @@ func Routes() *web.Route {
 					m.Post("/orgs", bind(api.CreateOrgOption{}), admin.CreateOrg)
 					m.Post("/repos", bind(api.CreateRepoOption{}), admin.CreateRepo)
 					m.Post("/rename", bind(api.RenameUserOption{}), admin.RenameUser)
+					m.Delete("/sessions", admin.RevokeUserSessions)
 					m.Combo("/emails").
 						Get(admin.ListUserEmails).
 						Delete(bind(api.DeleteEmailOption{}), admin.DeleteUserEmails)
@@ -10,6 +10,7 @@ import (
 
 	"forgejo.org/models"
 	activities_model "forgejo.org/models/activities"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
@@ -20,6 +21,7 @@ import (
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/user"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	org_service "forgejo.org/services/org"
@@ -251,6 +253,7 @@ func CreateTeam(ctx *context.APIContext) {
 		}
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamCreate, audit_service.TeamTarget(team), nil, audit_service.TeamState(team))
 
 	apiTeam, err := convert.ToTeam(ctx, team, true)
 	if err != nil {
@@ -292,6 +295,7 @@ func EditTeam(ctx *context.APIContext) {
 		ctx.InternalServerError(err)
 		return
 	}
+	before := audit_service.TeamState(team)
 
 	if form.CanCreateOrgRepo != nil {
 		team.CanCreateOrgRepo = team.IsOwnerTeam() || *form.CanCreateOrgRepo
@@ -339,6 +343,7 @@ func EditTeam(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "EditTeam", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamUpdate, audit_service.TeamTarget(team), before, audit_service.TeamState(team))
 
 	apiTeam, err := convert.ToTeam(ctx, team)
 	if err != nil {
@@ -370,6 +375,7 @@ func DeleteTeam(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "DeleteTeam", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamDelete, audit_service.TeamTarget(ctx.Org.Team), audit_service.TeamState(ctx.Org.Team), nil)
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -502,6 +508,7 @@ func AddTeamMember(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "AddMember", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamMemberAdd, audit_service.TeamTarget(ctx.Org.Team), nil, map[string]int64{"member_id": u.ID})
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -539,6 +546,7 @@ func RemoveTeamMember(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "RemoveTeamMember", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamMemberRemove, audit_service.TeamTarget(ctx.Org.Team), map[string]int64{"member_id": u.ID}, nil)
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -702,6 +710,7 @@ func AddTeamRepository(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "TeamAddRepository", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamRepoAdd, audit_service.TeamRepoTarget(ctx.Org.Team, repo.ID), nil, nil)
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -754,6 +763,7 @@ func RemoveTeamRepository(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "RemoveRepository", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamRepoRemove, audit_service.TeamRepoTarget(ctx.Org.Team, repo.ID), nil, nil)
 	ctx.Status(http.StatusNoContent)
 }
 
@@ -43,11 +43,19 @@ func ListPackages(ctx *context.APIContext) {
 	//   in: query
 	//   description: package type filter
 	//   type: string
-	//   enum: [alpine, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, vagrant]
+	//   enum: [alpine, cargo, chef, composer, conan, conda, container, cran, debian, generic, go, helm, maven, npm, nuget, pub, pypi, rpm, rubygems, swift, terraform, vagrant]
 	// - name: q
 	//   in: query
 	//   description: name filter
 	//   type: string
+	// - name: component
+	//   in: query
+	//   description: only list package versions whose SBOM includes the component with this name or package URL
+	//   type: string
+	// - name: component_version
+	//   in: query
+	//   description: version of the component, requires component
+	//   type: string
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/PackageList"
@@ -64,7 +72,11 @@ func ListPackages(ctx *context.APIContext) {
 		Type:       packages.Type(packageType),
 		Name:       packages.SearchValue{Value: query},
 		IsInternal: optional.Some(false),
-		Paginator:  &listOptions,
+		HasComponent: packages.ComponentSearchValue{
+			Name:    ctx.FormTrim("component"),
+			Version: ctx.FormTrim("component_version"),
+		},
+		Paginator: &listOptions,
 	})
 	if err != nil {
 		ctx.Error(http.StatusInternalServerError, "SearchVersions", err)
@@ -0,0 +1,250 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	packages_model "forgejo.org/models/packages"
+	packages_module "forgejo.org/modules/packages"
+	sbom_module "forgejo.org/modules/packages/sbom"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	packages_service "forgejo.org/services/packages"
+)
+
+// UploadPackageSBOM attaches a SBOM to a package version
+func UploadPackageSBOM(ctx *context.APIContext) {
+	// swagger:operation PUT /packages/{owner}/{type}/{name}/{version}/sbom package uploadPackageSBOM
+	// ---
+	// summary: Attach a CycloneDX or SPDX JSON document to a package version
+	// description: An existing SBOM of the package version is replaced.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: the SBOM document
+	//   schema:
+	//     type: object
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageSBOM"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "413":
+	//     "$ref": "#/responses/error"
+
+	buf, err := packages_module.CreateHashedBufferFromReader(http.MaxBytesReader(ctx.Resp, ctx.Req.Body, sbom_module.MaxDocumentSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.Error(http.StatusRequestEntityTooLarge, "", sbom_module.ErrDocumentTooLarge)
+			return
+		}
+		ctx.Error(http.StatusInternalServerError, "CreateHashedBufferFromReader", err)
+		return
+	}
+	defer buf.Close()
+
+	ps, err := packages_service.UploadSBOM(ctx, ctx.Doer, ctx.Package.Descriptor.Version, buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusBadRequest, "UploadSBOM", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "UploadSBOM", err)
+		}
+		return
+	}
+
+	pb, err := packages_model.GetBlobByID(ctx, ps.BlobID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetBlobByID", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToPackageSBOM(ps, pb))
+}
+
+// GetPackageSBOM downloads the SBOM of a package version
+func GetPackageSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/sbom package getPackageSBOM
+	// ---
+	// summary: Download the SBOM document attached to a package version
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     description: the SBOM document
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	s, ps, err := packages_service.GetSBOMStream(ctx, ctx.Package.Descriptor.Version)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetSBOMStream", err)
+		}
+		return
+	}
+	defer s.Close()
+
+	contentType := sbom_module.MediaTypeCycloneDX
+	if ps.Format == string(sbom_module.FormatSPDX) {
+		contentType = sbom_module.MediaTypeSPDX
+	}
+
+	ctx.ServeContent(s, &context.ServeHeaderOptions{
+		ContentType:  contentType,
+		LastModified: ps.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// DeletePackageSBOM removes the SBOM of a package version
+func DeletePackageSBOM(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/{type}/{name}/{version}/sbom package deletePackageSBOM
+	// ---
+	// summary: Remove the SBOM and its components from a package version
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pv := ctx.Package.Descriptor.Version
+
+	if _, err := packages_model.GetSBOMByVersionID(ctx, pv.ID); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetSBOMByVersionID", err)
+		}
+		return
+	}
+
+	if err := packages_model.DeleteSBOMByVersionID(ctx, pv.ID); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteSBOMByVersionID", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListPackageComponents gets all components listed in the SBOM of a package version
+func ListPackageComponents(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/components package listPackageComponents
+	// ---
+	// summary: Gets all components listed in the SBOM of a package version
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageComponentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pcs, err := packages_model.GetComponentsByVersionID(ctx, ctx.Package.Descriptor.Version.ID)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetComponentsByVersionID", err)
+		return
+	}
+
+	apiComponents := make([]*api.PackageComponent, 0, len(pcs))
+	for _, pc := range pcs {
+		apiComponents = append(apiComponents, convert.ToPackageComponent(pc))
+	}
+
+	ctx.JSON(http.StatusOK, apiComponents)
+}
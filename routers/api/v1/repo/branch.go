@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	"forgejo.org/models/organization"
@@ -20,6 +21,7 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	pull_service "forgejo.org/services/pull"
@@ -703,6 +705,8 @@ func CreateBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "UpdateProtectBranch", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionBranchProtectionEdit, audit_service.BranchProtectionTarget(repo, protectBranch),
+		nil, audit_service.BranchProtectionState(protectBranch))
 
 	if isBranchExist {
 		if err = pull_service.CheckPRsForBaseBranch(ctx, ctx.Repo.Repository, ruleName); err != nil {
@@ -802,6 +806,7 @@ func EditBranchProtection(ctx *context.APIContext) {
 		ctx.NotFound()
 		return
 	}
+	before := audit_service.BranchProtectionState(protectBranch)
 
 	if form.EnablePush != nil {
 		if !*form.EnablePush {
@@ -978,6 +983,8 @@ func EditBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "UpdateProtectBranch", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionBranchProtectionEdit, audit_service.BranchProtectionTarget(repo, protectBranch),
+		before, audit_service.BranchProtectionState(protectBranch))
 
 	isPlainRule := !git_model.IsRuleNameSpecial(bpName)
 	var isBranchExist bool
@@ -1079,6 +1086,8 @@ func DeleteBranchProtection(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "DeleteProtectedBranch", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionBranchProtectionDrop, audit_service.BranchProtectionTarget(repo, bp),
+		audit_service.BranchProtectionState(bp), nil)
 
 	ctx.Status(http.StatusNoContent)
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"net/http"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
@@ -17,6 +18,7 @@ import (
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	repo_service "forgejo.org/services/repository"
@@ -192,12 +194,16 @@ func AddCollaborator(ctx *context.APIContext) {
 		}
 		return
 	}
+	target := audit_service.CollaboratorTarget(ctx.Repo.Repository, collaborator.ID, collaborator.Name)
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionCollaboratorAdd, target, nil, nil)
 
 	if form.Permission != nil {
-		if err := repo_model.ChangeCollaborationAccessMode(ctx, ctx.Repo.Repository, collaborator.ID, perm.ParseAccessMode(*form.Permission)); err != nil {
+		mode := perm.ParseAccessMode(*form.Permission)
+		if err := repo_model.ChangeCollaborationAccessMode(ctx, ctx.Repo.Repository, collaborator.ID, mode); err != nil {
 			ctx.Error(http.StatusInternalServerError, "ChangeCollaborationAccessMode", err)
 			return
 		}
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionCollaboratorAccess, target, nil, map[string]string{"mode": mode.String()})
 	}
 
 	ctx.Status(http.StatusNoContent)
@@ -248,6 +254,7 @@ func DeleteCollaborator(ctx *context.APIContext) {
 		ctx.Error(http.StatusInternalServerError, "DeleteCollaboration", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionCollaboratorRemove, audit_service.CollaboratorTarget(ctx.Repo.Repository, collaborator.ID, collaborator.Name), nil, nil)
 	ctx.Status(http.StatusNoContent)
 }
 
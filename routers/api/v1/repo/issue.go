@@ -19,11 +19,14 @@ import (
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/gitrepo"
 	issue_indexer "forgejo.org/modules/indexer/issues"
+	issue_template "forgejo.org/modules/issue/template"
 	"forgejo.org/modules/optional"
 	"forgejo.org/modules/setting"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
 	"forgejo.org/services/context"
@@ -284,6 +287,9 @@ func SearchIssues(ctx *context.APIContext) {
 		SortBy:              issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
 	}
 
+	if ctx.Doer != nil {
+		searchOpt.DoerID = ctx.Doer.ID
+	}
 	if err := searchOpt.WithKeyword(ctx, keyword); err != nil {
 		ctx.Error(http.StatusInternalServerError, "WithKeyword", err)
 		return
@@ -320,6 +326,17 @@ func SearchIssues(ctx *context.APIContext) {
 		searchOpt.PriorityRepoID = optional.Some(priorityRepoID)
 	}
 
+	if !isPull.ValueOrDefault(false) { // pull requests are never confidential
+		confidential, err := issue_service.ConfidentialFilter(ctx, ctx.Doer, repoIDs)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "ConfidentialFilter", err)
+			return
+		}
+		if confidential != nil {
+			searchOpt.Confidential = &issue_indexer.ConfidentialFilter{UserID: confidential.UserID, RepoIDs: confidential.RepoIDs}
+		}
+	}
+
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
 		ctx.Error(http.StatusInternalServerError, "SearchIssues", err)
@@ -400,6 +417,13 @@ func ListIssues(ctx *context.APIContext) {
 	//   in: query
 	//   description: Only show items in which the given user was mentioned
 	//   type: string
+	// - name: custom_field
+	//   in: query
+	//   description: Only show items with the given value of a custom field, formatted as `{field id}:{value}`. An empty value only shows items without value
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
 	// - name: page
 	//   in: query
 	//   description: page number of results to return (1-based)
@@ -410,15 +434,16 @@ func ListIssues(ctx *context.APIContext) {
 	//   type: integer
 	// - name: sort
 	//   in: query
-	//   description: Type of sort
+	//   description: "Type of sort: relevance, latest, oldest, recentupdate, leastupdate, mostcomment, leastcomment, nearduedate, farduedate, or customfield-{field id} and customfield-{field id}-desc to sort by the value of a custom field"
 	//   type: string
-	//   enum: [relevance, latest, oldest, recentupdate, leastupdate, mostcomment, leastcomment, nearduedate, farduedate]
 	//   default: latest
 	// responses:
 	//   "200":
 	//     "$ref": "#/responses/IssueList"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 	before, since, err := context.GetQueryBeforeSince(ctx.Base)
 	if err != nil {
 		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
@@ -520,13 +545,21 @@ func ListIssues(ctx *context.APIContext) {
 	if ctx.Written() {
 		return
 	}
+	customFields := getCustomFieldFilters(ctx)
+	if ctx.Written() {
+		return
+	}
 
 	searchOpt := &issue_indexer.SearchOptions{
-		Paginator: &listOptions,
-		RepoIDs:   []int64{ctx.Repo.Repository.ID},
-		IsPull:    isPull,
-		IsClosed:  isClosed,
-		SortBy:    issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
+		Paginator:    &listOptions,
+		RepoIDs:      []int64{ctx.Repo.Repository.ID},
+		IsPull:       isPull,
+		IsClosed:     isClosed,
+		CustomFields: customFields,
+		SortBy:       issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
+	}
+	if ctx.Doer != nil {
+		searchOpt.DoerID = ctx.Doer.ID
 	}
 	if err := searchOpt.WithKeyword(ctx, keyword); err != nil {
 		ctx.Error(http.StatusInternalServerError, "WithKeyword", err)
@@ -565,6 +598,12 @@ func ListIssues(ctx *context.APIContext) {
 	if mentionedByID > 0 {
 		searchOpt.MentionID = optional.Some(mentionedByID)
 	}
+	if !ctx.Repo.CanWrite(unit.TypeIssues) {
+		searchOpt.Confidential = &issue_indexer.ConfidentialFilter{}
+		if ctx.Doer != nil {
+			searchOpt.Confidential.UserID = ctx.Doer.ID
+		}
+	}
 
 	ids, total, err := issue_indexer.SearchIssues(ctx, searchOpt)
 	if err != nil {
@@ -648,6 +687,24 @@ func GetIssue(ctx *context.APIContext) {
 	ctx.JSON(http.StatusOK, convert.ToAPIIssue(ctx, ctx.Doer, issue))
 }
 
+// getIssueTemplate returns the issue template with the file name in the default branch, and writes an error
+// if it does not exist
+func getIssueTemplate(ctx *context.APIContext, filename string) *api.IssueTemplate {
+	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, ctx.Repo.Repository)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "RepositoryFromContextOrOpen", err)
+		return nil
+	}
+	defer closer.Close()
+
+	it, err := issue_service.GetTemplateFromDefaultBranch(ctx.Repo.Repository, gitRepo, filename)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "GetTemplateFromDefaultBranch", err)
+		return nil
+	}
+	return it
+}
+
 // CreateIssue create an issue of a repository
 func CreateIssue(ctx *context.APIContext) {
 	// swagger:operation POST /repos/{owner}/{repo}/issues issue issueCreateIssue
@@ -692,14 +749,15 @@ func CreateIssue(ctx *context.APIContext) {
 	}
 
 	issue := &issues_model.Issue{
-		RepoID:       ctx.Repo.Repository.ID,
-		Repo:         ctx.Repo.Repository,
-		Title:        form.Title,
-		PosterID:     ctx.Doer.ID,
-		Poster:       ctx.Doer,
-		Content:      form.Body,
-		Ref:          form.Ref,
-		DeadlineUnix: deadlineUnix,
+		RepoID:         ctx.Repo.Repository.ID,
+		Repo:           ctx.Repo.Repository,
+		Title:          form.Title,
+		PosterID:       ctx.Doer.ID,
+		Poster:         ctx.Doer,
+		Content:        form.Body,
+		Ref:            form.Ref,
+		DeadlineUnix:   deadlineUnix,
+		IsConfidential: form.Confidential,
 	}
 
 	assigneeIDs := make([]int64, 0)
@@ -739,6 +797,32 @@ func CreateIssue(ctx *context.APIContext) {
 		form.Labels = make([]int64, 0)
 	}
 
+	// the labels, assignees, milestone and project of a template are set even if the user is not a writer,
+	// unless others were given
+	var projectID int64
+	if form.Template != "" {
+		it := getIssueTemplate(ctx, form.Template)
+		if ctx.Written() {
+			return
+		}
+		if it.Type() == api.IssueTemplateTypeYaml {
+			values := issue_service.TemplateFieldsToForm(it, form.TemplateFields)
+			if err := issue_service.ValidateTemplateForm(it, values); err != nil {
+				ctx.Error(http.StatusUnprocessableEntity, "ValidateTemplateForm", err)
+				return
+			}
+			issue.Content = issue_template.RenderToMarkdown(it, values)
+		} else if issue.Content == "" {
+			issue.Content = it.Content
+		}
+		metas, err := issue_service.GetTemplateMetas(ctx, ctx.Repo.Repository, it, false)
+		if err != nil {
+			ctx.Error(http.StatusInternalServerError, "GetTemplateMetas", err)
+			return
+		}
+		form.Labels, assigneeIDs, issue.MilestoneID, projectID = metas.Fill(form.Labels, assigneeIDs, issue.MilestoneID, 0)
+	}
+
 	if err := issue_service.NewIssue(ctx, ctx.Repo.Repository, issue, form.Labels, nil, assigneeIDs); err != nil {
 		if errors.Is(err, user_model.ErrBlockedByUser) {
 			ctx.Error(http.StatusForbidden, "BlockedByUser", err)
@@ -751,6 +835,13 @@ func CreateIssue(ctx *context.APIContext) {
 		return
 	}
 
+	if projectID > 0 && ctx.Repo.CanRead(unit.TypeProjects) {
+		if err := issues_model.IssueAssignOrRemoveProject(ctx, issue, ctx.Doer, projectID, 0); err != nil {
+			ctx.Error(http.StatusInternalServerError, "IssueAssignOrRemoveProject", err)
+			return
+		}
+	}
+
 	if form.Closed {
 		if err := issue_service.ChangeStatus(ctx, issue, ctx.Doer, "", true); err != nil {
 			if issues_model.IsErrDependenciesLeft(err) {
@@ -921,6 +1012,16 @@ func EditIssue(ctx *context.APIContext) {
 			return
 		}
 	}
+	if canWrite && form.Confidential != nil {
+		if err := issue_service.ChangeConfidential(ctx, ctx.Doer, issue, *form.Confidential); err != nil {
+			if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.Error(http.StatusUnprocessableEntity, "ChangeConfidential", err)
+				return
+			}
+			ctx.Error(http.StatusInternalServerError, "ChangeConfidential", err)
+			return
+		}
+	}
 	if form.State != nil {
 		if issue.IsPull {
 			if err := issue.LoadPullRequest(ctx); err != nil {
@@ -243,7 +243,8 @@ func isXRefCommentAccessible(ctx stdCtx.Context, user *user_model.User, c *issue
 			return false
 		}
 	}
-	return true
+	visible, err := c.IsRefIssueVisibleTo(ctx, user)
+	return err == nil && visible
 }
 
 // ListRepoIssueComments returns all issue-comments for a repo
@@ -320,6 +321,12 @@ func ListRepoIssueComments(ctx *context.APIContext) {
 		Before:      before,
 		IsPull:      isPull,
 	}
+	if !ctx.Repo.CanWrite(unit.TypeIssues) {
+		opts.Confidential = &issues_model.ConfidentialFilter{}
+		if ctx.Doer != nil {
+			opts.Confidential.UserID = ctx.Doer.ID
+		}
+	}
 
 	comments, err := issues_model.FindComments(ctx, opts)
 	if err != nil {
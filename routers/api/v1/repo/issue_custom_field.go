@@ -0,0 +1,435 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListCustomFields list the custom fields of a repository
+func ListCustomFields(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/custom_fields issue issueListCustomFields
+	// ---
+	// summary: Get the custom fields of the issues of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomFieldList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	fields, count, err := db.FindAndCount[issues_model.CustomField](ctx, issues_model.FindCustomFieldOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		RepoID:      ctx.Repo.Repository.ID,
+	})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindCustomFields", err)
+		return
+	}
+
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, convert.ToCustomFieldList(fields))
+}
+
+// GetCustomField get a custom field of a repository
+func GetCustomField(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/custom_fields/{id} issue issueGetCustomField
+	// ---
+	// summary: Get a custom field of the issues of a repository
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field to get
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	field := getRepoCustomField(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToCustomField(field))
+}
+
+// CreateCustomField create a custom field for a repository
+func CreateCustomField(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/custom_fields issue issueCreateCustomField
+	// ---
+	// summary: Create a custom field for the issues of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateCustomFieldOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/CustomField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateCustomFieldOption)
+
+	field := &issues_model.CustomField{
+		RepoID:  ctx.Repo.Repository.ID,
+		Name:    form.Name,
+		Type:    issues_model.CustomFieldType(form.Type),
+		Options: form.Options,
+		Sorting: form.Sorting,
+	}
+	if err := issues_model.NewCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "NewCustomField", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "NewCustomField", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToCustomField(field))
+}
+
+// EditCustomField modify a custom field of a repository
+func EditCustomField(ctx *context.APIContext) {
+	// swagger:operation PATCH /repos/{owner}/{repo}/custom_fields/{id} issue issueEditCustomField
+	// ---
+	// summary: Update a custom field of the issues of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field to edit
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditCustomFieldOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/CustomField"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditCustomFieldOption)
+	field := getRepoCustomField(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if form.Name != nil {
+		field.Name = *form.Name
+	}
+	if form.Options != nil {
+		field.Options = form.Options
+	}
+	if form.Sorting != nil {
+		field.Sorting = *form.Sorting
+	}
+	if err := issues_model.UpdateCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "UpdateCustomField", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "UpdateCustomField", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToCustomField(field))
+}
+
+// DeleteCustomField delete a custom field of a repository
+func DeleteCustomField(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/custom_fields/{id} issue issueDeleteCustomField
+	// ---
+	// summary: Delete a custom field of the issues of a repository and its values
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field to delete
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	field := getRepoCustomField(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issues_model.DeleteCustomField(ctx, field); err != nil {
+		ctx.Error(http.StatusInternalServerError, "DeleteCustomField", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListIssueCustomFieldValues list the values of the custom fields of an issue
+func ListIssueCustomFieldValues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/custom_fields issue issueListCustomFieldValues
+	// ---
+	// summary: Get the values of the custom fields of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueCustomFieldValueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+
+	values, err := issues_model.GetIssueCustomFieldValues(ctx, []int64{issue.ID})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueCustomFieldValues", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueCustomFieldValues(values[issue.ID]))
+}
+
+// SetIssueCustomFieldValue set the value of a custom field of an issue
+func SetIssueCustomFieldValue(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/issues/{index}/custom_fields/{id} issue issueSetCustomFieldValue
+	// ---
+	// summary: Set the value of a custom field of an issue, an empty value removes it
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the custom field, a field of the repository or of the project of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetIssueCustomFieldValueOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueCustomFieldValueList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.SetIssueCustomFieldValueOption)
+	issue, err := issues_model.GetIssueByIndex(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":index"))
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return
+	}
+
+	if !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden, "", "Not repo writer")
+		return
+	}
+
+	if err := issue_service.SetCustomFieldValue(ctx, ctx.Doer, issue, ctx.ParamsInt64(":id"), form.Value); err != nil {
+		switch {
+		case issues_model.IsErrCustomFieldNotExist(err):
+			ctx.NotFound()
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Error(http.StatusUnprocessableEntity, "SetCustomFieldValue", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "SetCustomFieldValue", err)
+		}
+		return
+	}
+
+	values, err := issues_model.GetIssueCustomFieldValues(ctx, []int64{issue.ID})
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetIssueCustomFieldValues", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToIssueCustomFieldValues(values[issue.ID]))
+}
+
+func getRepoCustomField(ctx *context.APIContext) *issues_model.CustomField {
+	field, err := issues_model.GetCustomFieldInRepoByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		if issues_model.IsErrCustomFieldNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetCustomFieldInRepoByID", err)
+		}
+		return nil
+	}
+	return field
+}
+
+// getCustomFieldFilters returns the filters of the custom_field query parameters, formatted as "<field id>:<value>"
+func getCustomFieldFilters(ctx *context.APIContext) []issue_indexer.CustomFieldFilter {
+	var filters []issues_model.CustomFieldFilter
+	for _, s := range ctx.FormStrings("custom_field") {
+		filter, ok := issues_model.ParseCustomFieldFilter(s)
+		if !ok {
+			ctx.Error(http.StatusUnprocessableEntity, "ParseCustomFieldFilter", fmt.Sprintf("invalid custom field filter: %s", s))
+			return nil
+		}
+		filters = append(filters, filter)
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+
+	filters, err := issues_model.NormalizeCustomFieldFilters(ctx, filters)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "NormalizeCustomFieldFilters", err)
+		return nil
+	}
+	result := make([]issue_indexer.CustomFieldFilter, 0, len(filters))
+	for _, filter := range filters {
+		result = append(result, issue_indexer.CustomFieldFilter{FieldID: filter.FieldID, Value: filter.Value})
+	}
+	return result
+}
@@ -124,8 +124,14 @@ func GetIssueDependencies(ctx *context.APIContext) {
 		}
 
 		// check permission
-		if !perm.CanReadIssuesOrPulls(blocker.IsPull) {
-			if !canWrite {
+		visible, err := blocker.Issue.IsVisibleTo(ctx, ctx.Doer, perm)
+		if err != nil {
+			ctx.ServerError("IsVisibleTo", err)
+			return
+		}
+		if !visible {
+			// the title of a confidential issue must not be shown to anyone who can't see it
+			if !canWrite || blocker.IsConfidential {
 				hiddenBlocker := &issues_model.DependencyInfo{
 					Issue: issues_model.Issue{
 						Title: "HIDDEN",
@@ -377,7 +383,12 @@ func GetIssueBlocks(ctx *context.APIContext) {
 			repoPerms[depMeta.RepoID] = perm
 		}
 
-		if !perm.CanReadIssuesOrPulls(depMeta.IsPull) {
+		visible, err := depMeta.Issue.IsVisibleTo(ctx, ctx.Doer, perm)
+		if err != nil {
+			ctx.ServerError("IsVisibleTo", err)
+			return
+		}
+		if !visible {
 			continue
 		}
 
@@ -0,0 +1,331 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ListSubIssues list the sub-issues of an issue
+func ListSubIssues(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueListSubIssues
+	// ---
+	// summary: List the sub-issues of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/IssueList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	subIssues, err := issue_service.GetVisibleSubIssues(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetVisibleSubIssues", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIIssueList(ctx, ctx.Doer, subIssues))
+}
+
+// GetSubIssueProgress get the progress of the sub-issues of an issue
+func GetSubIssueProgress(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/sub_issues/progress issue issueGetSubIssueProgress
+	// ---
+	// summary: Get the progress of the sub-issues of an issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SubIssueProgress"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	subIssues, err := issue_service.GetVisibleSubIssues(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetVisibleSubIssues", err)
+		return
+	}
+	progress, err := issues_model.GetSubIssueProgress(ctx, subIssues)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetSubIssueProgress", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &api.SubIssueProgress{
+		Total:            progress.Total,
+		Closed:           progress.Closed,
+		TotalTrackedTime: progress.TrackedSeconds,
+	})
+}
+
+// GetIssueParent get the parent of an issue
+func GetIssueParent(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/issues/{index}/parent issue issueGetParent
+	// ---
+	// summary: Get the parent of a sub-issue
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	issue := getParamsIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	parent, err := issue_service.GetVisibleParentIssue(ctx, ctx.Doer, issue)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "GetVisibleParentIssue", err)
+		return
+	}
+	if parent == nil {
+		ctx.NotFound()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToAPIIssue(ctx, ctx.Doer, parent))
+}
+
+// AddSubIssue add a sub-issue to an issue
+func AddSubIssue(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueAddSubIssue
+	// ---
+	// summary: Make the issue given in the body a sub-issue of the issue in path
+	// description: The sub-issue must be an issue of a repository of the same owner.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/Issue"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, subIssue := getSubIssueParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.AddSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		if issues_model.IsErrSubIssueHasParent(err) {
+			ctx.Error(http.StatusConflict, "AddSubIssue", err)
+		} else if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "AddSubIssue", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "AddSubIssue", err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToAPIIssue(ctx, ctx.Doer, subIssue))
+}
+
+// RemoveSubIssue remove a sub-issue from an issue
+func RemoveSubIssue(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/issues/{index}/sub_issues issue issueRemoveSubIssue
+	// ---
+	// summary: Remove the issue given in the body from the sub-issues of the issue in path
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: index
+	//   in: path
+	//   description: index of the issue
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/IssueMeta"
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "423":
+	//     "$ref": "#/responses/repoArchivedError"
+
+	parent, subIssue := getSubIssueParams(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := issue_service.RemoveSubIssue(ctx, ctx.Doer, parent, subIssue); err != nil {
+		if issues_model.IsErrSubIssueNotExist(err) {
+			ctx.NotFound(err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "RemoveSubIssue", err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getSubIssueParams returns the issue in path, which the doer must be able to change,
+// and the issue in the body, which the doer must be able to see
+func getSubIssueParams(ctx *context.APIContext) (parent, subIssue *issues_model.Issue) {
+	parent = getParamsIssue(ctx)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if parent.IsPull || !ctx.Repo.CanWriteIssuesOrPulls(false) {
+		ctx.NotFound()
+		return nil, nil
+	}
+
+	form := web.GetForm(ctx).(*api.IssueMeta)
+	repo := ctx.Repo.Repository
+	if form.Owner != repo.OwnerName || form.Name != repo.Name {
+		var err error
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, form.Owner, form.Name)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.NotFound("IsErrRepoNotExist", err)
+			} else {
+				ctx.Error(http.StatusInternalServerError, "GetRepositoryByOwnerAndName", err)
+			}
+			return nil, nil
+		}
+	}
+	perm := getPermissionForRepo(ctx, repo)
+	if ctx.Written() {
+		return nil, nil
+	}
+	if !perm.CanReadIssuesOrPulls(false) {
+		ctx.NotFound()
+		return nil, nil
+	}
+
+	subIssue, err := issues_model.GetIssueByIndex(ctx, repo.ID, form.Index)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			ctx.NotFound("IsErrIssueNotExist", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetIssueByIndex", err)
+		}
+		return nil, nil
+	}
+	if visible, err := subIssue.IsVisibleTo(ctx, ctx.Doer, *perm); err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsVisibleTo", err)
+		return nil, nil
+	} else if !visible {
+		ctx.NotFound()
+		return nil, nil
+	}
+	subIssue.Repo = repo
+	return parent, subIssue
+}
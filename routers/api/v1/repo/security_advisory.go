@@ -0,0 +1,195 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"net/http"
+
+	advisory_model "forgejo.org/models/advisory"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/optional"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListSecurityAdvisories lists the security advisories of a repository
+func ListSecurityAdvisories(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/security-advisories repository repoListSecurityAdvisories
+	// ---
+	// summary: List a repository's security advisories, the drafts are only listed for the administrators
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: state
+	//   in: query
+	//   description: filter by the state of the advisories
+	//   type: string
+	//   enum: [draft, published]
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecurityAdvisoryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	opts := advisory_model.FindAdvisoriesOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+	}
+	switch ctx.FormString("state") {
+	case "draft":
+		if !ctx.Repo.IsAdmin() {
+			ctx.NotFound()
+			return
+		}
+		opts.State = optional.Some(advisory_model.StateDraft)
+	case "published":
+		opts.State = optional.Some(advisory_model.StatePublished)
+	default:
+		if !ctx.Repo.IsAdmin() {
+			opts.State = optional.Some(advisory_model.StatePublished)
+		}
+	}
+
+	advisories, count, err := db.FindAndCount[advisory_model.Advisory](ctx, opts)
+	if err != nil {
+		ctx.Error(http.StatusInternalServerError, "FindAdvisories", err)
+		return
+	}
+
+	apiAdvisories := make([]*api.SecurityAdvisory, 0, len(advisories))
+	for _, advisory := range advisories {
+		advisory.Repo = ctx.Repo.Repository
+		if err := advisory.LoadAuthor(ctx); err != nil {
+			ctx.Error(http.StatusInternalServerError, "LoadAuthor", err)
+			return
+		}
+		apiAdvisories = append(apiAdvisories, convert.ToSecurityAdvisory(ctx, advisory, ctx.Doer))
+	}
+
+	ctx.SetLinkHeader(int(count), listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiAdvisories)
+}
+
+// getSecurityAdvisory returns the security advisory of the path, and writes a not found error
+// if it does not exist or if it is a draft the doer can't see
+func getSecurityAdvisory(ctx *context.APIContext) *advisory_model.Advisory {
+	advisory, err := advisory_model.GetAdvisoryByIdentifier(ctx, ctx.Repo.Repository.ID, ctx.Params(":identifier"))
+	if err != nil {
+		if advisory_model.IsErrAdvisoryNotExist(err) {
+			ctx.NotFound()
+		} else {
+			ctx.Error(http.StatusInternalServerError, "GetAdvisoryByIdentifier", err)
+		}
+		return nil
+	}
+	if advisory.IsDraft() && !ctx.Repo.IsAdmin() {
+		ctx.NotFound()
+		return nil
+	}
+	advisory.Repo = ctx.Repo.Repository
+	return advisory
+}
+
+// GetSecurityAdvisory gets a security advisory of a repository
+func GetSecurityAdvisory(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/security-advisories/{identifier} repository repoGetSecurityAdvisory
+	// ---
+	// summary: Get a security advisory
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: identifier
+	//   in: path
+	//   description: identifier of the advisory
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SecurityAdvisory"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	advisory := getSecurityAdvisory(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := advisory.LoadAuthor(ctx); err != nil {
+		ctx.Error(http.StatusInternalServerError, "LoadAuthor", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToSecurityAdvisory(ctx, advisory, ctx.Doer))
+}
+
+// GetSecurityAdvisoryOSV gets a published security advisory in the OSV format
+func GetSecurityAdvisoryOSV(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/security-advisories/{identifier}/osv repository repoGetSecurityAdvisoryOSV
+	// ---
+	// summary: Get a published security advisory in the Open Source Vulnerability format
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: identifier
+	//   in: path
+	//   description: identifier of the advisory
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/OSVVulnerability"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	advisory := getSecurityAdvisory(ctx)
+	if ctx.Written() {
+		return
+	}
+	// a draft has no OSV record until it is published
+	if advisory.IsDraft() {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToOSVVulnerability(advisory))
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"net/http"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/organization"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	org_service "forgejo.org/services/org"
@@ -218,6 +220,12 @@ func changeRepoTeam(ctx *context.APIContext, add bool) {
 		return
 	}
 
+	action := audit_model.ActionTeamRepoAdd
+	if !add {
+		action = audit_model.ActionTeamRepoRemove
+	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), action, audit_service.TeamRepoTarget(team, ctx.Repo.Repository.ID), nil, nil)
+
 	ctx.Status(http.StatusNoContent)
 }
 
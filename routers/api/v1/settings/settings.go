@@ -5,10 +5,14 @@ package settings
 
 import (
 	"net/http"
+	"strings"
 
 	"forgejo.org/modules/setting"
+	ssh_module "forgejo.org/modules/ssh"
 	api "forgejo.org/modules/structs"
 	"forgejo.org/services/context"
+
+	gossh "golang.org/x/crypto/ssh"
 )
 
 // GetGeneralUISettings returns instance's global settings for ui
@@ -84,3 +88,28 @@ func GetGeneralAttachmentSettings(ctx *context.APIContext) {
 		MaxSize:      setting.Attachment.MaxSize,
 	})
 }
+
+// GetSSHCertificateAuthority returns the public key of the instance's SSH certificate authority
+func GetSSHCertificateAuthority(ctx *context.APIContext) {
+	// swagger:operation GET /settings/ssh_ca settings getSSHCertificateAuthority
+	// ---
+	// summary: Get the SSH certificate authority of the instance
+	// produces:
+	// - application/json
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SSHCertificateAuthority"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	caKey := ssh_module.CAPublicKey()
+	if caKey == nil {
+		ctx.NotFound()
+		return
+	}
+	ctx.JSON(http.StatusOK, api.SSHCertificateAuthority{
+		PublicKey:              strings.TrimSpace(string(gossh.MarshalAuthorizedKey(caKey))),
+		Fingerprint:            gossh.FingerprintSHA256(caKey),
+		DefaultValiditySeconds: int64(setting.SSH.CACertificateValidity.Seconds()),
+		MaxValiditySeconds:     int64(setting.SSH.CAMaxCertificateValidity.Seconds()),
+	})
+}
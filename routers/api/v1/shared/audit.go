@@ -0,0 +1,46 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package shared
+
+import (
+	"net/http"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/routers/api/v1/utils"
+	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
+)
+
+// ListAuditEvents exports the audit log events matching opts, filtered by
+// the since, before and action query parameters
+func ListAuditEvents(ctx *context.APIContext, opts audit_model.FindEventsOptions) {
+	before, since, err := context.GetQueryBeforeSince(ctx.Base)
+	if err != nil {
+		ctx.Error(http.StatusUnprocessableEntity, "GetQueryBeforeSince", err)
+		return
+	}
+
+	opts.ListOptions = utils.GetListOptions(ctx)
+	opts.Action = audit_model.Action(ctx.FormTrim("action"))
+	opts.Since = timeutil.TimeStamp(since)
+	opts.Before = timeutil.TimeStamp(before)
+
+	events, total, err := db.FindAndCount[audit_model.Event](ctx, opts)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+
+	res := make([]*api.AuditEvent, len(events))
+	for i, e := range events {
+		res[i] = convert.ToAuditEvent(e)
+	}
+
+	ctx.SetLinkHeader(int(total), opts.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, res)
+}
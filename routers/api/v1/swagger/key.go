@@ -48,3 +48,10 @@ type swaggerResponseDeployKeyList struct {
 	// in:body
 	Body []api.DeployKey `json:"body"`
 }
+
+// SSHCertificate
+// swagger:response SSHCertificate
+type swaggerResponseSSHCertificate struct {
+	// in:body
+	Body api.SSHCertificate `json:"body"`
+}
@@ -69,6 +69,13 @@ type swaggerParameterBodies struct {
 	// in:body
 	EditLabelOption api.EditLabelOption
 
+	// in:body
+	CreateCustomFieldOption api.CreateCustomFieldOption
+	// in:body
+	EditCustomFieldOption api.EditCustomFieldOption
+	// in:body
+	SetIssueCustomFieldValueOption api.SetIssueCustomFieldValueOption
+
 	// in:body
 	MarkupOption api.MarkupOption
 	// in:body
@@ -239,4 +246,7 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	NoteOptions api.NoteOptions
+
+	// in:body
+	CreateSSHCertificateOption api.CreateSSHCertificateOption
 }
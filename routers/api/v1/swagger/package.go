@@ -27,3 +27,17 @@ type swaggerResponsePackageFileList struct {
 	// in:body
 	Body []api.PackageFile `json:"body"`
 }
+
+// PackageSBOM
+// swagger:response PackageSBOM
+type swaggerResponsePackageSBOM struct {
+	// in:body
+	Body api.PackageSBOM `json:"body"`
+}
+
+// PackageComponentList
+// swagger:response PackageComponentList
+type swaggerResponsePackageComponentList struct {
+	// in:body
+	Body []api.PackageComponent `json:"body"`
+}
@@ -140,6 +140,27 @@ type swaggerResponseReleaseList struct {
 	Body []api.Release `json:"body"`
 }
 
+// SecurityAdvisory
+// swagger:response SecurityAdvisory
+type swaggerResponseSecurityAdvisory struct {
+	// in:body
+	Body api.SecurityAdvisory `json:"body"`
+}
+
+// SecurityAdvisoryList
+// swagger:response SecurityAdvisoryList
+type swaggerResponseSecurityAdvisoryList struct {
+	// in:body
+	Body []api.SecurityAdvisory `json:"body"`
+}
+
+// OSVVulnerability
+// swagger:response OSVVulnerability
+type swaggerResponseOSVVulnerability struct {
+	// in:body
+	Body api.OSVVulnerability `json:"body"`
+}
+
 // PullRequest
 // swagger:response PullRequest
 type swaggerResponsePullRequest struct {
@@ -32,3 +32,10 @@ type swaggerResponseGeneralAttachmentSettings struct {
 	// in:body
 	Body api.GeneralAttachmentSettings `json:"body"`
 }
+
+// SSHCertificateAuthority
+// swagger:response SSHCertificateAuthority
+type swaggerResponseSSHCertificateAuthority struct {
+	// in:body
+	Body api.SSHCertificateAuthority `json:"body"`
+}
@@ -11,11 +11,16 @@ import (
 	"strconv"
 	"strings"
 
+	audit_model "forgejo.org/models/audit"
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/utils"
+	audit_service "forgejo.org/services/audit"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 )
@@ -59,11 +64,10 @@ func ListAccessTokens(ctx *context.APIContext) {
 
 	apiTokens := make([]*api.AccessToken, len(tokens))
 	for i := range tokens {
-		apiTokens[i] = &api.AccessToken{
-			ID:             tokens[i].ID,
-			Name:           tokens[i].Name,
-			TokenLastEight: tokens[i].TokenLastEight,
-			Scopes:         tokens[i].Scope.StringSlice(),
+		apiTokens[i], err = convert.ToAccessToken(ctx, tokens[i])
+		if err != nil {
+			ctx.InternalServerError(err)
+			return
 		}
 	}
 
@@ -99,6 +103,8 @@ func CreateAccessToken(ctx *context.APIContext) {
 	//     "$ref": "#/responses/forbidden"
 	//   "404":
 	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
 
 	form := web.GetForm(ctx).(*api.CreateAccessTokenOption)
 
@@ -128,17 +134,34 @@ func CreateAccessToken(ctx *context.APIContext) {
 	}
 	t.Scope = scope
 
+	if err := auth_service.SetAccessTokenResources(ctx, ctx.Doer, t, form.Repositories, form.Organization); err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.Error(http.StatusUnprocessableEntity, "SetAccessTokenResources", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "SetAccessTokenResources", err)
+		}
+		return
+	}
+	if form.ExpiresAt != nil {
+		t.ExpiresUnix = timeutil.TimeStamp(form.ExpiresAt.Unix())
+	}
+
 	if err := auth_model.NewAccessToken(ctx, t); err != nil {
-		ctx.Error(http.StatusInternalServerError, "NewAccessToken", err)
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Error(http.StatusUnprocessableEntity, "NewAccessToken", err)
+		} else {
+			ctx.Error(http.StatusInternalServerError, "NewAccessToken", err)
+		}
 		return
 	}
-	ctx.JSON(http.StatusCreated, &api.AccessToken{
-		Name:           t.Name,
-		Token:          t.Token,
-		ID:             t.ID,
-		TokenLastEight: t.TokenLastEight,
-		Scopes:         t.Scope.StringSlice(),
-	})
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAccessTokenCreate, audit_service.AccessTokenTarget(t), nil, audit_service.AccessTokenState(t))
+
+	apiToken, err := convert.ToAccessToken(ctx, t)
+	if err != nil {
+		ctx.InternalServerError(err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, apiToken)
 }
 
 // DeleteAccessToken deletes an access token
@@ -206,6 +229,7 @@ func DeleteAccessToken(ctx *context.APIContext) {
 		}
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAccessTokenDelete, audit_service.Target{Type: audit_model.TargetAccessToken, ID: tokenID}, nil, nil)
 
 	ctx.Status(http.StatusNoContent)
 }
@@ -7,19 +7,28 @@ import (
 	std_ctx "context"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/perm"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/setting"
+	ssh_module "forgejo.org/modules/ssh"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/api/v1/repo"
 	"forgejo.org/routers/api/v1/utils"
 	asymkey_service "forgejo.org/services/asymkey"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
+
+	gossh "golang.org/x/crypto/ssh"
 )
 
 // appendPrivateInformation appends the owner and key type information to api.PublicKey
@@ -316,3 +325,60 @@ func DeletePublicKey(ctx *context.APIContext) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+// CreateSSHCertificate issues a short-lived SSH certificate for a public key
+func CreateSSHCertificate(ctx *context.APIContext) {
+	// swagger:operation POST /user/ssh_certificates user userCurrentPostSSHCertificate
+	// ---
+	// summary: Issue a short-lived SSH certificate for a public key
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateSSHCertificateOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SSHCertificate"
+	//   "401":
+	//     "$ref": "#/responses/unauthorized"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateSSHCertificateOption)
+	cert, err := asymkey_service.IssueSSHCertificate(ctx, ctx.Doer, form.Key, time.Duration(form.ValiditySeconds)*time.Second)
+	if err != nil {
+		switch {
+		case errors.Is(err, ssh_module.ErrCADisabled):
+			ctx.NotFound()
+		case err == asymkey_model.ErrKeyIsPrivate:
+			ctx.Error(http.StatusUnprocessableEntity, "", "A public key is required")
+		case asymkey_model.IsErrKeyAlreadyExist(err):
+			ctx.Error(http.StatusUnprocessableEntity, "", "The user name is used as principal by another user")
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Error(http.StatusUnprocessableEntity, "", err)
+		default:
+			ctx.Error(http.StatusInternalServerError, "IssueSSHCertificate", err)
+		}
+		return
+	}
+
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionSSHCertificateIssue,
+		audit_service.UserTarget(ctx.Doer), nil, audit_service.SSHCertificateState(cert))
+
+	ctx.JSON(http.StatusCreated, &api.SSHCertificate{
+		Certificate: strings.TrimSpace(string(gossh.MarshalAuthorizedKey(cert))),
+		KeyID:       cert.KeyId,
+		Serial:      strconv.FormatUint(cert.Serial, 10),
+		Principals:  cert.ValidPrincipals,
+		ValidAfter:  time.Unix(int64(cert.ValidAfter), 0),
+		ValidBefore: time.Unix(int64(cert.ValidBefore), 0),
+	})
+}
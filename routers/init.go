@@ -29,13 +29,16 @@ import (
 	actions_router "forgejo.org/routers/api/actions"
 	forgejo "forgejo.org/routers/api/forgejo/v1"
 	packages_router "forgejo.org/routers/api/packages"
+	scim_router "forgejo.org/routers/api/scim"
 	apiv1 "forgejo.org/routers/api/v1"
 	"forgejo.org/routers/common"
 	"forgejo.org/routers/private"
 	web_routers "forgejo.org/routers/web"
 	actions_service "forgejo.org/services/actions"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/auth"
 	"forgejo.org/services/auth/source/oauth2"
+	automation_service "forgejo.org/services/automation"
 	"forgejo.org/services/automerge"
 	"forgejo.org/services/cron"
 	federation_service "forgejo.org/services/federation"
@@ -141,6 +144,7 @@ func InitWebInstalled(ctx context.Context) {
 	mustInitCtx(ctx, common.InitDBEngine)
 	log.Info("ORM engine initialization successful!")
 	mustInit(system.Init)
+	mustInit(audit_service.Init)
 	mustInitCtx(ctx, oauth2.Init)
 
 	mustInit(release_service.Init)
@@ -156,6 +160,7 @@ func InitWebInstalled(ctx context.Context) {
 	mustInit(webhook.Init)
 	mustInit(pull_service.Init)
 	mustInit(automerge.Init)
+	mustInit(automation_service.Init)
 	mustInit(task.Init)
 	mustInit(migrations_service.Init)
 	eventsource.GetManager().Init()
@@ -184,6 +189,7 @@ func NormalRoutes() *web.Route {
 	r.Mount("/", web_routers.Routes())
 	r.Mount("/api/v1", apiv1.Routes())
 	r.Mount("/api/forgejo/v1", forgejo.Routes())
+	r.Mount("/api/scim/v2", scim_router.Routes())
 	r.Mount("/api/internal", private.Routes())
 
 	r.Post("/-/fetch-redirect", common.FetchRedirectDelegate)
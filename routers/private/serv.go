@@ -10,6 +10,7 @@ import (
 
 	asymkey_model "forgejo.org/models/asymkey"
 	"forgejo.org/models/auth"
+	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
@@ -301,6 +302,24 @@ func ServCommand(ctx *context.PrivateContext) {
 			return
 		}
 
+		if owner.IsOrganization() {
+			org := organization.OrgFromUser(owner)
+			violations, err := context.CheckOrgSecurityPolicy(ctx, org, user, ctx.FormString("remote_addr"))
+			if err != nil {
+				sshLogger.Error("Unable to check the security policy of %s for %s Error: %v", org.Name, user.Name, err)
+				ctx.JSON(http.StatusInternalServerError, private.Response{
+					Err: fmt.Sprintf("Unable to check the security policy of %s for %s Error: %v", org.Name, user.Name, err),
+				})
+				return
+			}
+			if violations != nil {
+				ctx.JSON(http.StatusForbidden, private.Response{
+					UserMsg: context.OrgSecurityPolicyMessage(org, violations),
+				})
+				return
+			}
+		}
+
 		results.UserName = user.Name
 		if !user.KeepEmailPrivate {
 			results.UserEmail = user.Email
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	activities_model "forgejo.org/models/activities"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/cache"
@@ -20,6 +21,7 @@ import (
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/updatechecker"
 	"forgejo.org/modules/web"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/cron"
 	"forgejo.org/services/forms"
@@ -176,6 +178,7 @@ func DashboardPost(ctx *context.Context) {
 
 	// Run operation.
 	if form.Op != "" {
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminCronRun, audit_service.SystemTarget(form.Op), nil, nil)
 		switch form.Op {
 		case "sync_repo_branches":
 			go func() {
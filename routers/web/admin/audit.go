@@ -0,0 +1,28 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package admin
+
+import (
+	"net/http"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/modules/base"
+	shared_audit "forgejo.org/routers/web/shared/audit"
+	"forgejo.org/services/context"
+)
+
+const tplAudit base.TplName = "admin/audit"
+
+// AuditEvents shows the instance-wide audit log
+func AuditEvents(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("audit.title")
+	ctx.Data["PageIsAdminAudit"] = true
+
+	shared_audit.SetAuditEventsContext(ctx, audit_model.FindEventsOptions{})
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplAudit)
+}
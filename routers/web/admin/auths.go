@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	"forgejo.org/modules/auth/pam"
@@ -17,10 +18,12 @@ import (
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/web"
+	audit_service "forgejo.org/services/audit"
 	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/auth/source/ldap"
 	"forgejo.org/services/auth/source/oauth2"
 	pam_service "forgejo.org/services/auth/source/pam"
+	"forgejo.org/services/auth/source/saml"
 	"forgejo.org/services/auth/source/smtp"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
@@ -61,6 +64,7 @@ var (
 			{auth.DLDAP.String(), auth.DLDAP},
 			{auth.SMTP.String(), auth.SMTP},
 			{auth.OAuth2.String(), auth.OAuth2},
+			{auth.SAML.String(), auth.SAML},
 		}
 		if pam.Supported {
 			items = append(items, dropdownItem{auth.Names[auth.PAM], auth.PAM})
@@ -197,6 +201,31 @@ func parseOAuth2Config(form forms.AuthenticationForm) *oauth2.Source {
 	}
 }
 
+func parseSAMLConfig(form forms.AuthenticationForm) (*saml.Source, error) {
+	source := &saml.Source{
+		IdentityProviderMetadata:    form.SamlIdpMetadata,
+		IdentityProviderMetadataURL: form.SamlIdpMetadataURL,
+		ServiceProviderCertificate:  form.SamlSpCertificate,
+		ServiceProviderPrivateKey:   form.SamlSpPrivateKey,
+		SignRequests:                form.SamlSignRequests,
+		RequireEncryptedAssertions:  form.SamlRequireEncryption,
+		NameIDFormat:                form.SamlNameIDFormat,
+		IconURL:                     form.SamlIconURL,
+		AttributeUsername:           form.SamlAttributeUsername,
+		AttributeEmail:              form.SamlAttributeEmail,
+		AttributeFullName:           form.SamlAttributeFullName,
+		AttributeGroups:             form.SamlAttributeGroups,
+		AdminGroup:                  form.SamlAdminGroup,
+		RestrictedGroup:             form.SamlRestrictedGroup,
+		GroupTeamMap:                form.SamlGroupTeamMap,
+		GroupTeamMapRemoval:         form.SamlGroupTeamMapRemoval,
+		AttributeQuotaGroups:        form.SamlAttributeQuotaGroups,
+		QuotaGroupMap:               form.SamlQuotaGroupMap,
+		QuotaGroupMapRemoval:        form.SamlQuotaGroupMapRemoval,
+	}
+	return source, source.EnsureServiceProviderKeyPair(form.Name)
+}
+
 // NewAuthSourcePost response for adding an auth source
 func NewAuthSourcePost(ctx *context.Context) {
 	form := *web.GetForm(ctx).(*forms.AuthenticationForm)
@@ -237,6 +266,12 @@ func NewAuthSourcePost(ctx *context.Context) {
 				return
 			}
 		}
+	case auth.SAML:
+		var err error
+		if config, err = parseSAMLConfig(form); err != nil {
+			ctx.ServerError("parseSAMLConfig", err)
+			return
+		}
 	default:
 		ctx.Error(http.StatusBadRequest)
 		return
@@ -248,13 +283,14 @@ func NewAuthSourcePost(ctx *context.Context) {
 		return
 	}
 
-	if err := auth.CreateSource(ctx, &auth.Source{
+	source := &auth.Source{
 		Type:          auth.Type(form.Type),
 		Name:          form.Name,
 		IsActive:      form.IsActive,
 		IsSyncEnabled: form.IsSyncEnabled,
 		Cfg:           config,
-	}); err != nil {
+	}
+	if err := auth.CreateSource(ctx, source); err != nil {
 		if auth.IsErrSourceAlreadyExist(err) {
 			ctx.Data["Err_Name"] = true
 			ctx.RenderWithErr(ctx.Tr("admin.auths.login_source_exist", err.(auth.ErrSourceAlreadyExist).Name), tplAuthNew, form)
@@ -262,6 +298,8 @@ func NewAuthSourcePost(ctx *context.Context) {
 			ctx.Data["Err_DiscoveryURL"] = true
 			unwrapped := err.(oauth2.ErrOpenIDConnectInitialize).Unwrap()
 			ctx.RenderWithErr(ctx.Tr("admin.auths.unable_to_initialize_openid", unwrapped), tplAuthNew, form)
+		} else if saml.IsErrInvalidConfig(err) {
+			ctx.RenderWithErr(ctx.Tr("admin.auths.invalid_saml_config", err.(saml.ErrInvalidConfig).Unwrap()), tplAuthNew, form)
 		} else {
 			ctx.ServerError("auth.CreateSource", err)
 		}
@@ -269,6 +307,7 @@ func NewAuthSourcePost(ctx *context.Context) {
 	}
 
 	log.Trace("Authentication created by admin(%s): %s", ctx.Doer.Name, form.Name)
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminAuthSourceCreate, audit_service.AuthSourceTarget(source), nil, audit_service.AuthSourceState(source))
 
 	ctx.Flash.Success(ctx.Tr("admin.auths.new_success", form.Name))
 	ctx.Redirect(setting.AppSubURL + "/admin/auths")
@@ -292,6 +331,13 @@ func EditAuthSource(ctx *context.Context) {
 	ctx.Data["Source"] = source
 	ctx.Data["HasTLS"] = source.HasTLS()
 
+	ctx.Data["HasSCIMToken"], err = auth.HasSCIMToken(ctx, source.ID)
+	if err != nil {
+		ctx.ServerError("auth.HasSCIMToken", err)
+		return
+	}
+	ctx.Data["SCIMEndpoint"] = setting.AppURL + "api/scim/v2"
+
 	if source.IsOAuth2() {
 		type Named interface {
 			Name() string
@@ -353,11 +399,17 @@ func EditAuthSourcePost(ctx *context.Context) {
 				return
 			}
 		}
+	case auth.SAML:
+		if config, err = parseSAMLConfig(form); err != nil {
+			ctx.ServerError("parseSAMLConfig", err)
+			return
+		}
 	default:
 		ctx.Error(http.StatusBadRequest)
 		return
 	}
 
+	before := audit_service.AuthSourceState(source)
 	source.Name = form.Name
 	source.IsActive = form.IsActive
 	source.IsSyncEnabled = form.IsSyncEnabled
@@ -370,12 +422,15 @@ func EditAuthSourcePost(ctx *context.Context) {
 			ctx.Flash.Error(err.Error(), true)
 			ctx.Data["Err_DiscoveryURL"] = true
 			ctx.HTML(http.StatusOK, tplAuthEdit)
+		} else if saml.IsErrInvalidConfig(err) {
+			ctx.RenderWithErr(ctx.Tr("admin.auths.invalid_saml_config", err.(saml.ErrInvalidConfig).Unwrap()), tplAuthEdit, form)
 		} else {
 			ctx.ServerError("UpdateSource", err)
 		}
 		return
 	}
 	log.Trace("Authentication changed by admin(%s): %d", ctx.Doer.Name, source.ID)
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminAuthSourceUpdate, audit_service.AuthSourceTarget(source), before, audit_service.AuthSourceState(source))
 
 	ctx.Flash.Success(ctx.Tr("admin.auths.update_success"))
 	ctx.Redirect(setting.AppSubURL + "/admin/auths/" + strconv.FormatInt(form.ID, 10))
@@ -399,7 +454,48 @@ func DeleteAuthSource(ctx *context.Context) {
 		return
 	}
 	log.Trace("Authentication deleted by admin(%s): %d", ctx.Doer.Name, source.ID)
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminAuthSourceDelete, audit_service.AuthSourceTarget(source), audit_service.AuthSourceState(source), nil)
 
 	ctx.Flash.Success(ctx.Tr("admin.auths.deletion_success"))
 	ctx.JSONRedirect(setting.AppSubURL + "/admin/auths")
 }
+
+// GenerateSCIMToken generates a new SCIM token for an auth source, replacing the previous one
+func GenerateSCIMToken(ctx *context.Context) {
+	source, err := auth.GetSourceByID(ctx, ctx.ParamsInt64(":authid"))
+	if err != nil {
+		ctx.ServerError("auth.GetSourceByID", err)
+		return
+	}
+
+	token, err := auth.GenerateSCIMToken(ctx, source.ID)
+	if err != nil {
+		ctx.ServerError("auth.GenerateSCIMToken", err)
+		return
+	}
+	log.Trace("SCIM token generated by admin(%s): %d", ctx.Doer.Name, source.ID)
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminAuthSourceUpdate, audit_service.AuthSourceTarget(source), nil, map[string]string{"scim_token": "generated"})
+
+	ctx.Flash.Success(ctx.Tr("admin.auths.scim_token_generated"))
+	ctx.Flash.Info(token)
+	ctx.Redirect(setting.AppSubURL + "/admin/auths/" + strconv.FormatInt(source.ID, 10))
+}
+
+// DeleteSCIMToken revokes the SCIM token of an auth source
+func DeleteSCIMToken(ctx *context.Context) {
+	source, err := auth.GetSourceByID(ctx, ctx.ParamsInt64(":authid"))
+	if err != nil {
+		ctx.ServerError("auth.GetSourceByID", err)
+		return
+	}
+
+	if err := auth.DeleteSCIMToken(ctx, source.ID); err != nil {
+		ctx.ServerError("auth.DeleteSCIMToken", err)
+		return
+	}
+	log.Trace("SCIM token revoked by admin(%s): %d", ctx.Doer.Name, source.ID)
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminAuthSourceUpdate, audit_service.AuthSourceTarget(source), nil, map[string]string{"scim_token": "revoked"})
+
+	ctx.Flash.Success(ctx.Tr("admin.auths.scim_token_revoked"))
+	ctx.Redirect(setting.AppSubURL + "/admin/auths/" + strconv.FormatInt(source.ID, 10))
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	org_model "forgejo.org/models/organization"
@@ -26,6 +27,8 @@ import (
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/web/explore"
 	user_setting "forgejo.org/routers/web/user/setting"
+	audit_service "forgejo.org/services/audit"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	"forgejo.org/services/mailer"
@@ -220,6 +223,7 @@ func NewUserPost(ctx *context.Context) {
 	}
 
 	log.Trace("Account created by admin (%s): %s", ctx.Doer.Name, u.Name)
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminUserCreate, audit_service.UserTarget(u), nil, audit_service.UserState(u))
 
 	// Send email notification.
 	if form.SendNotify {
@@ -356,6 +360,7 @@ func EditUserPost(ctx *context.Context) {
 		ctx.HTML(http.StatusOK, tplUserEdit)
 		return
 	}
+	before := audit_service.UserState(u)
 
 	if form.UserName != "" {
 		if err := user_service.AdminRenameUser(ctx, u, form.UserName); err != nil {
@@ -451,6 +456,7 @@ func EditUserPost(ctx *context.Context) {
 		AllowImportLocal:        optional.Some(form.AllowImportLocal),
 		MaxRepoCreation:         optional.Some(form.MaxRepoCreation),
 		AllowCreateOrganization: optional.Some(form.AllowCreateOrganization),
+		IsRateLimitExempt:       optional.Some(form.RateLimitExempt),
 		IsRestricted:            optional.Some(form.Restricted),
 		Visibility:              optional.Some(form.Visibility),
 		Language:                optional.Some(form.Language),
@@ -479,7 +485,7 @@ func EditUserPost(ctx *context.Context) {
 			}
 		}
 
-		wn, err := auth.GetWebAuthnCredentialsByUID(ctx, u.ID)
+		wn, err := auth.GetSecurityKeysByUID(ctx, u.ID)
 		if err != nil {
 			ctx.ServerError("auth.GetTwoFactorByUID", err)
 			return
@@ -490,7 +496,9 @@ func EditUserPost(ctx *context.Context) {
 				return
 			}
 		}
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTwoFactorDisable, audit_service.UserTarget(u), nil, nil)
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminUserUpdate, audit_service.UserTarget(u), before, audit_service.UserState(u))
 
 	ctx.Flash.Success(ctx.Tr("admin.users.update_profile_success"))
 	ctx.Redirect(setting.AppSubURL + "/admin/users/" + url.PathEscape(ctx.Params(":userid")))
@@ -531,6 +539,7 @@ func DeleteUser(ctx *context.Context) {
 		return
 	}
 	log.Trace("Account deleted by admin (%s): %s", ctx.Doer.Name, u.Name)
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminUserDelete, audit_service.UserTarget(u), audit_service.UserState(u), nil)
 
 	ctx.Flash.Success(ctx.Tr("admin.users.deletion_success"))
 	ctx.Redirect(setting.AppSubURL + "/admin/users")
@@ -566,3 +575,20 @@ func DeleteAvatar(ctx *context.Context) {
 
 	ctx.JSONRedirect(setting.AppSubURL + "/admin/users/" + strconv.FormatInt(u.ID, 10))
 }
+
+// RevokeUserSessions signs out a user everywhere
+func RevokeUserSessions(ctx *context.Context) {
+	u := prepareUserInfo(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := auth_service.RevokeAllSessions(ctx, u.ID); err != nil {
+		ctx.ServerError("RevokeAllSessions", err)
+		return
+	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAdminUserSignOut, audit_service.UserTarget(u), nil, nil)
+
+	ctx.Flash.Success(ctx.Tr("admin.users.sign_out_everywhere_success", u.Name))
+	ctx.JSONRedirect(setting.AppSubURL + "/admin/users/" + strconv.FormatInt(u.ID, 10))
+}
@@ -95,10 +95,11 @@ func TwoFactorPost(ctx *context.Context) {
 			return
 		}
 
-		handleSignIn(ctx, u, remember)
+		handleSignIn(ctx, u, remember, auth.LoginMethodTwoFactor)
 		return
 	}
 
+	recordFailedSignIn(ctx, id, "", "totp")
 	ctx.RenderWithErr(ctx.Tr("auth.twofa_passcode_incorrect"), tplTwofa, forms.TwoFactorAuthForm{})
 }
 
@@ -162,7 +163,7 @@ func TwoFactorScratchPost(ctx *context.Context) {
 			}
 		}
 
-		handleSignInFull(ctx, u, remember, false)
+		handleSignInFull(ctx, u, remember, false, auth.LoginMethodTwoFactor)
 		if ctx.Written() {
 			return
 		}
@@ -171,5 +172,6 @@ func TwoFactorScratchPost(ctx *context.Context) {
 		return
 	}
 
+	recordFailedSignIn(ctx, id, "", "scratch_token")
 	ctx.RenderWithErr(ctx.Tr("auth.twofa_scratch_token_incorrect"), tplTwofaScratch, forms.TwoFactorScratchAuthForm{})
 }
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	user_model "forgejo.org/models/user"
@@ -27,6 +28,7 @@ import (
 	"forgejo.org/modules/validation"
 	"forgejo.org/modules/web"
 	"forgejo.org/modules/web/middleware"
+	audit_service "forgejo.org/services/audit"
 	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/auth/source/oauth2"
 	"forgejo.org/services/context"
@@ -72,9 +74,12 @@ func autoSignIn(ctx *context.Context) (bool, error) {
 
 	isSucceed = true
 
+	lookupKey, _, _ := strings.Cut(authCookie, ":")
 	if err := updateSession(ctx, nil, map[string]any{
 		// Set session IDs
-		"uid": u.ID,
+		"uid":                              u.ID,
+		auth_service.LoginMethodSessionKey: auth.LoginMethodRememberMe,
+		auth_service.AuthTokenSessionKey:   lookupKey,
 	}); err != nil {
 		return false, fmt.Errorf("unable to updateSession: %w", err)
 	}
@@ -159,6 +164,10 @@ func SignIn(ctx *context.Context) {
 		return
 	}
 	ctx.Data["OAuth2Providers"] = oauth2Providers
+	if ctx.Data["SAMLProviders"], err = getSAMLProviders(ctx); err != nil {
+		ctx.ServerError("getSAMLProviders", err)
+		return
+	}
 	ctx.Data["Title"] = ctx.Tr("sign_in")
 	ctx.Data["SignInLink"] = setting.AppSubURL + "/user/login"
 	ctx.Data["PageIsSignIn"] = true
@@ -184,6 +193,10 @@ func SignInPost(ctx *context.Context) {
 		return
 	}
 	ctx.Data["OAuth2Providers"] = oauth2Providers
+	if ctx.Data["SAMLProviders"], err = getSAMLProviders(ctx); err != nil {
+		ctx.ServerError("getSAMLProviders", err)
+		return
+	}
 	ctx.Data["Title"] = ctx.Tr("sign_in")
 	ctx.Data["SignInLink"] = setting.AppSubURL + "/user/login"
 	ctx.Data["PageIsSignIn"] = true
@@ -218,11 +231,14 @@ func SignInPost(ctx *context.Context) {
 		if errors.Is(err, util.ErrNotExist) || errors.Is(err, util.ErrInvalidArgument) {
 			ctx.RenderWithErr(ctx.Tr("form.username_password_incorrect"), tplSignIn, &form)
 			log.Warn("Failed authentication attempt for %s from %s: %v", form.UserName, ctx.RemoteAddr(), err)
+			recordFailedSignIn(ctx, 0, form.UserName, "password")
 		} else if user_model.IsErrEmailAlreadyUsed(err) {
 			ctx.RenderWithErr(ctx.Tr("form.email_been_used"), tplSignIn, &form)
 			log.Warn("Failed authentication attempt for %s from %s: %v", form.UserName, ctx.RemoteAddr(), err)
+			recordFailedSignIn(ctx, 0, form.UserName, "email_already_used")
 		} else if user_model.IsErrUserProhibitLogin(err) {
 			log.Warn("Failed authentication attempt for %s from %s: %v", form.UserName, ctx.RemoteAddr(), err)
+			recordFailedSignIn(ctx, 0, form.UserName, "prohibit_login")
 			ctx.Data["Title"] = ctx.Tr("auth.prohibit_login")
 			ctx.HTML(http.StatusOK, "user/auth/prohibit_login")
 		} else {
@@ -235,7 +251,7 @@ func SignInPost(ctx *context.Context) {
 
 	// First of all if the source can skip local two fa we're done
 	if skipper, ok := source.Cfg.(auth_service.LocalTwoFASkipper); ok && skipper.IsSkipLocalTwoFA() {
-		handleSignIn(ctx, u, form.Remember)
+		handleSignIn(ctx, u, form.Remember, auth.LoginMethodPassword)
 		return
 	}
 
@@ -256,7 +272,7 @@ func SignInPost(ctx *context.Context) {
 
 	if !hasTOTPtwofa && !hasWebAuthnTwofa {
 		// No two factor auth configured we can sign in the user
-		handleSignIn(ctx, u, form.Remember)
+		handleSignIn(ctx, u, form.Remember, auth.LoginMethodPassword)
 		return
 	}
 
@@ -285,20 +301,27 @@ func SignInPost(ctx *context.Context) {
 }
 
 // This handles the final part of the sign-in process of the user.
-func handleSignIn(ctx *context.Context, u *user_model.User, remember bool) {
-	redirect := handleSignInFull(ctx, u, remember, true)
+func handleSignIn(ctx *context.Context, u *user_model.User, remember bool, loginMethod string) {
+	redirect := handleSignInFull(ctx, u, remember, true, loginMethod)
 	if ctx.Written() {
 		return
 	}
 	ctx.Redirect(redirect)
 }
 
-func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRedirect bool) string {
+// handleSignInFull signs in the user, loginMethod is recorded in the session registry
+func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRedirect bool, loginMethod string) string {
+	updates := map[string]any{
+		"uid":                              u.ID,
+		auth_service.LoginMethodSessionKey: loginMethod,
+	}
 	if remember {
-		if err := ctx.SetLTACookie(u); err != nil {
+		lookupKey, err := ctx.SetLTACookie(u)
+		if err != nil {
 			ctx.ServerError("GenerateAuthToken", err)
 			return setting.AppSubURL + "/"
 		}
+		updates[auth_service.AuthTokenSessionKey] = lookupKey
 	}
 
 	if err := updateSession(ctx, []string{
@@ -311,9 +334,9 @@ func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRe
 		"twofaRemember",
 		"twofaOpenID",
 		"linkAccount",
-	}, map[string]any{
-		"uid": u.ID,
-	}); err != nil {
+		context.PhishingResistantAuthSessionKey,
+		auth_service.AuthTokenSessionKey,
+	}, updates); err != nil {
 		ctx.ServerError("RegenerateSession", err)
 		return setting.AppSubURL + "/"
 	}
@@ -341,6 +364,7 @@ func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRe
 		ctx.ServerError("UpdateUser", err)
 		return setting.AppSubURL + "/"
 	}
+	audit_service.Record(ctx, audit_service.NewActor(u, ctx.RemoteAddr()), audit_model.ActionUserLogin, audit_service.UserTarget(u), nil, map[string]any{"method": loginMethod})
 
 	redirectTo := ctx.GetSiteCookie("redirect_to")
 	if redirectTo != "" {
@@ -355,6 +379,13 @@ func handleSignInFull(ctx *context.Context, u *user_model.User, remember, obeyRe
 	return setting.AppSubURL + "/"
 }
 
+// recordFailedSignIn records a failed sign-in attempt for the user with the given ID or name in the audit log
+func recordFailedSignIn(ctx *context.Context, uid int64, name, reason string) {
+	audit_service.Record(ctx, audit_service.NewActor(nil, ctx.RemoteAddr()), audit_model.ActionUserLoginFailed,
+		audit_service.Target{Type: audit_model.TargetUser, ID: uid, Name: name},
+		nil, map[string]string{"reason": reason})
+}
+
 func getUserName(gothUser *goth.User) (string, error) {
 	switch setting.OAuth2Client.Username {
 	case setting.OAuth2UsernameEmail:
@@ -368,6 +399,11 @@ func getUserName(gothUser *goth.User) (string, error) {
 
 // HandleSignOut resets the session and sets the cookies
 func HandleSignOut(ctx *context.Context) {
+	if ctx.Doer != nil {
+		if err := auth_service.RevokeSessionByID(ctx, ctx.Doer.ID, ctx.Session.ID()); err != nil {
+			log.Error("RevokeSessionByID: %v", err)
+		}
+	}
 	_ = ctx.Session.Flush()
 	_ = ctx.Session.Destroy(ctx.Resp, ctx.Req)
 	ctx.DeleteSiteCookie(setting.CookieRememberName)
@@ -399,6 +435,10 @@ func SignUp(ctx *context.Context) {
 	}
 
 	ctx.Data["OAuth2Providers"] = oauth2Providers
+	if ctx.Data["SAMLProviders"], err = getSAMLProviders(ctx); err != nil {
+		ctx.ServerError("getSAMLProviders", err)
+		return
+	}
 	context.SetCaptchaData(ctx)
 
 	ctx.Data["PageIsSignUp"] = true
@@ -428,6 +468,10 @@ func SignUpPost(ctx *context.Context) {
 	}
 
 	ctx.Data["OAuth2Providers"] = oauth2Providers
+	if ctx.Data["SAMLProviders"], err = getSAMLProviders(ctx); err != nil {
+		ctx.ServerError("getSAMLProviders", err)
+		return
+	}
 	context.SetCaptchaData(ctx)
 
 	ctx.Data["PageIsSignUp"] = true
@@ -494,7 +538,7 @@ func SignUpPost(ctx *context.Context) {
 	}
 
 	ctx.Flash.Success(ctx.Tr("auth.sign_up_successful"))
-	handleSignIn(ctx, u, false)
+	handleSignIn(ctx, u, false, auth.LoginMethodSignUp)
 }
 
 // createAndHandleCreatedUser calls createUserInContext and
@@ -799,7 +843,8 @@ func handleAccountActivation(ctx *context.Context, user *user_model.User) {
 	log.Trace("User activated: %s", user.Name)
 
 	if err := updateSession(ctx, nil, map[string]any{
-		"uid": user.ID,
+		"uid":                              user.ID,
+		auth_service.LoginMethodSessionKey: auth.LoginMethodSignUp,
 	}); err != nil {
 		log.Error("Unable to regenerate session for user: %-v with email: %s: %v", user, user.Email, err)
 		ctx.ServerError("ActivateUserEmail", err)
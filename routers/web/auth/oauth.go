@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	org_model "forgejo.org/models/organization"
 	user_model "forgejo.org/models/user"
@@ -32,6 +33,7 @@ import (
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	"forgejo.org/modules/web/middleware"
+	audit_service "forgejo.org/services/audit"
 	auth_service "forgejo.org/services/auth"
 	source_service "forgejo.org/services/auth/source"
 	"forgejo.org/services/auth/source/oauth2"
@@ -109,6 +111,14 @@ const (
 	AccessTokenErrorCodeUnsupportedGrantType = "unsupported_grant_type"
 	// AccessTokenErrorCodeInvalidScope represents an error code specified in RFC 6749
 	AccessTokenErrorCodeInvalidScope = "invalid_scope"
+	// AccessTokenErrorCodeAuthorizationPending represents an error code specified in RFC 8628
+	AccessTokenErrorCodeAuthorizationPending = "authorization_pending"
+	// AccessTokenErrorCodeSlowDown represents an error code specified in RFC 8628
+	AccessTokenErrorCodeSlowDown = "slow_down"
+	// AccessTokenErrorCodeAccessDenied represents an error code specified in RFC 8628
+	AccessTokenErrorCodeAccessDenied = "access_denied"
+	// AccessTokenErrorCodeExpiredToken represents an error code specified in RFC 8628
+	AccessTokenErrorCodeExpiredToken = "expired_token"
 )
 
 // AccessTokenError represents an error response specified in RFC 6749
@@ -394,9 +404,11 @@ func IntrospectOAuth(ctx *context.Context) {
 	}
 
 	var response struct {
-		Active   bool   `json:"active"`
-		Scope    string `json:"scope,omitempty"`
-		Username string `json:"username,omitempty"`
+		Active    bool   `json:"active"`
+		Scope     string `json:"scope,omitempty"`
+		ClientID  string `json:"client_id,omitempty"`
+		Username  string `json:"username,omitempty"`
+		TokenType string `json:"token_type,omitempty"`
 		jwt.RegisteredClaims
 	}
 
@@ -409,9 +421,14 @@ func IntrospectOAuth(ctx *context.Context) {
 			if err == nil && app != nil {
 				response.Active = true
 				response.Scope = grant.Scope
+				response.ClientID = app.ClientID
 				response.Issuer = strings.TrimSuffix(setting.AppURL, "/")
 				response.Audience = []string{app.ClientID}
 				response.Subject = fmt.Sprint(grant.UserID)
+				response.ExpiresAt = token.ExpiresAt
+				if token.Type == oauth2.TypeAccessToken {
+					response.TokenType = string(TokenTypeBearer)
+				}
 			}
 			if user, err := user_model.GetUserByID(ctx, grant.UserID); err == nil {
 				response.Username = user.Name
@@ -422,6 +439,68 @@ func IntrospectOAuth(ctx *context.Context) {
 	ctx.JSON(http.StatusOK, response)
 }
 
+// authenticateOAuthClient returns the application of the client identified by
+// the form or the basic authentication header. Confidential clients must
+// authenticate with their secret.
+func authenticateOAuthClient(ctx *context.Context, clientID, clientSecret string) (*auth.OAuth2Application, *AccessTokenError) {
+	if clientID == "" {
+		if id, secret, err := parseBasicAuth(ctx); err == nil {
+			clientID, clientSecret = id, secret
+		}
+	}
+
+	app, err := auth.GetOAuth2ApplicationByClientID(ctx, clientID)
+	if err != nil {
+		if !auth.IsErrOauthClientIDInvalid(err) {
+			log.Error("Error retrieving client_id: %v", err)
+		}
+		return nil, &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidClient,
+			ErrorDescription: fmt.Sprintf("cannot load client with client id: %q", clientID),
+		}
+	}
+	if app.ConfidentialClient && !app.ValidateClientSecret([]byte(clientSecret)) {
+		return nil, &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidClient,
+			ErrorDescription: "invalid client secret",
+		}
+	}
+	return app, nil
+}
+
+// RevokeOAuth revokes an access or refresh token together with all tokens of
+// its grant, see RFC 7009
+func RevokeOAuth(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.RevokeTokenForm)
+	app, acErr := authenticateOAuthClient(ctx, form.ClientID, form.ClientSecret)
+	if acErr != nil {
+		ctx.Resp.Header().Set("WWW-Authenticate", `Basic realm=""`)
+		ctx.JSON(http.StatusUnauthorized, acErr)
+		return
+	}
+
+	// "invalid tokens do not cause an error response"
+	// https://datatracker.ietf.org/doc/html/rfc7009#section-2.2
+	token, err := oauth2.ParseToken(form.Token, oauth2.DefaultSigningKey)
+	if err != nil {
+		ctx.Status(http.StatusOK)
+		return
+	}
+	grant, err := auth.GetOAuth2GrantByID(ctx, token.GrantID)
+	if err != nil {
+		ctx.ServerError("GetOAuth2GrantByID", err)
+		return
+	}
+	// clients can only revoke the tokens issued to them
+	if grant != nil && grant.ApplicationID == app.ID {
+		if err := auth.RevokeOAuth2Grant(ctx, grant.ID, grant.UserID); err != nil {
+			ctx.ServerError("RevokeOAuth2Grant", err)
+			return
+		}
+	}
+	ctx.Status(http.StatusOK)
+}
+
 // AuthorizeOAuth manages authorize requests
 func AuthorizeOAuth(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.AuthorizationForm)
@@ -756,10 +835,12 @@ func AccessTokenOAuth(ctx *context.Context) {
 		handleRefreshToken(ctx, form, serverKey, clientKey)
 	case "authorization_code":
 		handleAuthorizationCode(ctx, form, serverKey, clientKey)
+	case grantTypeDeviceCode:
+		handleDeviceCode(ctx, form, serverKey, clientKey)
 	default:
 		handleAccessTokenError(ctx, AccessTokenError{
 			ErrorCode:        AccessTokenErrorCodeUnsupportedGrantType,
-			ErrorDescription: "Only refresh_token or authorization_code grant type is supported",
+			ErrorDescription: "Only refresh_token, authorization_code or device_code grant type is supported",
 		})
 	}
 }
@@ -1306,7 +1387,8 @@ func handleOAuth2SignIn(ctx *context.Context, source *auth.Source, u *user_model
 	// we can't sign the user in just yet. Instead, redirect them to the 2FA authentication page.
 	if !needs2FA {
 		if err := updateSession(ctx, nil, map[string]any{
-			"uid": u.ID,
+			"uid":                              u.ID,
+			auth_service.LoginMethodSessionKey: auth.LoginMethodOAuth2,
 		}); err != nil {
 			ctx.ServerError("updateSession", err)
 			return
@@ -1320,6 +1402,7 @@ func handleOAuth2SignIn(ctx *context.Context, source *auth.Source, u *user_model
 			ctx.ServerError("UpdateUser", err)
 			return
 		}
+		audit_service.Record(ctx, audit_service.NewActor(u, ctx.RemoteAddr()), audit_model.ActionUserLogin, audit_service.UserTarget(u), nil, map[string]string{"method": auth.LoginMethodOAuth2, "source": source.Name})
 
 		if oauth2Source.GroupTeamMap != "" || oauth2Source.GroupTeamMapRemoval {
 			if err := source_service.SyncGroupsToTeams(ctx, u, groups, groupTeamMapping, oauth2Source.GroupTeamMapRemoval); err != nil {
@@ -1390,8 +1473,8 @@ func handleOAuth2SignIn(ctx *context.Context, source *auth.Source, u *user_model
 	}
 
 	// If WebAuthn is enrolled -> Redirect to WebAuthn instead
-	regs, err := auth.GetWebAuthnCredentialsByUID(ctx, u.ID)
-	if err == nil && len(regs) > 0 {
+	hasWebAuthn, err := auth.HasWebAuthnRegistrationsByUID(ctx, u.ID)
+	if err == nil && hasWebAuthn {
 		ctx.Redirect(setting.AppSubURL + "/user/webauthn")
 		return
 	}
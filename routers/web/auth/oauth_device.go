@@ -0,0 +1,277 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	go_context "context"
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/auth/source/oauth2"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const (
+	tplDeviceVerify base.TplName = "user/auth/device"
+	tplDeviceGrant  base.TplName = "user/auth/device_grant"
+
+	// grantTypeDeviceCode is the grant type of the access token requests of devices, see RFC 8628 section 3.4
+	grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// DeviceAuthorizationResponse represents a successful device authorization response
+// https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+// DeviceAuthorizationOAuth starts the authorization of a device which can't open a browser itself
+func DeviceAuthorizationOAuth(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.DeviceAuthorizationForm)
+	app, acErr := authenticateOAuthClient(ctx, form.ClientID, form.ClientSecret)
+	if acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+
+	device, deviceCode, err := auth.CreateOAuth2DeviceAuthorization(ctx, app, form.Scope, setting.OAuth2.DeviceCodeExpirationTime, setting.OAuth2.DeviceCodePollingInterval)
+	if err != nil {
+		ctx.ServerError("CreateOAuth2DeviceAuthorization", err)
+		return
+	}
+
+	verificationURI := setting.AppURL + "login/oauth/device"
+	ctx.JSON(http.StatusOK, &DeviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                device.UserCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(device.UserCode),
+		ExpiresIn:               setting.OAuth2.DeviceCodeExpirationTime,
+		Interval:                device.Interval,
+	})
+}
+
+// DeviceOAuth shows the page to enter the user code displayed by a device
+func DeviceOAuth(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("auth.device_title")
+	ctx.Data["user_code"] = ctx.FormString("user_code")
+	ctx.HTML(http.StatusOK, tplDeviceVerify)
+}
+
+// DeviceVerifyOAuth asks the user to confirm the access of the device with the entered user code
+func DeviceVerifyOAuth(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.DeviceGrantForm)
+	ctx.Data["Title"] = ctx.Tr("auth.device_title")
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplDeviceVerify)
+		return
+	}
+
+	device, app := loadDeviceAuthorization(ctx, form)
+	if device == nil {
+		return
+	}
+
+	var creator *user_model.User
+	if app.UID != 0 {
+		var err error
+		if creator, err = user_model.GetUserByID(ctx, app.UID); err != nil {
+			ctx.ServerError("GetUserByID", err)
+			return
+		}
+	}
+	if creator != nil {
+		ctx.Data["ApplicationCreatorLinkHTML"] = template.HTML(fmt.Sprintf(`<a href="%s">@%s</a>`, html.EscapeString(creator.HomeLink()), html.EscapeString(creator.Name)))
+	} else {
+		ctx.Data["ApplicationCreatorLinkHTML"] = template.HTML(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(setting.AppSubURL+"/"), html.EscapeString(setting.AppName)))
+	}
+	ctx.Data["Application"] = app
+	ctx.Data["Scope"] = device.Scope
+	ctx.Data["UserCode"] = device.UserCode
+	ctx.HTML(http.StatusOK, tplDeviceGrant)
+}
+
+// DeviceGrantOAuth manages the post request submitted when a user grants access to a device
+func DeviceGrantOAuth(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.DeviceGrantForm)
+	ctx.Data["Title"] = ctx.Tr("auth.device_title")
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplDeviceVerify)
+		return
+	}
+
+	device, app := loadDeviceAuthorization(ctx, form)
+	if device == nil {
+		return
+	}
+
+	if !form.Granted {
+		if err := device.Deny(ctx); err != nil {
+			ctx.ServerError("Deny", err)
+			return
+		}
+		ctx.Flash.Info(ctx.Tr("auth.device_denied"))
+		ctx.Redirect(setting.AppSubURL + "/login/oauth/device")
+		return
+	}
+
+	grant, err := app.GetGrantByUserID(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetGrantByUserID", err)
+		return
+	}
+	if grant == nil {
+		grant, err = app.CreateGrant(ctx, ctx.Doer.ID, device.Scope)
+		if err != nil {
+			ctx.ServerError("CreateGrant", err)
+			return
+		}
+	} else if grant.Scope != device.Scope {
+		ctx.Flash.Error(ctx.Tr("auth.device_grant_scope_conflict", app.Name))
+		ctx.Redirect(setting.AppSubURL + "/login/oauth/device")
+		return
+	}
+
+	if err := device.Approve(ctx, grant.ID); err != nil {
+		ctx.ServerError("Approve", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("auth.device_authorized", app.Name))
+	ctx.Redirect(setting.AppSubURL + "/login/oauth/device")
+}
+
+// loadDeviceAuthorization returns the pending authorization of the user code
+// of the form and its application, it renders an error when there is none.
+func loadDeviceAuthorization(ctx *context.Context, form *forms.DeviceGrantForm) (*auth.OAuth2DeviceAuthorization, *auth.OAuth2Application) {
+	device, err := auth.GetOAuth2DeviceAuthorizationByUserCode(ctx, form.UserCode)
+	if err != nil {
+		ctx.ServerError("GetOAuth2DeviceAuthorizationByUserCode", err)
+		return nil, nil
+	}
+	if device == nil {
+		ctx.Data["Err_UserCode"] = true
+		ctx.RenderWithErr(ctx.Tr("auth.device_user_code_invalid"), tplDeviceVerify, form)
+		return nil, nil
+	}
+
+	app, err := auth.GetOAuth2ApplicationByID(ctx, device.ApplicationID)
+	if err != nil {
+		ctx.ServerError("GetOAuth2ApplicationByID", err)
+		return nil, nil
+	}
+	return device, app
+}
+
+// handleDeviceCode answers the polling of a device, with the tokens once the user granted access
+func handleDeviceCode(ctx *context.Context, form forms.AccessTokenForm, serverKey, clientKey oauth2.JWTSigningKey) {
+	app, acErr := authenticateOAuthClient(ctx, form.ClientID, form.ClientSecret)
+	if acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+
+	device, err := auth.GetOAuth2DeviceAuthorizationByDeviceCode(ctx, form.DeviceCode)
+	if err != nil {
+		ctx.ServerError("GetOAuth2DeviceAuthorizationByDeviceCode", err)
+		return
+	}
+	if device == nil || device.ApplicationID != app.ID {
+		handleAccessTokenError(ctx, AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+			ErrorDescription: "invalid device code",
+		})
+		return
+	}
+
+	switch {
+	case device.IsExpired():
+		acErr = &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeExpiredToken,
+			ErrorDescription: "the device code has expired",
+		}
+	case device.Denied:
+		acErr = &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeAccessDenied,
+			ErrorDescription: "the request is denied",
+		}
+	case !device.IsApproved():
+		// "the client SHOULD wait at least interval seconds between polls", slow it down
+		// https://datatracker.ietf.org/doc/html/rfc8628#section-3.5
+		acErr = &AccessTokenError{
+			ErrorCode:        AccessTokenErrorCodeAuthorizationPending,
+			ErrorDescription: "the user has not granted access yet",
+		}
+		if device.LastPolledUnix.Add(device.Interval) > timeutil.TimeStampNow() {
+			device.Interval += 5
+			acErr.ErrorCode = AccessTokenErrorCodeSlowDown
+			acErr.ErrorDescription = fmt.Sprintf("poll at most every %d seconds", device.Interval)
+		}
+		if err := device.UpdatePoll(ctx); err != nil {
+			ctx.ServerError("UpdatePoll", err)
+			return
+		}
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+
+	// the device code can only be used once whatever the outcome, the tokens are issued
+	// in the same transaction so that only one of concurrent polls can get them
+	var resp *AccessTokenResponse
+	err = db.WithTx(ctx, func(ctx go_context.Context) error {
+		invalidated, err := device.Invalidate(ctx)
+		if err != nil {
+			return err
+		}
+		if !invalidated {
+			acErr = &AccessTokenError{
+				ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+				ErrorDescription: "the device code was already used",
+			}
+			return nil
+		}
+		if acErr != nil {
+			return nil
+		}
+
+		grant, err := auth.GetOAuth2GrantByID(ctx, device.GrantID)
+		if err != nil || grant == nil {
+			acErr = &AccessTokenError{
+				ErrorCode:        AccessTokenErrorCodeInvalidGrant,
+				ErrorDescription: "grant does not exist",
+			}
+			return nil
+		}
+		// keep the device code when no tokens could be issued
+		resp, acErr = newAccessTokenResponse(ctx, grant, serverKey, clientKey)
+		if acErr != nil {
+			return acErr
+		}
+		return nil
+	})
+	if acErr != nil {
+		handleAccessTokenError(ctx, *acErr)
+		return
+	}
+	if err != nil {
+		ctx.ServerError("Invalidate", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
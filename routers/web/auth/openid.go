@@ -156,7 +156,7 @@ func signInOpenIDVerify(ctx *context.Context) {
 		log.Trace("User exists, logging in")
 		remember, _ := ctx.Session.Get("openid_signin_remember").(bool)
 		log.Trace("Session stored openid-remember: %t", remember)
-		handleSignIn(ctx, u, remember)
+		handleSignIn(ctx, u, remember, auth_model.LoginMethodOpenID)
 		return
 	}
 
@@ -295,7 +295,7 @@ func ConnectOpenIDPost(ctx *context.Context) {
 		}
 
 		ctx.Flash.Success(ctx.Tr("settings.add_openid_success"))
-		handleSignIn(ctx, u, remember)
+		handleSignIn(ctx, u, remember, auth_model.LoginMethodOpenID)
 		return
 	}
 
@@ -421,5 +421,5 @@ func RegisterOpenIDPost(ctx *context.Context) {
 
 	remember, _ := ctx.Session.Get("openid_signin_remember").(bool)
 	log.Trace("Session stored openid-remember: %t", remember)
-	handleSignIn(ctx, u, remember)
+	handleSignIn(ctx, u, remember, auth_model.LoginMethodOpenID)
 }
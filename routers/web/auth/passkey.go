@@ -0,0 +1,124 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	wa "forgejo.org/modules/auth/webauthn"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// PasskeyLoginAssertion submits a challenge for a discoverable credential to the browser,
+// the user is not known until the browser answers it
+func PasskeyLoginAssertion(ctx *context.Context) {
+	if !setting.Service.EnableInternalSignIn {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	assertion, sessionData, err := wa.WebAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		ctx.ServerError("webauthn.BeginDiscoverableLogin", err)
+		return
+	}
+
+	if err := ctx.Session.Set("passkeyAssertion", sessionData); err != nil {
+		ctx.ServerError("Session.Set", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, assertion)
+}
+
+// PasskeyLoginAssertionPost validates the signature of a passkey and signs the user in
+func PasskeyLoginAssertionPost(ctx *context.Context) {
+	if !setting.Service.EnableInternalSignIn {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	sessionData, ok := ctx.Session.Get("passkeyAssertion").(*webauthn.SessionData)
+	if !ok || sessionData == nil {
+		ctx.ServerError("UserSignIn", errors.New("not in passkey session"))
+		return
+	}
+	defer func() {
+		_ = ctx.Session.Delete("passkeyAssertion")
+	}()
+
+	parsedResponse, err := protocol.ParseCredentialRequestResponse(ctx.Req)
+	if err != nil {
+		log.Info("Failed passkey authentication attempt from %s: %v", ctx.RemoteAddr(), err)
+		recordFailedSignIn(ctx, 0, "", "passkey")
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	var dbCred *auth.WebAuthnCredential
+	findUser := func(rawID, userHandle []byte) (webauthn.User, error) {
+		user, err := wa.GetUserByWebAuthnID(ctx, userHandle)
+		if err != nil {
+			return nil, err
+		}
+		dbCred, err = auth.GetWebAuthnCredentialByCredID(ctx, user.ID, rawID)
+		if err != nil {
+			return nil, err
+		}
+		if !dbCred.Passkey {
+			return nil, errors.New("the credential is not a passkey")
+		}
+		return (*wa.User)(user), nil
+	}
+
+	waUser, cred, err := wa.WebAuthn.ValidatePasskeyLogin(findUser, *sessionData, parsedResponse)
+	if err != nil {
+		log.Info("Failed passkey authentication attempt from %s: %v", ctx.RemoteAddr(), err)
+		recordFailedSignIn(ctx, 0, "", "passkey")
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+	user := (*user_model.User)(waUser.(*wa.User))
+
+	// Ensure that the credential wasn't cloned by checking if CloneWarning is set.
+	// (This is set if the sign counter is less than the one we have stored.)
+	if cred.Authenticator.CloneWarning {
+		log.Info("Failed passkey authentication attempt for %s from %s: cloned credential", user.Name, ctx.RemoteAddr())
+		recordFailedSignIn(ctx, user.ID, user.Name, "cloned_passkey")
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	if user.ProhibitLogin || !user.IsActive {
+		log.Info("Failed passkey authentication attempt for %s from %s: the user is not allowed to sign in", user.Name, ctx.RemoteAddr())
+		recordFailedSignIn(ctx, user.ID, user.Name, "prohibit_login")
+		ctx.Status(http.StatusForbidden)
+		return
+	}
+
+	dbCred.SignCount = cred.Authenticator.SignCount
+	if err := dbCred.UpdateSignCount(ctx); err != nil {
+		ctx.ServerError("UpdateSignCount", err)
+		return
+	}
+
+	// a passkey combines possession and user verification, it is not followed by another factor
+	redirect := handleSignInFull(ctx, user, false, false, auth.LoginMethodPasskey)
+	if ctx.Written() {
+		return
+	}
+	if redirect == "" {
+		redirect = setting.AppSubURL + "/"
+	}
+	_ = ctx.Session.Set(context.PhishingResistantAuthSessionKey, true)
+
+	ctx.JSONRedirect(redirect)
+}
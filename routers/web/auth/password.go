@@ -253,7 +253,7 @@ func ResetPasswdPost(ctx *context.Context) {
 			return
 		}
 
-		handleSignInFull(ctx, u, remember, false)
+		handleSignInFull(ctx, u, remember, false, auth.LoginMethodTwoFactor)
 		if ctx.Written() {
 			return
 		}
@@ -262,7 +262,7 @@ func ResetPasswdPost(ctx *context.Context) {
 		return
 	}
 
-	handleSignIn(ctx, u, remember)
+	handleSignIn(ctx, u, remember, auth.LoginMethodPassword)
 }
 
 // MustChangePassword renders the page to change a user's password
@@ -0,0 +1,336 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	auth_module "forgejo.org/modules/auth"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/web/middleware"
+	audit_service "forgejo.org/services/audit"
+	auth_service "forgejo.org/services/auth"
+	source_service "forgejo.org/services/auth/source"
+	saml_source "forgejo.org/services/auth/source/saml"
+	"forgejo.org/services/context"
+	user_service "forgejo.org/services/user"
+
+	"github.com/crewjam/saml"
+)
+
+// samlRequestCookieName tracks the ID of the pending AuthnRequest and where to redirect afterwards.
+// The identity provider posts the response cross-site, so the cookie can't rely on the session cookie.
+const samlRequestCookieName = "saml_request"
+
+// getSAMLProviders returns the active SAML sources to list on the sign in page
+func getSAMLProviders(ctx *context.Context) ([]*auth.Source, error) {
+	return db.Find[auth.Source](ctx, auth.FindSourcesOptions{
+		IsActive:  optional.Some(true),
+		LoginType: auth.SAML,
+	})
+}
+
+func getSAMLServiceProvider(ctx *context.Context) (*auth.Source, *saml_source.Source, *saml.ServiceProvider) {
+	authSource, err := auth.GetActiveSAMLSourceByName(ctx, ctx.Params(":provider"))
+	if err != nil {
+		if auth.IsErrSourceNotExist(err) {
+			ctx.NotFound("GetActiveSAMLSourceByName", err)
+		} else {
+			ctx.ServerError("GetActiveSAMLSourceByName", err)
+		}
+		return nil, nil, nil
+	}
+
+	samlSource := authSource.Cfg.(*saml_source.Source)
+	sp, err := samlSource.ServiceProvider(ctx)
+	if err != nil {
+		ctx.ServerError("ServiceProvider", err)
+		return nil, nil, nil
+	}
+	return authSource, samlSource, sp
+}
+
+func setSAMLRequestCookie(ctx *context.Context, value string, maxAge int) {
+	cookie := &http.Cookie{
+		Name:     samlRequestCookieName,
+		Value:    value,
+		MaxAge:   maxAge,
+		Path:     setting.AppSubURL + "/user/saml/",
+		Secure:   setting.SessionConfig.Secure || strings.HasPrefix(setting.AppURL, "https://"),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if cookie.Secure {
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(ctx.Resp, cookie)
+}
+
+// SAMLMetadata serves the service provider metadata of a SAML source
+func SAMLMetadata(ctx *context.Context) {
+	_, _, sp := getSAMLServiceProvider(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	buf, err := xml.MarshalIndent(sp.Metadata(), "", "  ")
+	if err != nil {
+		ctx.ServerError("MarshalIndent", err)
+		return
+	}
+	ctx.Resp.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = ctx.Resp.Write(buf)
+}
+
+// SignInSAML redirects to the identity provider of a SAML source
+func SignInSAML(ctx *context.Context) {
+	authSource, _, sp := getSAMLServiceProvider(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	ssoURL := sp.GetSSOBindingLocation(saml.HTTPRedirectBinding)
+	if ssoURL == "" {
+		ctx.ServerError("SignInSAML", errors.New("the identity provider of "+authSource.Name+" does not support the HTTP-Redirect binding"))
+		return
+	}
+	authnRequest, err := sp.MakeAuthenticationRequest(ssoURL, saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		ctx.ServerError("MakeAuthenticationRequest", err)
+		return
+	}
+	redirectURL, err := authnRequest.Redirect("", sp)
+	if err != nil {
+		ctx.ServerError("Redirect", err)
+		return
+	}
+
+	tracked := url.Values{"id": {authnRequest.ID}}
+	if redirectTo := ctx.FormString("redirect_to"); redirectTo != "" {
+		tracked.Set("redirect_to", redirectTo)
+	}
+	setSAMLRequestCookie(ctx, tracked.Encode(), int(saml.MaxIssueDelay.Seconds()))
+
+	ctx.Redirect(redirectURL.String())
+}
+
+// SignInSAMLACS is the assertion consumer service receiving the SAML response of the identity provider
+func SignInSAMLACS(ctx *context.Context) {
+	authSource, samlSource, sp := getSAMLServiceProvider(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var tracked url.Values
+	if cookie, err := ctx.Req.Cookie(samlRequestCookieName); err == nil {
+		tracked, _ = url.ParseQuery(cookie.Value)
+	}
+	setSAMLRequestCookie(ctx, "", -1)
+
+	var possibleRequestIDs []string
+	if id := tracked.Get("id"); id != "" {
+		possibleRequestIDs = append(possibleRequestIDs, id)
+	}
+
+	assertion, err := samlSource.ParseResponse(sp, ctx.Req.PostFormValue("SAMLResponse"), possibleRequestIDs)
+	if err == nil {
+		var externalUser *saml_source.ExternalUser
+		if externalUser, err = samlSource.ExternalUser(assertion); err == nil {
+			handleSAMLExternalUser(ctx, authSource, samlSource, externalUser, tracked.Get("redirect_to"))
+			return
+		}
+	}
+
+	var invalidResponse *saml.InvalidResponseError
+	if errors.As(err, &invalidResponse) {
+		err = invalidResponse.PrivateErr
+	}
+	log.Warn("Failed SAML sign in with source %s from %s: %v", authSource.Name, ctx.RemoteAddr(), err)
+	ctx.Flash.Error(ctx.Tr("auth.saml.signin.error"))
+	ctx.Redirect(setting.AppSubURL + "/user/login")
+}
+
+func handleSAMLExternalUser(ctx *context.Context, authSource *auth.Source, samlSource *saml_source.Source, externalUser *saml_source.ExternalUser, redirectTo string) {
+	u := &user_model.User{
+		LoginName:   externalUser.NameID,
+		LoginType:   auth.SAML,
+		LoginSource: authSource.ID,
+	}
+	hasUser, err := user_model.GetUser(ctx, u)
+	if err != nil {
+		ctx.ServerError("GetUser", err)
+		return
+	}
+
+	if !hasUser {
+		if setting.Service.AllowOnlyInternalRegistration {
+			ctx.Flash.Error(ctx.Tr("auth.saml.signin.error.no_account"))
+			ctx.Redirect(setting.AppSubURL + "/user/login")
+			return
+		}
+		if externalUser.Email == "" {
+			log.Error("SAML source %s returned no email address for %s, check the email attribute name", authSource.Name, externalUser.NameID)
+			ctx.Flash.Error(ctx.Tr("auth.saml.signin.error.missing_email"))
+			ctx.Redirect(setting.AppSubURL + "/user/login")
+			return
+		}
+
+		username := externalUser.Username
+		if username == "" {
+			username = strings.Split(externalUser.Email, "@")[0]
+		}
+		if username, err = user_model.NormalizeUserName(username); err != nil {
+			ctx.ServerError("NormalizeUserName", err)
+			return
+		}
+
+		u = &user_model.User{
+			Name:        username,
+			FullName:    externalUser.FullName,
+			Email:       externalUser.Email,
+			LoginType:   auth.SAML,
+			LoginSource: authSource.ID,
+			LoginName:   externalUser.NameID,
+		}
+		isAdmin, isRestricted := getSAMLUserAdminAndRestricted(samlSource, externalUser)
+		u.IsAdmin = isAdmin.ValueOrDefault(false)
+		u.IsRestricted = isRestricted.ValueOrDefault(setting.Service.DefaultUserIsRestricted)
+
+		overwriteDefault := &user_model.CreateUserOverwriteOptions{
+			// the identity provider is trusted to have verified the email address
+			IsActive: optional.Some(!setting.Service.RegisterManualConfirm),
+		}
+		if !createAndHandleCreatedUser(ctx, "", nil, u, overwriteDefault, nil, false) {
+			// error already handled
+			return
+		}
+	}
+
+	handleSAMLSignIn(ctx, samlSource, u, externalUser, redirectTo)
+}
+
+func getSAMLUserAdminAndRestricted(source *saml_source.Source, externalUser *saml_source.ExternalUser) (isAdmin, isRestricted optional.Option[bool]) {
+	if source.AdminGroup != "" {
+		isAdmin = optional.Some(externalUser.Groups.Contains(source.AdminGroup))
+	}
+	if source.RestrictedGroup != "" {
+		isRestricted = optional.Some(externalUser.Groups.Contains(source.RestrictedGroup))
+	}
+	return isAdmin, isRestricted
+}
+
+func syncSAMLGroups(ctx *context.Context, source *saml_source.Source, externalUser *saml_source.ExternalUser, u *user_model.User) error {
+	if source.GroupTeamMap != "" || source.GroupTeamMapRemoval {
+		groupTeamMapping, err := auth_module.UnmarshalGroupTeamMapping(source.GroupTeamMap)
+		if err != nil {
+			return err
+		}
+		if err := source_service.SyncGroupsToTeams(ctx, u, externalUser.Groups, groupTeamMapping, source.GroupTeamMapRemoval); err != nil {
+			return err
+		}
+	}
+
+	if source.QuotaGroupMap != "" || source.QuotaGroupMapRemoval {
+		quotaGroupMapping, err := auth_module.UnmarshalQuotaGroupMapping(source.QuotaGroupMap)
+		if err != nil {
+			return err
+		}
+		if err := source_service.SyncGroupsToQuotaGroups(ctx, u, externalUser.QuotaGroups, quotaGroupMapping, source.QuotaGroupMapRemoval); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func handleSAMLSignIn(ctx *context.Context, source *saml_source.Source, u *user_model.User, externalUser *saml_source.ExternalUser, redirectTo string) {
+	if u.ProhibitLogin {
+		log.Info("Failed authentication attempt for %s from %s: user is prohibited from login", u.Name, ctx.RemoteAddr())
+		recordFailedSignIn(ctx, u.ID, u.Name, "prohibit_login")
+		ctx.Data["Title"] = ctx.Tr("auth.prohibit_login")
+		ctx.HTML(http.StatusOK, "user/auth/prohibit_login")
+		return
+	}
+
+	opts := &user_service.UpdateOptions{}
+	opts.IsAdmin, opts.IsRestricted = getSAMLUserAdminAndRestricted(source, externalUser)
+	if opts.IsAdmin.Has() || opts.IsRestricted.Has() {
+		if err := user_service.UpdateUser(ctx, u, opts); err != nil {
+			ctx.ServerError("UpdateUser", err)
+			return
+		}
+	}
+
+	if err := syncSAMLGroups(ctx, source, externalUser, u); err != nil {
+		ctx.ServerError("syncSAMLGroups", err)
+		return
+	}
+
+	needs2FA := false
+	if !source.SkipLocalTwoFA {
+		var err error
+		needs2FA, err = auth.HasTwoFactorByUID(ctx, u.ID)
+		if err != nil {
+			ctx.ServerError("UserSignIn", err)
+			return
+		}
+	}
+
+	if needs2FA {
+		// User needs to use 2FA, save data and redirect to 2FA page.
+		if err := updateSession(ctx, nil, map[string]any{
+			"twofaUid":      u.ID,
+			"twofaRemember": false,
+		}); err != nil {
+			ctx.ServerError("updateSession", err)
+			return
+		}
+		if redirectTo != "" {
+			middleware.SetRedirectToCookie(ctx.Resp, redirectTo)
+		}
+
+		// If WebAuthn is enrolled -> Redirect to WebAuthn instead
+		hasWebAuthn, err := auth.HasWebAuthnRegistrationsByUID(ctx, u.ID)
+		if err == nil && hasWebAuthn {
+			ctx.Redirect(setting.AppSubURL + "/user/webauthn")
+			return
+		}
+		ctx.Redirect(setting.AppSubURL + "/user/two_factor")
+		return
+	}
+
+	if err := updateSession(ctx, nil, map[string]any{
+		"uid":                              u.ID,
+		auth_service.LoginMethodSessionKey: auth.LoginMethodSAML,
+	}); err != nil {
+		ctx.ServerError("updateSession", err)
+		return
+	}
+
+	if err := user_service.UpdateUser(ctx, u, &user_service.UpdateOptions{SetLastLogin: true}); err != nil {
+		ctx.ServerError("UpdateUser", err)
+		return
+	}
+	audit_service.Record(ctx, audit_service.NewActor(u, ctx.RemoteAddr()), audit_model.ActionUserLogin, audit_service.UserTarget(u), nil, map[string]string{"method": auth.LoginMethodSAML})
+
+	if err := resetLocale(ctx, u); err != nil {
+		ctx.ServerError("resetLocale", err)
+		return
+	}
+
+	if redirectTo != "" {
+		ctx.RedirectToFirst(redirectTo)
+		return
+	}
+	ctx.Redirect(setting.AppSubURL + "/")
+}
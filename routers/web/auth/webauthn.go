@@ -112,6 +112,7 @@ func WebAuthnLoginAssertionPost(ctx *context.Context) {
 	if err != nil {
 		// Failed authentication attempt.
 		log.Info("Failed authentication attempt for %s from %s: %v", user.Name, ctx.RemoteAddr(), err)
+		recordFailedSignIn(ctx, user.ID, user.Name, "security_key")
 		ctx.Status(http.StatusForbidden)
 		return
 	}
@@ -140,6 +141,7 @@ func WebAuthnLoginAssertionPost(ctx *context.Context) {
 	if err != nil {
 		// Failed authentication attempt.
 		log.Info("Failed authentication attempt for %s from %s: %v", user.Name, ctx.RemoteAddr(), err)
+		recordFailedSignIn(ctx, user.ID, user.Name, "security_key")
 		ctx.Status(http.StatusForbidden)
 		return
 	}
@@ -148,6 +150,7 @@ func WebAuthnLoginAssertionPost(ctx *context.Context) {
 	// (This is set if the sign counter is less than the one we have stored.)
 	if cred.Authenticator.CloneWarning {
 		log.Info("Failed authentication attempt for %s from %s: cloned credential", user.Name, ctx.RemoteAddr())
+		recordFailedSignIn(ctx, user.ID, user.Name, "cloned_security_key")
 		ctx.Status(http.StatusForbidden)
 		return
 	}
@@ -175,11 +178,12 @@ func WebAuthnLoginAssertionPost(ctx *context.Context) {
 	}
 
 	remember := ctx.Session.Get("twofaRemember").(bool)
-	redirect := handleSignInFull(ctx, user, remember, false)
+	redirect := handleSignInFull(ctx, user, remember, false, auth.LoginMethodSecurityKey)
 	if redirect == "" {
 		redirect = setting.AppSubURL + "/"
 	}
 	_ = ctx.Session.Delete("twofaUid")
+	_ = ctx.Session.Set(context.PhishingResistantAuthSessionKey, true)
 
 	ctx.JSONRedirect(redirect)
 }
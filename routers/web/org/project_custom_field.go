@@ -0,0 +1,155 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package org
+
+import (
+	"errors"
+	"net/http"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const tplProjectCustomFields base.TplName = "org/projects/custom_fields"
+
+// getOwnerProject returns the project of the :id parameter if it belongs to the context user
+func getOwnerProject(ctx *context.Context) *project_model.Project {
+	p, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetProjectByID", project_model.IsErrProjectNotExist, err)
+		return nil
+	}
+	if p.OwnerID != ctx.ContextUser.ID {
+		ctx.NotFound("", nil)
+		return nil
+	}
+	return p
+}
+
+// ProjectCustomFields renders the custom fields of the issues of a project
+func ProjectCustomFields(ctx *context.Context) {
+	p := getOwnerProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	fields, err := db.Find[issues_model.CustomField](ctx, issues_model.FindCustomFieldOptions{ProjectIDs: []int64{p.ID}})
+	if err != nil {
+		ctx.ServerError("FindCustomFields", err)
+		return
+	}
+
+	ctx.Data["Title"] = ctx.Tr("repo.issues.custom_fields")
+	ctx.Data["PageIsViewProjects"] = true
+	ctx.Data["CanWriteProjects"] = canWriteProjects(ctx)
+	ctx.Data["Project"] = p
+	ctx.Data["CustomFields"] = fields
+	ctx.Data["CustomFieldTypes"] = issues_model.CustomFieldTypes
+	ctx.Data["CustomFieldsLink"] = p.Link(ctx) + "/fields"
+	ctx.Data["CanEditCustomFields"] = canWriteProjects(ctx)
+	shared_user.RenderUserHeader(ctx)
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplProjectCustomFields)
+}
+
+// NewProjectCustomField creates a custom field for the issues of a project
+func NewProjectCustomField(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CustomFieldForm)
+	p := getOwnerProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := p.Link(ctx) + "/fields"
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.Data["ErrorMsg"].(string))
+		ctx.Redirect(link)
+		return
+	}
+
+	field := &issues_model.CustomField{
+		ProjectID: p.ID,
+		Name:      form.Name,
+		Type:      issues_model.CustomFieldType(form.Type),
+		Options:   form.OptionList(),
+		Sorting:   form.Sorting,
+	}
+	if err := issues_model.NewCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("repo.issues.custom_field_invalid", err.Error()))
+			ctx.Redirect(link)
+			return
+		}
+		ctx.ServerError("NewCustomField", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.issues.custom_field_created", field.Name))
+	ctx.Redirect(link)
+}
+
+// EditProjectCustomField updates a custom field of a project
+func EditProjectCustomField(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CustomFieldForm)
+	p := getOwnerProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	link := p.Link(ctx) + "/fields"
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.Data["ErrorMsg"].(string))
+		ctx.Redirect(link)
+		return
+	}
+
+	field, err := issues_model.GetCustomFieldInProjectByID(ctx, p.ID, ctx.ParamsInt64(":fieldID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetCustomFieldInProjectByID", issues_model.IsErrCustomFieldNotExist, err)
+		return
+	}
+	field.Name = form.Name
+	field.Options = form.OptionList()
+	field.Sorting = form.Sorting
+	if err := issues_model.UpdateCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("repo.issues.custom_field_invalid", err.Error()))
+			ctx.Redirect(link)
+			return
+		}
+		ctx.ServerError("UpdateCustomField", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.issues.custom_field_updated", field.Name))
+	ctx.Redirect(link)
+}
+
+// DeleteProjectCustomField deletes a custom field of a project and its values
+func DeleteProjectCustomField(ctx *context.Context) {
+	p := getOwnerProject(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	field, err := issues_model.GetCustomFieldInProjectByID(ctx, p.ID, ctx.ParamsInt64(":fieldID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetCustomFieldInProjectByID", issues_model.IsErrCustomFieldNotExist, err)
+		return
+	}
+	if err := issues_model.DeleteCustomField(ctx, field); err != nil {
+		ctx.ServerError("DeleteCustomField", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.issues.custom_field_deleted", field.Name))
+	ctx.Redirect(p.Link(ctx) + "/fields")
+}
@@ -20,6 +20,7 @@ import (
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/templates"
 	"forgejo.org/modules/web"
+	shared_project "forgejo.org/routers/web/shared/project"
 	shared_user "forgejo.org/routers/web/shared/user"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
@@ -237,7 +238,9 @@ func DeleteProject(ctx *context.Context) {
 		return
 	}
 
-	if err := project_model.DeleteProjectByID(ctx, p.ID); err != nil {
+	if err := issues_model.DeleteProjectCustomFields(ctx, p.ID); err != nil {
+		ctx.Flash.Error("DeleteProjectCustomFields: " + err.Error())
+	} else if err := project_model.DeleteProjectByID(ctx, p.ID); err != nil {
 		ctx.Flash.Error("DeleteProjectByID: " + err.Error())
 	} else {
 		ctx.Flash.Success(ctx.Tr("repo.projects.deletion_success"))
@@ -384,7 +387,31 @@ func ViewProject(ctx *context.Context) {
 		}
 	}
 
+	issueIDs := make([]int64, 0, 10)
+	for _, issuesList := range issuesMap {
+		for _, issue := range issuesList {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	customFieldValues, err := issues_model.GetIssueCustomFieldValues(ctx, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldValues", err)
+		return
+	}
+	boardCustomFields, err := db.Find[issues_model.CustomField](ctx, issues_model.FindCustomFieldOptions{ProjectIDs: []int64{project.ID}})
+	if err != nil {
+		ctx.ServerError("FindCustomFields", err)
+		return
+	}
+
 	project.RenderedContent = templates.RenderMarkdownToHtml(ctx, project.Description)
+	shared_project.LoadViews(ctx, project, columns, issuesMap)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Data["CustomFieldValues"] = customFieldValues
+	ctx.Data["BoardCustomFields"] = boardCustomFields
 	ctx.Data["LinkedPRs"] = linkedPrsMap
 	ctx.Data["PageIsViewProjects"] = true
 	ctx.Data["CanWriteProjects"] = canWriteProjects(ctx)
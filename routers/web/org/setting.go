@@ -7,10 +7,15 @@ package org
 import (
 	"net/http"
 	"net/url"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
+	"forgejo.org/models/organization"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/models/webhook"
@@ -22,6 +27,7 @@ import (
 	"forgejo.org/modules/web"
 	shared_user "forgejo.org/routers/web/shared/user"
 	user_setting "forgejo.org/routers/web/user/setting"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	org_service "forgejo.org/services/org"
@@ -48,6 +54,7 @@ func Settings(ctx *context.Context) {
 	ctx.Data["PageIsSettingsOptions"] = true
 	ctx.Data["CurrentVisibility"] = ctx.Org.Organization.Visibility
 	ctx.Data["RepoAdminChangeTeamAccess"] = ctx.Org.Organization.RepoAdminChangeTeamAccess
+	ctx.Data["RequirePhishingResistantAuth"] = ctx.Org.Organization.RequirePhishingResistantAuth
 	ctx.Data["ContextUser"] = ctx.ContextUser
 	ctx.Data["CooldownPeriod"] = setting.Service.UsernameCooldownPeriod
 	ctx.Data["MaxAvatarFileSize"] = setting.Avatar.MaxFileSize
@@ -60,6 +67,15 @@ func Settings(ctx *context.Context) {
 		return
 	}
 
+	policy, err := organization.GetOrgSecurityPolicy(ctx, ctx.Org.Organization.ID)
+	if err != nil {
+		ctx.ServerError("GetOrgSecurityPolicy", err)
+		return
+	}
+	ctx.Data["SecurityPolicy"] = policy
+	ctx.Data["SSHKeyTypes"] = organization.SSHKeyTypes
+	ctx.Data["IPAllowlist"] = strings.Join(policy.IPAllowlist, "\n")
+
 	ctx.HTML(http.StatusOK, tplSettingsOptions)
 }
 
@@ -74,6 +90,17 @@ func SettingsPost(ctx *context.Context) {
 	ctx.Data["MaxAvatarFileSize"] = setting.Avatar.MaxFileSize
 	ctx.Data["MaxAvatarWidth"] = setting.Avatar.MaxWidth
 	ctx.Data["MaxAvatarHeight"] = setting.Avatar.MaxHeight
+	ctx.Data["RequirePhishingResistantAuth"] = form.RequirePhishingResistantAuth
+	ctx.Data["SSHKeyTypes"] = organization.SSHKeyTypes
+	ctx.Data["IPAllowlist"] = form.IPAllowlist
+
+	policy := &organization.OrgSecurityPolicy{
+		OrgID:            ctx.Org.Organization.ID,
+		RequireTwoFactor: form.RequireTwoFactor,
+		SSHKeyTypes:      form.SSHKeyTypes,
+		MinRSAKeySize:    form.MinRSAKeySize,
+	}
+	ctx.Data["SecurityPolicy"] = policy
 
 	if ctx.HasError() {
 		ctx.HTML(http.StatusOK, tplSettingsOptions)
@@ -82,6 +109,32 @@ func SettingsPost(ctx *context.Context) {
 
 	org := ctx.Org.Organization
 
+	var err error
+	if policy.IPAllowlist, err = organization.ParseIPAllowlist(form.IPAllowlist); err != nil {
+		ctx.Data["Err_IPAllowlist"] = true
+		ctx.RenderWithErr(ctx.Tr("org.settings.ip_allowlist_invalid", err.Error()), tplSettingsOptions, &form)
+		return
+	}
+	for _, keyType := range policy.SSHKeyTypes {
+		if !slices.Contains(organization.SSHKeyTypes, keyType) {
+			ctx.Error(http.StatusBadRequest, "unknown SSH key type")
+			return
+		}
+	}
+
+	// site administrators are not subject to the policy, other owners must not lock themselves out
+	if !ctx.Doer.IsAdmin {
+		violations, err := policy.CheckMember(ctx, ctx.Doer, ctx.RemoteAddr())
+		if err != nil {
+			ctx.ServerError("CheckMember", err)
+			return
+		}
+		if !violations.IsEmpty() {
+			ctx.RenderWithErr(ctx.Tr("org.settings.security_policy_self_violation", context.OrgSecurityPolicyMessage(org, violations)), tplSettingsOptions, &form)
+			return
+		}
+	}
+
 	if org.Name != form.Name {
 		if err := user_service.RenameUser(ctx, org.AsUser(), form.Name); err != nil {
 			if user_model.IsErrUserAlreadyExist(err) {
@@ -120,12 +173,13 @@ func SettingsPost(ctx *context.Context) {
 	}
 
 	opts := &user_service.UpdateOptions{
-		FullName:                  optional.Some(form.FullName),
-		Description:               optional.Some(form.Description),
-		Website:                   optional.Some(form.Website),
-		Location:                  optional.Some(form.Location),
-		Visibility:                optional.Some(form.Visibility),
-		RepoAdminChangeTeamAccess: optional.Some(form.RepoAdminChangeTeamAccess),
+		FullName:                     optional.Some(form.FullName),
+		Description:                  optional.Some(form.Description),
+		Website:                      optional.Some(form.Website),
+		Location:                     optional.Some(form.Location),
+		Visibility:                   optional.Some(form.Visibility),
+		RepoAdminChangeTeamAccess:    optional.Some(form.RepoAdminChangeTeamAccess),
+		RequirePhishingResistantAuth: optional.Some(form.RequirePhishingResistantAuth),
 	}
 	if ctx.Doer.IsAdmin {
 		opts.MaxRepoCreation = optional.Some(form.MaxRepoCreation)
@@ -138,6 +192,19 @@ func SettingsPost(ctx *context.Context) {
 		return
 	}
 
+	oldPolicy, err := organization.GetOrgSecurityPolicy(ctx, org.ID)
+	if err != nil {
+		ctx.ServerError("GetOrgSecurityPolicy", err)
+		return
+	}
+	if err := organization.UpdateOrgSecurityPolicy(ctx, policy); err != nil {
+		ctx.ServerError("UpdateOrgSecurityPolicy", err)
+		return
+	}
+	if before, after := securityPolicyState(oldPolicy), securityPolicyState(policy); !reflect.DeepEqual(before, after) {
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionOrgSecurityPolicy, audit_service.UserTarget(org.AsUser()), before, after)
+	}
+
 	// update forks visibility
 	if visibilityChanged {
 		repos, _, err := repo_model.GetUserRepositories(ctx, &repo_model.SearchRepoOptions{
@@ -161,6 +228,16 @@ func SettingsPost(ctx *context.Context) {
 	ctx.Redirect(ctx.Org.OrgLink + "/settings")
 }
 
+// securityPolicyState returns the audited state of the security policy of an organization
+func securityPolicyState(policy *organization.OrgSecurityPolicy) map[string]any {
+	return map[string]any{
+		"require_two_factor": policy.RequireTwoFactor,
+		"ssh_key_types":      policy.SSHKeyTypes,
+		"min_rsa_key_size":   policy.MinRSAKeySize,
+		"ip_allowlist":       policy.IPAllowlist,
+	}
+}
+
 // SettingsAvatar response for change avatar on settings page
 func SettingsAvatar(ctx *context.Context) {
 	form := web.GetForm(ctx).(*forms.AvatarForm)
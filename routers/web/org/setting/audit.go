@@ -0,0 +1,33 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"net/http"
+
+	audit_model "forgejo.org/models/audit"
+	shared_audit "forgejo.org/routers/web/shared/audit"
+	shared_user "forgejo.org/routers/web/shared/user"
+	"forgejo.org/services/context"
+)
+
+const tplAudit = "org/settings/audit"
+
+// AuditEvents shows the audit log of the organization and its repositories
+func AuditEvents(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("audit.title")
+	ctx.Data["PageIsSettingsAudit"] = true
+
+	if err := shared_user.LoadHeaderCount(ctx); err != nil {
+		ctx.ServerError("LoadHeaderCount", err)
+		return
+	}
+
+	shared_audit.SetAuditEventsContext(ctx, audit_model.FindEventsOptions{OwnerID: ctx.Org.Organization.ID})
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplAudit)
+}
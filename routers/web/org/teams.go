@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	org_model "forgejo.org/models/organization"
 	"forgejo.org/models/perm"
@@ -25,6 +26,7 @@ import (
 	"forgejo.org/modules/validation"
 	"forgejo.org/modules/web"
 	shared_user "forgejo.org/routers/web/shared/user"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
@@ -79,9 +81,14 @@ func TeamsAction(ctx *context.Context) {
 			return
 		}
 		err = models.AddTeamMember(ctx, ctx.Org.Team, ctx.Doer.ID)
+		if err == nil {
+			recordTeamMemberChange(ctx, audit_model.ActionTeamMemberAdd, ctx.Doer.ID)
+		}
 	case "leave":
 		err = models.RemoveTeamMember(ctx, ctx.Org.Team, ctx.Doer.ID)
-		if err != nil {
+		if err == nil {
+			recordTeamMemberChange(ctx, audit_model.ActionTeamMemberRemove, ctx.Doer.ID)
+		} else {
 			if org_model.IsErrLastOrgOwner(err) {
 				ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
 			} else {
@@ -108,7 +115,9 @@ func TeamsAction(ctx *context.Context) {
 		}
 
 		err = models.RemoveTeamMember(ctx, ctx.Org.Team, uid)
-		if err != nil {
+		if err == nil {
+			recordTeamMemberChange(ctx, audit_model.ActionTeamMemberRemove, uid)
+		} else {
 			if org_model.IsErrLastOrgOwner(err) {
 				ctx.Flash.Error(ctx.Tr("form.last_org_owner"))
 			} else {
@@ -163,6 +172,9 @@ func TeamsAction(ctx *context.Context) {
 			ctx.Flash.Error(ctx.Tr("org.teams.add_duplicate_users"))
 		} else {
 			err = models.AddTeamMember(ctx, ctx.Org.Team, u.ID)
+			if err == nil {
+				recordTeamMemberChange(ctx, audit_model.ActionTeamMemberAdd, u.ID)
+			}
 		}
 
 		page = "team"
@@ -210,6 +222,15 @@ func TeamsAction(ctx *context.Context) {
 	}
 }
 
+func recordTeamMemberChange(ctx *context.Context, action audit_model.Action, uid int64) {
+	state := map[string]int64{"member_id": uid}
+	if action == audit_model.ActionTeamMemberRemove {
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), action, audit_service.TeamTarget(ctx.Org.Team), state, nil)
+	} else {
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), action, audit_service.TeamTarget(ctx.Org.Team), nil, state)
+	}
+}
+
 func checkIsOrgMemberAndRedirect(ctx *context.Context, defaultRedirect string) {
 	if isOrgMember, err := org_model.IsOrganizationMember(ctx, ctx.Org.Organization.ID, ctx.Doer.ID); err != nil {
 		ctx.ServerError("IsOrganizationMember", err)
@@ -247,13 +268,22 @@ func TeamsRepoAction(ctx *context.Context) {
 			ctx.ServerError("GetRepositoryByName", err)
 			return
 		}
-		err = org_service.TeamAddRepository(ctx, ctx.Org.Team, repo)
+		if err = org_service.TeamAddRepository(ctx, ctx.Org.Team, repo); err == nil {
+			audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamRepoAdd, audit_service.TeamRepoTarget(ctx.Org.Team, repo.ID), nil, nil)
+		}
 	case "remove":
-		err = repo_service.RemoveRepositoryFromTeam(ctx, ctx.Org.Team, ctx.FormInt64("repoid"))
+		repoID := ctx.FormInt64("repoid")
+		if err = repo_service.RemoveRepositoryFromTeam(ctx, ctx.Org.Team, repoID); err == nil {
+			audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamRepoRemove, audit_service.TeamRepoTarget(ctx.Org.Team, repoID), nil, nil)
+		}
 	case "addall":
-		err = models.AddAllRepositories(ctx, ctx.Org.Team)
+		if err = models.AddAllRepositories(ctx, ctx.Org.Team); err == nil {
+			audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamRepoAdd, audit_service.TeamTarget(ctx.Org.Team), nil, map[string]bool{"all": true})
+		}
 	case "removeall":
-		err = models.RemoveAllRepositories(ctx, ctx.Org.Team)
+		if err = models.RemoveAllRepositories(ctx, ctx.Org.Team); err == nil {
+			audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamRepoRemove, audit_service.TeamTarget(ctx.Org.Team), map[string]bool{"all": true}, nil)
+		}
 	}
 
 	if err != nil {
@@ -363,6 +393,7 @@ func NewTeamPost(ctx *context.Context) {
 		return
 	}
 	log.Trace("Team created: %s/%s", ctx.Org.Organization.Name, t.Name)
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamCreate, audit_service.TeamTarget(t), nil, audit_service.TeamState(t))
 	ctx.Redirect(ctx.Org.OrgLink + "/teams/" + url.PathEscape(t.LowerName))
 }
 
@@ -486,6 +517,7 @@ func EditTeamPost(ctx *context.Context) {
 	isAuthChanged := false
 	isIncludeAllChanged := false
 	includesAllRepositories := form.RepoAccess == "all"
+	before := audit_service.TeamState(t)
 
 	ctx.Data["Title"] = ctx.Org.Organization.FullName
 	ctx.Data["PageIsOrgTeams"] = true
@@ -541,6 +573,7 @@ func EditTeamPost(ctx *context.Context) {
 		}
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamUpdate, audit_service.TeamTarget(t), before, audit_service.TeamState(t))
 	ctx.Redirect(ctx.Org.OrgLink + "/teams/" + url.PathEscape(t.LowerName))
 }
 
@@ -549,6 +582,7 @@ func DeleteTeam(ctx *context.Context) {
 	if err := models.DeleteTeam(ctx, ctx.Org.Team); err != nil {
 		ctx.Flash.Error("DeleteTeam: " + err.Error())
 	} else {
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamDelete, audit_service.TeamTarget(ctx.Org.Team), audit_service.TeamState(ctx.Org.Team), nil)
 		ctx.Flash.Success(ctx.Tr("org.teams.delete_team_success"))
 	}
 
@@ -592,6 +626,7 @@ func TeamInvitePost(ctx *context.Context) {
 		ctx.ServerError("AddTeamMember", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamMemberAdd, audit_service.TeamTarget(team), nil, map[string]int64{"member_id": ctx.Doer.ID})
 
 	if err := org_model.RemoveInviteByID(ctx, invite.ID, team.ID); err != nil {
 		log.Error("RemoveInviteByID: %v", err)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 
+	issues_model "forgejo.org/models/issues"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/modules/httpcache"
@@ -120,6 +121,20 @@ func ServeAttachment(ctx *context.Context, uuid string) {
 			ctx.Error(http.StatusNotFound)
 			return
 		}
+		if attach.IssueID != 0 {
+			issue, err := issues_model.GetIssueByID(ctx, attach.IssueID)
+			if err != nil {
+				ctx.ServerError("GetIssueByID", err)
+				return
+			}
+			if visible, err := issue.IsVisibleTo(ctx, ctx.Doer, perm); err != nil {
+				ctx.ServerError("IsVisibleTo", err)
+				return
+			} else if !visible {
+				ctx.Error(http.StatusNotFound)
+				return
+			}
+		}
 	}
 
 	if attach.ExternalURL != "" {
@@ -20,6 +20,7 @@ import (
 
 	actions_model "forgejo.org/models/actions"
 	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
@@ -174,6 +175,19 @@ func httpBase(ctx *context.Context) *serviceHandler {
 			return nil
 		}
 
+		if owner.IsOrganization() {
+			org := organization.OrgFromUser(owner)
+			violations, err := context.CheckOrgSecurityPolicy(ctx, org, ctx.Doer, ctx.RemoteAddr())
+			if err != nil {
+				ctx.ServerError("CheckOrgSecurityPolicy", err)
+				return nil
+			}
+			if violations != nil {
+				ctx.PlainText(http.StatusForbidden, context.OrgSecurityPolicyMessage(org, violations))
+				return nil
+			}
+		}
+
 		environ = []string{
 			repo_module.EnvRepoUsername + "=" + username,
 			repo_module.EnvRepoName + "=" + reponame,
@@ -197,6 +197,12 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		keyword = ""
 	}
 
+	customFieldFilters, customFieldQuery, err := parseCustomFieldFilters(ctx)
+	if err != nil {
+		ctx.ServerError("parseCustomFieldFilters", err)
+		return
+	}
+
 	var mileIDs []int64
 	if milestoneID > 0 || milestoneID == db.NoConditionID { // -1 to get those issues which have no any milestone assigned
 		mileIDs = []int64{milestoneID}
@@ -215,6 +221,8 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		ReviewedID:        reviewedID,
 		IsPull:            isPullOption,
 		IssueIDs:          nil,
+		CustomFields:      customFieldFilters,
+		Confidential:      confidentialFilter(ctx),
 	}
 	if keyword != "" {
 		allIssueIDs, _, err := issueIDsFromSearch(ctx, keyword, statsOpts)
@@ -301,6 +309,8 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 			IsClosed:          isShowClosed,
 			IsPull:            isPullOption,
 			LabelIDs:          labelIDs,
+			CustomFields:      customFieldFilters,
+			Confidential:      confidentialFilter(ctx),
 			SortType:          sortType,
 		})
 		if err != nil {
@@ -446,9 +456,16 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 		return
 	}
 
+	sortCustomFields, err := db.Find[issues_model.CustomField](ctx, issues_model.FindCustomFieldOptions{RepoID: repo.ID})
+	if err != nil {
+		ctx.ServerError("FindCustomFields", err)
+		return
+	}
+
 	cleanedKeyword := util.RemoveAllStr(keyword, false, "is:open", "-is:open", "is:closed", "-is:closed", "is:all")
 
 	ctx.Data["PinnedIssues"] = pinned
+	ctx.Data["SortCustomFields"] = sortCustomFields
 	ctx.Data["IsRepoAdmin"] = ctx.IsSigned && (ctx.Repo.IsAdmin() || ctx.Doer.IsAdmin)
 	ctx.Data["IssueStats"] = issueStats
 	ctx.Data["OpenCount"] = issueStats.OpenCount
@@ -457,13 +474,14 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 	linkStr := "?q=%s&type=%s&sort=%s&state=%s&labels=%s&milestone=%d&project=%d&assignee=%d&poster=%d&archived=%t"
 	ctx.Data["AllStatesLink"] = fmt.Sprintf(linkStr,
 		url.QueryEscape(cleanedKeyword), url.QueryEscape(viewType), url.QueryEscape(sortType), "all", url.QueryEscape(selectLabels),
-		milestoneID, projectID, assigneeID, posterID, archived)
+		milestoneID, projectID, assigneeID, posterID, archived) + customFieldQuery
 	ctx.Data["OpenLink"] = fmt.Sprintf(linkStr,
 		url.QueryEscape(cleanedKeyword), url.QueryEscape(viewType), url.QueryEscape(sortType), "open", url.QueryEscape(selectLabels),
-		milestoneID, projectID, assigneeID, posterID, archived)
+		milestoneID, projectID, assigneeID, posterID, archived) + customFieldQuery
 	ctx.Data["ClosedLink"] = fmt.Sprintf(linkStr,
 		url.QueryEscape(cleanedKeyword), url.QueryEscape(viewType), url.QueryEscape(sortType), "closed", url.QueryEscape(selectLabels),
-		milestoneID, projectID, assigneeID, posterID, archived)
+		milestoneID, projectID, assigneeID, posterID, archived) + customFieldQuery
+	ctx.Data["CustomFieldQuery"] = template.URL(customFieldQuery)
 	ctx.Data["SelLabelIDs"] = labelIDs
 	ctx.Data["SelectLabels"] = selectLabels
 	ctx.Data["ViewType"] = viewType
@@ -494,16 +512,32 @@ func issues(ctx *context.Context, milestoneID, projectID int64, isPullOption opt
 	pager.AddParam(ctx, "assignee", "AssigneeID")
 	pager.AddParam(ctx, "poster", "PosterID")
 	pager.AddParam(ctx, "archived", "ShowArchivedLabels")
+	for _, filter := range ctx.FormStrings("custom_field") {
+		pager.AddParamString("custom_field", filter)
+	}
 
 	ctx.Data["Page"] = pager
 }
 
+// confidentialFilter returns the filter of the confidential issues of the repository that the doer can see,
+// nil if the doer can see all of them
+func confidentialFilter(ctx *context.Context) *issues_model.ConfidentialFilter {
+	if ctx.Repo.CanWrite(unit.TypeIssues) {
+		return nil
+	}
+	filter := &issues_model.ConfidentialFilter{}
+	if ctx.Doer != nil {
+		filter.UserID = ctx.Doer.ID
+	}
+	return filter
+}
+
 func issueIDsFromSearch(
 	ctx *context.Context,
 	keyword string,
 	opts *issues_model.IssuesOptions,
 ) ([]int64, *issue_indexer.SearchOptions, error) {
-	searchOpts := issue_indexer.ToSearchOptions(ctx, keyword, opts)
+	searchOpts := issue_indexer.ToSearchOptions(ctx, ctx.Doer, keyword, opts)
 	ids, _, err := issue_indexer.SearchIssues(ctx, searchOpts)
 	if err != nil {
 		return nil, searchOpts, fmt.Errorf("SearchIssues: %w", err)
@@ -542,6 +576,8 @@ func Issues(ctx *context.Context) {
 	}
 
 	ctx.Data["CanWriteIssuesOrPulls"] = ctx.Repo.CanWriteIssuesOrPulls(isPullList)
+	ctx.Data["IssueExportLink"] = issueExportLink(ctx, isPullList)
+	ctx.Data["CanImportIssues"] = !isPullList && !ctx.Repo.Repository.IsArchived && ctx.Repo.CanWrite(unit.TypeIssues)
 
 	ctx.HTML(http.StatusOK, tplIssues)
 }
@@ -919,8 +955,8 @@ func setTemplateIfExists(ctx *context.Context, ctxDataKey string, possibleFiles
 			}
 
 			ctx.Data["Fields"] = template.Fields
-			ctx.Data["TemplateFile"] = template.FileName
 		}
+		ctx.Data["TemplateFile"] = template.FileName
 		labelIDs := make([]string, 0, len(template.Labels))
 		if repoLabels, err := issues_model.GetLabelsByRepoID(ctx, ctx.Repo.Repository.ID, "", db.ListOptions{}); err == nil {
 			ctx.Data["Labels"] = repoLabels
@@ -945,6 +981,23 @@ func setTemplateIfExists(ctx *context.Context, ctxDataKey string, possibleFiles
 		if template.Ref != "" && !strings.HasPrefix(template.Ref, "refs/") { // Assume that the ref intended is always a branch - for tags users should use refs/tags/<ref>
 			template.Ref = git.BranchPrefix + template.Ref
 		}
+		// the milestone and the project given in the query take precedence over the ones of the template
+		if metas, err := issue_service.GetTemplateMetas(ctx, ctx.Repo.Repository, template, ctxDataKey == pullRequestTemplateKey); err != nil {
+			log.Error("GetTemplateMetas: %v", err)
+		} else {
+			if _, ok := ctx.Data["milestone_id"]; !ok && metas.MilestoneID > 0 {
+				if milestone, err := issues_model.GetMilestoneByRepoID(ctx, ctx.Repo.Repository.ID, metas.MilestoneID); err == nil {
+					ctx.Data["Milestone"] = milestone
+					ctx.Data["milestone_id"] = milestone.ID
+				}
+			}
+			if _, ok := ctx.Data["project_id"]; !ok && metas.ProjectID > 0 && ctx.Repo.CanRead(unit.TypeProjects) {
+				if project, err := project_model.GetProjectByID(ctx, metas.ProjectID); err == nil {
+					ctx.Data["Project"] = project
+					ctx.Data["project_id"] = project.ID
+				}
+			}
+		}
 		ctx.Data["HasSelectedLabel"] = len(labelIDs) > 0
 		ctx.Data["label_ids"] = strings.Join(labelIDs, ",")
 		ctx.Data["Reference"] = template.Ref
@@ -954,6 +1007,43 @@ func setTemplateIfExists(ctx *context.Context, ctxDataKey string, possibleFiles
 	return false, templateErrs
 }
 
+// getSubmittedTemplate returns the template an issue is created from, and writes an error if the answers
+// to its issue form are invalid
+func getSubmittedTemplate(ctx *context.Context) *api.IssueTemplate {
+	filename := ctx.Req.Form.Get("template-file")
+	if filename == "" {
+		return nil
+	}
+	template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename)
+	if err != nil {
+		log.Debug("UnmarshalFromRepo: %s: %v", filename, err)
+		return nil
+	}
+	if err := issue_service.ValidateTemplateForm(template, ctx.Req.Form); err != nil {
+		ctx.JSONError(ctx.Tr("repo.issues.new.invalid_form", err.Error()))
+		return nil
+	}
+	return template
+}
+
+// applySubmittedTemplate renders the content of an issue from the submitted issue form and assigns it to the
+// assignees of the template if none were chosen. The labels, the milestone and the project of the template are
+// already selected in the sidebar of the form, the user may have removed them.
+func applySubmittedTemplate(ctx *context.Context, template *api.IssueTemplate, content *string, assigneeIDs *[]int64, isPull bool) {
+	if template.Type() == api.IssueTemplateTypeYaml {
+		*content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
+	}
+	if len(*assigneeIDs) > 0 || len(template.Assignees) == 0 {
+		return
+	}
+	metas, err := issue_service.GetTemplateMetas(ctx, ctx.Repo.Repository, template, isPull)
+	if err != nil {
+		ctx.ServerError("GetTemplateMetas", err)
+		return
+	}
+	*assigneeIDs = metas.AssigneeIDs
+}
+
 // NewIssue render creating issue page
 func NewIssue(ctx *context.Context) {
 	issueConfig, _ := issue_service.GetTemplateConfigFromDefaultBranch(ctx.Repo.Repository, ctx.Repo.GitRepo)
@@ -1244,21 +1334,25 @@ func NewIssuePost(ctx *context.Context) {
 	}
 
 	content := form.Content
-	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
-		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
-			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
+	if template := getSubmittedTemplate(ctx); ctx.Written() {
+		return
+	} else if template != nil {
+		applySubmittedTemplate(ctx, template, &content, &assigneeIDs, false)
+		if ctx.Written() {
+			return
 		}
 	}
 
 	issue := &issues_model.Issue{
-		RepoID:      repo.ID,
-		Repo:        repo,
-		Title:       form.Title,
-		PosterID:    ctx.Doer.ID,
-		Poster:      ctx.Doer,
-		MilestoneID: milestoneID,
-		Content:     content,
-		Ref:         form.Ref,
+		RepoID:         repo.ID,
+		Repo:           repo,
+		Title:          form.Title,
+		PosterID:       ctx.Doer.ID,
+		Poster:         ctx.Doer,
+		MilestoneID:    milestoneID,
+		Content:        content,
+		Ref:            form.Ref,
+		IsConfidential: form.IsConfidential,
 	}
 
 	if err := issue_service.NewIssue(ctx, repo, issue, labelIDs, attachments, assigneeIDs); err != nil {
@@ -1454,6 +1548,14 @@ func ViewIssue(ctx *context.Context) {
 		return
 	}
 
+	if visible, err := issue.IsVisibleTo(ctx, ctx.Doer, ctx.Repo.Permission); err != nil {
+		ctx.ServerError("IsVisibleTo", err)
+		return
+	} else if !visible {
+		ctx.NotFound("ViewIssue", nil)
+		return
+	}
+
 	if issue.IsPull {
 		MustAllowPulls(ctx)
 		if ctx.Written() {
@@ -1506,6 +1608,44 @@ func ViewIssue(ctx *context.Context) {
 		}
 	}
 	ctx.Data["IssueWatch"] = iw
+
+	customFields, err := issues_model.GetIssueCustomFields(ctx, issue)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFields", err)
+		return
+	}
+	customFieldValues, err := issues_model.GetIssueCustomFieldValues(ctx, []int64{issue.ID})
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldValues", err)
+		return
+	}
+	displayValues := make(map[int64]string, len(customFieldValues[issue.ID]))
+	for _, v := range customFieldValues[issue.ID] {
+		displayValues[v.FieldID] = v.DisplayValue()
+	}
+	ctx.Data["CustomFields"] = customFields
+	ctx.Data["CustomFieldValues"] = displayValues
+
+	if !issue.IsPull {
+		parentIssue, err := issue_service.GetVisibleParentIssue(ctx, ctx.Doer, issue)
+		if err != nil {
+			ctx.ServerError("GetVisibleParentIssue", err)
+			return
+		}
+		subIssues, err := issue_service.GetVisibleSubIssues(ctx, ctx.Doer, issue)
+		if err != nil {
+			ctx.ServerError("GetVisibleSubIssues", err)
+			return
+		}
+		subIssueProgress, err := issues_model.GetSubIssueProgress(ctx, subIssues)
+		if err != nil {
+			ctx.ServerError("GetSubIssueProgress", err)
+			return
+		}
+		ctx.Data["ParentIssue"] = parentIssue
+		ctx.Data["SubIssues"] = subIssues
+		ctx.Data["SubIssueProgress"] = subIssueProgress
+	}
 	issue.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 		Links: markup.Links{
 			Base: ctx.Repo.RepoLink,
@@ -2064,6 +2204,15 @@ func ViewIssue(ctx *context.Context) {
 		pinAllowed = true
 	}
 
+	if issue.IsConfidential && !issue.IsPull {
+		_, isServiceDeskIssue, err := issues_model.GetServiceDeskContact(ctx, issue.ID)
+		if err != nil {
+			ctx.ServerError("GetServiceDeskContact", err)
+			return
+		}
+		ctx.Data["IsServiceDeskIssue"] = isServiceDeskIssue
+	}
+
 	ctx.Data["Participants"] = participants
 	ctx.Data["NumParticipants"] = len(participants)
 	ctx.Data["Issue"] = issue
@@ -2126,7 +2275,12 @@ func checkBlockedByIssues(ctx *context.Context, blockers []*issues_model.Depende
 			}
 			repoPerms[blocker.RepoID] = perm
 		}
-		if perm.CanReadIssuesOrPulls(blocker.IsPull) {
+		visible, err := blocker.Issue.IsVisibleTo(ctx, ctx.Doer, perm)
+		if err != nil {
+			ctx.ServerError("IsVisibleTo", err)
+			return nil, nil
+		}
+		if visible {
 			canRead = append(canRead, blocker)
 		} else {
 			notPermitted = append(notPermitted, blocker)
@@ -2166,8 +2320,10 @@ func GetActionIssue(ctx *context.Context) *issues_model.Issue {
 }
 
 func checkIssueRights(ctx *context.Context, issue *issues_model.Issue) {
-	if issue.IsPull && !ctx.Repo.CanRead(unit.TypePullRequests) ||
-		!issue.IsPull && !ctx.Repo.CanRead(unit.TypeIssues) {
+	visible, err := issue.IsVisibleTo(ctx, ctx.Doer, ctx.Repo.Permission)
+	if err != nil {
+		ctx.ServerError("IsVisibleTo", err)
+	} else if !visible {
 		ctx.NotFound("IssueOrPullRequestUnitNotAllowed", nil)
 	}
 }
@@ -2192,15 +2348,13 @@ func getActionIssues(ctx *context.Context) issues_model.IssueList {
 		return nil
 	}
 	// Check access rights for all issues
-	issueUnitEnabled := ctx.Repo.CanRead(unit.TypeIssues)
-	prUnitEnabled := ctx.Repo.CanRead(unit.TypePullRequests)
 	for _, issue := range issues {
 		if issue.RepoID != ctx.Repo.Repository.ID {
 			ctx.NotFound("some issue's RepoID is incorrect", errors.New("some issue's RepoID is incorrect"))
 			return nil
 		}
-		if issue.IsPull && !prUnitEnabled || !issue.IsPull && !issueUnitEnabled {
-			ctx.NotFound("IssueOrPullRequestUnitNotAllowed", nil)
+		checkIssueRights(ctx, issue)
+		if ctx.Written() {
 			return nil
 		}
 		if err = issue.LoadAttributes(ctx); err != nil {
@@ -2236,6 +2390,13 @@ func GetIssueInfo(ctx *context.Context) {
 			return
 		}
 	}
+	if visible, err := issue.IsVisibleTo(ctx, ctx.Doer, ctx.Repo.Permission); err != nil {
+		ctx.Error(http.StatusInternalServerError, "IsVisibleTo", err.Error())
+		return
+	} else if !visible {
+		ctx.Error(http.StatusNotFound)
+		return
+	}
 
 	ctx.JSON(http.StatusOK, convert.ToIssue(ctx, ctx.Doer, issue))
 }
@@ -2782,6 +2943,9 @@ func SearchIssues(ctx *context.Context) {
 		ProjectID:           projectID,
 		SortBy:              issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
 	}
+	if ctx.Doer != nil {
+		searchOpt.DoerID = ctx.Doer.ID
+	}
 	if err := searchOpt.WithKeyword(ctx, keyword); err != nil {
 		log.Error("WithKeyword: %v", err)
 		ctx.Error(http.StatusInternalServerError)
@@ -2956,6 +3120,9 @@ func ListIssues(ctx *context.Context) {
 		ProjectID: projectID,
 		SortBy:    issue_indexer.ParseSortBy(ctx.FormString("sort"), issue_indexer.SortByCreatedDesc),
 	}
+	if ctx.Doer != nil {
+		searchOpt.DoerID = ctx.Doer.ID
+	}
 	if err := searchOpt.WithKeyword(ctx, keyword); err != nil {
 		log.Error("WithKeyword: %v", err)
 		ctx.Error(http.StatusInternalServerError)
@@ -3279,6 +3446,10 @@ func UpdateCommentContent(ctx *context.Context) {
 		ctx.NotFound("CompareRepoID", issues_model.ErrCommentNotExist{})
 		return
 	}
+	checkIssueRights(ctx, comment.Issue)
+	if ctx.Written() {
+		return
+	}
 
 	if !ctx.IsSigned || (ctx.Doer.ID != comment.PosterID && !ctx.Repo.CanWriteIssuesOrPulls(comment.Issue.IsPull)) {
 		ctx.Error(http.StatusForbidden)
@@ -3354,6 +3525,10 @@ func DeleteComment(ctx *context.Context) {
 		ctx.NotFound("CompareRepoID", issues_model.ErrCommentNotExist{})
 		return
 	}
+	checkIssueRights(ctx, comment.Issue)
+	if ctx.Written() {
+		return
+	}
 
 	if !ctx.IsSigned || (ctx.Doer.ID != comment.PosterID && !ctx.Repo.CanWriteIssuesOrPulls(comment.Issue.IsPull)) {
 		ctx.Error(http.StatusForbidden)
@@ -3479,6 +3654,10 @@ func ChangeCommentReaction(ctx *context.Context) {
 		ctx.NotFound("CompareRepoID", issues_model.ErrCommentNotExist{})
 		return
 	}
+	checkIssueRights(ctx, comment.Issue)
+	if ctx.Written() {
+		return
+	}
 
 	if !ctx.IsSigned || (ctx.Doer.ID != comment.PosterID && !ctx.Repo.CanReadIssuesOrPulls(comment.Issue.IsPull)) {
 		if log.IsTrace() {
@@ -3591,6 +3770,14 @@ func filterXRefComments(ctx *context.Context, issue *issues_model.Issue) error {
 				continue
 			}
 		}
+		visible, err := c.IsRefIssueVisibleTo(ctx, ctx.Doer)
+		if err != nil {
+			return err
+		}
+		if !visible {
+			issue.Comments = append(issue.Comments[:i], issue.Comments[i+1:]...)
+			continue
+		}
 		i++
 	}
 	return nil
@@ -3626,6 +3813,10 @@ func GetCommentAttachments(ctx *context.Context) {
 		ctx.NotFound("CompareRepoID", issues_model.ErrCommentNotExist{})
 		return
 	}
+	checkIssueRights(ctx, comment.Issue)
+	if ctx.Written() {
+		return
+	}
 
 	if !ctx.Repo.CanReadIssuesOrPulls(comment.Issue.IsPull) {
 		ctx.NotFound("CanReadIssuesOrPulls", issues_model.ErrCommentNotExist{})
@@ -0,0 +1,35 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// UpdateIssueConfidential changes whether an issue is only visible to its poster,
+// its assignees and the users with write access
+func UpdateIssueConfidential(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if issue.IsPull {
+		ctx.NotFound("UpdateIssueConfidential", nil)
+		return
+	}
+
+	if err := issue_service.ChangeConfidential(ctx, ctx.Doer, issue, ctx.FormBool("confidential")); err != nil {
+		if errors.Is(err, issue_service.ErrServiceDeskIssueConfidential) {
+			ctx.JSONError(ctx.Tr("repo.issues.confidential.service_desk"))
+			return
+		}
+		ctx.ServerError("ChangeConfidential", err)
+		return
+	}
+
+	ctx.JSONRedirect(issue.Link())
+}
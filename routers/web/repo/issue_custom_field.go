@@ -0,0 +1,166 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	issue_service "forgejo.org/services/issue"
+)
+
+const (
+	tplCustomFields base.TplName = "repo/issue/custom_fields"
+)
+
+// CustomFields renders the custom fields of the issues of a repository
+func CustomFields(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.issues.custom_fields")
+	ctx.Data["PageIsIssueList"] = true
+	ctx.Data["PageIsCustomFields"] = true
+
+	fields, err := db.Find[issues_model.CustomField](ctx, issues_model.FindCustomFieldOptions{RepoID: ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.ServerError("FindCustomFields", err)
+		return
+	}
+	ctx.Data["CustomFields"] = fields
+	ctx.Data["CustomFieldTypes"] = issues_model.CustomFieldTypes
+	ctx.Data["CustomFieldsLink"] = ctx.Repo.RepoLink + "/custom_fields"
+	ctx.Data["CanEditCustomFields"] = (ctx.Repo.CanWriteIssuesOrPulls(false) || ctx.Repo.CanWriteIssuesOrPulls(true)) && !ctx.Repo.Repository.IsArchived
+	ctx.HTML(http.StatusOK, tplCustomFields)
+}
+
+// NewCustomField creates a custom field for the issues of a repository
+func NewCustomField(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CustomFieldForm)
+	link := ctx.Repo.RepoLink + "/custom_fields"
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.Data["ErrorMsg"].(string))
+		ctx.Redirect(link)
+		return
+	}
+
+	field := &issues_model.CustomField{
+		RepoID:  ctx.Repo.Repository.ID,
+		Name:    form.Name,
+		Type:    issues_model.CustomFieldType(form.Type),
+		Options: form.OptionList(),
+		Sorting: form.Sorting,
+	}
+	if err := issues_model.NewCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("repo.issues.custom_field_invalid", err.Error()))
+			ctx.Redirect(link)
+			return
+		}
+		ctx.ServerError("NewCustomField", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.issues.custom_field_created", field.Name))
+	ctx.Redirect(link)
+}
+
+// EditCustomField updates a custom field of a repository
+func EditCustomField(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.CustomFieldForm)
+	link := ctx.Repo.RepoLink + "/custom_fields"
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.Data["ErrorMsg"].(string))
+		ctx.Redirect(link)
+		return
+	}
+
+	field, err := issues_model.GetCustomFieldInRepoByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetCustomFieldInRepoByID", issues_model.IsErrCustomFieldNotExist, err)
+		return
+	}
+	field.Name = form.Name
+	field.Options = form.OptionList()
+	field.Sorting = form.Sorting
+	if err := issues_model.UpdateCustomField(ctx, field); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("repo.issues.custom_field_invalid", err.Error()))
+			ctx.Redirect(link)
+			return
+		}
+		ctx.ServerError("UpdateCustomField", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.issues.custom_field_updated", field.Name))
+	ctx.Redirect(link)
+}
+
+// DeleteCustomField deletes a custom field of a repository and its values
+func DeleteCustomField(ctx *context.Context) {
+	field, err := issues_model.GetCustomFieldInRepoByID(ctx, ctx.Repo.Repository.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetCustomFieldInRepoByID", issues_model.IsErrCustomFieldNotExist, err)
+		return
+	}
+	if err := issues_model.DeleteCustomField(ctx, field); err != nil {
+		ctx.ServerError("DeleteCustomField", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("repo.issues.custom_field_deleted", field.Name))
+	ctx.Redirect(ctx.Repo.RepoLink + "/custom_fields")
+}
+
+// UpdateIssueCustomFieldValue sets the value of a custom field of an issue
+func UpdateIssueCustomFieldValue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if !ctx.IsSigned || !ctx.Repo.CanWriteIssuesOrPulls(issue.IsPull) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	if err := issue_service.SetCustomFieldValue(ctx, ctx.Doer, issue, ctx.ParamsInt64(":id"), ctx.FormString("value")); err != nil {
+		switch {
+		case issues_model.IsErrCustomFieldNotExist(err):
+			ctx.NotFound("SetCustomFieldValue", err)
+			return
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("repo.issues.custom_field_invalid", err.Error()))
+		default:
+			ctx.ServerError("SetCustomFieldValue", err)
+			return
+		}
+	}
+	ctx.RedirectToFirst(ctx.FormString("redirect_to"), issue.Link())
+}
+
+// parseCustomFieldFilters parses the custom_field query parameters of an issue list, formatted
+// as "<field id>:<value>", and returns them with the query string to keep them in links
+func parseCustomFieldFilters(ctx *context.Context) ([]issues_model.CustomFieldFilter, string, error) {
+	var (
+		filters []issues_model.CustomFieldFilter
+		query   string
+	)
+	for _, s := range ctx.FormStrings("custom_field") {
+		filter, ok := issues_model.ParseCustomFieldFilter(s)
+		if !ok {
+			ctx.Flash.Error(ctx.Tr("invalid_data", s), true)
+			continue
+		}
+		filters = append(filters, filter)
+		query += "&custom_field=" + url.QueryEscape(s)
+	}
+	if len(filters) == 0 {
+		return nil, "", nil
+	}
+	filters, err := issues_model.NormalizeCustomFieldFilters(ctx, filters)
+	return filters, query, err
+}
@@ -0,0 +1,241 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unit"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	"forgejo.org/services/migrations"
+)
+
+const (
+	tplIssueImport base.TplName = "repo/issue/import"
+
+	// maxIssueImportSize is the maximum size of the files issues are imported from
+	maxIssueImportSize = 10 << 20
+)
+
+// issueExportLink returns the link exporting the issues of the current search of the issue list
+func issueExportLink(ctx *context.Context, isPull bool) string {
+	query := ctx.Req.URL.Query()
+	query.Del("page")
+	if state, ok := ctx.Data["State"].(string); ok {
+		query.Set("state", state)
+	}
+	return fmt.Sprintf("%s/%s/export?%s", ctx.Repo.RepoLink, util.Iif(isPull, "pulls", "issues"), query.Encode())
+}
+
+// ExportIssues exports the issues or the pull requests of a search of the issue list to a CSV or JSON file
+func ExportIssues(isPull bool) func(ctx *context.Context) {
+	return func(ctx *context.Context) {
+		if !ctx.Repo.CanRead(util.Iif(isPull, unit.TypePullRequests, unit.TypeIssues)) {
+			ctx.NotFound("ExportIssues", nil)
+			return
+		}
+		format := migrations.IssueExchangeFormat(ctx.FormString("format"))
+		if format == "" {
+			format = migrations.IssueExchangeFormatCSV
+		}
+		if !format.IsValid() {
+			ctx.Error(http.StatusBadRequest, "unknown format")
+			return
+		}
+
+		ids, sortType, err := exportIssueIDs(ctx, isPull)
+		if err != nil {
+			ctx.ServerError("exportIssueIDs", err)
+			return
+		}
+
+		var buf bytes.Buffer
+		if len(ids) == 0 {
+			err = migrations.WriteExchangeIssues(&buf, format, []*migrations.ExchangeIssue{})
+		} else {
+			err = migrations.ExportIssues(ctx, &buf, format, &issues_model.IssuesOptions{
+				RepoIDs:  []int64{ctx.Repo.Repository.ID},
+				IssueIDs: ids,
+				SortType: sortType,
+			}, ctx.FormBool("comments"))
+		}
+		if err != nil {
+			ctx.ServerError("ExportIssues", err)
+			return
+		}
+
+		ctx.ServeContent(bytes.NewReader(buf.Bytes()), &context.ServeHeaderOptions{
+			Filename:    fmt.Sprintf("%s-%s.%s", ctx.Repo.Repository.Name, util.Iif(isPull, "pulls", "issues"), format),
+			ContentType: util.Iif(format == migrations.IssueExchangeFormatJSON, "application/json", "text/csv"),
+		})
+	}
+}
+
+// exportIssueIDs returns the IDs of the issues matching the filters of the issue list, and their sort type
+func exportIssueIDs(ctx *context.Context, isPull bool) ([]int64, string, error) {
+	opts := &issues_model.IssuesOptions{
+		RepoIDs:      []int64{ctx.Repo.Repository.ID},
+		IsPull:       optional.Some(isPull),
+		AssigneeID:   ctx.FormInt64("assignee"),
+		PosterID:     ctx.FormInt64("poster"),
+		ProjectID:    ctx.FormInt64("project"),
+		Confidential: confidentialFilter(ctx),
+		SortType:     ctx.FormString("sort"),
+	}
+	if ctx.IsSigned {
+		switch ctx.FormString("type") {
+		case "created_by":
+			opts.PosterID = ctx.Doer.ID
+		case "mentioned":
+			opts.MentionedID = ctx.Doer.ID
+		case "assigned":
+			opts.AssigneeID = ctx.Doer.ID
+		case "review_requested":
+			opts.ReviewRequestedID = ctx.Doer.ID
+		case "reviewed_by":
+			opts.ReviewedID = ctx.Doer.ID
+		}
+	}
+	if milestoneID := ctx.FormInt64("milestone"); milestoneID > 0 || milestoneID == db.NoConditionID {
+		opts.MilestoneIDs = []int64{milestoneID}
+	}
+	if labels := ctx.FormString("labels"); labels != "" {
+		labelIDs, err := base.StringsToInt64s(strings.Split(labels, ","))
+		if err != nil {
+			return nil, "", err
+		}
+		opts.LabelIDs = labelIDs
+	}
+	switch ctx.FormString("state") {
+	case "closed":
+		opts.IsClosed = optional.Some(true)
+	case "all":
+	default:
+		opts.IsClosed = optional.Some(false)
+	}
+	customFieldFilters, _, err := parseCustomFieldFilters(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	opts.CustomFields = customFieldFilters
+
+	const pageSize = 50
+	keyword := strings.TrimSpace(ctx.FormString("q"))
+	var ids []int64
+	for page := 1; ; page++ {
+		opts.Paginator = &db.ListOptions{Page: page, PageSize: pageSize}
+		pageIDs, searchOpts, err := issueIDsFromSearch(ctx, keyword, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		ids = append(ids, pageIDs...)
+		if len(pageIDs) < pageSize {
+			return ids, searchOpts.SortBy.ToIssueSort(), nil
+		}
+	}
+}
+
+// ImportIssues renders the page to import issues from a CSV or JSON file
+func ImportIssues(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.issues.import")
+	ctx.Data["PageIsIssueList"] = true
+	ctx.Data["DryRun"] = true
+
+	ctx.HTML(http.StatusOK, tplIssueImport)
+}
+
+// ImportIssuesPost imports issues from a CSV or JSON file, or previews the changes of the import in a dry run
+func ImportIssuesPost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ImportIssuesForm)
+	ctx.Data["Title"] = ctx.Tr("repo.issues.import")
+	ctx.Data["PageIsIssueList"] = true
+	ctx.Data["DryRun"] = form.DryRun
+
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplIssueImport)
+		return
+	}
+
+	content, format, err := readIssueImport(form)
+	if err == nil && len(content) > maxIssueImportSize {
+		err = util.NewInvalidArgumentErrorf("the file is larger than %d bytes", maxIssueImportSize)
+	}
+	var records []*migrations.ExchangeIssue
+	if err == nil {
+		records, err = migrations.ReadExchangeIssues(strings.NewReader(content), format)
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.RenderWithErr(ctx.Tr("repo.issues.import.invalid", err.Error()), tplIssueImport, form)
+			return
+		}
+		ctx.ServerError("readIssueImport", err)
+		return
+	}
+
+	results, err := migrations.ImportIssues(ctx, ctx.Doer, ctx.Repo.Repository, records, form.DryRun)
+	if err != nil {
+		ctx.ServerError("ImportIssues", err)
+		return
+	}
+
+	if form.DryRun || results.HasErrors() {
+		ctx.Data["ImportResults"] = results
+		ctx.Data["ImportHasErrors"] = results.HasErrors()
+		ctx.Data["ImportContent"] = content
+		ctx.Data["ImportFormat"] = format
+		ctx.HTML(http.StatusOK, tplIssueImport)
+		return
+	}
+
+	created, updated := results.Counts()
+	ctx.Flash.Success(ctx.Tr("repo.issues.import.success", created, updated))
+	ctx.Redirect(ctx.Repo.RepoLink + "/issues?state=all")
+}
+
+// readIssueImport returns the content of the uploaded file, or of the file confirmed after a dry run, and its format
+func readIssueImport(form *forms.ImportIssuesForm) (string, migrations.IssueExchangeFormat, error) {
+	content, filename := form.Content, ""
+	if form.File != nil {
+		f, err := form.File.Open()
+		if err != nil {
+			return "", "", err
+		}
+		defer f.Close()
+		b, err := io.ReadAll(io.LimitReader(f, maxIssueImportSize+1))
+		if err != nil {
+			return "", "", err
+		}
+		content, filename = string(b), form.File.Filename
+	}
+	if strings.TrimSpace(content) == "" {
+		return "", "", util.NewInvalidArgumentErrorf("the file is empty")
+	}
+
+	format := migrations.IssueExchangeFormat(form.Format)
+	if format == "" {
+		switch {
+		case strings.EqualFold(path.Ext(filename), ".json"):
+			format = migrations.IssueExchangeFormatJSON
+		case filename == "" && strings.HasPrefix(strings.TrimSpace(content), "["):
+			format = migrations.IssueExchangeFormatJSON
+		default:
+			format = migrations.IssueExchangeFormatCSV
+		}
+	}
+	return content, format, nil
+}
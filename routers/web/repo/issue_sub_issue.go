@@ -0,0 +1,122 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/util"
+	"forgejo.org/services/context"
+	issue_service "forgejo.org/services/issue"
+)
+
+// getSubIssueByReference returns the issue referenced by "#<index>" in the repository
+// or by "<owner>/<repo>#<index>", nil if there is no such issue the doer can see
+func getSubIssueByReference(ctx *context.Context, ref string) (*issues_model.Issue, error) {
+	repoRef, indexStr, ok := strings.Cut(strings.TrimSpace(ref), "#")
+	if !ok {
+		return nil, nil
+	}
+	index, err := strconv.ParseInt(indexStr, 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+
+	repo := ctx.Repo.Repository
+	if repoRef != "" {
+		ownerName, repoName, ok := strings.Cut(repoRef, "/")
+		if !ok {
+			return nil, nil
+		}
+		repo, err = repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if repo_model.IsErrRepoNotExist(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	issue, err := issues_model.GetIssueByIndex(ctx, repo.ID, index)
+	if issues_model.IsErrIssueNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	issue.Repo = repo
+
+	perm, err := access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
+	if err != nil {
+		return nil, err
+	}
+	if visible, err := issue.IsVisibleTo(ctx, ctx.Doer, perm); err != nil || !visible {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// AddSubIssue makes the issue given by reference a sub-issue of the issue
+func AddSubIssue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if issue.IsPull || !ctx.Repo.CanWriteIssuesOrPulls(false) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	defer ctx.Redirect(issue.Link())
+
+	subIssue, err := getSubIssueByReference(ctx, ctx.FormString("sub_issue"))
+	if err != nil {
+		ctx.ServerError("getSubIssueByReference", err)
+		return
+	}
+	if subIssue == nil {
+		ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.not_exist"))
+		return
+	}
+
+	if err := issue_service.AddSubIssue(ctx, ctx.Doer, issue, subIssue); err != nil {
+		switch {
+		case issues_model.IsErrSubIssueHasParent(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.has_parent"))
+		case issues_model.IsErrCircularSubIssue(err):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.circular"))
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.Flash.Error(ctx.Tr("repo.issues.sub_issues.invalid", err.Error()))
+		default:
+			ctx.ServerError("AddSubIssue", err)
+		}
+	}
+}
+
+// RemoveSubIssue removes an issue from the sub-issues of the issue
+func RemoveSubIssue(ctx *context.Context) {
+	issue := GetActionIssue(ctx)
+	if ctx.Written() {
+		return
+	}
+	if issue.IsPull || !ctx.Repo.CanWriteIssuesOrPulls(false) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
+	subIssue, err := issues_model.GetIssueByID(ctx, ctx.FormInt64("sub_issue_id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetIssueByID", issues_model.IsErrIssueNotExist, err)
+		return
+	}
+	if err := issue_service.RemoveSubIssue(ctx, ctx.Doer, issue, subIssue); err != nil {
+		ctx.NotFoundOrServerError("RemoveSubIssue", issues_model.IsErrSubIssueNotExist, err)
+		return
+	}
+	ctx.Redirect(issue.Link())
+}
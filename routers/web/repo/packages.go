@@ -27,20 +27,31 @@ func Packages(ctx *context.Context) {
 	}
 	query := ctx.FormTrim("q")
 	packageType := ctx.FormTrim("type")
+	component := packages.ComponentSearchValue{
+		Name:    ctx.FormTrim("component"),
+		Version: ctx.FormTrim("component_version"),
+	}
+
+	search := packages.SearchLatestVersions
+	if component.Name != "" {
+		// all affected versions are of interest when looking for a component
+		search = packages.SearchVersions
+	}
 
-	pvs, total, err := packages.SearchLatestVersions(ctx, &packages.PackageSearchOptions{
+	pvs, total, err := search(ctx, &packages.PackageSearchOptions{
 		Paginator: &db.ListOptions{
 			PageSize: setting.UI.PackagesPagingNum,
 			Page:     page,
 		},
-		OwnerID:    ctx.ContextUser.ID,
-		RepoID:     ctx.Repo.Repository.ID,
-		Type:       packages.Type(packageType),
-		Name:       packages.SearchValue{Value: query},
-		IsInternal: optional.Some(false),
+		OwnerID:      ctx.ContextUser.ID,
+		RepoID:       ctx.Repo.Repository.ID,
+		Type:         packages.Type(packageType),
+		Name:         packages.SearchValue{Value: query},
+		IsInternal:   optional.Some(false),
+		HasComponent: component,
 	})
 	if err != nil {
-		ctx.ServerError("SearchLatestVersions", err)
+		ctx.ServerError("SearchVersions", err)
 		return
 	}
 
@@ -60,6 +71,8 @@ func Packages(ctx *context.Context) {
 	ctx.Data["IsPackagesPage"] = true
 	ctx.Data["Query"] = query
 	ctx.Data["PackageType"] = packageType
+	ctx.Data["Component"] = component.Name
+	ctx.Data["ComponentVersion"] = component.Version
 	ctx.Data["AvailableTypes"] = packages.TypeList
 	ctx.Data["HasPackages"] = hasPackages
 	if ctx.Repo != nil {
@@ -72,6 +85,8 @@ func Packages(ctx *context.Context) {
 	pager := context.NewPagination(int(total), setting.UI.PackagesPagingNum, page, 5)
 	pager.AddParam(ctx, "q", "Query")
 	pager.AddParam(ctx, "type", "PackageType")
+	pager.AddParam(ctx, "component", "Component")
+	pager.AddParam(ctx, "component_version", "ComponentVersion")
 	ctx.Data["Page"] = pager
 
 	ctx.HTML(http.StatusOK, tplPackagesList)
@@ -23,6 +23,7 @@ import (
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
+	shared_project "forgejo.org/routers/web/shared/project"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 )
@@ -363,6 +364,30 @@ func ViewProject(ctx *context.Context) {
 	}
 	ctx.Data["LinkedPRs"] = linkedPrsMap
 
+	issueIDs := make([]int64, 0, 10)
+	for _, issuesList := range issuesMap {
+		for _, issue := range issuesList {
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	customFieldValues, err := issues_model.GetIssueCustomFieldValues(ctx, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetIssueCustomFieldValues", err)
+		return
+	}
+	boardCustomFields, err := db.Find[issues_model.CustomField](ctx, issues_model.FindCustomFieldOptions{RepoID: ctx.Repo.Repository.ID})
+	if err != nil {
+		ctx.ServerError("FindCustomFields", err)
+		return
+	}
+	shared_project.LoadViews(ctx, project, columns, issuesMap)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.Data["CustomFieldValues"] = customFieldValues
+	ctx.Data["BoardCustomFields"] = boardCustomFields
+
 	project.RenderedContent, err = markdown.RenderString(&markup.RenderContext{
 		Links: markup.Links{
 			Base: ctx.Repo.RepoLink,
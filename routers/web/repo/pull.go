@@ -36,7 +36,6 @@ import (
 	"forgejo.org/modules/emoji"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/gitrepo"
-	issue_template "forgejo.org/modules/issue/template"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/markup"
 	"forgejo.org/modules/optional"
@@ -1607,9 +1606,12 @@ func CompareAndPullRequestPost(ctx *context.Context) {
 	}
 
 	content := form.Content
-	if filename := ctx.Req.Form.Get("template-file"); filename != "" {
-		if template, err := issue_template.UnmarshalFromRepo(ctx.Repo.GitRepo, ctx.Repo.Repository.DefaultBranch, filename); err == nil {
-			content = issue_template.RenderToMarkdown(template, ctx.Req.Form)
+	if template := getSubmittedTemplate(ctx); ctx.Written() {
+		return
+	} else if template != nil {
+		applySubmittedTemplate(ctx, template, &content, &assigneeIDs, true)
+		if ctx.Written() {
+			return
 		}
 	}
 
@@ -0,0 +1,280 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package repo
+
+import (
+	stdctx "context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	advisory_model "forgejo.org/models/advisory"
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/cvss"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/markup"
+	"forgejo.org/modules/markup/markdown"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	advisory_service "forgejo.org/services/advisory"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const (
+	tplSecurityAdvisories   base.TplName = "repo/security/advisories"
+	tplSecurityAdvisory     base.TplName = "repo/security/advisory"
+	tplSecurityAdvisoryEdit base.TplName = "repo/security/advisory_edit"
+)
+
+// SecurityAdvisories renders the security advisories of a repository, the drafts are only listed for the administrators
+func SecurityAdvisories(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.security.advisories")
+	ctx.Data["PageIsSecurityAdvisories"] = true
+
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	opts := advisory_model.FindAdvisoriesOptions{
+		ListOptions: db.ListOptions{Page: page, PageSize: setting.UI.IssuePagingNum},
+		RepoID:      ctx.Repo.Repository.ID,
+	}
+	if !ctx.Repo.IsAdmin() {
+		opts.State = optional.Some(advisory_model.StatePublished)
+	}
+	advisories, count, err := db.FindAndCount[advisory_model.Advisory](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAdvisories", err)
+		return
+	}
+	for _, advisory := range advisories {
+		advisory.Repo = ctx.Repo.Repository
+	}
+
+	ctx.Data["Advisories"] = advisories
+	ctx.Data["Page"] = context.NewPagination(int(count), opts.PageSize, page, 5)
+	ctx.HTML(http.StatusOK, tplSecurityAdvisories)
+}
+
+// getSecurityAdvisory returns the security advisory of the path, drafts are only found for the administrators
+func getSecurityAdvisory(ctx *context.Context) *advisory_model.Advisory {
+	advisory, err := advisory_model.GetAdvisoryByIdentifier(ctx, ctx.Repo.Repository.ID, ctx.Params(":identifier"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetAdvisoryByIdentifier", advisory_model.IsErrAdvisoryNotExist, err)
+		return nil
+	}
+	if advisory.IsDraft() && !ctx.Repo.IsAdmin() {
+		ctx.NotFound("GetAdvisoryByIdentifier", nil)
+		return nil
+	}
+	advisory.Repo = ctx.Repo.Repository
+	return advisory
+}
+
+// ViewSecurityAdvisory renders a security advisory
+func ViewSecurityAdvisory(ctx *context.Context) {
+	advisory := getSecurityAdvisory(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := advisory.LoadAuthor(ctx); err != nil {
+		ctx.ServerError("LoadAuthor", err)
+		return
+	}
+
+	var err error
+	ctx.Data["RenderedDescription"], err = markdown.RenderString(&markup.RenderContext{
+		Ctx: ctx,
+		Links: markup.Links{
+			Base: ctx.Repo.RepoLink,
+		},
+		Metas:   ctx.Repo.Repository.ComposeMetas(ctx),
+		GitRepo: ctx.Repo.GitRepo,
+	}, advisory.Description)
+	if err != nil {
+		ctx.ServerError("RenderString", err)
+		return
+	}
+
+	if advisory.ForkRepoID != 0 {
+		fork, err := repo_model.GetRepositoryByID(ctx, advisory.ForkRepoID)
+		if err != nil {
+			ctx.ServerError("GetRepositoryByID", err)
+			return
+		}
+		ctx.Data["ForkRepo"] = fork
+	}
+
+	ctx.Data["Title"] = advisory.Title
+	ctx.Data["PageIsSecurityAdvisories"] = true
+	ctx.Data["Advisory"] = advisory
+	ctx.HTML(http.StatusOK, tplSecurityAdvisory)
+}
+
+func prepareSecurityAdvisoryForm(ctx *context.Context, advisory *advisory_model.Advisory) {
+	ctx.Data["PageIsSecurityAdvisories"] = true
+	ctx.Data["Advisory"] = advisory
+	ctx.Data["Severities"] = advisory_model.Severities
+	ctx.Data["CreditTypes"] = advisory_model.CreditTypes
+	// an empty row is always shown to add an affected package or a credit
+	ctx.Data["AffectedRows"] = append(slices.Clone(advisory.Affected), advisory_model.Affected{})
+	ctx.Data["CreditRows"] = append(slices.Clone(advisory.Credits), advisory_model.Credit{})
+}
+
+// NewSecurityAdvisory renders the page to create a draft security advisory
+func NewSecurityAdvisory(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.security.advisories.new")
+	prepareSecurityAdvisoryForm(ctx, &advisory_model.Advisory{Severity: cvss.SeverityMedium})
+	ctx.HTML(http.StatusOK, tplSecurityAdvisoryEdit)
+}
+
+// NewSecurityAdvisoryPost creates a draft security advisory
+func NewSecurityAdvisoryPost(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.security.advisories.new")
+	advisory := &advisory_model.Advisory{
+		RepoID:   ctx.Repo.Repository.ID,
+		Repo:     ctx.Repo.Repository,
+		AuthorID: ctx.Doer.ID,
+	}
+	saveSecurityAdvisory(ctx, advisory, advisory_model.CreateAdvisory)
+}
+
+// EditSecurityAdvisory renders the page to edit a security advisory
+func EditSecurityAdvisory(ctx *context.Context) {
+	advisory := getSecurityAdvisory(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = advisory.Title
+	prepareSecurityAdvisoryForm(ctx, advisory)
+	ctx.HTML(http.StatusOK, tplSecurityAdvisoryEdit)
+}
+
+// EditSecurityAdvisoryPost updates a security advisory
+func EditSecurityAdvisoryPost(ctx *context.Context) {
+	advisory := getSecurityAdvisory(ctx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Title"] = advisory.Title
+	saveSecurityAdvisory(ctx, advisory, advisory_model.UpdateAdvisory)
+}
+
+// formRow returns the trimmed value of a row of fields with the same name
+func formRow(values []string, i int) string {
+	if i < len(values) {
+		return strings.TrimSpace(values[i])
+	}
+	return ""
+}
+
+func saveSecurityAdvisory(ctx *context.Context, advisory *advisory_model.Advisory, save func(ctx stdctx.Context, a *advisory_model.Advisory) error) {
+	form := web.GetForm(ctx).(*forms.SecurityAdvisoryForm)
+
+	advisory.Title = strings.TrimSpace(form.Title)
+	advisory.Description = form.Description
+	advisory.Severity = cvss.Severity(form.Severity)
+	advisory.CVSSVector = strings.TrimSpace(form.CVSSVector)
+	advisory.CVEID = strings.TrimSpace(form.CVEID)
+	advisory.Affected = advisory.Affected[:0]
+	for i := range form.AffectedPackage {
+		if pkg := formRow(form.AffectedPackage, i); pkg != "" {
+			advisory.Affected = append(advisory.Affected, advisory_model.Affected{
+				Ecosystem: formRow(form.AffectedEcosystem, i),
+				Package:   pkg,
+				Versions:  formRow(form.AffectedVersions, i),
+				Patched:   formRow(form.AffectedPatched, i),
+			})
+		}
+	}
+	advisory.Credits = advisory.Credits[:0]
+	for i := range form.CreditName {
+		if name := formRow(form.CreditName, i); name != "" {
+			advisory.Credits = append(advisory.Credits, advisory_model.Credit{Name: name, Type: formRow(form.CreditType, i)})
+		}
+	}
+
+	prepareSecurityAdvisoryForm(ctx, advisory)
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSecurityAdvisoryEdit)
+		return
+	}
+
+	if err := save(ctx, advisory); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.RenderWithErr(ctx.Tr("repo.security.advisories.invalid", err.Error()), tplSecurityAdvisoryEdit, form)
+			return
+		}
+		ctx.ServerError("SaveAdvisory", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.security.advisories.saved", advisory.Identifier))
+	ctx.Redirect(advisory.Link())
+}
+
+// SecurityAdvisoryFork creates the temporary private fork of a draft security advisory
+func SecurityAdvisoryFork(ctx *context.Context) {
+	advisory := getSecurityAdvisory(ctx)
+	if ctx.Written() {
+		return
+	}
+	fork, err := advisory_service.CreateTemporaryFork(ctx, ctx.Doer, advisory)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, util.ErrAlreadyExist) || repo_model.IsErrReachLimitOfRepo(err) {
+			ctx.Flash.Error(ctx.Tr("repo.security.advisories.fork_failed", err.Error()))
+			ctx.Redirect(advisory.Link())
+			return
+		}
+		ctx.ServerError("CreateTemporaryFork", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.security.advisories.fork_created", fork.FullName()))
+	ctx.Redirect(advisory.Link())
+}
+
+// SecurityAdvisoryPublish merges the fix of a draft security advisory and publishes it
+func SecurityAdvisoryPublish(ctx *context.Context) {
+	advisory := getSecurityAdvisory(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := advisory_service.Publish(ctx, ctx.Doer, advisory); err != nil {
+		// the fix may not be mergeable, which must be resolved in the temporary fork
+		log.Warn("Publish security advisory %s of %-v: %v", advisory.Identifier, ctx.Repo.Repository, err)
+		ctx.Flash.Error(ctx.Tr("repo.security.advisories.publish_failed", err.Error()))
+		ctx.Redirect(advisory.Link())
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.security.advisories.publish_success", advisory.Identifier))
+	ctx.Redirect(advisory.Link())
+}
+
+// DeleteSecurityAdvisory deletes a draft security advisory and its temporary fork
+func DeleteSecurityAdvisory(ctx *context.Context) {
+	advisory := getSecurityAdvisory(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := advisory_service.Delete(ctx, ctx.Doer, advisory); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(err.Error())
+			ctx.Redirect(advisory.Link())
+			return
+		}
+		ctx.ServerError("DeleteAdvisory", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.security.advisories.deleted", advisory.Identifier))
+	ctx.Redirect(ctx.Repo.RepoLink + "/security/advisories")
+}
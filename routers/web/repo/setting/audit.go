@@ -0,0 +1,28 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"net/http"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/modules/base"
+	shared_audit "forgejo.org/routers/web/shared/audit"
+	"forgejo.org/services/context"
+)
+
+const tplAudit base.TplName = "repo/settings/audit"
+
+// AuditEvents shows the audit log of the repository
+func AuditEvents(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("audit.title")
+	ctx.Data["PageIsSettingsAudit"] = true
+
+	shared_audit.SetAuditEventsContext(ctx, audit_model.FindEventsOptions{RepoID: ctx.Repo.Repository.ID})
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, tplAudit)
+}
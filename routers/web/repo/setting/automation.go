@@ -0,0 +1,313 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	stdctx "context"
+	"errors"
+	"net/http"
+
+	automation_model "forgejo.org/models/automation"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	shared_user "forgejo.org/routers/web/shared/user"
+	automation_service "forgejo.org/services/automation"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+const (
+	tplRepoAutomation     base.TplName = "repo/settings/automation"
+	tplRepoAutomationEdit base.TplName = "repo/settings/automation_edit"
+	tplOrgAutomation      base.TplName = "org/settings/automation"
+	tplOrgAutomationEdit  base.TplName = "org/settings/automation_edit"
+)
+
+type automationCtx struct {
+	OwnerID      int64
+	RepoID       int64
+	Link         string
+	ListTemplate base.TplName
+	EditTemplate base.TplName
+}
+
+// getAutomationCtx determines whether the automation rules are the rules of a repository or of an organization
+func getAutomationCtx(ctx *context.Context) (*automationCtx, error) {
+	if ctx.Data["PageIsRepoSettings"] == true {
+		return &automationCtx{
+			RepoID:       ctx.Repo.Repository.ID,
+			Link:         ctx.Repo.RepoLink + "/settings/automation",
+			ListTemplate: tplRepoAutomation,
+			EditTemplate: tplRepoAutomationEdit,
+		}, nil
+	}
+
+	if ctx.Data["PageIsOrgSettings"] == true {
+		if err := shared_user.LoadHeaderCount(ctx); err != nil {
+			return nil, err
+		}
+		return &automationCtx{
+			OwnerID:      ctx.ContextUser.ID,
+			Link:         ctx.Org.OrgLink + "/settings/automation",
+			ListTemplate: tplOrgAutomation,
+			EditTemplate: tplOrgAutomationEdit,
+		}, nil
+	}
+
+	return nil, errors.New("unable to set Automation context")
+}
+
+// AutomationRules renders the automation rules of a repository or of an organization
+func AutomationRules(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.automation")
+	ctx.Data["PageIsSettingsAutomation"] = true
+
+	aCtx, err := getAutomationCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getAutomationCtx", err)
+		return
+	}
+
+	rules, err := automation_model.GetRules(ctx, aCtx.OwnerID, aCtx.RepoID)
+	if err != nil {
+		ctx.ServerError("GetRules", err)
+		return
+	}
+	ctx.Data["Rules"] = rules
+	ctx.Data["AutomationLink"] = aCtx.Link
+
+	ctx.HTML(http.StatusOK, aCtx.ListTemplate)
+}
+
+// projectColumnOption is a column of a project actions can move the issues to
+type projectColumnOption struct {
+	ID    int64
+	Title string
+}
+
+func prepareAutomationRuleForm(ctx *context.Context, aCtx *automationCtx) {
+	ctx.Data["PageIsSettingsAutomation"] = true
+	ctx.Data["AutomationLink"] = aCtx.Link
+	ctx.Data["Events"] = automation_model.Events
+
+	opts := []project_model.SearchOptions{}
+	if aCtx.RepoID > 0 {
+		opts = append(opts, project_model.SearchOptions{RepoID: aCtx.RepoID, IsClosed: optional.Some(false), Type: project_model.TypeRepository})
+		if ctx.Repo.Owner.IsOrganization() {
+			opts = append(opts, project_model.SearchOptions{OwnerID: ctx.Repo.Owner.ID, IsClosed: optional.Some(false), Type: project_model.TypeOrganization})
+		}
+	} else {
+		opts = append(opts, project_model.SearchOptions{OwnerID: aCtx.OwnerID, IsClosed: optional.Some(false), Type: project_model.TypeOrganization})
+	}
+
+	var columns []projectColumnOption
+	for _, opt := range opts {
+		projects, err := db.Find[project_model.Project](ctx, opt)
+		if err != nil {
+			ctx.ServerError("FindProjects", err)
+			return
+		}
+		for _, project := range projects {
+			projectColumns, err := project.GetColumns(ctx)
+			if err != nil {
+				ctx.ServerError("GetColumns", err)
+				return
+			}
+			for _, column := range projectColumns {
+				columns = append(columns, projectColumnOption{ID: column.ID, Title: project.Title + " / " + column.Title})
+			}
+		}
+	}
+	ctx.Data["ProjectColumns"] = columns
+}
+
+// AutomationRuleNew renders the page to create an automation rule
+func AutomationRuleNew(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("repo.settings.automation.new")
+
+	aCtx, err := getAutomationCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getAutomationCtx", err)
+		return
+	}
+	prepareAutomationRuleForm(ctx, aCtx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Rule"] = &automation_model.Rule{IsActive: true, Event: automation_model.EventIssueOpened}
+
+	ctx.HTML(http.StatusOK, aCtx.EditTemplate)
+}
+
+// AutomationRuleNewPost creates an automation rule
+func AutomationRuleNewPost(ctx *context.Context) {
+	aCtx, err := getAutomationCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getAutomationCtx", err)
+		return
+	}
+	rule := &automation_model.Rule{OwnerID: aCtx.OwnerID, RepoID: aCtx.RepoID}
+	saveAutomationRule(ctx, aCtx, rule, automation_model.CreateRule)
+}
+
+// AutomationRuleEdit renders the page to edit an automation rule, with its execution log
+func AutomationRuleEdit(ctx *context.Context) {
+	aCtx, err := getAutomationCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getAutomationCtx", err)
+		return
+	}
+	rule, err := automation_model.GetRuleByID(ctx, aCtx.OwnerID, aCtx.RepoID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetRuleByID", automation_model.IsErrRuleNotExist, err)
+		return
+	}
+	ctx.Data["Title"] = rule.Name
+	prepareAutomationRuleForm(ctx, aCtx)
+	if ctx.Written() {
+		return
+	}
+	ctx.Data["Rule"] = rule
+
+	loadAutomationRuleLogs(ctx, rule)
+	if ctx.Written() {
+		return
+	}
+
+	ctx.HTML(http.StatusOK, aCtx.EditTemplate)
+}
+
+func loadAutomationRuleLogs(ctx *context.Context, rule *automation_model.Rule) {
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	const pageSize = 20
+	logs, total, err := automation_model.GetRuleLogs(ctx, rule.ID, db.ListOptions{Page: page, PageSize: pageSize})
+	if err != nil {
+		ctx.ServerError("GetRuleLogs", err)
+		return
+	}
+
+	issueIDs := make([]int64, 0, len(logs))
+	for _, l := range logs {
+		issueIDs = append(issueIDs, l.IssueID)
+	}
+	issues, err := issues_model.GetIssuesByIDs(ctx, issueIDs)
+	if err != nil {
+		ctx.ServerError("GetIssuesByIDs", err)
+		return
+	}
+	if _, err := issues.LoadRepositories(ctx); err != nil {
+		ctx.ServerError("LoadRepositories", err)
+		return
+	}
+	logIssues := make(map[int64]*issues_model.Issue, len(issues))
+	for _, issue := range issues {
+		logIssues[issue.ID] = issue
+	}
+
+	ctx.Data["RuleLogs"] = logs
+	ctx.Data["RuleLogIssues"] = logIssues
+	ctx.Data["Page"] = context.NewPagination(int(total), pageSize, page, 5)
+}
+
+// AutomationRuleEditPost updates an automation rule
+func AutomationRuleEditPost(ctx *context.Context) {
+	aCtx, err := getAutomationCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getAutomationCtx", err)
+		return
+	}
+	rule, err := automation_model.GetRuleByID(ctx, aCtx.OwnerID, aCtx.RepoID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetRuleByID", automation_model.IsErrRuleNotExist, err)
+		return
+	}
+	saveAutomationRule(ctx, aCtx, rule, automation_model.UpdateRule)
+}
+
+func saveAutomationRule(ctx *context.Context, aCtx *automationCtx, rule *automation_model.Rule, save func(ctx stdctx.Context, r *automation_model.Rule) error) {
+	form := web.GetForm(ctx).(*forms.AutomationRuleForm)
+
+	rule.Name = form.Name
+	rule.Event = automation_model.Event(form.Event)
+	rule.IsActive = form.IsActive
+	rule.Conditions = automation_model.Conditions{
+		Labels:         automation_service.SplitNames(form.Labels),
+		ExcludedLabels: automation_service.SplitNames(form.ExcludedLabels),
+		Authors:        automation_service.SplitNames(form.Authors),
+		Paths:          form.PathList(),
+		InactiveDays:   form.InactiveDays,
+	}
+	switch form.IssueType {
+	case "issues":
+		rule.Conditions.IsPull = util.ToPointer(false)
+	case "pulls":
+		rule.Conditions.IsPull = util.ToPointer(true)
+	}
+	rule.Actions = automation_model.Actions{
+		AddLabels:       automation_service.SplitNames(form.AddLabels),
+		RemoveLabels:    automation_service.SplitNames(form.RemoveLabels),
+		Assignees:       automation_service.SplitNames(form.Assignees),
+		Reviewers:       automation_service.SplitNames(form.Reviewers),
+		Comment:         form.Comment,
+		Close:           form.Close,
+		ProjectColumnID: form.ProjectColumnID,
+		OnLinkedIssues:  form.OnLinkedIssues,
+	}
+
+	if ctx.HasError() {
+		prepareAutomationRuleForm(ctx, aCtx)
+		if ctx.Written() {
+			return
+		}
+		ctx.Data["Rule"] = rule
+		ctx.HTML(http.StatusOK, aCtx.EditTemplate)
+		return
+	}
+
+	if err := save(ctx, rule); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			prepareAutomationRuleForm(ctx, aCtx)
+			if ctx.Written() {
+				return
+			}
+			ctx.Data["Rule"] = rule
+			ctx.RenderWithErr(ctx.Tr("repo.settings.automation.invalid", err.Error()), aCtx.EditTemplate, form)
+			return
+		}
+		ctx.ServerError("SaveRule", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.automation.saved", rule.Name))
+	ctx.Redirect(aCtx.Link)
+}
+
+// AutomationRuleDelete deletes an automation rule
+func AutomationRuleDelete(ctx *context.Context) {
+	aCtx, err := getAutomationCtx(ctx)
+	if err != nil {
+		ctx.ServerError("getAutomationCtx", err)
+		return
+	}
+	rule, err := automation_model.GetRuleByID(ctx, aCtx.OwnerID, aCtx.RepoID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetRuleByID", automation_model.IsErrRuleNotExist, err)
+		return
+	}
+	if err := automation_model.DeleteRule(ctx, rule); err != nil {
+		ctx.ServerError("DeleteRule", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("repo.settings.automation.deleted", rule.Name))
+	ctx.Redirect(aCtx.Link)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
@@ -17,6 +18,7 @@ import (
 	"forgejo.org/modules/log"
 	repo_module "forgejo.org/modules/repository"
 	"forgejo.org/modules/setting"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/mailer"
 	org_service "forgejo.org/services/org"
@@ -117,6 +119,8 @@ func CollaborationPost(ctx *context.Context) {
 		return
 	}
 
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionCollaboratorAdd, audit_service.CollaboratorTarget(ctx.Repo.Repository, u.ID, u.Name), nil, nil)
+
 	if setting.Service.EnableNotifyMail {
 		mailer.SendCollaboratorMail(u, ctx.Doer, ctx.Repo.Repository)
 	}
@@ -127,20 +131,22 @@ func CollaborationPost(ctx *context.Context) {
 
 // ChangeCollaborationAccessMode response for changing access of a collaboration
 func ChangeCollaborationAccessMode(ctx *context.Context) {
-	if err := repo_model.ChangeCollaborationAccessMode(
-		ctx,
-		ctx.Repo.Repository,
-		ctx.FormInt64("uid"),
-		perm.AccessMode(ctx.FormInt("mode"))); err != nil {
+	uid := ctx.FormInt64("uid")
+	mode := perm.AccessMode(ctx.FormInt("mode"))
+	if err := repo_model.ChangeCollaborationAccessMode(ctx, ctx.Repo.Repository, uid, mode); err != nil {
 		log.Error("ChangeCollaborationAccessMode: %v", err)
+		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionCollaboratorAccess, audit_service.CollaboratorTarget(ctx.Repo.Repository, uid, ""), nil, map[string]string{"mode": mode.String()})
 }
 
 // DeleteCollaboration delete a collaboration for a repository
 func DeleteCollaboration(ctx *context.Context) {
-	if err := repo_service.DeleteCollaboration(ctx, ctx.Repo.Repository, ctx.FormInt64("id")); err != nil {
+	uid := ctx.FormInt64("id")
+	if err := repo_service.DeleteCollaboration(ctx, ctx.Repo.Repository, uid); err != nil {
 		ctx.Flash.Error("DeleteCollaboration: " + err.Error())
 	} else {
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionCollaboratorRemove, audit_service.CollaboratorTarget(ctx.Repo.Repository, uid, ""), nil, nil)
 		ctx.Flash.Success(ctx.Tr("repo.settings.remove_collaborator_success"))
 	}
 
@@ -188,6 +194,7 @@ func AddTeamPost(ctx *context.Context) {
 		ctx.ServerError("TeamAddRepository", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamRepoAdd, audit_service.TeamRepoTarget(team, ctx.Repo.Repository.ID), nil, nil)
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.add_team_success"))
 	ctx.Redirect(ctx.Repo.RepoLink + "/settings/collaboration")
@@ -211,6 +218,7 @@ func DeleteTeam(ctx *context.Context) {
 		ctx.ServerError("team.RemoveRepositorys", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTeamRepoRemove, audit_service.TeamRepoTarget(team, ctx.Repo.Repository.ID), nil, nil)
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.remove_team_success"))
 	ctx.JSONRedirect(ctx.Repo.RepoLink + "/settings/collaboration")
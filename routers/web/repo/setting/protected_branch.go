@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	audit_model "forgejo.org/models/audit"
 	git_model "forgejo.org/models/git"
 	"forgejo.org/models/organization"
 	"forgejo.org/models/perm"
@@ -18,6 +19,7 @@ import (
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/web"
 	"forgejo.org/routers/web/repo"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	pull_service "forgejo.org/services/pull"
@@ -145,12 +147,15 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 			return
 		}
 	}
+	var before map[string]any
 	if protectBranch == nil {
 		// No options found, create defaults.
 		protectBranch = &git_model.ProtectedBranch{
 			RepoID:   ctx.Repo.Repository.ID,
 			RuleName: f.RuleName,
 		}
+	} else {
+		before = audit_service.BranchProtectionState(protectBranch)
 	}
 
 	var whitelistUsers, whitelistTeams, mergeWhitelistUsers, mergeWhitelistTeams, approvalsWhitelistUsers, approvalsWhitelistTeams []int64
@@ -251,6 +256,8 @@ func SettingsProtectedBranchPost(ctx *context.Context) {
 		ctx.ServerError("UpdateProtectBranch", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionBranchProtectionEdit, audit_service.BranchProtectionTarget(ctx.Repo.Repository, protectBranch),
+		before, audit_service.BranchProtectionState(protectBranch))
 
 	// FIXME: since we only need to recheck files protected rules, we could improve this
 	matchedBranches, err := git_model.FindAllMatchedBranches(ctx, ctx.Repo.Repository.ID, protectBranch.RuleName)
@@ -296,6 +303,8 @@ func DeleteProtectedBranchRulePost(ctx *context.Context) {
 		ctx.JSONRedirect(fmt.Sprintf("%s/settings/branches", ctx.Repo.RepoLink))
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionBranchProtectionDrop, audit_service.BranchProtectionTarget(ctx.Repo.Repository, rule),
+		audit_service.BranchProtectionState(rule), nil)
 
 	ctx.Flash.Success(ctx.Tr("repo.settings.remove_protected_branch_success", rule.RuleName))
 	ctx.JSONRedirect(fmt.Sprintf("%s/settings/branches", ctx.Repo.RepoLink))
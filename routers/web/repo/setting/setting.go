@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"forgejo.org/models"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/organization"
 	quota_model "forgejo.org/models/quota"
@@ -35,9 +36,11 @@ import (
 	"forgejo.org/modules/web"
 	actions_service "forgejo.org/services/actions"
 	asymkey_service "forgejo.org/services/asymkey"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/federation"
 	"forgejo.org/services/forms"
+	"forgejo.org/services/mailer/token"
 	"forgejo.org/services/migrations"
 	mirror_service "forgejo.org/services/mirror"
 	repo_service "forgejo.org/services/repository"
@@ -103,6 +106,9 @@ func SettingsCtxData(ctx *context.Context) {
 func Units(ctx *context.Context) {
 	ctx.Data["Title"] = ctx.Tr("repo.settings.units.units")
 	ctx.Data["PageIsRepoSettingsUnits"] = true
+	if setting.IncomingEmail.Enabled {
+		ctx.Data["ServiceDeskAddress"] = token.ServiceDeskAddress(ctx.Repo.Repository, "")
+	}
 
 	ctx.HTML(http.StatusOK, tplSettingsUnits)
 }
@@ -204,6 +210,8 @@ func UnitsPost(ctx *context.Context) {
 				EnableTimetracker:                form.EnableTimetracker,
 				AllowOnlyContributorsToTrackTime: form.AllowOnlyContributorsToTrackTime,
 				EnableDependencies:               form.EnableIssueDependencies,
+				CloseParentIssues:                form.CloseParentIssues,
+				EnableServiceDesk:                form.EnableServiceDesk,
 			},
 		})
 		deleteUnitTypes = append(deleteUnitTypes, unit_model.TypeExternalTracker)
@@ -389,6 +397,10 @@ func SettingsPost(ctx *context.Context) {
 			ctx.ServerError("UpdateRepository", err)
 			return
 		}
+		if visibilityChanged {
+			audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionRepoVisibility, audit_service.RepoTarget(repo),
+				map[string]bool{"is_private": !repo.IsPrivate}, map[string]bool{"is_private": repo.IsPrivate})
+		}
 		log.Trace("Repository basic settings updated: %s/%s", ctx.Repo.Owner.Name, repo.Name)
 
 		ctx.Flash.Success(ctx.Tr("repo.settings.update_settings_success"))
@@ -0,0 +1,37 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit
+
+import (
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+)
+
+// SetAuditEventsContext loads a page of the audit log matching opts for
+// the admin, organization and repository views
+func SetAuditEventsContext(ctx *context.Context, opts audit_model.FindEventsOptions) {
+	page := ctx.FormInt("page")
+	if page <= 1 {
+		page = 1
+	}
+	opts.ListOptions = db.ListOptions{Page: page, PageSize: setting.UI.FeedPagingNum}
+	opts.Action = audit_model.Action(ctx.FormTrim("action"))
+
+	events, total, err := db.FindAndCount[audit_model.Event](ctx, opts)
+	if err != nil {
+		ctx.ServerError("FindAuditEvents", err)
+		return
+	}
+
+	ctx.Data["AuditEvents"] = events
+	ctx.Data["AuditActions"] = audit_model.Actions
+	ctx.Data["SelectedAction"] = string(opts.Action)
+	ctx.Data["Total"] = total
+
+	pager := context.NewPagination(int(total), setting.UI.FeedPagingNum, page, 5)
+	pager.AddParamString("action", string(opts.Action))
+	ctx.Data["Page"] = pager
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"errors"
+	"fmt"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+	project_service "forgejo.org/services/project"
+)
+
+// LoadViews sets the saved views of the project and, on the page of a view, the issues
+// of the board grouped and sorted by the view
+func LoadViews(ctx *context.Context, project *project_model.Project, columns project_model.ColumnList, issuesMap map[int64]issues_model.IssueList) {
+	views, err := project_model.GetProjectViews(ctx, project.ID)
+	if err != nil {
+		ctx.ServerError("GetProjectViews", err)
+		return
+	}
+	customFields, err := db.Find[issues_model.CustomField](ctx, issues_model.FindCustomFieldOptions{
+		RepoID:     project.RepoID,
+		ProjectIDs: []int64{project.ID},
+	})
+	if err != nil {
+		ctx.ServerError("FindCustomFields", err)
+		return
+	}
+	ctx.Data["ProjectViews"] = views
+	ctx.Data["ViewCustomFields"] = customFields
+	ctx.Data["ViewFields"] = project_model.ViewFields
+	ctx.Data["ViewGroupByFields"] = project_model.ViewGroupByFields
+	ctx.Data["ViewSortByFields"] = project_model.ViewSortByFields
+	ctx.Data["ViewDateFields"] = project_model.ViewDateFields
+
+	viewID := ctx.ParamsInt64(":viewID")
+	if viewID == 0 {
+		return
+	}
+	view, err := project_model.GetViewByProjectIDAndID(ctx, project.ID, viewID)
+	if err != nil {
+		ctx.NotFoundOrServerError("GetViewByProjectIDAndID", project_model.IsErrViewNotExist, err)
+		return
+	}
+	data, err := project_service.LoadView(ctx, project, view, columns, issuesMap)
+	if err != nil {
+		ctx.ServerError("LoadView", err)
+		return
+	}
+	ctx.Data["ProjectView"] = data
+}
+
+func getProject(ctx *context.Context) *project_model.Project {
+	project, err := project_model.GetProjectByID(ctx, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetProjectByID", project_model.IsErrProjectNotExist, err)
+		return nil
+	}
+	if !project.CanBeAccessedByOwnerRepo(ctx.ContextUser.ID, ctx.Repo.Repository) {
+		ctx.NotFound("CanBeAccessedByOwnerRepo", nil)
+		return nil
+	}
+	return project
+}
+
+func fillView(view *project_model.View, form *forms.ProjectViewForm) {
+	view.Name = form.Name
+	view.Type = project_model.ViewType(form.Type)
+	view.Fields = form.Fields
+	view.GroupBy = form.GroupBy
+	view.SortBy = form.SortBy
+	view.SortDesc = form.SortDesc
+	view.DateField = form.DateField
+}
+
+// NewView creates a saved view of a project
+func NewView(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectViewForm)
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(project.Link(ctx))
+		return
+	}
+
+	view := &project_model.View{ProjectID: project.ID, CreatorID: ctx.Doer.ID}
+	fillView(view, form)
+	if err := project_model.NewView(ctx, view); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("projects.view.invalid", err.Error()))
+			ctx.Redirect(project.Link(ctx))
+			return
+		}
+		ctx.ServerError("NewView", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("projects.view.created", view.Name))
+	ctx.Redirect(fmt.Sprintf("%s/views/%d", project.Link(ctx), view.ID))
+}
+
+// EditView updates a saved view of a project
+func EditView(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.ProjectViewForm)
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	view, err := project_model.GetViewByProjectIDAndID(ctx, project.ID, ctx.ParamsInt64(":viewID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetViewByProjectIDAndID", project_model.IsErrViewNotExist, err)
+		return
+	}
+	link := fmt.Sprintf("%s/views/%d", project.Link(ctx), view.ID)
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(link)
+		return
+	}
+
+	fillView(view, form)
+	if err := project_model.UpdateView(ctx, view); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("projects.view.invalid", err.Error()))
+			ctx.Redirect(link)
+			return
+		}
+		ctx.ServerError("UpdateView", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("projects.view.updated", view.Name))
+	ctx.Redirect(link)
+}
+
+// DeleteView deletes a saved view of a project
+func DeleteView(ctx *context.Context) {
+	project := getProject(ctx)
+	if ctx.Written() {
+		return
+	}
+	view, err := project_model.GetViewByProjectIDAndID(ctx, project.ID, ctx.ParamsInt64(":viewID"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetViewByProjectIDAndID", project_model.IsErrViewNotExist, err)
+		return
+	}
+	if err := project_model.DeleteView(ctx, view); err != nil {
+		ctx.ServerError("DeleteView", err)
+		return
+	}
+	ctx.Flash.Success(ctx.Tr("projects.view.deleted", view.Name))
+	ctx.Redirect(project.Link(ctx))
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package web
+
+import (
+	"net/http"
+
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+)
+
+type terraformServices struct {
+	ModulesV1   string `json:"modules.v1"`
+	ProvidersV1 string `json:"providers.v1"`
+}
+
+// TerraformServiceDiscovery announces the Terraform registry endpoints of the package registry
+// https://developer.hashicorp.com/terraform/internals/remote-service-discovery
+func TerraformServiceDiscovery(ctx *context.Context) {
+	ctx.JSON(http.StatusOK, &terraformServices{
+		ModulesV1:   setting.AppSubURL + "/api/packages/terraform/modules/v1/",
+		ProvidersV1: setting.AppSubURL + "/api/packages/terraform/providers/v1/",
+	})
+}
@@ -366,6 +366,41 @@ func Issues(ctx *context.Context) {
 	buildIssueOverview(ctx, unit.TypeIssues)
 }
 
+// issueFilterModeOfViewType returns the filter mode of a view type of the issues overview,
+// false for the default view type
+func issueFilterModeOfViewType(viewType string) (int, bool) {
+	switch viewType {
+	case "assigned":
+		return issues_model.FilterModeAssign, true
+	case "mentioned":
+		return issues_model.FilterModeMention, true
+	case "review_requested":
+		return issues_model.FilterModeReviewRequested, true
+	case "reviewed_by":
+		return issues_model.FilterModeReviewed, true
+	case "your_repositories":
+		return issues_model.FilterModeYourRepositories, true
+	}
+	return 0, false
+}
+
+func applyIssueFilterMode(opts *issues_model.IssuesOptions, filterMode int, doer *user_model.User) {
+	switch filterMode {
+	case issues_model.FilterModeAll:
+	case issues_model.FilterModeYourRepositories:
+	case issues_model.FilterModeAssign:
+		opts.AssigneeID = doer.ID
+	case issues_model.FilterModeCreate:
+		opts.PosterID = doer.ID
+	case issues_model.FilterModeMention:
+		opts.MentionedID = doer.ID
+	case issues_model.FilterModeReviewRequested:
+		opts.ReviewRequestedID = doer.ID
+	case issues_model.FilterModeReviewed:
+		opts.ReviewedID = doer.ID
+	}
+}
+
 // Regexp for repos query
 var issueReposQueryPattern = regexp.MustCompile(`^\[\d+(,\d+)*,?\]$`)
 
@@ -419,20 +454,8 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	}
 
 	viewType = ctx.FormString("type")
-	switch viewType {
-	case "assigned":
-		filterMode = issues_model.FilterModeAssign
-	case "mentioned":
-		filterMode = issues_model.FilterModeMention
-	case "review_requested":
-		filterMode = issues_model.FilterModeReviewRequested
-	case "reviewed_by":
-		filterMode = issues_model.FilterModeReviewed
-	case "your_repositories":
-		filterMode = issues_model.FilterModeYourRepositories
-	case "created_by":
-		fallthrough
-	default:
+	var ok bool
+	if filterMode, ok = issueFilterModeOfViewType(viewType); !ok {
 		filterMode = defaultFilterMode
 		viewType = defaultViewType
 	}
@@ -510,19 +533,21 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 		opts.AllPublic = true
 	}
 
-	switch filterMode {
-	case issues_model.FilterModeAll:
-	case issues_model.FilterModeYourRepositories:
-	case issues_model.FilterModeAssign:
-		opts.AssigneeID = ctx.Doer.ID
-	case issues_model.FilterModeCreate:
-		opts.PosterID = ctx.Doer.ID
-	case issues_model.FilterModeMention:
-		opts.MentionedID = ctx.Doer.ID
-	case issues_model.FilterModeReviewRequested:
-		opts.ReviewRequestedID = ctx.Doer.ID
-	case issues_model.FilterModeReviewed:
-		opts.ReviewedID = ctx.Doer.ID
+	applyIssueFilterMode(opts, filterMode, ctx.Doer)
+
+	if !isPullList {
+		var err error
+		if opts.Confidential, err = issue_service.ConfidentialFilter(ctx, ctx.Doer, opts.RepoIDs); err != nil {
+			ctx.ServerError("ConfidentialFilter", err)
+			return
+		}
+	}
+
+	if ctx.Org == nil {
+		loadSavedFilters(ctx, isPullList, opts.RepoIDs, opts.Confidential)
+		if ctx.Written() {
+			return
+		}
 	}
 
 	// keyword holds the search term entered into the search field.
@@ -592,7 +617,7 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	// USING FINAL STATE OF opts FOR A QUERY.
 	var issues issues_model.IssueList
 	{
-		issueIDs, _, err := issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(ctx, keyword, opts))
+		issueIDs, _, err := issue_indexer.SearchIssues(ctx, issue_indexer.ToSearchOptions(ctx, ctx.Doer, keyword, opts))
 		if err != nil {
 			ctx.ServerError("issueIDsFromSearch", err)
 			return
@@ -613,7 +638,7 @@ func buildIssueOverview(ctx *context.Context, unitType unit.Type) {
 	// -------------------------------
 	// Fill stats to post to ctx.Data.
 	// -------------------------------
-	searchOpts := issue_indexer.ToSearchOptions(ctx, keyword, opts)
+	searchOpts := issue_indexer.ToSearchOptions(ctx, ctx.Doer, keyword, opts)
 	issueStats, err := getUserIssueStats(ctx, ctxUser, filterMode, searchOpts)
 	if err != nil {
 		ctx.ServerError("getUserIssueStats", err)
@@ -296,6 +296,10 @@ func NotificationSubscriptions(ctx *context.Context) {
 		ctx.ServerError("Issues", err)
 		return
 	}
+	if issues, err = issues.FilterVisibleTo(ctx, ctx.Doer); err != nil {
+		ctx.ServerError("FilterVisibleTo", err)
+		return
+	}
 
 	commitStatuses, lastStatus, err := pull_service.GetIssuesAllCommitStatus(ctx, issues)
 	if err != nil {
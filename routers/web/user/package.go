@@ -48,19 +48,30 @@ func ListPackages(ctx *context.Context) {
 	}
 	query := ctx.FormTrim("q")
 	packageType := ctx.FormTrim("type")
+	component := packages_model.ComponentSearchValue{
+		Name:    ctx.FormTrim("component"),
+		Version: ctx.FormTrim("component_version"),
+	}
+
+	search := packages_model.SearchLatestVersions
+	if component.Name != "" {
+		// all affected versions are of interest when looking for a component
+		search = packages_model.SearchVersions
+	}
 
-	pvs, total, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+	pvs, total, err := search(ctx, &packages_model.PackageSearchOptions{
 		Paginator: &db.ListOptions{
 			PageSize: setting.UI.PackagesPagingNum,
 			Page:     page,
 		},
-		OwnerID:    ctx.ContextUser.ID,
-		Type:       packages_model.Type(packageType),
-		Name:       packages_model.SearchValue{Value: query},
-		IsInternal: optional.Some(false),
+		OwnerID:      ctx.ContextUser.ID,
+		Type:         packages_model.Type(packageType),
+		Name:         packages_model.SearchValue{Value: query},
+		IsInternal:   optional.Some(false),
+		HasComponent: component,
 	})
 	if err != nil {
-		ctx.ServerError("SearchLatestVersions", err)
+		ctx.ServerError("SearchVersions", err)
 		return
 	}
 
@@ -99,6 +110,8 @@ func ListPackages(ctx *context.Context) {
 	ctx.Data["IsPackagesPage"] = true
 	ctx.Data["Query"] = query
 	ctx.Data["PackageType"] = packageType
+	ctx.Data["Component"] = component.Name
+	ctx.Data["ComponentVersion"] = component.Version
 	ctx.Data["AvailableTypes"] = packages_model.TypeList
 	ctx.Data["HasPackages"] = hasPackages
 	ctx.Data["PackageDescriptors"] = pds
@@ -129,6 +142,8 @@ func ListPackages(ctx *context.Context) {
 	pager := context.NewPagination(int(total), setting.UI.PackagesPagingNum, page, 5)
 	pager.AddParam(ctx, "q", "Query")
 	pager.AddParam(ctx, "type", "PackageType")
+	pager.AddParam(ctx, "component", "Component")
+	pager.AddParam(ctx, "component_version", "ComponentVersion")
 	ctx.Data["Page"] = pager
 
 	ctx.HTML(http.StatusOK, tplPackagesList)
@@ -0,0 +1,125 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package user
+
+import (
+	"errors"
+
+	issues_model "forgejo.org/models/issues"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	"forgejo.org/modules/web"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+)
+
+// loadSavedFilters loads the saved filters of the doer with the number of
+// the open issues updated since the doer last viewed each of them
+func loadSavedFilters(ctx *context.Context, isPull bool, repoIDs []int64, confidential *issues_model.ConfidentialFilter) {
+	filters, err := issues_model.GetSavedFilters(ctx, ctx.Doer.ID, isPull)
+	if err != nil {
+		ctx.ServerError("GetSavedFilters", err)
+		return
+	}
+
+	viewedID := ctx.FormInt64("saved_filter")
+	unreadCounts := make(map[int64]int64, len(filters))
+	for _, f := range filters {
+		if f.ID == viewedID {
+			if err := issues_model.MarkSavedFilterViewed(ctx, f); err != nil {
+				ctx.ServerError("MarkSavedFilterViewed", err)
+				return
+			}
+			ctx.Data["SavedFilter"] = f
+			continue
+		}
+
+		filterMode, ok := issueFilterModeOfViewType(f.ViewType)
+		if !ok {
+			filterMode = issues_model.FilterModeCreate
+		}
+		opts := &issues_model.IssuesOptions{
+			IsPull:       optional.Some(isPull),
+			IsArchived:   optional.Some(false),
+			IsClosed:     optional.Some(false),
+			User:         ctx.Doer,
+			RepoIDs:      repoIDs,
+			AllPublic:    filterMode != issues_model.FilterModeYourRepositories,
+			Confidential: confidential,
+		}
+		applyIssueFilterMode(opts, filterMode, ctx.Doer)
+
+		searchOpts := issue_indexer.ToSearchOptions(ctx, ctx.Doer, f.Query, opts)
+		if searchOpts.UpdatedAfterUnix.ValueOrDefault(0) < int64(f.LastViewedUnix) {
+			searchOpts.UpdatedAfterUnix = optional.Some(int64(f.LastViewedUnix))
+		}
+		unreadCounts[f.ID], err = issue_indexer.CountIssues(ctx, searchOpts)
+		if err != nil {
+			ctx.ServerError("CountIssues", err)
+			return
+		}
+	}
+
+	ctx.Data["SavedFilters"] = filters
+	ctx.Data["SavedFilterUnreadCounts"] = unreadCounts
+}
+
+// NewSavedFilter saves the search of the issues or pull requests of the dashboard
+func NewSavedFilter(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SavedFilterForm)
+	redirect := setting.AppSubURL + "/issues"
+	if form.IsPull {
+		redirect = setting.AppSubURL + "/pulls"
+	}
+	if ctx.HasError() {
+		ctx.Flash.Error(ctx.GetErrMsg())
+		ctx.Redirect(redirect)
+		return
+	}
+
+	f := &issues_model.SavedFilter{
+		UserID:   ctx.Doer.ID,
+		Name:     form.Name,
+		Query:    form.Query,
+		IsPull:   form.IsPull,
+		ViewType: form.ViewType,
+	}
+	if _, ok := issueFilterModeOfViewType(f.ViewType); !ok {
+		f.ViewType = "created_by"
+	}
+	if err := issues_model.CreateSavedFilter(ctx, f); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.Flash.Error(ctx.Tr("user.saved_filters.invalid"))
+			ctx.Redirect(redirect)
+			return
+		}
+		ctx.ServerError("CreateSavedFilter", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("user.saved_filters.saved", f.Name))
+	ctx.Redirect(f.Link())
+}
+
+// DeleteSavedFilter deletes a saved filter of the doer
+func DeleteSavedFilter(ctx *context.Context) {
+	f, err := issues_model.GetSavedFilterByUserIDAndID(ctx, ctx.Doer.ID, ctx.ParamsInt64(":id"))
+	if err != nil {
+		ctx.NotFoundOrServerError("GetSavedFilterByUserIDAndID", issues_model.IsErrSavedFilterNotExist, err)
+		return
+	}
+	if err := issues_model.DeleteSavedFilter(ctx, f); err != nil {
+		ctx.ServerError("DeleteSavedFilter", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("user.saved_filters.deleted", f.Name))
+	if f.IsPull {
+		ctx.Redirect(setting.AppSubURL + "/pulls")
+		return
+	}
+	ctx.Redirect(setting.AppSubURL + "/issues")
+}
@@ -83,10 +83,13 @@ func AccountPost(ctx *context.Context) {
 		} else {
 			// Re-generate LTA cookie.
 			if len(ctx.GetSiteCookie(setting.CookieRememberName)) != 0 {
-				if err := ctx.SetLTACookie(ctx.Doer); err != nil {
+				lookupKey, err := ctx.SetLTACookie(ctx.Doer)
+				if err != nil {
 					ctx.ServerError("SetLTACookie", err)
 					return
 				}
+				// link the new token to the current session in the session registry
+				_ = ctx.Session.Set(auth.AuthTokenSessionKey, lookupKey)
 			}
 
 			log.Trace("User password updated: %s", ctx.Doer.Name)
@@ -5,15 +5,23 @@
 package setting
 
 import (
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
+	audit_model "forgejo.org/models/audit"
 	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
 	"forgejo.org/modules/web"
+	audit_service "forgejo.org/services/audit"
+	auth_service "forgejo.org/services/auth"
 	"forgejo.org/services/context"
+	"forgejo.org/services/convert"
 	"forgejo.org/services/forms"
 )
 
@@ -69,11 +77,45 @@ func ApplicationsPost(ctx *context.Context) {
 		return
 	}
 
+	if err := auth_service.SetAccessTokenResources(ctx, ctx.Doer, t, strings.Split(form.Repositories, ","), form.Organization); err != nil {
+		var errResource auth_service.ErrInvalidAccessTokenResource
+		if errors.As(err, &errResource) {
+			ctx.Flash.Error(ctx.Tr("settings.token_resource_invalid", errResource.Name))
+			ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
+			return
+		}
+		ctx.ServerError("SetAccessTokenResources", err)
+		return
+	}
+
+	if form.ExpiresAt != "" {
+		expiresAt, err := time.ParseInLocation("2006-01-02", form.ExpiresAt, setting.DefaultUILocation)
+		if err != nil {
+			ctx.Flash.Error(ctx.Tr("settings.token_expiry_invalid"))
+			ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
+			return
+		}
+		t.ExpiresUnix = timeutil.TimeStamp(expiresAt.Unix())
+	}
+
 	if err := auth_model.NewAccessToken(ctx, t); err != nil {
-		ctx.ServerError("NewAccessToken", err)
+		switch {
+		case errors.Is(err, auth_model.ErrAccessTokenExpiryRequired):
+			ctx.Flash.Error(ctx.Tr("settings.token_expiry_required"))
+		case errors.Is(err, auth_model.ErrAccessTokenExpiryInPast):
+			ctx.Flash.Error(ctx.Tr("settings.token_expiry_in_past"))
+		case errors.Is(err, auth_model.ErrAccessTokenLifetimeExceeded):
+			ctx.Flash.Error(ctx.Tr("settings.token_lifetime_exceeded", int(setting.AccessTokenMaxLifetime.Hours()/24)))
+		default:
+			ctx.ServerError("NewAccessToken", err)
+			return
+		}
+		ctx.Redirect(setting.AppSubURL + "/user/settings/applications")
 		return
 	}
 
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAccessTokenCreate, audit_service.AccessTokenTarget(t), nil, audit_service.AccessTokenState(t))
+
 	ctx.Flash.Success(ctx.Tr("settings.generate_token_success"))
 	ctx.Flash.Info(t.Token)
 
@@ -82,9 +124,11 @@ func ApplicationsPost(ctx *context.Context) {
 
 // DeleteApplication response for delete user access token
 func DeleteApplication(ctx *context.Context) {
-	if err := auth_model.DeleteAccessTokenByID(ctx, ctx.FormInt64("id"), ctx.Doer.ID); err != nil {
+	id := ctx.FormInt64("id")
+	if err := auth_model.DeleteAccessTokenByID(ctx, id, ctx.Doer.ID); err != nil {
 		ctx.Flash.Error("DeleteAccessTokenByID: " + err.Error())
 	} else {
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAccessTokenDelete, audit_service.Target{Type: audit_model.TargetAccessToken, ID: id}, nil, nil)
 		ctx.Flash.Success(ctx.Tr("settings.delete_token_success"))
 	}
 
@@ -101,6 +145,7 @@ func RegenerateApplication(ctx *context.Context) {
 			log.Error("DeleteAccessTokenByID", err)
 		}
 	} else {
+		audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionAccessTokenCreate, audit_service.AccessTokenTarget(t), nil, audit_service.AccessTokenState(t))
 		ctx.Flash.Success(ctx.Tr("settings.regenerate_token_success"))
 		ctx.Flash.Info(t.Token)
 	}
@@ -108,6 +153,25 @@ func RegenerateApplication(ctx *context.Context) {
 	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/applications")
 }
 
+// loadAccessTokenResources returns the names of the organizations and repositories the tokens are restricted to
+func loadAccessTokenResources(ctx *context.Context, tokens []*auth_model.AccessToken) (map[int64][]string, error) {
+	resources := make(map[int64][]string)
+	for _, t := range tokens {
+		if !t.IsRestricted() {
+			continue
+		}
+		apiToken, err := convert.ToAccessToken(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		if apiToken.Organization != "" {
+			resources[t.ID] = append(resources[t.ID], apiToken.Organization)
+		}
+		resources[t.ID] = append(resources[t.ID], apiToken.Repositories...)
+	}
+	return resources, nil
+}
+
 func loadApplicationsData(ctx *context.Context) {
 	ctx.Data["AccessTokenScopePublicOnly"] = auth_model.AccessTokenScopePublicOnly
 	tokens, err := db.Find[auth_model.AccessToken](ctx, auth_model.ListAccessTokensOptions{UserID: ctx.Doer.ID})
@@ -116,6 +180,14 @@ func loadApplicationsData(ctx *context.Context) {
 		return
 	}
 	ctx.Data["Tokens"] = tokens
+	ctx.Data["TokenResources"], err = loadAccessTokenResources(ctx, tokens)
+	if err != nil {
+		ctx.ServerError("loadAccessTokenResources", err)
+		return
+	}
+	if setting.AccessTokenMaxLifetime > 0 {
+		ctx.Data["TokenMaxExpiryDate"] = time.Now().Add(setting.AccessTokenMaxLifetime).In(setting.DefaultUILocation).Format("2006-01-02")
+	}
 	ctx.Data["EnableOAuth2"] = setting.OAuth2.Enabled
 	ctx.Data["IsAdmin"] = ctx.Doer.IsAdmin
 	if setting.OAuth2.Enabled {
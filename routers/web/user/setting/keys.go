@@ -7,16 +7,24 @@ package setting
 import (
 	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/base"
 	"forgejo.org/modules/setting"
+	ssh_module "forgejo.org/modules/ssh"
+	"forgejo.org/modules/util"
 	"forgejo.org/modules/web"
 	asymkey_service "forgejo.org/services/asymkey"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
+
+	gossh "golang.org/x/crypto/ssh"
 )
 
 const (
@@ -285,6 +293,55 @@ func DeleteKey(ctx *context.Context) {
 	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/keys")
 }
 
+// SSHCertificatePost issues a short-lived certificate for an SSH public key of the user
+func SSHCertificatePost(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.SSHCertificateForm)
+	ctx.Data["Title"] = ctx.Tr("settings.ssh_gpg_keys")
+	ctx.Data["PageIsSettingsKeys"] = true
+	ctx.Data["DisableSSH"] = setting.SSH.Disabled
+	ctx.Data["BuiltinSSH"] = setting.SSH.StartBuiltinServer
+	ctx.Data["AllowPrincipals"] = setting.SSH.AuthorizedPrincipalsEnabled
+	// the forms of the page post relative to it
+	ctx.Data["Link"] = setting.AppSubURL + "/user/settings/keys"
+
+	if ssh_module.CAPublicKey() == nil {
+		ctx.NotFound("SSHCertificatePost", ssh_module.ErrCADisabled)
+		return
+	}
+
+	loadKeysData(ctx)
+	if ctx.Written() {
+		return
+	}
+	if ctx.HasError() {
+		ctx.HTML(http.StatusOK, tplSettingsKeys)
+		return
+	}
+
+	cert, err := asymkey_service.IssueSSHCertificate(ctx, ctx.Doer, form.Content, time.Duration(form.Validity)*time.Hour)
+	if err != nil {
+		ctx.Data["Err_Content"] = true
+		switch {
+		case err == asymkey_model.ErrKeyIsPrivate:
+			ctx.RenderWithErr(ctx.Tr("form.must_use_public_key"), tplSettingsKeys, &form)
+		case asymkey_model.IsErrKeyAlreadyExist(err):
+			ctx.RenderWithErr(ctx.Tr("settings.ssh_certificate_principal_used", ctx.Doer.Name), tplSettingsKeys, &form)
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.RenderWithErr(ctx.Tr("form.invalid_ssh_key", err.Error()), tplSettingsKeys, &form)
+		default:
+			ctx.ServerError("IssueSSHCertificate", err)
+		}
+		return
+	}
+
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionSSHCertificateIssue,
+		audit_service.UserTarget(ctx.Doer), nil, audit_service.SSHCertificateState(cert))
+
+	ctx.Data["SSHCertificate"] = string(gossh.MarshalAuthorizedKey(cert))
+	ctx.Data["SSHCertificateValidBefore"] = time.Unix(int64(cert.ValidBefore), 0)
+	ctx.HTML(http.StatusOK, tplSettingsKeys)
+}
+
 func loadKeysData(ctx *context.Context) {
 	keys, err := db.Find[asymkey_model.PublicKey](ctx, asymkey_model.FindPublicKeyOptions{
 		OwnerID:    ctx.Doer.ID,
@@ -335,4 +392,10 @@ func loadKeysData(ctx *context.Context) {
 	ctx.Data["VerifyingID"] = ctx.FormString("verify_gpg")
 	ctx.Data["VerifyingFingerprint"] = ctx.FormString("verify_ssh")
 	ctx.Data["UserDisabledFeatures"] = user_model.DisabledFeaturesWithLoginType(ctx.Doer)
+
+	if caKey := ssh_module.CAPublicKey(); caKey != nil {
+		ctx.Data["SSHCAPublicKey"] = strings.TrimSpace(string(gossh.MarshalAuthorizedKey(caKey)))
+		ctx.Data["SSHCertificateValidity"] = max(1, int(setting.SSH.CACertificateValidity.Hours()))
+		ctx.Data["SSHCertificateMaxValidity"] = max(1, int(setting.SSH.CAMaxCertificateValidity.Hours()))
+	}
 }
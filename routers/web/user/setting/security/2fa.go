@@ -12,10 +12,12 @@ import (
 	"net/http"
 	"strings"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/web"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	"forgejo.org/services/mailer"
@@ -92,6 +94,7 @@ func disableTwoFactor(ctx *context.Context) {
 		}
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTwoFactorDisable, audit_service.UserTarget(ctx.Doer), nil, nil)
 
 	if err := mailer.SendDisabledTOTP(ctx, ctx.Doer); err != nil {
 		ctx.ServerError("SendDisabledTOTP", err)
@@ -261,6 +264,7 @@ func enrollTwoFactor(ctx *context.Context) {
 		ctx.ServerError("SettingsTwoFactor: Failed to save two factor", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionTwoFactorEnable, audit_service.UserTarget(ctx.Doer), nil, nil)
 
 	ctx.Flash.Success(ctx.Tr("settings.twofa_enrolled", token))
 	ctx.Redirect(setting.AppSubURL + "/user/settings/security")
@@ -0,0 +1,126 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package security
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/auth"
+	wa "forgejo.org/modules/auth/webauthn"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/web"
+	audit_service "forgejo.org/services/audit"
+	"forgejo.org/services/context"
+	"forgejo.org/services/forms"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// PasskeyRegister initializes the registration of a passkey, a discoverable credential that
+// verifies the user and is used to sign in without username and password
+func PasskeyRegister(ctx *context.Context) {
+	form := web.GetForm(ctx).(*forms.WebauthnRegistrationForm)
+	if form.Name == "" {
+		// Set name to the hexadecimal of the current time
+		form.Name = strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+
+	cred, err := auth.GetWebAuthnCredentialByName(ctx, ctx.Doer.ID, form.Name)
+	if err != nil && !auth.IsErrWebAuthnCredentialNotExist(err) {
+		ctx.ServerError("GetWebAuthnCredentialByName", err)
+		return
+	}
+	if cred != nil {
+		ctx.Error(http.StatusConflict, "Name already taken")
+		return
+	}
+
+	passkeys, err := auth.GetPasskeysByUID(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetPasskeysByUID", err)
+		return
+	}
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(passkeys))
+	for _, passkey := range passkeys.ToCredentials() {
+		exclusions = append(exclusions, passkey.Descriptor())
+	}
+
+	_ = ctx.Session.Delete("passkeyRegistration")
+	if err := ctx.Session.Set("passkeyName", form.Name); err != nil {
+		ctx.ServerError("Unable to set session key for passkeyName", err)
+		return
+	}
+
+	credentialOptions, sessionData, err := wa.WebAuthn.BeginRegistration((*wa.User)(ctx.Doer),
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			UserVerification:   protocol.VerificationRequired,
+		}),
+		webauthn.WithExclusions(exclusions),
+	)
+	if err != nil {
+		ctx.ServerError("Unable to BeginRegistration", err)
+		return
+	}
+
+	if err = ctx.Session.Set("passkeyRegistration", sessionData); err != nil {
+		ctx.ServerError("Unable to set session", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, credentialOptions)
+}
+
+// PasskeyRegisterPost receives the response of the authenticator
+func PasskeyRegisterPost(ctx *context.Context) {
+	name, ok := ctx.Session.Get("passkeyName").(string)
+	if !ok || name == "" {
+		ctx.ServerError("Get passkeyName", errors.New("no passkeyName"))
+		return
+	}
+
+	sessionData, ok := ctx.Session.Get("passkeyRegistration").(*webauthn.SessionData)
+	if !ok || sessionData == nil {
+		ctx.ServerError("Get registration", errors.New("no registration"))
+		return
+	}
+	defer func() {
+		_ = ctx.Session.Delete("passkeyRegistration")
+	}()
+
+	cred, err := wa.WebAuthn.FinishRegistration((*wa.User)(ctx.Doer), *sessionData, ctx.Req)
+	if err != nil {
+		if pErr, ok := err.(*protocol.Error); ok {
+			log.Error("Unable to finish passkey registration due to error: %v\nDevInfo: %s", pErr, pErr.DevInfo)
+		}
+		ctx.ServerError("CreatePasskey", err)
+		return
+	}
+
+	dbCred, err := auth.GetWebAuthnCredentialByName(ctx, ctx.Doer.ID, name)
+	if err != nil && !auth.IsErrWebAuthnCredentialNotExist(err) {
+		ctx.ServerError("GetWebAuthnCredentialByName", err)
+		return
+	}
+	if dbCred != nil {
+		ctx.Error(http.StatusConflict, "Name already taken")
+		return
+	}
+
+	dbCred, err = auth.CreatePasskey(ctx, ctx.Doer.ID, name, cred)
+	if err != nil {
+		ctx.ServerError("CreatePasskey", err)
+		return
+	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionSecurityKeyAdd, audit_service.SecurityKeyTarget(dbCred), nil, map[string]bool{"passkey": true})
+	_ = ctx.Session.Delete("passkeyName")
+
+	ctx.JSON(http.StatusCreated, cred)
+}
@@ -62,13 +62,20 @@ func loadSecurityData(ctx *context.Context) {
 	}
 	ctx.Data["TOTPEnrolled"] = enrolled
 
-	credentials, err := auth_model.GetWebAuthnCredentialsByUID(ctx, ctx.Doer.ID)
+	credentials, err := auth_model.GetSecurityKeysByUID(ctx, ctx.Doer.ID)
 	if err != nil {
-		ctx.ServerError("GetWebAuthnCredentialsByUID", err)
+		ctx.ServerError("GetSecurityKeysByUID", err)
 		return
 	}
 	ctx.Data["WebAuthnCredentials"] = credentials
 
+	passkeys, err := auth_model.GetPasskeysByUID(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("GetPasskeysByUID", err)
+		return
+	}
+	ctx.Data["Passkeys"] = passkeys
+
 	tokens, err := db.Find[auth_model.AccessToken](ctx, auth_model.ListAccessTokensOptions{UserID: ctx.Doer.ID})
 	if err != nil {
 		ctx.ServerError("ListAccessTokens", err)
@@ -9,11 +9,13 @@ import (
 	"strconv"
 	"time"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/auth"
 	wa "forgejo.org/modules/auth/webauthn"
 	"forgejo.org/modules/log"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/web"
+	audit_service "forgejo.org/services/audit"
 	"forgejo.org/services/context"
 	"forgejo.org/services/forms"
 	"forgejo.org/services/mailer"
@@ -100,11 +102,12 @@ func WebauthnRegisterPost(ctx *context.Context) {
 	}
 
 	// Create the credential
-	_, err = auth.CreateCredential(ctx, ctx.Doer.ID, name, cred)
+	dbCred, err = auth.CreateCredential(ctx, ctx.Doer.ID, name, cred)
 	if err != nil {
 		ctx.ServerError("CreateCredential", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionSecurityKeyAdd, audit_service.SecurityKeyTarget(dbCred), nil, map[string]bool{"passkey": false})
 	_ = ctx.Session.Delete("webauthnName")
 
 	ctx.JSON(http.StatusCreated, cred)
@@ -127,6 +130,7 @@ func WebauthnDelete(ctx *context.Context) {
 		ctx.ServerError("GetWebAuthnCredentialByID", err)
 		return
 	}
+	audit_service.Record(ctx, audit_service.NewActor(ctx.Doer, ctx.RemoteAddr()), audit_model.ActionSecurityKeyRemove, audit_service.SecurityKeyTarget(cred), map[string]bool{"passkey": cred.Passkey}, nil)
 
 	if err := mailer.SendRemovedSecurityKey(ctx, ctx.Doer, cred.Name); err != nil {
 		ctx.ServerError("SendRemovedSecurityKey", err)
@@ -0,0 +1,78 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package setting
+
+import (
+	"errors"
+	"net/http"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	auth_service "forgejo.org/services/auth"
+	"forgejo.org/services/context"
+)
+
+const (
+	tplSettingsSessions base.TplName = "user/settings/sessions"
+)
+
+// Sessions lists the sessions the user is signed in with and the OAuth2
+// applications the user authorized
+func Sessions(ctx *context.Context) {
+	ctx.Data["Title"] = ctx.Tr("settings.sessions.title")
+	ctx.Data["PageIsSettingsSessions"] = true
+
+	sessions, err := auth_service.ListSessions(ctx, ctx.Doer.ID)
+	if err != nil {
+		ctx.ServerError("ListSessions", err)
+		return
+	}
+	ctx.Data["Sessions"] = sessions
+	ctx.Data["CurrentSessionKeyHash"] = auth_model.HashSessionKey(ctx.Session.ID())
+
+	ctx.Data["EnableOAuth2"] = setting.OAuth2.Enabled
+	if setting.OAuth2.Enabled {
+		ctx.Data["Grants"], err = auth_model.GetOAuth2GrantsByUserID(ctx, ctx.Doer.ID)
+		if err != nil {
+			ctx.ServerError("GetOAuth2GrantsByUserID", err)
+			return
+		}
+	}
+
+	ctx.HTML(http.StatusOK, tplSettingsSessions)
+}
+
+// RevokeSession signs out one of the sessions of the user
+func RevokeSession(ctx *context.Context) {
+	s, err := auth_model.GetUserSessionByID(ctx, ctx.Doer.ID, ctx.FormInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.NotFound("GetUserSessionByID", err)
+		} else {
+			ctx.ServerError("GetUserSessionByID", err)
+		}
+		return
+	}
+
+	if err := auth_service.RevokeSession(ctx, s); err != nil {
+		ctx.ServerError("RevokeSession", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("settings.sessions.revoke_success"))
+	ctx.JSONRedirect(setting.AppSubURL + "/user/settings/sessions")
+}
+
+// RevokeOtherSessions signs out all sessions of the user except the current one
+func RevokeOtherSessions(ctx *context.Context) {
+	if err := auth_service.RevokeOtherSessions(ctx, ctx.Doer.ID, ctx.Session.ID()); err != nil {
+		ctx.ServerError("RevokeOtherSessions", err)
+		return
+	}
+
+	ctx.Flash.Success(ctx.Tr("settings.sessions.revoke_others_success"))
+	ctx.Redirect(setting.AppSubURL + "/user/settings/sessions")
+}
@@ -478,6 +478,7 @@ func registerRoutes(m *web.Route) {
 			m.Get("/nodeinfo", NodeInfoLinks)
 			m.Get("/webfinger", WebfingerQuery)
 		}, federationEnabled)
+		m.Get("/terraform.json", packagesEnabled, TerraformServiceDiscovery)
 		m.Get("/change-password", func(ctx *context.Context) {
 			ctx.Redirect(setting.AppSubURL + "/user/settings/account")
 		})
@@ -504,6 +505,8 @@ func registerRoutes(m *web.Route) {
 	m.Group("/issues", func() {
 		m.Get("", user.Issues)
 		m.Get("/search", repo.SearchIssues)
+		m.Post("/saved_filters", web.Bind(forms.SavedFilterForm{}), user.NewSavedFilter)
+		m.Post("/saved_filters/{id}/delete", user.DeleteSavedFilter)
 	}, reqSignIn)
 
 	if setting.Moderation.Enabled {
@@ -550,6 +553,10 @@ func registerRoutes(m *web.Route) {
 			m.Get("/assertion", auth.WebAuthnLoginAssertion)
 			m.Post("/assertion", auth.WebAuthnLoginAssertionPost)
 		})
+		m.Group("/passkey", func() {
+			m.Get("/assertion", auth.PasskeyLoginAssertion)
+			m.Post("/assertion", auth.PasskeyLoginAssertionPost)
+		})
 	}, reqSignOut)
 
 	m.Any("/user/events", routing.MarkLongPolling, events.Events)
@@ -560,6 +567,9 @@ func registerRoutes(m *web.Route) {
 			m.Post("/grant", web.Bind(forms.GrantApplicationForm{}), auth.GrantApplicationOAuth)
 			// TODO manage redirection
 			m.Post("/authorize", web.Bind(forms.AuthorizationForm{}), auth.AuthorizeOAuth)
+			m.Combo("/device").Get(auth.DeviceOAuth).
+				Post(web.Bind(forms.DeviceGrantForm{}), auth.DeviceVerifyOAuth)
+			m.Post("/device/grant", web.Bind(forms.DeviceGrantForm{}), auth.DeviceGrantOAuth)
 		}, reqSignIn)
 
 		m.Group("", func() {
@@ -567,6 +577,8 @@ func registerRoutes(m *web.Route) {
 			m.Methods("POST, OPTIONS", "/access_token", web.Bind(forms.AccessTokenForm{}), auth.AccessTokenOAuth)
 			m.Methods("GET, OPTIONS", "/keys", auth.OIDCKeys)
 			m.Methods("POST, OPTIONS", "/introspect", web.Bind(forms.IntrospectTokenForm{}), auth.IntrospectOAuth)
+			m.Methods("POST, OPTIONS", "/revoke", web.Bind(forms.RevokeTokenForm{}), auth.RevokeOAuth)
+			m.Methods("POST, OPTIONS", "/device_authorization", web.Bind(forms.DeviceAuthorizationForm{}), auth.DeviceAuthorizationOAuth)
 		}, optionsCorsHandler(), ignoreCSRF)
 	}, oauth2Enabled)
 
@@ -605,6 +617,10 @@ func registerRoutes(m *web.Route) {
 				m.Post("/register", security.WebauthnRegisterPost)
 				m.Post("/delete", web.Bind(forms.WebauthnDeleteForm{}), security.WebauthnDelete)
 			})
+			m.Group("/passkey", func() {
+				m.Post("/request_register", web.Bind(forms.WebauthnRegistrationForm{}), security.PasskeyRegister)
+				m.Post("/register", security.PasskeyRegisterPost)
+			})
 			m.Group("/openid", func() {
 				m.Post("", web.Bind(forms.AddOpenIDForm{}), security.OpenIDPost)
 				m.Post("/delete", security.DeleteOpenID)
@@ -613,6 +629,12 @@ func registerRoutes(m *web.Route) {
 			m.Post("/account_link", linkAccountEnabled, security.DeleteAccountLink)
 		}, requiredTwoFactor)
 
+		m.Group("/sessions", func() {
+			m.Get("", user_setting.Sessions)
+			m.Post("/revoke", user_setting.RevokeSession)
+			m.Post("/revoke_others", user_setting.RevokeOtherSessions)
+		})
+
 		m.Group("/applications", func() {
 			// oauth2 applications
 			m.Group("/oauth2", func() {
@@ -634,6 +656,7 @@ func registerRoutes(m *web.Route) {
 		m.Combo("/keys").Get(user_setting.Keys).
 			Post(web.Bind(forms.AddKeyForm{}), user_setting.KeysPost)
 		m.Post("/keys/delete", user_setting.DeleteKey)
+		m.Post("/keys/ssh_certificate", web.Bind(forms.SSHCertificateForm{}), user_setting.SSHCertificatePost)
 		m.Group("/packages", func() {
 			m.Get("", user_setting.Packages)
 			m.Group("/rules", func() {
@@ -702,6 +725,12 @@ func registerRoutes(m *web.Route) {
 			m.Get("/{provider}/callback", auth.SignInOAuthCallback)
 		})
 	})
+	// the identity provider posts the SAML response from another site
+	m.Group("/user/saml/{provider}", func() {
+		m.Get("", auth.SignInSAML)
+		m.Get("/metadata", auth.SAMLMetadata)
+		m.Post("/acs", auth.SignInSAMLACS)
+	}, ignoreCSRF)
 	// ***** END: User *****
 
 	m.Get("/avatar/{hash}", user.AvatarByEmailHash)
@@ -748,6 +777,7 @@ func registerRoutes(m *web.Route) {
 			m.Post("/{userid}/delete", admin.DeleteUser)
 			m.Post("/{userid}/avatar", web.Bind(forms.AvatarForm{}), admin.AvatarPost)
 			m.Post("/{userid}/avatar/delete", admin.DeleteAvatar)
+			m.Post("/{userid}/sessions/revoke", admin.RevokeUserSessions)
 		})
 
 		m.Group("/emails", func() {
@@ -793,8 +823,12 @@ func registerRoutes(m *web.Route) {
 			m.Combo("/{authid}").Get(admin.EditAuthSource).
 				Post(web.Bind(forms.AuthenticationForm{}), admin.EditAuthSourcePost)
 			m.Post("/{authid}/delete", admin.DeleteAuthSource)
+			m.Post("/{authid}/scim_token", admin.GenerateSCIMToken)
+			m.Post("/{authid}/scim_token/delete", admin.DeleteSCIMToken)
 		})
 
+		m.Get("/audit", admin.AuditEvents)
+
 		m.Group("/notices", func() {
 			m.Get("", admin.Notices)
 			m.Post("/delete", admin.DeleteNotices)
@@ -978,6 +1012,13 @@ func registerRoutes(m *web.Route) {
 					m.Post("/unblock", org_setting.BlockedUsersUnblock)
 				})
 				m.Get("/storage_overview", org_setting.StorageOverview)
+				m.Get("/audit", org_setting.AuditEvents)
+				m.Group("/automation", func() {
+					m.Get("", repo_setting.AutomationRules)
+					m.Combo("/new").Get(repo_setting.AutomationRuleNew).Post(web.Bind(forms.AutomationRuleForm{}), repo_setting.AutomationRuleNewPost)
+					m.Combo("/{id}").Get(repo_setting.AutomationRuleEdit).Post(web.Bind(forms.AutomationRuleForm{}), repo_setting.AutomationRuleEditPost)
+					m.Post("/{id}/delete", repo_setting.AutomationRuleDelete)
+				})
 
 				m.Group("/packages", func() {
 					m.Get("", org.Packages)
@@ -1037,6 +1078,7 @@ func registerRoutes(m *web.Route) {
 			m.Group("", func() {
 				m.Get("", org.Projects)
 				m.Get("/{id}", org.ViewProject)
+				m.Get("/{id}/views/{viewID}", org.ViewProject)
 			}, reqUnitAccess(unit.TypeProjects, perm.AccessModeRead, true))
 			m.Group("", func() { //nolint:dupl
 				m.Get("/new", org.RenderNewProject)
@@ -1048,6 +1090,17 @@ func registerRoutes(m *web.Route) {
 
 					m.Get("/edit", org.RenderEditProject)
 					m.Post("/edit", web.Bind(forms.CreateProjectForm{}), org.EditProjectPost)
+					m.Group("/views", func() {
+						m.Post("/new", web.Bind(forms.ProjectViewForm{}), project.NewView)
+						m.Post("/{viewID}/edit", web.Bind(forms.ProjectViewForm{}), project.EditView)
+						m.Post("/{viewID}/delete", project.DeleteView)
+					})
+					m.Group("/fields", func() {
+						m.Get("", org.ProjectCustomFields)
+						m.Post("/new", web.Bind(forms.CustomFieldForm{}), org.NewProjectCustomField)
+						m.Post("/{fieldID}/edit", web.Bind(forms.CustomFieldForm{}), org.EditProjectCustomField)
+						m.Post("/{fieldID}/delete", org.DeleteProjectCustomField)
+					})
 					m.Post("/{action:open|close}", org.ChangeProjectStatus)
 
 					m.Group("/{columnID}", func() {
@@ -1091,6 +1144,13 @@ func registerRoutes(m *web.Route) {
 					m.Post("/delete", repo_setting.DeleteTeam)
 				})
 			})
+			m.Get("/audit", repo_setting.AuditEvents)
+			m.Group("/automation", func() {
+				m.Get("", repo_setting.AutomationRules)
+				m.Combo("/new").Get(repo_setting.AutomationRuleNew).Post(web.Bind(forms.AutomationRuleForm{}), repo_setting.AutomationRuleNewPost)
+				m.Combo("/{id}").Get(repo_setting.AutomationRuleEdit).Post(web.Bind(forms.AutomationRuleForm{}), repo_setting.AutomationRuleEditPost)
+				m.Post("/{id}/delete", repo_setting.AutomationRuleDelete)
+			})
 
 			m.Group("/branches", func() {
 				m.Post("/", repo_setting.SetDefaultBranchPost)
@@ -1213,6 +1273,8 @@ func registerRoutes(m *web.Route) {
 				m.Get("/choose", context.RepoRef(), repo.NewIssueChooseTemplate)
 			})
 			m.Get("/search", repo.ListIssues)
+			m.Combo("/import", context.RequireRepoWriter(unit.TypeIssues)).Get(repo.ImportIssues).
+				Post(web.Bind(forms.ImportIssuesForm{}), repo.ImportIssuesPost)
 		}, context.RepoMustNotBeArchived(), reqRepoIssueReader)
 		// FIXME: should use different URLs but mostly same logic for comments of issue and pull request.
 		// So they can apply their own enable/disable logic on routers.
@@ -1242,7 +1304,13 @@ func registerRoutes(m *web.Route) {
 				m.Post("/reactions/{action}", web.Bind(forms.ReactionForm{}), repo.ChangeIssueReaction)
 				m.Post("/lock", reqRepoIssuesOrPullsWriter, web.Bind(forms.IssueLockForm{}), repo.LockIssue)
 				m.Post("/unlock", reqRepoIssuesOrPullsWriter, repo.UnlockIssue)
+				m.Post("/confidential", context.RequireRepoWriter(unit.TypeIssues), repo.UpdateIssueConfidential)
 				m.Post("/delete", reqRepoAdmin, repo.DeleteIssue)
+				m.Post("/custom_fields/{id}", repo.UpdateIssueCustomFieldValue)
+				m.Group("/sub_issues", func() {
+					m.Post("/add", repo.AddSubIssue)
+					m.Post("/remove", repo.RemoveSubIssue)
+				})
 			}, context.RepoMustNotBeArchived())
 			m.Group("/{index}", func() {
 				m.Get("/attachments", repo.GetIssueAttachments)
@@ -1281,6 +1349,11 @@ func registerRoutes(m *web.Route) {
 			m.Post("/delete", repo.DeleteLabel)
 			m.Post("/initialize", web.Bind(forms.InitializeLabelsForm{}), repo.InitializeLabels)
 		}, context.RepoMustNotBeArchived(), reqRepoIssuesOrPullsWriter, context.RepoRef())
+		m.Group("/custom_fields", func() {
+			m.Post("/new", web.Bind(forms.CustomFieldForm{}), repo.NewCustomField)
+			m.Post("/{id}/edit", web.Bind(forms.CustomFieldForm{}), repo.EditCustomField)
+			m.Post("/{id}/delete", repo.DeleteCustomField)
+		}, context.RepoMustNotBeArchived(), reqRepoIssuesOrPullsWriter, context.RepoRef())
 		m.Group("/milestones", func() {
 			m.Combo("/new").Get(repo.NewMilestone).
 				Post(web.Bind(forms.CreateMilestoneForm{}), repo.NewMilestonePost)
@@ -1367,6 +1440,25 @@ func registerRoutes(m *web.Route) {
 		}, reqSignIn, repo.MustBeNotEmpty, context.RepoMustNotBeArchived(), reqRepoReleaseWriter, repo.CommitInfoCache, context.EnforceQuotaWeb(quota_model.LimitSubjectSizeReposAll, context.QuotaTargetRepo))
 	}, ignSignIn, context.RepoAssignment, context.UnitTypes(), reqRepoReleaseReader)
 
+	// Security advisories
+	m.Group("/{username}/{reponame}/security/advisories", func() {
+		m.Get("", repo.SecurityAdvisories)
+		m.Combo("/new", reqSignIn, reqRepoAdmin, context.RepoMustNotBeArchived()).
+			Get(repo.NewSecurityAdvisory).
+			Post(web.Bind(forms.SecurityAdvisoryForm{}), repo.NewSecurityAdvisoryPost)
+		m.Group("/{identifier}", func() {
+			m.Get("", repo.ViewSecurityAdvisory)
+			m.Group("", func() {
+				m.Combo("/edit").
+					Get(repo.EditSecurityAdvisory).
+					Post(web.Bind(forms.SecurityAdvisoryForm{}), repo.EditSecurityAdvisoryPost)
+				m.Post("/fork", context.EnforceQuotaWeb(quota_model.LimitSubjectSizeReposAll, context.QuotaTargetRepo), repo.SecurityAdvisoryFork)
+				m.Post("/publish", repo.SecurityAdvisoryPublish)
+				m.Post("/delete", repo.DeleteSecurityAdvisory)
+			}, reqSignIn, reqRepoAdmin, context.RepoMustNotBeArchived())
+		})
+	}, ignSignIn, context.RepoAssignment, context.UnitTypes(), reqRepoCodeReader)
+
 	// to maintain compatibility with old attachments
 	m.Group("/{username}/{reponame}", func() {
 		m.Get("/attachments/{uuid}", repo.GetAttachment)
@@ -1379,6 +1471,8 @@ func registerRoutes(m *web.Route) {
 	m.Group("/{username}/{reponame}", func() {
 		m.Group("", func() {
 			m.Get("/issues/posters", repo.IssuePosters) // it can't use {type:issues|pulls} because other routes like "/pulls/{index}" has higher priority
+			m.Get("/issues/export", repo.ExportIssues(false))
+			m.Get("/pulls/export", repo.ExportIssues(true))
 			m.Get("/{type:^(issues|pulls)$}", repo.Issues)
 			m.Get("/{type:^(issues|pulls)$}/{index}", repo.ViewIssue)
 			m.Group("/{type:^(issues|pulls)$}/{index}/content-history", func() {
@@ -1387,6 +1481,7 @@ func registerRoutes(m *web.Route) {
 				m.Get("/detail", repo.GetContentHistoryDetail)
 			})
 			m.Get("/labels", reqRepoIssuesOrPullsReader, repo.RetrieveLabels, repo.Labels)
+			m.Get("/custom_fields", reqRepoIssuesOrPullsReader, repo.CustomFields)
 			m.Get("/milestones", reqRepoIssuesOrPullsReader, repo.Milestones)
 		}, context.RepoRef())
 
@@ -1417,6 +1512,7 @@ func registerRoutes(m *web.Route) {
 		m.Group("/projects", func() {
 			m.Get("", repo.Projects)
 			m.Get("/{id}", repo.ViewProject)
+			m.Get("/{id}/views/{viewID}", repo.ViewProject)
 			m.Group("", func() { //nolint:dupl
 				m.Get("/new", repo.RenderNewProject)
 				m.Post("/new", web.Bind(forms.CreateProjectForm{}), repo.NewProjectPost)
@@ -1428,6 +1524,11 @@ func registerRoutes(m *web.Route) {
 					m.Get("/edit", repo.RenderEditProject)
 					m.Post("/edit", web.Bind(forms.CreateProjectForm{}), repo.EditProjectPost)
 					m.Post("/{action:open|close}", repo.ChangeProjectStatus)
+					m.Group("/views", func() {
+						m.Post("/new", web.Bind(forms.ProjectViewForm{}), project.NewView)
+						m.Post("/{viewID}/edit", web.Bind(forms.ProjectViewForm{}), project.EditView)
+						m.Post("/{viewID}/delete", project.DeleteView)
+					})
 
 					m.Group("/{columnID}", func() {
 						m.Put("", web.Bind(forms.EditProjectColumnForm{}), repo.EditProjectColumn)
@@ -0,0 +1,184 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package advisory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	advisory_model "forgejo.org/models/advisory"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+	pull_service "forgejo.org/services/pull"
+	repo_service "forgejo.org/services/repository"
+)
+
+// CreateTemporaryFork creates the temporary private fork of a draft advisory, where its fix is developed.
+// The fork belongs to the owner of the repository and is deleted when the advisory is published.
+func CreateTemporaryFork(ctx context.Context, doer *user_model.User, advisory *advisory_model.Advisory) (*repo_model.Repository, error) {
+	if !advisory.IsDraft() {
+		return nil, util.NewInvalidArgumentErrorf("only the draft advisories can have a temporary fork")
+	}
+	if advisory.ForkRepoID != 0 {
+		return nil, util.NewAlreadyExistErrorf("the advisory %s already has a temporary fork", advisory.Identifier)
+	}
+	if err := advisory.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+	repo := advisory.Repo
+	if repo.IsEmpty {
+		return nil, util.NewInvalidArgumentErrorf("an empty repository can't be forked")
+	}
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+
+	fork, err := repo_service.ForkRepositoryAndUpdates(ctx, doer, repo.Owner, repo_service.ForkRepoOptions{
+		BaseRepo:          repo,
+		Name:              strings.ToLower(repo.Name + "-" + advisory.Identifier),
+		Description:       fmt.Sprintf("Temporary private fork for the security advisory %s", advisory.Identifier),
+		SingleBranch:      repo.DefaultBranch,
+		Private:           true,
+		AllowExistingFork: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	advisory.ForkRepoID = fork.ID
+	if err := advisory_model.UpdateAdvisoryCols(ctx, advisory, "fork_repo_id"); err != nil {
+		return nil, err
+	}
+	return fork, nil
+}
+
+// Publish merges the fix of a draft advisory from its temporary fork if it has one, deletes the fork,
+// makes the advisory public and notifies the watchers of the repository.
+func Publish(ctx context.Context, doer *user_model.User, advisory *advisory_model.Advisory) error {
+	if !advisory.IsDraft() {
+		return util.NewInvalidArgumentErrorf("the advisory %s is already published", advisory.Identifier)
+	}
+	if err := advisory.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	if advisory.ForkRepoID != 0 {
+		fork, err := repo_model.GetRepositoryByID(ctx, advisory.ForkRepoID)
+		if err != nil {
+			return err
+		}
+		if advisory.FixCommitID, err = mergeFix(ctx, doer, advisory, fork); err != nil {
+			return fmt.Errorf("merge the fix of %s: %w", advisory.Identifier, err)
+		}
+		if err := repo_service.DeleteRepository(ctx, doer, fork, true); err != nil {
+			return err
+		}
+		advisory.ForkRepoID = 0
+	}
+
+	advisory.State = advisory_model.StatePublished
+	advisory.PublishedUnix = timeutil.TimeStampNow()
+	if err := advisory_model.UpdateAdvisoryCols(ctx, advisory, "state", "published_unix", "fork_repo_id", "fix_commit_id"); err != nil {
+		return err
+	}
+
+	notify_service.PublishSecurityAdvisory(ctx, doer, advisory)
+	return nil
+}
+
+// mergeFix merges the default branch of the temporary fork of an advisory into the one of the repository
+// with a pull request, and returns the merge commit, or nothing if the fork has no new commit
+func mergeFix(ctx context.Context, doer *user_model.User, advisory *advisory_model.Advisory, fork *repo_model.Repository) (string, error) {
+	repo := advisory.Repo
+
+	forkGitRepo, err := gitrepo.OpenRepository(ctx, fork)
+	if err != nil {
+		return "", err
+	}
+	defer forkGitRepo.Close()
+	headCommitID, err := forkGitRepo.GetBranchCommitID(fork.DefaultBranch)
+	if err != nil {
+		return "", err
+	}
+
+	baseGitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+	defer baseGitRepo.Close()
+	if baseGitRepo.IsCommitExist(headCommitID) {
+		return "", nil
+	}
+
+	prUnit, err := repo.GetUnit(ctx, unit.TypePullRequests)
+	if err != nil {
+		return "", util.NewInvalidArgumentErrorf("the pull requests must be enabled to merge the fix")
+	}
+	mergeStyle := prUnit.PullRequestsConfig().GetDefaultMergeStyle()
+
+	issue := &issues_model.Issue{
+		RepoID:   repo.ID,
+		Repo:     repo,
+		Title:    fmt.Sprintf("Fix %s: %s", advisory.Identifier, advisory.Title),
+		PosterID: doer.ID,
+		Poster:   doer,
+		IsPull:   true,
+		Content:  fmt.Sprintf("Fix of the security advisory [%s](%s).", advisory.Identifier, advisory.HTMLURL()),
+	}
+	pr := &issues_model.PullRequest{
+		HeadRepoID: fork.ID,
+		BaseRepoID: repo.ID,
+		HeadBranch: fork.DefaultBranch,
+		BaseBranch: repo.DefaultBranch,
+		HeadRepo:   fork,
+		BaseRepo:   repo,
+		Type:       issues_model.PullRequestGitea,
+	}
+	if err := pull_service.NewPullRequest(ctx, repo, issue, nil, nil, pr, nil); err != nil {
+		return "", err
+	}
+
+	message, body, err := pull_service.GetDefaultMergeMessage(ctx, baseGitRepo, pr, mergeStyle)
+	if err != nil {
+		return "", err
+	}
+	if body != "" {
+		message += "\n\n" + body
+	}
+	if err := pull_service.Merge(ctx, pr, doer, baseGitRepo, mergeStyle, headCommitID, message, false); err != nil {
+		return "", err
+	}
+
+	pr, err = issues_model.GetPullRequestByID(ctx, pr.ID)
+	if err != nil {
+		return "", err
+	}
+	return pr.MergedCommitID, nil
+}
+
+// Delete deletes a draft advisory and its temporary fork
+func Delete(ctx context.Context, doer *user_model.User, advisory *advisory_model.Advisory) error {
+	if !advisory.IsDraft() {
+		return util.NewInvalidArgumentErrorf("the published advisories can't be deleted")
+	}
+	if advisory.ForkRepoID != 0 {
+		fork, err := repo_model.GetRepositoryByID(ctx, advisory.ForkRepoID)
+		if err != nil && !repo_model.IsErrRepoNotExist(err) {
+			return err
+		}
+		if fork != nil {
+			if err := repo_service.DeleteRepository(ctx, doer, fork, true); err != nil {
+				return err
+			}
+		}
+	}
+	return advisory_model.DeleteAdvisory(ctx, advisory)
+}
@@ -0,0 +1,76 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package asymkey
+
+import (
+	"context"
+	"time"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	ssh_module "forgejo.org/modules/ssh"
+	"forgejo.org/modules/util"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// IssueSSHCertificate issues a short-lived user certificate for the public key
+// of the doer. The principal of the certificate is the user name, it is
+// registered as a principal of the user so both the built-in SSH server and
+// OpenSSH with the authorized principals file accept the certificate. A zero
+// validity issues the certificate for the configured default duration.
+func IssueSSHCertificate(ctx context.Context, doer *user_model.User, content string, validity time.Duration) (*gossh.Certificate, error) {
+	if ssh_module.CAPublicKey() == nil {
+		return nil, ssh_module.ErrCADisabled
+	}
+
+	if validity == 0 {
+		validity = setting.SSH.CACertificateValidity
+	}
+	if validity < 0 || validity > setting.SSH.CAMaxCertificateValidity {
+		return nil, util.NewInvalidArgumentErrorf("certificates can be valid for at most %s", setting.SSH.CAMaxCertificateValidity)
+	}
+
+	content, err := asymkey_model.CheckPublicKeyString(content)
+	if err == asymkey_model.ErrKeyIsPrivate {
+		return nil, err
+	} else if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid public key: %v", err)
+	}
+	pub, _, _, _, err := gossh.ParseAuthorizedKey([]byte(content))
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid public key: %v", err)
+	}
+	if _, ok := pub.(*gossh.Certificate); ok {
+		return nil, util.NewInvalidArgumentErrorf("a public key is required, not a certificate")
+	}
+
+	if err := registerUsernamePrincipal(ctx, doer); err != nil {
+		return nil, err
+	}
+
+	cert, err := ssh_module.SignUserCertificate(pub, "forgejo-"+doer.Name, []string{doer.Name}, time.Now().Add(validity))
+	if err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+// registerUsernamePrincipal makes sure the user name is a principal of the user
+func registerUsernamePrincipal(ctx context.Context, u *user_model.User) error {
+	key, err := asymkey_model.SearchPublicKeyByContentExact(ctx, u.Name)
+	if err == nil {
+		if key.Type != asymkey_model.KeyTypePrincipal || key.OwnerID != u.ID {
+			return asymkey_model.ErrKeyAlreadyExist{OwnerID: key.OwnerID, Content: u.Name}
+		}
+		return nil
+	} else if !asymkey_model.IsErrKeyNotExist(err) {
+		return err
+	}
+
+	_, err = asymkey_model.AddPrincipalKey(ctx, u.ID, u.Name, 0)
+	return err
+}
@@ -6,13 +6,18 @@ package attachment
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 
+	blob_model "forgejo.org/models/blob"
 	"forgejo.org/models/db"
 	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/setting"
 	"forgejo.org/modules/storage"
 	"forgejo.org/modules/util"
+	"forgejo.org/modules/util/filebuffer"
 	"forgejo.org/modules/validation"
 	"forgejo.org/services/context/upload"
 
@@ -27,6 +32,19 @@ func NewAttachment(ctx context.Context, attach *repo_model.Attachment, file io.R
 
 	err := db.WithTx(ctx, func(ctx context.Context) error {
 		attach.UUID = uuid.New().String()
+
+		if setting.Blobs.Enabled {
+			buf, hash, err := createHashedBuffer(file)
+			if err != nil {
+				return fmt.Errorf("Create: %w", err)
+			}
+			defer buf.Close()
+
+			attach.HashSHA256 = hash
+			file = buf
+			size = buf.Size()
+		}
+
 		size, err := storage.Attachments.Save(attach.RelativePath(), file, size)
 		if err != nil {
 			return fmt.Errorf("Create: %w", err)
@@ -37,13 +55,25 @@ func NewAttachment(ctx context.Context, attach *repo_model.Attachment, file io.R
 		if attach.NoAutoTime {
 			eng.NoAutoTime()
 		}
-		_, err = eng.Insert(attach)
-		return err
+		if _, err = eng.Insert(attach); err != nil {
+			return err
+		}
+		return blob_model.AddReference(ctx, attach.HashSHA256, attach.Size)
 	})
 
 	return attach, err
 }
 
+// createHashedBuffer copies the content into a buffer to calculate its sha256 hash before it gets stored
+func createHashedBuffer(r io.Reader) (*filebuffer.FileBackedBuffer, string, error) {
+	h := sha256.New()
+	buf, err := filebuffer.CreateFromReader(io.TeeReader(r, h), 32*1024*1024)
+	if err != nil {
+		return nil, "", err
+	}
+	return buf, hex.EncodeToString(h.Sum(nil)), nil
+}
+
 func NewExternalAttachment(ctx context.Context, attach *repo_model.Attachment) (*repo_model.Attachment, error) {
 	if attach.RepoID == 0 {
 		return nil, fmt.Errorf("attachment %s should belong to a repository", attach.Name)
@@ -4,14 +4,22 @@
 package attachment
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	blob_model "forgejo.org/models/blob"
 	"forgejo.org/models/db"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/storage"
+	"forgejo.org/modules/test"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,3 +51,52 @@ func TestUploadAttachment(t *testing.T) {
 	assert.Equal(t, user.ID, attachment.UploaderID)
 	assert.Equal(t, int64(0), attachment.DownloadCount)
 }
+
+func TestUploadAttachmentDeduplicated(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	blobs, err := storage.NewLocalStorage(t.Context(), &setting.Storage{Path: t.TempDir()})
+	require.NoError(t, err)
+
+	defer test.MockVariableValue(&setting.Blobs.Enabled, true)()
+	defer test.MockVariableValue(&storage.Blobs, blobs)()
+	defer test.MockVariableValue(&storage.Attachments, storage.ObjectStorage(storage.NewDeduplicatedStorage(blobs, storage.Attachments)))()
+
+	content := "deduplicated content"
+	sum := sha256.Sum256([]byte(content))
+	hash := hex.EncodeToString(sum[:])
+
+	attachments := make([]*repo_model.Attachment, 0, 2)
+	for range 2 {
+		attach, err := NewAttachment(db.DefaultContext, &repo_model.Attachment{
+			RepoID:     1,
+			UploaderID: 1,
+			Name:       "file.txt",
+		}, strings.NewReader(content), -1)
+		require.NoError(t, err)
+		assert.Equal(t, hash, attach.HashSHA256)
+		assert.EqualValues(t, len(content), attach.Size)
+		attachments = append(attachments, attach)
+	}
+
+	cb, err := blob_model.GetBlobByHash(db.DefaultContext, hash)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, cb.RefCount)
+	assert.EqualValues(t, len(content), cb.Size)
+
+	_, err = blobs.Stat(storage.BlobRelativePath(hash))
+	require.NoError(t, err)
+
+	require.NoError(t, repo_model.DeleteAttachment(db.DefaultContext, attachments[0], true))
+
+	cb, err = blob_model.GetBlobByHash(db.DefaultContext, hash)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, cb.RefCount)
+
+	f, err := storage.Attachments.Open(attachments[1].RelativePath())
+	require.NoError(t, err)
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
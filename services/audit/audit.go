@@ -0,0 +1,285 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit
+
+import (
+	"context"
+	"net"
+	"time"
+
+	audit_model "forgejo.org/models/audit"
+	auth_model "forgejo.org/models/auth"
+	git_model "forgejo.org/models/git"
+	org_model "forgejo.org/models/organization"
+	repo_model "forgejo.org/models/repo"
+	secret_model "forgejo.org/models/secret"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/web/middleware"
+	"forgejo.org/services/convert"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Actor is the user who performed an audited action and the address of the client
+// the request came from. Doer is nil for anonymous actions such as failed sign-in attempts.
+type Actor struct {
+	Doer      *user_model.User
+	IPAddress string
+}
+
+// NewActor returns the actor of a request, the remote address may include a port
+func NewActor(doer *user_model.User, remoteAddr string) Actor {
+	return Actor{Doer: doer, IPAddress: remoteIP(remoteAddr)}
+}
+
+// ActorFromContext returns the actor of the request a context was derived from,
+// the address is empty outside of a request
+func ActorFromContext(ctx context.Context, doer *user_model.User) Actor {
+	return NewActor(doer, middleware.GetRemoteAddr(ctx))
+}
+
+// Target is the object an audit event acted on and the scope it belongs to
+type Target struct {
+	Type    audit_model.TargetType
+	ID      int64
+	Name    string
+	OwnerID int64
+	RepoID  int64
+}
+
+// UserTarget returns the target for a user or an organization
+func UserTarget(u *user_model.User) Target {
+	if u.IsOrganization() {
+		return Target{Type: audit_model.TargetOrganization, ID: u.ID, Name: u.Name, OwnerID: u.ID}
+	}
+	return Target{Type: audit_model.TargetUser, ID: u.ID, Name: u.Name}
+}
+
+// UserState returns the audited state of a user account
+func UserState(u *user_model.User) map[string]any {
+	return map[string]any{
+		"name":                 u.Name,
+		"email":                u.Email,
+		"login_source":         u.LoginSource,
+		"login_name":           u.LoginName,
+		"is_active":            u.IsActive,
+		"is_admin":             u.IsAdmin,
+		"is_restricted":        u.IsRestricted,
+		"prohibit_login":       u.ProhibitLogin,
+		"visibility":           u.Visibility.String(),
+		"is_rate_limit_exempt": u.IsRateLimitExempt,
+	}
+}
+
+// RepoTarget returns the target for a repository
+func RepoTarget(repo *repo_model.Repository) Target {
+	return Target{Type: audit_model.TargetRepository, ID: repo.ID, Name: repo.FullName(), OwnerID: repo.OwnerID, RepoID: repo.ID}
+}
+
+// RepoState returns the audited state of a repository
+func RepoState(repo *repo_model.Repository) map[string]any {
+	return map[string]any{
+		"name":       repo.FullName(),
+		"is_private": repo.IsPrivate,
+	}
+}
+
+// CollaboratorTarget returns the target for a collaborator of a repository
+func CollaboratorTarget(repo *repo_model.Repository, uid int64, name string) Target {
+	return Target{Type: audit_model.TargetUser, ID: uid, Name: name, OwnerID: repo.OwnerID, RepoID: repo.ID}
+}
+
+// TeamTarget returns the target for a team of an organization
+func TeamTarget(team *org_model.Team) Target {
+	return Target{Type: audit_model.TargetTeam, ID: team.ID, Name: team.Name, OwnerID: team.OrgID}
+}
+
+// TeamRepoTarget returns the target for the access of a team to a repository
+func TeamRepoTarget(team *org_model.Team, repoID int64) Target {
+	return Target{Type: audit_model.TargetTeam, ID: team.ID, Name: team.Name, OwnerID: team.OrgID, RepoID: repoID}
+}
+
+// TeamState returns the audited state of a team, the permissions of the units
+// are only part of it if they are loaded
+func TeamState(team *org_model.Team) map[string]any {
+	state := map[string]any{
+		"name":                      team.Name,
+		"access_mode":               team.AccessMode.String(),
+		"includes_all_repositories": team.IncludesAllRepositories,
+		"can_create_org_repo":       team.CanCreateOrgRepo,
+	}
+	if team.Units != nil {
+		units := make(map[string]string, len(team.Units))
+		for _, u := range team.Units {
+			units[u.Type.String()] = u.AccessMode.String()
+		}
+		state["units"] = units
+	}
+	return state
+}
+
+// AccessTokenTarget returns the target for an access token of a user, tokens
+// restricted to an organization are part of its audit log
+func AccessTokenTarget(token *auth_model.AccessToken) Target {
+	return Target{Type: audit_model.TargetAccessToken, ID: token.ID, Name: token.Name, OwnerID: token.OrgID}
+}
+
+// AccessTokenState returns the audited state of an access token
+func AccessTokenState(token *auth_model.AccessToken) map[string]any {
+	return map[string]any{
+		"scope":    token.Scope,
+		"org_id":   token.OrgID,
+		"repo_ids": token.RepoIDs,
+		"expires":  token.ExpiresUnix,
+	}
+}
+
+// SecurityKeyTarget returns the target for a WebAuthn credential of a user
+func SecurityKeyTarget(cred *auth_model.WebAuthnCredential) Target {
+	return Target{Type: audit_model.TargetSecurityKey, ID: cred.ID, Name: cred.Name}
+}
+
+// SSHCertificateState returns the audited state of an SSH certificate issued to a user
+func SSHCertificateState(cert *gossh.Certificate) map[string]any {
+	return map[string]any{
+		"serial":       cert.Serial,
+		"fingerprint":  gossh.FingerprintSHA256(cert.Key),
+		"valid_before": time.Unix(int64(cert.ValidBefore), 0).UTC(),
+	}
+}
+
+// BranchProtectionTarget returns the target for a branch protection rule of a repository
+func BranchProtectionTarget(repo *repo_model.Repository, rule *git_model.ProtectedBranch) Target {
+	return Target{Type: audit_model.TargetBranchProtection, ID: rule.ID, Name: rule.RuleName, OwnerID: repo.OwnerID, RepoID: repo.ID}
+}
+
+// BranchProtectionState returns the audited state of a branch protection rule
+func BranchProtectionState(rule *git_model.ProtectedBranch) map[string]any {
+	return map[string]any{
+		"rule_name":                         rule.RuleName,
+		"can_push":                          rule.CanPush,
+		"enable_whitelist":                  rule.EnableWhitelist,
+		"whitelist_user_ids":                rule.WhitelistUserIDs,
+		"whitelist_team_ids":                rule.WhitelistTeamIDs,
+		"whitelist_deploy_keys":             rule.WhitelistDeployKeys,
+		"enable_merge_whitelist":            rule.EnableMergeWhitelist,
+		"merge_whitelist_user_ids":          rule.MergeWhitelistUserIDs,
+		"merge_whitelist_team_ids":          rule.MergeWhitelistTeamIDs,
+		"enable_status_check":               rule.EnableStatusCheck,
+		"status_check_contexts":             rule.StatusCheckContexts,
+		"enable_approvals_whitelist":        rule.EnableApprovalsWhitelist,
+		"approvals_whitelist_user_ids":      rule.ApprovalsWhitelistUserIDs,
+		"approvals_whitelist_team_ids":      rule.ApprovalsWhitelistTeamIDs,
+		"required_approvals":                rule.RequiredApprovals,
+		"block_on_rejected_reviews":         rule.BlockOnRejectedReviews,
+		"block_on_official_review_requests": rule.BlockOnOfficialReviewRequests,
+		"block_on_outdated_branch":          rule.BlockOnOutdatedBranch,
+		"dismiss_stale_approvals":           rule.DismissStaleApprovals,
+		"ignore_stale_approvals":            rule.IgnoreStaleApprovals,
+		"require_signed_commits":            rule.RequireSignedCommits,
+		"protected_file_patterns":           rule.ProtectedFilePatterns,
+		"unprotected_file_patterns":         rule.UnprotectedFilePatterns,
+		"apply_to_admins":                   rule.ApplyToAdmins,
+	}
+}
+
+// SecretTarget returns the target for a secret of a user, an organization or a repository
+func SecretTarget(secret *secret_model.Secret) Target {
+	return Target{Type: audit_model.TargetSecret, ID: secret.ID, Name: secret.Name, OwnerID: secret.OwnerID, RepoID: secret.RepoID}
+}
+
+// AuthSourceTarget returns the target for an authentication source
+func AuthSourceTarget(source *auth_model.Source) Target {
+	return Target{Type: audit_model.TargetAuthSource, ID: source.ID, Name: source.Name}
+}
+
+// AuthSourceState returns the audited state of an authentication source,
+// its configuration is left out as it contains credentials
+func AuthSourceState(source *auth_model.Source) map[string]any {
+	return map[string]any{
+		"name":            source.Name,
+		"type":            source.Type.String(),
+		"is_active":       source.IsActive,
+		"is_sync_enabled": source.IsSyncEnabled,
+	}
+}
+
+// SystemTarget returns the target for instance wide actions
+func SystemTarget(name string) Target {
+	return Target{Type: audit_model.TargetSystem, Name: name}
+}
+
+// Record appends an event of actor acting on target to the audit log and
+// forwards it to the configured sink. The states before and after the change
+// are stored as JSON objects, either can be nil.
+// Recording never fails the action that is audited, errors are logged.
+func Record(ctx context.Context, actor Actor, action audit_model.Action, target Target, before, after any) {
+	if !setting.Audit.Enabled {
+		return
+	}
+
+	e := &audit_model.Event{
+		Action:     action,
+		IPAddress:  actor.IPAddress,
+		OwnerID:    target.OwnerID,
+		RepoID:     target.RepoID,
+		TargetType: target.Type,
+		TargetID:   target.ID,
+		TargetName: target.Name,
+		Before:     marshalState(before),
+		After:      marshalState(after),
+	}
+	if actor.Doer != nil {
+		e.ActorID = actor.Doer.ID
+		e.ActorName = actor.Doer.Name
+	}
+
+	// the event must be recorded even if the client went away in the meantime
+	if err := audit_model.InsertEvent(context.WithoutCancel(ctx), e); err != nil {
+		log.Error("Unable to record audit event %s for %s %d: %v", action, target.Type, target.ID, err)
+		return
+	}
+
+	streamEvent(e)
+}
+
+func remoteIP(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+func marshalState(state any) string {
+	if state == nil {
+		return ""
+	}
+	bs, err := json.Marshal(state)
+	if err != nil {
+		log.Error("Unable to marshal audit event state: %v", err)
+		return ""
+	}
+	// a nil map or pointer means there is no state either
+	if string(bs) == "null" {
+		return ""
+	}
+	return string(bs)
+}
+
+func streamEvent(e *audit_model.Event) {
+	if currentSink == nil {
+		return
+	}
+	bs, err := json.Marshal(convert.ToAuditEvent(e))
+	if err != nil {
+		log.Error("Unable to marshal audit event %d: %v", e.ID, err)
+		return
+	}
+	if err := currentSink.Write(bs); err != nil {
+		log.Error("Unable to stream audit event %d: %v", e.ID, err)
+	}
+}
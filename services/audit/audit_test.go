@@ -0,0 +1,77 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+	"forgejo.org/modules/web/middleware"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Audit.Enabled, true)()
+
+	logPath := filepath.Join(t.TempDir(), "audit", "audit.log")
+	fs, err := newFileSink(logPath)
+	require.NoError(t, err)
+	defer test.MockVariableValue[sink](&currentSink, fs)()
+	defer fs.Close()
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	var before map[string]any
+	Record(db.DefaultContext, NewActor(doer, "127.0.0.1:3000"), audit_model.ActionRepoVisibility, RepoTarget(repo), before, RepoState(repo))
+
+	e := unittest.AssertExistsAndLoadBean(t, &audit_model.Event{Action: audit_model.ActionRepoVisibility, RepoID: repo.ID})
+	assert.Equal(t, doer.ID, e.ActorID)
+	assert.Equal(t, "127.0.0.1", e.IPAddress)
+	assert.Equal(t, repo.OwnerID, e.OwnerID)
+	assert.Equal(t, audit_model.TargetRepository, e.TargetType)
+	assert.Empty(t, e.Before)
+	assert.NotEmpty(t, e.After)
+
+	content, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 1)
+	var streamed api.AuditEvent
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &streamed))
+	assert.Equal(t, e.ID, streamed.ID)
+	assert.Equal(t, "repository.visibility", streamed.Action)
+	assert.Equal(t, repo.FullName(), streamed.TargetName)
+	assert.Nil(t, streamed.Before)
+	assert.Equal(t, repo.IsPrivate, streamed.After["is_private"])
+}
+
+func TestActorFromContext(t *testing.T) {
+	ctx := context.WithValue(t.Context(), middleware.RemoteAddrContextKey, "[::1]:3000")
+	assert.Equal(t, Actor{IPAddress: "::1"}, ActorFromContext(ctx, nil))
+	assert.Empty(t, ActorFromContext(t.Context(), nil).IPAddress)
+}
+
+func TestRecordDisabled(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Audit.Enabled, false)()
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	Record(db.DefaultContext, NewActor(doer, "127.0.0.1:3000"), audit_model.ActionAdminCronRun, SystemTarget("test_disabled"), nil, nil)
+	unittest.AssertNotExistsBean(t, &audit_model.Event{Action: audit_model.ActionAdminCronRun, TargetName: "test_disabled"})
+}
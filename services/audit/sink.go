@@ -0,0 +1,89 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package audit
+
+import (
+	"fmt"
+	"log/syslog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"forgejo.org/modules/setting"
+)
+
+// sink receives every recorded event as a single line of JSON
+type sink interface {
+	Write(line []byte) error
+	Close() error
+}
+
+var currentSink sink
+
+// Init opens the sink the audit log is streamed to
+func Init() error {
+	if !setting.Audit.Enabled {
+		return nil
+	}
+
+	var err error
+	switch setting.Audit.Sink {
+	case "file":
+		currentSink, err = newFileSink(setting.Audit.SinkFile)
+	case "syslog":
+		currentSink, err = newSyslogSink(setting.Audit.SyslogNetwork, setting.Audit.SyslogAddress, setting.Audit.SyslogTag)
+	}
+	return err
+}
+
+// fileSink appends JSON lines to a file
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileSink(path string) (*fileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("unable to create directory for the audit log %s: %w", path, err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the audit log %s: %w", path, err)
+	}
+	return &fileSink{file: f}, nil
+}
+
+func (s *fileSink) Write(line []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.file.Write(append(line, '\n'))
+	return err
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// syslogSink sends every event as a message to a syslog daemon
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+func newSyslogSink(network, address, tag string) (*syslogSink, error) {
+	w, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_AUTHPRIV, tag)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to syslog for the audit log: %w", err)
+	}
+	return &syslogSink{writer: w}, nil
+}
+
+func (s *syslogSink) Write(line []byte) error {
+	return s.writer.Info(string(line))
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/organization"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+)
+
+// ErrInvalidAccessTokenResource represents a repository or organization a token can't be restricted to
+type ErrInvalidAccessTokenResource struct {
+	Name string
+}
+
+func (err ErrInvalidAccessTokenResource) Error() string {
+	return fmt.Sprintf("repository or organization %q does not exist or is not accessible", err.Name)
+}
+
+func (err ErrInvalidAccessTokenResource) Unwrap() error {
+	return util.ErrNotExist
+}
+
+// SetAccessTokenResources restricts the token to the repositories given by their full names
+// and to the repositories of the organization. The doer must have access to all of them.
+func SetAccessTokenResources(ctx context.Context, doer *user_model.User, t *auth_model.AccessToken, repoNames []string, orgName string) error {
+	for _, fullName := range repoNames {
+		fullName = strings.TrimSpace(fullName)
+		if fullName == "" {
+			continue
+		}
+		ownerName, repoName, ok := strings.Cut(fullName, "/")
+		if !ok {
+			return ErrInvalidAccessTokenResource{fullName}
+		}
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return ErrInvalidAccessTokenResource{fullName}
+			}
+			return err
+		}
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return err
+		}
+		if !perm.HasAccess() {
+			return ErrInvalidAccessTokenResource{fullName}
+		}
+		if !slices.Contains(t.RepoIDs, repo.ID) {
+			t.RepoIDs = append(t.RepoIDs, repo.ID)
+		}
+	}
+
+	if orgName = strings.TrimSpace(orgName); orgName != "" {
+		org, err := organization.GetOrgByName(ctx, orgName)
+		if err != nil {
+			if organization.IsErrOrgNotExist(err) {
+				return ErrInvalidAccessTokenResource{orgName}
+			}
+			return err
+		}
+		isMember, err := org.IsOrgMember(ctx, doer.ID)
+		if err != nil {
+			return err
+		}
+		if !isMember {
+			return ErrInvalidAccessTokenResource{orgName}
+		}
+		t.OrgID = org.ID
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 
+	auth_model "forgejo.org/models/auth"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/auth/webauthn"
 	"forgejo.org/modules/log"
@@ -83,6 +84,7 @@ func handleSignIn(resp http.ResponseWriter, req *http.Request, sess SessionStore
 	if err != nil {
 		log.Error(fmt.Sprintf("Error setting session: %v", err))
 	}
+	_ = sess.Set(LoginMethodSessionKey, auth_model.LoginMethodReverseProxy)
 
 	// Language setting of the user overwrites the one previously set
 	// If the user does not have a locale set, we save the current one.
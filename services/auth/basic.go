@@ -102,7 +102,10 @@ func (b *Basic) Verify(req *http.Request, w http.ResponseWriter, store DataStore
 
 		store.GetData()["IsApiToken"] = true
 		store.GetData()["ApiTokenScope"] = token.Scope
+		store.GetData()["ApiAccessToken"] = token
 		return u, nil
+	} else if auth_model.IsErrAccessTokenExpired(err) {
+		log.Trace("Basic Authorization: %v", err)
 	} else if !auth_model.IsErrAccessTokenNotExist(err) && !auth_model.IsErrAccessTokenEmpty(err) {
 		log.Error("GetAccessTokenBySha: %v", err)
 	}
@@ -146,7 +146,8 @@ func parseToken(req *http.Request) (string, bool) {
 
 // userIDFromToken returns the user id corresponding to the OAuth token.
 // It will set 'IsApiToken' to true if the token is an API token and
-// set 'ApiTokenScope' to the scope of the access token
+// set 'ApiTokenScope' to the scope of the access token. Personal access tokens
+// are also stored as 'ApiAccessToken' to enforce their resource restrictions.
 func (o *OAuth2) userIDFromToken(ctx context.Context, tokenSHA string, store DataStore) int64 {
 	// Let's see if token is valid.
 	if strings.Contains(tokenSHA, ".") {
@@ -184,6 +185,8 @@ func (o *OAuth2) userIDFromToken(ctx context.Context, tokenSHA string, store Dat
 
 				return user_model.ActionsUserID
 			}
+		} else if auth_model.IsErrAccessTokenExpired(err) {
+			log.Trace("OAuth2 Authorization: %v", err)
 		} else if !auth_model.IsErrAccessTokenNotExist(err) && !auth_model.IsErrAccessTokenEmpty(err) {
 			log.Error("GetAccessTokenBySHA: %v", err)
 		}
@@ -194,6 +197,7 @@ func (o *OAuth2) userIDFromToken(ctx context.Context, tokenSHA string, store Dat
 	}
 	store.GetData()["IsApiToken"] = true
 	store.GetData()["ApiTokenScope"] = t.Scope
+	store.GetData()["ApiAccessToken"] = t
 	return t.UID
 }
 
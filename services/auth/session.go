@@ -55,6 +55,17 @@ func (s *Session) Verify(req *http.Request, w http.ResponseWriter, store DataSto
 		return nil, nil
 	}
 
+	revoked, err := checkSessionRegistry(req, sess, user.ID)
+	if err != nil {
+		log.Error("checkSessionRegistry: %v", err)
+		return nil, err
+	}
+	if revoked {
+		log.Trace("Session Authorization: Session of user %-v has been revoked", user)
+		_ = sess.Delete("uid")
+		return nil, nil
+	}
+
 	log.Trace("Session Authorization: Logged in user %-v", user)
 	return user, nil
 }
@@ -0,0 +1,208 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/modules/util"
+)
+
+const (
+	// LoginMethodSessionKey is the session key the sign-in handlers store
+	// the way the user signed in with, it is recorded in the session registry.
+	LoginMethodSessionKey = "login_method"
+	// AuthTokenSessionKey is the session key of the lookup key of the
+	// "remember me" token the session was created with or has issued.
+	AuthTokenSessionKey = "auth_token_key"
+
+	// registeredSessionKey holds the key hash the session was registered
+	// with, a registered session without registry entry has expired.
+	registeredSessionKey = "registered_session"
+
+	// lastSeenInterval limits how often the last activity of a session is stored
+	lastSeenInterval = time.Minute
+)
+
+type sessionWithID interface {
+	ID() string
+}
+
+// checkSessionRegistry registers the signed-in session of the user with the
+// given ID, or updates its last activity. It reports whether the session has
+// been revoked.
+func checkSessionRegistry(req *http.Request, sess SessionStore, uid int64) (revoked bool, err error) {
+	idSess, ok := sess.(sessionWithID)
+	if !ok {
+		return false, nil
+	}
+	ctx := req.Context()
+	keyHash := auth_model.HashSessionKey(idSess.ID())
+	tokenKey, _ := sess.Get(AuthTokenSessionKey).(string)
+
+	registered, _ := sess.Get(registeredSessionKey).(string)
+
+	s, err := auth_model.GetUserSessionByKeyHash(ctx, keyHash)
+	if errors.Is(err, util.ErrNotExist) {
+		if registered == keyHash {
+			return true, nil
+		}
+
+		// sessions are registered on their first request after signing in,
+		// this also covers the sessions that predate the registry
+		method, _ := sess.Get(LoginMethodSessionKey).(string)
+		if method == "" {
+			method = auth_model.LoginMethodUnknown
+		}
+		s = &auth_model.UserSession{
+			UID:          uid,
+			KeyHash:      keyHash,
+			AuthTokenKey: tokenKey,
+			LoginMethod:  method,
+			UserAgent:    req.UserAgent(),
+			IPAddress:    remoteIP(req.RemoteAddr),
+			LastSeenUnix: timeutil.TimeStampNow(),
+		}
+		if err = auth_model.InsertUserSession(ctx, s); err == nil {
+			return false, sess.Set(registeredSessionKey, keyHash)
+		}
+		// a concurrent request of the same session may have registered it
+		// already, use its entry which may even have been revoked meanwhile
+		log.Trace("Unable to register session of user %d: %v", uid, err)
+		s, err = auth_model.GetUserSessionByKeyHash(ctx, keyHash)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if s.UID != uid || s.IsRevoked() {
+		return true, nil
+	}
+	if registered != keyHash {
+		if err := sess.Set(registeredSessionKey, keyHash); err != nil {
+			return false, err
+		}
+	}
+
+	now := timeutil.TimeStampNow()
+	ip := remoteIP(req.RemoteAddr)
+	if now.AsTime().Sub(s.LastSeenUnix.AsTime()) < lastSeenInterval && s.IPAddress == ip && s.AuthTokenKey == tokenKey {
+		return false, nil
+	}
+	s.LastSeenUnix = now
+	s.IPAddress = ip
+	s.UserAgent = req.UserAgent()
+	s.AuthTokenKey = tokenKey
+	return false, auth_model.UpdateUserSessionActivity(ctx, s)
+}
+
+func remoteIP(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// inactiveSince returns the time before which sessions have expired
+func inactiveSince() timeutil.TimeStamp {
+	return timeutil.TimeStampNow().Add(-setting.SessionConfig.Maxlifetime)
+}
+
+// ListSessions returns the sessions the user is signed in with
+func ListSessions(ctx context.Context, uid int64) ([]*auth_model.UserSession, error) {
+	since := inactiveSince()
+	if err := auth_model.DeleteInactiveUserSessions(ctx, uid, since); err != nil {
+		return nil, err
+	}
+	return auth_model.FindUserSessions(ctx, uid, since)
+}
+
+// IsCurrentSession reports whether s is the session with the given ID
+func IsCurrentSession(s *auth_model.UserSession, sessionID string) bool {
+	return s.KeyHash == auth_model.HashSessionKey(sessionID)
+}
+
+// RevokeSession signs out a session and invalidates its "remember me" token
+func RevokeSession(ctx context.Context, s *auth_model.UserSession) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := auth_model.RevokeUserSessions(ctx, s.UID, s.ID); err != nil {
+			return err
+		}
+		if s.AuthTokenKey == "" {
+			return nil
+		}
+		return auth_model.DeleteLongTermAuthTokenByLookupKey(ctx, s.UID, s.AuthTokenKey)
+	})
+}
+
+// RevokeSessionByID signs out the current session, if it is registered
+func RevokeSessionByID(ctx context.Context, uid int64, sessionID string) error {
+	s, err := auth_model.GetUserSessionByKeyHash(ctx, auth_model.HashSessionKey(sessionID))
+	if errors.Is(err, util.ErrNotExist) || (s != nil && s.UID != uid) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return RevokeSession(ctx, s)
+}
+
+// RevokeOtherSessions signs out all sessions of the user except the one
+// with the given ID, and invalidates all other "remember me" tokens
+func RevokeOtherSessions(ctx context.Context, uid int64, sessionID string) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		sessions, err := auth_model.FindUserSessions(ctx, uid, 0)
+		if err != nil {
+			return err
+		}
+		var keep []string
+		var revoke []int64
+		for _, s := range sessions {
+			if IsCurrentSession(s, sessionID) {
+				if s.AuthTokenKey != "" {
+					keep = append(keep, s.AuthTokenKey)
+				}
+				continue
+			}
+			revoke = append(revoke, s.ID)
+		}
+		if len(revoke) > 0 {
+			if err := auth_model.RevokeUserSessions(ctx, uid, revoke...); err != nil {
+				return err
+			}
+		}
+		return auth_model.DeleteLongTermAuthTokens(ctx, uid, keep...)
+	})
+}
+
+// RevokeAllSessions signs out the user everywhere: all sessions, "remember
+// me" tokens and OAuth2 grants are revoked
+func RevokeAllSessions(ctx context.Context, uid int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := auth_model.RevokeUserSessions(ctx, uid); err != nil {
+			return err
+		}
+		if err := auth_model.DeleteLongTermAuthTokens(ctx, uid); err != nil {
+			return err
+		}
+		grants, err := auth_model.GetOAuth2GrantsByUserID(ctx, uid)
+		if err != nil {
+			return err
+		}
+		for _, grant := range grants {
+			if err := auth_model.RevokeOAuth2Grant(ctx, grant.ID, uid); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -37,6 +37,10 @@ func DeleteSource(ctx context.Context, source *auth.Source) error {
 		}
 	}
 
+	if err := auth.DeleteSCIMDataBySourceID(ctx, source.ID); err != nil {
+		return err
+	}
+
 	_, err = db.GetEngine(ctx).ID(source.ID).Delete(new(auth.Source))
 	return err
 }
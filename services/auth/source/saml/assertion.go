@@ -0,0 +1,182 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"encoding/base64"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"forgejo.org/modules/cache"
+	"forgejo.org/modules/container"
+
+	"github.com/beevik/etree"
+	"github.com/crewjam/saml"
+)
+
+const assertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+var (
+	// ErrUnencryptedAssertion is returned if the source requires encrypted assertions but the response contains a plaintext one
+	ErrUnencryptedAssertion = errors.New("the SAML response contains an unencrypted assertion")
+	// ErrMissingNameID is returned if the assertion does not identify its subject
+	ErrMissingNameID = errors.New("the SAML assertion has no NameID")
+	// ErrReplayedAssertion is returned if the assertion, or another one answering the same request, was already used
+	ErrReplayedAssertion = errors.New("the SAML assertion has already been used")
+)
+
+// replayCache remembers the consumed assertions until they expire
+type replayCache interface {
+	IsExist(key string) bool
+	Put(key string, val any, timeout int64) error
+}
+
+// The lock only protects against concurrent responses handled by this process,
+// instances sharing a cache may accept a response posted to both at once.
+var replayMutexMap cache.MutexMap
+
+// ExternalUser holds the user information of a validated assertion
+type ExternalUser struct {
+	NameID      string
+	Username    string
+	Email       string
+	FullName    string
+	Groups      container.Set[string]
+	QuotaGroups container.Set[string]
+	Attributes  map[string][]string
+}
+
+// ParseResponse decodes the base64 encoded SAMLResponse posted to the assertion consumer service
+// and returns its assertion once the signature, the conditions and the request ID are validated.
+// Encrypted assertions are decrypted with the service provider private key.
+func (source *Source) ParseResponse(sp *saml.ServiceProvider, samlResponse string, possibleRequestIDs []string) (*saml.Assertion, error) {
+	raw, err := base64.StdEncoding.DecodeString(samlResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.RequireEncryptedAssertions {
+		doc := etree.NewDocument()
+		if err := doc.ReadFromBytes(raw); err != nil {
+			return nil, err
+		}
+		if doc.Root() == nil {
+			return nil, errors.New("the SAML response is empty")
+		}
+		for _, el := range doc.Root().ChildElements() {
+			if el.Tag == "Assertion" && el.NamespaceURI() == assertionNamespace {
+				return nil, ErrUnencryptedAssertion
+			}
+		}
+	}
+
+	assertion, err := sp.ParseXMLResponse(raw, possibleRequestIDs, sp.AcsURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := consumeAssertion(cache.GetCache(), assertion, time.Now()); err != nil {
+		return nil, err
+	}
+	return assertion, nil
+}
+
+// consumeAssertion records the ID of the assertion and of the request it answers
+// as used until the assertion expires, it fails if either was used already.
+func consumeAssertion(c replayCache, assertion *saml.Assertion, now time.Time) error {
+	if c == nil {
+		return errors.New("no cache to protect against replayed SAML assertions")
+	}
+	if assertion.ID == "" {
+		return errors.New("the SAML assertion has no ID")
+	}
+
+	// the assertion is accepted as long as its issue instant is recent enough and
+	// its conditions hold, both with some leeway for the clock skew
+	expires := assertion.IssueInstant.Add(saml.MaxIssueDelay)
+	if assertion.Conditions != nil && assertion.Conditions.NotOnOrAfter.After(expires) {
+		expires = assertion.Conditions.NotOnOrAfter
+	}
+	keys := []string{"saml_assertion:" + assertion.Issuer.Value + ":" + assertion.ID}
+	if assertion.Subject != nil {
+		for _, confirmation := range assertion.Subject.SubjectConfirmations {
+			data := confirmation.SubjectConfirmationData
+			if data == nil {
+				continue
+			}
+			if data.NotOnOrAfter.After(expires) {
+				expires = data.NotOnOrAfter
+			}
+			if key := "saml_request:" + data.InResponseTo; data.InResponseTo != "" && !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	ttl := int64(expires.Add(saml.MaxClockSkew).Sub(now)/time.Second) + 1
+
+	for _, key := range keys {
+		defer replayMutexMap.Lock(key)()
+	}
+	for _, key := range keys {
+		if c.IsExist(key) {
+			return ErrReplayedAssertion
+		}
+	}
+	for _, key := range keys {
+		if err := c.Put(key, true, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExternalUser maps the subject and the attributes of the assertion to the user information
+func (source *Source) ExternalUser(assertion *saml.Assertion) (*ExternalUser, error) {
+	if assertion.Subject == nil || assertion.Subject.NameID == nil || strings.TrimSpace(assertion.Subject.NameID.Value) == "" {
+		return nil, ErrMissingNameID
+	}
+
+	attributes := make(map[string][]string)
+	for _, statement := range assertion.AttributeStatements {
+		for _, attr := range statement.Attributes {
+			values := make([]string, 0, len(attr.Values))
+			for _, v := range attr.Values {
+				values = append(values, strings.TrimSpace(v.Value))
+			}
+			attributes[attr.Name] = append(attributes[attr.Name], values...)
+			if attr.FriendlyName != "" && attr.FriendlyName != attr.Name {
+				attributes[attr.FriendlyName] = append(attributes[attr.FriendlyName], values...)
+			}
+		}
+	}
+
+	first := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		for _, v := range attributes[name] {
+			if v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	set := func(name string) container.Set[string] {
+		if name == "" {
+			return nil
+		}
+		return container.SetOf(attributes[name]...)
+	}
+
+	return &ExternalUser{
+		NameID:      strings.TrimSpace(assertion.Subject.NameID.Value),
+		Username:    first(source.AttributeUsername),
+		Email:       first(source.AttributeEmail),
+		FullName:    first(source.AttributeFullName),
+		Groups:      set(source.AttributeGroups),
+		QuotaGroups: set(source.AttributeQuotaGroups),
+		Attributes:  attributes,
+	}, nil
+}
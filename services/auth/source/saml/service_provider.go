@@ -0,0 +1,222 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"forgejo.org/modules/proxy"
+	"forgejo.org/modules/setting"
+
+	"github.com/crewjam/saml"
+	"github.com/crewjam/saml/samlsp"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+// metadataCacheDuration is how long fetched identity provider metadata is reused
+const metadataCacheDuration = time.Hour
+
+var (
+	// ErrMissingIdentityProviderMetadata is returned if neither the metadata nor its URL are configured
+	ErrMissingIdentityProviderMetadata = errors.New("identity provider metadata or metadata URL must be set")
+
+	metadataCacheMutex sync.Mutex
+	metadataCache      = map[string]*cachedMetadata{}
+)
+
+// ErrInvalidConfig represents a "InvalidConfig" kind of error.
+type ErrInvalidConfig struct {
+	SourceName string
+	Cause      error
+}
+
+// IsErrInvalidConfig checks if an error is a ErrInvalidConfig.
+func IsErrInvalidConfig(err error) bool {
+	_, ok := err.(ErrInvalidConfig)
+	return ok
+}
+
+func (err ErrInvalidConfig) Error() string {
+	return fmt.Sprintf("Failed to initialize SAML service provider with name '%s': %v", err.SourceName, err.Cause)
+}
+
+func (err ErrInvalidConfig) Unwrap() error {
+	return err.Cause
+}
+
+type cachedMetadata struct {
+	descriptor *saml.EntityDescriptor
+	fetched    time.Time
+}
+
+// ServiceProviderURL returns the base URL of the service provider endpoints of the named source
+func ServiceProviderURL(sourceName string) string {
+	return setting.AppURL + "user/saml/" + url.PathEscape(sourceName)
+}
+
+// identityProviderMetadata returns the configured or fetched metadata of the identity provider
+func (source *Source) identityProviderMetadata(ctx context.Context) (*saml.EntityDescriptor, error) {
+	if strings.TrimSpace(source.IdentityProviderMetadata) != "" {
+		return samlsp.ParseMetadata([]byte(source.IdentityProviderMetadata))
+	}
+	if source.IdentityProviderMetadataURL == "" {
+		return nil, ErrMissingIdentityProviderMetadata
+	}
+
+	metadataCacheMutex.Lock()
+	defer metadataCacheMutex.Unlock()
+
+	if cached, ok := metadataCache[source.IdentityProviderMetadataURL]; ok && time.Since(cached.fetched) < metadataCacheDuration {
+		return cached.descriptor, nil
+	}
+
+	metadataURL, err := url.Parse(source.IdentityProviderMetadataURL)
+	if err != nil || (metadataURL.Scheme != "http" && metadataURL.Scheme != "https") {
+		return nil, fmt.Errorf("invalid identity provider metadata URL: %s", source.IdentityProviderMetadataURL)
+	}
+
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{Proxy: proxy.Proxy()},
+	}
+	descriptor, err := samlsp.FetchMetadata(ctx, client, *metadataURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching identity provider metadata from %s: %w", source.IdentityProviderMetadataURL, err)
+	}
+	metadataCache[source.IdentityProviderMetadataURL] = &cachedMetadata{descriptor: descriptor, fetched: time.Now()}
+	return descriptor, nil
+}
+
+// ServiceProvider creates the SAML service provider for this source
+func (source *Source) ServiceProvider(ctx context.Context) (*saml.ServiceProvider, error) {
+	keyPair, err := tls.X509KeyPair([]byte(source.ServiceProviderCertificate), []byte(source.ServiceProviderPrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid service provider certificate or private key: %w", err)
+	}
+	certificate, err := x509.ParseCertificate(keyPair.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := keyPair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("service provider private key can not be used for signing")
+	}
+
+	idpMetadata, err := source.identityProviderMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := ServiceProviderURL(source.authSource.Name)
+	metadataURL, err := url.Parse(baseURL + "/metadata")
+	if err != nil {
+		return nil, err
+	}
+	acsURL, err := url.Parse(baseURL + "/acs")
+	if err != nil {
+		return nil, err
+	}
+
+	sp := &saml.ServiceProvider{
+		Key:               signer,
+		Certificate:       certificate,
+		MetadataURL:       *metadataURL,
+		AcsURL:            *acsURL,
+		IDPMetadata:       idpMetadata,
+		AuthnNameIDFormat: saml.UnspecifiedNameIDFormat,
+	}
+	if source.NameIDFormat != "" {
+		sp.AuthnNameIDFormat = saml.NameIDFormat(source.NameIDFormat)
+	}
+
+	if source.SignRequests {
+		switch signer.(type) {
+		case *rsa.PrivateKey:
+			sp.SignatureMethod = dsig.RSASHA256SignatureMethod
+		case *ecdsa.PrivateKey:
+			sp.SignatureMethod = dsig.ECDSASHA256SignatureMethod
+		default:
+			return nil, fmt.Errorf("unsupported service provider private key type %T", signer)
+		}
+	}
+	return sp, nil
+}
+
+// RegisterSource validates the configuration of the source on creation and update
+func (source *Source) RegisterSource() error {
+	source.UnregisterSource()
+	if _, err := source.ServiceProvider(context.Background()); err != nil {
+		return ErrInvalidConfig{SourceName: source.authSource.Name, Cause: err}
+	}
+	return nil
+}
+
+// UnregisterSource drops the cached identity provider metadata of the source
+func (source *Source) UnregisterSource() error {
+	if source.IdentityProviderMetadataURL != "" {
+		metadataCacheMutex.Lock()
+		delete(metadataCache, source.IdentityProviderMetadataURL)
+		metadataCacheMutex.Unlock()
+	}
+	return nil
+}
+
+// GenerateServiceProviderKeyPair generates a self-signed certificate and private key
+// to sign AuthnRequests and decrypt assertions, both PEM encoded
+func GenerateServiceProviderKeyPair(commonName string) (certificate, privateKey string, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 3072)
+	if err != nil {
+		return "", "", err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", "", err
+	}
+
+	notBefore := time.Now().Add(-time.Hour)
+	template := x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return "", "", err
+	}
+
+	certificate = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	privateKey = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	return certificate, privateKey, nil
+}
+
+// EnsureServiceProviderKeyPair generates the service provider certificate and private key if none are configured
+func (source *Source) EnsureServiceProviderKeyPair(sourceName string) error {
+	if source.ServiceProviderCertificate != "" || source.ServiceProviderPrivateKey != "" {
+		return nil
+	}
+
+	var err error
+	source.ServiceProviderCertificate, source.ServiceProviderPrivateKey, err = GenerateServiceProviderKeyPair(ServiceProviderURL(sourceName))
+	return err
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"forgejo.org/models/auth"
+	"forgejo.org/modules/json"
+)
+
+// Source holds configuration for the SAML login source.
+type Source struct {
+	// IdentityProviderMetadata is the XML metadata of the identity provider,
+	// IdentityProviderMetadataURL is used to fetch it if it is empty.
+	IdentityProviderMetadata    string
+	IdentityProviderMetadataURL string
+
+	// ServiceProviderCertificate and ServiceProviderPrivateKey are PEM encoded,
+	// they are used to sign AuthnRequests and to decrypt assertions.
+	ServiceProviderCertificate string
+	ServiceProviderPrivateKey  string
+	SignRequests               bool
+	RequireEncryptedAssertions bool
+	NameIDFormat               string
+	IconURL                    string
+
+	AttributeUsername    string
+	AttributeEmail       string
+	AttributeFullName    string
+	AttributeGroups      string
+	AdminGroup           string
+	RestrictedGroup      string
+	GroupTeamMap         string
+	GroupTeamMapRemoval  bool
+	AttributeQuotaGroups string
+	QuotaGroupMap        string
+	QuotaGroupMapRemoval bool
+	SkipLocalTwoFA       bool `json:",omitempty"`
+
+	// reference to the authSource
+	authSource *auth.Source
+}
+
+// FromDB fills up a SAML source from serialized format.
+func (source *Source) FromDB(bs []byte) error {
+	return json.UnmarshalHandleDoubleEncode(bs, &source)
+}
+
+// ToDB exports a SAML source to a serialized format.
+func (source *Source) ToDB() ([]byte, error) {
+	return json.Marshal(source)
+}
+
+// SetAuthSource sets the related AuthSource
+func (source *Source) SetAuthSource(authSource *auth.Source) {
+	source.authSource = authSource
+}
+
+func init() {
+	auth.RegisterTypeConfig(auth.SAML, &Source{})
+}
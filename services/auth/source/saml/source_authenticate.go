@@ -0,0 +1,19 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"context"
+
+	user_model "forgejo.org/models/user"
+	"forgejo.org/services/auth/source/db"
+)
+
+// Authenticate falls back to the db authenticator
+func (source *Source) Authenticate(ctx context.Context, user *user_model.User, login, password string) (*user_model.User, error) {
+	return db.Authenticate(ctx, user, login, password)
+}
+
+// NB: SAML does not implement LocalTwoFASkipper for password authentication
+// as its password authentication drops to db authentication
@@ -0,0 +1,203 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package saml
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"forgejo.org/models/auth"
+	"forgejo.org/modules/cache"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/beevik/etree"
+	"github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestIdentityProvider(t *testing.T) *saml.IdentityProvider {
+	certificate, privateKey, err := GenerateServiceProviderKeyPair("idp.example.com")
+	require.NoError(t, err)
+	keyPair, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey))
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(keyPair.Certificate[0])
+	require.NoError(t, err)
+
+	metadataURL, _ := url.Parse("https://idp.example.com/metadata")
+	ssoURL, _ := url.Parse("https://idp.example.com/sso")
+	return &saml.IdentityProvider{
+		Key:         keyPair.PrivateKey,
+		Certificate: cert,
+		MetadataURL: *metadataURL,
+		SSOURL:      *ssoURL,
+	}
+}
+
+func newTestSource(t *testing.T, idp *saml.IdentityProvider) *Source {
+	metadata, err := xml.Marshal(idp.Metadata())
+	require.NoError(t, err)
+
+	source := &Source{
+		IdentityProviderMetadata: string(metadata),
+		AttributeUsername:        "uid",
+		AttributeEmail:           "mail",
+		AttributeFullName:        "displayName",
+		AttributeGroups:          "memberOf",
+		AttributeQuotaGroups:     "quota",
+	}
+	require.NoError(t, source.EnsureServiceProviderKeyPair("my idp"))
+	source.SetAuthSource(&auth.Source{Name: "my idp"})
+	return source
+}
+
+// makeResponse returns a signed SAMLResponse of the identity provider as posted to the assertion consumer service
+func makeResponse(t *testing.T, idp *saml.IdentityProvider, sp *saml.ServiceProvider, requestID string, encrypt bool) string {
+	spMetadata := sp.Metadata()
+	descriptor := &spMetadata.SPSSODescriptors[0]
+	if !encrypt {
+		var keyDescriptors []saml.KeyDescriptor
+		for _, kd := range descriptor.KeyDescriptors {
+			if kd.Use != "encryption" {
+				keyDescriptors = append(keyDescriptors, kd)
+			}
+		}
+		descriptor.KeyDescriptors = keyDescriptors
+	}
+
+	req := &saml.IdpAuthnRequest{
+		IDP:                     idp,
+		HTTPRequest:             httptest.NewRequest(http.MethodPost, "https://idp.example.com/sso", nil),
+		Request:                 saml.AuthnRequest{ID: requestID},
+		ServiceProviderMetadata: spMetadata,
+		SPSSODescriptor:         descriptor,
+		ACSEndpoint:             &descriptor.AssertionConsumerServices[0],
+		Now:                     time.Now(),
+	}
+	attribute := func(name string, values ...string) saml.Attribute {
+		attr := saml.Attribute{Name: name, NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"}
+		for _, v := range values {
+			attr.Values = append(attr.Values, saml.AttributeValue{Type: "xs:string", Value: v})
+		}
+		return attr
+	}
+	session := &saml.Session{
+		ID:           "session",
+		CreateTime:   time.Now(),
+		ExpireTime:   time.Now().Add(time.Hour),
+		NameID:       "jdoe@example.com",
+		NameIDFormat: string(saml.EmailAddressNameIDFormat),
+		CustomAttributes: []saml.Attribute{
+			attribute("uid", "jdoe"),
+			attribute("mail", "jdoe@example.com"),
+			attribute("displayName", "John Doe"),
+			attribute("memberOf", "developers", "admins"),
+			attribute("quota", "large"),
+		},
+	}
+	require.NoError(t, saml.DefaultAssertionMaker{}.MakeAssertion(req, session))
+	require.NoError(t, req.MakeResponse())
+
+	doc := etree.NewDocument()
+	doc.SetRoot(req.ResponseEl)
+	raw, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestServiceProvider(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppURL, "https://forgejo.example.com/")()
+
+	source := newTestSource(t, newTestIdentityProvider(t))
+	sp, err := source.ServiceProvider(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://forgejo.example.com/user/saml/my%20idp/metadata", sp.MetadataURL.String())
+	assert.Equal(t, "https://forgejo.example.com/user/saml/my%20idp/acs", sp.AcsURL.String())
+	assert.Equal(t, "https://idp.example.com/sso", sp.GetSSOBindingLocation(saml.HTTPRedirectBinding))
+	assert.Empty(t, sp.SignatureMethod)
+
+	source.SignRequests = true
+	sp, err = source.ServiceProvider(t.Context())
+	require.NoError(t, err)
+	assert.NotEmpty(t, sp.SignatureMethod)
+
+	source.IdentityProviderMetadata = ""
+	_, err = source.ServiceProvider(t.Context())
+	require.ErrorIs(t, err, ErrMissingIdentityProviderMetadata)
+	assert.True(t, IsErrInvalidConfig(source.RegisterSource()))
+}
+
+func TestParseResponse(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppURL, "https://forgejo.example.com/")()
+	require.NoError(t, cache.Init())
+
+	idp := newTestIdentityProvider(t)
+	source := newTestSource(t, idp)
+	sp, err := source.ServiceProvider(t.Context())
+	require.NoError(t, err)
+
+	t.Run("Encrypted", func(t *testing.T) {
+		source.RequireEncryptedAssertions = true
+		defer func() { source.RequireEncryptedAssertions = false }()
+
+		assertion, err := source.ParseResponse(sp, makeResponse(t, idp, sp, "id-1", true), []string{"id-1"})
+		require.NoError(t, err)
+
+		user, err := source.ExternalUser(assertion)
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe@example.com", user.NameID)
+		assert.Equal(t, "jdoe", user.Username)
+		assert.Equal(t, "jdoe@example.com", user.Email)
+		assert.Equal(t, "John Doe", user.FullName)
+		assert.True(t, user.Groups.Contains("developers"))
+		assert.True(t, user.Groups.Contains("admins"))
+		assert.True(t, user.QuotaGroups.Contains("large"))
+	})
+
+	t.Run("Unencrypted", func(t *testing.T) {
+		response := makeResponse(t, idp, sp, "id-2", false)
+
+		assertion, err := source.ParseResponse(sp, response, []string{"id-2"})
+		require.NoError(t, err)
+		user, err := source.ExternalUser(assertion)
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe", user.Username)
+
+		source.RequireEncryptedAssertions = true
+		defer func() { source.RequireEncryptedAssertions = false }()
+		_, err = source.ParseResponse(sp, response, []string{"id-2"})
+		require.ErrorIs(t, err, ErrUnencryptedAssertion)
+	})
+
+	t.Run("UnknownRequest", func(t *testing.T) {
+		_, err := source.ParseResponse(sp, makeResponse(t, idp, sp, "id-3", true), []string{"id-4"})
+		require.Error(t, err)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		response := makeResponse(t, idp, sp, "id-6", true)
+		_, err := source.ParseResponse(sp, response, []string{"id-6"})
+		require.NoError(t, err)
+		_, err = source.ParseResponse(sp, response, []string{"id-6"})
+		require.ErrorIs(t, err, ErrReplayedAssertion)
+
+		// Another assertion answering the same request is refused as well.
+		_, err = source.ParseResponse(sp, makeResponse(t, idp, sp, "id-6", true), []string{"id-6"})
+		require.ErrorIs(t, err, ErrReplayedAssertion)
+	})
+
+	t.Run("OtherIdentityProvider", func(t *testing.T) {
+		_, err := source.ParseResponse(sp, makeResponse(t, newTestIdentityProvider(t), sp, "id-5", true), []string{"id-5"})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	automation_model "forgejo.org/models/automation"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	issue_service "forgejo.org/services/issue"
+)
+
+func runActions(ctx context.Context, doer *user_model.User, a *automation_model.Actions, issue *issues_model.Issue) error {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	if len(a.AddLabels) > 0 {
+		labels, err := labelsByNames(ctx, issue, a.AddLabels)
+		if err != nil {
+			return err
+		}
+		if err := issue_service.AddLabels(ctx, issue, doer, labels); err != nil {
+			return fmt.Errorf("add labels: %w", err)
+		}
+	}
+
+	if len(a.RemoveLabels) > 0 {
+		if err := issue.LoadLabels(ctx); err != nil {
+			return err
+		}
+		for _, l := range issue.Labels {
+			if !slices.ContainsFunc(a.RemoveLabels, func(name string) bool { return strings.EqualFold(name, l.Name) }) {
+				continue
+			}
+			if err := issue_service.RemoveLabel(ctx, issue, doer, l); err != nil {
+				return fmt.Errorf("remove label %s: %w", l.Name, err)
+			}
+		}
+	}
+
+	for _, name := range a.Assignees {
+		if err := assign(ctx, doer, issue, name); err != nil {
+			return fmt.Errorf("assign %s: %w", name, err)
+		}
+	}
+
+	for _, name := range a.Reviewers {
+		if err := requestReview(ctx, doer, issue, name); err != nil {
+			return fmt.Errorf("request review of %s: %w", name, err)
+		}
+	}
+
+	if a.ProjectColumnID > 0 {
+		if err := moveToProjectColumn(ctx, doer, issue, a.ProjectColumnID); err != nil {
+			return fmt.Errorf("move to project column: %w", err)
+		}
+	}
+
+	if a.Comment != "" {
+		if _, err := issue_service.CreateIssueComment(ctx, doer, issue.Repo, issue, a.Comment, nil); err != nil {
+			return fmt.Errorf("comment: %w", err)
+		}
+	}
+
+	if a.Close && !issue.IsClosed {
+		if err := issue_service.ChangeStatus(ctx, issue, doer, "", true); err != nil {
+			return fmt.Errorf("close: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// labelsByNames returns the labels of the repository or of the organization of an issue
+func labelsByNames(ctx context.Context, issue *issues_model.Issue, names []string) ([]*issues_model.Label, error) {
+	if err := issue.Repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	labels := make([]*issues_model.Label, 0, len(names))
+	for _, name := range names {
+		l, err := issues_model.GetLabelInRepoByName(ctx, issue.RepoID, name)
+		if issues_model.IsErrRepoLabelNotExist(err) && issue.Repo.Owner.IsOrganization() {
+			l, err = issues_model.GetLabelInOrgByName(ctx, issue.Repo.OwnerID, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("label %s: %w", name, err)
+		}
+		labels = append(labels, l)
+	}
+	return labels, nil
+}
+
+func assign(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, name string) error {
+	assignee, err := user_model.GetUserByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	assigned, err := issues_model.IsUserAssignedToIssue(ctx, issue, assignee)
+	if err != nil || assigned {
+		return err
+	}
+	canBeAssigned, err := access_model.CanBeAssigned(ctx, assignee, issue.Repo, issue.IsPull)
+	if err != nil {
+		return err
+	} else if !canBeAssigned {
+		return fmt.Errorf("%s cannot be assigned in %s", assignee.Name, issue.Repo.FullName())
+	}
+	_, _, err = issue_service.ToggleAssigneeWithNotify(ctx, issue, doer, assignee.ID)
+	return err
+}
+
+func requestReview(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, name string) error {
+	if !issue.IsPull {
+		return nil
+	}
+	reviewer, err := user_model.GetUserByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if reviewer.ID == issue.PosterID {
+		return nil
+	}
+	perm, err := access_model.GetUserRepoPermission(ctx, issue.Repo, reviewer)
+	if err != nil {
+		return err
+	}
+	if !perm.CanRead(unit.TypePullRequests) {
+		return fmt.Errorf("%s cannot read the pull requests of %s", reviewer.Name, issue.Repo.FullName())
+	}
+	_, err = issue_service.ReviewRequest(ctx, issue, doer, reviewer, true)
+	return err
+}
+
+func moveToProjectColumn(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, columnID int64) error {
+	column, err := project_model.GetColumn(ctx, columnID)
+	if err != nil {
+		return err
+	}
+	project, err := project_model.GetProjectByID(ctx, column.ProjectID)
+	if err != nil {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if !project.CanBeAccessedByOwnerRepo(issue.Repo.OwnerID, issue.Repo) {
+		return fmt.Errorf("the project %q can't be used by %s", project.Title, issue.Repo.FullName())
+	}
+	if err := issue.LoadProject(ctx); err != nil {
+		return err
+	}
+	if issue.Project == nil || issue.Project.ID != column.ProjectID {
+		return issues_model.IssueAssignOrRemoveProject(ctx, issue, doer, column.ProjectID, column.ID)
+	}
+	if issue.ProjectColumnID(ctx) == column.ID {
+		return nil
+	}
+	return project_model.MoveIssuesOnProjectColumn(ctx, column, map[int64]int64{0: issue.ID})
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package automation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	automation_model "forgejo.org/models/automation"
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/graceful"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/queue"
+	notify_service "forgejo.org/services/notify"
+)
+
+// ruleEvent is an event which may trigger the automation rules of the repository of an issue
+type ruleEvent struct {
+	Event   automation_model.Event
+	IssueID int64
+}
+
+var ruleQueue *queue.WorkerPoolQueue[ruleEvent]
+
+type automationContextKey struct{}
+
+// withAutomation marks a context as running automation rules: the events of the changes
+// made by the rules don't trigger other rules, which could loop forever otherwise
+func withAutomation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, automationContextKey{}, true)
+}
+
+func isAutomation(ctx context.Context) bool {
+	return ctx.Value(automationContextKey{}) != nil
+}
+
+// Init starts the queue running the automation rules
+func Init() error {
+	ruleQueue = queue.CreateSimpleQueue(graceful.GetManager().ShutdownContext(), "automation", handler)
+	if ruleQueue == nil {
+		return errors.New("unable to create automation queue")
+	}
+	go graceful.GetManager().RunWithCancel(ruleQueue)
+
+	notify_service.RegisterNotifier(NewNotifier())
+	return nil
+}
+
+func handler(items ...ruleEvent) []ruleEvent {
+	ctx := withAutomation(graceful.GetManager().ShutdownContext())
+	for _, e := range items {
+		if err := runRules(ctx, e); err != nil {
+			log.Error("automation: unable to run the rules of %s on issue %d: %v", e.Event, e.IssueID, err)
+		}
+	}
+	return nil
+}
+
+func pushEvent(ctx context.Context, event automation_model.Event, issueID int64) {
+	if ruleQueue == nil || isAutomation(ctx) {
+		return
+	}
+	if err := ruleQueue.Push(ruleEvent{Event: event, IssueID: issueID}); err != nil {
+		log.Error("automation: unable to push %s of issue %d: %v", event, issueID, err)
+	}
+}
+
+func runRules(ctx context.Context, e ruleEvent) error {
+	issue, err := issues_model.GetIssueByID(ctx, e.IssueID)
+	if err != nil {
+		if issues_model.IsErrIssueNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if issue.Repo.IsArchived {
+		return nil
+	}
+
+	rules, err := automation_model.GetActiveRulesForRepo(ctx, issue.Repo.OwnerID, issue.RepoID, e.Event)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		if err := RunRule(ctx, rule, issue, e.Event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RunRule runs the actions of a rule on an issue or a pull request if it matches the conditions of the rule,
+// and records the execution in the log of the rule. It only returns an error if the log cannot be written.
+func RunRule(ctx context.Context, rule *automation_model.Rule, issue *issues_model.Issue, event automation_model.Event) error {
+	ctx = withAutomation(ctx)
+
+	matched, err := matchConditions(ctx, &rule.Conditions, issue, event)
+	if err != nil {
+		return insertLog(ctx, rule, issue, event, fmt.Errorf("conditions: %w", err))
+	}
+	if !matched {
+		return nil
+	}
+
+	targets := []*issues_model.Issue{issue}
+	if rule.Actions.OnLinkedIssues {
+		if targets, err = linkedIssues(ctx, rule, issue); err != nil {
+			return insertLog(ctx, rule, issue, event, fmt.Errorf("linked issues: %w", err))
+		}
+	}
+
+	doer := user_model.NewActionsUser()
+	for _, target := range targets {
+		if err := insertLog(ctx, rule, target, event, runActions(ctx, doer, &rule.Actions, target)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertLog(ctx context.Context, rule *automation_model.Rule, issue *issues_model.Issue, event automation_model.Event, runErr error) error {
+	l := &automation_model.RuleLog{
+		RuleID:    rule.ID,
+		RepoID:    issue.RepoID,
+		IssueID:   issue.ID,
+		Event:     event,
+		IsSuccess: runErr == nil,
+	}
+	if runErr != nil {
+		l.Message = runErr.Error()
+		log.Debug("automation: rule %d failed on issue %d: %v", rule.ID, issue.ID, runErr)
+	}
+	return automation_model.InsertRuleLog(ctx, l)
+}
+
+// linkedIssues returns the issues a pull request closes or reopens when it is merged,
+// leaving out the issues of the repositories the rule doesn't apply to
+func linkedIssues(ctx context.Context, rule *automation_model.Rule, issue *issues_model.Issue) ([]*issues_model.Issue, error) {
+	if !issue.IsPull {
+		return nil, nil
+	}
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		return nil, err
+	}
+	issue.PullRequest.Issue = issue
+	refs, err := issue.PullRequest.ResolveCrossReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]*issues_model.Issue, 0, len(refs))
+	for _, ref := range refs {
+		if err := ref.LoadIssue(ctx); err != nil {
+			return nil, err
+		}
+		if err := ref.Issue.LoadRepo(ctx); err != nil {
+			return nil, err
+		}
+		if (rule.RepoID > 0 && ref.Issue.RepoID != rule.RepoID) || (rule.OwnerID > 0 && ref.Issue.Repo.OwnerID != rule.OwnerID) {
+			continue
+		}
+		issues = append(issues, ref.Issue)
+	}
+	return issues, nil
+}
+
+// SplitNames splits a comma separated list of names, as entered in the forms of the rules
+func SplitNames(s string) []string {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package automation
+
+import (
+	"testing"
+
+	automation_model "forgejo.org/models/automation"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRule(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	isPull := false
+	rule := &automation_model.Rule{
+		RepoID:   1,
+		Name:     "triage",
+		Event:    automation_model.EventIssueOpened,
+		IsActive: true,
+		Conditions: automation_model.Conditions{
+			IsPull:         &isPull,
+			Authors:        []string{"user1"},
+			ExcludedLabels: []string{"label2"},
+		},
+		Actions: automation_model.Actions{
+			AddLabels: []string{"label2"},
+			Assignees: []string{"user2"},
+			Comment:   "Thanks for the report",
+		},
+	}
+	require.NoError(t, automation_model.CreateRule(db.DefaultContext, rule))
+
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, RunRule(db.DefaultContext, rule, issue, automation_model.EventIssueOpened))
+
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueLabel{IssueID: 1, LabelID: 2})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: 1, AssigneeID: 2})
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 1, PosterID: user_model.ActionsUserID, Content: "Thanks for the report"})
+	unittest.AssertExistsAndLoadBean(t, &automation_model.RuleLog{RuleID: rule.ID, IssueID: 1, IsSuccess: true})
+
+	// the issue has the excluded label now
+	issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	require.NoError(t, RunRule(db.DefaultContext, rule, issue, automation_model.EventIssueOpened))
+	// a pull request
+	pull := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2})
+	require.NoError(t, RunRule(db.DefaultContext, rule, pull, automation_model.EventIssueOpened))
+	unittest.AssertCount(t, &automation_model.RuleLog{RuleID: rule.ID}, 1)
+
+	// the failures are in the log
+	rule.Conditions = automation_model.Conditions{}
+	rule.Actions = automation_model.Actions{AddLabels: []string{"unknown"}}
+	require.NoError(t, RunRule(db.DefaultContext, rule, issue, automation_model.EventIssueOpened))
+	failure := unittest.AssertExistsAndLoadBean(t, &automation_model.RuleLog{RuleID: rule.ID, IsSuccess: false})
+	assert.Contains(t, failure.Message, "unknown")
+
+	// the column of a project of another repository isn't used
+	rule.Actions = automation_model.Actions{ProjectColumnID: 5}
+	require.NoError(t, RunRule(db.DefaultContext, rule, issue, automation_model.EventIssueOpened))
+	unittest.AssertNotExistsBean(t, &project_model.ProjectIssue{IssueID: issue.ID, ProjectID: 2})
+}
+
+func TestRunStaleIssuesRules(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	// issue 1 has been active recently
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	issue.UpdatedUnix = timeutil.TimeStampNow()
+	require.NoError(t, issues_model.UpdateIssueCols(db.DefaultContext, issue, "updated_unix"))
+
+	isPull := false
+	rule := &automation_model.Rule{
+		OwnerID:    2,
+		Name:       "stale",
+		Event:      automation_model.EventIssueStale,
+		IsActive:   true,
+		Conditions: automation_model.Conditions{IsPull: &isPull, InactiveDays: 60},
+		Actions:    automation_model.Actions{Comment: "Closed for inactivity", Close: true},
+	}
+	require.NoError(t, automation_model.CreateRule(db.DefaultContext, rule))
+	require.NoError(t, RunStaleIssuesRules(db.DefaultContext))
+
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7}).IsClosed)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: 7, Content: "Closed for inactivity"})
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1}).IsClosed)
+	// pull requests are excluded
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 2}).IsClosed)
+}
+
+func TestMatchPaths(t *testing.T) {
+	files := []string{"docs/index.md", "go.mod"}
+
+	matched, err := matchPaths([]string{"docs/**"}, files)
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	matched, err = matchPaths([]string{"*.go", "web_src/**"}, files)
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	_, err = matchPaths([]string{"[a"}, files)
+	require.Error(t, err)
+}
+
+func TestSplitNames(t *testing.T) {
+	assert.Equal(t, []string{"bug", "needs triage"}, SplitNames(" bug, ,needs triage,"))
+	assert.Empty(t, SplitNames(""))
+}
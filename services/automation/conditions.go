@@ -0,0 +1,105 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package automation
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	automation_model "forgejo.org/models/automation"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/gitrepo"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/gobwas/glob"
+)
+
+func matchConditions(ctx context.Context, c *automation_model.Conditions, issue *issues_model.Issue, event automation_model.Event) (bool, error) {
+	if c.IsPull != nil && *c.IsPull != issue.IsPull {
+		return false, nil
+	}
+
+	if event == automation_model.EventIssueStale {
+		if issue.IsClosed {
+			return false, nil
+		}
+		inactiveSince := timeutil.TimeStamp(time.Now().AddDate(0, 0, -c.InactiveDays).Unix())
+		if issue.UpdatedUnix > inactiveSince {
+			return false, nil
+		}
+	}
+
+	if len(c.Authors) > 0 {
+		if err := issue.LoadPoster(ctx); err != nil {
+			return false, err
+		}
+		if !slices.ContainsFunc(c.Authors, func(name string) bool { return strings.EqualFold(name, issue.Poster.Name) }) {
+			return false, nil
+		}
+	}
+
+	if len(c.Labels) > 0 || len(c.ExcludedLabels) > 0 {
+		if err := issue.LoadLabels(ctx); err != nil {
+			return false, err
+		}
+		hasLabel := func(name string) bool {
+			return slices.ContainsFunc(issue.Labels, func(l *issues_model.Label) bool { return strings.EqualFold(l.Name, name) })
+		}
+		for _, name := range c.Labels {
+			if !hasLabel(name) {
+				return false, nil
+			}
+		}
+		if slices.ContainsFunc(c.ExcludedLabels, hasLabel) {
+			return false, nil
+		}
+	}
+
+	if len(c.Paths) > 0 {
+		if !issue.IsPull {
+			return false, nil
+		}
+		files, err := changedFiles(ctx, issue)
+		if err != nil {
+			return false, err
+		}
+		return matchPaths(c.Paths, files)
+	}
+
+	return true, nil
+}
+
+// matchPaths returns true if one of the glob patterns matches one of the files
+func matchPaths(patterns, files []string) (bool, error) {
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return false, err
+		}
+		if slices.ContainsFunc(files, g.Match) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// changedFiles returns the files changed by a pull request
+func changedFiles(ctx context.Context, issue *issues_model.Issue) ([]string, error) {
+	if err := issue.LoadPullRequest(ctx); err != nil {
+		return nil, err
+	}
+	pr := issue.PullRequest
+	if err := pr.LoadBaseRepo(ctx); err != nil {
+		return nil, err
+	}
+	gitRepo, err := gitrepo.OpenRepository(ctx, pr.BaseRepo)
+	if err != nil {
+		return nil, err
+	}
+	defer gitRepo.Close()
+
+	return gitRepo.GetFilesChangedBetween(pr.MergeBase, pr.GetGitRefName())
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package automation
+
+import (
+	"context"
+
+	automation_model "forgejo.org/models/automation"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	notify_service "forgejo.org/services/notify"
+)
+
+type automationNotifier struct {
+	notify_service.NullNotifier
+}
+
+var _ notify_service.Notifier = &automationNotifier{}
+
+// NewNotifier create a new automationNotifier notifier
+func NewNotifier() notify_service.Notifier {
+	return &automationNotifier{}
+}
+
+func (n *automationNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
+	pushEvent(ctx, automation_model.EventIssueOpened, issue.ID)
+}
+
+func (n *automationNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, isClosed bool) {
+	if isClosed {
+		pushEvent(ctx, automation_model.EventIssueClosed, issue.ID)
+	} else {
+		pushEvent(ctx, automation_model.EventIssueReopened, issue.ID)
+	}
+}
+
+func (n *automationNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
+	addedLabels, removedLabels []*issues_model.Label,
+) {
+	if len(addedLabels) > 0 {
+		pushEvent(ctx, automation_model.EventIssueLabeled, issue.ID)
+	}
+}
+
+func (n *automationNotifier) CreateIssueComment(ctx context.Context, doer *user_model.User, repo *repo_model.Repository,
+	issue *issues_model.Issue, comment *issues_model.Comment, mentions []*user_model.User,
+) {
+	pushEvent(ctx, automation_model.EventIssueComment, issue.ID)
+}
+
+func (n *automationNotifier) NewPullRequest(ctx context.Context, pr *issues_model.PullRequest, mentions []*user_model.User) {
+	pushEvent(ctx, automation_model.EventPullRequestOpened, pr.IssueID)
+}
+
+func (n *automationNotifier) PullRequestSynchronized(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	pushEvent(ctx, automation_model.EventPullRequestPushed, pr.IssueID)
+}
+
+func (n *automationNotifier) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	pushEvent(ctx, automation_model.EventPullRequestMerged, pr.IssueID)
+}
+
+func (n *automationNotifier) AutoMergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
+	pushEvent(ctx, automation_model.EventPullRequestMerged, pr.IssueID)
+}
@@ -0,0 +1,82 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package automation
+
+import (
+	"context"
+	"time"
+
+	automation_model "forgejo.org/models/automation"
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+)
+
+// staleIssuesBatch is the maximum number of stale issues a rule processes in a run
+const staleIssuesBatch = 100
+
+// RunStaleIssuesRules runs the rules of the stale issues on the open issues and pull requests
+// without activity for the number of days of each rule
+func RunStaleIssuesRules(ctx context.Context) error {
+	rules, err := automation_model.GetActiveRulesByEvent(ctx, automation_model.EventIssueStale)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("before running the automation rule %d", rule.ID)
+		default:
+		}
+		if err := runStaleIssuesRule(ctx, rule); err != nil {
+			log.Error("automation: unable to run the rule %d on the stale issues: %v", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+func runStaleIssuesRule(ctx context.Context, rule *automation_model.Rule) error {
+	repoIDs := []int64{rule.RepoID}
+	if rule.RepoID == 0 {
+		repos, err := repo_model.GetRepositoriesByOwnerID(ctx, rule.OwnerID)
+		if err != nil {
+			return err
+		}
+		repoIDs = make([]int64, 0, len(repos))
+		for _, repo := range repos {
+			if !repo.IsArchived {
+				repoIDs = append(repoIDs, repo.ID)
+			}
+		}
+		if len(repoIDs) == 0 {
+			return nil
+		}
+	}
+
+	issues, err := issues_model.Issues(ctx, &issues_model.IssuesOptions{
+		Paginator:         &db.ListOptions{Page: 1, PageSize: staleIssuesBatch},
+		RepoIDs:           repoIDs,
+		IsClosed:          optional.Some(false),
+		IsPull:            optional.FromPtr(rule.Conditions.IsPull),
+		UpdatedBeforeUnix: time.Now().AddDate(0, 0, -rule.Conditions.InactiveDays).Unix(),
+		SortType:          "leastupdate",
+	})
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if err := issue.LoadRepo(ctx); err != nil {
+			return err
+		}
+		if issue.Repo.IsArchived {
+			continue
+		}
+		if err := RunRule(ctx, rule, issue, automation_model.EventIssueStale); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package blob
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	blob_model "forgejo.org/models/blob"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/storage"
+	"forgejo.org/modules/timeutil"
+)
+
+func init() {
+	// a deleted object may have been the only reference of its blob, or the blob was stored
+	// by an upload which failed before adding its reference
+	storage.ReleaseBlob = func(hash string, size int64) error {
+		return blob_model.EnsureBlob(db.DefaultContext, hash, size)
+	}
+}
+
+// CleanupTask removes content blobs which are no longer referenced by LFS objects, package blobs or attachments
+func CleanupTask(ctx context.Context, olderThan time.Duration) error {
+	cbs, err := blob_model.FindUnreferencedBlobs(ctx, timeutil.TimeStamp(time.Now().Add(-olderThan).Unix()))
+	if err != nil {
+		return err
+	}
+
+	for _, cb := range cbs {
+		select {
+		case <-ctx.Done():
+			return db.ErrCancelledf("While cleaning up content blobs")
+		default:
+		}
+
+		if err := db.WithTx(ctx, func(ctx context.Context) error {
+			// The reference counter may be outdated, the blob must not be removed while it is still in use.
+			count, err := blob_model.CountReferences(ctx, cb.HashSHA256)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				log.Warn("Content blob %s has %d references but the counter is %d", cb.HashSHA256, count, cb.RefCount)
+				return blob_model.SetReferenceCount(ctx, cb.ID, count)
+			}
+
+			if err := blob_model.DeleteBlobByID(ctx, cb.ID); err != nil {
+				return err
+			}
+			return storage.Blobs.Delete(storage.BlobRelativePath(cb.HashSHA256))
+		}); err != nil {
+			return fmt.Errorf("CleanupTask: %w", err)
+		}
+	}
+
+	return nil
+}
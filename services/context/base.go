@@ -310,6 +310,7 @@ func NewBaseContext(resp http.ResponseWriter, req *http.Request) (b *Base, close
 		Data:      middleware.GetContextData(req.Context()),
 	}
 	b.AppendContextValue(translation.ContextKey, b.Locale)
+	b.AppendContextValueFunc(middleware.RemoteAddrContextKey, func() any { return b.RemoteAddr() })
 	b.Req = b.Req.WithContext(b)
 	return b, b.cleanUp
 }
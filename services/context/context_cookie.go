@@ -45,12 +45,13 @@ func (ctx *Context) GetSiteCookie(name string) string {
 }
 
 // SetLTACookie will generate a LTA token and add it as an cookie.
-func (ctx *Context) SetLTACookie(u *user_model.User) error {
+// It returns the lookup key of the token.
+func (ctx *Context) SetLTACookie(u *user_model.User) (string, error) {
 	days := 86400 * setting.LogInRememberDays
 	lookup, validator, err := auth_model.GenerateAuthToken(ctx, u.ID, timeutil.TimeStampNow().Add(int64(days)), auth_model.LongTermAuthorization)
 	if err != nil {
-		return err
+		return "", err
 	}
 	ctx.SetSiteCookie(setting.CookieRememberName, lookup+":"+validator, days)
-	return nil
+	return lookup, nil
 }
@@ -163,6 +163,9 @@ func HandleOrgAssignment(ctx *Context, args ...bool) {
 		ctx.NotFound("OrgAssignment", err)
 		return
 	}
+	if ctx.Org.IsMember && !checkOrgPolicy(ctx, org) {
+		return
+	}
 	ctx.Data["IsOrganizationOwner"] = ctx.Org.IsOwner
 	ctx.Data["IsOrganizationMember"] = ctx.Org.IsMember
 	ctx.Data["IsPackageEnabled"] = setting.Packages.Enabled
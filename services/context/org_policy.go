@@ -0,0 +1,117 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package context
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"forgejo.org/models/organization"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+)
+
+const tplOrgPolicyViolation base.TplName = "org/policy_violation"
+
+// PhishingResistantAuthSessionKey is set in the session of users who signed in with
+// a passkey or with a security key as second factor
+const PhishingResistantAuthSessionKey = "phishingResistantAuth"
+
+// IsPhishingResistantAuth returns whether the doer signed in with a passkey or a security key
+func (ctx *Context) IsPhishingResistantAuth() bool {
+	if ctx.Session == nil {
+		return false
+	}
+	ok, _ := ctx.Session.Get(PhishingResistantAuthSessionKey).(bool)
+	return ok
+}
+
+// CheckOrgSecurityPolicy returns what the doer has to fix to comply with the security policy of the
+// organization when accessing it from the remote address. It returns nil when the doer complies or
+// when the policy does not apply to them, it only applies to members who are not site administrators.
+func CheckOrgSecurityPolicy(ctx context.Context, org *organization.Organization, doer *user_model.User, remoteAddr string) (*organization.SecurityPolicyViolations, error) {
+	if doer == nil || doer.IsAdmin {
+		return nil, nil
+	}
+
+	policy, err := organization.GetOrgSecurityPolicy(ctx, org.ID)
+	if err != nil {
+		return nil, fmt.Errorf("GetOrgSecurityPolicy: %w", err)
+	}
+	if !policy.IsEnforced() {
+		return nil, nil
+	}
+
+	isMember, err := org.IsOrgMember(ctx, doer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("IsOrgMember: %w", err)
+	}
+	if !isMember {
+		return nil, nil
+	}
+
+	violations, err := policy.CheckMember(ctx, doer, remoteAddr)
+	if err != nil {
+		return nil, fmt.Errorf("CheckMember: %w", err)
+	}
+	if violations.IsEmpty() {
+		return nil, nil
+	}
+	return violations, nil
+}
+
+// OrgSecurityPolicyMessage describes the violations of the security policy of the organization
+// for API and git clients, which can't be shown a page
+func OrgSecurityPolicyMessage(org *organization.Organization, violations *organization.SecurityPolicyViolations) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "The organization %s enforces a security policy for its members:", org.Name)
+	if violations.TwoFactor {
+		sb.WriteString(" you must enable two-factor authentication;")
+	}
+	for _, key := range violations.SSHKeys {
+		fmt.Fprintf(&sb, " you must remove the SSH key %q (%s) which is not allowed;", key.Name, key.Fingerprint)
+	}
+	if violations.RemoteAddr != "" {
+		fmt.Fprintf(&sb, " access from %s is not allowed;", violations.RemoteAddr)
+	}
+	return strings.TrimSuffix(sb.String(), ";") + "."
+}
+
+// checkOrgPolicy enforces the security policy of the organization for its members, instead of
+// the requested page they are shown what they have to do to comply with it.
+// It returns false if the response has been written.
+func checkOrgPolicy(ctx *Context, org *organization.Organization) bool {
+	if !ctx.IsSigned || ctx.Doer.IsAdmin {
+		return true
+	}
+
+	violations, err := CheckOrgSecurityPolicy(ctx, org, ctx.Doer, ctx.RemoteAddr())
+	if err != nil {
+		ctx.ServerError("CheckOrgSecurityPolicy", err)
+		return false
+	}
+
+	requirePhishingResistantAuth := org.RequirePhishingResistantAuth && !ctx.IsPhishingResistantAuth()
+	if requirePhishingResistantAuth {
+		isMember, err := org.IsOrgMember(ctx, ctx.Doer.ID)
+		if err != nil {
+			ctx.ServerError("IsOrgMember", err)
+			return false
+		}
+		requirePhishingResistantAuth = isMember
+	}
+
+	if violations == nil && !requirePhishingResistantAuth {
+		return true
+	}
+
+	ctx.Data["Title"] = org.DisplayName()
+	ctx.Data["PolicyOrg"] = org
+	ctx.Data["PolicyRequirePhishingResistantAuth"] = requirePhishingResistantAuth
+	ctx.Data["PolicyViolations"] = violations
+	ctx.HTML(http.StatusForbidden, tplOrgPolicyViolation)
+	return false
+}
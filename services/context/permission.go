@@ -9,7 +9,9 @@ import (
 	auth_model "forgejo.org/models/auth"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/log"
+	"forgejo.org/modules/web/middleware"
 )
 
 // RequireRepoAdmin returns a middleware for requiring repository admin permission
@@ -124,12 +126,31 @@ func CheckRepoDelegateActionTrust(ctx *Context) bool {
 	return ctx.Repo.IsAdmin() || (ctx.IsSigned && ctx.Doer.IsAdmin) || ctx.Repo.CanWrite(unit.TypeActions)
 }
 
+// AccessTokenAllowsRepo checks whether the personal access token used to authenticate
+// the request, if any, isn't restricted to other repositories
+func AccessTokenAllowsRepo(data middleware.ContextData, repo *repo_model.Repository) bool {
+	token, ok := data["ApiAccessToken"].(*auth_model.AccessToken)
+	return !ok || token.CanAccessRepo(repo.ID, repo.OwnerID)
+}
+
+// AccessTokenAllowsOwner checks whether the personal access token used to authenticate
+// the request, if any, isn't restricted to other owners
+func AccessTokenAllowsOwner(data middleware.ContextData, owner *user_model.User) bool {
+	token, ok := data["ApiAccessToken"].(*auth_model.AccessToken)
+	return !ok || token.CanAccessOwner(owner.ID)
+}
+
 // CheckRepoScopedToken check whether personal access token has repo scope
 func CheckRepoScopedToken(ctx *Context, repo *repo_model.Repository, level auth_model.AccessTokenScopeLevel) {
 	if !ctx.IsBasicAuth || ctx.Data["IsApiToken"] != true {
 		return
 	}
 
+	if !AccessTokenAllowsRepo(ctx.Data, repo) {
+		ctx.Error(http.StatusForbidden)
+		return
+	}
+
 	scope, ok := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope)
 	if ok { // it's a personal access token but not oauth2 token
 		var scopeMatched bool
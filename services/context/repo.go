@@ -17,9 +17,11 @@ import (
 
 	"forgejo.org/models"
 	"forgejo.org/models/actions"
+	advisory_model "forgejo.org/models/advisory"
 	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/organization"
 	packages_model "forgejo.org/models/packages"
 	access_model "forgejo.org/models/perm/access"
 	repo_model "forgejo.org/models/repo"
@@ -375,6 +377,10 @@ func repoAssignment(ctx *Context, repo *repo_model.Repository) {
 		return
 	}
 
+	if repo.Owner.IsOrganization() && !checkOrgPolicy(ctx, organization.OrgFromUser(repo.Owner)) {
+		return
+	}
+
 	ctx.Repo.Permission, err = access_model.GetUserRepoPermission(ctx, repo, ctx.Doer)
 	if err != nil {
 		ctx.ServerError("GetUserRepoPermission", err)
@@ -382,7 +388,7 @@ func repoAssignment(ctx *Context, repo *repo_model.Repository) {
 	}
 
 	// Check access.
-	if !ctx.Repo.HasAccess() {
+	if !ctx.Repo.HasAccess() || !AccessTokenAllowsRepo(ctx.Data, repo) {
 		if ctx.FormString("go-get") == "1" {
 			EarlyResponseForGoGetMeta(ctx)
 			return
@@ -583,6 +589,16 @@ func RepoAssignment(ctx *Context) context.CancelFunc {
 		ctx.ServerError("GetPackageCountByRepoID", err)
 		return nil
 	}
+	advisoriesOpts := advisory_model.FindAdvisoriesOptions{RepoID: ctx.Repo.Repository.ID}
+	if !ctx.Repo.IsAdmin() {
+		// only the administrators see the draft advisories
+		advisoriesOpts.State = optional.Some(advisory_model.StatePublished)
+	}
+	ctx.Data["NumSecurityAdvisories"], err = db.Count[advisory_model.Advisory](ctx, advisoriesOpts)
+	if err != nil {
+		ctx.ServerError("CountAdvisories", err)
+		return nil
+	}
 	ctx.Data["NumOpenActionRuns"] = actions.RepoNumOpenActions(ctx, ctx.Repo.Repository.ID)
 
 	ctx.Data["Title"] = owner.Name + "/" + repo.Name
@@ -0,0 +1,52 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	"context"
+
+	auth_model "forgejo.org/models/auth"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAccessToken converts an access token to its API format, the token itself is only included right after creation
+func ToAccessToken(ctx context.Context, t *auth_model.AccessToken) (*api.AccessToken, error) {
+	apiToken := &api.AccessToken{
+		ID:             t.ID,
+		Name:           t.Name,
+		Token:          t.Token,
+		TokenLastEight: t.TokenLastEight,
+		Scopes:         t.Scope.StringSlice(),
+	}
+
+	if len(t.RepoIDs) > 0 {
+		repos, err := repo_model.GetRepositoriesMapByIDs(ctx, t.RepoIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range t.RepoIDs {
+			if repo, ok := repos[id]; ok {
+				apiToken.Repositories = append(apiToken.Repositories, repo.FullName())
+			}
+		}
+	}
+
+	if t.OrgID > 0 {
+		org, err := user_model.GetUserByID(ctx, t.OrgID)
+		if err != nil && !user_model.IsErrUserNotExist(err) {
+			return nil, err
+		}
+		if org != nil {
+			apiToken.Organization = org.Name
+		}
+	}
+
+	if t.ExpiresUnix > 0 {
+		expiresAt := t.ExpiresUnix.AsTime()
+		apiToken.ExpiresAt = &expiresAt
+	}
+	return apiToken, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	audit_model "forgejo.org/models/audit"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	api "forgejo.org/modules/structs"
+)
+
+// ToAuditEvent converts an audit log entry to its API format
+func ToAuditEvent(e *audit_model.Event) *api.AuditEvent {
+	return &api.AuditEvent{
+		ID:         e.ID,
+		Action:     string(e.Action),
+		ActorID:    e.ActorID,
+		ActorName:  e.ActorName,
+		IPAddress:  e.IPAddress,
+		OwnerID:    e.OwnerID,
+		RepoID:     e.RepoID,
+		TargetType: string(e.TargetType),
+		TargetID:   e.TargetID,
+		TargetName: e.TargetName,
+		Before:     toAuditState(e.Before),
+		After:      toAuditState(e.After),
+		Created:    e.CreatedUnix.AsTime(),
+	}
+}
+
+func toAuditState(state string) map[string]any {
+	if state == "" {
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal([]byte(state), &m); err != nil {
+		log.Error("Unable to unmarshal audit event state %q: %v", state, err)
+		return nil
+	}
+	return m
+}
@@ -55,6 +55,8 @@ func toIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Iss
 		Created:     issue.CreatedUnix.AsTime(),
 		Updated:     issue.UpdatedUnix.AsTime(),
 		PinOrder:    issue.PinOrder,
+
+		IsConfidential: issue.IsConfidential,
 	}
 
 	if issue.Repo != nil {
@@ -286,3 +288,38 @@ func ToLabelTemplateList(labels []*label.Label) []*api.LabelTemplate {
 	}
 	return result
 }
+
+// ToCustomField converts CustomField to API format
+func ToCustomField(field *issues_model.CustomField) *api.CustomField {
+	return &api.CustomField{
+		ID:        field.ID,
+		Name:      field.Name,
+		Type:      string(field.Type),
+		Options:   field.Options,
+		Sorting:   field.Sorting,
+		ProjectID: field.ProjectID,
+	}
+}
+
+// ToCustomFieldList converts list of CustomField to API format
+func ToCustomFieldList(fields []*issues_model.CustomField) []*api.CustomField {
+	result := make([]*api.CustomField, len(fields))
+	for i := range fields {
+		result[i] = ToCustomField(fields[i])
+	}
+	return result
+}
+
+// ToIssueCustomFieldValues converts the values of the custom fields of an issue to API format
+func ToIssueCustomFieldValues(values []*issues_model.CustomFieldValue) []*api.IssueCustomFieldValue {
+	result := make([]*api.IssueCustomFieldValue, 0, len(values))
+	for _, v := range values {
+		result = append(result, &api.IssueCustomFieldValue{
+			FieldID: v.FieldID,
+			Name:    v.Field.Name,
+			Type:    string(v.Field.Type),
+			Value:   v.DisplayValue(),
+		})
+	}
+	return result
+}
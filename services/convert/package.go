@@ -51,3 +51,26 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageSBOM converts packages.PackageSBOM to api.PackageSBOM
+func ToPackageSBOM(ps *packages.PackageSBOM, pb *packages.PackageBlob) *api.PackageSBOM {
+	return &api.PackageSBOM{
+		Format:      ps.Format,
+		SpecVersion: ps.SpecVersion,
+		Size:        pb.Size,
+		HashSHA256:  pb.HashSHA256,
+		CreatedAt:   ps.CreatedUnix.AsTime(),
+	}
+}
+
+// ToPackageComponent converts packages.PackageComponent to api.PackageComponent
+func ToPackageComponent(pc *packages.PackageComponent) *api.PackageComponent {
+	return &api.PackageComponent{
+		Name:            pc.Name,
+		Version:         pc.Version,
+		PURL:            pc.PURL,
+		Type:            pc.Type,
+		Licenses:        pc.Licenses,
+		Vulnerabilities: pc.Vulnerabilities,
+	}
+}
@@ -66,6 +66,8 @@ func innerToRepo(ctx stdCtx.Context, repo *repo_model.Repository, permissionInRe
 			EnableTimeTracker:                config.EnableTimetracker,
 			AllowOnlyContributorsToTrackTime: config.AllowOnlyContributorsToTrackTime,
 			EnableIssueDependencies:          config.EnableDependencies,
+			CloseParentIssues:                config.CloseParentIssues,
+			EnableServiceDesk:                config.EnableServiceDesk,
 		}
 	} else if unit, err := repo.GetUnit(ctx, unit_model.TypeExternalTracker); err == nil {
 		config := unit.ExternalTrackerConfig()
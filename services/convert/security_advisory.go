@@ -0,0 +1,134 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	"context"
+	"strings"
+
+	advisory_model "forgejo.org/models/advisory"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+)
+
+// ToSecurityAdvisory converts a security advisory to its API format, its repository and author must be loaded
+func ToSecurityAdvisory(ctx context.Context, advisory *advisory_model.Advisory, doer *user_model.User) *api.SecurityAdvisory {
+	result := &api.SecurityAdvisory{
+		Identifier:  advisory.Identifier,
+		Title:       advisory.Title,
+		Description: advisory.Description,
+		Severity:    string(advisory.Severity),
+		CVSSVector:  advisory.CVSSVector,
+		CVEID:       advisory.CVEID,
+		State:       "draft",
+		Affected:    make([]*api.SecurityAdvisoryAffected, 0, len(advisory.Affected)),
+		Credits:     make([]*api.SecurityAdvisoryCredit, 0, len(advisory.Credits)),
+		FixCommitID: advisory.FixCommitID,
+		HTMLURL:     advisory.HTMLURL(),
+		Author:      ToUser(ctx, advisory.Author, doer),
+		Created:     advisory.CreatedUnix.AsTime(),
+		Updated:     advisory.UpdatedUnix.AsTime(),
+	}
+	if v := advisory.CVSS(); v != nil {
+		result.CVSSScore = v.BaseScore()
+	}
+	if !advisory.IsDraft() {
+		result.State = "published"
+		published := advisory.PublishedUnix.AsTime()
+		result.Published = &published
+	}
+	for _, affected := range advisory.Affected {
+		result.Affected = append(result.Affected, &api.SecurityAdvisoryAffected{
+			Ecosystem: affected.Ecosystem,
+			Package:   affected.Package,
+			Versions:  affected.Versions,
+			Patched:   affected.Patched,
+		})
+	}
+	for _, credit := range advisory.Credits {
+		result.Credits = append(result.Credits, &api.SecurityAdvisoryCredit{Name: credit.Name, Type: credit.Type})
+	}
+	return result
+}
+
+// ToOSVVulnerability converts a published security advisory to the OSV format, its repository must be loaded
+func ToOSVVulnerability(advisory *advisory_model.Advisory) *api.OSVVulnerability {
+	osv := &api.OSVVulnerability{
+		SchemaVersion: "1.6.0",
+		ID:            advisory.Identifier,
+		Modified:      advisory.UpdatedUnix.AsTime().UTC(),
+		Published:     advisory.PublishedUnix.AsTime().UTC(),
+		Summary:       advisory.Title,
+		Details:       advisory.Description,
+		References:    []*api.OSVReference{{Type: "ADVISORY", URL: advisory.HTMLURL()}},
+		DatabaseSpecific: map[string]string{
+			"severity": strings.ToUpper(string(advisory.Severity)),
+		},
+	}
+	if advisory.CVEID != "" {
+		osv.Aliases = []string{advisory.CVEID}
+	}
+	if advisory.CVSSVector != "" {
+		osv.Severity = []*api.OSVSeverity{{Type: "CVSS_V3", Score: advisory.CVSSVector}}
+	}
+	for _, affected := range advisory.Affected {
+		osv.Affected = append(osv.Affected, toOSVAffected(affected))
+	}
+	if advisory.FixCommitID != "" {
+		osv.Affected = append(osv.Affected, &api.OSVAffected{
+			Ranges: []*api.OSVRange{{
+				Type:   "GIT",
+				Repo:   advisory.Repo.CloneLink().HTTPS,
+				Events: []*api.OSVEvent{{Introduced: "0"}, {Fixed: advisory.FixCommitID}},
+			}},
+		})
+		osv.References = append(osv.References, &api.OSVReference{Type: "FIX", URL: advisory.Repo.HTMLURL() + "/commit/" + advisory.FixCommitID})
+	}
+	for _, credit := range advisory.Credits {
+		osv.Credits = append(osv.Credits, &api.OSVCredit{Name: credit.Name, Type: credit.Type})
+	}
+	return osv
+}
+
+// toOSVAffected converts an affected package whose vulnerable versions are comparisons such as
+// ">= 1.0.0, < 1.2.3". OSV has no exclusive lower bound, a "> 1.0.0" range is widened to 1.0.0.
+func toOSVAffected(affected advisory_model.Affected) *api.OSVAffected {
+	introduced, fixed, lastAffected := "0", "", ""
+	var versions []string
+	for _, comparison := range strings.Split(affected.Versions, ",") {
+		comparison = strings.TrimSpace(comparison)
+		switch {
+		case strings.HasPrefix(comparison, ">="):
+			introduced = strings.TrimSpace(comparison[2:])
+		case strings.HasPrefix(comparison, ">"):
+			introduced = strings.TrimSpace(comparison[1:])
+		case strings.HasPrefix(comparison, "<="):
+			lastAffected = strings.TrimSpace(comparison[2:])
+		case strings.HasPrefix(comparison, "<"):
+			fixed = strings.TrimSpace(comparison[1:])
+		case strings.HasPrefix(comparison, "="):
+			versions = append(versions, strings.TrimSpace(strings.TrimLeft(comparison, "=")))
+		}
+	}
+	if fixed == "" && lastAffected == "" {
+		fixed = affected.Patched
+	}
+
+	result := &api.OSVAffected{
+		Package:          &api.OSVPackage{Ecosystem: affected.Ecosystem, Name: affected.Package},
+		DatabaseSpecific: map[string]string{"vulnerable_versions": affected.Versions},
+	}
+	if len(versions) > 0 && introduced == "0" && fixed == "" && lastAffected == "" {
+		result.Versions = versions
+		return result
+	}
+	events := []*api.OSVEvent{{Introduced: introduced}}
+	if fixed != "" {
+		events = append(events, &api.OSVEvent{Fixed: fixed})
+	} else if lastAffected != "" {
+		events = append(events, &api.OSVEvent{LastAffected: lastAffected})
+	}
+	result.Ranges = []*api.OSVRange{{Type: "ECOSYSTEM", Events: events}}
+	return result
+}
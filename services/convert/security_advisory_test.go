@@ -0,0 +1,89 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package convert
+
+import (
+	"testing"
+
+	advisory_model "forgejo.org/models/advisory"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/cvss"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToOSVAffected(t *testing.T) {
+	cases := []struct {
+		affected advisory_model.Affected
+		ranges   []*api.OSVEvent
+		versions []string
+	}{
+		{
+			affected: advisory_model.Affected{Versions: "< 1.2.3"},
+			ranges:   []*api.OSVEvent{{Introduced: "0"}, {Fixed: "1.2.3"}},
+		},
+		{
+			affected: advisory_model.Affected{Versions: ">= 1.0.0, <= 1.2.2", Patched: "1.2.3"},
+			ranges:   []*api.OSVEvent{{Introduced: "1.0.0"}, {LastAffected: "1.2.2"}},
+		},
+		{
+			affected: advisory_model.Affected{Versions: ">= 1.0.0", Patched: "1.2.3"},
+			ranges:   []*api.OSVEvent{{Introduced: "1.0.0"}, {Fixed: "1.2.3"}},
+		},
+		{
+			affected: advisory_model.Affected{Versions: "= 1.0.0"},
+			versions: []string{"1.0.0"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.affected.Versions, func(t *testing.T) {
+			affected := toOSVAffected(c.affected)
+			assert.Equal(t, c.affected.Versions, affected.DatabaseSpecific["vulnerable_versions"])
+			assert.Equal(t, c.versions, affected.Versions)
+			if c.ranges == nil {
+				assert.Empty(t, affected.Ranges)
+				return
+			}
+			require.Len(t, affected.Ranges, 1)
+			assert.Equal(t, "ECOSYSTEM", affected.Ranges[0].Type)
+			assert.Equal(t, c.ranges, affected.Ranges[0].Events)
+		})
+	}
+}
+
+func TestToOSVVulnerability(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	advisory := &advisory_model.Advisory{
+		Repo:          unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1}),
+		Identifier:    "FJSA-2345-6789-cfgh",
+		Title:         "remote code execution",
+		Severity:      cvss.SeverityCritical,
+		CVSSVector:    "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		CVEID:         "CVE-2026-12345",
+		Affected:      []advisory_model.Affected{{Ecosystem: "Go", Package: "example.com/lib", Versions: "< 1.2.3"}},
+		Credits:       []advisory_model.Credit{{Name: "user5", Type: "FINDER"}},
+		State:         advisory_model.StatePublished,
+		FixCommitID:   "65f1bf27bc3bf70f64657658635e66094edbcb4d",
+		PublishedUnix: timeutil.TimeStamp(1700000000),
+	}
+
+	osv := ToOSVVulnerability(advisory)
+	assert.Equal(t, "FJSA-2345-6789-cfgh", osv.ID)
+	assert.Equal(t, []string{"CVE-2026-12345"}, osv.Aliases)
+	assert.Equal(t, "CRITICAL", osv.DatabaseSpecific["severity"])
+	assert.Equal(t, []*api.OSVSeverity{{Type: "CVSS_V3", Score: advisory.CVSSVector}}, osv.Severity)
+	assert.Equal(t, []*api.OSVCredit{{Name: "user5", Type: "FINDER"}}, osv.Credits)
+	require.Len(t, osv.Affected, 2)
+	assert.Equal(t, &api.OSVPackage{Ecosystem: "Go", Name: "example.com/lib"}, osv.Affected[0].Package)
+	assert.Equal(t, "GIT", osv.Affected[1].Ranges[0].Type)
+	assert.Equal(t, advisory.FixCommitID, osv.Affected[1].Ranges[0].Events[1].Fixed)
+	require.Len(t, osv.References, 2)
+	assert.Equal(t, advisory.HTMLURL(), osv.References[0].URL)
+	assert.Equal(t, "FIX", osv.References[1].Type)
+}
@@ -13,12 +13,16 @@ import (
 	"forgejo.org/models/webhook"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/setting"
+	"forgejo.org/modules/storage"
 	"forgejo.org/services/auth"
+	automation_service "forgejo.org/services/automation"
+	blob_service "forgejo.org/services/blob"
 	"forgejo.org/services/migrations"
 	mirror_service "forgejo.org/services/mirror"
 	packages_cleanup_service "forgejo.org/services/packages/cleanup"
 	repo_service "forgejo.org/services/repository"
 	archiver_service "forgejo.org/services/repository/archiver"
+	user_service "forgejo.org/services/user"
 )
 
 func registerUpdateMirrorTask() {
@@ -156,6 +160,50 @@ func registerCleanupPackages() {
 	})
 }
 
+func registerCleanupContentBlobs() {
+	RegisterTaskFatal("cleanup_content_blobs", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    true,
+			RunAtStart: false,
+			Schedule:   "@midnight",
+		},
+		OlderThan: 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		realConfig := config.(*OlderThanConfig)
+		return blob_service.CleanupTask(ctx, realConfig.OlderThan)
+	})
+}
+
+func registerMoveTieredStorageObjects() {
+	RegisterTaskFatal("move_tiered_storage_objects", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return storage.MoveTieredObjects(ctx)
+	})
+}
+
+func registerNotifyExpiringAccessTokens() {
+	RegisterTaskFatal("notify_expiring_access_tokens", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return user_service.NotifyExpiringAccessTokens(ctx)
+	})
+}
+
+func registerRunStaleIssuesAutomationRules() {
+	RegisterTaskFatal("run_stale_issues_automation_rules", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@midnight",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return automation_service.RunStaleIssuesRules(ctx)
+	})
+}
+
 func initBasicTasks() {
 	if setting.Mirror.Enabled {
 		registerUpdateMirrorTask()
@@ -172,4 +220,10 @@ func initBasicTasks() {
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
 	}
+	if setting.Blobs.Enabled {
+		registerCleanupContentBlobs()
+	}
+	registerMoveTieredStorageObjects()
+	registerNotifyExpiringAccessTokens()
+	registerRunStaleIssuesAutomationRules()
 }
@@ -9,6 +9,7 @@ import (
 
 	activities_model "forgejo.org/models/activities"
 	asymkey_model "forgejo.org/models/asymkey"
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/system"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
@@ -174,6 +175,20 @@ func registerDeleteOldSystemNotices() {
 	})
 }
 
+func registerDeleteOldAuditEvents() {
+	RegisterTaskFatal("delete_old_audit_events", &OlderThanConfig{
+		BaseConfig: BaseConfig{
+			Enabled:    false,
+			RunAtStart: false,
+			Schedule:   "@every 24h",
+		},
+		OlderThan: 365 * 24 * time.Hour,
+	}, func(ctx context.Context, _ *user_model.User, config Config) error {
+		olderThanConfig := config.(*OlderThanConfig)
+		return audit_model.DeleteOldEvents(ctx, olderThanConfig.OlderThan)
+	})
+}
+
 type GCLFSConfig struct {
 	BaseConfig
 	OlderThan                time.Duration
@@ -258,6 +273,7 @@ func initExtendedTasks() {
 	registerDeleteOldActions()
 	registerUpdateGiteaChecker()
 	registerDeleteOldSystemNotices()
+	registerDeleteOldAuditEvents()
 	registerGCLFS()
 	registerRebuildIssueIndexer()
 	if setting.Moderation.Enabled {
@@ -0,0 +1,138 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package doctor
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	blob_model "forgejo.org/models/blob"
+	"forgejo.org/models/db"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/storage"
+	"forgejo.org/modules/timeutil"
+)
+
+// contentBlobGracePeriod keeps the blobs of uploads in progress, their reference is added after they are stored
+const contentBlobGracePeriod = time.Hour
+
+func init() {
+	Register(&Check{
+		Title:       "Check the reference counters of deduplicated content blobs and remove unreferenced blobs",
+		Name:        "check-content-blob-refs",
+		IsDefault:   false,
+		Run:         checkContentBlobReferences,
+		Priority:    15,
+		InitStorage: true,
+	})
+}
+
+func checkContentBlobReferences(ctx context.Context, logger log.Logger, autofix bool) error {
+	if !setting.Blobs.Enabled {
+		return errors.New("content deduplication is disabled")
+	}
+
+	found := 0
+	graceStart := time.Now().Add(-contentBlobGracePeriod)
+	err := db.Iterate(ctx, nil, func(ctx context.Context, cb *blob_model.ContentBlob) error {
+		return db.WithTx(ctx, func(ctx context.Context) error {
+			count, err := blob_model.CountReferences(ctx, cb.HashSHA256)
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				if cb.UpdatedUnix >= timeutil.TimeStamp(graceStart.Unix()) {
+					return nil
+				}
+				found++
+				logger.Warn("Content blob %s is not referenced", cb.HashSHA256)
+				if !autofix {
+					return nil
+				}
+				if err := blob_model.DeleteBlobByID(ctx, cb.ID); err != nil {
+					return err
+				}
+				return storage.Blobs.Delete(storage.BlobRelativePath(cb.HashSHA256))
+			}
+			if count == cb.RefCount {
+				return nil
+			}
+
+			found++
+			logger.Warn("Content blob %s has %d references but the counter is %d", cb.HashSHA256, count, cb.RefCount)
+			if !autofix {
+				return nil
+			}
+			return blob_model.SetReferenceCount(ctx, cb.ID, count)
+		})
+	})
+	if err != nil {
+		logger.Critical("Unable to check the content blob references: %v", err)
+		return err
+	}
+
+	// blobs stored by uploads which failed before adding their reference have no row,
+	// as well as the temporary content of interrupted uploads
+	err = storage.Blobs.IterateObjects("", func(p string, obj storage.Object) error {
+		fi, err := obj.Stat()
+		if err != nil {
+			return err
+		}
+		if fi.ModTime().After(graceStart) {
+			return nil
+		}
+
+		hash := path.Base(p)
+		if !strings.HasPrefix(p, "tmp/") {
+			if _, err := blob_model.GetBlobByHash(ctx, hash); err == nil {
+				return nil
+			} else if !errors.Is(err, blob_model.ErrContentBlobNotExist) {
+				return err
+			}
+			count, err := blob_model.CountReferences(ctx, hash)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				found++
+				logger.Warn("Content blob %s has %d references but no counter", hash, count)
+				if !autofix {
+					return nil
+				}
+				if err := blob_model.AddReference(ctx, hash, fi.Size()); err != nil {
+					return err
+				}
+				cb, err := blob_model.GetBlobByHash(ctx, hash)
+				if err != nil {
+					return err
+				}
+				return blob_model.SetReferenceCount(ctx, cb.ID, count)
+			}
+		}
+
+		found++
+		logger.Warn("Stored content blob %s is not referenced", p)
+		if !autofix {
+			return nil
+		}
+		return storage.Blobs.Delete(p)
+	})
+	if err != nil {
+		logger.Critical("Unable to check the stored content blobs: %v", err)
+		return err
+	}
+
+	if found == 0 {
+		logger.Info("All content blob reference counters are correct")
+	} else if autofix {
+		logger.Info("%d content blob problems fixed", found)
+	} else {
+		logger.Warn("%d content blob problems found", found)
+	}
+	return nil
+}
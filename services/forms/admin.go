@@ -51,6 +51,7 @@ type AdminEditUserForm struct {
 	AllowImportLocal        bool
 	AllowCreateOrganization bool
 	ProhibitLogin           bool
+	RateLimitExempt         bool
 	Reset2FA                bool `form:"reset_2fa"`
 	Visibility              structs.VisibleType
 	HideEmail               bool `form:"hide_email"`
@@ -36,15 +36,20 @@ func (f *CreateOrgForm) Validate(req *http.Request, errs binding.Errors) binding
 
 // UpdateOrgSettingForm form for updating organization settings
 type UpdateOrgSettingForm struct {
-	Name                      string `binding:"Required;Username;MaxSize(40)" locale:"org.org_name_holder"`
-	FullName                  string `binding:"MaxSize(100)"`
-	Email                     string `binding:"MaxSize(255)"`
-	Description               string `binding:"MaxSize(255)"`
-	Website                   string `binding:"ValidUrl;MaxSize(255)"`
-	Location                  string `binding:"MaxSize(50)"`
-	Visibility                structs.VisibleType
-	MaxRepoCreation           int
-	RepoAdminChangeTeamAccess bool
+	Name                         string `binding:"Required;Username;MaxSize(40)" locale:"org.org_name_holder"`
+	FullName                     string `binding:"MaxSize(100)"`
+	Email                        string `binding:"MaxSize(255)"`
+	Description                  string `binding:"MaxSize(255)"`
+	Website                      string `binding:"ValidUrl;MaxSize(255)"`
+	Location                     string `binding:"MaxSize(50)"`
+	Visibility                   structs.VisibleType
+	MaxRepoCreation              int
+	RepoAdminChangeTeamAccess    bool
+	RequirePhishingResistantAuth bool
+	RequireTwoFactor             bool
+	SSHKeyTypes                  []string `form:"ssh_key_types"`
+	MinRSAKeySize                int      `form:"min_rsa_key_size" binding:"Range(0,16384)"`
+	IPAllowlist                  string   `form:"ip_allowlist"`
 }
 
 // Validate validates the fields
@@ -7,6 +7,7 @@ package forms
 
 import (
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -201,6 +202,8 @@ type RepoUnitSettingForm struct {
 	EnableTimetracker                     bool
 	AllowOnlyContributorsToTrackTime      bool
 	EnableIssueDependencies               bool
+	CloseParentIssues                     bool
+	EnableServiceDesk                     bool
 }
 
 // Validate validates the fields
@@ -333,6 +336,7 @@ type CreateIssueForm struct {
 	Content             string
 	Files               []string
 	AllowMaintainerEdit bool
+	IsConfidential      bool
 }
 
 // Validate validates the fields
@@ -407,6 +411,23 @@ type EditProjectColumnForm struct {
 	Color   string `binding:"MaxSize(7)"`
 }
 
+// ProjectViewForm is a form for creating or editing a table or roadmap view of a project
+type ProjectViewForm struct {
+	Name      string `binding:"Required;MaxSize(50)"`
+	Type      string `binding:"Required;In(table,roadmap)"`
+	Fields    []string
+	GroupBy   string
+	SortBy    string
+	SortDesc  bool
+	DateField string
+}
+
+// Validate validates the fields
+func (f *ProjectViewForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // CreateMilestoneForm form for creating milestone
 type CreateMilestoneForm struct {
 	Title    string `binding:"Required;MaxSize(50)"`
@@ -436,6 +457,103 @@ func (f *CreateLabelForm) Validate(req *http.Request, errs binding.Errors) bindi
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// CustomFieldForm form for creating or editing a custom field
+type CustomFieldForm struct {
+	Name    string `binding:"Required;MaxSize(50)" locale:"repo.issues.custom_field_name"`
+	Type    string
+	Options string
+	Sorting int64
+}
+
+// Validate validates the fields
+func (f *CustomFieldForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// OptionList returns the options of a single-select field, one per line
+func (f *CustomFieldForm) OptionList() []string {
+	return strings.Split(strings.ReplaceAll(f.Options, "\r", ""), "\n")
+}
+
+// AutomationRuleForm form for creating or editing an automation rule
+type AutomationRuleForm struct {
+	Name     string `binding:"Required;MaxSize(100)" locale:"repo.settings.automation.name"`
+	Event    string `binding:"Required"`
+	IsActive bool
+	// IssueType restricts the rule to the issues or to the pull requests: "", "issues" or "pulls"
+	IssueType      string `binding:"In(,issues,pulls)"`
+	Labels         string
+	ExcludedLabels string
+	Authors        string
+	Paths          string
+	InactiveDays   int `binding:"Range(0,3650)"`
+
+	AddLabels       string
+	RemoveLabels    string
+	Assignees       string
+	Reviewers       string
+	Comment         string
+	Close           bool
+	ProjectColumnID int64
+	OnLinkedIssues  bool
+}
+
+// Validate validates the fields
+func (f *AutomationRuleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// PathList returns the non-empty glob patterns of the paths, one per line
+func (f *AutomationRuleForm) PathList() []string {
+	var paths []string
+	for _, path := range strings.Split(f.Paths, "\n") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// SecurityAdvisoryForm form for creating or editing a security advisory,
+// the affected packages and the credits are rows of fields with the same names
+type SecurityAdvisoryForm struct {
+	Title       string `binding:"Required;MaxSize(255)" locale:"repo.security.advisories.advisory_title"`
+	Description string
+	Severity    string
+	CVSSVector  string `form:"cvss_vector" binding:"MaxSize(255)"`
+	CVEID       string `form:"cve_id" binding:"MaxSize(50)"`
+
+	AffectedEcosystem []string `form:"affected_ecosystem"`
+	AffectedPackage   []string `form:"affected_package"`
+	AffectedVersions  []string `form:"affected_versions"`
+	AffectedPatched   []string `form:"affected_patched"`
+	CreditName        []string `form:"credit_name"`
+	CreditType        []string `form:"credit_type"`
+}
+
+// Validate validates the fields
+func (f *SecurityAdvisoryForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// ImportIssuesForm form for importing issues from a CSV or JSON file
+type ImportIssuesForm struct {
+	File *multipart.FileHeader
+	// Content is the content of the file confirmed after a dry run
+	Content string
+	Format  string `binding:"In(,csv,json)"`
+	DryRun  bool
+}
+
+// Validate validates the fields
+func (f *ImportIssuesForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // InitializeLabelsForm form for initializing labels
 type InitializeLabelsForm struct {
 	TemplateName string `binding:"Required"`
@@ -187,6 +187,9 @@ type AccessTokenForm struct {
 
 	// PKCE support
 	CodeVerifier string `json:"code_verifier"`
+
+	// device authorization grant
+	DeviceCode string `json:"device_code"`
 }
 
 // Validate validates the fields
@@ -206,6 +209,45 @@ func (f *IntrospectTokenForm) Validate(req *http.Request, errs binding.Errors) b
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// RevokeTokenForm for revoking tokens
+type RevokeTokenForm struct {
+	Token         string `json:"token"`
+	TokenTypeHint string `json:"token_type_hint"`
+	ClientID      string `json:"client_id"`
+	ClientSecret  string `json:"client_secret"`
+}
+
+// Validate validates the fields
+func (f *RevokeTokenForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// DeviceAuthorizationForm for starting the authorization of a device
+type DeviceAuthorizationForm struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Scope        string `json:"scope"`
+}
+
+// Validate validates the fields
+func (f *DeviceAuthorizationForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// DeviceGrantForm form for verifying the user code of a device and granting it access
+type DeviceGrantForm struct {
+	UserCode string `binding:"Required"`
+	Granted  bool
+}
+
+// Validate validates the fields
+func (f *DeviceGrantForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 //   __________________________________________.___ _______    ________  _________
 //  /   _____/\_   _____/\__    ___/\__    ___/|   |\      \  /  _____/ /   _____/
 //  \_____  \  |    __)_   |    |     |    |   |   |/   |   \/   \  ___ \_____  \
@@ -329,6 +371,18 @@ func (f *AddOpenIDForm) Validate(req *http.Request, errs binding.Errors) binding
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// SSHCertificateForm form for requesting an SSH certificate
+type SSHCertificateForm struct {
+	Content  string `binding:"Required"`
+	Validity int    `binding:"Range(0,8760)"`
+}
+
+// Validate validates the fields
+func (f *SSHCertificateForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // AddKeyForm form for adding SSH/GPG key
 type AddKeyForm struct {
 	Type        string `binding:"OmitEmpty"`
@@ -370,8 +424,11 @@ func (f *EditVariableForm) Validate(req *http.Request, errs binding.Errors) bind
 
 // NewAccessTokenForm form for creating access token
 type NewAccessTokenForm struct {
-	Name  string `binding:"Required;MaxSize(255)" locale:"settings.token_name"`
-	Scope []string
+	Name         string `binding:"Required;MaxSize(255)" locale:"settings.token_name"`
+	Scope        []string
+	Repositories string
+	Organization string
+	ExpiresAt    string
 }
 
 // Validate validates the fields
@@ -454,3 +511,17 @@ func (f *PackageSettingForm) Validate(req *http.Request, errs binding.Errors) bi
 	ctx := context.GetValidateContext(req)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+// SavedFilterForm form for saving a search of the issues or pull requests of the dashboard
+type SavedFilterForm struct {
+	Name     string `binding:"Required;MaxSize(50)"`
+	Query    string `binding:"Required"`
+	ViewType string `form:"type"`
+	IsPull   bool
+}
+
+// Validate validates the fields
+func (f *SavedFilterForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
@@ -131,6 +131,19 @@ func (r *indexerNotifier) IssueChangeRef(ctx context.Context, doer *user_model.U
 	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
 }
 
+func (r *indexerNotifier) IssueChangeCustomField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.CustomField) {
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+}
+
+func (r *indexerNotifier) IssueChangeConfidential(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+}
+
+func (r *indexerNotifier) IssueChangeParent(ctx context.Context, doer *user_model.User, issue, parent *issues_model.Issue, removed bool) {
+	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+	issue_indexer.UpdateIssueIndexer(ctx, parent.ID)
+}
+
 func (r *indexerNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, closeOrReopen bool) {
 	issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
 }
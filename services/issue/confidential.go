@@ -0,0 +1,67 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+)
+
+// ChangeConfidential changes whether an issue is only visible to its poster, its assignees and the users with write access
+func ChangeConfidential(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, confidential bool) error {
+	if issue.IsConfidential == confidential {
+		return nil
+	}
+	if issue.IsPull {
+		return util.NewInvalidArgumentErrorf("pull requests can't be confidential")
+	}
+	if !confidential {
+		if _, has, err := issues_model.GetServiceDeskContact(ctx, issue.ID); err != nil {
+			return err
+		} else if has {
+			return ErrServiceDeskIssueConfidential
+		}
+	}
+	if err := issues_model.UpdateIssueConfidential(ctx, issue, confidential); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeConfidential(ctx, doer, issue)
+	return nil
+}
+
+// ConfidentialFilter returns the filter of the confidential issues of the repositories that a user can see
+// when searching issues, nil if the user can see all of them.
+func ConfidentialFilter(ctx context.Context, doer *user_model.User, repoIDs []int64) (*issues_model.ConfidentialFilter, error) {
+	if doer != nil && doer.IsAdmin {
+		return nil, nil
+	}
+	filter := &issues_model.ConfidentialFilter{}
+	if doer == nil {
+		return filter, nil
+	}
+	filter.UserID = doer.ID
+
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, repo := range repos {
+		perm, err := access_model.GetUserRepoPermission(ctx, repo, doer)
+		if err != nil {
+			return nil, err
+		}
+		if perm.CanWrite(unit.TypeIssues) {
+			filter.RepoIDs = append(filter.RepoIDs, repo.ID)
+		}
+	}
+	return filter, nil
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	notify_service "forgejo.org/services/notify"
+)
+
+// SetCustomFieldValue sets the value of a custom field of the issue, an empty value removes it.
+// The field must be a field of the repository of the issue or of its project.
+func SetCustomFieldValue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, fieldID int64, value string) error {
+	fields, err := issues_model.GetIssueCustomFields(ctx, issue)
+	if err != nil {
+		return err
+	}
+	field := fields.GetByID(fieldID)
+	if field == nil {
+		return issues_model.ErrCustomFieldNotExist{ID: fieldID}
+	}
+
+	if err := issues_model.SetIssueCustomFieldValue(ctx, issue, field, value); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeCustomField(ctx, doer, issue, field)
+	return nil
+}
@@ -278,10 +278,14 @@ func deleteIssue(ctx context.Context, issue *issues_model.Issue) error {
 		&issues_model.Stopwatch{IssueID: issue.ID},
 		&issues_model.TrackedTime{IssueID: issue.ID},
 		&project_model.ProjectIssue{IssueID: issue.ID},
+		&issues_model.CustomFieldValue{IssueID: issue.ID},
+		&issues_model.ServiceDeskContact{IssueID: issue.ID},
 		&repo_model.Attachment{IssueID: issue.ID},
 		&issues_model.PullRequest{IssueID: issue.ID},
 		&issues_model.Comment{RefIssueID: issue.ID},
 		&issues_model.IssueDependency{DependencyID: issue.ID},
+		&issues_model.SubIssue{IssueID: issue.ID},
+		&issues_model.SubIssue{ParentID: issue.ID},
 		&issues_model.Comment{DependentIssueID: issue.ID},
 	); err != nil {
 		return err
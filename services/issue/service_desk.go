@@ -0,0 +1,75 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/util"
+	notify_service "forgejo.org/services/notify"
+)
+
+// ErrServiceDeskIssueConfidential is returned when making an issue of the service
+// desk public, it holds the name, the email address and the messages of its contact.
+var ErrServiceDeskIssueConfidential = util.NewInvalidArgumentErrorf("issues created by the service desk must stay confidential")
+
+// NewServiceDeskIssue creates a confidential issue from an email sent by an
+// external contact to the service desk address of a repository. The issue is
+// posted by the ghost user on behalf of the contact, and mentions in its
+// content are ignored.
+func NewServiceDeskIssue(ctx context.Context, repo *repo_model.Repository, contact *issues_model.ServiceDeskContact, title, content string, uuids []string) (*issues_model.Issue, error) {
+	poster := user_model.NewGhostUser()
+	issue := &issues_model.Issue{
+		RepoID:         repo.ID,
+		Repo:           repo,
+		Title:          title,
+		PosterID:       poster.ID,
+		Poster:         poster,
+		OriginalAuthor: contact.DisplayName(),
+		Content:        content,
+		IsConfidential: true,
+	}
+
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := issues_model.NewIssue(ctx, repo, issue, nil, uuids); err != nil {
+			return err
+		}
+		contact.IssueID = issue.ID
+		return issues_model.NewServiceDeskContact(ctx, contact)
+	}); err != nil {
+		return nil, err
+	}
+
+	notify_service.NewIssue(ctx, issue, nil)
+	return issue, nil
+}
+
+// CreateServiceDeskComment creates a comment from an email replied by the
+// external contact of an issue created by the service desk.
+func CreateServiceDeskComment(ctx context.Context, issue *issues_model.Issue, contact *issues_model.ServiceDeskContact, content string, uuids []string) (*issues_model.Comment, error) {
+	if err := issue.LoadRepo(ctx); err != nil {
+		return nil, err
+	}
+
+	doer := user_model.NewGhostUser()
+	comment, err := issues_model.CreateComment(ctx, &issues_model.CreateCommentOptions{
+		Type:           issues_model.CommentTypeComment,
+		Doer:           doer,
+		OriginalAuthor: contact.DisplayName(),
+		Repo:           issue.Repo,
+		Issue:          issue,
+		Content:        content,
+		Attachments:    uuids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notify_service.CreateIssueComment(ctx, doer, issue.Repo, issue, comment, nil)
+	return comment, nil
+}
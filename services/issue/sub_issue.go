@@ -0,0 +1,114 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"context"
+
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	user_model "forgejo.org/models/user"
+	notify_service "forgejo.org/services/notify"
+)
+
+// AddSubIssue makes an issue a sub-issue of the parent
+func AddSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue) error {
+	if err := issues_model.AddSubIssue(ctx, doer, parent, issue); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeParent(ctx, doer, issue, parent, false)
+	return nil
+}
+
+// RemoveSubIssue removes an issue from the sub-issues of the parent
+func RemoveSubIssue(ctx context.Context, doer *user_model.User, parent, issue *issues_model.Issue) error {
+	if err := issues_model.RemoveSubIssue(ctx, parent, issue); err != nil {
+		return err
+	}
+
+	notify_service.IssueChangeParent(ctx, doer, issue, parent, true)
+	return nil
+}
+
+// closeParentIfSubIssuesClosed closes the parent of a closed issue when all its sub-issues
+// are closed, its repository closes parent issues and the doer can close it
+func closeParentIfSubIssuesClosed(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) error {
+	parent, err := issues_model.GetParentIssue(ctx, issue)
+	if err != nil || parent == nil || parent.IsClosed {
+		return err
+	}
+	if err := parent.LoadRepo(ctx); err != nil {
+		return err
+	}
+	if !parent.Repo.CloseParentIssues(ctx) {
+		return nil
+	}
+
+	perm, err := access_model.GetUserRepoPermission(ctx, parent.Repo, doer)
+	if err != nil {
+		return err
+	}
+	if !perm.CanWriteIssuesOrPulls(false) {
+		return nil
+	}
+
+	subIssues, err := issues_model.GetSubIssues(ctx, parent.ID)
+	if err != nil {
+		return err
+	}
+	for _, subIssue := range subIssues {
+		if !subIssue.IsClosed {
+			return nil
+		}
+	}
+
+	// the parent stays open while it is blocked by other issues
+	if err := ChangeStatus(ctx, parent, doer, "", true); err != nil && !issues_model.IsErrDependenciesLeft(err) {
+		return err
+	}
+	return nil
+}
+
+// GetVisibleSubIssues returns the sub-issues of an issue the doer can see
+func GetVisibleSubIssues(ctx context.Context, doer *user_model.User, parent *issues_model.Issue) (issues_model.IssueList, error) {
+	subIssues, err := issues_model.GetSubIssues(ctx, parent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := make(map[int64]access_model.Permission)
+	visible := make(issues_model.IssueList, 0, len(subIssues))
+	for _, subIssue := range subIssues {
+		perm, ok := perms[subIssue.RepoID]
+		if !ok {
+			if perm, err = access_model.GetUserRepoPermission(ctx, subIssue.Repo, doer); err != nil {
+				return nil, err
+			}
+			perms[subIssue.RepoID] = perm
+		}
+		can, err := subIssue.IsVisibleTo(ctx, doer, perm)
+		if err != nil {
+			return nil, err
+		}
+		if can {
+			visible = append(visible, subIssue)
+		}
+	}
+	return visible, nil
+}
+
+// GetVisibleParentIssue returns the parent of an issue, nil if it has none or the doer
+// cannot see it
+func GetVisibleParentIssue(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) (*issues_model.Issue, error) {
+	parent, err := issues_model.GetParentIssue(ctx, issue)
+	if err != nil || parent == nil {
+		return nil, err
+	}
+	visible, err := issues_model.IsIssueVisibleToUser(ctx, parent, doer)
+	if err != nil || !visible {
+		return nil, err
+	}
+	return parent, nil
+}
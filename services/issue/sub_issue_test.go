@@ -0,0 +1,43 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"testing"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseParentIssue(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	parent := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+	closed := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 5})
+	issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 7})
+	require.NoError(t, AddSubIssue(db.DefaultContext, doer, parent, closed))
+	require.NoError(t, AddSubIssue(db.DefaultContext, doer, parent, issue))
+
+	// the repository does not close parent issues
+	require.NoError(t, ChangeStatus(db.DefaultContext, issue, doer, "", true))
+	assert.False(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: parent.ID}).IsClosed)
+	require.NoError(t, ChangeStatus(db.DefaultContext, issue, doer, "", false))
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: parent.RepoID})
+	issuesUnit, err := repo.GetUnit(db.DefaultContext, unit.TypeIssues)
+	require.NoError(t, err)
+	issuesUnit.IssuesConfig().CloseParentIssues = true
+	require.NoError(t, repo_model.UpdateRepoUnit(db.DefaultContext, issuesUnit))
+
+	require.NoError(t, ChangeStatus(db.DefaultContext, issue, doer, "", true))
+	assert.True(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: parent.ID}).IsClosed)
+}
@@ -4,17 +4,27 @@
 package issue
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/url"
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	project_model "forgejo.org/models/project"
 	"forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/git"
 	"forgejo.org/modules/issue/template"
 	"forgejo.org/modules/log"
 	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/util"
 
 	"go.yaml.in/yaml/v3"
 )
@@ -159,6 +169,17 @@ func GetTemplatesFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repositor
 	return issueTemplates, invalidFiles
 }
 
+// GetTemplateFromDefaultBranch returns the valid issue template with the file name in the repo's default branch
+func GetTemplateFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repository, filename string) (*api.IssueTemplate, error) {
+	issueTemplates, _ := GetTemplatesFromDefaultBranch(repo, gitRepo)
+	for _, it := range issueTemplates {
+		if it.FileName == filename {
+			return it, nil
+		}
+	}
+	return nil, util.NewNotExistErrorf("issue template %q does not exist", filename)
+}
+
 // GetTemplateConfigFromDefaultBranch returns the issue config for this repo.
 // It never returns a nil config.
 func GetTemplateConfigFromDefaultBranch(repo *repo.Repository, gitRepo *git.Repository) (api.IssueConfig, error) {
@@ -193,3 +214,229 @@ func HasTemplatesOrContactLinks(repo *repo.Repository, gitRepo *git.Repository)
 	issueConfig, _ := GetTemplateConfigFromDefaultBranch(repo, gitRepo)
 	return len(issueConfig.ContactLinks) > 0
 }
+
+// ErrIssueFormInvalid represents an answer to an issue form which doesn't satisfy its template
+type ErrIssueFormInvalid struct {
+	Field  string
+	Reason string
+}
+
+// IsErrIssueFormInvalid checks if an error is a ErrIssueFormInvalid.
+func IsErrIssueFormInvalid(err error) bool {
+	_, ok := err.(ErrIssueFormInvalid)
+	return ok
+}
+
+func (err ErrIssueFormInvalid) Error() string {
+	return fmt.Sprintf("%s: %s", err.Field, err.Reason)
+}
+
+func (err ErrIssueFormInvalid) Unwrap() error {
+	return util.ErrInvalidArgument
+}
+
+// ValidateTemplateForm checks the answers submitted to the issue form of a template: the required fields,
+// the numbers and regular expressions of the inputs and the options of the dropdowns and checkboxes.
+// The fields hidden by their conditions are not checked.
+func ValidateTemplateForm(it *api.IssueTemplate, values url.Values) error {
+	if it.Type() != api.IssueTemplateTypeYaml {
+		return nil
+	}
+	for _, field := range template.ShownFields(it, values) {
+		if err := validateFormField(field, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateFormField(field *api.IssueFormField, values url.Values) error {
+	label, _ := field.Attributes["label"].(string)
+	invalid := func(format string, a ...any) error {
+		return ErrIssueFormInvalid{Field: label, Reason: fmt.Sprintf(format, a...)}
+	}
+	required, _ := field.Validations["required"].(bool)
+	value := strings.TrimSpace(values.Get("form-field-" + field.ID))
+
+	switch field.Type {
+	case api.IssueFormFieldTypeInput, api.IssueFormFieldTypeTextarea:
+		if value == "" {
+			if required {
+				return invalid("is required")
+			}
+			return nil
+		}
+		if isNumber, _ := field.Validations["is_number"].(bool); isNumber {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return invalid("should be a number")
+			}
+		}
+		if pattern, _ := field.Validations["regex"].(string); pattern != "" {
+			// like the pattern attribute of the inputs, the regex must match the whole value
+			re, err := regexp.Compile("^(?:" + pattern + ")$")
+			if err != nil {
+				// browsers support some patterns Go doesn't, such as lookarounds
+				log.Debug("Skip the regex %q of the field %q: %v", pattern, field.ID, err)
+			} else if !re.MatchString(value) {
+				return invalid("should match %s", pattern)
+			}
+		}
+	case api.IssueFormFieldTypeDropdown:
+		options, _ := field.Attributes["options"].([]any)
+		var selected []string
+		if value != "" {
+			selected = strings.Split(value, ",")
+		}
+		if len(selected) == 0 && required {
+			return invalid("is required")
+		}
+		if multiple, _ := field.Attributes["multiple"].(bool); !multiple && len(selected) > 1 {
+			return invalid("accepts only one option")
+		}
+		for _, v := range selected {
+			if idx, err := strconv.Atoi(v); err != nil || idx < 0 || idx >= len(options) {
+				return invalid("has no option %q", v)
+			}
+		}
+	case api.IssueFormFieldTypeCheckboxes:
+		options, _ := field.Attributes["options"].([]any)
+		for i, option := range options {
+			opt, _ := option.(map[string]any)
+			if required, _ := opt["required"].(bool); required && values.Get(fmt.Sprintf("form-field-%s-%d", field.ID, i)) != "on" {
+				optionLabel, _ := opt["label"].(string)
+				return invalid("%q is required", optionLabel)
+			}
+		}
+	}
+	return nil
+}
+
+// TemplateFieldsToForm converts the answers to an issue form given by the API to the values submitted by the
+// web form. The answers to dropdowns and checkboxes are the comma-separated indexes of the chosen options.
+func TemplateFieldsToForm(it *api.IssueTemplate, fields map[string]string) url.Values {
+	values := url.Values{}
+	for _, field := range it.Fields {
+		answer, ok := fields[field.ID]
+		if !ok || field.ID == "" {
+			continue
+		}
+		if field.Type != api.IssueFormFieldTypeCheckboxes {
+			values.Set("form-field-"+field.ID, answer)
+			continue
+		}
+		for _, index := range strings.Split(answer, ",") {
+			if index = strings.TrimSpace(index); index != "" {
+				values.Set("form-field-"+field.ID+"-"+index, "on")
+			}
+		}
+	}
+	return values
+}
+
+// TemplateMetas are the labels, assignees, milestone and project a template sets on the new issues
+type TemplateMetas struct {
+	LabelIDs    []int64
+	AssigneeIDs []int64
+	MilestoneID int64
+	ProjectID   int64
+}
+
+// Fill completes the labels, assignees, milestone and project of a new issue with the ones of the template,
+// for those which are not set
+func (m *TemplateMetas) Fill(labelIDs, assigneeIDs []int64, milestoneID, projectID int64) ([]int64, []int64, int64, int64) {
+	if len(labelIDs) == 0 {
+		labelIDs = m.LabelIDs
+	}
+	if len(assigneeIDs) == 0 {
+		assigneeIDs = m.AssigneeIDs
+	}
+	if milestoneID == 0 {
+		milestoneID = m.MilestoneID
+	}
+	if projectID == 0 {
+		projectID = m.ProjectID
+	}
+	return labelIDs, assigneeIDs, milestoneID, projectID
+}
+
+// GetTemplateMetas resolves the labels, assignees, milestone and project of a template in a repository,
+// those which don't exist or can't be used in the repository are ignored like the unknown labels on the web.
+func GetTemplateMetas(ctx context.Context, repo *repo.Repository, it *api.IssueTemplate, isPull bool) (*TemplateMetas, error) {
+	metas := &TemplateMetas{}
+
+	if len(it.Labels) > 0 {
+		labels, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if err := repo.LoadOwner(ctx); err != nil {
+			return nil, err
+		}
+		if repo.Owner.IsOrganization() {
+			orgLabels, err := issues_model.GetLabelsByOrgID(ctx, repo.OwnerID, "", db.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			labels = append(labels, orgLabels...)
+		}
+		for _, name := range it.Labels {
+			for _, label := range labels {
+				if strings.EqualFold(label.Name, name) {
+					metas.LabelIDs = append(metas.LabelIDs, label.ID)
+					break
+				}
+			}
+		}
+	}
+
+	for _, name := range it.Assignees {
+		assignee, err := user_model.GetUserByName(ctx, strings.TrimPrefix(name, "@"))
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if assignee.IsOrganization() {
+			continue
+		}
+		if ok, err := access_model.CanBeAssigned(ctx, assignee, repo, isPull); err != nil {
+			return nil, err
+		} else if ok {
+			metas.AssigneeIDs = append(metas.AssigneeIDs, assignee.ID)
+		}
+	}
+
+	if it.Milestone != "" {
+		milestone, err := issues_model.GetMilestoneByRepoIDANDName(ctx, repo.ID, it.Milestone)
+		if err == nil {
+			metas.MilestoneID = milestone.ID
+		} else if !issues_model.IsErrMilestoneNotExist(err) {
+			return nil, err
+		}
+	}
+
+	if it.Project != "" && repo.UnitEnabled(ctx, unit.TypeProjects) {
+		// the projects of the repository come before the ones of its owner
+		for _, opts := range []project_model.SearchOptions{
+			{RepoID: repo.ID, Title: it.Project},
+			{OwnerID: repo.OwnerID, Title: it.Project},
+		} {
+			projects, err := db.Find[project_model.Project](ctx, opts)
+			if err != nil {
+				return nil, err
+			}
+			for _, project := range projects {
+				if strings.EqualFold(project.Title, it.Project) && project.CanBeAccessedByOwnerRepo(repo.OwnerID, repo) {
+					metas.ProjectID = project.ID
+					break
+				}
+			}
+			if metas.ProjectID != 0 {
+				break
+			}
+		}
+	}
+
+	return metas, nil
+}
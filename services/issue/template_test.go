@@ -0,0 +1,141 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package issue
+
+import (
+	"net/url"
+	"testing"
+
+	"forgejo.org/models/db"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/issue/template"
+	api "forgejo.org/modules/structs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTemplateForm(t *testing.T) {
+	it, err := template.Unmarshal("bug.yaml", []byte(`
+name: Bug
+about: Report a bug
+body:
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+      regex: "[0-9]+\\.[0-9]+"
+  - type: input
+    id: count
+    attributes:
+      label: Count
+    validations:
+      is_number: true
+  - type: dropdown
+    id: kind
+    attributes:
+      label: Kind
+      options:
+        - Bug
+        - Crash
+    validations:
+      required: true
+  - type: textarea
+    id: trace
+    attributes:
+      label: Stack trace
+    validations:
+      required: true
+    show_if:
+      id: kind
+      values: [Crash]
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Terms
+      options:
+        - label: I searched the existing issues
+          required: true
+`))
+	require.NoError(t, err)
+
+	valid := func() url.Values {
+		return url.Values{
+			"form-field-version": {"1.2"},
+			"form-field-kind":    {"0"},
+			"form-field-terms-0": {"on"},
+		}
+	}
+	require.NoError(t, ValidateTemplateForm(it, valid()))
+
+	for _, tt := range []struct {
+		name    string
+		key     string
+		value   string
+		wantErr string
+	}{
+		{"missing required input", "form-field-version", "", "Version: is required"},
+		{"partial regex match", "form-field-version", "v1.2", "Version: should match [0-9]+\\.[0-9]+"},
+		{"not a number", "form-field-count", "many", "Count: should be a number"},
+		{"missing dropdown", "form-field-kind", "", "Kind: is required"},
+		{"unknown option", "form-field-kind", "2", `Kind: has no option "2"`},
+		{"several options", "form-field-kind", "0,1", "Kind: accepts only one option"},
+		{"shown required field", "form-field-kind", "1", "Stack trace: is required"},
+		{"required checkbox", "form-field-terms-0", "", `Terms: "I searched the existing issues" is required`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			values := valid()
+			values.Set(tt.key, tt.value)
+			err := ValidateTemplateForm(it, values)
+			require.EqualError(t, err, tt.wantErr)
+			assert.True(t, IsErrIssueFormInvalid(err))
+		})
+	}
+
+	// markdown templates have no form
+	require.NoError(t, ValidateTemplateForm(&api.IssueTemplate{FileName: "bug.md"}, url.Values{}))
+
+	// the answers given by the API are converted to the values of the web form
+	values := TemplateFieldsToForm(it, map[string]string{
+		"version": "1.2",
+		"kind":    "1",
+		"trace":   "panic",
+		"terms":   "0",
+		"unknown": "ignored",
+	})
+	assert.Equal(t, url.Values{
+		"form-field-version": {"1.2"},
+		"form-field-kind":    {"1"},
+		"form-field-trace":   {"panic"},
+		"form-field-terms-0": {"on"},
+	}, values)
+	require.NoError(t, ValidateTemplateForm(it, values))
+}
+
+func TestGetTemplateMetas(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	metas, err := GetTemplateMetas(db.DefaultContext, repo, &api.IssueTemplate{
+		Labels:    []string{"LABEL2", "unknown"},
+		Assignees: []string{"@user2", "user5", "unknown"},
+		Milestone: "milestone1",
+		Project:   "first project",
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, metas.LabelIDs)
+	// user5 can't be assigned in repo1
+	assert.Equal(t, []int64{2}, metas.AssigneeIDs)
+	assert.EqualValues(t, 1, metas.MilestoneID)
+	assert.EqualValues(t, 1, metas.ProjectID)
+
+	labelIDs, assigneeIDs, milestoneID, projectID := metas.Fill([]int64{1}, nil, 0, 3)
+	assert.Equal(t, []int64{1}, labelIDs)
+	assert.Equal(t, []int64{2}, assigneeIDs)
+	assert.EqualValues(t, 1, milestoneID)
+	assert.EqualValues(t, 3, projectID)
+}
@@ -247,6 +247,26 @@ loop:
 					return nil
 				}
 
+				if ownerName, repoName, key, ok := token.ParseServiceDeskToken(t); ok {
+					from, err := env.AddressList("From")
+					if err != nil || len(from) == 0 {
+						log.Debug("Service desk email without sender")
+						return nil
+					}
+
+					if err := serviceDeskHandler.Handle(ctx, &ServiceDeskMail{
+						From:    from[0],
+						Subject: env.GetHeader("Subject"),
+						Content: getContentFromMailReader(env),
+					}, ownerName, repoName, key); err != nil {
+						return fmt.Errorf("could not handle service desk message: %w", err)
+					}
+
+					handledSet.AddNum(msg.SeqNum)
+
+					return nil
+				}
+
 				handlerType, user, payload, err := token.ExtractToken(ctx, t)
 				if err != nil {
 					if _, ok := err.(*token.ErrToken); ok {
@@ -32,6 +32,8 @@ var handlers = map[token.HandlerType]MailHandler{
 	token.UnsubscribeHandlerType: &UnsubscribeHandler{},
 }
 
+var serviceDeskHandler = &ServiceDeskHandler{}
+
 // ReplyHandler handles incoming emails to create a reply from them
 type ReplyHandler struct{}
 
@@ -84,23 +86,9 @@ func (h *ReplyHandler) Handle(ctx context.Context, content *MailContent, doer *u
 
 	log.Trace("incoming mail related to %T", ref)
 
-	attachmentIDs := make([]string, 0, len(content.Attachments))
-	if setting.Attachment.Enabled {
-		for _, attachment := range content.Attachments {
-			a, err := attachment_service.UploadAttachment(ctx, bytes.NewReader(attachment.Content), setting.Attachment.AllowedTypes, int64(len(attachment.Content)), &repo_model.Attachment{
-				Name:       attachment.Name,
-				UploaderID: doer.ID,
-				RepoID:     issue.Repo.ID,
-			})
-			if err != nil {
-				if upload.IsErrFileTypeForbidden(err) {
-					log.Info("Skipping disallowed attachment type: %s", attachment.Name)
-					continue
-				}
-				return err
-			}
-			attachmentIDs = append(attachmentIDs, a.UUID)
-		}
+	attachmentIDs, err := uploadAttachments(ctx, content, doer.ID, issue.Repo.ID)
+	if err != nil {
+		return err
 	}
 
 	if content.Content == "" && len(attachmentIDs) == 0 {
@@ -149,6 +137,30 @@ func (h *ReplyHandler) Handle(ctx context.Context, content *MailContent, doer *u
 	return nil
 }
 
+// uploadAttachments uploads the attachments of an incoming mail and returns their UUIDs
+func uploadAttachments(ctx context.Context, content *MailContent, uploaderID, repoID int64) ([]string, error) {
+	attachmentIDs := make([]string, 0, len(content.Attachments))
+	if !setting.Attachment.Enabled {
+		return attachmentIDs, nil
+	}
+	for _, attachment := range content.Attachments {
+		a, err := attachment_service.UploadAttachment(ctx, bytes.NewReader(attachment.Content), setting.Attachment.AllowedTypes, int64(len(attachment.Content)), &repo_model.Attachment{
+			Name:       attachment.Name,
+			UploaderID: uploaderID,
+			RepoID:     repoID,
+		})
+		if err != nil {
+			if upload.IsErrFileTypeForbidden(err) {
+				log.Info("Skipping disallowed attachment type: %s", attachment.Name)
+				continue
+			}
+			return nil, err
+		}
+		attachmentIDs = append(attachmentIDs, a.UUID)
+	}
+	return attachmentIDs, nil
+}
+
 // UnsubscribeHandler handles unwatching issues/pulls
 type UnsubscribeHandler struct{}
 
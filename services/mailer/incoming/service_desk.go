@@ -0,0 +1,120 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package incoming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	net_mail "net/mail"
+	"strings"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/util"
+	issue_service "forgejo.org/services/issue"
+)
+
+// ServiceDeskMail is an email sent to the service desk address of a repository
+type ServiceDeskMail struct {
+	From    *net_mail.Address
+	Subject string
+	Content *MailContent
+}
+
+// ServiceDeskHandler handles emails sent by external contacts to the service desk of a repository
+type ServiceDeskHandler struct{}
+
+// Handle creates an issue from an email sent to the service desk address of
+// the repository, or a comment if the key of the contact of an issue is given
+func (h *ServiceDeskHandler) Handle(ctx context.Context, mail *ServiceDeskMail, ownerName, repoName, key string) error {
+	if mail.From == nil || mail.From.Address == "" {
+		return util.NewInvalidArgumentErrorf("sender can't be empty")
+	}
+	if setting.MailService != nil && strings.EqualFold(mail.From.Address, setting.MailService.FromEmail) {
+		log.Debug("Skipping service desk email sent by the instance")
+		return nil
+	}
+
+	repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ownerName, repoName)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			log.Debug("Service desk email to a repository which does not exist: %s/%s", ownerName, repoName)
+			return nil
+		}
+		return err
+	}
+	if repo.IsArchived || !repo.IsServiceDeskEnabled(ctx) {
+		log.Debug("Service desk of %s is disabled", repo.FullName())
+		return nil
+	}
+
+	if key == "" {
+		return h.newIssue(ctx, repo, mail)
+	}
+	return h.reply(ctx, repo, mail, key)
+}
+
+func (h *ServiceDeskHandler) newIssue(ctx context.Context, repo *repo_model.Repository, mail *ServiceDeskMail) error {
+	attachmentIDs, err := uploadAttachments(ctx, mail.Content, user_model.GhostUserID, repo.ID)
+	if err != nil {
+		return err
+	}
+
+	title := strings.TrimSpace(mail.Subject)
+	if title == "" {
+		title = mail.From.Address
+	}
+
+	issue, err := issue_service.NewServiceDeskIssue(ctx, repo, &issues_model.ServiceDeskContact{
+		Email: mail.From.Address,
+		Name:  mail.From.Name,
+	}, title, mail.Content.Content, attachmentIDs)
+	if err != nil {
+		return fmt.Errorf("NewServiceDeskIssue failed: %w", err)
+	}
+	log.Trace("service desk email created issue %s#%d", repo.FullName(), issue.Index)
+	return nil
+}
+
+func (h *ServiceDeskHandler) reply(ctx context.Context, repo *repo_model.Repository, mail *ServiceDeskMail, key string) error {
+	contact, err := issues_model.GetServiceDeskContactByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			log.Info("Unknown service desk contact key for %s", repo.FullName())
+			return nil
+		}
+		return err
+	}
+
+	issue, err := issues_model.GetIssueByID(ctx, contact.IssueID)
+	if err != nil {
+		return err
+	}
+	if issue.RepoID != repo.ID {
+		log.Info("Service desk contact key of another repository than %s", repo.FullName())
+		return nil
+	}
+	if issue.IsLocked {
+		log.Debug("Skipping service desk reply to a locked issue")
+		return nil
+	}
+
+	attachmentIDs, err := uploadAttachments(ctx, mail.Content, user_model.GhostUserID, repo.ID)
+	if err != nil {
+		return err
+	}
+	if mail.Content.Content == "" && len(attachmentIDs) == 0 {
+		log.Trace("service desk email has no content and no attachment")
+		return nil
+	}
+
+	if _, err := issue_service.CreateServiceDeskComment(ctx, issue, contact, mail.Content.Content, attachmentIDs); err != nil {
+		return fmt.Errorf("CreateServiceDeskComment failed: %w", err)
+	}
+	return nil
+}
@@ -36,15 +36,16 @@ import (
 )
 
 const (
-	mailAuthActivate           base.TplName = "auth/activate"
-	mailAuthActivateEmail      base.TplName = "auth/activate_email"
-	mailAuthResetPassword      base.TplName = "auth/reset_passwd"
-	mailAuthRegisterNotify     base.TplName = "auth/register_notify"
-	mailAuthPasswordChange     base.TplName = "auth/password_change"
-	mailAuthPrimaryMailChange  base.TplName = "auth/primary_mail_change"
-	mailAuth2faDisabled        base.TplName = "auth/2fa_disabled"
-	mailAuthRemovedSecurityKey base.TplName = "auth/removed_security_key"
-	mailAuthTOTPEnrolled       base.TplName = "auth/totp_enrolled"
+	mailAuthActivate            base.TplName = "auth/activate"
+	mailAuthActivateEmail       base.TplName = "auth/activate_email"
+	mailAuthResetPassword       base.TplName = "auth/reset_passwd"
+	mailAuthRegisterNotify      base.TplName = "auth/register_notify"
+	mailAuthPasswordChange      base.TplName = "auth/password_change"
+	mailAuthPrimaryMailChange   base.TplName = "auth/primary_mail_change"
+	mailAuth2faDisabled         base.TplName = "auth/2fa_disabled"
+	mailAuthRemovedSecurityKey  base.TplName = "auth/removed_security_key"
+	mailAuthTOTPEnrolled        base.TplName = "auth/totp_enrolled"
+	mailAuthAccessTokenExpiring base.TplName = "auth/access_token_expiring"
 
 	mailNotifyCollaborator base.TplName = "notify/collaborator"
 
@@ -749,3 +750,32 @@ func SendTOTPEnrolled(ctx context.Context, u *user_model.User) error {
 	SendAsync(msg)
 	return nil
 }
+
+// SendAccessTokenExpiring informs the user that some of their access tokens expire soon.
+func SendAccessTokenExpiring(ctx context.Context, u *user_model.User, tokens []*auth_model.AccessToken) error {
+	if setting.MailService == nil {
+		return nil
+	}
+	locale := translation.NewLocale(u.Language)
+
+	data := map[string]any{
+		"locale":       locale,
+		"Tokens":       tokens,
+		"SettingsLink": setting.AppURL + "user/settings/applications",
+		"DisplayName":  u.DisplayName(),
+		"Username":     u.Name,
+		"Language":     locale.Language(),
+	}
+
+	var content bytes.Buffer
+
+	if err := bodyTemplates.ExecuteTemplate(&content, string(mailAuthAccessTokenExpiring), data); err != nil {
+		return err
+	}
+
+	msg := NewMessage(u.EmailTo(), locale.TrString("mail.access_token_expiring.subject"), content.String())
+	msg.Info = fmt.Sprintf("UID: %d, access token expiry notification", u.ID)
+
+	SendAsync(msg)
+	return nil
+}
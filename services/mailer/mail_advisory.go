@@ -0,0 +1,101 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	advisory_model "forgejo.org/models/advisory"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/markup"
+	"forgejo.org/modules/markup/markdown"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/translation"
+)
+
+const (
+	tplSecurityAdvisoryMail base.TplName = "security_advisory"
+)
+
+// MailSecurityAdvisory sends a published security advisory to the watchers of its repository
+func MailSecurityAdvisory(ctx context.Context, doer *user_model.User, advisory *advisory_model.Advisory) {
+	if setting.MailService == nil {
+		// No mail service configured
+		return
+	}
+
+	watcherIDList, err := repo_model.GetRepoWatchersIDs(ctx, advisory.RepoID)
+	if err != nil {
+		log.Error("GetRepoWatchersIDs(%d): %v", advisory.RepoID, err)
+		return
+	}
+
+	recipients, err := user_model.GetMaileableUsersByIDs(ctx, watcherIDList, false)
+	if err != nil {
+		log.Error("user_model.GetMaileableUsersByIDs: %v", err)
+		return
+	}
+
+	langMap := make(map[string][]*user_model.User)
+	for _, user := range recipients {
+		if user.ID != doer.ID && access_model.CheckRepoUnitUser(ctx, advisory.Repo, user, unit.TypeCode) {
+			langMap[user.Language] = append(langMap[user.Language], user)
+		}
+	}
+
+	for lang, tos := range langMap {
+		mailSecurityAdvisory(ctx, lang, tos, doer, advisory)
+	}
+}
+
+func mailSecurityAdvisory(ctx context.Context, lang string, tos []*user_model.User, doer *user_model.User, advisory *advisory_model.Advisory) {
+	locale := translation.NewLocale(lang)
+
+	description, err := markdown.RenderString(&markup.RenderContext{
+		Ctx: ctx,
+		Links: markup.Links{
+			AbsolutePrefix: true,
+			Base:           advisory.Repo.HTMLURL(),
+		},
+		Metas: advisory.Repo.ComposeMetas(ctx),
+	}, advisory.Description)
+	if err != nil {
+		log.Error("markdown.RenderString(%d): %v", advisory.RepoID, err)
+		return
+	}
+
+	subject := locale.TrString("mail.security_advisory.subject", advisory.Repo.FullName(), advisory.Title)
+	mailMeta := map[string]any{
+		"locale":      locale,
+		"Advisory":    advisory,
+		"Description": description,
+		"Subject":     subject,
+		"Language":    locale.Language(),
+		"Link":        advisory.HTMLURL(),
+	}
+
+	var mailBody bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&mailBody, string(tplSecurityAdvisoryMail), mailMeta); err != nil {
+		log.Error("ExecuteTemplate [%s]: %v", tplSecurityAdvisoryMail, err)
+		return
+	}
+
+	msgs := make([]*Message, 0, len(tos))
+	msgID := fmt.Sprintf("<%s/security/advisories/%s@%s>", advisory.Repo.FullName(), advisory.Identifier, setting.Domain)
+	for _, to := range tos {
+		msg := NewMessageFrom(to.EmailTo(), fromDisplayName(doer), setting.MailService.FromEmail, subject, mailBody.String())
+		msg.Info = subject
+		msg.SetHeader("Message-ID", msgID)
+		msgs = append(msgs, msg)
+	}
+
+	SendAsync(msgs...)
+}
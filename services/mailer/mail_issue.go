@@ -162,6 +162,15 @@ func mailIssueCommentBatch(ctx *mailCommentContext, users []*user_model.User, vi
 		if !access_model.CheckRepoUnitUser(ctx, ctx.Issue.Repo, user, checkUnit) {
 			continue
 		}
+		if ctx.Issue.IsConfidential {
+			visible, err := issues_model.IsIssueVisibleToUser(ctx, ctx.Issue, user)
+			if err != nil {
+				return err
+			}
+			if !visible {
+				continue
+			}
+		}
 
 		langMap[user.Language] = append(langMap[user.Language], user)
 	}
@@ -0,0 +1,131 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	issues_model "forgejo.org/models/issues"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/base"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/markup"
+	"forgejo.org/modules/markup/markdown"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/storage"
+	"forgejo.org/modules/translation"
+	"forgejo.org/services/mailer/token"
+)
+
+const (
+	tplServiceDeskMail base.TplName = "issue/service_desk"
+
+	// maxServiceDeskAttachmentsSize is the maximum total size of the files attached to a mail to a service desk contact
+	maxServiceDeskAttachmentsSize = 10 << 20
+)
+
+// MailServiceDeskContact mails to the external contact of an issue created by the service desk
+// that the issue was created, or a comment posted on it by a user of the instance.
+func MailServiceDeskContact(ctx context.Context, issue *issues_model.Issue, comment *issues_model.Comment) error {
+	// issues of the service desk are posted by the ghost user
+	if setting.MailService == nil || !setting.IncomingEmail.Enabled || issue.PosterID != user_model.GhostUserID {
+		return nil
+	}
+
+	contact, has, err := issues_model.GetServiceDeskContact(ctx, issue.ID)
+	if err != nil || !has {
+		return err
+	}
+	if err := issue.LoadRepo(ctx); err != nil {
+		return err
+	}
+
+	content, fromName := issue.Content, setting.MailService.FromName
+	var attachments []*MessageAttachment
+	if comment != nil {
+		if err := comment.LoadPoster(ctx); err != nil {
+			return err
+		}
+		if err := comment.LoadAttachments(ctx); err != nil {
+			return err
+		}
+		content, fromName = comment.Content, fromDisplayName(comment.Poster)
+		if attachments, err = serviceDeskAttachments(comment); err != nil {
+			return err
+		}
+	}
+
+	body, err := markdown.RenderString(&markup.RenderContext{
+		Ctx: ctx,
+		Links: markup.Links{
+			AbsolutePrefix: true,
+			Base:           issue.Repo.HTMLURL(),
+		},
+		Metas: issue.Repo.ComposeMetas(ctx),
+	}, content)
+	if err != nil {
+		return err
+	}
+
+	locale := translation.NewLocale("")
+	subject := fmt.Sprintf("[%s] %s (#%d)", issue.Repo.FullName(), issue.Title, issue.Index)
+	if comment != nil {
+		subject = "Re: " + subject
+	}
+	mailMeta := map[string]any{
+		"locale":   locale,
+		"Subject":  subject,
+		"Language": locale.Language(),
+		"Issue":    issue,
+		"Comment":  comment,
+		"Repo":     issue.Repo.FullName(),
+		"Body":     body,
+	}
+
+	var mailBody bytes.Buffer
+	if err := bodyTemplates.ExecuteTemplate(&mailBody, string(tplServiceDeskMail), mailMeta); err != nil {
+		return fmt.Errorf("ExecuteTemplate [%s]: %w", tplServiceDeskMail, err)
+	}
+
+	replyToken := token.ServiceDeskToken(issue.Repo, contact.Key)
+	msg := NewMessageFrom(contact.Email, fromName, setting.MailService.FromEmail, sanitizeSubject(subject), mailBody.String())
+	msg.Info = fmt.Sprintf("Subject: %s, service desk contact of issue %d", subject, issue.ID)
+	msg.ReplyTo = token.ServiceDeskAddress(issue.Repo, contact.Key)
+	msg.Attachments = attachments
+
+	reference := createReference(issue, nil, 0)
+	msg.SetHeader("Message-ID", createReference(issue, comment, 0))
+	msg.SetHeader("In-Reply-To", reference)
+	msg.SetHeader("References", reference, fmt.Sprintf("<reply-%s@%s>", replyToken, setting.Domain))
+
+	SendAsync(msg)
+	return nil
+}
+
+// serviceDeskAttachments returns the files attached to a comment, up to maxServiceDeskAttachmentsSize
+func serviceDeskAttachments(comment *issues_model.Comment) ([]*MessageAttachment, error) {
+	attachments := make([]*MessageAttachment, 0, len(comment.Attachments))
+	var size int64
+	for _, a := range comment.Attachments {
+		if size+a.Size > maxServiceDeskAttachmentsSize {
+			log.Debug("Skipping attachment %s of comment %d which exceeds the size of a mail", a.Name, comment.ID)
+			continue
+		}
+		f, err := storage.Attachments.Open(a.RelativePath())
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		size += a.Size
+		attachments = append(attachments, &MessageAttachment{Name: a.Name, Content: content})
+	}
+	return attachments, nil
+}
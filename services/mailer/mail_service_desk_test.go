@@ -0,0 +1,71 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package mailer_test
+
+import (
+	"testing"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+	issue_service "forgejo.org/services/issue"
+	"forgejo.org/services/mailer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMailServiceDeskContact(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.IncomingEmail.Enabled, true)()
+	defer test.MockVariableValue(&setting.IncomingEmail.ReplyToAddress, "incoming+%{token}@localhost")()
+
+	var sent []*mailer.Message
+	defer mailer.MockMailSettings(func(msgs ...*mailer.Message) {
+		sent = append(sent, msgs...)
+	})()
+
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	contact := &issues_model.ServiceDeskContact{Email: "customer@example.com", Name: "Customer"}
+	issue, err := issue_service.NewServiceDeskIssue(t.Context(), repo, contact, "Printer on fire", "Please help", nil)
+	require.NoError(t, err)
+	assert.True(t, issue.IsConfidential)
+	assert.Equal(t, "Customer <customer@example.com>", issue.OriginalAuthor)
+	assert.NotEmpty(t, contact.Key)
+
+	t.Run("New issue", func(t *testing.T) {
+		sent = nil
+		require.NoError(t, mailer.MailServiceDeskContact(t.Context(), issue, nil))
+		require.Len(t, sent, 1)
+		msg := sent[0]
+		assert.Equal(t, "customer@example.com", msg.To)
+		assert.Equal(t, "incoming+user2/repo1+support+"+contact.Key+"@localhost", msg.ReplyTo)
+		assert.Contains(t, msg.Body, "Please help")
+		mailer.AssertTranslatedLocale(t, msg.Body, "mail.issue.service_desk")
+	})
+
+	t.Run("Comment", func(t *testing.T) {
+		comment, err := issue_service.CreateIssueComment(t.Context(), doer, repo, issue, "We are on it", nil)
+		require.NoError(t, err)
+
+		sent = nil
+		require.NoError(t, mailer.MailServiceDeskContact(t.Context(), issue, comment))
+		require.Len(t, sent, 1)
+		msg := sent[0]
+		assert.Contains(t, msg.Subject, "Re: [user2/repo1] Printer on fire")
+		assert.Contains(t, msg.Body, "We are on it")
+		assert.Contains(t, msg.Headers["References"], "<reply-user2/repo1+support+"+contact.Key+"@localhost>")
+	})
+
+	t.Run("Not a service desk issue", func(t *testing.T) {
+		sent = nil
+		other := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		require.NoError(t, mailer.MailServiceDeskContact(t.Context(), other, nil))
+		assert.Empty(t, sent)
+	})
+}
@@ -44,6 +44,13 @@ type Message struct {
 	Date            time.Time
 	Body            string
 	Headers         map[string][]string
+	Attachments     []*MessageAttachment
+}
+
+// MessageAttachment is a file attached to a mail
+type MessageAttachment struct {
+	Name    string
+	Content []byte
 }
 
 // ToMessage converts a Message to gomail.Message
@@ -77,6 +84,14 @@ func (m *Message) ToMessage() *gomail.Message {
 		msg.AddAlternative("text/html", m.Body)
 	}
 
+	for _, attachment := range m.Attachments {
+		content := attachment.Content
+		msg.Attach(attachment.Name, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(content)
+			return err
+		}))
+	}
+
 	if len(msg.GetHeader("Message-ID")) == 0 {
 		msg.SetHeader("Message-ID", m.generateAutoMessageID())
 	}
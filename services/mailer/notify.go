@@ -9,6 +9,7 @@ import (
 
 	actions_model "forgejo.org/models/actions"
 	activities_model "forgejo.org/models/activities"
+	advisory_model "forgejo.org/models/advisory"
 	issues_model "forgejo.org/models/issues"
 	repo_model "forgejo.org/models/repo"
 	user_model "forgejo.org/models/user"
@@ -47,12 +48,23 @@ func (m *mailNotifier) CreateIssueComment(ctx context.Context, doer *user_model.
 	if err := MailParticipantsComment(ctx, comment, act, issue, mentions); err != nil {
 		log.Error("MailParticipantsComment: %v", err)
 	}
+
+	// comments mailed by the contact of a service desk issue have an original author
+	if comment.Type == issues_model.CommentTypeComment && comment.OriginalAuthor == "" && !issue.IsPull {
+		if err := MailServiceDeskContact(ctx, issue, comment); err != nil {
+			log.Error("MailServiceDeskContact: %v", err)
+		}
+	}
 }
 
 func (m *mailNotifier) NewIssue(ctx context.Context, issue *issues_model.Issue, mentions []*user_model.User) {
 	if err := MailParticipants(ctx, issue, issue.Poster, activities_model.ActionCreateIssue, mentions, nil); err != nil {
 		log.Error("MailParticipants: %v", err)
 	}
+
+	if err := MailServiceDeskContact(ctx, issue, nil); err != nil {
+		log.Error("MailServiceDeskContact: %v", err)
+	}
 }
 
 func (m *mailNotifier) IssueChangeStatus(ctx context.Context, doer *user_model.User, commitID string, issue *issues_model.Issue, actionComment *issues_model.Comment, isClosed bool) {
@@ -206,6 +218,10 @@ func (m *mailNotifier) NewRelease(ctx context.Context, rel *repo_model.Release)
 	MailNewRelease(ctx, rel)
 }
 
+func (m *mailNotifier) PublishSecurityAdvisory(ctx context.Context, doer *user_model.User, advisory *advisory_model.Advisory) {
+	MailSecurityAdvisory(ctx, doer, advisory)
+}
+
 func (m *mailNotifier) RepoPendingTransfer(ctx context.Context, doer, newOwner *user_model.User, repo *repo_model.Repository) {
 	if err := SendRepoTransferNotifyMail(ctx, doer, newOwner, repo); err != nil {
 		log.Error("SendRepoTransferNotifyMail: %v", err)
@@ -0,0 +1,56 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package token
+
+import (
+	"strings"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/setting"
+)
+
+// The service desk of a repository is reached through the incoming email
+// address, with the token replaced by:
+//
+//	<owner>/<repo>+support        to create an issue
+//	<owner>/<repo>+support+<key>  to reply to the issue of the contact having the key
+//
+// Owner and repository names contain neither "/" nor "+".
+const serviceDeskSuffix = "+support"
+
+// ServiceDeskToken returns the token of the service desk address of a repository,
+// or of the address the contact having the given key replies to if it is not empty
+func ServiceDeskToken(repo *repo_model.Repository, key string) string {
+	t := strings.ToLower(repo.OwnerName+"/"+repo.Name) + serviceDeskSuffix
+	if key != "" {
+		t += "+" + key
+	}
+	return t
+}
+
+// ServiceDeskAddress returns the service desk address of a repository,
+// or the address the contact having the given key replies to if it is not empty
+func ServiceDeskAddress(repo *repo_model.Repository, key string) string {
+	return strings.Replace(setting.IncomingEmail.ReplyToAddress, setting.IncomingEmail.TokenPlaceholder, ServiceDeskToken(repo, key), 1)
+}
+
+// ParseServiceDeskToken returns the owner and repository names and the contact key of a service desk token
+func ParseServiceDeskToken(t string) (ownerName, repoName, key string, ok bool) {
+	fullName, rest, ok := strings.Cut(t, serviceDeskSuffix)
+	if !ok {
+		return "", "", "", false
+	}
+	switch {
+	case rest == "":
+	case strings.HasPrefix(rest, "+") && len(rest) > 1:
+		key = rest[1:]
+	default:
+		return "", "", "", false
+	}
+	ownerName, repoName, ok = strings.Cut(fullName, "/")
+	if !ok || ownerName == "" || repoName == "" || strings.ContainsAny(repoName, "/+") {
+		return "", "", "", false
+	}
+	return ownerName, repoName, key, true
+}
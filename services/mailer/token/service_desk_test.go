@@ -0,0 +1,43 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package token
+
+import (
+	"testing"
+
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeskToken(t *testing.T) {
+	defer test.MockVariableValue(&setting.IncomingEmail.ReplyToAddress, "incoming+%{token}@example.com")()
+
+	repo := &repo_model.Repository{OwnerName: "User2", Name: "Repo1"}
+	assert.Equal(t, "user2/repo1+support", ServiceDeskToken(repo, ""))
+	assert.Equal(t, "incoming+user2/repo1+support@example.com", ServiceDeskAddress(repo, ""))
+	assert.Equal(t, "incoming+user2/repo1+support+0123abcd@example.com", ServiceDeskAddress(repo, "0123abcd"))
+
+	for _, tc := range []struct {
+		token, owner, repo, key string
+		ok                      bool
+	}{
+		{token: "user2/repo1+support", owner: "user2", repo: "repo1", ok: true},
+		{token: "user2/repo1+support+0123abcd", owner: "user2", repo: "repo1", key: "0123abcd", ok: true},
+		{token: "user2/repo1+support+"},
+		{token: "user2/repo1+supporting"},
+		{token: "user2+support"},
+		{token: "/repo1+support"},
+		{token: "user2/sub/repo1+support"},
+		{token: "GEZDGNBVGY3TQOJQGEZDGNBVGY"},
+	} {
+		owner, repo, key, ok := ParseServiceDeskToken(tc.token)
+		assert.Equal(t, tc.ok, ok, tc.token)
+		assert.Equal(t, tc.owner, owner, tc.token)
+		assert.Equal(t, tc.repo, repo, tc.token)
+		assert.Equal(t, tc.key, key, tc.token)
+	}
+}
@@ -74,6 +74,20 @@ func NewGiteaLocalUploader(ctx context.Context, doer *user_model.User, repoOwner
 	}
 }
 
+// newGiteaLocalUploaderForRepo creates an Uploader adding issues and comments to an existing repository,
+// with its labels and milestones. The issues and comments of other users than the doer are kept with
+// their original author, as for the migrations from other forges.
+func newGiteaLocalUploaderForRepo(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, labels map[string]*issues_model.Label, milestones map[string]int64) *GiteaLocalUploader {
+	g := NewGiteaLocalUploader(ctx, doer, repo.OwnerName, repo.Name)
+	g.repo = repo
+	g.sameApp = true
+	g.labels = labels
+	g.milestones = milestones
+	// the posters unknown to the import have no ID
+	g.userMap[0] = 0
+	return g
+}
+
 // MaxBatchInsertSize returns the table's max batch insert size
 func (g *GiteaLocalUploader) MaxBatchInsertSize(tp string) int {
 	switch tp {
@@ -0,0 +1,425 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package migrations
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/modules/util"
+)
+
+// IssueExchangeFormat is the format of the files issues are exported to and imported from
+type IssueExchangeFormat string
+
+const (
+	IssueExchangeFormatCSV  IssueExchangeFormat = "csv"
+	IssueExchangeFormatJSON IssueExchangeFormat = "json"
+)
+
+// IsValid returns true if the format is known
+func (f IssueExchangeFormat) IsValid() bool {
+	return f == IssueExchangeFormatCSV || f == IssueExchangeFormatJSON
+}
+
+// ExchangeIssue is an issue as it is exported to and imported from a file.
+// On import, the nil attributes are left unchanged: a spreadsheet may
+// only have the columns it is meant to change.
+type ExchangeIssue struct {
+	Index     int64             `json:"index,omitempty"`
+	Title     string            `json:"title"`
+	State     *string           `json:"state,omitempty"`
+	Poster    string            `json:"poster,omitempty"`
+	Milestone *string           `json:"milestone,omitempty"`
+	Labels    []string          `json:"labels"`
+	Assignees []string          `json:"assignees"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	Created   *time.Time        `json:"created,omitempty"`
+	Updated   *time.Time        `json:"updated,omitempty"`
+	Closed    *time.Time        `json:"closed,omitempty"`
+	Content   *string           `json:"content,omitempty"`
+	// Comments are only exported on demand, and only imported with new issues
+	Comments []*ExchangeComment `json:"comments,omitempty"`
+}
+
+// ExchangeComment is a comment of an exported issue
+type ExchangeComment struct {
+	Poster  string    `json:"poster"`
+	Created time.Time `json:"created"`
+	Content string    `json:"content"`
+}
+
+const (
+	issueStateOpen   = "open"
+	issueStateClosed = "closed"
+
+	// exchangeFieldPrefix prefixes the CSV columns of the custom fields
+	exchangeFieldPrefix = "field:"
+)
+
+var exchangeColumns = []string{"index", "title", "state", "poster", "milestone", "labels", "assignees", "created", "updated", "closed", "content"}
+
+// ExportIssues writes the issues matching the options to w, with their comments if withComments is set
+func ExportIssues(ctx context.Context, w io.Writer, format IssueExchangeFormat, opts *issues_model.IssuesOptions, withComments bool) error {
+	records, err := exchangeIssues(ctx, opts, withComments)
+	if err != nil {
+		return err
+	}
+	return WriteExchangeIssues(w, format, records)
+}
+
+func exchangeIssues(ctx context.Context, opts *issues_model.IssuesOptions, withComments bool) ([]*ExchangeIssue, error) {
+	const batchSize = 50
+
+	pageOpts := *opts
+	records := make([]*ExchangeIssue, 0, batchSize)
+	for page := 1; ; page++ {
+		pageOpts.Paginator = &db.ListOptions{Page: page, PageSize: batchSize}
+		issues, err := issues_model.Issues(ctx, &pageOpts)
+		if err != nil {
+			return nil, err
+		}
+		if err := loadExchangeAttributes(ctx, issues, withComments); err != nil {
+			return nil, err
+		}
+		ids := make([]int64, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		values, err := issues_model.GetIssueCustomFieldValues(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			records = append(records, toExchangeIssue(issue, values[issue.ID], withComments))
+		}
+		if len(issues) < batchSize {
+			return records, nil
+		}
+	}
+}
+
+func loadExchangeAttributes(ctx context.Context, issues issues_model.IssueList, withComments bool) error {
+	if err := issues.LoadPosters(ctx); err != nil {
+		return err
+	}
+	if err := issues.LoadLabels(ctx); err != nil {
+		return err
+	}
+	if err := issues.LoadMilestones(ctx); err != nil {
+		return err
+	}
+	if err := issues.LoadAssignees(ctx); err != nil {
+		return err
+	}
+	if !withComments {
+		return nil
+	}
+	if err := issues.LoadDiscussComments(ctx); err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if err := issue.Comments.LoadPosters(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toExchangeIssue(issue *issues_model.Issue, values []*issues_model.CustomFieldValue, withComments bool) *ExchangeIssue {
+	state := issueStateOpen
+	if issue.IsClosed {
+		state = issueStateClosed
+	}
+	milestone := ""
+	if issue.Milestone != nil {
+		milestone = issue.Milestone.Name
+	}
+	created := issue.CreatedUnix.AsTime()
+	updated := issue.UpdatedUnix.AsTime()
+
+	record := &ExchangeIssue{
+		Index:     issue.Index,
+		Title:     issue.Title,
+		State:     &state,
+		Poster:    exchangePoster(issue.OriginalAuthor, issue.Poster.Name),
+		Milestone: &milestone,
+		Labels:    make([]string, 0, len(issue.Labels)),
+		Assignees: make([]string, 0, len(issue.Assignees)),
+		Created:   &created,
+		Updated:   &updated,
+		Content:   &issue.Content,
+	}
+	if issue.IsClosed && issue.ClosedUnix > 0 {
+		closed := issue.ClosedUnix.AsTime()
+		record.Closed = &closed
+	}
+	for _, l := range issue.Labels {
+		record.Labels = append(record.Labels, l.Name)
+	}
+	for _, u := range issue.Assignees {
+		record.Assignees = append(record.Assignees, u.Name)
+	}
+	for _, v := range values {
+		if record.Fields == nil {
+			record.Fields = make(map[string]string, len(values))
+		}
+		record.Fields[v.Field.Name] = v.DisplayValue()
+	}
+	if withComments {
+		record.Comments = make([]*ExchangeComment, 0, len(issue.Comments))
+		for _, c := range issue.Comments {
+			record.Comments = append(record.Comments, &ExchangeComment{
+				Poster:  exchangePoster(c.OriginalAuthor, c.Poster.Name),
+				Created: c.CreatedUnix.AsTime(),
+				Content: c.Content,
+			})
+		}
+	}
+	return record
+}
+
+// exchangePoster returns the original author of migrated issues and comments, the poster otherwise
+func exchangePoster(originalAuthor, poster string) string {
+	if originalAuthor != "" {
+		return originalAuthor
+	}
+	return poster
+}
+
+// WriteExchangeIssues writes issues to w in the format
+func WriteExchangeIssues(w io.Writer, format IssueExchangeFormat, records []*ExchangeIssue) error {
+	switch format {
+	case IssueExchangeFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case IssueExchangeFormatCSV:
+		return writeExchangeIssuesCSV(w, records)
+	}
+	return util.NewInvalidArgumentErrorf("unknown format %q", format)
+}
+
+func writeExchangeIssuesCSV(w io.Writer, records []*ExchangeIssue) error {
+	var fields []string
+	withComments := false
+	for _, record := range records {
+		for name := range record.Fields {
+			if !slices.Contains(fields, name) {
+				fields = append(fields, name)
+			}
+		}
+		withComments = withComments || record.Comments != nil
+	}
+	slices.Sort(fields)
+
+	header := slices.Clone(exchangeColumns)
+	for _, name := range fields {
+		header = append(header, exchangeFieldPrefix+name)
+	}
+	if withComments {
+		header = append(header, "comments")
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, record := range records {
+		row := []string{
+			strconv.FormatInt(record.Index, 10),
+			record.Title,
+			exchangeString(record.State),
+			record.Poster,
+			exchangeString(record.Milestone),
+			strings.Join(record.Labels, ","),
+			strings.Join(record.Assignees, ","),
+			formatExchangeTime(record.Created),
+			formatExchangeTime(record.Updated),
+			formatExchangeTime(record.Closed),
+			exchangeString(record.Content),
+		}
+		for _, name := range fields {
+			row = append(row, record.Fields[name])
+		}
+		if withComments {
+			comments, err := json.Marshal(record.Comments)
+			if err != nil {
+				return err
+			}
+			row = append(row, string(comments))
+		}
+		for i := range row {
+			row[i] = escapeExchangeCell(row[i])
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exchangeFormulaPrefixes are the characters spreadsheets start a formula with
+const exchangeFormulaPrefixes = "=+-@\t\r"
+
+// escapeExchangeCell prefixes the CSV cells that a spreadsheet would evaluate as
+// formula with a quote, cells already starting with a quote are prefixed as well
+// so that unescapeExchangeCell restores them.
+func escapeExchangeCell(s string) string {
+	if s != "" && strings.ContainsRune(exchangeFormulaPrefixes+"'", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// unescapeExchangeCell removes the quote escapeExchangeCell added
+func unescapeExchangeCell(s string) string {
+	if len(s) > 1 && s[0] == '\'' && strings.ContainsRune(exchangeFormulaPrefixes+"'", rune(s[1])) {
+		return s[1:]
+	}
+	return s
+}
+
+func exchangeString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func formatExchangeTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ReadExchangeIssues reads the issues of a file in the format
+func ReadExchangeIssues(r io.Reader, format IssueExchangeFormat) ([]*ExchangeIssue, error) {
+	switch format {
+	case IssueExchangeFormatJSON:
+		var records []*ExchangeIssue
+		if err := json.NewDecoder(r).Decode(&records); err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid JSON: %v", err)
+		}
+		if slices.Contains(records, nil) {
+			return nil, util.NewInvalidArgumentErrorf("invalid JSON: null issue")
+		}
+		return records, nil
+	case IssueExchangeFormatCSV:
+		return readExchangeIssuesCSV(r)
+	}
+	return nil, util.NewInvalidArgumentErrorf("unknown format %q", format)
+}
+
+func readExchangeIssuesCSV(r io.Reader) ([]*ExchangeIssue, error) {
+	cr := csv.NewReader(r)
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid CSV: %v", err)
+	}
+	if len(rows) == 0 {
+		return nil, util.NewInvalidArgumentErrorf("invalid CSV: no header")
+	}
+
+	header := rows[0]
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+		if !slices.Contains(exchangeColumns, header[i]) && header[i] != "comments" && !strings.HasPrefix(header[i], exchangeFieldPrefix) {
+			return nil, util.NewInvalidArgumentErrorf("invalid CSV: unknown column %q", header[i])
+		}
+	}
+	if !slices.Contains(header, "title") {
+		return nil, util.NewInvalidArgumentErrorf("invalid CSV: no title column")
+	}
+
+	records := make([]*ExchangeIssue, 0, len(rows)-1)
+	for line, row := range rows[1:] {
+		record, err := parseExchangeIssueRow(header, row)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("invalid CSV: line %d: %v", line+2, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func parseExchangeIssueRow(header, row []string) (*ExchangeIssue, error) {
+	record := &ExchangeIssue{}
+	for i, column := range header {
+		value := unescapeExchangeCell(row[i])
+		var err error
+		switch column {
+		case "index":
+			if value = strings.TrimSpace(value); value != "" {
+				record.Index, err = strconv.ParseInt(value, 10, 64)
+			}
+		case "title":
+			record.Title = value
+		case "state":
+			record.State = &value
+		case "poster":
+			record.Poster = strings.TrimSpace(value)
+		case "milestone":
+			record.Milestone = &value
+		case "labels":
+			record.Labels = splitExchangeList(value)
+		case "assignees":
+			record.Assignees = splitExchangeList(value)
+		case "created":
+			record.Created, err = parseExchangeTime(value)
+		case "updated":
+			record.Updated, err = parseExchangeTime(value)
+		case "closed":
+			record.Closed, err = parseExchangeTime(value)
+		case "content":
+			record.Content = &value
+		case "comments":
+			if strings.TrimSpace(value) != "" {
+				err = json.Unmarshal([]byte(value), &record.Comments)
+			}
+		default:
+			if record.Fields == nil {
+				record.Fields = make(map[string]string)
+			}
+			record.Fields[strings.TrimPrefix(column, exchangeFieldPrefix)] = value
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", column, err)
+		}
+	}
+	return record, nil
+}
+
+// splitExchangeList splits a comma separated list of names, an empty list is not nil
+func splitExchangeList(s string) []string {
+	names := []string{}
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func parseExchangeTime(s string) (*time.Time, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
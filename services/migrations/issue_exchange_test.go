@@ -0,0 +1,222 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package migrations
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchangeIssuesRoundTrip(t *testing.T) {
+	state, milestone, content := "closed", "v1.0", "line one,\n\"line two\""
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := []*ExchangeIssue{
+		{
+			Index:     3,
+			Title:     "Crash, on start",
+			State:     &state,
+			Poster:    "user2",
+			Milestone: &milestone,
+			Labels:    []string{"bug", "ui"},
+			Assignees: []string{},
+			Fields:    map[string]string{"Priority": "High"},
+			Created:   &created,
+			Content:   &content,
+			Comments:  []*ExchangeComment{{Poster: "user5", Created: created, Content: "me too"}},
+		},
+	}
+
+	for _, format := range []IssueExchangeFormat{IssueExchangeFormatCSV, IssueExchangeFormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteExchangeIssues(&buf, format, records))
+			read, err := ReadExchangeIssues(&buf, format)
+			require.NoError(t, err)
+			require.Len(t, read, 1)
+			assert.Equal(t, records[0].Index, read[0].Index)
+			assert.Equal(t, records[0].Title, read[0].Title)
+			assert.Equal(t, state, *read[0].State)
+			assert.Equal(t, milestone, *read[0].Milestone)
+			assert.Equal(t, content, *read[0].Content)
+			assert.Equal(t, records[0].Labels, read[0].Labels)
+			assert.Empty(t, read[0].Assignees)
+			assert.NotNil(t, read[0].Assignees)
+			assert.Equal(t, records[0].Fields, read[0].Fields)
+			assert.True(t, created.Equal(*read[0].Created))
+			require.Len(t, read[0].Comments, 1)
+			assert.Equal(t, "me too", read[0].Comments[0].Content)
+		})
+	}
+
+	t.Run("Formulas", func(t *testing.T) {
+		content := "'quoted"
+		records := []*ExchangeIssue{{
+			Title:   "=HYPERLINK(\"https://example.com\")",
+			Poster:  "@user2",
+			Labels:  []string{"+1"},
+			Fields:  map[string]string{"Estimate": "-3", "Note": "\tindented"},
+			Content: &content,
+		}}
+		var buf bytes.Buffer
+		require.NoError(t, WriteExchangeIssues(&buf, IssueExchangeFormatCSV, records))
+		assert.Contains(t, buf.String(), `"'=HYPERLINK(""https://example.com"")"`)
+		assert.Contains(t, buf.String(), ",'@user2,")
+		assert.Contains(t, buf.String(), ",'+1,")
+		assert.Contains(t, buf.String(), ",'-3,")
+		assert.Contains(t, buf.String(), "''quoted")
+
+		read, err := ReadExchangeIssues(&buf, IssueExchangeFormatCSV)
+		require.NoError(t, err)
+		require.Len(t, read, 1)
+		assert.Equal(t, records[0].Title, read[0].Title)
+		assert.Equal(t, "@user2", read[0].Poster)
+		assert.Equal(t, []string{"+1"}, read[0].Labels)
+		assert.Equal(t, records[0].Fields, read[0].Fields)
+		assert.Equal(t, content, *read[0].Content)
+	})
+
+	t.Run("Missing columns", func(t *testing.T) {
+		read, err := ReadExchangeIssues(strings.NewReader("index,title\n1,Renamed\n"), IssueExchangeFormatCSV)
+		require.NoError(t, err)
+		require.Len(t, read, 1)
+		assert.Equal(t, "Renamed", read[0].Title)
+		assert.Nil(t, read[0].State)
+		assert.Nil(t, read[0].Labels)
+		assert.Nil(t, read[0].Content)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ReadExchangeIssues(strings.NewReader("index,name\n1,x\n"), IssueExchangeFormatCSV)
+		require.ErrorContains(t, err, "unknown column")
+		_, err = ReadExchangeIssues(strings.NewReader("index\n1\n"), IssueExchangeFormatCSV)
+		require.ErrorContains(t, err, "no title column")
+		_, err = ReadExchangeIssues(strings.NewReader("{}"), IssueExchangeFormatJSON)
+		require.ErrorContains(t, err, "invalid JSON")
+	})
+}
+
+func TestExportIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportIssues(t.Context(), &buf, IssueExchangeFormatJSON, &issues_model.IssuesOptions{
+		RepoIDs:  []int64{1},
+		IsPull:   optional.Some(false),
+		SortType: "oldest",
+	}, true))
+	records, err := ReadExchangeIssues(&buf, IssueExchangeFormatJSON)
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+
+	assert.EqualValues(t, 1, records[0].Index)
+	assert.Equal(t, "issue1", records[0].Title)
+	assert.Equal(t, "open", *records[0].State)
+	assert.Equal(t, "user1", records[0].Poster)
+	assert.Equal(t, []string{"label1"}, records[0].Labels)
+	assert.NotEmpty(t, records[0].Comments)
+}
+
+func TestImportIssues(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	doer := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+
+	closed, milestone, content := "closed", "milestone1", "imported"
+	records := func() []*ExchangeIssue {
+		return []*ExchangeIssue{
+			{Index: 1, Title: "issue1 renamed", State: &closed, Labels: []string{"label2"}},
+			{
+				Title:     "imported issue",
+				Poster:    "someone",
+				Milestone: &milestone,
+				Labels:    []string{"label1"},
+				Assignees: []string{"user2"},
+				Content:   &content,
+				Comments:  []*ExchangeComment{{Poster: "user2", Content: "first comment"}},
+			},
+		}
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		results, err := ImportIssues(t.Context(), doer, repo, append(records(),
+			&ExchangeIssue{Index: 2, Title: "a pull request"},
+			&ExchangeIssue{Title: "unknown label", Labels: []string{"nope"}},
+		), false)
+		require.NoError(t, err)
+		assert.True(t, results.HasErrors())
+		assert.Empty(t, results[0].Error)
+		assert.Contains(t, results[2].Error, "#2 is a pull request")
+		assert.Contains(t, results[3].Error, `unknown label "nope"`)
+
+		// nothing is imported
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, Title: "issue1", IsClosed: false})
+		unittest.AssertNotExistsBean(t, &issues_model.Issue{RepoID: 1, Title: "imported issue"})
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		results, err := ImportIssues(t.Context(), doer, repo, records(), true)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.False(t, results.HasErrors())
+
+		assert.False(t, results[0].IsNew)
+		assert.EqualValues(t, 1, results[0].Index)
+		assert.Equal(t, []*IssueImportChange{
+			{Name: "title", Old: "issue1", New: "issue1 renamed"},
+			{Name: "state", Old: "open", New: "closed"},
+			{Name: "labels", Old: "label1", New: "label2"},
+		}, results[0].Changes)
+
+		assert.True(t, results[1].IsNew)
+		assert.Len(t, results[1].Changes, 6)
+
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, Title: "issue1", IsClosed: false})
+		unittest.AssertNotExistsBean(t, &issues_model.Issue{RepoID: 1, Title: "imported issue"})
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		results, err := ImportIssues(t.Context(), doer, repo, records(), false)
+		require.NoError(t, err)
+		assert.False(t, results.HasErrors())
+		created, updated := results.Counts()
+		assert.Equal(t, 1, created)
+		assert.Equal(t, 1, updated)
+
+		issue1 := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1})
+		assert.Equal(t, "issue1 renamed", issue1.Title)
+		assert.True(t, issue1.IsClosed)
+		require.NoError(t, issue1.LoadLabels(t.Context()))
+		require.Len(t, issue1.Labels, 1)
+		assert.Equal(t, "label2", issue1.Labels[0].Name)
+
+		issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Title: "imported issue"})
+		assert.Equal(t, results[1].Index, issue.Index)
+		assert.Equal(t, "imported", issue.Content)
+		assert.EqualValues(t, 1, issue.MilestoneID)
+		assert.EqualValues(t, user_model.GhostUserID, issue.PosterID)
+		assert.Equal(t, "someone", issue.OriginalAuthor)
+		require.NoError(t, issue.LoadLabels(t.Context()))
+		require.Len(t, issue.Labels, 1)
+		assert.Equal(t, "label1", issue.Labels[0].Name)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: issue.ID, AssigneeID: 2})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: issue.ID, PosterID: 2, Content: "first comment"})
+
+		// importing the same file again only creates a new issue
+		results, err = ImportIssues(t.Context(), doer, repo, records(), true)
+		require.NoError(t, err)
+		assert.Empty(t, results[0].Changes)
+		assert.True(t, results[1].IsNew)
+	})
+}
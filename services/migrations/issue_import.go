@@ -0,0 +1,507 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package migrations
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	access_model "forgejo.org/models/perm/access"
+	repo_model "forgejo.org/models/repo"
+	user_model "forgejo.org/models/user"
+	issue_indexer "forgejo.org/modules/indexer/issues"
+	base "forgejo.org/modules/migration"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/util"
+	issue_service "forgejo.org/services/issue"
+)
+
+// IssueImportChange is a change of an attribute of an issue made by an import
+type IssueImportChange struct {
+	Name string
+	Old  string
+	New  string
+}
+
+// IssueImportResult is the outcome of the import of an issue, or its preview in a dry run
+type IssueImportResult struct {
+	// Record is the position of the issue in the imported file, starting at 1
+	Record int
+	// Index is the index of the updated or created issue, 0 for the new issues of a dry run
+	Index   int64
+	Title   string
+	IsNew   bool
+	Changes []*IssueImportChange
+	Error   string
+}
+
+// IssueImportResults are the outcomes of the import of the issues of a file
+type IssueImportResults []*IssueImportResult
+
+// HasErrors returns true if an issue cannot be imported
+func (results IssueImportResults) HasErrors() bool {
+	return slices.ContainsFunc(results, func(r *IssueImportResult) bool { return r.Error != "" })
+}
+
+// Counts returns the number of the created issues and of the changed issues
+func (results IssueImportResults) Counts() (created, updated int) {
+	for _, r := range results {
+		if r.IsNew {
+			created++
+		} else if len(r.Changes) > 0 {
+			updated++
+		}
+	}
+	return created, updated
+}
+
+type issueImportFieldValue struct {
+	field *issues_model.CustomField
+	value string
+}
+
+// issueImportPlan is what the import of a record changes
+type issueImportPlan struct {
+	record      *ExchangeIssue
+	result      *IssueImportResult
+	issue       *issues_model.Issue // nil for a new issue
+	closed      optional.Option[bool]
+	milestoneID optional.Option[int64]
+	labels      []*issues_model.Label // nil if unchanged
+	assignees   []*user_model.User    // nil if unchanged
+	fields      []issueImportFieldValue
+}
+
+func (plan *issueImportPlan) change(name, old, new string) {
+	if old != new {
+		plan.result.Changes = append(plan.result.Changes, &IssueImportChange{Name: name, Old: old, New: new})
+	}
+}
+
+func (plan *issueImportPlan) changes(name string) bool {
+	return slices.ContainsFunc(plan.result.Changes, func(c *IssueImportChange) bool { return c.Name == name })
+}
+
+type issueImporter struct {
+	doer       *user_model.User
+	repo       *repo_model.Repository
+	labels     map[string]*issues_model.Label
+	milestones map[string]*issues_model.Milestone
+	fields     issues_model.CustomFieldList
+}
+
+// ImportIssues creates the issues of the records without the index of an issue of the repository, and updates
+// the others. All the records are checked first: nothing is changed if one of them is invalid, nor in a dry run
+// which only returns the changes the import would make.
+func ImportIssues(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, records []*ExchangeIssue, dryRun bool) (IssueImportResults, error) {
+	imp, err := newIssueImporter(ctx, doer, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*issueImportPlan, 0, len(records))
+	results := make(IssueImportResults, 0, len(records))
+	for i, record := range records {
+		plan := &issueImportPlan{record: record, result: &IssueImportResult{Record: i + 1}}
+		if err := imp.check(ctx, plan); err != nil {
+			if !errors.Is(err, util.ErrInvalidArgument) {
+				return nil, err
+			}
+			plan.result.Error = err.Error()
+			plan.result.Changes = nil
+		}
+		plans = append(plans, plan)
+		results = append(results, plan.result)
+	}
+	if dryRun || results.HasErrors() {
+		return results, nil
+	}
+
+	created := make([]*issueImportPlan, 0, len(plans))
+	for _, plan := range plans {
+		if plan.issue == nil {
+			created = append(created, plan)
+			continue
+		}
+		if err := imp.update(ctx, plan); err != nil {
+			if !issues_model.IsErrDependenciesLeft(err) {
+				return nil, err
+			}
+			plan.result.Error = err.Error()
+		}
+	}
+	return results, imp.create(ctx, created)
+}
+
+func newIssueImporter(ctx context.Context, doer *user_model.User, repo *repo_model.Repository) (*issueImporter, error) {
+	if err := repo.LoadOwner(ctx); err != nil {
+		return nil, err
+	}
+	imp := &issueImporter{
+		doer:       doer,
+		repo:       repo,
+		labels:     make(map[string]*issues_model.Label),
+		milestones: make(map[string]*issues_model.Milestone),
+	}
+
+	if repo.Owner.IsOrganization() {
+		orgLabels, err := issues_model.GetLabelsByOrgID(ctx, repo.OwnerID, "", db.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range orgLabels {
+			imp.labels[l.Name] = l
+		}
+	}
+	// the labels of the repository take precedence over the labels of the organization
+	repoLabels, err := issues_model.GetLabelsByRepoID(ctx, repo.ID, "", db.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range repoLabels {
+		imp.labels[l.Name] = l
+	}
+
+	milestones, err := db.Find[issues_model.Milestone](ctx, issues_model.FindMilestoneOptions{RepoID: repo.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range milestones {
+		imp.milestones[m.Name] = m
+	}
+
+	imp.fields, err = db.Find[issues_model.CustomField](ctx, issues_model.FindCustomFieldOptions{RepoID: repo.ID})
+	if err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// check validates a record and computes the changes of its issue
+func (imp *issueImporter) check(ctx context.Context, plan *issueImportPlan) error {
+	record := plan.record
+	record.Title = strings.TrimSpace(record.Title)
+	plan.result.Title = record.Title
+	if record.Title == "" || utf8.RuneCountInString(record.Title) > 255 {
+		return util.NewInvalidArgumentErrorf("the title must have between 1 and 255 characters")
+	}
+
+	if record.Index > 0 {
+		issue, err := issues_model.GetIssueByIndex(ctx, imp.repo.ID, record.Index)
+		if err != nil && !issues_model.IsErrIssueNotExist(err) {
+			return err
+		}
+		if issue != nil && issue.IsPull {
+			return util.NewInvalidArgumentErrorf("#%d is a pull request", record.Index)
+		}
+		plan.issue = issue
+	}
+
+	var old struct {
+		title, content, state, milestone string
+		labels, assignees                []string
+		fields                           map[string]string
+	}
+	old.fields = make(map[string]string)
+	fields := imp.fields
+	if issue := plan.issue; issue != nil {
+		plan.result.Index = issue.Index
+		if err := issue.LoadMilestone(ctx); err != nil {
+			return err
+		}
+		if err := issue.LoadLabels(ctx); err != nil {
+			return err
+		}
+		if err := issue.LoadAssignees(ctx); err != nil {
+			return err
+		}
+		values, err := issues_model.GetIssueCustomFieldValues(ctx, []int64{issue.ID})
+		if err != nil {
+			return err
+		}
+		if fields, err = issues_model.GetIssueCustomFields(ctx, issue); err != nil {
+			return err
+		}
+
+		old.title, old.content, old.state = issue.Title, issue.Content, issueStateOpen
+		if issue.IsClosed {
+			old.state = issueStateClosed
+		}
+		if issue.Milestone != nil {
+			old.milestone = issue.Milestone.Name
+		}
+		for _, l := range issue.Labels {
+			old.labels = append(old.labels, l.Name)
+		}
+		for _, u := range issue.Assignees {
+			old.assignees = append(old.assignees, u.Name)
+		}
+		for _, v := range values[issue.ID] {
+			old.fields[v.Field.Name] = v.DisplayValue()
+		}
+	} else {
+		plan.result.IsNew = true
+	}
+
+	plan.change("title", old.title, record.Title)
+
+	if record.Content != nil {
+		plan.change("content", old.content, *record.Content)
+	}
+
+	if record.State != nil {
+		switch state := strings.ToLower(strings.TrimSpace(*record.State)); state {
+		case "":
+		case issueStateOpen, issueStateClosed:
+			plan.closed = optional.Some(state == issueStateClosed)
+			plan.change("state", old.state, state)
+		default:
+			return util.NewInvalidArgumentErrorf("unknown state %q", state)
+		}
+	}
+
+	if record.Milestone != nil {
+		name := strings.TrimSpace(*record.Milestone)
+		var id int64
+		if name != "" {
+			m, ok := imp.milestones[name]
+			if !ok {
+				return util.NewInvalidArgumentErrorf("unknown milestone %q", name)
+			}
+			id = m.ID
+		}
+		plan.milestoneID = optional.Some(id)
+		plan.change("milestone", old.milestone, name)
+	}
+
+	if record.Labels != nil {
+		plan.labels = make([]*issues_model.Label, 0, len(record.Labels))
+		names := make([]string, 0, len(record.Labels))
+		for _, name := range record.Labels {
+			l, ok := imp.labels[name]
+			if !ok {
+				return util.NewInvalidArgumentErrorf("unknown label %q", name)
+			}
+			plan.labels = append(plan.labels, l)
+			names = append(names, l.Name)
+		}
+		plan.change("labels", joinSorted(old.labels), joinSorted(names))
+	}
+
+	if record.Assignees != nil {
+		plan.assignees = make([]*user_model.User, 0, len(record.Assignees))
+		names := make([]string, 0, len(record.Assignees))
+		for _, name := range record.Assignees {
+			u, err := user_model.GetUserByName(ctx, name)
+			if err != nil {
+				if user_model.IsErrUserNotExist(err) {
+					return util.NewInvalidArgumentErrorf("unknown user %q", name)
+				}
+				return err
+			}
+			canBeAssigned, err := access_model.CanBeAssigned(ctx, u, imp.repo, false)
+			if err != nil {
+				return err
+			} else if !canBeAssigned {
+				return util.NewInvalidArgumentErrorf("%s cannot be assigned", u.Name)
+			}
+			plan.assignees = append(plan.assignees, u)
+			names = append(names, u.Name)
+		}
+		plan.change("assignees", joinSorted(old.assignees), joinSorted(names))
+	}
+
+	names := make([]string, 0, len(record.Fields))
+	for name := range record.Fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		idx := slices.IndexFunc(fields, func(f *issues_model.CustomField) bool { return f.Name == name })
+		if idx < 0 {
+			return util.NewInvalidArgumentErrorf("unknown custom field %q", name)
+		}
+		value := strings.TrimSpace(record.Fields[name])
+		if value != "" {
+			if _, _, err := fields[idx].NormalizeValue(ctx, value); err != nil {
+				return err
+			}
+		}
+		plan.fields = append(plan.fields, issueImportFieldValue{field: fields[idx], value: value})
+		plan.change(exchangeFieldPrefix+name, old.fields[name], value)
+	}
+
+	if plan.issue == nil && len(record.Comments) > 0 {
+		plan.change("comments", "", strconv.Itoa(len(record.Comments)))
+	}
+	return nil
+}
+
+func joinSorted(names []string) string {
+	names = slices.Clone(names)
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
+// update applies the changes of a record to its existing issue
+func (imp *issueImporter) update(ctx context.Context, plan *issueImportPlan) error {
+	issue, record := plan.issue, plan.record
+
+	if plan.changes("title") {
+		if err := issue_service.ChangeTitle(ctx, issue, imp.doer, record.Title); err != nil {
+			return err
+		}
+	}
+	if plan.changes("content") {
+		if err := issue_service.ChangeContent(ctx, issue, imp.doer, *record.Content, issue.ContentVersion); err != nil {
+			return err
+		}
+	}
+	if plan.changes("milestone") {
+		oldMilestoneID := issue.MilestoneID
+		issue.MilestoneID = plan.milestoneID.Value()
+		if err := issue_service.ChangeMilestoneAssign(ctx, issue, imp.doer, oldMilestoneID); err != nil {
+			return err
+		}
+	}
+	if plan.changes("labels") {
+		if err := issue_service.ReplaceLabels(ctx, issue, imp.doer, plan.labels); err != nil {
+			return err
+		}
+	}
+	if plan.changes("assignees") {
+		if err := imp.assign(ctx, issue, plan.assignees); err != nil {
+			return err
+		}
+	}
+	for _, f := range plan.fields {
+		if !plan.changes(exchangeFieldPrefix + f.field.Name) {
+			continue
+		}
+		if err := issue_service.SetCustomFieldValue(ctx, imp.doer, issue, f.field.ID, f.value); err != nil {
+			return err
+		}
+	}
+	if plan.changes("state") {
+		if err := issue_service.ChangeStatus(ctx, issue, imp.doer, "", plan.closed.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (imp *issueImporter) assign(ctx context.Context, issue *issues_model.Issue, assignees []*user_model.User) error {
+	names := make([]string, 0, len(assignees))
+	for _, u := range assignees {
+		names = append(names, u.Name)
+	}
+	return issue_service.UpdateAssignees(ctx, issue, "", names, imp.doer)
+}
+
+// poster returns the user the issues and comments of an author are created for: the doer,
+// or no user, in which case the author is kept as the original author
+func (imp *issueImporter) poster(name string) (int64, string) {
+	if name == "" || strings.EqualFold(name, imp.doer.Name) {
+		return imp.doer.ID, imp.doer.Name
+	}
+	return 0, name
+}
+
+// create creates the issues of the records with the uploader of the migrations
+func (imp *issueImporter) create(ctx context.Context, plans []*issueImportPlan) error {
+	if len(plans) == 0 {
+		return nil
+	}
+
+	milestones := make(map[string]int64, len(imp.milestones))
+	for name, m := range imp.milestones {
+		milestones[name] = m.ID
+	}
+	uploader := newGiteaLocalUploaderForRepo(ctx, imp.doer, imp.repo, imp.labels, milestones)
+	defer uploader.Close()
+
+	now := time.Now()
+	issues := make([]*base.Issue, 0, len(plans))
+	var comments []*base.Comment
+	for _, plan := range plans {
+		index, err := db.GetNextResourceIndex(ctx, "issue_index", imp.repo.ID)
+		if err != nil {
+			return err
+		}
+		plan.result.Index = index
+
+		record := plan.record
+		issue := &base.Issue{
+			Number:  index,
+			Title:   record.Title,
+			Content: exchangeString(record.Content),
+			State:   issueStateOpen,
+		}
+		issue.PosterID, issue.PosterName = imp.poster(record.Poster)
+		if record.Created != nil {
+			issue.Created = *record.Created
+		}
+		if record.Updated != nil {
+			issue.Updated = *record.Updated
+		}
+		if plan.closed.Value() {
+			issue.State = issueStateClosed
+			issue.Closed = util.Iif(record.Closed != nil, record.Closed, &now)
+		}
+		if record.Milestone != nil {
+			issue.Milestone = strings.TrimSpace(*record.Milestone)
+		}
+		for _, l := range plan.labels {
+			issue.Labels = append(issue.Labels, &base.Label{Name: l.Name})
+		}
+		issues = append(issues, issue)
+
+		for _, c := range record.Comments {
+			comment := &base.Comment{
+				IssueIndex: index,
+				Created:    c.Created,
+				Updated:    c.Created,
+				Content:    c.Content,
+			}
+			comment.PosterID, comment.PosterName = imp.poster(c.Poster)
+			comments = append(comments, comment)
+		}
+	}
+
+	if err := uploader.CreateIssues(issues...); err != nil {
+		return err
+	}
+	if err := uploader.CreateComments(comments...); err != nil {
+		return err
+	}
+	if err := uploader.Finish(); err != nil {
+		return err
+	}
+
+	for _, plan := range plans {
+		issue := uploader.issues[plan.result.Index]
+		if len(plan.assignees) > 0 {
+			if err := imp.assign(ctx, issue, plan.assignees); err != nil {
+				return err
+			}
+		}
+		for _, f := range plan.fields {
+			if f.value == "" {
+				continue
+			}
+			if err := issue_service.SetCustomFieldValue(ctx, imp.doer, issue, f.field.ID, f.value); err != nil {
+				return err
+			}
+		}
+		issue_indexer.UpdateIssueIndexer(ctx, issue.ID)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	actions_model "forgejo.org/models/actions"
+	advisory_model "forgejo.org/models/advisory"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -37,6 +38,9 @@ type Notifier interface {
 	IssueClearLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue)
 	IssueChangeTitle(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldTitle string)
 	IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldRef string)
+	IssueChangeCustomField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.CustomField)
+	IssueChangeConfidential(ctx context.Context, doer *user_model.User, issue *issues_model.Issue)
+	IssueChangeParent(ctx context.Context, doer *user_model.User, issue, parent *issues_model.Issue, removed bool)
 	IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 		addedLabels, removedLabels []*issues_model.Label)
 
@@ -65,6 +69,8 @@ type Notifier interface {
 	UpdateRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release)
 	DeleteRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release)
 
+	PublishSecurityAdvisory(ctx context.Context, doer *user_model.User, advisory *advisory_model.Advisory)
+
 	PushCommits(ctx context.Context, pusher *user_model.User, repo *repo_model.Repository, opts *repository.PushUpdateOptions, commits *repository.PushCommits)
 	CreateRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName, refID string)
 	DeleteRef(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, refFullName git.RefName)
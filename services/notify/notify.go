@@ -8,6 +8,7 @@ import (
 	"slices"
 
 	actions_model "forgejo.org/models/actions"
+	advisory_model "forgejo.org/models/advisory"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -193,6 +194,17 @@ func DeleteRelease(ctx context.Context, doer *user_model.User, rel *repo_model.R
 	}
 }
 
+// PublishSecurityAdvisory notifies the publication of a security advisory to notifiers
+func PublishSecurityAdvisory(ctx context.Context, doer *user_model.User, advisory *advisory_model.Advisory) {
+	if err := advisory.LoadRepo(ctx); err != nil {
+		log.Error("LoadRepo: %v", err)
+		return
+	}
+	for _, notifier := range notifiers {
+		notifier.PublishSecurityAdvisory(ctx, doer, advisory)
+	}
+}
+
 // IssueChangeMilestone notifies change milestone to notifiers
 func IssueChangeMilestone(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldMilestoneID int64) {
 	for _, notifier := range notifiers {
@@ -242,6 +254,27 @@ func IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_mo
 	}
 }
 
+// IssueChangeCustomField notifies a change of the value of a custom field to notifiers
+func IssueChangeCustomField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.CustomField) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeCustomField(ctx, doer, issue, field)
+	}
+}
+
+// IssueChangeConfidential notifies a change of the confidentiality of an issue to notifiers
+func IssueChangeConfidential(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeConfidential(ctx, doer, issue)
+	}
+}
+
+// IssueChangeParent notifies the addition or removal of a sub-issue to notifiers
+func IssueChangeParent(ctx context.Context, doer *user_model.User, issue, parent *issues_model.Issue, removed bool) {
+	for _, notifier := range notifiers {
+		notifier.IssueChangeParent(ctx, doer, issue, parent, removed)
+	}
+}
+
 // IssueChangeLabels notifies change labels to notifiers
 func IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 	addedLabels, removedLabels []*issues_model.Label,
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	actions_model "forgejo.org/models/actions"
+	advisory_model "forgejo.org/models/advisory"
 	issues_model "forgejo.org/models/issues"
 	packages_model "forgejo.org/models/packages"
 	repo_model "forgejo.org/models/repo"
@@ -105,6 +106,10 @@ func (*NullNotifier) UpdateRelease(ctx context.Context, doer *user_model.User, r
 func (*NullNotifier) DeleteRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release) {
 }
 
+// PublishSecurityAdvisory places a place holder function
+func (*NullNotifier) PublishSecurityAdvisory(ctx context.Context, doer *user_model.User, advisory *advisory_model.Advisory) {
+}
+
 // IssueChangeMilestone places a place holder function
 func (*NullNotifier) IssueChangeMilestone(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldMilestoneID int64) {
 }
@@ -133,6 +138,18 @@ func (*NullNotifier) IssueChangeTitle(ctx context.Context, doer *user_model.User
 func (*NullNotifier) IssueChangeRef(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, oldTitle string) {
 }
 
+// IssueChangeCustomField places a place holder function
+func (*NullNotifier) IssueChangeCustomField(ctx context.Context, doer *user_model.User, issue *issues_model.Issue, field *issues_model.CustomField) {
+}
+
+// IssueChangeConfidential places a place holder function
+func (*NullNotifier) IssueChangeConfidential(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+}
+
+// IssueChangeParent places a place holder function
+func (*NullNotifier) IssueChangeParent(ctx context.Context, doer *user_model.User, issue, parent *issues_model.Issue, removed bool) {
+}
+
 // IssueChangeLabels places a place holder function
 func (*NullNotifier) IssueChangeLabels(ctx context.Context, doer *user_model.User, issue *issues_model.Issue,
 	addedLabels, removedLabels []*issues_model.Label) {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"forgejo.org/models"
+	automation_model "forgejo.org/models/automation"
 	"forgejo.org/models/db"
 	org_model "forgejo.org/models/organization"
 	packages_model "forgejo.org/models/packages"
@@ -48,6 +49,10 @@ func DeleteOrganization(ctx context.Context, org *org_model.Organization, purge
 		return models.ErrUserOwnPackages{UID: org.ID}
 	}
 
+	if err := automation_model.DeleteRulesByOwnerID(ctx, org.ID); err != nil {
+		return fmt.Errorf("DeleteRulesByOwnerID: %w", err)
+	}
+
 	if err := org_model.DeleteOrganization(ctx, org); err != nil {
 		return fmt.Errorf("DeleteOrganization: %w", err)
 	}
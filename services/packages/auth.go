@@ -22,9 +22,16 @@ type packageClaims struct {
 	jwt.RegisteredClaims
 	UserID int64
 	Scope  auth_model.AccessTokenScope
+	// OrgID and RepoIDs carry the restriction of the access token the
+	// authorization token has been created with
+	OrgID   int64   `json:",omitempty"`
+	RepoIDs []int64 `json:",omitempty"`
 }
 
-func CreateAuthorizationToken(u *user_model.User, scope auth_model.AccessTokenScope) (string, error) {
+// CreateAuthorizationToken creates a token for the package registries which
+// authenticate with their own tokens. accessToken is the personal access token
+// of the request, if any, whose restriction is passed on.
+func CreateAuthorizationToken(u *user_model.User, scope auth_model.AccessTokenScope, accessToken *auth_model.AccessToken) (string, error) {
 	now := time.Now()
 
 	claims := packageClaims{
@@ -35,6 +42,10 @@ func CreateAuthorizationToken(u *user_model.User, scope auth_model.AccessTokenSc
 		UserID: u.ID,
 		Scope:  scope,
 	}
+	if accessToken != nil {
+		claims.OrgID = accessToken.OrgID
+		claims.RepoIDs = accessToken.RepoIDs
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	tokenString, err := token.SignedString(setting.GetGeneralTokenSigningSecret())
@@ -45,16 +56,18 @@ func CreateAuthorizationToken(u *user_model.User, scope auth_model.AccessTokenSc
 	return tokenString, nil
 }
 
-func ParseAuthorizationToken(req *http.Request) (int64, auth_model.AccessTokenScope, error) {
+// ParseAuthorizationToken returns the user and the scope of the token of the
+// request, and the restriction of the access token it was created with, if any.
+func ParseAuthorizationToken(req *http.Request) (int64, auth_model.AccessTokenScope, *auth_model.AccessToken, error) {
 	h := req.Header.Get("Authorization")
 	if h == "" {
-		return 0, "", nil
+		return 0, "", nil, nil
 	}
 
 	parts := strings.SplitN(h, " ", 2)
 	if len(parts) != 2 {
 		log.Error("split token failed: %s", h)
-		return 0, "", errors.New("split token failed")
+		return 0, "", nil, errors.New("split token failed")
 	}
 
 	token, err := jwt.ParseWithClaims(parts[1], &packageClaims{}, func(t *jwt.Token) (any, error) {
@@ -64,13 +77,17 @@ func ParseAuthorizationToken(req *http.Request) (int64, auth_model.AccessTokenSc
 		return setting.GetGeneralTokenSigningSecret(), nil
 	})
 	if err != nil {
-		return 0, "", err
+		return 0, "", nil, err
 	}
 
 	c, ok := token.Claims.(*packageClaims)
 	if !token.Valid || !ok {
-		return 0, "", errors.New("invalid token claim")
+		return 0, "", nil, errors.New("invalid token claim")
 	}
 
-	return c.UserID, c.Scope, nil
+	var restriction *auth_model.AccessToken
+	if c.OrgID > 0 || len(c.RepoIDs) > 0 {
+		restriction = &auth_model.AccessToken{UID: c.UserID, Scope: c.Scope, OrgID: c.OrgID, RepoIDs: c.RepoIDs}
+	}
+	return c.UserID, c.Scope, restriction, nil
 }
@@ -0,0 +1,119 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package packages
+
+import (
+	"context"
+	"io"
+
+	"forgejo.org/models/db"
+	packages_model "forgejo.org/models/packages"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	packages_module "forgejo.org/modules/packages"
+	sbom_module "forgejo.org/modules/packages/sbom"
+)
+
+// UploadSBOM parses the SBOM document, stores it and attaches it to the package version.
+// An existing SBOM of the package version gets replaced.
+func UploadSBOM(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion, buf *packages_module.HashedBuffer) (*packages_model.PackageSBOM, error) {
+	doc, err := sbom_module.ParseDocument(buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	ctx, committer, err := db.TxContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer committer.Close()
+
+	pb, exists, err := packages_model.GetOrInsertBlob(ctx, NewPackageBlob(buf))
+	if err != nil {
+		log.Error("Error inserting package blob: %v", err)
+		return nil, err
+	}
+	removeBlob := false
+	defer func() {
+		if removeBlob {
+			contentStore := packages_module.NewContentStore()
+			if err := contentStore.Delete(packages_module.BlobHash256Key(pb.HashSHA256)); err != nil {
+				log.Error("Error deleting package blob from content store: %v", err)
+			}
+		}
+	}()
+	if !exists {
+		contentStore := packages_module.NewContentStore()
+		if err := contentStore.Save(packages_module.BlobHash256Key(pb.HashSHA256), buf, buf.Size()); err != nil {
+			log.Error("Error saving package blob in content store: %v", err)
+			return nil, err
+		}
+	}
+
+	ps, err := AttachSBOM(ctx, doer, pv, pb, doc)
+	if err != nil {
+		removeBlob = !exists
+		return nil, err
+	}
+
+	if err := committer.Commit(); err != nil {
+		removeBlob = !exists
+		return nil, err
+	}
+
+	return ps, nil
+}
+
+// AttachSBOM attaches a stored blob containing the parsed SBOM document to the package version.
+// An existing SBOM of the package version gets replaced.
+func AttachSBOM(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion, pb *packages_model.PackageBlob, doc *sbom_module.Document) (*packages_model.PackageSBOM, error) {
+	ps := &packages_model.PackageSBOM{
+		VersionID:   pv.ID,
+		BlobID:      pb.ID,
+		Format:      string(doc.Format),
+		SpecVersion: doc.SpecVersion,
+		CreatorID:   doer.ID,
+	}
+
+	pcs := make([]*packages_model.PackageComponent, 0, len(doc.Components))
+	for _, c := range doc.Components {
+		pcs = append(pcs, &packages_model.PackageComponent{
+			Name:            c.Name,
+			Version:         c.Version,
+			PURL:            c.PURL,
+			Type:            c.Type,
+			Licenses:        c.Licenses,
+			Vulnerabilities: c.Vulnerabilities,
+		})
+	}
+
+	return ps, db.WithTx(ctx, func(ctx context.Context) error {
+		if err := packages_model.DeleteSBOMByVersionID(ctx, pv.ID); err != nil {
+			return err
+		}
+		return packages_model.InsertSBOM(ctx, ps, pcs)
+	})
+}
+
+// GetSBOMStream returns the content of the SBOM attached to the package version
+func GetSBOMStream(ctx context.Context, pv *packages_model.PackageVersion) (io.ReadSeekCloser, *packages_model.PackageSBOM, error) {
+	ps, err := packages_model.GetSBOMByVersionID(ctx, pv.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pb, err := packages_model.GetBlobByID(ctx, ps.BlobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s, err := packages_module.NewContentStore().Get(packages_module.BlobHash256Key(pb.HashSHA256))
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, ps, nil
+}
@@ -0,0 +1,312 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"forgejo.org/models/db"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	"forgejo.org/modules/timeutil"
+)
+
+// ViewField is a field of the issues shown in a column of a table view
+type ViewField struct {
+	Key string
+	// CustomField is set for the fields of custom fields
+	CustomField *issues_model.CustomField
+}
+
+// ViewRow is an issue shown by a view
+type ViewRow struct {
+	Issue  *issues_model.Issue
+	Column *project_model.Column
+	// CustomFieldValues are the values of the custom fields of the issue, by field ID
+	CustomFieldValues map[int64]*issues_model.CustomFieldValue
+	// Date places the issue on a roadmap, zero when the issue has no date
+	Date timeutil.TimeStamp
+
+	position int
+}
+
+// CustomFieldValue returns the displayed value of a custom field of the issue
+func (r *ViewRow) CustomFieldValue(fieldID int64) string {
+	if v, ok := r.CustomFieldValues[fieldID]; ok {
+		return v.DisplayValue()
+	}
+	return ""
+}
+
+// ViewGroup is a group of the rows of a view
+type ViewGroup struct {
+	// Name is the name of the group, NameKey the locale key of the name of a built-in group
+	Name    string
+	NameKey string
+	Rows    []*ViewRow
+
+	empty bool
+	order float64
+}
+
+// ViewData are the issues of a project as grouped and sorted by a view
+type ViewData struct {
+	View   *project_model.View
+	Fields []*ViewField
+	Groups []*ViewGroup
+	Count  int
+}
+
+// LoadView groups and sorts the issues of the columns of a project for a view.
+// The issues are the issues of the board the doer can see.
+func LoadView(ctx context.Context, p *project_model.Project, view *project_model.View, columns project_model.ColumnList, issuesMap map[int64]issues_model.IssueList) (*ViewData, error) {
+	customFields, err := db.Find[issues_model.CustomField](ctx, issues_model.FindCustomFieldOptions{
+		RepoID:     p.RepoID,
+		ProjectIDs: []int64{p.ID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	customFieldList := issues_model.CustomFieldList(customFields)
+
+	rows := make([]*ViewRow, 0, 20)
+	issueIDs := make([]int64, 0, 20)
+	for _, column := range columns {
+		for _, issue := range issuesMap[column.ID] {
+			rows = append(rows, &ViewRow{Issue: issue, Column: column, position: len(rows)})
+			issueIDs = append(issueIDs, issue.ID)
+		}
+	}
+	values, err := issues_model.GetIssueCustomFieldValues(ctx, issueIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		row.CustomFieldValues = make(map[int64]*issues_model.CustomFieldValue, len(values[row.Issue.ID]))
+		for _, v := range values[row.Issue.ID] {
+			row.CustomFieldValues[v.FieldID] = v
+		}
+	}
+
+	data := &ViewData{View: view, Count: len(rows)}
+	for _, key := range view.Fields {
+		field := &ViewField{Key: key}
+		if id, ok := project_model.ParseViewCustomField(key); ok {
+			if field.CustomField = customFieldList.GetByID(id); field.CustomField == nil {
+				// the custom field has been deleted
+				continue
+			}
+		}
+		data.Fields = append(data.Fields, field)
+	}
+
+	if view.IsRoadmap() {
+		for _, row := range rows {
+			row.Date = rowDate(row, view.DateField)
+		}
+		slices.SortStableFunc(rows, func(a, b *ViewRow) int {
+			return cmp.Or(
+				compareEmptyLast(a.Date == 0, b.Date == 0),
+				cmp.Compare(a.Date, b.Date),
+				compareRows(a, b, view.SortBy, view.SortDesc),
+			)
+		})
+		data.Groups = groupRowsByMonth(rows)
+		return data, nil
+	}
+
+	slices.SortStableFunc(rows, func(a, b *ViewRow) int {
+		return compareRows(a, b, view.SortBy, view.SortDesc)
+	})
+	data.Groups = groupRows(rows, view.GroupBy, customFieldList)
+	return data, nil
+}
+
+func compareEmptyLast(aEmpty, bEmpty bool) int {
+	switch {
+	case aEmpty == bEmpty:
+		return 0
+	case aEmpty:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// rowDate returns the date of the row used by a roadmap
+func rowDate(row *ViewRow, dateField string) timeutil.TimeStamp {
+	switch dateField {
+	case project_model.ViewFieldMilestone:
+		// a milestone without due date is due in the year 9999
+		if row.Issue.Milestone != nil && row.Issue.Milestone.DeadlineUnix.Year() != 9999 {
+			return row.Issue.Milestone.DeadlineUnix
+		}
+	case project_model.ViewFieldDeadline:
+		return row.Issue.DeadlineUnix
+	default:
+		id, _ := project_model.ParseViewCustomField(dateField)
+		if v, ok := row.CustomFieldValues[id]; ok && v.Field.Type == issues_model.CustomFieldTypeDate {
+			return timeutil.TimeStamp(v.SortValue)
+		}
+	}
+	return 0
+}
+
+// sortKey returns the value of a field ordering the rows, numbers are compared before strings
+func sortKey(row *ViewRow, field string) (number float64, str string, empty bool) {
+	issue := row.Issue
+	switch field {
+	case project_model.ViewFieldTitle:
+		return 0, strings.ToLower(issue.Title), false
+	case project_model.ViewFieldState:
+		if issue.IsClosed {
+			return 1, "", false
+		}
+		return 0, "", false
+	case project_model.ViewFieldColumn:
+		return float64(row.Column.Sorting), "", false
+	case project_model.ViewFieldMilestone:
+		if issue.Milestone == nil {
+			return 0, "", true
+		}
+		return float64(issue.Milestone.DeadlineUnix), strings.ToLower(issue.Milestone.Name), false
+	case project_model.ViewFieldDeadline:
+		return float64(issue.DeadlineUnix), "", issue.DeadlineUnix == 0
+	case project_model.ViewFieldCreated:
+		return float64(issue.CreatedUnix), "", false
+	case project_model.ViewFieldUpdated:
+		return float64(issue.UpdatedUnix), "", false
+	}
+	if id, ok := project_model.ParseViewCustomField(field); ok {
+		v, ok := row.CustomFieldValues[id]
+		if !ok {
+			return 0, "", true
+		}
+		switch v.Field.Type {
+		case issues_model.CustomFieldTypeText, issues_model.CustomFieldTypeUser:
+			return 0, strings.ToLower(v.DisplayValue()), false
+		default:
+			return v.SortValue, "", false
+		}
+	}
+	return 0, "", false
+}
+
+// compareRows orders the rows by a field, rows without value last and in the order of the board
+func compareRows(a, b *ViewRow, field string, desc bool) int {
+	if field == "" {
+		return cmp.Compare(a.position, b.position)
+	}
+	aNumber, aStr, aEmpty := sortKey(a, field)
+	bNumber, bStr, bEmpty := sortKey(b, field)
+	if c := compareEmptyLast(aEmpty, bEmpty); c != 0 || aEmpty {
+		return cmp.Or(c, cmp.Compare(a.position, b.position))
+	}
+	c := cmp.Or(cmp.Compare(aNumber, bNumber), cmp.Compare(aStr, bStr))
+	if desc {
+		c = -c
+	}
+	return cmp.Or(c, cmp.Compare(a.position, b.position))
+}
+
+// groupOf returns the group of a row, keyed by its name
+func groupOf(row *ViewRow, field string, customFields issues_model.CustomFieldList) *ViewGroup {
+	issue := row.Issue
+	switch field {
+	case project_model.ViewFieldState:
+		if issue.IsClosed {
+			return &ViewGroup{NameKey: "repo.issues.closed_title", order: 1}
+		}
+		return &ViewGroup{NameKey: "repo.issues.open_title"}
+	case project_model.ViewFieldColumn:
+		return &ViewGroup{Name: row.Column.Title, order: float64(row.Column.Sorting)}
+	case project_model.ViewFieldAssignees:
+		if len(issue.Assignees) == 0 {
+			return &ViewGroup{NameKey: "repo.issues.filter_assginee_no_assignee", empty: true}
+		}
+		names := make([]string, 0, len(issue.Assignees))
+		for _, assignee := range issue.Assignees {
+			names = append(names, assignee.Name)
+		}
+		slices.Sort(names)
+		return &ViewGroup{Name: strings.Join(names, ", ")}
+	case project_model.ViewFieldMilestone:
+		if issue.Milestone == nil {
+			return &ViewGroup{NameKey: "repo.issues.filter_milestone_none", empty: true}
+		}
+		return &ViewGroup{Name: issue.Milestone.Name, order: float64(issue.Milestone.DeadlineUnix)}
+	}
+	if id, ok := project_model.ParseViewCustomField(field); ok && customFields.GetByID(id) != nil {
+		v, ok := row.CustomFieldValues[id]
+		if !ok {
+			return &ViewGroup{NameKey: "projects.view.no_value", empty: true}
+		}
+		group := &ViewGroup{Name: v.DisplayValue()}
+		if v.Field.Type != issues_model.CustomFieldTypeText && v.Field.Type != issues_model.CustomFieldTypeUser {
+			group.order = v.SortValue
+		}
+		return group
+	}
+	return nil
+}
+
+// groupRows groups the sorted rows by a field, the groups are ordered by the field
+// and the rows of a group keep their order
+func groupRows(rows []*ViewRow, field string, customFields issues_model.CustomFieldList) []*ViewGroup {
+	if field == "" || len(rows) == 0 {
+		return []*ViewGroup{{Rows: rows}}
+	}
+
+	groups := make([]*ViewGroup, 0, 10)
+	byKey := make(map[string]*ViewGroup)
+	for _, row := range rows {
+		group := groupOf(row, field, customFields)
+		if group == nil {
+			return []*ViewGroup{{Rows: rows}}
+		}
+		key := group.NameKey + "\x00" + group.Name
+		if existing, ok := byKey[key]; ok {
+			group = existing
+		} else {
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.Rows = append(group.Rows, row)
+	}
+
+	slices.SortStableFunc(groups, func(a, b *ViewGroup) int {
+		return cmp.Or(
+			compareEmptyLast(a.empty, b.empty),
+			cmp.Compare(a.order, b.order),
+			cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)),
+		)
+	})
+	return groups
+}
+
+// groupRowsByMonth groups the rows of a roadmap sorted by date by month, the rows
+// without date are in a last group
+func groupRowsByMonth(rows []*ViewRow) []*ViewGroup {
+	groups := make([]*ViewGroup, 0, 12)
+	var current *ViewGroup
+	for _, row := range rows {
+		name := ""
+		if row.Date > 0 {
+			name = row.Date.AsTimeInLocation(time.UTC).Format("2006-01")
+		}
+		if current == nil || current.Name != name {
+			current = &ViewGroup{Name: name, empty: name == ""}
+			if current.empty {
+				current.NameKey = "projects.view.no_date"
+			}
+			groups = append(groups, current)
+		}
+		current.Rows = append(current.Rows, row)
+	}
+	return groups
+}
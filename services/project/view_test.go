@@ -0,0 +1,98 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package project
+
+import (
+	"slices"
+	"testing"
+
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewRows(t *testing.T) {
+	todo := &project_model.Column{ID: 1, Title: "To Do", Sorting: 0}
+	done := &project_model.Column{ID: 2, Title: "Done", Sorting: 1}
+	milestone := &issues_model.Milestone{Name: "v1", DeadlineUnix: timeutil.TimeStamp(1767312000)}
+	user2 := &user_model.User{Name: "user2"}
+	priority := &issues_model.CustomField{ID: 3, Type: issues_model.CustomFieldTypeSelect, Options: []string{"high", "low"}}
+
+	rows := []*ViewRow{
+		{Issue: &issues_model.Issue{ID: 1, Title: "b", Milestone: milestone, DeadlineUnix: 200}, Column: done, position: 0},
+		{Issue: &issues_model.Issue{ID: 2, Title: "A", Assignees: []*user_model.User{user2}, IsClosed: true}, Column: todo, position: 1},
+		{Issue: &issues_model.Issue{ID: 3, Title: "c", DeadlineUnix: 100}, Column: todo, position: 2},
+	}
+	rows[2].CustomFieldValues = map[int64]*issues_model.CustomFieldValue{
+		priority.ID: {FieldID: priority.ID, Field: priority, Value: "low", SortValue: 1},
+	}
+	ids := func(rows []*ViewRow) []int64 {
+		ids := make([]int64, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.Issue.ID)
+		}
+		return ids
+	}
+	sorted := func(field string, desc bool) []int64 {
+		rows := slices.Clone(rows)
+		slices.SortStableFunc(rows, func(a, b *ViewRow) int {
+			return compareRows(a, b, field, desc)
+		})
+		return ids(rows)
+	}
+
+	t.Run("Sort", func(t *testing.T) {
+		assert.Equal(t, []int64{1, 2, 3}, sorted("", false))
+		assert.Equal(t, []int64{2, 1, 3}, sorted(project_model.ViewFieldTitle, false))
+		assert.Equal(t, []int64{3, 1, 2}, sorted(project_model.ViewFieldTitle, true))
+		assert.Equal(t, []int64{2, 3, 1}, sorted(project_model.ViewFieldColumn, false))
+		// the issues without a value are last in both directions
+		assert.Equal(t, []int64{3, 1, 2}, sorted(project_model.ViewFieldDeadline, false))
+		assert.Equal(t, []int64{1, 3, 2}, sorted(project_model.ViewFieldDeadline, true))
+		assert.Equal(t, []int64{3, 1, 2}, sorted(project_model.ViewCustomField(priority.ID), true))
+	})
+
+	t.Run("Group", func(t *testing.T) {
+		groups := groupRows(rows, project_model.ViewFieldColumn, nil)
+		require.Len(t, groups, 2)
+		assert.Equal(t, "To Do", groups[0].Name)
+		assert.Equal(t, []int64{2, 3}, ids(groups[0].Rows))
+		assert.Equal(t, "Done", groups[1].Name)
+
+		groups = groupRows(rows, project_model.ViewFieldAssignees, nil)
+		require.Len(t, groups, 2)
+		assert.Equal(t, "user2", groups[0].Name)
+		assert.Equal(t, "repo.issues.filter_assginee_no_assignee", groups[1].NameKey)
+		assert.Equal(t, []int64{1, 3}, ids(groups[1].Rows))
+
+		groups = groupRows(rows, project_model.ViewCustomField(priority.ID), issues_model.CustomFieldList{priority})
+		require.Len(t, groups, 2)
+		assert.Equal(t, "low", groups[0].Name)
+		assert.Equal(t, "projects.view.no_value", groups[1].NameKey)
+
+		groups = groupRows(rows, "", nil)
+		require.Len(t, groups, 1)
+		assert.Len(t, groups[0].Rows, 3)
+	})
+
+	t.Run("Roadmap", func(t *testing.T) {
+		rows := slices.Clone(rows)
+		for _, row := range rows {
+			row.Date = rowDate(row, project_model.ViewFieldMilestone)
+		}
+		slices.SortStableFunc(rows, func(a, b *ViewRow) int {
+			return compareEmptyLast(a.Date == 0, b.Date == 0)
+		})
+		groups := groupRowsByMonth(rows)
+		require.Len(t, groups, 2)
+		assert.Equal(t, "2026-01", groups[0].Name)
+		assert.Equal(t, []int64{1}, ids(groups[0].Rows))
+		assert.Equal(t, "projects.view.no_date", groups[1].NameKey)
+		assert.Equal(t, []int64{2, 3}, ids(groups[1].Rows))
+	})
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// Package ratelimit limits the requests made to the API, git over HTTP and
+// the package registries with token buckets stored in the configured cache.
+//
+// Each user, access token, Actions task or, for anonymous requests, IP
+// address has a bucket per class of routes. A bucket holds at most BURST
+// tokens and is refilled with LIMIT tokens per PERIOD, every request takes
+// one token and is refused once the bucket is empty.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/cache"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/services/context"
+)
+
+// The classes of routes, each has its own buckets and settings
+const (
+	ClassAPI      = "api"
+	ClassGit      = "git"
+	ClassPackages = "packages"
+)
+
+func bucketSettings(class string) *setting.RateLimitBucket {
+	switch class {
+	case ClassAPI:
+		return &setting.RateLimit.API
+	case ClassGit:
+		return &setting.RateLimit.Git
+	case ClassPackages:
+		return &setting.RateLimit.Packages
+	}
+	panic("unknown rate limit class " + class)
+}
+
+// store is the part of the cache the buckets are kept in
+type store interface {
+	Get(key string) any
+	Put(key string, val any, timeout int64) error
+}
+
+// Result is the state of a bucket after a request took a token from it
+type Result struct {
+	Limit     int
+	Remaining int
+	// Reset is the time the bucket is full again
+	Reset time.Time
+	// RetryAfter is how long to wait for the next token, zero when the request is allowed
+	RetryAfter time.Duration
+}
+
+// Allowed returns whether the request may proceed
+func (r *Result) Allowed() bool {
+	return r.RetryAfter == 0
+}
+
+var mutexMap cache.MutexMap
+
+// take removes a token from the bucket stored at key. The bucket holds at most
+// burst tokens and gains limit tokens per period.
+func take(c store, key string, limit, burst int, period time.Duration, now time.Time) *Result {
+	// The lock only protects against concurrent requests handled by this process,
+	// instances sharing a cache may let a few requests above the limit through.
+	defer mutexMap.Lock(key)()
+
+	// the time it takes to refill the given number of tokens
+	refill := func(tokens float64) time.Duration {
+		return time.Duration(math.Ceil(tokens * float64(period) / float64(limit)))
+	}
+
+	tokens := float64(burst)
+	if last, saved, ok := parseBucket(c.Get(key)); ok {
+		tokens = min(float64(burst), saved+float64(now.UnixNano()-last)*float64(limit)/float64(period))
+	}
+
+	res := &Result{Limit: burst}
+	if tokens >= 1 {
+		tokens--
+	} else {
+		res.RetryAfter = refill(1 - tokens)
+	}
+	res.Remaining = int(tokens)
+	toFull := refill(float64(burst) - tokens)
+	res.Reset = now.Add(toFull)
+
+	ttl := int64(toFull/time.Second) + 1
+	if err := c.Put(key, formatBucket(now.UnixNano(), tokens), ttl); err != nil {
+		log.Error("Failed to store rate limit bucket %s: %v", key, err)
+	}
+	return res
+}
+
+func formatBucket(last int64, tokens float64) string {
+	return strconv.FormatInt(last, 10) + ":" + strconv.FormatFloat(tokens, 'f', -1, 64)
+}
+
+func parseBucket(v any) (last int64, tokens float64, ok bool) {
+	s, isString := v.(string)
+	if !isString {
+		return 0, 0, false
+	}
+	lastStr, tokensStr, found := strings.Cut(s, ":")
+	if !found {
+		return 0, 0, false
+	}
+	last, err := strconv.ParseInt(lastStr, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	tokens, err = strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return last, tokens, true
+}
+
+// bucketKey returns who the request is accounted to, tokens and Actions tasks
+// have their own bucket so a busy CI job doesn't starve the interactive use of
+// its owner.
+func bucketKey(base *context.Base, doer *user_model.User) (key string, anonymous bool) {
+	if token, ok := base.Data["ApiAccessToken"].(*auth_model.AccessToken); ok {
+		return fmt.Sprintf("token:%d", token.ID), false
+	}
+	if taskID, ok := base.Data["ActionsTaskID"].(int64); ok {
+		return fmt.Sprintf("task:%d", taskID), false
+	}
+	if doer != nil {
+		return fmt.Sprintf("user:%d", doer.ID), false
+	}
+	ip := base.RemoteAddr()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return "ip:" + ip, true
+}
+
+// check accounts the request to its bucket, sets the X-RateLimit headers and
+// returns false when the request has to be refused.
+func check(base *context.Base, doer *user_model.User, class string) bool {
+	cfg := bucketSettings(class)
+	if !setting.RateLimit.Enabled || !cfg.Enabled {
+		return true
+	}
+	if doer != nil && doer.IsRateLimitExempt {
+		return true
+	}
+	c := cache.GetCache()
+	if c == nil {
+		return true
+	}
+
+	key, anonymous := bucketKey(base, doer)
+	limit, burst := cfg.Limit, cfg.Burst
+	if anonymous {
+		limit, burst = cfg.AnonymousLimit, cfg.AnonymousBurst
+	}
+	res := take(c, "ratelimit:"+class+":"+key, limit, burst, cfg.Period, time.Now())
+
+	header := base.Resp.Header()
+	header.Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(res.Reset.Unix(), 10))
+	header.Set("X-RateLimit-Resource", class)
+	if res.Allowed() {
+		return true
+	}
+	header.Set("Retry-After", strconv.FormatInt(int64(math.Ceil(res.RetryAfter.Seconds())), 10))
+	log.Debug("Rate limit of %s exceeded by %s", class, key)
+	return false
+}
+
+// APIMiddleware limits the requests to the API
+func APIMiddleware() func(*context.APIContext) {
+	return func(ctx *context.APIContext) {
+		if !check(ctx.Base, ctx.Doer, ClassAPI) {
+			ctx.Error(http.StatusTooManyRequests, "RateLimit", "API rate limit exceeded")
+		}
+	}
+}
+
+// Middleware limits the requests to git over HTTP or the package registries
+func Middleware(class string) func(*context.Context) {
+	bucketSettings(class) // fail early on unknown classes
+	return func(ctx *context.Context) {
+		if !check(ctx.Base, ctx.Doer, class) {
+			ctx.PlainText(http.StatusTooManyRequests, "Rate limit exceeded")
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore map[string]any
+
+func (m memoryStore) Get(key string) any {
+	return m[key]
+}
+
+func (m memoryStore) Put(key string, val any, _ int64) error {
+	m[key] = val
+	return nil
+}
+
+func TestTake(t *testing.T) {
+	c := memoryStore{}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// 60 requests per minute, bursts up to 3
+	takeAt := func(key string, after time.Duration) *Result {
+		return take(c, key, 60, 3, time.Minute, start.Add(after))
+	}
+
+	for i := 2; i >= 0; i-- {
+		res := takeAt("a", 0)
+		assert.True(t, res.Allowed())
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, i, res.Remaining)
+	}
+
+	res := takeAt("a", 0)
+	assert.False(t, res.Allowed())
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, time.Second, res.RetryAfter)
+	assert.Equal(t, start.Add(3*time.Second), res.Reset)
+
+	// Other keys have their own bucket.
+	assert.True(t, takeAt("b", 0).Allowed())
+
+	// One token is refilled per second.
+	res = takeAt("a", 500*time.Millisecond)
+	assert.False(t, res.Allowed())
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	res = takeAt("a", time.Second)
+	assert.True(t, res.Allowed())
+	assert.Equal(t, 0, res.Remaining)
+
+	// The bucket doesn't grow above the burst size.
+	res = takeAt("a", time.Hour)
+	assert.True(t, res.Allowed())
+	assert.Equal(t, 2, res.Remaining)
+	assert.Equal(t, start.Add(time.Hour+time.Second), res.Reset)
+}
+
+func TestParseBucket(t *testing.T) {
+	last, tokens, ok := parseBucket(formatBucket(42, 1.5))
+	assert.True(t, ok)
+	assert.EqualValues(t, 42, last)
+	assert.InDelta(t, 1.5, tokens, 0)
+
+	for _, v := range []any{nil, 42, "", "42", "a:1", "42:b"} {
+		_, _, ok := parseBucket(v)
+		assert.False(t, ok, "%v", v)
+	}
+}
@@ -12,7 +12,9 @@ import (
 	actions_model "forgejo.org/models/actions"
 	activities_model "forgejo.org/models/activities"
 	admin_model "forgejo.org/models/admin"
+	advisory_model "forgejo.org/models/advisory"
 	asymkey_model "forgejo.org/models/asymkey"
+	automation_model "forgejo.org/models/automation"
 	"forgejo.org/models/db"
 	git_model "forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
@@ -155,6 +157,9 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		&git_model.LFSLock{RepoID: repoID},
 		&repo_model.LanguageStat{RepoID: repoID},
 		&issues_model.Milestone{RepoID: repoID},
+		&issues_model.CustomField{RepoID: repoID},
+		&automation_model.RuleLog{RepoID: repoID},
+		&advisory_model.Advisory{RepoID: repoID},
 		&repo_model.Mirror{RepoID: repoID},
 		&activities_model.Notification{RepoID: repoID},
 		&git_model.ProtectedBranch{RepoID: repoID},
@@ -235,6 +240,14 @@ func DeleteRepositoryDirectly(ctx context.Context, doer *user_model.User, repoID
 		return fmt.Errorf("unable to delete projects for repo[%d]: %w", repoID, err)
 	}
 
+	if err := automation_model.DeleteRulesByRepoID(ctx, repoID); err != nil {
+		return fmt.Errorf("unable to delete automation rules for repo[%d]: %w", repoID, err)
+	}
+
+	if err := advisory_model.UnlinkForkRepo(ctx, repoID); err != nil {
+		return fmt.Errorf("unable to unlink the security advisory of the fork repo[%d]: %w", repoID, err)
+	}
+
 	// Remove LFS objects
 	var lfsObjects []*git_model.LFSMetaObject
 	if err = sess.Where("repository_id=?", repoID).Find(&lfsObjects); err != nil {
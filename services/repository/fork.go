@@ -49,6 +49,10 @@ type ForkRepoOptions struct {
 	Name         string
 	Description  string
 	SingleBranch string
+	// Private makes the fork private even if the base repository is public
+	Private bool
+	// AllowExistingFork allows the owner to have several forks of the base repository
+	AllowExistingFork bool
 }
 
 // ForkRepositoryIfNotExists creates a fork of a repository if it does not already exists and fails otherwise
@@ -60,15 +64,19 @@ func ForkRepositoryIfNotExists(ctx context.Context, doer, owner *user_model.User
 		}
 	}
 
-	forkedRepo, err := repo_model.GetUserFork(ctx, opts.BaseRepo.ID, owner.ID)
-	if err != nil {
-		return nil, err
-	}
-	if forkedRepo != nil {
-		return nil, ErrForkAlreadyExist{
-			Uname:    owner.Name,
-			RepoName: opts.BaseRepo.FullName(),
-			ForkName: forkedRepo.FullName(),
+	var err error
+	if !opts.AllowExistingFork {
+		var forkedRepo *repo_model.Repository
+		forkedRepo, err = repo_model.GetUserFork(ctx, opts.BaseRepo.ID, owner.ID)
+		if err != nil {
+			return nil, err
+		}
+		if forkedRepo != nil {
+			return nil, ErrForkAlreadyExist{
+				Uname:    owner.Name,
+				RepoName: opts.BaseRepo.FullName(),
+				ForkName: forkedRepo.FullName(),
+			}
 		}
 	}
 
@@ -84,7 +92,7 @@ func ForkRepositoryIfNotExists(ctx context.Context, doer, owner *user_model.User
 		LowerName:        strings.ToLower(opts.Name),
 		Description:      opts.Description,
 		DefaultBranch:    defaultBranch,
-		IsPrivate:        opts.BaseRepo.IsPrivate || opts.BaseRepo.Owner.Visibility == structs.VisibleTypePrivate,
+		IsPrivate:        opts.Private || opts.BaseRepo.IsPrivate || opts.BaseRepo.Owner.Visibility == structs.VisibleTypePrivate,
 		IsEmpty:          opts.BaseRepo.IsEmpty,
 		IsFork:           true,
 		ForkID:           opts.BaseRepo.ID,
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	"forgejo.org/models/git"
 	issues_model "forgejo.org/models/issues"
@@ -22,6 +23,7 @@ import (
 	repo_module "forgejo.org/modules/repository"
 	"forgejo.org/modules/setting"
 	"forgejo.org/modules/structs"
+	audit_service "forgejo.org/services/audit"
 	notify_service "forgejo.org/services/notify"
 	pull_service "forgejo.org/services/pull"
 )
@@ -63,7 +65,11 @@ func DeleteRepository(ctx context.Context, doer *user_model.User, repo *repo_mod
 		notify_service.DeleteRepository(ctx, doer, repo)
 	}
 
-	return DeleteRepositoryDirectly(ctx, doer, repo.ID)
+	if err := DeleteRepositoryDirectly(ctx, doer, repo.ID); err != nil {
+		return err
+	}
+	audit_service.Record(ctx, audit_service.ActorFromContext(ctx, doer), audit_model.ActionRepoDelete, audit_service.RepoTarget(repo), audit_service.RepoState(repo), nil)
+	return nil
 }
 
 // PushCreateRepo creates a repository when a new repository is pushed to an appropriate namespace
@@ -0,0 +1,65 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Filter is an equality filter on a single attribute, which is what identity providers use to
+// look up existing resources before provisioning them, e.g. `userName eq "jdoe"`
+type Filter struct {
+	Attribute string
+	Value     string
+}
+
+var filterRegexp = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(?i:eq)\s+("(?:[^"\\]|\\.)*"|true|false|null)\s*$`)
+
+// ParseFilter parses the filter query parameter, only the `eq` operator is supported
+func ParseFilter(filter string, attributes ...string) (*Filter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+
+	m := filterRegexp.FindStringSubmatch(filter)
+	if m == nil {
+		return nil, NewError(http.StatusBadRequest, "invalidFilter", "unsupported filter: %s", filter)
+	}
+
+	for _, attr := range attributes {
+		if strings.EqualFold(attr, m[1]) {
+			value := m[2]
+			if strings.HasPrefix(value, `"`) {
+				unquoted, err := strconv.Unquote(value)
+				if err != nil {
+					return nil, NewError(http.StatusBadRequest, "invalidFilter", "invalid filter value: %s", value)
+				}
+				value = unquoted
+			}
+			return &Filter{Attribute: attr, Value: value}, nil
+		}
+	}
+	return nil, NewError(http.StatusBadRequest, "invalidFilter", "unsupported filter attribute: %s", m[1])
+}
+
+// valuePathRegexp matches paths like `members[value eq "2"]` or `emails[type eq "work"].value`
+var valuePathRegexp = regexp.MustCompile(`^([A-Za-z][\w]*)\[(.+)\](?:\.([A-Za-z][\w]*))?$`)
+
+// parsePath splits a PATCH path into the attribute, the optional value filter and the optional sub-attribute
+func parsePath(path string) (attribute string, filter *Filter, subAttribute string, err error) {
+	m := valuePathRegexp.FindStringSubmatch(path)
+	if m == nil {
+		attribute, subAttribute, _ = strings.Cut(path, ".")
+		return attribute, nil, subAttribute, nil
+	}
+
+	filter, err = ParseFilter(m[2], "value", "type", "primary")
+	if err != nil {
+		return "", nil, "", NewError(http.StatusBadRequest, "invalidPath", "unsupported path: %s", path)
+	}
+	return m[1], filter, m[3], nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter("", "userName")
+	require.NoError(t, err)
+	assert.Nil(t, filter)
+
+	filter, err = ParseFilter(`username EQ "jdoe@example.com"`, "userName", "emails.value")
+	require.NoError(t, err)
+	assert.Equal(t, &Filter{Attribute: "userName", Value: "jdoe@example.com"}, filter)
+
+	filter, err = ParseFilter(`emails.value eq "a \"quoted\" value"`, "userName", "emails.value")
+	require.NoError(t, err)
+	assert.Equal(t, &Filter{Attribute: "emails.value", Value: `a "quoted" value`}, filter)
+
+	filter, err = ParseFilter(`primary eq true`, "primary")
+	require.NoError(t, err)
+	assert.Equal(t, &Filter{Attribute: "primary", Value: "true"}, filter)
+
+	for _, invalid := range []string{
+		`userName co "jdoe"`,
+		`userName eq "jdoe" and active eq true`,
+		`userName eq jdoe`,
+		`displayName eq "jdoe"`,
+	} {
+		_, err = ParseFilter(invalid, "userName")
+		var scimErr *Error
+		require.ErrorAs(t, err, &scimErr, invalid)
+		assert.Equal(t, http.StatusBadRequest, scimErr.StatusCode())
+		assert.Equal(t, "invalidFilter", scimErr.ScimType)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	attribute, filter, subAttribute, err := parsePath("name.givenName")
+	require.NoError(t, err)
+	assert.Equal(t, "name", attribute)
+	assert.Nil(t, filter)
+	assert.Equal(t, "givenName", subAttribute)
+
+	attribute, filter, subAttribute, err = parsePath(`members[value eq "2"]`)
+	require.NoError(t, err)
+	assert.Equal(t, "members", attribute)
+	assert.Equal(t, &Filter{Attribute: "value", Value: "2"}, filter)
+	assert.Empty(t, subAttribute)
+
+	attribute, filter, subAttribute, err = parsePath(`emails[type eq "work"].value`)
+	require.NoError(t, err)
+	assert.Equal(t, "emails", attribute)
+	assert.Equal(t, &Filter{Attribute: "type", Value: "work"}, filter)
+	assert.Equal(t, "value", subAttribute)
+
+	_, _, _, err = parsePath(`members[display co "j"]`)
+	require.Error(t, err)
+}
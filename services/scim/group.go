@@ -0,0 +1,385 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	auth_module "forgejo.org/modules/auth"
+	"forgejo.org/modules/container"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	source_service "forgejo.org/services/auth/source"
+	"forgejo.org/services/auth/source/ldap"
+	"forgejo.org/services/auth/source/oauth2"
+	"forgejo.org/services/auth/source/saml"
+
+	"xorm.io/builder"
+)
+
+// groupTeamMapping returns the group team map configured for the authentication source
+func groupTeamMapping(source *auth_model.Source) (mapping string, removal bool) {
+	switch cfg := source.Cfg.(type) {
+	case *oauth2.Source:
+		return cfg.GroupTeamMap, cfg.GroupTeamMapRemoval
+	case *saml.Source:
+		return cfg.GroupTeamMap, cfg.GroupTeamMapRemoval
+	case *ldap.Source:
+		return cfg.GroupTeamMap, cfg.GroupTeamMapRemoval
+	}
+	return "", false
+}
+
+// syncUserTeams maps the SCIM groups of the user to organization teams
+func syncUserTeams(ctx context.Context, source *auth_model.Source, u *user_model.User) error {
+	rawMapping, removal := groupTeamMapping(source)
+	if rawMapping == "" {
+		return nil
+	}
+	mapping, err := auth_module.UnmarshalGroupTeamMapping(rawMapping)
+	if err != nil {
+		return err
+	}
+
+	groups, err := auth_model.GetSCIMGroupNamesByUserID(ctx, source.ID, u.ID)
+	if err != nil {
+		return err
+	}
+	return source_service.SyncGroupsToTeams(ctx, u, container.SetOf(groups...), mapping, removal)
+}
+
+// syncTeams synchronizes the team memberships of the users
+func syncTeams(ctx context.Context, source *auth_model.Source, userIDs []int64) error {
+	if rawMapping, _ := groupTeamMapping(source); rawMapping == "" || len(userIDs) == 0 {
+		return nil
+	}
+
+	users, err := user_model.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		if err := syncUserTeams(ctx, source, u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToGroup converts a SCIM group to the SCIM group resource, the members are omitted if withMembers is false
+func ToGroup(ctx context.Context, group *auth_model.SCIMGroup, withMembers bool) (*Group, error) {
+	g := &Group{
+		Schemas:     []string{SchemaGroup},
+		ID:          strconv.FormatInt(group.ID, 10),
+		ExternalID:  group.ExternalID,
+		DisplayName: group.DisplayName,
+		Members:     []*MultiValue{},
+		Meta: &Meta{
+			ResourceType: "Group",
+			Created:      group.CreatedUnix.AsTime(),
+			LastModified: group.UpdatedUnix.AsTime(),
+			Location:     resourceLocation("Groups", group.ID),
+		},
+	}
+	if !withMembers {
+		return g, nil
+	}
+
+	userIDs, err := auth_model.GetSCIMGroupMemberIDs(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := user_model.GetUsersByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		display := u.LoginName
+		if display == "" {
+			display = u.Name
+		}
+		g.Members = append(g.Members, &MultiValue{
+			Value:   strconv.FormatInt(u.ID, 10),
+			Display: display,
+			Ref:     resourceLocation("Users", u.ID),
+		})
+	}
+	return g, nil
+}
+
+// GetGroup returns the SCIM group of the authentication source
+func GetGroup(ctx context.Context, source *auth_model.Source, id string) (*auth_model.SCIMGroup, error) {
+	gid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, NewError(http.StatusNotFound, "", "group %s not found", id)
+	}
+	group, err := auth_model.GetSCIMGroupByID(ctx, source.ID, gid)
+	if auth_model.IsErrSCIMGroupNotExist(err) {
+		return nil, NewError(http.StatusNotFound, "", "group %s not found", id)
+	}
+	return group, err
+}
+
+// ListGroups returns a page of the groups of the authentication source
+func ListGroups(ctx context.Context, source *auth_model.Source, filter *Filter, startIndex, count int, withMembers bool) (*ListResponse, error) {
+	opts := auth_model.FindSCIMGroupsOptions{
+		ListOptions: db.ListOptions{Page: 1, PageSize: count},
+		SourceID:    source.ID,
+	}
+	if filter != nil {
+		switch filter.Attribute {
+		case "displayName":
+			opts.DisplayName = filter.Value
+		case "externalId":
+			opts.ExternalID = filter.Value
+		}
+	}
+
+	groups := make([]*auth_model.SCIMGroup, 0, count)
+	total, err := db.GetEngine(ctx).Where(opts.ToConds()).OrderBy(opts.ToOrders()).Limit(count, startIndex-1).FindAndCount(&groups)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(groups),
+		Resources:    make([]any, 0, len(groups)),
+	}
+	for _, group := range groups {
+		g, err := ToGroup(ctx, group, withMembers)
+		if err != nil {
+			return nil, err
+		}
+		resp.Resources = append(resp.Resources, g)
+	}
+	return resp, nil
+}
+
+// memberIDs validates that the members are users of the authentication source and returns their IDs
+func memberIDs(ctx context.Context, source *auth_model.Source, members []*MultiValue) ([]int64, error) {
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m.Value, 10, 64)
+		if err != nil {
+			return nil, NewError(http.StatusBadRequest, "invalidValue", "unknown member %s", m.Value)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	count, err := db.GetEngine(ctx).Where(userCond(source)).And(builder.In("id", ids)).Count(new(user_model.User))
+	if err != nil {
+		return nil, err
+	} else if int(count) != len(ids) {
+		return nil, NewError(http.StatusBadRequest, "invalidValue", "members must be users provisioned by this identity provider")
+	}
+	return ids, nil
+}
+
+func checkDisplayName(ctx context.Context, source *auth_model.Source, displayName string, groupID int64) error {
+	if strings.TrimSpace(displayName) == "" {
+		return NewError(http.StatusBadRequest, "invalidValue", "displayName is required")
+	}
+	has, err := db.GetEngine(ctx).
+		Where(builder.Eq{"source_id": source.ID, "display_name": displayName}.And(builder.Neq{"id": groupID})).
+		Exist(new(auth_model.SCIMGroup))
+	if err != nil {
+		return err
+	} else if has {
+		return NewError(http.StatusConflict, "uniqueness", "group %s already exists", displayName)
+	}
+	return nil
+}
+
+// CreateGroup creates the group and synchronizes the teams of its members
+func CreateGroup(ctx context.Context, source *auth_model.Source, in *Group) (*auth_model.SCIMGroup, error) {
+	if err := checkDisplayName(ctx, source, in.DisplayName, 0); err != nil {
+		return nil, err
+	}
+	userIDs, err := memberIDs(ctx, source, in.Members)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &auth_model.SCIMGroup{
+		SourceID:    source.ID,
+		ExternalID:  in.ExternalID,
+		DisplayName: in.DisplayName,
+	}
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := db.Insert(ctx, group); err != nil {
+			return err
+		}
+		return auth_model.AddSCIMGroupMembers(ctx, group.ID, userIDs...)
+	}); err != nil {
+		return nil, err
+	}
+	log.Info("SCIM[%s]: created group %s", source.Name, group.DisplayName)
+
+	return group, syncTeams(ctx, source, userIDs)
+}
+
+// ReplaceGroup updates the group with the SCIM group resource and synchronizes the teams of its former and current members
+func ReplaceGroup(ctx context.Context, source *auth_model.Source, group *auth_model.SCIMGroup, in *Group) error {
+	if err := checkDisplayName(ctx, source, in.DisplayName, group.ID); err != nil {
+		return err
+	}
+	userIDs, err := memberIDs(ctx, source, in.Members)
+	if err != nil {
+		return err
+	}
+	formerIDs, err := auth_model.GetSCIMGroupMemberIDs(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+
+	removed := make([]int64, 0, len(formerIDs))
+	for _, id := range formerIDs {
+		if !slices.Contains(userIDs, id) {
+			removed = append(removed, id)
+		}
+	}
+
+	renamed := group.DisplayName != in.DisplayName
+	group.DisplayName = in.DisplayName
+	group.ExternalID = in.ExternalID
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).ID(group.ID).Cols("display_name", "external_id").Update(group); err != nil {
+			return err
+		}
+		if err := auth_model.RemoveSCIMGroupMembers(ctx, group.ID, removed...); err != nil {
+			return err
+		}
+		return auth_model.AddSCIMGroupMembers(ctx, group.ID, userIDs...)
+	}); err != nil {
+		return err
+	}
+
+	// members which have been neither added nor removed only need to be synchronized if the group has been renamed
+	affected := make([]int64, 0, len(userIDs)+len(removed))
+	affected = append(affected, removed...)
+	for _, id := range userIDs {
+		if renamed || !slices.Contains(formerIDs, id) {
+			affected = append(affected, id)
+		}
+	}
+	return syncTeams(ctx, source, affected)
+}
+
+// PatchGroup applies the operations of a PATCH request to the group
+func PatchGroup(ctx context.Context, source *auth_model.Source, group *auth_model.SCIMGroup, patch *PatchOp) error {
+	g, err := ToGroup(ctx, group, true)
+	if err != nil {
+		return err
+	}
+	for _, op := range patch.Operations {
+		if err := applyGroupOperation(g, op); err != nil {
+			return err
+		}
+	}
+	return ReplaceGroup(ctx, source, group, g)
+}
+
+func applyGroupOperation(group *Group, op *PatchOperation) error {
+	operation := strings.ToLower(op.Op)
+	if operation != "add" && operation != "replace" && operation != "remove" {
+		return NewError(http.StatusBadRequest, "invalidSyntax", "unsupported operation: %s", op.Op)
+	}
+
+	if op.Path == "" {
+		if operation == "remove" {
+			return NewError(http.StatusBadRequest, "noTarget", "remove operations require a path")
+		}
+		values := map[string]json.RawMessage{}
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return NewError(http.StatusBadRequest, "invalidValue", "the value must be an object without path")
+		}
+		for path, value := range values {
+			if err := applyGroupOperation(group, &PatchOperation{Op: op.Op, Path: path, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	attribute, filter, _, err := parsePath(op.Path)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(attribute) {
+	case "displayname", "externalid":
+		var str string
+		if operation != "remove" {
+			if err := json.Unmarshal(op.Value, &str); err != nil {
+				return NewError(http.StatusBadRequest, "invalidValue", "%s must be a string", op.Path)
+			}
+		}
+		if strings.EqualFold(attribute, "displayName") {
+			group.DisplayName = str
+		} else {
+			group.ExternalID = str
+		}
+	case "members":
+		var members []*MultiValue
+		if len(op.Value) > 0 && string(op.Value) != "null" {
+			if err := json.Unmarshal(op.Value, &members); err != nil {
+				return NewError(http.StatusBadRequest, "invalidValue", "members must be a list")
+			}
+		}
+
+		switch operation {
+		case "add":
+			group.Members = append(group.Members, members...)
+		case "replace":
+			group.Members = members
+		case "remove":
+			switch {
+			case filter != nil && filter.Attribute == "value":
+				// members[value eq "2"]
+				members = []*MultiValue{{Value: filter.Value}}
+			case filter != nil:
+				return NewError(http.StatusBadRequest, "invalidFilter", "unsupported path: %s", op.Path)
+			case len(members) == 0:
+				// no value removes all members
+				group.Members = nil
+				return nil
+			}
+			group.Members = slices.DeleteFunc(group.Members, func(m *MultiValue) bool {
+				return slices.ContainsFunc(members, func(r *MultiValue) bool { return r.Value == m.Value })
+			})
+		}
+	default:
+		return NewError(http.StatusBadRequest, "invalidPath", "unsupported path: %s", op.Path)
+	}
+	return nil
+}
+
+// DeleteGroup deletes the group and synchronizes the teams of its former members
+func DeleteGroup(ctx context.Context, source *auth_model.Source, group *auth_model.SCIMGroup) error {
+	userIDs, err := auth_model.GetSCIMGroupMemberIDs(ctx, group.ID)
+	if err != nil {
+		return err
+	}
+	if err := auth_model.DeleteSCIMGroup(ctx, group.ID); err != nil {
+		return err
+	}
+	log.Info("SCIM[%s]: deleted group %s", source.Name, group.DisplayName)
+
+	return syncTeams(ctx, source, userIDs)
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"testing"
+
+	"forgejo.org/modules/json"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyUserOperation(t *testing.T) {
+	newUser := func() *User {
+		active := true
+		return &User{
+			UserName:    "jdoe@example.com",
+			DisplayName: "John Doe",
+			Emails:      []*MultiValue{{Value: "jdoe@example.com", Primary: true}},
+			Active:      &active,
+		}
+	}
+
+	t.Run("Deactivate", func(t *testing.T) {
+		for _, value := range []string{`false`, `"False"`} {
+			user := newUser()
+			require.NoError(t, applyUserOperation(user, &PatchOperation{Op: "Replace", Path: "active", Value: json.RawMessage(value)}))
+			require.NotNil(t, user.Active)
+			assert.False(t, *user.Active)
+		}
+
+		user := newUser()
+		require.NoError(t, applyUserOperation(user, &PatchOperation{Op: "replace", Value: json.RawMessage(`{"active": false}`)}))
+		assert.False(t, *user.Active)
+
+		require.Error(t, applyUserOperation(user, &PatchOperation{Op: "replace", Path: "active", Value: json.RawMessage(`"no"`)}))
+	})
+
+	t.Run("Name", func(t *testing.T) {
+		user := newUser()
+		require.NoError(t, applyUserOperation(user, &PatchOperation{Op: "replace", Path: "name.givenName", Value: json.RawMessage(`"Jane"`)}))
+		require.NoError(t, applyUserOperation(user, &PatchOperation{Op: "replace", Path: "name.familyName", Value: json.RawMessage(`"Roe"`)}))
+		assert.Equal(t, "Jane Roe", user.FullName())
+
+		require.NoError(t, applyUserOperation(user, &PatchOperation{Op: "replace", Path: "displayName", Value: json.RawMessage(`"J. Roe"`)}))
+		assert.Equal(t, "J. Roe", user.FullName())
+	})
+
+	t.Run("Emails", func(t *testing.T) {
+		user := newUser()
+		require.NoError(t, applyUserOperation(user, &PatchOperation{Op: "replace", Path: `emails[type eq "work"].value`, Value: json.RawMessage(`"jane@example.com"`)}))
+		assert.Equal(t, "jane@example.com", user.PrimaryEmail())
+
+		require.NoError(t, applyUserOperation(user, &PatchOperation{Op: "replace", Path: "emails", Value: json.RawMessage(`[{"value": "roe@example.com", "primary": true}]`)}))
+		assert.Equal(t, "roe@example.com", user.PrimaryEmail())
+
+		require.Error(t, applyUserOperation(user, &PatchOperation{Op: "remove", Path: "emails"}))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		user := newUser()
+		require.Error(t, applyUserOperation(user, &PatchOperation{Op: "move", Path: "active", Value: json.RawMessage(`false`)}))
+		require.Error(t, applyUserOperation(user, &PatchOperation{Op: "remove"}))
+		require.Error(t, applyUserOperation(user, &PatchOperation{Op: "remove", Path: "userName"}))
+		require.Error(t, applyUserOperation(user, &PatchOperation{Op: "replace", Path: "userName", Value: json.RawMessage(`42`)}))
+
+		// unknown attributes are ignored
+		require.NoError(t, applyUserOperation(user, &PatchOperation{Op: "replace", Path: "phoneNumbers", Value: json.RawMessage(`[]`)}))
+		assert.Equal(t, newUser(), user)
+	})
+}
+
+func TestApplyGroupOperation(t *testing.T) {
+	memberValues := func(group *Group) []string {
+		values := make([]string, 0, len(group.Members))
+		for _, m := range group.Members {
+			values = append(values, m.Value)
+		}
+		return values
+	}
+
+	group := &Group{DisplayName: "developers"}
+	require.NoError(t, applyGroupOperation(group, &PatchOperation{Op: "add", Path: "members", Value: json.RawMessage(`[{"value": "1"}, {"value": "2"}, {"value": "3"}]`)}))
+	assert.Equal(t, []string{"1", "2", "3"}, memberValues(group))
+
+	require.NoError(t, applyGroupOperation(group, &PatchOperation{Op: "remove", Path: `members[value eq "2"]`}))
+	assert.Equal(t, []string{"1", "3"}, memberValues(group))
+
+	require.NoError(t, applyGroupOperation(group, &PatchOperation{Op: "remove", Path: "members", Value: json.RawMessage(`[{"value": "3"}]`)}))
+	assert.Equal(t, []string{"1"}, memberValues(group))
+
+	require.NoError(t, applyGroupOperation(group, &PatchOperation{Op: "replace", Value: json.RawMessage(`{"displayName": "engineers", "externalId": "abc"}`)}))
+	assert.Equal(t, "engineers", group.DisplayName)
+	assert.Equal(t, "abc", group.ExternalID)
+
+	require.NoError(t, applyGroupOperation(group, &PatchOperation{Op: "replace", Path: "members", Value: json.RawMessage(`[{"value": "4"}]`)}))
+	assert.Equal(t, []string{"4"}, memberValues(group))
+
+	require.NoError(t, applyGroupOperation(group, &PatchOperation{Op: "remove", Path: "members"}))
+	assert.Empty(t, group.Members)
+
+	require.Error(t, applyGroupOperation(group, &PatchOperation{Op: "remove", Path: `members[display eq "x"]`}))
+	require.Error(t, applyGroupOperation(group, &PatchOperation{Op: "replace", Path: "owner", Value: json.RawMessage(`"x"`)}))
+}
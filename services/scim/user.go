@@ -0,0 +1,388 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"forgejo.org/models"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/optional"
+	"forgejo.org/modules/setting"
+	user_service "forgejo.org/services/user"
+
+	"xorm.io/builder"
+)
+
+func resourceLocation(resourceType string, id int64) string {
+	return fmt.Sprintf("%sapi/scim/v2/%s/%d", setting.AppURL, resourceType, id)
+}
+
+// ToUser converts a user provisioned by the authentication source to the SCIM user resource
+func ToUser(ctx context.Context, source *auth_model.Source, u *user_model.User) (*User, error) {
+	active := !u.ProhibitLogin
+	user := &User{
+		Schemas:     []string{SchemaUser},
+		ID:          strconv.FormatInt(u.ID, 10),
+		UserName:    u.LoginName,
+		DisplayName: u.FullName,
+		Emails:      []*MultiValue{{Value: u.Email, Type: "work", Primary: true}},
+		Active:      &active,
+		Meta: &Meta{
+			ResourceType: "User",
+			Created:      u.CreatedUnix.AsTime(),
+			LastModified: u.UpdatedUnix.AsTime(),
+			Location:     resourceLocation("Users", u.ID),
+		},
+	}
+	if user.UserName == "" {
+		user.UserName = u.Name
+	}
+	if u.FullName != "" {
+		user.Name = &Name{Formatted: u.FullName}
+	}
+
+	groups := make([]*auth_model.SCIMGroup, 0, 5)
+	if err := db.GetEngine(ctx).
+		Join("INNER", "scim_group_member", "scim_group_member.group_id = scim_group.id").
+		Where("scim_group.source_id = ? AND scim_group_member.user_id = ?", source.ID, u.ID).
+		OrderBy("scim_group.id").
+		Find(&groups); err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		user.Groups = append(user.Groups, &MultiValue{
+			Value:   strconv.FormatInt(g.ID, 10),
+			Display: g.DisplayName,
+			Ref:     resourceLocation("Groups", g.ID),
+		})
+	}
+	return user, nil
+}
+
+func userCond(source *auth_model.Source) builder.Cond {
+	return builder.Eq{
+		"login_source": source.ID,
+		"type":         user_model.UserTypeIndividual,
+	}
+}
+
+// GetUser returns the user provisioned by the authentication source
+func GetUser(ctx context.Context, source *auth_model.Source, id string) (*user_model.User, error) {
+	uid, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, NewError(http.StatusNotFound, "", "user %s not found", id)
+	}
+	u := &user_model.User{}
+	has, err := db.GetEngine(ctx).Where(userCond(source)).And("id = ?", uid).Get(u)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, NewError(http.StatusNotFound, "", "user %s not found", id)
+	}
+	return u, nil
+}
+
+// ListUsers returns a page of the users provisioned by the authentication source,
+// startIndex is 1-based as defined by RFC 7644
+func ListUsers(ctx context.Context, source *auth_model.Source, filter *Filter, startIndex, count int) (*ListResponse, error) {
+	cond := userCond(source)
+	if filter != nil {
+		switch filter.Attribute {
+		case "userName":
+			cond = cond.And(builder.Expr("LOWER(login_name) = ?", strings.ToLower(filter.Value)))
+		case "emails.value", "emails":
+			cond = cond.And(builder.Eq{"email": strings.ToLower(filter.Value)}.Or(builder.Eq{"email": filter.Value}))
+		}
+	}
+
+	users := make([]*user_model.User, 0, count)
+	total, err := db.GetEngine(ctx).Where(cond).OrderBy("id").Limit(count, startIndex-1).FindAndCount(&users)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListResponse{
+		Schemas:      []string{SchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(users),
+		Resources:    make([]any, 0, len(users)),
+	}
+	for _, u := range users {
+		user, err := ToUser(ctx, source, u)
+		if err != nil {
+			return nil, err
+		}
+		resp.Resources = append(resp.Resources, user)
+	}
+	return resp, nil
+}
+
+// CreateUser creates the user as a user of the authentication source. The SCIM userName is
+// used as login name, so the users can sign in with the source if it identifies them the same way.
+func CreateUser(ctx context.Context, source *auth_model.Source, in *User) (*user_model.User, error) {
+	if strings.TrimSpace(in.UserName) == "" {
+		return nil, NewError(http.StatusBadRequest, "invalidValue", "userName is required")
+	}
+	email := in.PrimaryEmail()
+	if email == "" {
+		return nil, NewError(http.StatusBadRequest, "invalidValue", "an email address is required")
+	}
+
+	has, err := db.GetEngine(ctx).Where(userCond(source)).And(builder.Expr("LOWER(login_name) = ?", strings.ToLower(in.UserName))).Exist(new(user_model.User))
+	if err != nil {
+		return nil, err
+	} else if has {
+		return nil, NewError(http.StatusConflict, "uniqueness", "user %s already exists", in.UserName)
+	}
+
+	// identity providers usually use email addresses or user principal names as userName
+	name, _, _ := strings.Cut(in.UserName, "@")
+	if name, err = user_model.NormalizeUserName(name); err != nil {
+		return nil, NewError(http.StatusBadRequest, "invalidValue", "%v", err)
+	}
+
+	u := &user_model.User{
+		Name:          name,
+		FullName:      in.FullName(),
+		Email:         email,
+		LoginType:     source.Type,
+		LoginSource:   source.ID,
+		LoginName:     in.UserName,
+		ProhibitLogin: in.Active != nil && !*in.Active,
+	}
+	overwriteDefault := &user_model.CreateUserOverwriteOptions{
+		// the identity provider is trusted to have verified the email address
+		IsActive: optional.Some(true),
+	}
+	if err := user_model.CreateUser(ctx, u, overwriteDefault); err != nil {
+		return nil, err
+	}
+	log.Info("SCIM[%s]: created user %s", source.Name, u.Name)
+	return u, nil
+}
+
+// ReplaceUser updates the user with the SCIM user resource
+func ReplaceUser(ctx context.Context, source *auth_model.Source, u *user_model.User, in *User) error {
+	if strings.TrimSpace(in.UserName) == "" {
+		return NewError(http.StatusBadRequest, "invalidValue", "userName is required")
+	}
+
+	if !strings.EqualFold(in.UserName, u.LoginName) {
+		has, err := db.GetEngine(ctx).Where(userCond(source)).
+			And(builder.Expr("LOWER(login_name) = ?", strings.ToLower(in.UserName))).
+			And(builder.Neq{"id": u.ID}).
+			Exist(new(user_model.User))
+		if err != nil {
+			return err
+		} else if has {
+			return NewError(http.StatusConflict, "uniqueness", "user %s already exists", in.UserName)
+		}
+	}
+
+	if err := user_service.UpdateUser(ctx, u, &user_service.UpdateOptions{
+		FullName: optional.Some(in.FullName()),
+	}); err != nil {
+		return err
+	}
+
+	if email := in.PrimaryEmail(); email != "" {
+		if err := user_service.ReplacePrimaryEmailAddress(ctx, u, email); err != nil {
+			return err
+		}
+	}
+
+	wasActive := !u.ProhibitLogin
+	authOpts := &user_service.UpdateAuthOptions{
+		LoginName: optional.Some(in.UserName),
+	}
+	if in.Active != nil {
+		authOpts.ProhibitLogin = optional.Some(!*in.Active)
+	}
+	if err := user_service.UpdateAuth(ctx, u, authOpts); err != nil {
+		return err
+	}
+
+	if wasActive && u.ProhibitLogin {
+		log.Info("SCIM[%s]: deactivated user %s", source.Name, u.Name)
+	}
+	return nil
+}
+
+// PatchUser applies the operations of a PATCH request to the user
+func PatchUser(ctx context.Context, source *auth_model.Source, u *user_model.User, patch *PatchOp) error {
+	user, err := ToUser(ctx, source, u)
+	if err != nil {
+		return err
+	}
+	for _, op := range patch.Operations {
+		if err := applyUserOperation(user, op); err != nil {
+			return err
+		}
+	}
+	return ReplaceUser(ctx, source, u, user)
+}
+
+func applyUserOperation(user *User, op *PatchOperation) error {
+	operation := strings.ToLower(op.Op)
+	if operation != "add" && operation != "replace" && operation != "remove" {
+		return NewError(http.StatusBadRequest, "invalidSyntax", "unsupported operation: %s", op.Op)
+	}
+
+	if op.Path == "" {
+		if operation == "remove" {
+			return NewError(http.StatusBadRequest, "noTarget", "remove operations require a path")
+		}
+		values := map[string]json.RawMessage{}
+		if err := json.Unmarshal(op.Value, &values); err != nil {
+			return NewError(http.StatusBadRequest, "invalidValue", "the value must be an object without path")
+		}
+		for path, value := range values {
+			if err := applyUserOperation(user, &PatchOperation{Op: op.Op, Path: path, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	attribute, filter, subAttribute, err := parsePath(op.Path)
+	if err != nil {
+		return err
+	}
+
+	var str string
+	if operation != "remove" && isStringAttribute(attribute, filter, subAttribute) {
+		if err := json.Unmarshal(op.Value, &str); err != nil {
+			return NewError(http.StatusBadRequest, "invalidValue", "%s must be a string", op.Path)
+		}
+	}
+
+	switch strings.ToLower(attribute) {
+	case "active":
+		if operation == "remove" {
+			user.Active = nil
+			return nil
+		}
+		active, err := parseBool(op.Value)
+		if err != nil {
+			return err
+		}
+		user.Active = &active
+	case "username":
+		if operation == "remove" {
+			return NewError(http.StatusBadRequest, "mutability", "userName is required")
+		}
+		user.UserName = str
+	case "displayname":
+		user.DisplayName = str
+	case "name":
+		if user.Name == nil {
+			user.Name = &Name{}
+		}
+		// the display name has precedence over the name
+		user.DisplayName = ""
+		switch strings.ToLower(subAttribute) {
+		case "":
+			user.Name = &Name{}
+			if operation != "remove" {
+				if err := json.Unmarshal(op.Value, user.Name); err != nil {
+					return NewError(http.StatusBadRequest, "invalidValue", "name must be an object")
+				}
+			}
+		case "formatted":
+			user.Name.Formatted = str
+		case "givenname":
+			user.Name.Formatted = ""
+			user.Name.GivenName = str
+		case "familyname":
+			user.Name.Formatted = ""
+			user.Name.FamilyName = str
+		}
+	case "emails":
+		if operation == "remove" {
+			return NewError(http.StatusBadRequest, "mutability", "an email address is required")
+		}
+		if filter != nil || subAttribute != "" {
+			// e.g. emails[type eq "work"].value, Forgejo only keeps the primary email address
+			user.Emails = []*MultiValue{{Value: str, Primary: true}}
+			return nil
+		}
+		var emails []*MultiValue
+		if err := json.Unmarshal(op.Value, &emails); err != nil {
+			return NewError(http.StatusBadRequest, "invalidValue", "emails must be a list")
+		}
+		user.Emails = emails
+	default:
+		// attributes Forgejo doesn't store, like externalId or phoneNumbers, are ignored
+	}
+	return nil
+}
+
+func isStringAttribute(attribute string, filter *Filter, subAttribute string) bool {
+	switch strings.ToLower(attribute) {
+	case "username", "displayname":
+		return true
+	case "name", "emails":
+		return filter != nil || subAttribute != ""
+	}
+	return false
+}
+
+// parseBool accepts JSON booleans as well as strings like "False", which some identity providers send
+func parseBool(value json.RawMessage) (bool, error) {
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return b, nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err == nil {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	return false, NewError(http.StatusBadRequest, "invalidValue", "%s is not a boolean", string(value))
+}
+
+// DeleteUser deletes the user. Users owning repositories, packages or belonging to organizations
+// can't be deleted, they are deactivated instead so that they can't sign in anymore.
+func DeleteUser(ctx context.Context, source *auth_model.Source, u *user_model.User) error {
+	groupIDs := make([]int64, 0, 5)
+	if err := db.GetEngine(ctx).Table("scim_group_member").Where("user_id = ?", u.ID).Cols("group_id").Find(&groupIDs); err != nil {
+		return err
+	}
+	for _, groupID := range groupIDs {
+		if err := auth_model.RemoveSCIMGroupMembers(ctx, groupID, u.ID); err != nil {
+			return err
+		}
+	}
+	if len(groupIDs) > 0 {
+		if err := syncUserTeams(ctx, source, u); err != nil {
+			return err
+		}
+	}
+
+	err := user_service.DeleteUser(ctx, u, false)
+	if err == nil {
+		log.Info("SCIM[%s]: deleted user %s", source.Name, u.Name)
+		return nil
+	}
+	if !models.IsErrUserOwnRepos(err) && !models.IsErrUserHasOrgs(err) && !models.IsErrUserOwnPackages(err) {
+		return err
+	}
+
+	if err := user_service.UpdateAuth(ctx, u, &user_service.UpdateAuthOptions{ProhibitLogin: optional.Some(true)}); err != nil {
+		return err
+	}
+	log.Info("SCIM[%s]: deactivated user %s instead of deleting it: %v", source.Name, u.Name, err)
+	return NewError(http.StatusConflict, "mutability", "the user has been deactivated but can't be deleted: %v", err)
+}
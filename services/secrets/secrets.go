@@ -6,11 +6,14 @@ package secrets
 import (
 	"context"
 
+	audit_model "forgejo.org/models/audit"
 	"forgejo.org/models/db"
 	secret_model "forgejo.org/models/secret"
+	user_model "forgejo.org/models/user"
+	audit_service "forgejo.org/services/audit"
 )
 
-func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data string) (*secret_model.Secret, bool, error) {
+func CreateOrUpdateSecret(ctx context.Context, doer *user_model.User, ownerID, repoID int64, name, data string) (*secret_model.Secret, bool, error) {
 	if err := ValidateName(name); err != nil {
 		return nil, false, err
 	}
@@ -29,6 +32,7 @@ func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data
 		if err != nil {
 			return nil, false, err
 		}
+		audit_service.Record(ctx, audit_service.ActorFromContext(ctx, doer), audit_model.ActionSecretUpdate, audit_service.SecretTarget(s), nil, map[string]any{"created": true})
 		return s, true, nil
 	}
 
@@ -36,10 +40,11 @@ func CreateOrUpdateSecret(ctx context.Context, ownerID, repoID int64, name, data
 	if _, err := db.GetEngine(ctx).Cols("data").ID(s.ID).Update(s); err != nil {
 		return nil, false, err
 	}
+	audit_service.Record(ctx, audit_service.ActorFromContext(ctx, doer), audit_model.ActionSecretUpdate, audit_service.SecretTarget(s), nil, map[string]any{"created": false})
 	return s, false, nil
 }
 
-func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) error {
+func DeleteSecretByID(ctx context.Context, doer *user_model.User, ownerID, repoID, secretID int64) error {
 	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
 		OwnerID:  ownerID,
 		RepoID:   repoID,
@@ -52,10 +57,10 @@ func DeleteSecretByID(ctx context.Context, ownerID, repoID, secretID int64) erro
 		return secret_model.ErrSecretNotFound{}
 	}
 
-	return deleteSecret(ctx, s[0])
+	return deleteSecret(ctx, doer, s[0])
 }
 
-func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string) error {
+func DeleteSecretByName(ctx context.Context, doer *user_model.User, ownerID, repoID int64, name string) error {
 	if err := ValidateName(name); err != nil {
 		return err
 	}
@@ -72,12 +77,13 @@ func DeleteSecretByName(ctx context.Context, ownerID, repoID int64, name string)
 		return secret_model.ErrSecretNotFound{}
 	}
 
-	return deleteSecret(ctx, s[0])
+	return deleteSecret(ctx, doer, s[0])
 }
 
-func deleteSecret(ctx context.Context, s *secret_model.Secret) error {
+func deleteSecret(ctx context.Context, doer *user_model.User, s *secret_model.Secret) error {
 	if _, err := db.DeleteByID[secret_model.Secret](ctx, s.ID); err != nil {
 		return err
 	}
+	audit_service.Record(ctx, audit_service.ActorFromContext(ctx, doer), audit_model.ActionSecretDelete, audit_service.SecretTarget(s), nil, nil)
 	return nil
 }
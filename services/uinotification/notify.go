@@ -122,6 +122,15 @@ func (ns *notificationService) IssueChangeTitle(ctx context.Context, doer *user_
 	}
 }
 
+func (ns *notificationService) IssueChangeConfidential(ctx context.Context, doer *user_model.User, issue *issues_model.Issue) {
+	if !issue.IsConfidential {
+		return
+	}
+	if err := activities_model.DeleteHiddenIssueNotifications(ctx, issue); err != nil {
+		log.Error("DeleteHiddenIssueNotifications: %v", err)
+	}
+}
+
 func (ns *notificationService) MergePullRequest(ctx context.Context, doer *user_model.User, pr *issues_model.PullRequest) {
 	_ = ns.issueQueue.Push(issueNotificationOpts{
 		IssueID:              pr.Issue.ID,
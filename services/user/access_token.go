@@ -0,0 +1,55 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package user
+
+import (
+	"context"
+
+	auth_model "forgejo.org/models/auth"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/log"
+	"forgejo.org/modules/setting"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/services/mailer"
+)
+
+// NotifyExpiringAccessTokens sends a mail to the owners of access tokens which expire soon
+func NotifyExpiringAccessTokens(ctx context.Context) error {
+	tokens, err := auth_model.FindExpiringAccessTokens(ctx, timeutil.TimeStampNow().AddDuration(setting.AccessTokenExpiryNotice))
+	if err != nil {
+		return err
+	}
+
+	byUser := make(map[int64][]*auth_model.AccessToken)
+	for _, t := range tokens {
+		byUser[t.UID] = append(byUser[t.UID], t)
+	}
+
+	for uid, userTokens := range byUser {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		u, err := user_model.GetUserByID(ctx, uid)
+		if err != nil {
+			if !user_model.IsErrUserNotExist(err) {
+				return err
+			}
+		} else if err := mailer.SendAccessTokenExpiring(ctx, u, userTokens); err != nil {
+			log.Error("SendAccessTokenExpiring for user %d: %v", uid, err)
+			continue
+		}
+
+		ids := make([]int64, 0, len(userTokens))
+		for _, t := range userTokens {
+			ids = append(ids, t.ID)
+		}
+		if err := auth_model.SetAccessTokensExpiryNotified(ctx, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -34,11 +34,13 @@ type UpdateOptions struct {
 	Theme                        optional.Option[string]
 	DiffViewStyle                optional.Option[string]
 	AllowCreateOrganization      optional.Option[bool]
+	IsRateLimitExempt            optional.Option[bool]
 	IsActive                     optional.Option[bool]
 	IsAdmin                      optional.Option[bool]
 	EmailNotificationsPreference optional.Option[string]
 	SetLastLogin                 bool
 	RepoAdminChangeTeamAccess    optional.Option[bool]
+	RequirePhishingResistantAuth optional.Option[bool]
 	EnableRepoUnitHints          optional.Option[bool]
 	KeepPronounsPrivate          optional.Option[bool]
 }
@@ -160,11 +162,21 @@ func UpdateUser(ctx context.Context, u *user_model.User, opts *UpdateOptions) er
 
 		cols = append(cols, "allow_create_organization")
 	}
+	if opts.IsRateLimitExempt.Has() {
+		u.IsRateLimitExempt = opts.IsRateLimitExempt.Value()
+
+		cols = append(cols, "is_rate_limit_exempt")
+	}
 	if opts.RepoAdminChangeTeamAccess.Has() {
 		u.RepoAdminChangeTeamAccess = opts.RepoAdminChangeTeamAccess.Value()
 
 		cols = append(cols, "repo_admin_change_team_access")
 	}
+	if opts.RequirePhishingResistantAuth.Has() {
+		u.RequirePhishingResistantAuth = opts.RequirePhishingResistantAuth.Value()
+
+		cols = append(cols, "require_phishing_resistant_auth")
+	}
 
 	if opts.EmailNotificationsPreference.Has() {
 		u.EmailNotificationsPreference = opts.EmailNotificationsPreference.Value()
@@ -0,0 +1,56 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+// @watch start
+// templates/user/auth/**
+// templates/user/settings/**
+// web_src/js/features/user-**
+// @watch end
+
+import {expect} from '@playwright/test';
+import {test, create_temp_user} from './utils_e2e.ts';
+
+test('Passkey register & login flow', async ({browser, request}, workerInfo) => {
+  test.skip(workerInfo.project.name !== 'chromium', 'Uses Chrome protocol');
+  const {context} = await create_temp_user(browser, workerInfo, request);
+  const page = await context.newPage();
+
+  // Register a passkey.
+  let response = await page.goto('/user/settings/security');
+  expect(response?.status()).toBe(200);
+
+  const cdpSession = await page.context().newCDPSession(page);
+  await cdpSession.send('WebAuthn.enable');
+  await cdpSession.send('WebAuthn.addVirtualAuthenticator', {
+    options: {
+      protocol: 'ctap2',
+      ctap2Version: 'ctap2_1',
+      hasResidentKey: true,
+      hasUserVerification: true,
+      transport: 'internal',
+      automaticPresenceSimulation: true,
+      isUserVerified: true,
+    },
+  });
+
+  await page.locator('input#passkey-nickname').fill('Testing Passkey');
+  await page.getByText('Add passkey').click();
+  await expect(page.getByText('Testing Passkey')).toBeVisible();
+
+  // The passkey is not a second factor.
+  await expect(page.getByText('Enroll into two-factor authentication')).toBeVisible();
+
+  // Logout.
+  await page.locator('summary[aria-label="Profile and settings…"]').click();
+  await page.getByText('Sign out').click();
+  await expect(async () => {
+    await page.waitForURL(`${workerInfo.project.use.baseURL}/`);
+  }).toPass();
+
+  // Login without username and password.
+  response = await page.goto('/user/login');
+  expect(response?.status()).toBe(200);
+  await page.getByRole('button', {name: 'Sign in with a passkey'}).click();
+  await page.waitForURL(`${workerInfo.project.use.baseURL}/`);
+  await expect(page.locator('summary[aria-label="Profile and settings…"]')).toBeVisible();
+});
@@ -0,0 +1,139 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIIssueConfidential(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+	readerToken := getUserToken(t, "user5", auth_model.AccessTokenScopeReadIssue)
+
+	req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues", &api.CreateIssueOption{
+		Title:        "security report",
+		Body:         "details of the vulnerability",
+		Confidential: true,
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+	var apiIssue api.Issue
+	DecodeJSON(t, resp, &apiIssue)
+	assert.True(t, apiIssue.IsConfidential)
+	unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: apiIssue.ID, IsConfidential: true})
+
+	link := fmt.Sprintf("/api/v1/repos/user2/repo1/issues/%d", apiIssue.Index)
+	listIDs := func(t *testing.T, token string) []int64 {
+		t.Helper()
+		req := NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues?state=all&type=issues&limit=50").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var issues []*api.Issue
+		DecodeJSON(t, resp, &issues)
+		ids := make([]int64, 0, len(issues))
+		for _, issue := range issues {
+			ids = append(ids, issue.ID)
+		}
+		return ids
+	}
+
+	t.Run("Hidden", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", link).AddTokenAuth(token), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", link).AddTokenAuth(readerToken), http.StatusNotFound)
+		MakeRequest(t, NewRequest(t, "GET", link+"/comments").AddTokenAuth(readerToken), http.StatusNotFound)
+		MakeRequest(t, NewRequest(t, "GET", link), http.StatusNotFound)
+
+		assert.Contains(t, listIDs(t, token), apiIssue.ID)
+		assert.NotContains(t, listIDs(t, readerToken), apiIssue.ID)
+
+		session := loginUser(t, "user5")
+		session.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/user2/repo1/issues/%d", apiIssue.Index)), http.StatusNotFound)
+	})
+
+	t.Run("Web", func(t *testing.T) {
+		session := loginUser(t, "user2")
+		issueLink := fmt.Sprintf("/user2/repo1/issues/%d", apiIssue.Index)
+		resp := session.MakeRequest(t, NewRequest(t, "GET", issueLink), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		htmlDoc.AssertElement(t, fmt.Sprintf("form[action='%s/confidential'] input[name='confidential'][value='false']", issueLink), true)
+
+		req := NewRequestWithValues(t, "POST", issueLink+"/confidential", map[string]string{
+			"confidential": "false",
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: apiIssue.ID, IsConfidential: false})
+
+		// readers can't change it
+		req = NewRequestWithValues(t, "POST", issueLink+"/confidential", map[string]string{
+			"confidential": "true",
+		})
+		loginUser(t, "user5").MakeRequest(t, req, http.StatusNotFound)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: apiIssue.ID, IsConfidential: false})
+	})
+
+	t.Run("Edit", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", link).AddTokenAuth(readerToken), http.StatusOK)
+
+		confidential := true
+		req := NewRequestWithJSON(t, "PATCH", link, &api.EditIssueOption{Confidential: &confidential}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var edited api.Issue
+		DecodeJSON(t, resp, &edited)
+		require.True(t, edited.IsConfidential)
+
+		MakeRequest(t, NewRequest(t, "GET", link).AddTokenAuth(readerToken), http.StatusNotFound)
+
+		// pull requests can't be confidential
+		req = NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1/issues/2", &api.EditIssueOption{Confidential: &confidential}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Dependencies", func(t *testing.T) {
+		confidentialMeta := api.IssueMeta{Index: apiIssue.Index, Owner: "user2", Name: "repo1"}
+		createPublicIssue := func(t *testing.T, title string) *api.Issue {
+			t.Helper()
+			req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues", &api.CreateIssueOption{Title: title}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusCreated)
+			var issue api.Issue
+			DecodeJSON(t, resp, &issue)
+			return &issue
+		}
+		listTitles := func(t *testing.T, link, token string) []string {
+			t.Helper()
+			resp := MakeRequest(t, NewRequest(t, "GET", link).AddTokenAuth(token), http.StatusOK)
+			var issues []*api.Issue
+			DecodeJSON(t, resp, &issues)
+			titles := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				titles = append(titles, issue.Title)
+			}
+			return titles
+		}
+
+		// the public issue depends on the confidential issue
+		blocked := createPublicIssue(t, "blocked by the security report")
+		dependenciesLink := fmt.Sprintf("/api/v1/repos/user2/repo1/issues/%d/dependencies", blocked.Index)
+		MakeRequest(t, NewRequestWithJSON(t, "POST", dependenciesLink, confidentialMeta).AddTokenAuth(token), http.StatusCreated)
+		assert.Equal(t, []string{"security report"}, listTitles(t, dependenciesLink, token))
+		assert.Equal(t, []string{"HIDDEN"}, listTitles(t, dependenciesLink, readerToken))
+
+		// the public issue blocks the confidential issue
+		blocking := createPublicIssue(t, "blocking the security report")
+		blocksLink := fmt.Sprintf("/api/v1/repos/user2/repo1/issues/%d/blocks", blocking.Index)
+		MakeRequest(t, NewRequestWithJSON(t, "POST", blocksLink, confidentialMeta).AddTokenAuth(token), http.StatusCreated)
+		assert.Equal(t, []string{"security report"}, listTitles(t, blocksLink, token))
+		assert.Empty(t, listTitles(t, blocksLink, readerToken))
+	})
+}
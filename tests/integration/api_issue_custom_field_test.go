@@ -0,0 +1,112 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIIssueCustomFields(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeWriteIssue)
+	link := fmt.Sprintf("/api/v1/repos/%s/%s/custom_fields", owner.Name, repo.Name)
+
+	var field api.CustomField
+	t.Run("Create", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", link, api.CreateCustomFieldOption{
+			Name:    "Priority",
+			Type:    "select",
+			Options: []string{"high", "low"},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		DecodeJSON(t, resp, &field)
+		assert.Equal(t, "Priority", field.Name)
+		assert.Equal(t, []string{"high", "low"}, field.Options)
+
+		req = NewRequestWithJSON(t, "POST", link, api.CreateCustomFieldOption{Name: "Priority", Type: "select"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		otherToken := getUserToken(t, "user5", auth_model.AccessTokenScopeWriteIssue)
+		req = NewRequestWithJSON(t, "POST", link, api.CreateCustomFieldOption{Name: "Other", Type: "text"}).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "GET", link).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var fields []*api.CustomField
+		DecodeJSON(t, resp, &fields)
+		require.Len(t, fields, 1)
+		assert.Equal(t, field.ID, fields[0].ID)
+	})
+
+	t.Run("SetValue", func(t *testing.T) {
+		valueLink := fmt.Sprintf("/api/v1/repos/%s/%s/issues/1/custom_fields/%d", owner.Name, repo.Name, field.ID)
+		req := NewRequestWithJSON(t, "PUT", valueLink, api.SetIssueCustomFieldValueOption{Value: "medium"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", valueLink, api.SetIssueCustomFieldValueOption{Value: "low"}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var values []*api.IssueCustomFieldValue
+		DecodeJSON(t, resp, &values)
+		require.Len(t, values, 1)
+		assert.Equal(t, "low", values[0].Value)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/%s/issues/1/custom_fields", owner.Name, repo.Name)).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &values)
+		require.Len(t, values, 1)
+		assert.Equal(t, "Priority", values[0].Name)
+
+		unittest.AssertExistsAndLoadBean(t, &issues_model.CustomFieldValue{FieldID: field.ID, IssueID: 1, Value: "low"})
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/%s/issues?state=all&type=issues&custom_field=%s", owner.Name, repo.Name, url.QueryEscape(fmt.Sprintf("%d:low", field.ID)))).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var issues []*api.Issue
+		DecodeJSON(t, resp, &issues)
+		require.Len(t, issues, 1)
+		assert.EqualValues(t, 1, issues[0].Index)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/%s/issues?custom_field=invalid", owner.Name, repo.Name)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Web", func(t *testing.T) {
+		session := loginUser(t, owner.Name)
+		req := NewRequestWithValues(t, "POST", fmt.Sprintf("/%s/%s/issues/1/custom_fields/%d", owner.Name, repo.Name, field.ID), map[string]string{
+			"value": "high",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.CustomFieldValue{FieldID: field.ID, IssueID: 1, Value: "high"})
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/%s/%s/issues/1", owner.Name, repo.Name))
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		htmlDoc.AssertElement(t, fmt.Sprintf("form[action$='/issues/1/custom_fields/%d'] option[value='high'][selected]", field.ID), true)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		req := NewRequest(t, "DELETE", fmt.Sprintf("%s/%d", link, field.ID)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &issues_model.CustomField{ID: field.ID})
+		unittest.AssertNotExistsBean(t, &issues_model.CustomFieldValue{FieldID: field.ID})
+	})
+}
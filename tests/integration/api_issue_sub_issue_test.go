@@ -0,0 +1,133 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIIssueSubIssues(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+	link := "/api/v1/repos/user2/repo1/issues/1/sub_issues"
+
+	t.Run("Add", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", link, api.IssueMeta{Owner: "user2", Name: "repo2", Index: 2}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var issue api.Issue
+		DecodeJSON(t, resp, &issue)
+		assert.EqualValues(t, 7, issue.ID)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.SubIssue{ParentID: 1, IssueID: 7})
+
+		req = NewRequestWithJSON(t, "POST", link, api.IssueMeta{Owner: "user2", Name: "repo1", Index: 4}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// the issue already has a parent
+		req = NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo1/issues/4/sub_issues", api.IssueMeta{Owner: "user2", Name: "repo2", Index: 2}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		// an issue of another owner
+		req = NewRequestWithJSON(t, "POST", link, api.IssueMeta{Owner: "user3", Name: "repo3", Index: 1}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		otherToken := getUserToken(t, "user5", auth_model.AccessTokenScopeWriteIssue)
+		req = NewRequestWithJSON(t, "POST", link, api.IssueMeta{Owner: "user2", Name: "repo1", Index: 1}).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		req := NewRequest(t, "GET", link).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var issues []*api.Issue
+		DecodeJSON(t, resp, &issues)
+		require.Len(t, issues, 2)
+		assert.EqualValues(t, 7, issues[0].ID)
+		assert.EqualValues(t, 5, issues[1].ID)
+
+		req = NewRequest(t, "GET", link+"/progress").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var progress api.SubIssueProgress
+		DecodeJSON(t, resp, &progress)
+		assert.Equal(t, 2, progress.Total)
+		assert.Equal(t, 1, progress.Closed)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo2/issues/2/parent").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		var parent api.Issue
+		DecodeJSON(t, resp, &parent)
+		assert.EqualValues(t, 1, parent.ID)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/issues/1/parent").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "DELETE", link, api.IssueMeta{Owner: "user2", Name: "repo2", Index: 2}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: 7})
+
+		req = NewRequestWithJSON(t, "DELETE", link, api.IssueMeta{Owner: "user2", Name: "repo2", Index: 2}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Web", func(t *testing.T) {
+		session := loginUser(t, "user2")
+		req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/sub_issues/add", map[string]string{
+			"sub_issue": "user2/repo2#2",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.SubIssue{ParentID: 1, IssueID: 7})
+
+		req = NewRequest(t, "GET", "/user2/repo1/issues/1")
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		htmlDoc.AssertElement(t, ".sub-issues input[name='sub_issue_id'][value='7']", true)
+
+		req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/sub_issues/remove", map[string]string{
+			"sub_issue_id": "7",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: 7})
+	})
+
+	t.Run("Confidential", func(t *testing.T) {
+		// user5 can change the issues of repo1 but can only read the ones of repo2
+		repoToken := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository, auth_model.AccessTokenScopeWriteIssue)
+		for repo, permission := range map[string]string{"repo1": "write", "repo2": "read"} {
+			req := NewRequestWithJSON(t, "PUT", "/api/v1/repos/user2/"+repo+"/collaborators/user5", api.AddCollaboratorOption{Permission: &permission}).AddTokenAuth(repoToken)
+			MakeRequest(t, req, http.StatusNoContent)
+		}
+
+		req := NewRequestWithJSON(t, "POST", "/api/v1/repos/user2/repo2/issues", &api.CreateIssueOption{
+			Title:        "security report",
+			Confidential: true,
+		}).AddTokenAuth(repoToken)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var confidential api.Issue
+		DecodeJSON(t, resp, &confidential)
+
+		otherToken := getUserToken(t, "user5", auth_model.AccessTokenScopeWriteIssue)
+		req = NewRequestWithJSON(t, "POST", link, api.IssueMeta{Owner: "user2", Name: "repo2", Index: confidential.Index}).AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusNotFound)
+		unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: confidential.ID})
+
+		req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/1/sub_issues/add", map[string]string{
+			"sub_issue": fmt.Sprintf("user2/repo2#%d", confidential.Index),
+		})
+		loginUser(t, "user5").MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &issues_model.SubIssue{IssueID: confidential.ID})
+	})
+}
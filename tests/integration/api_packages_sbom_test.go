@@ -0,0 +1,172 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	packages_model "forgejo.org/models/packages"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageSBOM(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	tokenReadPackage := getUserToken(t, user.Name, auth_model.AccessTokenScopeReadPackage)
+	tokenWritePackage := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "sbom-package"
+
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", user.Name, packageName, version), bytes.NewReader([]byte{1})).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	sbomURL := fmt.Sprintf("/api/v1/packages/%s/generic/%s/1.0.0/sbom", user.Name, packageName)
+	cycloneDX := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "components": [
+    {"bom-ref": "log4j", "type": "library", "group": "org.apache.logging.log4j", "name": "log4j-core", "version": "2.14.1", "purl": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", "licenses": [{"license": {"id": "Apache-2.0"}}]},
+    {"type": "library", "name": "lodash", "version": "4.17.21", "purl": "pkg:npm/lodash@4.17.21"}
+  ],
+  "vulnerabilities": [{"id": "CVE-2021-44228", "affects": [{"ref": "log4j"}]}]
+}`
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", sbomURL, strings.NewReader(cycloneDX))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "PUT", sbomURL, strings.NewReader(cycloneDX)).
+			AddTokenAuth(tokenReadPackage)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "PUT", sbomURL, strings.NewReader(`{"name":"invalid"}`)).
+			AddTokenAuth(tokenWritePackage)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", sbomURL, strings.NewReader(cycloneDX)).
+			AddTokenAuth(tokenWritePackage)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var apiSBOM *api.PackageSBOM
+		DecodeJSON(t, resp, &apiSBOM)
+		assert.Equal(t, "cyclonedx", apiSBOM.Format)
+		assert.Equal(t, "1.5", apiSBOM.SpecVersion)
+		assert.EqualValues(t, len(cycloneDX), apiSBOM.Size)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", sbomURL).
+			AddTokenAuth(tokenReadPackage)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, cycloneDX, resp.Body.String())
+		assert.Equal(t, "application/vnd.cyclonedx+json", resp.Header().Get("Content-Type"))
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/generic/%s/2.0.0/sbom", user.Name, packageName)).
+			AddTokenAuth(tokenReadPackage)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("ListComponents", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/generic/%s/1.0.0/components", user.Name, packageName)).
+			AddTokenAuth(tokenReadPackage)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var apiComponents []*api.PackageComponent
+		DecodeJSON(t, resp, &apiComponents)
+		require.Len(t, apiComponents, 2)
+		assert.Equal(t, "lodash", apiComponents[0].Name)
+		assert.Equal(t, "org.apache.logging.log4j/log4j-core", apiComponents[1].Name)
+		assert.Equal(t, "2.14.1", apiComponents[1].Version)
+		assert.Equal(t, []string{"Apache-2.0"}, apiComponents[1].Licenses)
+		assert.Equal(t, []string{"CVE-2021-44228"}, apiComponents[1].Vulnerabilities)
+	})
+
+	t.Run("SearchByComponent", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		cases := []struct {
+			Query    string
+			Expected int
+		}{
+			{"component=lodash", 1},
+			{"component=LODASH&component_version=4.17.21", 1},
+			{"component=lodash&component_version=4.17.20", 0},
+			{"component=pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", 1},
+			{"component=unknown", 0},
+		}
+
+		for _, c := range cases {
+			req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s?%s", user.Name, c.Query)).
+				AddTokenAuth(tokenReadPackage)
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var apiPackages []*api.Package
+			DecodeJSON(t, resp, &apiPackages)
+			require.Len(t, apiPackages, c.Expected, c.Query)
+			if c.Expected == 1 {
+				assert.Equal(t, "1.0.0", apiPackages[0].Version)
+			}
+		}
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/%s/-/packages?component=lodash", user.Name))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), packageName+" 1.0.0")
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", sbomURL).
+			AddTokenAuth(tokenWritePackage)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", sbomURL).
+			AddTokenAuth(tokenWritePackage)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		unittest.AssertCount(t, &packages_model.PackageComponent{}, 0)
+	})
+
+	t.Run("DeleteVersion", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", sbomURL, strings.NewReader(cycloneDX)).
+			AddTokenAuth(tokenWritePackage)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pv, err := packages_model.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages_model.TypeGeneric, packageName, "1.0.0")
+		require.NoError(t, err)
+		ps, err := packages_model.GetSBOMByVersionID(db.DefaultContext, pv.ID)
+		require.NoError(t, err)
+
+		req = NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/packages/%s/generic/%s/1.0.0", user.Name, packageName)).
+			AddTokenAuth(tokenWritePackage)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		unittest.AssertNotExistsBean(t, &packages_model.PackageSBOM{ID: ps.ID})
+		unittest.AssertCount(t, &packages_model.PackageComponent{}, 0)
+	})
+}
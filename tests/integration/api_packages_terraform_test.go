@@ -0,0 +1,337 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/packages"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	terraform_module "forgejo.org/modules/packages/terraform"
+	"forgejo.org/modules/setting"
+	"forgejo.org/tests"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageTerraform(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+
+	token := "Bearer " + getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	t.Run("ServiceDiscovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", "/.well-known/terraform.json")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+
+		assert.Equal(t, setting.AppSubURL+"/api/packages/terraform/modules/v1/", result["modules.v1"])
+		assert.Equal(t, setting.AppSubURL+"/api/packages/terraform/providers/v1/", result["providers.v1"])
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		moduleName := "vpc"
+		moduleSystem := "aws"
+		moduleVersion := "1.0.1"
+		readme := "# VPC"
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		archive := tar.NewWriter(zw)
+		for name, content := range map[string]string{"main.tf": "", "README.md": readme} {
+			archive.WriteHeader(&tar.Header{
+				Name: name,
+				Mode: 0o600,
+				Size: int64(len(content)),
+			})
+			archive.Write([]byte(content))
+		}
+		archive.Close()
+		zw.Close()
+		content := buf.Bytes()
+
+		uploadURL := fmt.Sprintf("/api/packages/%s/terraform/modules/%s/%s/%s", user.Name, moduleName, moduleSystem, moduleVersion)
+		registryURL := fmt.Sprintf("/api/packages/terraform/modules/v1/%s/%s/%s", user.Name, moduleName, moduleSystem)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/terraform/modules/%s/%s/latest", user.Name, moduleName, moduleSystem), bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, strings.NewReader("invalid")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pvs, err := packages.GetVersionsByPackageType(db.DefaultContext, user.ID, packages.TypeTerraform)
+			require.NoError(t, err)
+			assert.Len(t, pvs, 1)
+
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pvs[0])
+			require.NoError(t, err)
+			assert.NotNil(t, pd.SemVer)
+			assert.IsType(t, &terraform_module.Metadata{}, pd.Metadata)
+			assert.Equal(t, terraform_module.KindModule, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Equal(t, readme, pd.Metadata.(*terraform_module.Metadata).Readme)
+			assert.Equal(t, moduleName+"/"+moduleSystem, pd.Package.Name)
+			assert.Equal(t, moduleVersion, pd.Version.Version)
+
+			req = NewRequestWithBody(t, "PUT", uploadURL, bytes.NewReader(content)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusConflict)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", registryURL+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Modules []struct {
+					Versions []struct {
+						Version string `json:"version"`
+					} `json:"versions"`
+				} `json:"modules"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			require.Len(t, result.Modules, 1)
+			require.Len(t, result.Modules[0].Versions, 1)
+			assert.Equal(t, moduleVersion, result.Modules[0].Versions[0].Version)
+
+			req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/terraform/modules/v1/%s/%s/other/versions", user.Name, moduleName))
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/%s/download", registryURL, moduleVersion))
+			resp := MakeRequest(t, req, http.StatusNoContent)
+
+			assert.Equal(t, setting.AppURL+strings.TrimPrefix(uploadURL, "/")+"?archive=tar.gz", resp.Header().Get("X-Terraform-Get"))
+
+			req = NewRequest(t, "GET", uploadURL)
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, content, resp.Body.Bytes())
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "DELETE", uploadURL)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "DELETE", uploadURL).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNoContent)
+
+			req = NewRequest(t, "GET", registryURL+"/versions")
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+
+	t.Run("Provider", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		providerType := "example"
+		providerVersion := "2.0.0"
+		filePrefix := fmt.Sprintf("terraform-provider-%s_%s", providerType, providerVersion)
+		archiveName := filePrefix + "_linux_amd64.zip"
+		archiveContent := []byte("dummy zip")
+		archiveSum := sha256.Sum256(archiveContent)
+		sums := fmt.Sprintf("%s  %s\n", hex.EncodeToString(archiveSum[:]), archiveName)
+
+		entity, err := openpgp.NewEntity("Forgejo", "", user.Email, nil)
+		require.NoError(t, err)
+		var publicKey bytes.Buffer
+		w, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, entity.Serialize(w))
+		require.NoError(t, w.Close())
+		_, err = asymkey_model.AddGPGKey(db.DefaultContext, user.ID, publicKey.String(), "", "")
+		require.NoError(t, err)
+
+		var signature bytes.Buffer
+		require.NoError(t, openpgp.DetachSign(&signature, entity, strings.NewReader(sums), nil))
+
+		fileURL := fmt.Sprintf("/api/packages/%s/terraform/providers/%s/%s", user.Name, providerType, providerVersion)
+		registryURL := fmt.Sprintf("/api/packages/terraform/providers/v1/%s/%s", user.Name, providerType)
+
+		t.Run("Upload", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithBody(t, "PUT", fileURL+"/"+archiveName, bytes.NewReader(archiveContent))
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequestWithBody(t, "PUT", fileURL+"/terraform-provider-other_2.0.0_linux_amd64.zip", bytes.NewReader(archiveContent)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", fileURL+"/"+filePrefix+"_SHA256SUMS.sig", bytes.NewReader(signature.Bytes())).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", fileURL+"/"+archiveName, bytes.NewReader(archiveContent)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			req = NewRequestWithBody(t, "PUT", fileURL+"/"+filePrefix+"_manifest.json", strings.NewReader(`{"version":1,"metadata":{"protocol_versions":["6.0"]}}`)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			// the checksum of the uploaded archive does not match
+			otherSum := sha256.Sum256([]byte("other zip"))
+			req = NewRequestWithBody(t, "PUT", fileURL+"/"+filePrefix+"_SHA256SUMS", strings.NewReader(fmt.Sprintf("%s  %s\n", hex.EncodeToString(otherSum[:]), archiveName))).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", fileURL+"/"+filePrefix+"_SHA256SUMS", strings.NewReader(sums)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			// the archive is not listed in the uploaded SHA256SUMS file
+			req = NewRequestWithBody(t, "PUT", fileURL+"/"+filePrefix+"_darwin_arm64.zip", bytes.NewReader(archiveContent)).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", fileURL+"/"+filePrefix+"_SHA256SUMS.sig", strings.NewReader("invalid")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusBadRequest)
+
+			req = NewRequestWithBody(t, "PUT", fileURL+"/"+filePrefix+"_SHA256SUMS.sig", bytes.NewReader(signature.Bytes())).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusCreated)
+
+			pv, err := packages.GetVersionByNameAndVersion(db.DefaultContext, user.ID, packages.TypeTerraform, "terraform-provider-"+providerType, providerVersion)
+			require.NoError(t, err)
+
+			pd, err := packages.GetPackageDescriptor(db.DefaultContext, pv)
+			require.NoError(t, err)
+			assert.Equal(t, terraform_module.KindProvider, pd.Metadata.(*terraform_module.Metadata).Kind)
+			assert.Len(t, pd.Files, 4)
+		})
+
+		t.Run("EnumerateVersions", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", registryURL+"/versions")
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Versions []struct {
+					Version   string   `json:"version"`
+					Protocols []string `json:"protocols"`
+					Platforms []struct {
+						OS   string `json:"os"`
+						Arch string `json:"arch"`
+					} `json:"platforms"`
+				} `json:"versions"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			require.Len(t, result.Versions, 1)
+			assert.Equal(t, providerVersion, result.Versions[0].Version)
+			assert.Equal(t, []string{"6.0"}, result.Versions[0].Protocols)
+			require.Len(t, result.Versions[0].Platforms, 1)
+			assert.Equal(t, "linux", result.Versions[0].Platforms[0].OS)
+			assert.Equal(t, "amd64", result.Versions[0].Platforms[0].Arch)
+		})
+
+		t.Run("Download", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "GET", fmt.Sprintf("%s/%s/download/darwin/arm64", registryURL, providerVersion))
+			MakeRequest(t, req, http.StatusNotFound)
+
+			req = NewRequest(t, "GET", fmt.Sprintf("%s/%s/download/linux/amd64", registryURL, providerVersion))
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var result struct {
+				Protocols           []string `json:"protocols"`
+				OS                  string   `json:"os"`
+				Arch                string   `json:"arch"`
+				Filename            string   `json:"filename"`
+				DownloadURL         string   `json:"download_url"`
+				SHASumsURL          string   `json:"shasums_url"`
+				SHASumsSignatureURL string   `json:"shasums_signature_url"`
+				SHASum              string   `json:"shasum"`
+				SigningKeys         struct {
+					GPGPublicKeys []struct {
+						KeyID      string `json:"key_id"`
+						ASCIIArmor string `json:"ascii_armor"`
+					} `json:"gpg_public_keys"`
+				} `json:"signing_keys"`
+			}
+			DecodeJSON(t, resp, &result)
+
+			baseURL := setting.AppURL + strings.TrimPrefix(fileURL, "/") + "/"
+
+			assert.Equal(t, []string{"6.0"}, result.Protocols)
+			assert.Equal(t, "linux", result.OS)
+			assert.Equal(t, "amd64", result.Arch)
+			assert.Equal(t, archiveName, result.Filename)
+			assert.Equal(t, baseURL+archiveName, result.DownloadURL)
+			assert.Equal(t, baseURL+filePrefix+"_SHA256SUMS", result.SHASumsURL)
+			assert.Equal(t, baseURL+filePrefix+"_SHA256SUMS.sig", result.SHASumsSignatureURL)
+			assert.Equal(t, hex.EncodeToString(archiveSum[:]), result.SHASum)
+			require.Len(t, result.SigningKeys.GPGPublicKeys, 1)
+			assert.Equal(t, entity.PrimaryKey.KeyIdString(), result.SigningKeys.GPGPublicKeys[0].KeyID)
+
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(result.SigningKeys.GPGPublicKeys[0].ASCIIArmor))
+			require.NoError(t, err)
+			_, err = openpgp.CheckDetachedSignature(keyring, strings.NewReader(sums), bytes.NewReader(signature.Bytes()), nil)
+			require.NoError(t, err)
+
+			req = NewRequest(t, "GET", fileURL+"/"+archiveName)
+			resp = MakeRequest(t, req, http.StatusOK)
+			assert.Equal(t, archiveContent, resp.Body.Bytes())
+		})
+
+		t.Run("Delete", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "DELETE", fileURL)
+			MakeRequest(t, req, http.StatusUnauthorized)
+
+			req = NewRequest(t, "DELETE", fileURL).
+				AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNoContent)
+
+			req = NewRequest(t, "GET", registryURL+"/versions")
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/json"
+	"forgejo.org/services/auth/source/ldap"
+	scim_service "forgejo.org/services/scim"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPISCIM(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	require.NoError(t, auth_model.CreateSource(t.Context(), &auth_model.Source{
+		Type:     auth_model.LDAP,
+		Name:     "scim-ldap",
+		IsActive: true,
+		Cfg: &ldap.Source{
+			GroupTeamMap:        `{"developers": {"org26": ["team11"]}}`,
+			GroupTeamMapRemoval: true,
+		},
+	}))
+	source := unittest.AssertExistsAndLoadBean(t, &auth_model.Source{Name: "scim-ldap"})
+	token, err := auth_model.GenerateSCIMToken(t.Context(), source.ID)
+	require.NoError(t, err)
+
+	isTeamMember := func(t *testing.T, userID int64) bool {
+		t.Helper()
+		isMember, err := organization.IsTeamMember(t.Context(), 26, 11, userID)
+		require.NoError(t, err)
+		return isMember
+	}
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", "/api/scim/v2/Users"), http.StatusUnauthorized)
+		MakeRequest(t, NewRequest(t, "GET", "/api/scim/v2/Users").AddTokenAuth("0123456789abcdef"), http.StatusUnauthorized)
+	})
+
+	var user scim_service.User
+	t.Run("CreateUser", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/scim/v2/Users", &scim_service.User{
+			Schemas:  []string{scim_service.SchemaUser},
+			UserName: "scim-user@example.com",
+			Name:     &scim_service.Name{GivenName: "Scim", FamilyName: "User"},
+			Emails:   []*scim_service.MultiValue{{Value: "scim-user@example.com", Primary: true}},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		assert.Equal(t, scim_service.ContentType, resp.Header().Get("Content-Type"))
+		DecodeJSON(t, resp, &user)
+		assert.Equal(t, "scim-user@example.com", user.UserName)
+		require.NotNil(t, user.Active)
+		assert.True(t, *user.Active)
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "scim-user"})
+		assert.Equal(t, fmt.Sprint(u.ID), user.ID)
+		assert.Equal(t, source.ID, u.LoginSource)
+		assert.Equal(t, "Scim User", u.FullName)
+		assert.True(t, u.IsActive)
+
+		// the user already exists
+		req = NewRequestWithJSON(t, "POST", "/api/scim/v2/Users", &scim_service.User{
+			UserName: "scim-user@example.com",
+			Emails:   []*scim_service.MultiValue{{Value: "scim-user@example.com"}},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+	})
+
+	t.Run("ListUsers", func(t *testing.T) {
+		req := NewRequest(t, "GET", `/api/scim/v2/Users?filter=userName+eq+"scim-user@example.com"`).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var list struct {
+			TotalResults int64                `json:"totalResults"`
+			Resources    []*scim_service.User `json:"Resources"`
+		}
+		DecodeJSON(t, resp, &list)
+		assert.EqualValues(t, 1, list.TotalResults)
+		require.Len(t, list.Resources, 1)
+		assert.Equal(t, user.ID, list.Resources[0].ID)
+
+		// users of other sources are not visible
+		req = NewRequest(t, "GET", "/api/scim/v2/Users/1").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	var group scim_service.Group
+	t.Run("CreateGroup", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", "/api/scim/v2/Groups", &scim_service.Group{
+			Schemas:     []string{scim_service.SchemaGroup},
+			DisplayName: "developers",
+			Members:     []*scim_service.MultiValue{{Value: user.ID}},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		DecodeJSON(t, resp, &group)
+		assert.Equal(t, "developers", group.DisplayName)
+		require.Len(t, group.Members, 1)
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "scim-user"})
+		assert.True(t, isTeamMember(t, u.ID))
+
+		// members must be users of the source
+		req = NewRequestWithJSON(t, "POST", "/api/scim/v2/Groups", &scim_service.Group{
+			DisplayName: "admins",
+			Members:     []*scim_service.MultiValue{{Value: "1"}},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+	})
+
+	t.Run("DeactivateUser", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PATCH", "/api/scim/v2/Users/"+user.ID, &scim_service.PatchOp{
+			Schemas:    []string{scim_service.SchemaPatchOp},
+			Operations: []*scim_service.PatchOperation{{Op: "replace", Path: "active", Value: json.RawMessage(`false`)}},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &user)
+		require.NotNil(t, user.Active)
+		assert.False(t, *user.Active)
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "scim-user"})
+		assert.True(t, u.ProhibitLogin)
+	})
+
+	t.Run("RemoveGroupMember", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "PATCH", "/api/scim/v2/Groups/"+group.ID, &scim_service.PatchOp{
+			Schemas:    []string{scim_service.SchemaPatchOp},
+			Operations: []*scim_service.PatchOperation{{Op: "remove", Path: fmt.Sprintf(`members[value eq "%s"]`, user.ID)}},
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		u := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "scim-user"})
+		assert.False(t, isTeamMember(t, u.ID))
+	})
+
+	t.Run("DeleteUser", func(t *testing.T) {
+		req := NewRequest(t, "DELETE", "/api/scim/v2/Users/"+user.ID).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+		unittest.AssertNotExistsBean(t, &user_model.User{Name: "scim-user"})
+
+		req = NewRequest(t, "GET", "/api/scim/v2/Users/"+user.ID).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("RevokedToken", func(t *testing.T) {
+		require.NoError(t, auth_model.DeleteSCIMToken(t.Context(), source.ID))
+		req := NewRequest(t, "GET", "/api/scim/v2/Groups").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnauthorized)
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/db"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/timeutil"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIRestrictedToken(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	expiresAt := time.Now().Add(24 * time.Hour)
+	scopes := []string{"write:repository", "read:organization"}
+
+	createToken := func(t *testing.T, option api.CreateAccessTokenOption, expectedStatus int) *api.AccessToken {
+		t.Helper()
+		req := NewRequestWithJSON(t, "POST", "/api/v1/users/user2/tokens", option).
+			AddBasicAuth(user.Name)
+		resp := MakeRequest(t, req, expectedStatus)
+		if expectedStatus != http.StatusCreated {
+			return nil
+		}
+		var token *api.AccessToken
+		DecodeJSON(t, resp, &token)
+		return token
+	}
+
+	t.Run("Validation", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		createToken(t, api.CreateAccessTokenOption{Name: "no-expiry", Scopes: scopes, Repositories: []string{"user2/repo2"}}, http.StatusUnprocessableEntity)
+		createToken(t, api.CreateAccessTokenOption{Name: "unknown-repo", Scopes: scopes, Repositories: []string{"user2/unknown"}, ExpiresAt: &expiresAt}, http.StatusUnprocessableEntity)
+		createToken(t, api.CreateAccessTokenOption{Name: "no-access", Scopes: scopes, Repositories: []string{"user20/big_test_private_mirror_5"}, ExpiresAt: &expiresAt}, http.StatusUnprocessableEntity)
+		createToken(t, api.CreateAccessTokenOption{Name: "not-member", Scopes: scopes, Organization: "org6", ExpiresAt: &expiresAt}, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("Repositories", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := createToken(t, api.CreateAccessTokenOption{Name: "repo-token", Scopes: scopes, Repositories: []string{"user2/repo2"}, ExpiresAt: &expiresAt}, http.StatusCreated)
+		assert.Equal(t, []string{"user2/repo2"}, token.Repositories)
+		assert.NotNil(t, token.ExpiresAt)
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo2").AddTokenAuth(token.Token), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token.Token), http.StatusForbidden)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/user/repos").AddTokenAuth(token.Token), http.StatusForbidden)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org3/repos").AddTokenAuth(token.Token), http.StatusForbidden)
+	})
+
+	t.Run("Organization", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := createToken(t, api.CreateAccessTokenOption{Name: "org-token", Scopes: scopes, Organization: "org3", ExpiresAt: &expiresAt}, http.StatusCreated)
+		assert.Equal(t, "org3", token.Organization)
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/org3/repo3").AddTokenAuth(token.Token), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org3/repos").AddTokenAuth(token.Token), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token.Token), http.StatusForbidden)
+	})
+
+	t.Run("Packages", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		packageScopes := []string{"write:package"}
+		orgToken := createToken(t, api.CreateAccessTokenOption{Name: "org-package-token", Scopes: packageScopes, Organization: "org3", ExpiresAt: &expiresAt}, http.StatusCreated)
+		repoToken := createToken(t, api.CreateAccessTokenOption{Name: "repo-package-token", Scopes: packageScopes, Repositories: []string{"user2/repo2"}, ExpiresAt: &expiresAt}, http.StatusCreated)
+
+		upload := func(owner, token string, expectedStatus int) {
+			t.Helper()
+			req := NewRequestWithBody(t, "PUT", "/api/packages/"+owner+"/generic/restricted/1.0.0/file.bin", strings.NewReader("content")).
+				AddTokenAuth(token)
+			MakeRequest(t, req, expectedStatus)
+		}
+		upload("org3", orgToken.Token, http.StatusCreated)
+		upload("user2", orgToken.Token, http.StatusForbidden)
+		// Packages don't belong to repositories.
+		upload("user2", repoToken.Token, http.StatusForbidden)
+		upload("org3", repoToken.Token, http.StatusForbidden)
+
+		// The package API checks the owner as well.
+		req := NewRequestWithBody(t, "PUT", "/api/packages/user2/generic/restricted/1.0.0/file.bin", strings.NewReader("content")).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/packages/user2").AddTokenAuth(orgToken.Token), http.StatusForbidden)
+		MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/packages/user2/generic/restricted/1.0.0").AddTokenAuth(orgToken.Token), http.StatusForbidden)
+		MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/packages/user2/generic/restricted/1.0.0").AddTokenAuth(repoToken.Token), http.StatusForbidden)
+		MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/packages/org3/generic/restricted/1.0.0").AddTokenAuth(repoToken.Token), http.StatusForbidden)
+		MakeRequest(t, NewRequest(t, "DELETE", "/api/v1/packages/org3/generic/restricted/1.0.0").AddTokenAuth(orgToken.Token), http.StatusNoContent)
+	})
+
+	t.Run("User routes", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		userScopes := []string{"write:repository", "write:user"}
+		repoToken := createToken(t, api.CreateAccessTokenOption{Name: "repo-user-token", Scopes: userScopes, Repositories: []string{"user2/repo2"}, ExpiresAt: &expiresAt}, http.StatusCreated)
+		orgToken := createToken(t, api.CreateAccessTokenOption{Name: "org-user-token", Scopes: userScopes, Organization: "org3", ExpiresAt: &expiresAt}, http.StatusCreated)
+
+		for _, token := range []string{repoToken.Token, orgToken.Token} {
+			// A new SSH key would give access to all the repositories of the user.
+			req := NewRequestWithJSON(t, "POST", "/api/v1/user/keys", &api.CreateKeyOption{
+				Title: "restricted",
+				Key:   "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKLIBmjsOoKxtyKIVo4xMIrmq2v8qa5I2Hyj1zK6ibLH restricted",
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusForbidden)
+			MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token), http.StatusForbidden)
+		}
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo2").AddTokenAuth(repoToken.Token), http.StatusOK)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := createToken(t, api.CreateAccessTokenOption{Name: "expiring-token", Scopes: scopes, ExpiresAt: &expiresAt}, http.StatusCreated)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1").AddTokenAuth(token.Token), http.StatusOK)
+
+		_, err := db.GetEngine(db.DefaultContext).ID(token.ID).Cols("expires_unix").Update(&auth_model.AccessToken{ExpiresUnix: timeutil.TimeStampNow().Add(-60)})
+		assert.NoError(t, err)
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token.Token), http.StatusUnauthorized)
+	})
+}
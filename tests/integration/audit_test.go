@@ -0,0 +1,82 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/translation"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	adminSession := loginUser(t, "user1")
+	adminToken := getTokenForLoggedInUser(t, adminSession, auth_model.AccessTokenScopeReadAdmin)
+
+	t.Run("Failed sign-in", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		testLoginFailed(t, "user15", "wrongPassword", translation.NewLocale("en-US").TrString("form.username_password_incorrect"))
+
+		req := NewRequest(t, "GET", "/api/v1/admin/audit?action=user.login_failed").AddTokenAuth(adminToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var events []*api.AuditEvent
+		DecodeJSON(t, resp, &events)
+		require.Len(t, events, 1)
+		assert.Equal(t, "user.login_failed", events[0].Action)
+		assert.Equal(t, "user15", events[0].TargetName)
+		assert.Equal(t, "password", events[0].After["reason"])
+		assert.NotEmpty(t, events[0].IPAddress)
+	})
+
+	t.Run("Repository visibility", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, "user2")
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWriteRepository)
+		private := true
+		req := NewRequestWithJSON(t, "PATCH", "/api/v1/repos/user2/repo1", &api.EditRepoOption{
+			Private: &private,
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", "/api/v1/repos/user2/repo1/audit").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		var events []*api.AuditEvent
+		DecodeJSON(t, resp, &events)
+		require.Len(t, events, 1)
+		assert.Equal(t, "repository.visibility", events[0].Action)
+		assert.Equal(t, "user2", events[0].ActorName)
+		assert.Equal(t, false, events[0].Before["is_private"])
+		assert.Equal(t, true, events[0].After["is_private"])
+
+		// the event shows up in the repository settings
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/settings/audit"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		htmlDoc.AssertElement(t, "table.audit-log tbody tr td pre", true)
+	})
+
+	t.Run("Permissions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, "user2")
+		token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeReadAdmin, auth_model.AccessTokenScopeReadOrganization)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/admin/audit").AddTokenAuth(token), http.StatusForbidden)
+		session.MakeRequest(t, NewRequest(t, "GET", "/admin/audit"), http.StatusForbidden)
+
+		// user2 owns org3, but is not a member of org19
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org3/audit").AddTokenAuth(token), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org19/audit").AddTokenAuth(token), http.StatusForbidden)
+
+		adminSession.MakeRequest(t, NewRequest(t, "GET", "/admin/audit"), http.StatusOK)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	automation_model "forgejo.org/models/automation"
+	"forgejo.org/models/unittest"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutomationRules(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+
+	t.Run("Repository", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		link := "/user2/repo1/settings/automation"
+		session.MakeRequest(t, NewRequest(t, "GET", link+"/new"), http.StatusOK)
+
+		req := NewRequestWithValues(t, "POST", link+"/new", map[string]string{
+			"name":       "Triage bugs",
+			"event":      string(automation_model.EventIssueOpened),
+			"is_active":  "on",
+			"issue_type": "issues",
+			"labels":     "bug",
+			"add_labels": "label1, label2",
+			"assignees":  "user2",
+			"comment":    "Thanks for the report",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		rule := unittest.AssertExistsAndLoadBean(t, &automation_model.Rule{RepoID: 1, Name: "Triage bugs"})
+		assert.True(t, rule.IsActive)
+		assert.Equal(t, "issues", rule.Conditions.IssueType())
+		assert.Equal(t, []string{"bug"}, rule.Conditions.Labels)
+		assert.Equal(t, []string{"label1", "label2"}, rule.Actions.AddLabels)
+		assert.Equal(t, []string{"user2"}, rule.Actions.Assignees)
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 1, htmlDoc.Find("table.automation-rules tbody tr a").Length())
+
+		ruleLink := fmt.Sprintf("%s/%d", link, rule.ID)
+		session.MakeRequest(t, NewRequest(t, "GET", ruleLink), http.StatusOK)
+
+		// a stale rule needs a number of inactive days
+		req = NewRequestWithValues(t, "POST", ruleLink, map[string]string{
+			"name":  "Triage bugs",
+			"event": string(automation_model.EventIssueStale),
+			"close": "on",
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		rule = unittest.AssertExistsAndLoadBean(t, &automation_model.Rule{ID: rule.ID})
+		assert.Equal(t, automation_model.EventIssueOpened, rule.Event)
+
+		req = NewRequestWithValues(t, "POST", ruleLink, map[string]string{
+			"name":          "Close stale issues",
+			"event":         string(automation_model.EventIssueStale),
+			"inactive_days": "30",
+			"close":         "on",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		rule = unittest.AssertExistsAndLoadBean(t, &automation_model.Rule{ID: rule.ID})
+		assert.Equal(t, "Close stale issues", rule.Name)
+		assert.Equal(t, automation_model.EventIssueStale, rule.Event)
+		assert.False(t, rule.IsActive)
+		assert.Equal(t, 30, rule.Conditions.InactiveDays)
+		assert.True(t, rule.Actions.Close)
+		assert.Empty(t, rule.Actions.AddLabels)
+
+		req = NewRequest(t, "POST", ruleLink+"/delete")
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertNotExistsBean(t, &automation_model.Rule{ID: rule.ID})
+	})
+
+	t.Run("Organization", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		link := "/org/org3/settings/automation"
+		req := NewRequestWithValues(t, "POST", link+"/new", map[string]string{
+			"name":      "Review pull requests",
+			"event":     string(automation_model.EventPullRequestOpened),
+			"is_active": "on",
+			"paths":     "docs/**\n*.md",
+			"reviewers": "user2",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		rule := unittest.AssertExistsAndLoadBean(t, &automation_model.Rule{OwnerID: 3, Name: "Review pull requests"})
+		assert.Equal(t, []string{"docs/**", "*.md"}, rule.Conditions.Paths)
+
+		// the rules of an organization are not the rules of its repositories
+		session.MakeRequest(t, NewRequest(t, "GET", fmt.Sprintf("/org3/repo3/settings/automation/%d", rule.ID)), http.StatusNotFound)
+
+		// only the owners of the organization manage its rules
+		other := loginUser(t, "user4")
+		other.MakeRequest(t, NewRequest(t, "GET", link), http.StatusNotFound)
+	})
+}
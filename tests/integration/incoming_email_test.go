@@ -5,21 +5,30 @@ package integration
 
 import (
 	"encoding/base32"
+	"fmt"
 	"io"
 	"net"
+	"net/http"
+	net_mail "net/mail"
 	"net/smtp"
 	"strings"
 	"testing"
 	"time"
 
+	auth_model "forgejo.org/models/auth"
 	"forgejo.org/models/db"
 	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unit"
 	"forgejo.org/models/unittest"
 	user_model "forgejo.org/models/user"
 	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
 	"forgejo.org/services/mailer/incoming"
 	incoming_payload "forgejo.org/services/mailer/incoming/payload"
 	token_service "forgejo.org/services/mailer/token"
+	repo_service "forgejo.org/services/repository"
 	"forgejo.org/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -250,6 +259,85 @@ func TestIncomingEmail(t *testing.T) {
 	}
 }
 
+func TestIncomingEmailServiceDesk(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.IncomingEmail.Enabled, true)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	handler := &incoming.ServiceDeskHandler{}
+	from := &net_mail.Address{Name: "Customer", Address: "customer@example.com"}
+	newMail := func(content string) *incoming.ServiceDeskMail {
+		return &incoming.ServiceDeskMail{
+			From:    from,
+			Subject: "Printer on fire",
+			Content: &incoming.MailContent{
+				Content:     content,
+				Attachments: []*incoming.Attachment{{Name: "photo.txt", Content: []byte("test")}},
+			},
+		}
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		require.NoError(t, handler.Handle(db.DefaultContext, newMail("help"), "user2", "repo1", ""))
+		unittest.AssertNotExistsBean(t, &issues_model.Issue{RepoID: repo.ID, Title: "Printer on fire"})
+	})
+
+	require.NoError(t, repo_service.UpdateRepositoryUnits(db.DefaultContext, repo, []repo_model.RepoUnit{{
+		RepoID: repo.ID,
+		Type:   unit.TypeIssues,
+		Config: &repo_model.IssuesConfig{EnableServiceDesk: true},
+	}}, nil))
+
+	var issue *issues_model.Issue
+	t.Run("New issue", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		require.NoError(t, handler.Handle(db.DefaultContext, newMail("help"), "user2", "repo1", ""))
+		issue = unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Title: "Printer on fire"})
+		assert.True(t, issue.IsConfidential)
+		assert.Equal(t, user_model.GhostUserID, issue.PosterID)
+		assert.Equal(t, "Customer <customer@example.com>", issue.OriginalAuthor)
+		assert.Equal(t, "help", issue.Content)
+		require.NoError(t, issue.LoadAttachments(db.DefaultContext))
+		assert.Len(t, issue.Attachments, 1)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.ServiceDeskContact{IssueID: issue.ID, Email: "customer@example.com"})
+
+		// only users with write access can see the issue
+		link := fmt.Sprintf("/user2/repo1/issues/%d", issue.Index)
+		loginUser(t, "user2").MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+		loginUser(t, "user5").MakeRequest(t, NewRequest(t, "GET", link), http.StatusNotFound)
+
+		// the issue can't be made public, it would expose the contact
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteIssue)
+		public := false
+		req := NewRequestWithJSON(t, "PATCH", fmt.Sprintf("/api/v1/repos/user2/repo1/issues/%d", issue.Index), &api.EditIssueOption{
+			Confidential: &public,
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+		assert.True(t, unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: issue.ID}).IsConfidential)
+	})
+
+	t.Run("Reply", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		contact := unittest.AssertExistsAndLoadBean(t, &issues_model.ServiceDeskContact{IssueID: issue.ID})
+		require.NoError(t, handler.Handle(db.DefaultContext, newMail("still burning"), "user2", "repo1", strings.ToUpper(contact.Key)))
+		comment := unittest.AssertExistsAndLoadBean(t, &issues_model.Comment{IssueID: issue.ID, Content: "still burning"})
+		assert.Equal(t, user_model.GhostUserID, comment.PosterID)
+		assert.Equal(t, "Customer <customer@example.com>", comment.OriginalAuthor)
+		require.NoError(t, comment.LoadAttachments(db.DefaultContext))
+		assert.Len(t, comment.Attachments, 1)
+
+		// the key of a contact only replies to its issue in its repository
+		require.NoError(t, handler.Handle(db.DefaultContext, newMail("wrong key"), "user2", "repo1", "0123abcd"))
+		require.NoError(t, handler.Handle(db.DefaultContext, newMail("wrong repo"), "user2", "repo2", contact.Key))
+		unittest.AssertNotExistsBean(t, &issues_model.Comment{IssueID: issue.ID, Content: "wrong key"})
+		unittest.AssertNotExistsBean(t, &issues_model.Comment{IssueID: issue.ID, Content: "wrong repo"})
+	})
+}
+
 // A simple SMTP mail sender used for integration tests.
 type smtpTestSender struct{}
 
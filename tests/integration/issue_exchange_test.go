@@ -0,0 +1,89 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	issues_model "forgejo.org/models/issues"
+	"forgejo.org/models/unittest"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueExportImport(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+
+	t.Run("Export", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		link, _ := htmlDoc.Find(".issue-list-export .menu a.item").First().Attr("href")
+		assert.Contains(t, link, "/user2/repo1/issues/export?")
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/export?state=all&format=csv"), http.StatusOK)
+		assert.Equal(t, "text/csv", resp.Header().Get("Content-Type"))
+		body := resp.Body.String()
+		assert.True(t, strings.HasPrefix(body, "index,title,state,poster,milestone,labels,assignees"))
+		assert.Contains(t, body, "issue1")
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/pulls/export?state=all&format=json"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), `"title": "issue2"`)
+
+		resp = session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/export?q=nothingmatches&format=json"), http.StatusOK)
+		assert.Equal(t, "[]\n", resp.Body.String())
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/import"), http.StatusOK)
+
+		content := "index,title,labels\n1,issue1 renamed,label2\n,Imported issue,label1\n"
+		req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/import", map[string]string{
+			"content": content,
+			"dry_run": "on",
+		})
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		assert.Equal(t, 2, htmlDoc.Find("table.issue-import-preview tbody tr").Length())
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, Title: "issue1"})
+
+		// the preview is confirmed without a dry run
+		assert.Equal(t, 1, htmlDoc.Find("form textarea[name=content]").Length())
+		req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/import", map[string]string{
+			"content": content,
+			"format":  "csv",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{ID: 1, Title: "issue1 renamed"})
+		unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: 1, Title: "Imported issue", PosterID: 2})
+
+		// an invalid file is rejected
+		req = NewRequestWithValues(t, "POST", "/user2/repo1/issues/import", map[string]string{
+			"content": "index,name\n1,x\n",
+		})
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "unknown column")
+	})
+
+	t.Run("Permissions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// user5 can read repo1 but not write its issues
+		other := loginUser(t, "user5")
+		other.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/export"), http.StatusOK)
+		other.MakeRequest(t, NewRequest(t, "GET", "/user2/repo1/issues/import"), http.StatusNotFound)
+		req := NewRequestWithValues(t, "POST", "/user2/repo1/issues/import", map[string]string{
+			"content": "title\nspam\n",
+		})
+		other.MakeRequest(t, req, http.StatusNotFound)
+	})
+}
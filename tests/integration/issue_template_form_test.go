@@ -0,0 +1,156 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	issues_model "forgejo.org/models/issues"
+	project_model "forgejo.org/models/project"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueTemplateForm(t *testing.T) {
+	onApplicationRun(t, func(t *testing.T, u *url.URL) {
+		repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+		user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+		templateFile := ".forgejo/issue_template/bug.yaml"
+		defer deleteFileInBranch(user, repo, templateFile, repo.DefaultBranch)
+		require.NoError(t, createOrReplaceFileInBranch(user, repo, templateFile, repo.DefaultBranch, `name: Bug
+about: Report a bug
+labels: [label2]
+assignees: [user2]
+milestone: milestone1
+project: First project
+body:
+  - type: input
+    id: version
+    attributes:
+      label: Version
+    validations:
+      required: true
+      regex: "[0-9]+\\.[0-9]+"
+  - type: dropdown
+    id: kind
+    attributes:
+      label: Kind
+      options:
+        - Bug
+        - Crash
+    validations:
+      required: true
+  - type: textarea
+    id: trace
+    attributes:
+      label: Stack trace
+    validations:
+      required: true
+    show_if:
+      id: kind
+      values: [Crash]
+`))
+
+		t.Run("API", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+			token := getUserToken(t, "user5", auth_model.AccessTokenScopeWriteIssue)
+			link := fmt.Sprintf("/api/v1/repos/%s/issues", repo.FullName())
+
+			for _, fields := range []map[string]string{
+				{"kind": "0"},
+				{"version": "v1", "kind": "0"},
+				{"version": "1.2", "kind": "2"},
+				{"version": "1.2", "kind": "1"},
+			} {
+				req := NewRequestWithJSON(t, "POST", link, &api.CreateIssueOption{
+					Title:          "invalid form",
+					Template:       templateFile,
+					TemplateFields: fields,
+				}).AddTokenAuth(token)
+				MakeRequest(t, req, http.StatusUnprocessableEntity)
+			}
+
+			req := NewRequestWithJSON(t, "POST", link, &api.CreateIssueOption{
+				Title:    "unknown template",
+				Template: ".forgejo/issue_template/unknown.yaml",
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+			// the template sets the labels, assignees, milestone and project even for a reader
+			req = NewRequestWithJSON(t, "POST", link, &api.CreateIssueOption{
+				Title:          "crash",
+				Template:       templateFile,
+				TemplateFields: map[string]string{"version": "1.2", "kind": "1", "trace": "panic: nil map"},
+			}).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusCreated)
+			var apiIssue api.Issue
+			DecodeJSON(t, resp, &apiIssue)
+			assert.Contains(t, apiIssue.Body, "### Version\n\n1.2")
+			assert.Contains(t, apiIssue.Body, "### Kind\n\nCrash")
+			assert.Contains(t, apiIssue.Body, "### Stack trace\n\npanic: nil map")
+			require.Len(t, apiIssue.Labels, 1)
+			assert.EqualValues(t, 2, apiIssue.Labels[0].ID)
+			require.Len(t, apiIssue.Assignees, 1)
+			assert.Equal(t, "user2", apiIssue.Assignees[0].UserName)
+			require.NotNil(t, apiIssue.Milestone)
+			assert.EqualValues(t, 1, apiIssue.Milestone.ID)
+			unittest.AssertExistsAndLoadBean(t, &project_model.ProjectIssue{IssueID: apiIssue.ID, ProjectID: 1})
+
+			// the hidden stack trace is neither required nor rendered
+			req = NewRequestWithJSON(t, "POST", link, &api.CreateIssueOption{
+				Title:          "bug",
+				Template:       templateFile,
+				TemplateFields: map[string]string{"version": "1.2", "kind": "0", "trace": "ignored"},
+			}).AddTokenAuth(token)
+			resp = MakeRequest(t, req, http.StatusCreated)
+			DecodeJSON(t, resp, &apiIssue)
+			assert.NotContains(t, apiIssue.Body, "Stack trace")
+		})
+
+		t.Run("Web", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+			session := loginUser(t, user.Name)
+			link := fmt.Sprintf("/%s/issues/new", repo.FullName())
+
+			req := NewRequest(t, "GET", link+"?template="+url.QueryEscape(templateFile))
+			htmlDoc := NewHTMLParser(t, session.MakeRequest(t, req, http.StatusOK).Body)
+			htmlDoc.AssertElement(t, "#new-issue input[name='template-file']", true)
+			htmlDoc.AssertElement(t, "#new-issue .field[data-show-if='kind'] textarea[name='form-field-trace']", true)
+			assert.Equal(t, "1", htmlDoc.Find("#milestone_id").AttrOr("value", ""))
+			assert.Equal(t, "1", htmlDoc.Find("#project_id").AttrOr("value", ""))
+
+			req = NewRequestWithValues(t, "POST", link, map[string]string{
+				"title":              "invalid form",
+				"template-file":      templateFile,
+				"form-field-version": "1.2",
+				"form-field-kind":    "1",
+			})
+			resp := session.MakeRequest(t, req, http.StatusBadRequest)
+			assert.Contains(t, resp.Body.String(), "Stack trace: is required")
+			unittest.AssertNotExistsBean(t, &issues_model.Issue{RepoID: repo.ID, Title: "invalid form"})
+
+			req = NewRequestWithValues(t, "POST", link, map[string]string{
+				"title":              "web crash",
+				"template-file":      templateFile,
+				"form-field-version": "1.2",
+				"form-field-kind":    "1",
+				"form-field-trace":   "panic: nil map",
+			})
+			session.MakeRequest(t, req, http.StatusOK)
+			issue := unittest.AssertExistsAndLoadBean(t, &issues_model.Issue{RepoID: repo.ID, Title: "web crash"})
+			assert.Contains(t, issue.Content, "### Stack trace\n\npanic: nil map")
+			unittest.AssertExistsAndLoadBean(t, &issues_model.IssueAssignees{IssueID: issue.ID, AssigneeID: user.ID})
+		})
+	})
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+	"forgejo.org/routers/web/auth"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthDeviceAuthorization(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	// the public "Test native app"
+	const clientID = "ce5a1322-42a7-11ed-b878-0242ac120002"
+
+	authorizeDevice := func(t *testing.T) *auth.DeviceAuthorizationResponse {
+		t.Helper()
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device_authorization", map[string]string{
+			"client_id": clientID,
+			"scope":     "read:user",
+		})
+		resp := MakeRequest(t, req, http.StatusOK)
+		device := new(auth.DeviceAuthorizationResponse)
+		DecodeJSON(t, resp, device)
+		assert.NotEmpty(t, device.DeviceCode)
+		assert.Equal(t, setting.AppURL+"login/oauth/device", device.VerificationURI)
+		return device
+	}
+
+	poll := func(t *testing.T, deviceCode string, expectedStatus int) *tokenEndpointResponse {
+		t.Helper()
+		req := NewRequestWithValues(t, "POST", "/login/oauth/access_token", map[string]string{
+			"grant_type":  "urn:ietf:params:oauth:grant-type:device_code",
+			"client_id":   clientID,
+			"device_code": deviceCode,
+		})
+		resp := MakeRequest(t, req, expectedStatus)
+		parsed := new(tokenEndpointResponse)
+		DecodeJSON(t, resp, parsed)
+		return parsed
+	}
+
+	session := loginUser(t, "user2")
+
+	t.Run("Grant", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.OAuth2.DeviceCodePollingInterval, 0)()
+
+		device := authorizeDevice(t)
+		assert.Equal(t, "authorization_pending", poll(t, device.DeviceCode, http.StatusBadRequest).Error)
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", device.VerificationURIComplete), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), device.UserCode)
+
+		// the user code is case and separator insensitive
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device", map[string]string{
+			"user_code": "  " + device.UserCode[:4] + device.UserCode[5:] + " ",
+		})
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "authorize-device")
+
+		req = NewRequestWithValues(t, "POST", "/login/oauth/device/grant", map[string]string{
+			"user_code": device.UserCode,
+			"granted":   "true",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		unittest.AssertExistsAndLoadBean(t, &auth_model.OAuth2Grant{UserID: 2, ApplicationID: 2, Scope: "read:user"})
+
+		token := poll(t, device.DeviceCode, http.StatusOK)
+		require.NotEmpty(t, token.AccessToken)
+		assert.NotEmpty(t, token.RefreshToken)
+
+		req = NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token.AccessToken)
+		var user api.User
+		DecodeJSON(t, MakeRequest(t, req, http.StatusOK), &user)
+		assert.Equal(t, "user2", user.UserName)
+
+		// the device code can only be used once
+		assert.Equal(t, "invalid_grant", poll(t, device.DeviceCode, http.StatusBadRequest).Error)
+
+		t.Run("Revoke", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			// other clients can't revoke the token
+			req := NewRequestWithValues(t, "POST", "/login/oauth/revoke", map[string]string{
+				"token":         token.AccessToken,
+				"client_id":     "da7da3ba-9a13-4167-856f-3899de0b0138",
+				"client_secret": "4MK8Na6R55smdCY0WuCCumZ6hjRPnGY5saWVRHHjJiA=",
+			})
+			MakeRequest(t, req, http.StatusOK)
+			MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token.AccessToken), http.StatusOK)
+
+			req = NewRequestWithValues(t, "POST", "/login/oauth/revoke", map[string]string{
+				"token":           token.RefreshToken,
+				"token_type_hint": "refresh_token",
+				"client_id":       clientID,
+			})
+			MakeRequest(t, req, http.StatusOK)
+			unittest.AssertNotExistsBean(t, &auth_model.OAuth2Grant{UserID: 2, ApplicationID: 2})
+
+			// the access token of the revoked grant is rejected too
+			MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token.AccessToken), http.StatusUnauthorized)
+
+			// invalid tokens are not an error
+			req = NewRequestWithValues(t, "POST", "/login/oauth/revoke", map[string]string{
+				"token":     "xyzzy",
+				"client_id": clientID,
+			})
+			MakeRequest(t, req, http.StatusOK)
+
+			req = NewRequestWithValues(t, "POST", "/login/oauth/revoke", map[string]string{
+				"token":     "xyzzy",
+				"client_id": "unknown",
+			})
+			MakeRequest(t, req, http.StatusUnauthorized)
+		})
+	})
+
+	t.Run("Deny", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.OAuth2.DeviceCodePollingInterval, 0)()
+
+		device := authorizeDevice(t)
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device/grant", map[string]string{
+			"user_code": device.UserCode,
+			"granted":   "false",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+
+		assert.Equal(t, "access_denied", poll(t, device.DeviceCode, http.StatusBadRequest).Error)
+		unittest.AssertNotExistsBean(t, &auth_model.OAuth2Grant{UserID: 2, ApplicationID: 2})
+	})
+
+	t.Run("Slow down", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		device := authorizeDevice(t)
+		assert.EqualValues(t, 5, device.Interval)
+		assert.Equal(t, "authorization_pending", poll(t, device.DeviceCode, http.StatusBadRequest).Error)
+		assert.Equal(t, "slow_down", poll(t, device.DeviceCode, http.StatusBadRequest).Error)
+	})
+
+	t.Run("Invalid user code", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device", map[string]string{
+			"user_code": "BCDF-GHJK",
+		})
+		resp := session.MakeRequest(t, req, http.StatusOK)
+		assert.NotContains(t, resp.Body.String(), "authorize-device")
+	})
+
+	t.Run("Invalid client", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithValues(t, "POST", "/login/oauth/device_authorization", map[string]string{
+			"client_id": "da7da3ba-9a13-4167-856f-3899de0b0138",
+		})
+		resp := MakeRequest(t, req, http.StatusBadRequest)
+		assert.Contains(t, resp.Body.String(), "invalid_client")
+	})
+}
+
+// tokenEndpointResponse is the union of the token and error responses of the token endpoint
+type tokenEndpointResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	Error        string `json:"error"`
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/organization"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/optional"
+	user_service "forgejo.org/services/user"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrgRequirePhishingResistantAuth(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	org := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "org3"})
+	require.NoError(t, user_service.UpdateUser(t.Context(), org, &user_service.UpdateOptions{
+		RequirePhishingResistantAuth: optional.Some(true),
+	}))
+
+	t.Run("Member", func(t *testing.T) {
+		session := loginUser(t, "user4")
+
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusForbidden)
+		assert.Contains(t, resp.Body.String(), "/user/settings/security")
+		session.MakeRequest(t, NewRequest(t, "GET", "/org3/repo3"), http.StatusForbidden)
+
+		// the member can still register a passkey
+		session.MakeRequest(t, NewRequest(t, "GET", "/user/settings/security"), http.StatusOK)
+	})
+
+	t.Run("NonMember", func(t *testing.T) {
+		session := loginUser(t, "user5")
+		session.MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusOK)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		session := loginUser(t, "user1")
+		session.MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusOK)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		require.NoError(t, user_service.UpdateUser(t.Context(), org, &user_service.UpdateOptions{
+			RequirePhishingResistantAuth: optional.Some(false),
+		}))
+		session := loginUser(t, "user4")
+		session.MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusOK)
+	})
+}
+
+func TestOrgSecurityPolicy(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	t.Run("Settings", func(t *testing.T) {
+		session := loginUser(t, "user2")
+
+		// the owner would be locked out by an IP allowlist without their address
+		req := NewRequestWithValues(t, "POST", "/org/org3/settings", map[string]string{
+			"name":         "org3",
+			"visibility":   "0",
+			"ip_allowlist": "198.51.100.0/24",
+		})
+		session.MakeRequest(t, req, http.StatusOK)
+		unittest.AssertNotExistsBean(t, &organization.OrgSecurityPolicy{OrgID: 3})
+
+		req = NewRequestWithValues(t, "POST", "/org/org3/settings", map[string]string{
+			"name":             "org3",
+			"visibility":       "0",
+			"ssh_key_types":    "rsa",
+			"min_rsa_key_size": "2048",
+		})
+		session.MakeRequest(t, req, http.StatusSeeOther)
+		policy := unittest.AssertExistsAndLoadBean(t, &organization.OrgSecurityPolicy{OrgID: 3})
+		assert.Equal(t, []string{"rsa"}, policy.SSHKeyTypes)
+		assert.Equal(t, 2048, policy.MinRSAKeySize)
+	})
+
+	require.NoError(t, organization.UpdateOrgSecurityPolicy(t.Context(), &organization.OrgSecurityPolicy{
+		OrgID:            3,
+		RequireTwoFactor: true,
+	}))
+
+	t.Run("TwoFactor", func(t *testing.T) {
+		session := loginUser(t, "user4")
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusForbidden)
+		assert.Contains(t, resp.Body.String(), "/user/settings/security")
+		session.MakeRequest(t, NewRequest(t, "GET", "/org3/repo3"), http.StatusForbidden)
+
+		token := getUserToken(t, "user4", auth_model.AccessTokenScopeReadOrganization, auth_model.AccessTokenScopeReadRepository)
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/orgs/org3").AddTokenAuth(token), http.StatusForbidden)
+		assert.Contains(t, resp.Body.String(), "two-factor authentication")
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/org3/repo3").AddTokenAuth(token), http.StatusForbidden)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", "/org3/repo3.git/info/refs").AddBasicAuth("user4"), http.StatusForbidden)
+		assert.Contains(t, resp.Body.String(), "two-factor authentication")
+
+		// non members and site administrators are not subject to the policy
+		loginUser(t, "user5").MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusOK)
+		loginUser(t, "user1").MakeRequest(t, NewRequest(t, "GET", "/org3"), http.StatusOK)
+	})
+
+	require.NoError(t, organization.UpdateOrgSecurityPolicy(t.Context(), &organization.OrgSecurityPolicy{
+		OrgID:       3,
+		IPAllowlist: []string{"192.0.2.0/24"},
+	}))
+
+	t.Run("IPAllowlist", func(t *testing.T) {
+		session := loginUser(t, "user4")
+
+		req := NewRequest(t, "GET", "/org3")
+		req.RemoteAddr = "192.0.2.7:12345"
+		session.MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", "/org3")
+		req.RemoteAddr = "198.51.100.7:12345"
+		resp := session.MakeRequest(t, req, http.StatusForbidden)
+		assert.Contains(t, resp.Body.String(), "198.51.100.7")
+
+		req = NewRequest(t, "GET", "/org3/repo3.git/info/refs").AddBasicAuth("user4")
+		req.RemoteAddr = "198.51.100.7:12345"
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	project_model "forgejo.org/models/project"
 	repo_model "forgejo.org/models/repo"
 	"forgejo.org/models/unittest"
+	"forgejo.org/modules/test"
 	"forgejo.org/tests"
 
 	"github.com/stretchr/testify/assert"
@@ -78,3 +79,44 @@ func TestMoveRepoProjectColumns(t *testing.T) {
 
 	require.NoError(t, project_model.DeleteProjectByID(db.DefaultContext, project1.ID))
 }
+
+func TestProjectViews(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	sess := loginUser(t, "user2")
+	req := NewRequestWithValues(t, "POST", "/user2/repo1/projects/1/views/new", map[string]string{
+		"name":     "By column",
+		"type":     "table",
+		"fields":   "assignees",
+		"group_by": "column",
+		"sort_by":  "title",
+	})
+	resp := sess.MakeRequest(t, req, http.StatusSeeOther)
+	view := unittest.AssertExistsAndLoadBean(t, &project_model.View{ProjectID: 1, Name: "By column"})
+	assert.Equal(t, fmt.Sprintf("/user2/repo1/projects/1/views/%d", view.ID), test.RedirectURL(resp))
+	assert.Equal(t, []string{"assignees"}, view.Fields)
+
+	req = NewRequest(t, "GET", fmt.Sprintf("/user2/repo1/projects/1/views/%d", view.ID))
+	resp = sess.MakeRequest(t, req, http.StatusOK)
+	htmlDoc := NewHTMLParser(t, resp.Body)
+	htmlDoc.AssertElement(t, "#project-view table", true)
+	htmlDoc.AssertElement(t, "#project-board", false)
+
+	// the other users can see the view but not change it
+	req = NewRequest(t, "GET", fmt.Sprintf("/user2/repo1/projects/1/views/%d", view.ID))
+	loginUser(t, "user5").MakeRequest(t, req, http.StatusOK)
+	req = NewRequest(t, "POST", fmt.Sprintf("/user2/repo1/projects/1/views/%d/delete", view.ID))
+	loginUser(t, "user5").MakeRequest(t, req, http.StatusNotFound)
+
+	req = NewRequestWithValues(t, "POST", "/user2/repo1/projects/1/views/new", map[string]string{
+		"name":       "Roadmap",
+		"type":       "roadmap",
+		"date_field": "created",
+	})
+	sess.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &project_model.View{ProjectID: 1, Name: "Roadmap"})
+
+	req = NewRequest(t, "POST", fmt.Sprintf("/user2/repo1/projects/1/views/%d/delete", view.ID))
+	sess.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &project_model.View{ID: view.ID})
+}
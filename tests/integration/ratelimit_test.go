@@ -0,0 +1,109 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.RateLimit.Enabled, true)()
+
+	bucket := setting.RateLimitBucket{
+		Enabled:        true,
+		Limit:          2,
+		Burst:          2,
+		AnonymousLimit: 1,
+		AnonymousBurst: 1,
+		Period:         time.Hour,
+	}
+	defer test.MockVariableValue(&setting.RateLimit.API, bucket)()
+	defer test.MockVariableValue(&setting.RateLimit.Git, bucket)()
+	defer test.MockVariableValue(&setting.RateLimit.Packages, bucket)()
+
+	t.Run("API token", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeReadUser)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token), http.StatusOK)
+		assert.Equal(t, "2", resp.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, "1", resp.Header().Get("X-RateLimit-Remaining"))
+		assert.NotEmpty(t, resp.Header().Get("X-RateLimit-Reset"))
+		assert.Equal(t, "api", resp.Header().Get("X-RateLimit-Resource"))
+
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token), http.StatusOK)
+		assert.Equal(t, "0", resp.Header().Get("X-RateLimit-Remaining"))
+
+		resp = MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token), http.StatusTooManyRequests)
+		assert.Equal(t, "0", resp.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "1800", resp.Header().Get("Retry-After"))
+
+		// Every token has its own bucket.
+		otherToken := getUserToken(t, "user2", auth_model.AccessTokenScopeReadUser)
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(otherToken), http.StatusOK)
+
+		t.Run("Exempt", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+			exempt := true
+			req := NewRequestWithJSON(t, "PATCH", "/api/v1/admin/users/user2", api.EditUserOption{
+				RateLimitExempt: &exempt,
+			}).AddTokenAuth(adminToken)
+			MakeRequest(t, req, http.StatusOK)
+			unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "user2", IsRateLimitExempt: true})
+
+			resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/user").AddTokenAuth(token), http.StatusOK)
+			assert.Empty(t, resp.Header().Get("X-RateLimit-Remaining"))
+		})
+	})
+
+	t.Run("API anonymous", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/version"), http.StatusOK)
+		assert.Equal(t, "1", resp.Header().Get("X-RateLimit-Limit"))
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/version"), http.StatusTooManyRequests)
+	})
+
+	t.Run("Git", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/user2/repo1.git/info/refs"), http.StatusOK)
+		assert.Equal(t, "git", resp.Header().Get("X-RateLimit-Resource"))
+		MakeRequest(t, NewRequest(t, "GET", "/user2/repo1.git/info/refs"), http.StatusTooManyRequests)
+	})
+
+	t.Run("Packages", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		url := "/api/packages/user2/generic/test-package/1.0.0/file.bin"
+		resp := MakeRequest(t, NewRequest(t, "GET", url), NoExpectedStatus)
+		assert.NotEqual(t, http.StatusTooManyRequests, resp.Code)
+		assert.Equal(t, "packages", resp.Header().Get("X-RateLimit-Resource"))
+		MakeRequest(t, NewRequest(t, "GET", url), http.StatusTooManyRequests)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+		defer test.MockVariableValue(&setting.RateLimit.Enabled, false)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/version"), http.StatusOK)
+		assert.Empty(t, resp.Header().Get("X-RateLimit-Limit"))
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"testing"
+
+	advisory_model "forgejo.org/models/advisory"
+	auth_model "forgejo.org/models/auth"
+	issues_model "forgejo.org/models/issues"
+	repo_model "forgejo.org/models/repo"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/cvss"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityAdvisory(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteRepository)
+	readerToken := getUserToken(t, "user5", auth_model.AccessTokenScopeReadRepository)
+
+	req := NewRequestWithValues(t, "POST", "/user2/repo1/security/advisories/new", map[string]string{
+		"title":              "Remote code execution",
+		"description":        "Details of the vulnerability",
+		"severity":           "low",
+		"cvss_vector":        "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+		"cve_id":             "CVE-2026-12345",
+		"affected_ecosystem": "Go",
+		"affected_package":   "example.com/repo1",
+		"affected_versions":  ">= 1.0.0, < 1.2.3",
+		"affected_patched":   "1.2.3",
+		"credit_name":        "user5",
+		"credit_type":        "FINDER",
+	})
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	advisory := unittest.AssertExistsAndLoadBean(t, &advisory_model.Advisory{RepoID: 1, Title: "Remote code execution"})
+	assert.True(t, advisory.IsDraft())
+	// the severity is computed from the CVSS vector
+	assert.Equal(t, cvss.SeverityCritical, advisory.Severity)
+	assert.Equal(t, []advisory_model.Affected{{Ecosystem: "Go", Package: "example.com/repo1", Versions: ">= 1.0.0, < 1.2.3", Patched: "1.2.3"}}, advisory.Affected)
+	assert.Equal(t, []advisory_model.Credit{{Name: "user5", Type: "FINDER"}}, advisory.Credits)
+
+	link := "/user2/repo1/security/advisories/" + advisory.Identifier
+	apiLink := "/api/v1/repos/user2/repo1/security-advisories/" + advisory.Identifier
+
+	t.Run("Draft", func(t *testing.T) {
+		MakeRequest(t, NewRequest(t, "GET", apiLink).AddTokenAuth(token), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", apiLink).AddTokenAuth(readerToken), http.StatusNotFound)
+		MakeRequest(t, NewRequest(t, "GET", apiLink+"/osv").AddTokenAuth(token), http.StatusNotFound)
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/repo1/security-advisories").AddTokenAuth(readerToken), http.StatusOK)
+		var advisories []*api.SecurityAdvisory
+		DecodeJSON(t, resp, &advisories)
+		assert.Empty(t, advisories)
+
+		loginUser(t, "user5").MakeRequest(t, NewRequest(t, "GET", link), http.StatusNotFound)
+		session.MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+
+		// only the administrators manage the advisories
+		req := NewRequestWithValues(t, "POST", link+"/fork", map[string]string{})
+		loginUser(t, "user5").MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	var fork *repo_model.Repository
+	t.Run("Fork", func(t *testing.T) {
+		session.MakeRequest(t, NewRequestWithValues(t, "POST", link+"/fork", map[string]string{}), http.StatusSeeOther)
+		advisory = unittest.AssertExistsAndLoadBean(t, &advisory_model.Advisory{ID: advisory.ID})
+		require.NotZero(t, advisory.ForkRepoID)
+		fork = unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: advisory.ForkRepoID})
+		assert.True(t, fork.IsPrivate)
+		assert.Equal(t, int64(2), fork.OwnerID)
+
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/repos/user2/"+fork.Name).AddTokenAuth(readerToken), http.StatusNotFound)
+
+		req := NewRequestWithJSON(t, "POST", fmt.Sprintf("/api/v1/repos/user2/%s/contents/fix.txt", fork.Name), &api.CreateFileOptions{
+			FileOptions:   api.FileOptions{Message: "Fix the vulnerability"},
+			ContentBase64: base64.StdEncoding.EncodeToString([]byte("fixed")),
+		}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	t.Run("Publish", func(t *testing.T) {
+		session.MakeRequest(t, NewRequestWithValues(t, "POST", link+"/publish", map[string]string{}), http.StatusSeeOther)
+		advisory = unittest.AssertExistsAndLoadBean(t, &advisory_model.Advisory{ID: advisory.ID})
+		assert.False(t, advisory.IsDraft())
+		assert.NotZero(t, advisory.PublishedUnix)
+		assert.Zero(t, advisory.ForkRepoID)
+		require.NotEmpty(t, advisory.FixCommitID)
+		unittest.AssertExistsAndLoadBean(t, &issues_model.PullRequest{BaseRepoID: 1, HasMerged: true, MergedCommitID: advisory.FixCommitID})
+		unittest.AssertNotExistsBean(t, &repo_model.Repository{ID: fork.ID})
+
+		resp := MakeRequest(t, NewRequest(t, "GET", apiLink).AddTokenAuth(readerToken), http.StatusOK)
+		var apiAdvisory api.SecurityAdvisory
+		DecodeJSON(t, resp, &apiAdvisory)
+		assert.Equal(t, "published", apiAdvisory.State)
+		assert.InDelta(t, 9.8, apiAdvisory.CVSSScore, 0.01)
+		assert.Equal(t, advisory.FixCommitID, apiAdvisory.FixCommitID)
+
+		resp = MakeRequest(t, NewRequest(t, "GET", apiLink+"/osv"), http.StatusOK)
+		var osv api.OSVVulnerability
+		DecodeJSON(t, resp, &osv)
+		assert.Equal(t, advisory.Identifier, osv.ID)
+		assert.Equal(t, []string{"CVE-2026-12345"}, osv.Aliases)
+		require.Len(t, osv.Affected, 2)
+		assert.Equal(t, "example.com/repo1", osv.Affected[0].Package.Name)
+		assert.Equal(t, "GIT", osv.Affected[1].Ranges[0].Type)
+
+		MakeRequest(t, NewRequest(t, "GET", link), http.StatusOK)
+
+		// the published advisories can't be deleted
+		session.MakeRequest(t, NewRequestWithValues(t, "POST", link+"/delete", map[string]string{}), http.StatusSeeOther)
+		unittest.AssertExistsAndLoadBean(t, &advisory_model.Advisory{ID: advisory.ID})
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	asymkey_model "forgejo.org/models/asymkey"
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/unittest"
+	"forgejo.org/modules/setting"
+	ssh_module "forgejo.org/modules/ssh"
+	api "forgejo.org/modules/structs"
+	"forgejo.org/modules/test"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestSSHCertificate(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.SSH.CAKeyPath, filepath.Join(t.TempDir(), "ca"))()
+	defer test.MockVariableValue(&setting.SSH.TrustedUserCAKeys, nil)()
+	defer test.MockVariableValue(&setting.SSH.TrustedUserCAKeysParsed, nil)()
+	defer test.MockVariableValue(&setting.SSH.CAEnabled, true)()
+	require.NoError(t, ssh_module.InitCA())
+
+	userPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pub, err := gossh.NewPublicKey(userPub)
+	require.NoError(t, err)
+	authorizedKey := string(gossh.MarshalAuthorizedKey(pub))
+
+	t.Run("Authority", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		resp := MakeRequest(t, NewRequest(t, "GET", "/api/v1/settings/ssh_ca"), http.StatusOK)
+		var ca api.SSHCertificateAuthority
+		DecodeJSON(t, resp, &ca)
+		assert.Equal(t, gossh.FingerprintSHA256(ssh_module.CAPublicKey()), ca.Fingerprint)
+		assert.EqualValues(t, setting.SSH.CACertificateValidity.Seconds(), ca.DefaultValiditySeconds)
+
+		defer test.MockVariableValue(&setting.SSH.CAEnabled, false)()
+		MakeRequest(t, NewRequest(t, "GET", "/api/v1/settings/ssh_ca"), http.StatusNotFound)
+	})
+
+	t.Run("API", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteUser)
+		req := NewRequestWithJSON(t, "POST", "/api/v1/user/ssh_certificates", &api.CreateSSHCertificateOption{
+			Key:             authorizedKey,
+			ValiditySeconds: 3600,
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+		var apiCert api.SSHCertificate
+		DecodeJSON(t, resp, &apiCert)
+		assert.Equal(t, []string{"user2"}, apiCert.Principals)
+		assert.Equal(t, "forgejo-user2", apiCert.KeyID)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), apiCert.ValidBefore, time.Minute)
+
+		parsed, _, _, _, err := gossh.ParseAuthorizedKey([]byte(apiCert.Certificate))
+		require.NoError(t, err)
+		cert, ok := parsed.(*gossh.Certificate)
+		require.True(t, ok)
+		assert.Equal(t, apiCert.Serial, strconv.FormatUint(cert.Serial, 10))
+		assert.Equal(t, pub.Marshal(), cert.Key.Marshal())
+		assert.Equal(t, ssh_module.CAPublicKey().Marshal(), cert.SignatureKey.Marshal())
+
+		// The user name is registered as principal so the certificate is accepted.
+		unittest.AssertExistsAndLoadBean(t, &asymkey_model.PublicKey{OwnerID: 2, Content: "user2", Type: asymkey_model.KeyTypePrincipal})
+
+		t.Run("Validity too long", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithJSON(t, "POST", "/api/v1/user/ssh_certificates", &api.CreateSSHCertificateOption{
+				Key:             authorizedKey,
+				ValiditySeconds: int64((setting.SSH.CAMaxCertificateValidity + time.Hour).Seconds()),
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+		})
+
+		t.Run("Invalid key", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequestWithJSON(t, "POST", "/api/v1/user/ssh_certificates", &api.CreateSSHCertificateOption{
+				Key: "not a key",
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusUnprocessableEntity)
+		})
+
+		t.Run("Disabled", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+			defer test.MockVariableValue(&setting.SSH.CAEnabled, false)()
+
+			req := NewRequestWithJSON(t, "POST", "/api/v1/user/ssh_certificates", &api.CreateSSHCertificateOption{
+				Key: authorizedKey,
+			}).AddTokenAuth(token)
+			MakeRequest(t, req, http.StatusNotFound)
+		})
+	})
+
+	t.Run("Web", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		session := loginUser(t, "user4")
+		resp := session.MakeRequest(t, NewRequest(t, "GET", "/user/settings/keys"), http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "/user/settings/keys/ssh_certificate")
+
+		req := NewRequestWithValues(t, "POST", "/user/settings/keys/ssh_certificate", map[string]string{
+			"content":  authorizedKey,
+			"validity": "2",
+		})
+		resp = session.MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), gossh.CertAlgoED25519v01)
+		unittest.AssertExistsAndLoadBean(t, &asymkey_model.PublicKey{OwnerID: 4, Content: "user4", Type: asymkey_model.KeyTypePrincipal})
+	})
+}
@@ -164,3 +164,32 @@ func TestDashboardReviewWorkflows(t *testing.T) {
 		assert.Equal(t, 4, count)
 	})
 }
+
+func TestUserDashboardSavedFilters(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	session := loginUser(t, "user2")
+	req := NewRequestWithValues(t, "POST", "/issues/saved_filters", map[string]string{
+		"name":    "bugs",
+		"query":   "label:bug",
+		"type":    "assigned",
+		"is_pull": "false",
+	})
+	resp := session.MakeRequest(t, req, http.StatusSeeOther)
+	filter := unittest.AssertExistsAndLoadBean(t, &issues.SavedFilter{UserID: 2, Name: "bugs"})
+	assert.Equal(t, "assigned", filter.ViewType)
+	assert.Equal(t, filter.Link(), resp.Header().Get("Location"))
+
+	page := NewHTMLParser(t, session.MakeRequest(t, NewRequest(t, "GET", "/issues"), http.StatusOK).Body)
+	page.AssertElement(t, ".saved-filters .saved-filter", true)
+	page = NewHTMLParser(t, session.MakeRequest(t, NewRequest(t, "GET", "/pulls"), http.StatusOK).Body)
+	page.AssertElement(t, ".saved-filters .saved-filter", false)
+
+	// another user cannot delete the filter
+	req = NewRequest(t, "POST", "/issues/saved_filters/"+strconv.FormatInt(filter.ID, 10)+"/delete")
+	loginUser(t, "user5").MakeRequest(t, req, http.StatusNotFound)
+
+	req = NewRequest(t, "POST", "/issues/saved_filters/"+strconv.FormatInt(filter.ID, 10)+"/delete")
+	session.MakeRequest(t, req, http.StatusSeeOther)
+	unittest.AssertNotExistsBean(t, &issues.SavedFilter{ID: filter.ID})
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 The Forgejo Authors. All rights reserved.
+// SPDX-License-Identifier: GPL-3.0-or-later
+
+package integration
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	auth_model "forgejo.org/models/auth"
+	"forgejo.org/models/unittest"
+	user_model "forgejo.org/models/user"
+	"forgejo.org/modules/setting"
+	"forgejo.org/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registeredSession(t *testing.T, sess *TestSession) *auth_model.UserSession {
+	t.Helper()
+
+	// Any authenticated request registers the session.
+	sess.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+
+	cookie := sess.GetCookie(setting.SessionConfig.CookieName)
+	require.NotNil(t, cookie)
+	return unittest.AssertExistsAndLoadBean(t, &auth_model.UserSession{KeyHash: auth_model.HashSessionKey(cookie.Value)})
+}
+
+func TestUserSessions(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{Name: "user2"})
+
+	t.Run("Revoke session", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		laptop := loginUserWithPasswordRemember(t, user.Name, userPassword, true)
+		phone := loginUser(t, user.Name)
+
+		laptopSession := registeredSession(t, laptop)
+		assert.Equal(t, user.ID, laptopSession.UID)
+		assert.Equal(t, auth_model.LoginMethodPassword, laptopSession.LoginMethod)
+		lookupKey, _, _ := strings.Cut(GetLTACookieValue(t, laptop), ":")
+		assert.Equal(t, lookupKey, laptopSession.AuthTokenKey)
+		phoneSession := registeredSession(t, phone)
+
+		resp := phone.MakeRequest(t, NewRequest(t, "GET", "/user/settings/sessions"), http.StatusOK)
+		htmlDoc := NewHTMLParser(t, resp.Body)
+		htmlDoc.AssertElement(t, `.delete-button[data-id="`+strconv.FormatInt(laptopSession.ID, 10)+`"]`, true)
+		// The current session can't be revoked from the list.
+		htmlDoc.AssertElement(t, `.delete-button[data-id="`+strconv.FormatInt(phoneSession.ID, 10)+`"]`, false)
+
+		req := NewRequestWithValues(t, "POST", "/user/settings/sessions/revoke", map[string]string{
+			"id": strconv.FormatInt(laptopSession.ID, 10),
+		})
+		phone.MakeRequest(t, req, http.StatusOK)
+
+		assert.True(t, unittest.AssertExistsAndLoadBean(t, &auth_model.UserSession{ID: laptopSession.ID}).IsRevoked())
+		unittest.AssertNotExistsBean(t, &auth_model.AuthorizationToken{LookupKey: lookupKey})
+
+		// The revoked session is signed out, its remember-me cookie doesn't sign it in again.
+		laptop.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusSeeOther)
+		phone.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+	})
+
+	t.Run("Revoke session of another user", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		victim := loginUser(t, user.Name)
+		victimSession := registeredSession(t, victim)
+
+		attacker := loginUser(t, "user4")
+		req := NewRequestWithValues(t, "POST", "/user/settings/sessions/revoke", map[string]string{
+			"id": strconv.FormatInt(victimSession.ID, 10),
+		})
+		attacker.MakeRequest(t, req, http.StatusNotFound)
+
+		assert.False(t, unittest.AssertExistsAndLoadBean(t, &auth_model.UserSession{ID: victimSession.ID}).IsRevoked())
+		victim.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+	})
+
+	t.Run("Revoke other sessions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		first := loginUser(t, user.Name)
+		second := loginUser(t, user.Name)
+		registeredSession(t, first)
+		registeredSession(t, second)
+
+		req := NewRequestWithValues(t, "POST", "/user/settings/sessions/revoke_others", nil)
+		second.MakeRequest(t, req, http.StatusSeeOther)
+
+		first.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusSeeOther)
+		second.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusOK)
+	})
+
+	t.Run("Sign out", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		sess := loginUser(t, user.Name)
+		s := registeredSession(t, sess)
+
+		sess.MakeRequest(t, NewRequestWithValues(t, "POST", "/user/logout", nil), http.StatusOK)
+
+		assert.True(t, unittest.AssertExistsAndLoadBean(t, &auth_model.UserSession{ID: s.ID}).IsRevoked())
+	})
+
+	t.Run("Admin signs user out everywhere", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		sess := loginUserWithPasswordRemember(t, user.Name, userPassword, true)
+		registeredSession(t, sess)
+
+		admin := loginUser(t, "user1")
+		req := NewRequestWithValues(t, "POST", "/admin/users/"+strconv.FormatInt(user.ID, 10)+"/sessions/revoke", nil)
+		admin.MakeRequest(t, req, http.StatusOK)
+
+		unittest.AssertNotExistsBean(t, &auth_model.UserSession{UID: user.ID}, unittest.Cond("revoked_unix = 0"))
+		unittest.AssertNotExistsBean(t, &auth_model.AuthorizationToken{UID: user.ID})
+		unittest.AssertNotExistsBean(t, &auth_model.OAuth2Grant{UserID: user.ID})
+		sess.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusSeeOther)
+	})
+
+	t.Run("Admin API", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		sess := loginUser(t, user.Name)
+		registeredSession(t, sess)
+
+		adminToken := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+		req := NewRequest(t, "DELETE", "/api/v1/admin/users/"+user.Name+"/sessions").AddTokenAuth(adminToken)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		unittest.AssertNotExistsBean(t, &auth_model.UserSession{UID: user.ID}, unittest.Cond("revoked_unix = 0"))
+		sess.MakeRequest(t, NewRequest(t, "GET", "/user/settings"), http.StatusSeeOther)
+
+		// Non-admins can't sign others out.
+		token := getUserToken(t, "user4", auth_model.AccessTokenScopeWriteAdmin)
+		req = NewRequest(t, "DELETE", "/api/v1/admin/users/"+user.Name+"/sessions").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+}
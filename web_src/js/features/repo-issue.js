@@ -789,6 +789,49 @@ export function initIssueTemplateCommentEditors($commentForm) {
   }
 }
 
+// The fields of an issue form with a "show_if" condition are only shown when the answer to an earlier field
+// is one of the expected values. The inputs of hidden fields are disabled, they are neither validated nor submitted.
+export function initRepoIssueTemplateConditions() {
+  const conditionalFields = document.querySelectorAll('.comment.form [data-show-if]');
+  if (!conditionalFields.length) return;
+  const form = conditionalFields[0].closest('form');
+
+  // the answers are the labels of the chosen options, or the value of an input or a textarea
+  const answersOf = (id) => {
+    const checkboxes = form.querySelectorAll(`input[type="checkbox"][name^="form-field-${CSS.escape(id)}-"]`);
+    if (checkboxes.length) {
+      return Array.from(checkboxes).filter((el) => el.checked).map((el) => el.getAttribute('data-label'));
+    }
+    const input = form.querySelector(`[name="form-field-${CSS.escape(id)}"]`);
+    if (!input) return [];
+    const dropdown = input.closest('.ui.dropdown');
+    if (dropdown) {
+      return input.value.split(',').filter(Boolean).map((index) => {
+        return dropdown.querySelector(`.item[data-value="${CSS.escape(index)}"]`)?.getAttribute('data-label');
+      });
+    }
+    return input.value.trim() ? [input.value.trim()] : [];
+  };
+
+  const update = () => {
+    // the conditions only reference earlier fields, so a single pass in the document order handles chained conditions
+    const hiddenIDs = new Set();
+    for (const field of conditionalFields) {
+      const dependency = field.getAttribute('data-show-if');
+      const values = JSON.parse(field.getAttribute('data-show-if-values')) ?? [];
+      const answers = hiddenIDs.has(dependency) ? [] : answersOf(dependency);
+      const shown = answers.length > 0 && (!values.length || answers.some((answer) => values.includes(answer)));
+      toggleElem(field, shown);
+      if (!shown) hiddenIDs.add(field.getAttribute('data-field-id'));
+      for (const el of field.querySelectorAll('input, textarea')) {
+        el.disabled = !shown;
+      }
+    }
+  };
+  $(form).on('change input', update);
+  update();
+}
+
 // This function used to show and hide archived label on issue/pr
 //  page in the sidebar where we select the labels
 //  If we have any archived label tagged to issue and pr. We will show that
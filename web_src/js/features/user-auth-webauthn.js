@@ -28,7 +28,7 @@ export async function initUserAuthWebAuthn() {
     const credential = await navigator.credentials.get({
       publicKey: options.publicKey,
     });
-    await verifyAssertion(credential);
+    await verifyAssertion(credential, `${appSubUrl}/user/webauthn/assertion`);
   } catch (err) {
     if (!options.publicKey.extensions?.appid) {
       webAuthnError('general', err.message);
@@ -39,14 +39,43 @@ export async function initUserAuthWebAuthn() {
       const credential = await navigator.credentials.get({
         publicKey: options.publicKey,
       });
-      await verifyAssertion(credential);
+      await verifyAssertion(credential, `${appSubUrl}/user/webauthn/assertion`);
     } catch (err) {
       webAuthnError('general', err.message);
     }
   }
 }
 
-async function verifyAssertion(assertedCredential) {
+export function initUserAuthPasskey() {
+  const elSignIn = document.getElementById('signin-passkey');
+  if (!elSignIn) {
+    return;
+  }
+  elSignIn.addEventListener('click', async (e) => {
+    e.preventDefault();
+    if (!detectWebAuthnSupport()) {
+      return;
+    }
+
+    const res = await GET(`${appSubUrl}/user/passkey/assertion`);
+    if (res.status !== 200) {
+      webAuthnError('unknown');
+      return;
+    }
+    const options = await res.json();
+    options.publicKey.challenge = decodeURLEncodedBase64(options.publicKey.challenge);
+    try {
+      const credential = await navigator.credentials.get({
+        publicKey: options.publicKey,
+      });
+      await verifyAssertion(credential, `${appSubUrl}/user/passkey/assertion`);
+    } catch (err) {
+      webAuthnError('general', err.message);
+    }
+  });
+}
+
+async function verifyAssertion(assertedCredential, url) {
   // Move data into Arrays in case it is super long
   const authData = new Uint8Array(assertedCredential.response.authenticatorData);
   const clientDataJSON = new Uint8Array(assertedCredential.response.clientDataJSON);
@@ -54,7 +83,7 @@ async function verifyAssertion(assertedCredential) {
   const sig = new Uint8Array(assertedCredential.response.signature);
   const userHandle = new Uint8Array(assertedCredential.response.userHandle);
 
-  const res = await POST(`${appSubUrl}/user/webauthn/assertion`, {
+  const res = await POST(url, {
     data: {
       id: assertedCredential.id,
       rawId: encodeURLEncodedBase64(rawId),
@@ -80,12 +109,12 @@ async function verifyAssertion(assertedCredential) {
   window.location.href = reply?.redirect ?? `${appSubUrl}/`;
 }
 
-async function webauthnRegistered(newCredential) {
+async function webauthnRegistered(newCredential, baseUrl) {
   const attestationObject = new Uint8Array(newCredential.response.attestationObject);
   const clientDataJSON = new Uint8Array(newCredential.response.clientDataJSON);
   const rawId = new Uint8Array(newCredential.rawId);
 
-  const res = await POST(`${appSubUrl}/user/settings/security/webauthn/register`, {
+  const res = await POST(`${baseUrl}/register`, {
     data: {
       id: newCredential.id,
       rawId: encodeURLEncodedBase64(rawId),
@@ -150,17 +179,30 @@ export function initUserAuthWebAuthnRegister() {
   }
   elRegister.addEventListener('click', async (e) => {
     e.preventDefault();
-    await webAuthnRegisterRequest();
+    await webAuthnRegisterRequest(document.getElementById('nickname'), `${appSubUrl}/user/settings/security/webauthn`);
   });
 }
 
-async function webAuthnRegisterRequest() {
-  const elNickname = document.getElementById('nickname');
+export function initUserAuthPasskeyRegister() {
+  const elRegister = document.getElementById('register-passkey');
+  if (!elRegister) {
+    return;
+  }
+  if (!detectWebAuthnSupport()) {
+    elRegister.disabled = true;
+    return;
+  }
+  elRegister.addEventListener('click', async (e) => {
+    e.preventDefault();
+    await webAuthnRegisterRequest(document.getElementById('passkey-nickname'), `${appSubUrl}/user/settings/security/passkey`);
+  });
+}
 
+async function webAuthnRegisterRequest(elNickname, baseUrl) {
   const formData = new FormData();
   formData.append('name', elNickname.value);
 
-  const res = await POST(`${appSubUrl}/user/settings/security/webauthn/request_register`, {
+  const res = await POST(`${baseUrl}/request_register`, {
     data: formData,
   });
 
@@ -187,7 +229,7 @@ async function webAuthnRegisterRequest() {
     const credential = await navigator.credentials.create({
       publicKey: options.publicKey,
     });
-    await webauthnRegistered(credential);
+    await webauthnRegistered(credential, baseUrl);
   } catch (err) {
     webAuthnError('unknown', err);
   }
@@ -32,6 +32,7 @@ import {
   initRepoIssueWipTitle,
   initRepoPullRequestAllowMaintainerEdit,
   initRepoPullRequestReview, initRepoIssueSidebarList, initArchivedLabelHandler,
+  initRepoIssueTemplateConditions,
 } from './features/repo-issue.js';
 import {initRepoEllipsisButton, initCommitStatuses, initCommitNotes} from './features/repo-commit.js';
 import {
@@ -61,7 +62,7 @@ import {
 } from './features/repo-settings.js';
 import {initRepoDiffView} from './features/repo-diff.js';
 import {initOrgTeamSearchRepoBox} from './features/org-team.js';
-import {initUserAuthWebAuthn, initUserAuthWebAuthnRegister} from './features/user-auth-webauthn.js';
+import {initUserAuthPasskey, initUserAuthPasskeyRegister, initUserAuthWebAuthn, initUserAuthWebAuthnRegister} from './features/user-auth-webauthn.js';
 import {initRepoRelease, initRepoReleaseNew} from './features/repo-release.js';
 import {initRepoEditor} from './features/repo-editor.js';
 import {initCompSearchUserBox} from './features/comp/SearchUserBox.js';
@@ -162,6 +163,7 @@ onDomReady(() => {
   initRepoIssueReferenceRepositorySearch();
   initRepoIssueTimeTracking();
   initRepoIssueWipTitle();
+  initRepoIssueTemplateConditions();
   initRepoMigration();
   initRepoMigrationStatusChecker();
   initRepoProject();
@@ -190,6 +192,8 @@ onDomReady(() => {
   initUserAuthOauth2();
   initUserAuthWebAuthn();
   initUserAuthWebAuthnRegister();
+  initUserAuthPasskey();
+  initUserAuthPasskeyRegister();
   initUserAuth();
   initRepoDiffView();
   initScopedAccessTokenCategories();